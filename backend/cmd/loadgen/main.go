@@ -0,0 +1,315 @@
+// Command loadgen drives a running API with synthetic traffic: post creates,
+// list reads and SSE connections at configurable rates, reporting latency
+// percentiles per operation. It signs in as the demo users the server's seed
+// command creates.
+//
+//	go run ./cmd/loadgen -target http://localhost:8080 -users 10 -create-rps 5 -read-rps 50 -sse 100 -duration 2m
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/http/cookiejar"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/seed"
+	"github.com/scheduler/backend/internal/tenant"
+)
+
+// config is the load to generate
+type config struct {
+	target       string
+	tenant       string
+	users        int
+	password     string
+	duration     time.Duration
+	createRPS    float64
+	readRPS      float64
+	sse          int
+	publishDelay time.Duration
+	timeout      time.Duration
+}
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.target, "target", "http://localhost:8080", "base URL of the API")
+	flag.StringVar(&cfg.tenant, "tenant", "", "tenant slug sent in the X-Tenant header")
+	flag.IntVar(&cfg.users, "users", seed.DefaultOptions.Users, "seeded demo users to sign in as (demo1@example.com, ...)")
+	flag.StringVar(&cfg.password, "password", seed.DefaultPassword, "password of the demo users")
+	flag.DurationVar(&cfg.duration, "duration", time.Minute, "how long to generate load")
+	flag.Float64Var(&cfg.createRPS, "create-rps", 2, "post creates per second")
+	flag.Float64Var(&cfg.readRPS, "read-rps", 10, "upcoming/history reads per second")
+	flag.IntVar(&cfg.sse, "sse", 10, "SSE connections held open")
+	flag.DurationVar(&cfg.publishDelay, "publish-delay", 0, "schedule created posts this far ahead and measure how long until the SSE publish event arrives; 0 schedules them days ahead so they don't publish")
+	flag.DurationVar(&cfg.timeout, "timeout", 10*time.Second, "per-request timeout")
+	flag.Parse()
+	cfg.target = strings.TrimRight(cfg.target, "/")
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	sessions, err := signIn(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to sign in: %v (run `server seed -users %d` against the target first)", err, cfg.users)
+	}
+	log.Printf("🔑 Signed in as %d demo users", len(sessions))
+
+	g := &generator{cfg: cfg, sessions: sessions, stats: newRecorder()}
+	start := time.Now()
+	g.run(ctx)
+	elapsed := time.Since(start)
+
+	fmt.Printf("\nLoad against %s for %v: %.1f creates/s, %.1f reads/s, %d SSE connections\n\n",
+		cfg.target, elapsed.Round(time.Second), cfg.createRPS, cfg.readRPS, cfg.sse)
+	g.stats.report(os.Stdout, elapsed)
+}
+
+// session is a signed-in demo user
+type session struct {
+	email  string
+	client *http.Client
+}
+
+// signIn logs in each demo user, keeping their auth cookies
+func signIn(ctx context.Context, cfg config) ([]*session, error) {
+	sessions := make([]*session, 0, cfg.users)
+	for i := 1; i <= cfg.users; i++ {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, err
+		}
+		s := &session{
+			email:  fmt.Sprintf("demo%d@example.com", i),
+			client: &http.Client{Jar: jar, Timeout: cfg.timeout},
+		}
+		status, err := s.do(ctx, cfg, http.MethodPost, "/api/auth/login", models.LoginRequest{Email: s.email, Password: cfg.password}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.email, err)
+		}
+		if status != http.StatusOK {
+			return nil, fmt.Errorf("%s: login returned %d", s.email, status)
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, nil
+}
+
+// do sends a JSON request, decoding a successful response into out if given
+func (s *session) do(ctx context.Context, cfg config, method, path string, body, out interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, cfg.target+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if cfg.tenant != "" {
+		req.Header.Set(tenant.Header, cfg.tenant)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if out != nil && resp.StatusCode < 300 {
+		return resp.StatusCode, json.NewDecoder(resp.Body).Decode(out)
+	}
+	_, err = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, err
+}
+
+// generator runs the configured load and records its outcome
+type generator struct {
+	cfg      config
+	sessions []*session
+	stats    *recorder
+
+	// scheduled maps posts created with a publish delay to when they were due,
+	// so SSE streams can time their publish events
+	scheduled sync.Map
+	created   atomic.Int64
+}
+
+// run holds the SSE connections open and fires creates and reads at their
+// rates until the duration passes or ctx is canceled
+func (g *generator) run(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, g.cfg.duration)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < g.cfg.sse; i++ {
+		wg.Add(1)
+		go func(s *session) {
+			defer wg.Done()
+			g.stream(ctx, s)
+		}(g.sessions[i%len(g.sessions)])
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		g.every(ctx, g.cfg.createRPS, g.create)
+	}()
+	go func() {
+		defer wg.Done()
+		g.every(ctx, g.cfg.readRPS, g.read)
+	}()
+
+	log.Printf("🚀 Generating load for %v", g.cfg.duration)
+	wg.Wait()
+}
+
+// every calls op rps times a second, each call in its own goroutine so slow
+// responses don't lower the offered load
+func (g *generator) every(ctx context.Context, rps float64, op func(context.Context, *session)) {
+	if rps <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rps))
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s := g.sessions[rand.Intn(len(g.sessions))]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				op(ctx, s)
+			}()
+		}
+	}
+}
+
+// create schedules a post
+func (g *generator) create(ctx context.Context, s *session) {
+	channels := models.ValidChannels()
+	scheduledAt := time.Now().Add(g.cfg.publishDelay)
+	if g.cfg.publishDelay <= 0 {
+		// Far enough out that the worker never publishes it during the run
+		scheduledAt = time.Now().Add(72*time.Hour + time.Duration(rand.Intn(24*60))*time.Minute)
+	}
+	req := models.CreatePostRequest{
+		Content:     fmt.Sprintf("Load test post %d (%s)", g.created.Add(1), uuid.NewString()[:8]),
+		Channel:     string(channels[rand.Intn(len(channels))]),
+		ScheduledAt: scheduledAt.UTC().Format(time.RFC3339),
+	}
+
+	var resp models.CreatePostResponse
+	start := time.Now()
+	status, err := s.do(ctx, g.cfg, http.MethodPost, "/api/posts", req, &resp)
+	g.observe(ctx, "create", start, status, http.StatusCreated, err)
+	if err == nil && status == http.StatusCreated && g.cfg.publishDelay > 0 && resp.Post != nil {
+		g.scheduled.Store(resp.Post.ID.String(), resp.Post.ScheduledAt)
+	}
+}
+
+// read fetches the upcoming or history list, as the dashboard does
+func (g *generator) read(ctx context.Context, s *session) {
+	op, path := "read_upcoming", "/api/posts/upcoming"
+	if rand.Intn(2) == 0 {
+		op, path = "read_history", "/api/posts/history"
+	}
+	start := time.Now()
+	status, err := s.do(ctx, g.cfg, http.MethodGet, path, nil, nil)
+	g.observe(ctx, op, start, status, http.StatusOK, err)
+}
+
+// observe records an operation's latency, or why it failed
+func (g *generator) observe(ctx context.Context, op string, start time.Time, status, want int, err error) {
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// Cut off by the end of the run; not a failure of the target
+	case errors.Is(err, context.DeadlineExceeded) || (err != nil && strings.Contains(err.Error(), "Client.Timeout")):
+		g.stats.fail(op, "timeout")
+	case err != nil:
+		g.stats.fail(op, "error")
+	case status != want:
+		g.stats.fail(op, fmt.Sprint(status))
+	default:
+		g.stats.record(op, time.Since(start))
+	}
+}
+
+// stream holds an SSE connection open, timing how long it takes to connect
+// and, for posts created with a publish delay, how late their publish event
+// arrives
+func (g *generator) stream(ctx context.Context, s *session) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.cfg.target+"/api/posts/stream", nil)
+	if err != nil {
+		g.stats.fail("sse_connect", "error")
+		return
+	}
+	if g.cfg.tenant != "" {
+		req.Header.Set(tenant.Header, g.cfg.tenant)
+	}
+
+	// The stream stays open, so it can't share the session's timeout
+	client := &http.Client{Jar: s.client.Jar}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctx.Err() == nil {
+			g.stats.fail("sse_connect", "error")
+		}
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		g.stats.fail("sse_connect", fmt.Sprint(resp.StatusCode))
+		return
+	}
+
+	var event string
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+			if event == "connected" {
+				g.stats.record("sse_connect", time.Since(start))
+			}
+		case strings.HasPrefix(line, "data: ") && event == "publish":
+			var data struct {
+				PostID string `json:"post_id"`
+			}
+			if json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &data) != nil {
+				continue
+			}
+			// Only the first stream to see a post's event times it
+			if due, ok := g.scheduled.LoadAndDelete(data.PostID); ok {
+				g.stats.record("publish_event_lag", time.Since(due.(time.Time)))
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// recorder collects the latency and outcome of every operation by name
+type recorder struct {
+	mu      sync.Mutex
+	samples map[string][]time.Duration
+	failed  map[string]map[string]int // Failure counts by status code or error kind
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		samples: make(map[string][]time.Duration),
+		failed:  make(map[string]map[string]int),
+	}
+}
+
+// record adds a successful operation's latency
+func (r *recorder) record(op string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.samples[op] = append(r.samples[op], d)
+}
+
+// fail counts a failed operation under reason, e.g. "429" or "timeout"
+func (r *recorder) fail(op, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failed[op] == nil {
+		r.failed[op] = make(map[string]int)
+	}
+	r.failed[op][reason]++
+}
+
+// percentile returns the nearest-rank p-th percentile (0-100) of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// report writes a table of throughput and latency percentiles per operation,
+// followed by failures by reason
+func (r *recorder) report(w io.Writer, elapsed time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ops := make(map[string]bool)
+	for op := range r.samples {
+		ops[op] = true
+	}
+	for op := range r.failed {
+		ops[op] = true
+	}
+	names := make([]string, 0, len(ops))
+	for op := range ops {
+		names = append(names, op)
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "operation\tok\tfailed\trate/s\tp50\tp90\tp99\tmax\t")
+	for _, op := range names {
+		sorted := append([]time.Duration(nil), r.samples[op]...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		failed := 0
+		for _, n := range r.failed[op] {
+			failed += n
+		}
+		var max time.Duration
+		if len(sorted) > 0 {
+			max = sorted[len(sorted)-1]
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%v\t%v\t%v\t%v\t\n", op, len(sorted), failed,
+			float64(len(sorted))/elapsed.Seconds(),
+			round(percentile(sorted, 50)), round(percentile(sorted, 90)), round(percentile(sorted, 99)), round(max))
+	}
+	tw.Flush()
+
+	for _, op := range names {
+		for reason, n := range r.failed[op] {
+			fmt.Fprintf(w, "  %s failed %d× with %s\n", op, n, reason)
+		}
+	}
+}
+
+// round trims latencies to a readable precision
+func round(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(10 * time.Microsecond)
+}
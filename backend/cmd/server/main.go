@@ -11,14 +11,29 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	"github.com/google/uuid"
 	"github.com/scheduler/backend/internal/api"
+	"github.com/scheduler/backend/internal/audience"
 	"github.com/scheduler/backend/internal/auth"
+	"github.com/scheduler/backend/internal/backup"
 	"github.com/scheduler/backend/internal/cache"
+	"github.com/scheduler/backend/internal/clock"
 	"github.com/scheduler/backend/internal/config"
+	"github.com/scheduler/backend/internal/cron"
 	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/inbox"
+	"github.com/scheduler/backend/internal/mailer"
+	"github.com/scheduler/backend/internal/maintenance"
+	"github.com/scheduler/backend/internal/media"
+	"github.com/scheduler/backend/internal/metrics"
+	"github.com/scheduler/backend/internal/models"
 	"github.com/scheduler/backend/internal/notifier"
+	"github.com/scheduler/backend/internal/publisher"
+	"github.com/scheduler/backend/internal/redisclient"
 	"github.com/scheduler/backend/internal/scheduler"
+	"github.com/scheduler/backend/internal/seed"
+	"github.com/scheduler/backend/internal/tenant"
+	"github.com/scheduler/backend/internal/usage"
 )
 
 func main() {
@@ -43,25 +58,37 @@ func main() {
 	}()
 
 	// Connect to database
-	database, err := db.New(ctx, cfg.DatabaseURL)
+	database, err := db.New(ctx, cfg.DatabaseURL, db.PoolConfig{
+		MaxConns:               int32(cfg.DBMaxConns),
+		MinConns:               int32(cfg.DBMinConns),
+		MaxConnLifetime:        cfg.DBMaxConnLifetime,
+		MaxConnIdleTime:        cfg.DBMaxConnIdleTime,
+		HealthCheckPeriod:      cfg.DBHealthCheckPeriod,
+		QueryExecMode:          cfg.DBQueryExecMode,
+		StatementCacheCapacity: cfg.DBStatementCacheCapacity,
+	})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer database.Close()
 	log.Println("✅ Connected to PostgreSQL")
 
-	// Run migrations only in API server mode (not in worker mode)
+	// The migrate command runs before the server would migrate on its own
+	if args := flag.Args(); len(args) > 0 && args[0] == "migrate" {
+		runMigrate(ctx, database, args[1:])
+		return
+	}
+
+	// Run migrations only in API server mode (not in worker mode). Without
+	// the API server applying them, workers check they have been run too.
 	if !*workerMode {
-		if err := database.RunMigrations(ctx); err != nil {
-			log.Fatalf("Failed to run migrations: %v", err)
-		}
-		log.Println("✅ Database migrations complete")
+		migrateOnStart(ctx, database, cfg.MigrateOnStart)
+	} else if cfg.MigrateOnStart == "none" {
+		requirePreDeployMigrations(ctx, database)
 	}
 
 	// Connect to Redis
-	redisClient := redis.NewClient(&redis.Options{
-		Addr: cfg.RedisURL,
-	})
+	redisClient := redisclient.New(cfg.RedisURL)
 	if err := redisClient.Ping(ctx).Err(); err != nil {
 		log.Fatalf("Failed to connect to Redis: %v", err)
 	}
@@ -69,22 +96,122 @@ func main() {
 	log.Println("✅ Connected to Redis")
 
 	// Initialize services
-	jwtService := auth.NewJWTService(cfg.JWTSecret, cfg.AccessTokenTTL, cfg.RefreshTokenTTL)
+	jwtService := auth.NewJWTService(cfg.JWTSecret, cfg.AccessTokenTTL, cfg.RefreshTokenTTL, cfg.RememberMeTTL)
 	blacklist := auth.NewBlacklist(redisClient)
-	queue := scheduler.NewQueue(redisClient)
+	domainPolicy := auth.NewDomainPolicy(cfg.EmailDomainAllowlist, cfg.EmailDomainDenylist, cfg.BlockDisposableEmails)
+	queue := scheduler.NewQueue(redisClient, clock.Real)
+
+	// Subcommands run once and exit
+	if args := flag.Args(); len(args) > 0 {
+		switch args[0] {
+		case "seed":
+			runSeed(ctx, database, queue, args[1:])
+		case "backup":
+			runBackup(ctx, database, args[1:])
+		case "restore":
+			runRestore(ctx, database, queue, args[1:])
+		default:
+			log.Fatalf("Unknown command %q", args[0])
+		}
+		return
+	}
 
 	if *workerMode {
 		// Run as worker
 		log.Println("🔧 Starting in WORKER mode")
 		postCache := cache.NewCache(redisClient)
+		postCache.EnableWarming(database)
 		postNotifier := notifier.NewNotifier(redisClient)
-		worker := scheduler.NewWorker(database, queue, postCache, postNotifier, cfg.WorkerInterval)
+		if cfg.NotifierSharedChannel {
+			postNotifier.EnableSharedChannel()
+		}
+		publishers := publisher.NewRegistry(database)
+		if cfg.PublishMode == "sandbox" {
+			log.Printf("🧪 Publishing in SANDBOX mode (failure rate %.0f%%, latency %v-%v)",
+				cfg.SandboxFailureRate*100, cfg.SandboxMinLatency, cfg.SandboxMaxLatency)
+			publishers = publisher.NewSandboxRegistry(database, publisher.SandboxConfig{
+				FailureRate: cfg.SandboxFailureRate,
+				MinLatency:  cfg.SandboxMinLatency,
+				MaxLatency:  cfg.SandboxMaxLatency,
+			})
+		}
+		inboxFetchers := inbox.NewRegistry()
+		if cfg.PublishMode == "sandbox" {
+			inboxFetchers = inbox.NewSandboxRegistry(cfg.SandboxReplyRate)
+		}
+		followerCounters := audience.NewRegistry()
+		if cfg.PublishMode == "sandbox" {
+			followerCounters = audience.NewSandboxRegistry()
+		}
+		dailyLimits := models.NewDailyLimits(cfg.ChannelDailyLimits)
+		heartbeats := scheduler.NewHeartbeatStore(redisClient)
+		lagMonitor := scheduler.NewLagMonitor(cfg.LagAlertThreshold, cfg.LagAlertWebhookURL)
+		breaker := scheduler.NewCircuitBreaker(cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCooldown)
+
+		// Expose worker metrics for Prometheus
+		metricsServer := serveMetrics(cfg.MetricsAddr)
+		defer metricsServer.Close()
+
+		jobQueue := scheduler.NewJobQueue(redisClient)
+		usageMeter := usage.NewMeter(redisClient)
+		worker := scheduler.NewWorker(database, queue, postCache, postNotifier, publishers, dailyLimits, heartbeats, lagMonitor, breaker, scheduler.NewBackoffStore(redisClient, clock.Real), scheduler.NewRetryBudget(redisClient, cfg.RetryBudgetPerMinute), jobQueue, maintenance.NewStore(redisClient), usageMeter, cfg.WorkerInterval, cfg.PublishTimeout, cfg.UndoWindow, clock.Real)
+		worker.RegisterJob(scheduler.JobEmailSend, scheduler.EmailJobHandler(mailer.New(cfg.SMTPAddr, cfg.SMTPUsername, cfg.SMTPPassword, cfg.EmailFrom)))
+		worker.RegisterJob(scheduler.JobWebhookSend, scheduler.WebhookJobHandler(nil))
+		if cfg.FFmpegPath != "" {
+			mediaStore, err := media.NewStore(cfg.MediaDir)
+			if err != nil {
+				log.Fatalf("Failed to initialize media store: %v", err)
+			}
+			worker.RegisterJob(scheduler.JobMediaProcess, scheduler.MediaJobHandler(database, mediaStore, &media.FFmpeg{Path: cfg.FFmpegPath}))
+		}
+
+		approvals := scheduler.NewApprovalMonitor(database, postNotifier, jobQueue, scheduler.ApprovalPolicy{
+			ReminderWindow:   cfg.ApprovalReminderWindow,
+			EscalationWindow: cfg.ApprovalEscalationWindow,
+			Escalation:       scheduler.ApprovalEscalation(cfg.ApprovalEscalation),
+		})
+
+		// Periodic maintenance jobs
+		cronRunner := cron.NewRunner(redisClient, worker.InstanceID())
+		cronJobs := []struct {
+			name, schedule string
+			fn             cron.Func
+		}{
+			{"recycle-posts", "@every 1m", worker.RecyclePosts},
+			{"reconcile-queue", "@every 15m", worker.ReconcileQueue},
+			{"reap-claims", "@every 1m", worker.ReapClaims},
+			{"approval-reminders", "@every 5m", approvals.Run},
+			{"ab-tests", "@every 5m", worker.RunABTests},
+			{"usage-rollup", "@every 15m", scheduler.NewUsageRollup(database, usageMeter).Run},
+			{"analytics-rollup", "@every 10m", scheduler.NewAnalyticsRollup(database).Run},
+			{"inbox-sync", "@every 5m", scheduler.NewInboxSync(database, inboxFetchers, postNotifier).Run},
+			{"keyword-monitors", "@every 5m", scheduler.NewKeywordMonitorSync(database, inboxFetchers, postNotifier).Run},
+			{"monthly-reports", "@every 1h", scheduler.NewMonthlyReports(database, postNotifier, jobQueue).Run},
+			{"follower-counts", "@every 1h", scheduler.NewFollowerPoll(database, followerCounters).Run},
+			{"retention", "@every 1h", scheduler.NewRetention(database, cfg.RetentionPolicy(), cfg.RetentionDryRun).Run},
+		}
+		for _, job := range cronJobs {
+			if err := cronRunner.Register(job.name, cfg.CronSchedule(job.name, job.schedule), job.fn); err != nil {
+				log.Fatalf("Failed to register cron job: %v", err)
+			}
+		}
+		go cronRunner.Run(ctx)
+
 		worker.Run(ctx)
 	} else {
 		// Run as API server
 		log.Println("🌐 Starting in API SERVER mode")
 
-		router := api.NewRouter(database, jwtService, blacklist, queue, redisClient, cfg.CORSOrigin, cfg.SecureCookies)
+		mediaStore, err := media.NewStore(cfg.MediaDir)
+		if err != nil {
+			log.Fatalf("Failed to initialize media store: %v", err)
+		}
+
+		router := api.NewRouter(database, jwtService, blacklist, domainPolicy, queue, mediaStore, redisClient, cfg, clock.Real)
+
+		// Expose request latency histograms for Prometheus
+		metricsServer := serveMetrics(cfg.MetricsAddr)
+		defer metricsServer.Close()
 
 		server := &http.Server{
 			Addr:         ":" + cfg.ServerPort,
@@ -117,3 +244,270 @@ func main() {
 
 	fmt.Println("Goodbye!")
 }
+
+// runSeed creates demo users and posts: server seed [-users N] [-posts N] [-password P] [-rand-seed N]
+func runSeed(ctx context.Context, database *db.DB, queue *scheduler.Queue, args []string) {
+	opts := seed.DefaultOptions
+	fs := flag.NewFlagSet("seed", flag.ExitOnError)
+	fs.IntVar(&opts.Users, "users", opts.Users, "demo users to create")
+	fs.IntVar(&opts.PostsPerUser, "posts", opts.PostsPerUser, "posts per demo user")
+	fs.StringVar(&opts.Password, "password", seed.DefaultPassword, "password of every demo user")
+	fs.Int64Var(&opts.RandSeed, "rand-seed", opts.RandSeed, "seed for the generated posts")
+	fs.Parse(args)
+
+	log.Printf("🌱 Seeding %d demo users with %d posts each", opts.Users, opts.PostsPerUser)
+	if err := seed.Run(ctx, database, queue, opts); err != nil {
+		log.Fatalf("Seeding failed: %v", err)
+	}
+	log.Printf("✅ Seeding complete; sign in as demo1@example.com with password %q", opts.Password)
+}
+
+// migrateOnStart applies the migrations MIGRATE_ON_START allows. Whatever it
+// is, the server doesn't start while pre-deploy migrations are pending, as
+// this code may need them.
+func migrateOnStart(ctx context.Context, database *db.DB, mode string) {
+	switch mode {
+	case "all":
+		if _, err := database.RunMigrations(ctx, db.MigrationPostDeploy); err != nil {
+			log.Fatalf("Failed to run migrations: %v", err)
+		}
+	case "pre-deploy":
+		if _, err := database.RunMigrations(ctx, db.MigrationPreDeploy); err != nil {
+			log.Fatalf("Failed to run migrations: %v", err)
+		}
+	}
+
+	requirePreDeployMigrations(ctx, database)
+	log.Println("✅ Database migrations complete")
+}
+
+// requirePreDeployMigrations exits if pre-deploy migrations are pending, as
+// this code may need them, and logs pending post-deploy migrations
+func requirePreDeployMigrations(ctx context.Context, database *db.DB) {
+	migrations, err := database.MigrationStatus(ctx)
+	if err != nil {
+		log.Fatalf("Failed to check migrations: %v", err)
+	}
+	pending, err := db.PlanMigrations(migrations, db.MigrationPreDeploy)
+	if err != nil {
+		log.Fatalf("Failed to check migrations: %v", err)
+	}
+	if len(pending) > 0 {
+		log.Fatalf("%d pre-deploy migrations are pending, starting with %s; run the migrate command first", len(pending), pending[0].Version)
+	}
+	for _, m := range migrations {
+		if !m.Applied {
+			log.Printf("⏳ Post-deploy migration %s is pending; run migrate -phase post-deploy once every instance runs this version", m.Version)
+		}
+	}
+}
+
+// runMigrate applies pending migrations of a phase, or with -status lists
+// them: server migrate [-phase pre-deploy|post-deploy] [-status]. Rolling
+// deployments run the pre-deploy phase before updating any instance and the
+// post-deploy phase after updating every one.
+func runMigrate(ctx context.Context, database *db.DB, args []string) {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	phase := fs.String("phase", string(db.MigrationPostDeploy), "pre-deploy, or post-deploy to apply every pending migration")
+	status := fs.Bool("status", false, "list pending migrations instead of applying them; exits with status 1 if any would run in -phase")
+	fs.Parse(args)
+
+	if *status {
+		migrations, err := database.MigrationStatus(ctx)
+		if err != nil {
+			log.Fatalf("Failed to check migrations: %v", err)
+		}
+		for _, m := range migrations {
+			if !m.Applied {
+				fmt.Printf("pending  %-11s  %s\n", m.Phase, m.Version)
+			}
+		}
+		plan, err := db.PlanMigrations(migrations, db.MigrationPhase(*phase))
+		if err != nil {
+			log.Fatalf("Migrations can't run: %v", err)
+		}
+		if len(plan) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	applied, err := database.RunMigrations(ctx, db.MigrationPhase(*phase))
+	if err != nil {
+		log.Fatalf("Migration failed after applying %d: %v", len(applied), err)
+	}
+	log.Printf("✅ Applied %d %s migrations", len(applied), *phase)
+}
+
+// runBackup writes an archive of a user's personal data or an organization's
+// data: server backup (-user EMAIL | -org ID) -out FILE [-tenant SLUG]
+func runBackup(ctx context.Context, database *db.DB, args []string) {
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+	email := fs.String("user", "", "email of the user whose personal data to back up")
+	orgID := fs.String("org", "", "ID of the organization to back up")
+	out := fs.String("out", "", "archive file to write")
+	tenantSlug := fs.String("tenant", models.DefaultTenantSlug, "tenant the user or organization belongs to")
+	fs.Parse(args)
+	if (*email == "") == (*orgID == "") || *out == "" {
+		log.Fatal("Usage: backup (-user EMAIL | -org ID) -out FILE [-tenant SLUG]")
+	}
+	ctx = tenantContext(ctx, database, *tenantSlug)
+
+	archive := &backup.Archive{Format: backup.FormatVersion, CreatedAt: time.Now().UTC()}
+	var err error
+	if *email != "" {
+		user, err := database.GetUserByEmail(ctx, *email)
+		if err != nil || user == nil {
+			log.Fatalf("User %s not found: %v", *email, err)
+		}
+		archive.Scope, archive.Source = backup.ScopeUser, user.Email
+		archive.Snapshot, err = database.SnapshotUser(ctx, user.ID)
+	} else {
+		id, err := uuid.Parse(*orgID)
+		if err != nil {
+			log.Fatalf("Invalid organization ID %q", *orgID)
+		}
+		archive.Scope = backup.ScopeOrganization
+		if archive.Snapshot, err = database.SnapshotOrganization(ctx, id); err == nil && archive.Snapshot != nil {
+			archive.Source, _ = archive.Snapshot.Organization["name"].(string)
+		}
+	}
+	if err != nil {
+		log.Fatalf("Backup failed: %v", err)
+	}
+	if archive.Snapshot == nil {
+		log.Fatal("Nothing to back up: not found")
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("Backup failed: %v", err)
+	}
+	if err := backup.Write(f, archive); err != nil {
+		f.Close()
+		log.Fatalf("Backup failed: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatalf("Backup failed: %v", err)
+	}
+	s := archive.Snapshot
+	log.Printf("✅ Backed up %s %s to %s: %d posts, %d media, %d folders, %d channel presets (schema %s)",
+		archive.Scope, archive.Source, *out, len(s.Posts), len(s.Media), len(s.MediaFolders), len(s.ChannelPresets), s.SchemaVersion)
+}
+
+// runRestore adds an archive's data to a user's account, or to a new
+// organization they own: server restore -in FILE -user EMAIL [-tenant SLUG]
+func runRestore(ctx context.Context, database *db.DB, queue *scheduler.Queue, args []string) {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	in := fs.String("in", "", "archive file to read")
+	email := fs.String("user", "", "email of the user to restore into, or to own the restored organization")
+	tenantSlug := fs.String("tenant", models.DefaultTenantSlug, "tenant to restore into")
+	fs.Parse(args)
+	if *in == "" || *email == "" {
+		log.Fatal("Usage: restore -in FILE -user EMAIL [-tenant SLUG]")
+	}
+	ctx = tenantContext(ctx, database, *tenantSlug)
+
+	f, err := os.Open(*in)
+	if err != nil {
+		log.Fatalf("Restore failed: %v", err)
+	}
+	archive, err := backup.Read(f)
+	f.Close()
+	if err != nil {
+		log.Fatalf("Restore failed: %v", err)
+	}
+	s := archive.Snapshot
+
+	// Rows are restored column for column, so the schemas must match
+	version, err := database.SchemaVersion(ctx)
+	if err != nil {
+		log.Fatalf("Restore failed: %v", err)
+	}
+	if version != s.SchemaVersion {
+		log.Fatalf("Archive was made at schema %s but this database is at %s; migrate both environments to the same version first", s.SchemaVersion, version)
+	}
+
+	user, err := database.GetUserByEmail(ctx, *email)
+	if err != nil || user == nil {
+		log.Fatalf("User %s not found: %v", *email, err)
+	}
+
+	// Authors, assignees and members are matched by email
+	target := backup.Target{TenantID: tenant.IDFromContext(ctx), UserID: user.ID, Users: make(map[string]uuid.UUID)}
+	for id, addr := range s.Users {
+		u, err := database.GetUserByEmail(ctx, addr)
+		if err != nil {
+			log.Fatalf("Restore failed: %v", err)
+		}
+		if u != nil {
+			target.Users[id] = u.ID
+		}
+	}
+
+	if archive.Scope == backup.ScopeOrganization {
+		org, err := database.CreateOrganization(ctx, archive.Source, user.ID)
+		if err != nil {
+			log.Fatalf("Restore failed: %v", err)
+		}
+		target.OrgID = &org.ID
+		for _, m := range s.Members {
+			member, err := database.GetUserByEmail(ctx, m.Email)
+			if err != nil {
+				log.Fatalf("Restore failed: %v", err)
+			}
+			if member == nil || member.ID == user.ID {
+				continue
+			}
+			if err := database.SetOrganizationMember(ctx, org.ID, member.ID, models.OrgRole(m.Role), m.CanOverrideWindows); err != nil {
+				log.Fatalf("Restore failed adding members to organization %s: %v", org.ID, err)
+			}
+		}
+	}
+
+	backup.Remap(s, target)
+	refs, err := database.RestoreSnapshot(ctx, s, user.ID, target.OrgID)
+	if err != nil {
+		if target.OrgID != nil {
+			log.Fatalf("Restore failed; organization %s was created without its data: %v", *target.OrgID, err)
+		}
+		log.Fatalf("Restore failed: %v", err)
+	}
+	if _, err := queue.EnqueueMissing(ctx, refs); err != nil {
+		log.Printf("⚠️ Failed to queue restored posts; the reconcile-queue job will pick them up: %v", err)
+	}
+
+	into := "account " + user.Email
+	if target.OrgID != nil {
+		into = "new organization " + target.OrgID.String()
+	}
+	log.Printf("✅ Restored %s %s into %s: %d posts (%d scheduled), %d media, %d folders, %d channel presets; %d of %d users matched by email",
+		archive.Scope, archive.Source, into, len(s.Posts), len(refs), len(s.Media), len(s.MediaFolders), len(s.ChannelPresets), len(target.Users), len(s.Users))
+}
+
+// tenantContext returns ctx scoped to the tenant with the given slug
+func tenantContext(ctx context.Context, database *db.DB, slug string) context.Context {
+	tenants, err := database.ListTenants(ctx)
+	if err != nil {
+		log.Fatalf("Failed to list tenants: %v", err)
+	}
+	for _, t := range tenants {
+		if t.Slug == slug {
+			return tenant.NewContext(ctx, t)
+		}
+	}
+	log.Fatalf("Tenant %q not found", slug)
+	return ctx
+}
+
+// serveMetrics serves Prometheus metrics on addr in the background
+func serveMetrics(addr string) *http.Server {
+	server := &http.Server{Addr: addr, Handler: metrics.Handler()}
+	go func() {
+		log.Printf("📈 Metrics listening on %s", addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("⚠️ Metrics server error: %v", err)
+		}
+	}()
+	return server
+}
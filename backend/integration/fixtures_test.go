@@ -0,0 +1,199 @@
+//go:build integration
+
+package integration
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// testPassword is the password of every fixture user
+const testPassword = "Integration-Passw0rd!"
+
+// newEmail returns an address no other test uses
+func newEmail() string {
+	return fmt.Sprintf("user-%s@example.com", uuid.NewString()[:8])
+}
+
+// newPostRequest is a text post on channel due at scheduledAt
+func newPostRequest(channel models.Channel, scheduledAt time.Time) models.CreatePostRequest {
+	return models.CreatePostRequest{
+		Content:     "Integration test post " + uuid.NewString()[:8],
+		Channel:     string(channel),
+		ScheduledAt: scheduledAt.Format(time.RFC3339),
+	}
+}
+
+// client is a signed-in browser session: it keeps the auth cookies the API sets
+type client struct {
+	t    *testing.T
+	base string
+	http *http.Client
+}
+
+// newClient creates a client with no session
+func (h *harness) newClient() *client {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		h.t.Fatalf("Failed to create cookie jar: %v", err)
+	}
+	return &client{
+		t:    h.t,
+		base: h.server.URL,
+		http: &http.Client{Jar: jar, Timeout: 10 * time.Second},
+	}
+}
+
+// do sends a JSON request and decodes the response into out if given,
+// returning the status code
+func (c *client) do(method, path string, body, out interface{}) int {
+	c.t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			c.t.Fatalf("Failed to encode %s %s body: %v", method, path, err)
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(method, c.base+path, reader)
+	if err != nil {
+		c.t.Fatalf("Failed to build %s %s: %v", method, path, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		c.t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode < 300 {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			c.t.Fatalf("Failed to decode %s %s response: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// register signs up a new user, leaving the client signed in as them
+func (c *client) register(email, password string) *models.UserResponse {
+	c.t.Helper()
+
+	var resp models.AuthResponse
+	if code := c.do(http.MethodPost, "/api/auth/register", models.RegisterRequest{Email: email, Password: password}, &resp); code != http.StatusCreated {
+		c.t.Fatalf("Register returned %d, want %d", code, http.StatusCreated)
+	}
+	return resp.User
+}
+
+// login signs the client in
+func (c *client) login(email, password string) *models.UserResponse {
+	c.t.Helper()
+
+	var resp models.AuthResponse
+	if code := c.do(http.MethodPost, "/api/auth/login", models.LoginRequest{Email: email, Password: password}, &resp); code != http.StatusOK {
+		c.t.Fatalf("Login returned %d, want %d", code, http.StatusOK)
+	}
+	return resp.User
+}
+
+// createPost schedules a post, failing the test unless it is accepted
+func (c *client) createPost(req models.CreatePostRequest) *models.Post {
+	c.t.Helper()
+
+	var post models.Post
+	if code := c.do(http.MethodPost, "/api/posts", req, &post); code != http.StatusCreated {
+		c.t.Fatalf("Create post returned %d, want %d", code, http.StatusCreated)
+	}
+	return &post
+}
+
+// sseEvent is one event read from the post stream
+type sseEvent struct {
+	Type string
+	Data string
+}
+
+// stream opens the SSE post stream, delivering its events until ctx is done
+func (c *client) stream(ctx context.Context) <-chan sseEvent {
+	c.t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.base+"/api/posts/stream", nil)
+	if err != nil {
+		c.t.Fatalf("Failed to build stream request: %v", err)
+	}
+	// The stream stays open, so it can't share the client's timeout
+	streamClient := &http.Client{Jar: c.http.Jar}
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		c.t.Fatalf("Failed to open stream: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		c.t.Fatalf("Stream returned %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	events := make(chan sseEvent, 16)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		var event sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				event.Type = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.Data = strings.TrimPrefix(line, "data: ")
+			case line == "" && event.Type != "":
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+				event = sseEvent{}
+			}
+		}
+	}()
+	return events
+}
+
+// waitForEvent returns the first event of the given type that match accepts,
+// failing the test if none arrives within timeout
+func waitForEvent(t *testing.T, events <-chan sseEvent, eventType string, timeout time.Duration, match func(sseEvent) bool) sseEvent {
+	t.Helper()
+
+	deadline := time.After(timeout)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("Stream closed before a %s event arrived", eventType)
+			}
+			if event.Type == eventType && (match == nil || match(event)) {
+				return event
+			}
+		case <-deadline:
+			t.Fatalf("No %s event within %v", eventType, timeout)
+		}
+	}
+}
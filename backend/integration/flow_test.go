@@ -0,0 +1,153 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// TestPublishFlow follows a post from signup to the SSE event announcing it was
+// published: register, login, create, worker publish, SSE receipt
+func TestPublishFlow(t *testing.T) {
+	h := newHarness(t)
+
+	email := newEmail()
+	registered := h.newClient().register(email, testPassword)
+
+	c := h.newClient()
+	user := c.login(email, testPassword)
+	if user.ID != registered.ID {
+		t.Fatalf("Logged in as %s, want %s", user.ID, registered.ID)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := c.stream(ctx)
+	waitForEvent(t, events, "connected", 5*time.Second, nil)
+
+	// Due now, so the worker picks it up on its next poll
+	post := c.createPost(newPostRequest(models.ChannelTwitter, h.clock.Now()))
+	if post.Status != models.PostStatusScheduled {
+		t.Fatalf("Created post status = %q, want %q", post.Status, models.PostStatusScheduled)
+	}
+
+	waitForEvent(t, events, "publish", 15*time.Second, func(e sseEvent) bool {
+		var data struct {
+			PostID string `json:"post_id"`
+		}
+		return json.Unmarshal([]byte(e.Data), &data) == nil && data.PostID == post.ID.String()
+	})
+
+	var published models.Post
+	if code := c.do(http.MethodGet, "/api/posts/"+post.ID.String(), nil, &published); code != http.StatusOK {
+		t.Fatalf("Get post returned %d, want %d", code, http.StatusOK)
+	}
+	if published.Status != models.PostStatusPublished {
+		t.Errorf("Post status = %q, want %q", published.Status, models.PostStatusPublished)
+	}
+	if published.PublishedAt == nil {
+		t.Error("Expected published_at to be set")
+	}
+}
+
+// TestDuePostSelection checks the worker leaves a post alone until the clock
+// reaches its scheduled time
+func TestDuePostSelection(t *testing.T) {
+	h := newHarness(t)
+
+	c := h.newClient()
+	c.register(newEmail(), testPassword)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := c.stream(ctx)
+	waitForEvent(t, events, "connected", 5*time.Second, nil)
+
+	post := c.createPost(newPostRequest(models.ChannelLinkedIn, h.clock.Now().Add(time.Hour)))
+
+	// Let the worker poll several times while the post isn't due yet
+	time.Sleep(5 * workerInterval)
+	var pending models.Post
+	if code := c.do(http.MethodGet, "/api/posts/"+post.ID.String(), nil, &pending); code != http.StatusOK {
+		t.Fatalf("Get post returned %d, want %d", code, http.StatusOK)
+	}
+	if pending.Status != models.PostStatusScheduled {
+		t.Fatalf("Post status before due = %q, want %q", pending.Status, models.PostStatusScheduled)
+	}
+
+	h.clock.Advance(time.Hour)
+	waitForEvent(t, events, "publish", 15*time.Second, func(e sseEvent) bool {
+		var data struct {
+			PostID string `json:"post_id"`
+		}
+		return json.Unmarshal([]byte(e.Data), &data) == nil && data.PostID == post.ID.String()
+	})
+}
+
+// TestScheduleValidation checks scheduled times are validated against the
+// clock: a little in the past is accepted, further back is rejected
+func TestScheduleValidation(t *testing.T) {
+	h := newHarness(t)
+
+	c := h.newClient()
+	c.register(newEmail(), testPassword)
+
+	var post models.Post
+	req := newPostRequest(models.ChannelTwitter, h.clock.Now().Add(-30*time.Second))
+	if code := c.do(http.MethodPost, "/api/posts", req, &post); code != http.StatusCreated {
+		t.Errorf("Post within the past grace returned %d, want %d", code, http.StatusCreated)
+	}
+
+	req = newPostRequest(models.ChannelTwitter, h.clock.Now().Add(-time.Hour))
+	if code := c.do(http.MethodPost, "/api/posts", req, nil); code != http.StatusBadRequest {
+		t.Errorf("Post an hour in the past returned %d, want %d", code, http.StatusBadRequest)
+	}
+
+	// The same time is fine once the clock is set back
+	h.clock.Set(h.clock.Now().Add(-time.Hour))
+	if code := c.do(http.MethodPost, "/api/posts", req, nil); code != http.StatusCreated {
+		t.Errorf("Post at the clock's time returned %d, want %d", code, http.StatusCreated)
+	}
+}
+
+// TestStreamRequiresSession checks the stream isn't served without signing in
+func TestStreamRequiresSession(t *testing.T) {
+	h := newHarness(t)
+
+	if code := h.newClient().do(http.MethodGet, "/api/posts/stream", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("Stream without session returned %d, want %d", code, http.StatusUnauthorized)
+	}
+}
+
+// TestLogoutRevokesAccessToken checks an access token copied before logout
+// stops working at logout rather than when it expires
+func TestLogoutRevokesAccessToken(t *testing.T) {
+	h := newHarness(t)
+	c := h.newClient()
+	c.register("logout@example.com", "password123")
+
+	// Another client holding a copy of the session's cookies
+	me, err := url.Parse(c.base + "/api/auth/me")
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %v", err)
+	}
+	stolen := h.newClient()
+	stolen.http.Jar.SetCookies(me, c.http.Jar.Cookies(me))
+	if code := stolen.do(http.MethodGet, "/api/auth/me", nil, nil); code != http.StatusOK {
+		t.Fatalf("Copied session returned %d before logout, want %d", code, http.StatusOK)
+	}
+
+	if code := c.do(http.MethodPost, "/api/auth/logout", nil, nil); code != http.StatusOK {
+		t.Fatalf("Logout returned %d, want %d", code, http.StatusOK)
+	}
+	if code := stolen.do(http.MethodGet, "/api/auth/me", nil, nil); code != http.StatusUnauthorized {
+		t.Errorf("Copied session returned %d after logout, want %d", code, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,179 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/api"
+	"github.com/scheduler/backend/internal/auth"
+	"github.com/scheduler/backend/internal/cache"
+	"github.com/scheduler/backend/internal/clock"
+	"github.com/scheduler/backend/internal/config"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/maintenance"
+	"github.com/scheduler/backend/internal/media"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+	"github.com/scheduler/backend/internal/publisher"
+	"github.com/scheduler/backend/internal/scheduler"
+	"github.com/scheduler/backend/internal/usage"
+)
+
+const (
+	databaseURLEnv = "INTEGRATION_DATABASE_URL"
+	redisURLEnv    = "INTEGRATION_REDIS_URL"
+
+	// workerInterval keeps the worker polling fast enough for tests to wait on it
+	workerInterval = 200 * time.Millisecond
+)
+
+// epoch is where every test's clock starts, well ahead of the real time so
+// post timestamps never depend on when the tests run
+var epoch = time.Date(2030, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+func TestMain(m *testing.M) {
+	if os.Getenv(databaseURLEnv) == "" || os.Getenv(redisURLEnv) == "" {
+		fmt.Printf("Skipping integration tests: %s and %s are not set\n", databaseURLEnv, redisURLEnv)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// harness is an API server and worker sharing freshly reset Postgres and
+// Redis. Both decide what is due by the harness's fake clock, which only moves
+// when a test advances it.
+type harness struct {
+	t      *testing.T
+	server *httptest.Server
+	db     *db.DB
+	redis  *redis.Client
+	clock  *clock.Fake
+}
+
+// newHarness resets the databases, then starts the API server and a worker
+// that are stopped when the test ends
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+
+	databaseURL := os.Getenv(databaseURLEnv)
+	redisURL := os.Getenv(redisURLEnv)
+	t.Setenv("DATABASE_URL", databaseURL)
+	t.Setenv("REDIS_URL", redisURL)
+	t.Setenv("JWT_SECRET", "integration-test-secret")
+	t.Setenv("PUBLISH_UNDO_WINDOW", "0s")
+	t.Setenv("MEDIA_DIR", t.TempDir())
+	cfg := config.Load()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	resetDatabase(t, ctx, databaseURL)
+	database, err := db.New(ctx, databaseURL, db.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	t.Cleanup(database.Close)
+	if _, err := database.RunMigrations(ctx, db.MigrationPostDeploy); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	redisClient := redis.NewClient(&redis.Options{Addr: redisURL})
+	if err := redisClient.FlushDB(ctx).Err(); err != nil {
+		t.Fatalf("Failed to flush Redis: %v", err)
+	}
+	t.Cleanup(func() { redisClient.Close() })
+
+	mediaStore, err := media.NewStore(cfg.MediaDir)
+	if err != nil {
+		t.Fatalf("Failed to initialize media store: %v", err)
+	}
+
+	clk := clock.NewFake(epoch)
+	queue := scheduler.NewQueue(redisClient, clk)
+	router := api.NewRouter(
+		database,
+		auth.NewJWTService(cfg.JWTSecret, cfg.AccessTokenTTL, cfg.RefreshTokenTTL, cfg.RememberMeTTL),
+		auth.NewBlacklist(redisClient),
+		auth.NewDomainPolicy(cfg.EmailDomainAllowlist, cfg.EmailDomainDenylist, cfg.BlockDisposableEmails),
+		queue,
+		mediaStore,
+		redisClient,
+		cfg,
+		clk,
+	)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	h := &harness{
+		t:      t,
+		server: server,
+		db:     database,
+		redis:  redisClient,
+		clock:  clk,
+	}
+	h.startWorker(ctx, cfg, queue)
+	return h
+}
+
+// startWorker runs a worker the way the server's worker mode does, publishing
+// through the simulated platform publishers
+func (h *harness) startWorker(ctx context.Context, cfg *config.Config, queue *scheduler.Queue) {
+	postCache := cache.NewCache(h.redis)
+	postNotifier := notifier.NewNotifier(h.redis)
+	h.t.Cleanup(postNotifier.Close)
+
+	worker := scheduler.NewWorker(
+		h.db,
+		queue,
+		postCache,
+		postNotifier,
+		publisher.NewRegistry(h.db),
+		models.NewDailyLimits(cfg.ChannelDailyLimits),
+		scheduler.NewHeartbeatStore(h.redis),
+		scheduler.NewLagMonitor(0, ""),
+		scheduler.NewCircuitBreaker(cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCooldown),
+		scheduler.NewBackoffStore(h.redis, h.clock),
+		scheduler.NewRetryBudget(h.redis, cfg.RetryBudgetPerMinute),
+		scheduler.NewJobQueue(h.redis),
+		maintenance.NewStore(h.redis),
+		usage.NewMeter(h.redis),
+		workerInterval,
+		cfg.PublishTimeout,
+		cfg.UndoWindow,
+		h.clock,
+	)
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		worker.Run(ctx)
+	}()
+	h.t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// resetDatabase drops every table so migrations run from scratch
+func resetDatabase(t *testing.T, ctx context.Context, databaseURL string) {
+	t.Helper()
+
+	conn, err := pgx.Connect(ctx, databaseURL)
+	if err != nil {
+		t.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	if _, err := conn.Exec(ctx, "DROP SCHEMA public CASCADE; CREATE SCHEMA public"); err != nil {
+		t.Fatalf("Failed to reset database: %v", err)
+	}
+}
@@ -0,0 +1,351 @@
+package abuse
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Signals that raise a subject's abuse score
+const (
+	SignalSignupBurst      = "signup_burst"      // Many signups from one IP
+	SignalDuplicateContent = "duplicate_content" // Identical post content across accounts
+	SignalTokenFailures    = "token_failures"    // Many invalid access tokens from one IP
+)
+
+const (
+	flaggedKey = "abuse:flagged"
+
+	// scoreTTL is how long a score lasts after it was last raised
+	scoreTTL = 24 * time.Hour
+
+	// Signals only count once their rate passes these limits
+	signupBurstLimit   = 5 // Signups per IP per signupBurstWindow
+	signupBurstWindow  = time.Hour
+	duplicateAccounts  = 3 // Accounts posting the same content per duplicateWindow
+	duplicateWindow    = 24 * time.Hour
+	tokenFailureLimit  = 20 // Invalid tokens per IP per tokenFailureWindow
+	tokenFailureWindow = 10 * time.Minute
+
+	// minDuplicateContent is the shortest content compared across accounts;
+	// shorter posts are too often identical by chance
+	minDuplicateContent = 20
+)
+
+// Points added to a score each time a signal fires past its limit
+const (
+	signupBurstPoints      = 25
+	duplicateContentPoints = 20
+	tokenFailurePoints     = 5
+)
+
+// ErrInvalidSubject is returned for subjects that aren't "user:<id>" or "ip:<address>"
+var ErrInvalidSubject = errors.New("invalid abuse subject")
+
+// Policy configures what an abuse score leads to
+type Policy struct {
+	ThrottleScore      int           // Score at which rate limits are reduced
+	ThrottleMultiplier float64       // Applied to rate limits of throttled subjects
+	HoldScore          int           // Score at which a user account is held
+	HoldDuration       time.Duration // How long automatic and manual holds last
+}
+
+// UserSubject is the subject for signals attributed to a user
+func UserSubject(id uuid.UUID) string {
+	return "user:" + id.String()
+}
+
+// IPSubject is the subject for signals attributed to a client IP
+func IPSubject(ip string) string {
+	return "ip:" + ip
+}
+
+// ParseSubject checks that s is a user or IP subject
+func ParseSubject(s string) (string, error) {
+	kind, value, ok := strings.Cut(s, ":")
+	if !ok || value == "" {
+		return "", ErrInvalidSubject
+	}
+	switch kind {
+	case "user":
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return "", ErrInvalidSubject
+		}
+		return UserSubject(id), nil
+	case "ip":
+		return s, nil
+	default:
+		return "", ErrInvalidSubject
+	}
+}
+
+func scoreKey(subject string) string   { return "abuse:score:" + subject }
+func signalsKey(subject string) string { return "abuse:signals:" + subject }
+func holdKey(subject string) string    { return "abuse:hold:" + subject }
+
+// Detector scores users and client IPs on abuse heuristics. Scores are kept in
+// Redis so every API instance sees the same value; they decay a day after the
+// last signal. Redis errors never block a request: detection fails open.
+type Detector struct {
+	redis  *redis.Client
+	policy Policy
+}
+
+// NewDetector creates a new abuse detector
+func NewDetector(redisClient *redis.Client, policy Policy) *Detector {
+	return &Detector{
+		redis:  redisClient,
+		policy: policy,
+	}
+}
+
+// RecordSignup counts a signup from ip, raising the IP's score for every
+// signup past the burst limit
+func (d *Detector) RecordSignup(ctx context.Context, ip string) {
+	n, err := d.count(ctx, "abuse:signups:"+ip, signupBurstWindow)
+	if err != nil {
+		log.Printf("⚠️ Failed to record signup from %s: %v", ip, err)
+		return
+	}
+	if n > signupBurstLimit {
+		d.raise(ctx, IPSubject(ip), SignalSignupBurst, signupBurstPoints)
+	}
+}
+
+// RecordContent remembers which accounts posted content, raising the user's
+// score when the same content comes from too many accounts
+func (d *Detector) RecordContent(ctx context.Context, userID uuid.UUID, content string) {
+	hash, ok := contentHash(content)
+	if !ok {
+		return
+	}
+
+	key := "abuse:content:" + hash
+	pipe := d.redis.Pipeline()
+	pipe.SAdd(ctx, key, userID.String())
+	pipe.Expire(ctx, key, duplicateWindow)
+	accounts := pipe.SCard(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("⚠️ Failed to record content of user %s: %v", userID, err)
+		return
+	}
+	if accounts.Val() > duplicateAccounts {
+		d.raise(ctx, UserSubject(userID), SignalDuplicateContent, duplicateContentPoints)
+	}
+}
+
+// RecordTokenFailure counts an invalid access token from ip, raising the IP's
+// score for every failure past the limit
+func (d *Detector) RecordTokenFailure(ctx context.Context, ip string) {
+	n, err := d.count(ctx, "abuse:token-failures:"+ip, tokenFailureWindow)
+	if err != nil {
+		log.Printf("⚠️ Failed to record token failure from %s: %v", ip, err)
+		return
+	}
+	if n > tokenFailureLimit {
+		d.raise(ctx, IPSubject(ip), SignalTokenFailures, tokenFailurePoints)
+	}
+}
+
+// count increments a counter that resets window after its first increment
+func (d *Detector) count(ctx context.Context, key string, window time.Duration) (int64, error) {
+	n, err := d.redis.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if n == 1 {
+		d.redis.Expire(ctx, key, window)
+	}
+	return n, nil
+}
+
+// raise adds points to the subject's score, holding user accounts whose score
+// reaches the hold threshold
+func (d *Detector) raise(ctx context.Context, subject, signal string, points int) {
+	pipe := d.redis.Pipeline()
+	score := pipe.IncrBy(ctx, scoreKey(subject), int64(points))
+	pipe.Expire(ctx, scoreKey(subject), scoreTTL)
+	pipe.HIncrBy(ctx, signalsKey(subject), signal, 1)
+	pipe.Expire(ctx, signalsKey(subject), scoreTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("⚠️ Failed to raise abuse score of %s: %v", subject, err)
+		return
+	}
+	d.redis.ZAdd(ctx, flaggedKey, redis.Z{Score: float64(score.Val()), Member: subject})
+
+	if score.Val() >= int64(d.policy.HoldScore) && strings.HasPrefix(subject, "user:") {
+		held, err := d.redis.SetNX(ctx, holdKey(subject), signal, d.policy.HoldDuration).Result()
+		if err != nil {
+			log.Printf("⚠️ Failed to hold %s: %v", subject, err)
+			return
+		}
+		if held {
+			log.Printf("🚨 Held %s for %s after %s (score %d)", subject, d.policy.HoldDuration, signal, score.Val())
+		}
+	}
+}
+
+// Throttle returns the limit to enforce for a request by the given subjects:
+// reduced by the throttle multiplier once any of them reaches the throttle score
+func (d *Detector) Throttle(ctx context.Context, limit int, subjects ...string) int {
+	return d.QueueThrottle(ctx, d.redis, limit, subjects...)()
+}
+
+// QueueThrottle adds reading the subjects' scores to pipe and returns a func
+// that gives the limit Throttle would, once pipe has run. pipe may be a
+// client, which runs the command at once.
+func (d *Detector) QueueThrottle(ctx context.Context, pipe redis.Cmdable, limit int, subjects ...string) func() int {
+	keys := make([]string, len(subjects))
+	for i, s := range subjects {
+		keys[i] = scoreKey(s)
+	}
+	mget := pipe.MGet(ctx, keys...)
+
+	return func() int {
+		scores, err := mget.Result()
+		if err != nil {
+			return limit
+		}
+
+		for _, v := range scores {
+			s, ok := v.(string)
+			if !ok {
+				continue
+			}
+			if score, err := strconv.Atoi(s); err == nil && score >= d.policy.ThrottleScore {
+				return int(math.Max(1, math.Round(float64(limit)*d.policy.ThrottleMultiplier)))
+			}
+		}
+		return limit
+	}
+}
+
+// HeldUntil returns when the user's hold expires, or nil if they aren't held
+func (d *Detector) HeldUntil(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	return d.QueueHeldUntil(ctx, d.redis, userID)()
+}
+
+// QueueHeldUntil adds reading the user's hold to pipe and returns a func that
+// gives the result HeldUntil would, once pipe has run. pipe may be a client,
+// which runs the command at once.
+func (d *Detector) QueueHeldUntil(ctx context.Context, pipe redis.Cmdable, userID uuid.UUID) func() (*time.Time, error) {
+	pttl := pipe.PTTL(ctx, holdKey(UserSubject(userID)))
+
+	return func() (*time.Time, error) {
+		ttl, err := pttl.Result()
+		if err != nil {
+			return nil, err
+		}
+		if ttl <= 0 {
+			return nil, nil
+		}
+		until := time.Now().Add(ttl).UTC()
+		return &until, nil
+	}
+}
+
+// List returns the subjects with a current abuse score, highest first
+func (d *Detector) List(ctx context.Context) ([]models.AbuseReport, error) {
+	subjects, err := d.redis.ZRevRange(ctx, flaggedKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]models.AbuseReport, 0, len(subjects))
+	var expired []interface{}
+	for _, subject := range subjects {
+		report, err := d.report(ctx, subject)
+		if err != nil {
+			return nil, err
+		}
+		if report == nil {
+			expired = append(expired, subject)
+			continue
+		}
+		reports = append(reports, *report)
+	}
+
+	// Scores that have decayed are dropped from the index
+	if len(expired) > 0 {
+		d.redis.ZRem(ctx, flaggedKey, expired...)
+	}
+	return reports, nil
+}
+
+// report returns the subject's score, signals and hold, or nil once both the
+// score and hold have expired
+func (d *Detector) report(ctx context.Context, subject string) (*models.AbuseReport, error) {
+	pipe := d.redis.Pipeline()
+	score := pipe.Get(ctx, scoreKey(subject))
+	signals := pipe.HGetAll(ctx, signalsKey(subject))
+	hold := pipe.PTTL(ctx, holdKey(subject))
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	n, scoreErr := score.Int()
+	holdTTL := hold.Val()
+	if scoreErr != nil && holdTTL <= 0 {
+		return nil, nil
+	}
+
+	report := &models.AbuseReport{
+		Subject:   subject,
+		Score:     n,
+		Signals:   make(map[string]int),
+		Throttled: n >= d.policy.ThrottleScore,
+	}
+	for signal, v := range signals.Val() {
+		if count, err := strconv.Atoi(v); err == nil {
+			report.Signals[signal] = count
+		}
+	}
+	if holdTTL > 0 {
+		until := time.Now().Add(holdTTL).UTC()
+		report.HeldUntil = &until
+	}
+	return report, nil
+}
+
+// Hold puts the subject on hold for the policy's hold duration, regardless of
+// its score. Only user holds block requests.
+func (d *Detector) Hold(ctx context.Context, subject string) (*models.AbuseReport, error) {
+	if err := d.redis.Set(ctx, holdKey(subject), "manual", d.policy.HoldDuration).Err(); err != nil {
+		return nil, err
+	}
+	if err := d.redis.ZAddNX(ctx, flaggedKey, redis.Z{Score: 0, Member: subject}).Err(); err != nil {
+		return nil, err
+	}
+	return d.report(ctx, subject)
+}
+
+// Clear resets the subject's score and releases any hold, after review
+func (d *Detector) Clear(ctx context.Context, subject string) error {
+	pipe := d.redis.TxPipeline()
+	pipe.Del(ctx, scoreKey(subject), signalsKey(subject), holdKey(subject))
+	pipe.ZRem(ctx, flaggedKey, subject)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// contentHash fingerprints post content, ignoring case and whitespace, so
+// trivially varied copies match. Short content isn't fingerprinted.
+func contentHash(content string) (string, bool) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(content), " "))
+	if len(normalized) < minDuplicateContent {
+		return "", false
+	}
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:]), true
+}
@@ -0,0 +1,47 @@
+package abuse
+
+import "testing"
+
+func TestParseSubject(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    string
+		wantErr bool
+	}{
+		{"user:3F2504E0-4F89-11D3-9A0C-0305E82C3301", "user:3f2504e0-4f89-11d3-9a0c-0305e82c3301", false},
+		{"ip:203.0.113.7", "ip:203.0.113.7", false},
+		{"ip:2001:db8::1", "ip:2001:db8::1", false},
+		{"user:not-a-uuid", "", true},
+		{"ip:", "", true},
+		{"email:a@example.com", "", true},
+		{"203.0.113.7", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSubject(tt.subject)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSubject(%q) error = %v, wantErr %v", tt.subject, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSubject(%q) = %q, want %q", tt.subject, got, tt.want)
+		}
+	}
+}
+
+func TestContentHash(t *testing.T) {
+	base, ok := contentHash("Check out this amazing offer today")
+	if !ok {
+		t.Fatal("contentHash() skipped content above the minimum length")
+	}
+
+	if got, _ := contentHash("  check out THIS amazing\n offer   today "); got != base {
+		t.Error("contentHash() should ignore case and whitespace")
+	}
+	if got, _ := contentHash("Check out this amazing offer tomorrow"); got == base {
+		t.Error("contentHash() matched different content")
+	}
+	if _, ok := contentHash("Good morning!"); ok {
+		t.Error("contentHash() should skip short content")
+	}
+}
@@ -0,0 +1,191 @@
+package announcement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// indexKey is a ZSET of announcement IDs scored by expiry, +inf for none
+	indexKey = "announcements"
+	// dataKey is a hash of announcement ID to its JSON
+	dataKey = "announcements:data"
+
+	// MaxMessageLength is the longest announcement message accepted
+	MaxMessageLength = 500
+	// MaxDuration is the longest an announcement may be shown
+	MaxDuration = 30 * 24 * time.Hour
+)
+
+// Level is how prominently clients should show an announcement
+type Level string
+
+const (
+	LevelInfo     Level = "info"
+	LevelWarning  Level = "warning"  // e.g. upcoming maintenance
+	LevelCritical Level = "critical" // e.g. an ongoing incident
+)
+
+// Announcement is a system-wide notice shown to every signed-in user
+type Announcement struct {
+	ID        uuid.UUID  `json:"id"`
+	Message   string     `json:"message"`
+	Level     Level      `json:"level"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Nil to show it until removed
+}
+
+// CreateRequest represents an admin request to post an announcement
+type CreateRequest struct {
+	Message          string `json:"message"`
+	Level            Level  `json:"level"`              // Defaults to info
+	ExpiresInMinutes int    `json:"expires_in_minutes"` // 0 shows it until removed
+}
+
+// Validate trims the message and checks the level and expiry, defaulting the level
+func (r *CreateRequest) Validate() error {
+	r.Message = strings.TrimSpace(r.Message)
+	if r.Message == "" {
+		return errors.New("message is required")
+	}
+	if len(r.Message) > MaxMessageLength {
+		return fmt.Errorf("message must not exceed %d characters", MaxMessageLength)
+	}
+
+	if r.Level == "" {
+		r.Level = LevelInfo
+	}
+	switch r.Level {
+	case LevelInfo, LevelWarning, LevelCritical:
+	default:
+		return errors.New("invalid level. Must be one of: info, warning, critical")
+	}
+
+	if r.ExpiresInMinutes < 0 || time.Duration(r.ExpiresInMinutes)*time.Minute > MaxDuration {
+		return fmt.Errorf("expires_in_minutes must be between 0 and %d", int(MaxDuration/time.Minute))
+	}
+	return nil
+}
+
+// Store keeps announcements in Redis so every API instance serves the same
+// ones to connecting clients. Expired announcements are pruned as they are read.
+type Store struct {
+	redis *redis.Client
+}
+
+// NewStore creates a new announcement store
+func NewStore(redisClient *redis.Client) *Store {
+	return &Store{
+		redis: redisClient,
+	}
+}
+
+// Create stores a new announcement
+func (s *Store) Create(ctx context.Context, req CreateRequest) (*Announcement, error) {
+	now := time.Now().UTC()
+	a := &Announcement{
+		ID:        uuid.New(),
+		Message:   req.Message,
+		Level:     req.Level,
+		CreatedAt: now,
+	}
+	score := math.Inf(1)
+	if req.ExpiresInMinutes > 0 {
+		expires := now.Add(time.Duration(req.ExpiresInMinutes) * time.Minute)
+		a.ExpiresAt = &expires
+		score = float64(expires.Unix())
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	pipe := s.redis.TxPipeline()
+	pipe.HSet(ctx, dataKey, a.ID.String(), data)
+	pipe.ZAdd(ctx, indexKey, redis.Z{Score: score, Member: a.ID.String()})
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Active returns the announcements that haven't expired, oldest first
+func (s *Store) Active(ctx context.Context) ([]*Announcement, error) {
+	now := time.Now().Unix()
+	if err := s.prune(ctx, now); err != nil {
+		return nil, err
+	}
+
+	ids, err := s.redis.ZRangeByScore(ctx, indexKey, &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(now, 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil || len(ids) == 0 {
+		return []*Announcement{}, err
+	}
+
+	values, err := s.redis.HMGet(ctx, dataKey, ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+	announcements := make([]*Announcement, 0, len(values))
+	for _, v := range values {
+		data, ok := v.(string)
+		if !ok {
+			continue
+		}
+		var a Announcement
+		if err := json.Unmarshal([]byte(data), &a); err != nil {
+			return nil, err
+		}
+		announcements = append(announcements, &a)
+	}
+
+	// The index is ordered by expiry
+	sort.Slice(announcements, func(i, j int) bool {
+		return announcements[i].CreatedAt.Before(announcements[j].CreatedAt)
+	})
+	return announcements, nil
+}
+
+// Delete removes an announcement, reporting whether it existed
+func (s *Store) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
+	pipe := s.redis.TxPipeline()
+	removed := pipe.ZRem(ctx, indexKey, id.String())
+	pipe.HDel(ctx, dataKey, id.String())
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+	return removed.Val() > 0, nil
+}
+
+// prune drops announcements that expired at or before now
+func (s *Store) prune(ctx context.Context, now int64) error {
+	expired, err := s.redis.ZRangeByScore(ctx, indexKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now, 10),
+	}).Result()
+	if err != nil || len(expired) == 0 {
+		return err
+	}
+
+	members := make([]interface{}, len(expired))
+	for i, id := range expired {
+		members[i] = id
+	}
+	pipe := s.redis.TxPipeline()
+	pipe.ZRem(ctx, indexKey, members...)
+	pipe.HDel(ctx, dataKey, expired...)
+	_, err = pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,40 @@
+package announcement
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCreateRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       CreateRequest
+		wantLevel Level
+		wantErr   bool
+	}{
+		{"defaults to info", CreateRequest{Message: "  Maintenance tonight  "}, LevelInfo, false},
+		{"critical with expiry", CreateRequest{Message: "Publishing delayed", Level: LevelCritical, ExpiresInMinutes: 60}, LevelCritical, false},
+		{"empty message", CreateRequest{Message: "   "}, "", true},
+		{"message too long", CreateRequest{Message: strings.Repeat("a", MaxMessageLength+1)}, "", true},
+		{"unknown level", CreateRequest{Message: "Hello", Level: "urgent"}, "", true},
+		{"negative expiry", CreateRequest{Message: "Hello", ExpiresInMinutes: -1}, "", true},
+		{"expiry too far out", CreateRequest{Message: "Hello", ExpiresInMinutes: 31 * 24 * 60}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.req.Level != tt.wantLevel {
+				t.Errorf("Level = %q, want %q", tt.req.Level, tt.wantLevel)
+			}
+			if tt.req.Message != strings.TrimSpace(tt.req.Message) {
+				t.Errorf("Message = %q, want it trimmed", tt.req.Message)
+			}
+		})
+	}
+}
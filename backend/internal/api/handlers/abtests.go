@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+)
+
+// GetABTest compares the variants of a post's A/B test. Either variant's ID may be given.
+func (h *PostHandler) GetABTest(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	post, ok := h.loadVisiblePost(w, r, user)
+	if !ok {
+		return
+	}
+
+	if post.ABParentID != nil {
+		parent, err := h.db.GetPostByID(r.Context(), *post.ABParentID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch post")
+			return
+		}
+		if parent == nil {
+			respondError(w, http.StatusNotFound, "Variant A of this test was deleted")
+			return
+		}
+		post = parent
+	}
+	if post.ABTest == nil {
+		respondError(w, http.StatusNotFound, "Post has no A/B test")
+		return
+	}
+
+	variant, err := h.db.GetABVariant(r.Context(), post.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch variant B")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, models.NewABComparison(post, variant))
+}
+
+// SetEngagement records a published post's engagement, as reported by the
+// platform. A/B tests pick their winner from these numbers.
+func (h *PostHandler) SetEngagement(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	post, ok := h.loadVisiblePost(w, r, user)
+	if !ok {
+		return
+	}
+
+	var req models.Engagement
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated, err := h.db.SetPostEngagement(r.Context(), post.ID, req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update engagement")
+		return
+	}
+	if updated == nil {
+		respondError(w, http.StatusConflict, "Engagement can only be recorded for published posts")
+		return
+	}
+
+	if h.cache != nil {
+		_ = h.cache.InvalidatePost(r.Context(), updated)
+	}
+	h.notifier.Notify(updated.UserID, notifier.UpdateTypeUpdate)
+
+	respondJSON(w, http.StatusOK, updated)
+}
+
+// loadVisiblePost fetches the post from the URL and checks the user can see
+// it: their own posts, and organization posts in their current workspace
+func (h *PostHandler) loadVisiblePost(w http.ResponseWriter, r *http.Request, user *models.User) (*models.Post, bool) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return nil, false
+	}
+
+	post, err := h.db.GetPostByID(r.Context(), postID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch post")
+		return nil, false
+	}
+	if post == nil {
+		respondError(w, http.StatusNotFound, "Post not found")
+		return nil, false
+	}
+	if post.UserID != user.ID && !post.InWorkspace(user.WorkspaceID) {
+		respondError(w, http.StatusForbidden, "Access denied")
+		return nil, false
+	}
+
+	return post, true
+}
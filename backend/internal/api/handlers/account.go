@@ -0,0 +1,344 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/auth"
+	"github.com/scheduler/backend/internal/cache"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/media"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// AccountHandler handles account management endpoints
+type AccountHandler struct {
+	db    db.Store
+	media *media.Store
+	cache *cache.Cache
+}
+
+// NewAccountHandler creates a new account handler
+func NewAccountHandler(database db.Store, mediaStore *media.Store, c *cache.Cache) *AccountHandler {
+	return &AccountHandler{
+		db:    database,
+		media: mediaStore,
+		cache: c,
+	}
+}
+
+// invalidateUser drops the user's cached session so the auth middleware reads
+// a change on the next request. The cache may be nil.
+func invalidateUser(ctx context.Context, c *cache.Cache, userID uuid.UUID) {
+	if c == nil {
+		return
+	}
+	if err := c.InvalidateUser(ctx, userID); err != nil {
+		log.Printf("⚠️ Failed to invalidate cached user %s: %v", userID, err)
+	}
+}
+
+// UploadAvatar accepts an image upload (multipart field "avatar"),
+// crops and resizes it, and stores it as the user's avatar
+func (h *AccountHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	// Allow some headroom for multipart framing
+	r.Body = http.MaxBytesReader(w, r.Body, media.MaxImageBytes+1<<20)
+
+	file, _, err := r.FormFile("avatar")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Avatar image is required (multipart field \"avatar\")")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, media.MaxImageBytes+1))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Failed to read avatar image")
+		return
+	}
+	if len(data) > media.MaxImageBytes {
+		respondError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Avatar image must not exceed %d MB", media.MaxImageBytes>>20))
+		return
+	}
+
+	processed, err := media.ProcessAvatar(data)
+	switch err {
+	case nil:
+	case media.ErrUnsupportedImage:
+		respondError(w, http.StatusBadRequest, "Unsupported image format. Use JPEG, PNG or GIF")
+		return
+	case media.ErrImageTooLarge:
+		respondError(w, http.StatusBadRequest, "Image dimensions are too large")
+		return
+	default:
+		respondError(w, http.StatusInternalServerError, "Failed to process avatar")
+		return
+	}
+
+	key := avatarKey(user)
+	if err := h.media.Put(key, processed); err != nil {
+		log.Printf("❌ Failed to store avatar for user %s: %v", user.ID, err)
+		respondError(w, http.StatusInternalServerError, "Failed to store avatar")
+		return
+	}
+
+	updated, err := h.db.SetUserAvatar(r.Context(), user.ID, &key)
+	if err != nil || updated == nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update avatar")
+		return
+	}
+	invalidateUser(r.Context(), h.cache, user.ID)
+
+	respondJSON(w, http.StatusOK, models.AuthResponse{
+		User: updated.ToResponse(),
+	})
+}
+
+// DeleteAvatar removes the user's avatar
+func (h *AccountHandler) DeleteAvatar(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	updated, err := h.db.SetUserAvatar(r.Context(), user.ID, nil)
+	if err != nil || updated == nil {
+		respondError(w, http.StatusInternalServerError, "Failed to remove avatar")
+		return
+	}
+	invalidateUser(r.Context(), h.cache, user.ID)
+
+	if err := h.media.Delete(avatarKey(user)); err != nil {
+		log.Printf("⚠️ Failed to delete avatar file for user %s: %v", user.ID, err)
+	}
+
+	respondJSON(w, http.StatusOK, models.AuthResponse{
+		User: updated.ToResponse(),
+	})
+}
+
+// GetSettings returns the user's account settings
+func (h *AccountHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, user.Settings())
+}
+
+// UpdateSettings updates the user's account settings
+func (h *AccountHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	var req models.UpdateAccountSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated, err := h.db.UpdateUserSettings(r.Context(), user.ID, req)
+	if err != nil || updated == nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update settings")
+		return
+	}
+	invalidateUser(r.Context(), h.cache, user.ID)
+
+	respondJSON(w, http.StatusOK, updated.Settings())
+}
+
+// GetNotifications returns which channels each notification event is delivered over
+func (h *AccountHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, user.NotificationPreferences.Resolve())
+}
+
+// UpdateNotifications changes the channels of the events in the request;
+// other events and channels are left unchanged
+func (h *AccountHandler) UpdateNotifications(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	var req models.UpdateNotificationPreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	prefs := req.Apply(user.NotificationPreferences)
+	if prefs.UsesWebhook() && user.ReminderWebhookURL == nil {
+		respondError(w, http.StatusBadRequest, "Set reminder_webhook_url in your account settings before routing notifications to a webhook")
+		return
+	}
+
+	updated, err := h.db.SetNotificationPreferences(r.Context(), user.ID, prefs)
+	if err != nil || updated == nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update notification preferences")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, updated.NotificationPreferences.Resolve())
+}
+
+// RotateWebhook generates a new inbound webhook token, replacing any previous one
+func (h *AccountHandler) RotateWebhook(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	token, err := auth.GenerateURLToken()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to generate webhook token")
+		return
+	}
+	hash := auth.HashURLToken(token)
+	if err := h.db.SetWebhookToken(r.Context(), user.ID, &hash); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to save webhook token")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, models.WebhookTokenResponse{
+		Token: token,
+		Path:  "/api/hooks/" + token,
+	})
+}
+
+// DeleteWebhook disables the user's inbound webhook
+func (h *AccountHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	if err := h.db.SetWebhookToken(r.Context(), user.ID, nil); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to disable webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RotateFeed generates a new public feed token, replacing any previous one
+func (h *AccountHandler) RotateFeed(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	token, err := auth.GenerateURLToken()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to generate feed token")
+		return
+	}
+	hash := auth.HashURLToken(token)
+	if err := h.db.SetFeedToken(r.Context(), user.ID, &hash); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to save feed token")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, models.FeedTokenResponse{
+		Token:    token,
+		RSSPath:  "/api/feeds/" + token + "/rss",
+		JSONPath: "/api/feeds/" + token + "/json",
+	})
+}
+
+// DeleteFeed disables the user's public feed
+func (h *AccountHandler) DeleteFeed(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	if err := h.db.SetFeedToken(r.Context(), user.ID, nil); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to disable feed")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RotateEmbed generates a new schedule embed token, replacing any previous one
+func (h *AccountHandler) RotateEmbed(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	token, err := auth.GenerateURLToken()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to generate embed token")
+		return
+	}
+	hash := auth.HashURLToken(token)
+	if err := h.db.SetEmbedToken(r.Context(), user.ID, &hash); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to save embed token")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, models.EmbedTokenResponse{
+		Token:    token,
+		HTMLPath: "/api/embeds/" + token + "/html",
+		JSONPath: "/api/embeds/" + token + "/json",
+	})
+}
+
+// DeleteEmbed disables the user's schedule embed
+func (h *AccountHandler) DeleteEmbed(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	if err := h.db.SetEmbedToken(r.Context(), user.ID, nil); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to disable embed")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// avatarKey returns the stable media key for a user's avatar
+func avatarKey(user *models.User) string {
+	return fmt.Sprintf("avatars/%s.png", user.ID)
+}
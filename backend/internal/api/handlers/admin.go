@@ -0,0 +1,495 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/abuse"
+	"github.com/scheduler/backend/internal/announcement"
+	"github.com/scheduler/backend/internal/auth"
+	"github.com/scheduler/backend/internal/cache"
+	"github.com/scheduler/backend/internal/cron"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/maintenance"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+	"github.com/scheduler/backend/internal/ratelimit"
+	"github.com/scheduler/backend/internal/scheduler"
+)
+
+// AdminHandler handles operator endpoints
+type AdminHandler struct {
+	db          db.Store
+	queue       *scheduler.Queue
+	heartbeats  *scheduler.HeartbeatStore
+	maintenance *maintenance.Store
+	rateLimits  *ratelimit.Registry
+	abuse       *abuse.Detector
+	dispatch    *scheduler.Dispatcher
+	announce    *announcement.Store
+	notifier    *notifier.Notifier
+	redis       *redis.Client
+	cache       *cache.Cache
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(database db.Store, queue *scheduler.Queue, heartbeats *scheduler.HeartbeatStore, maintenanceStore *maintenance.Store, rateLimits *ratelimit.Registry, detector *abuse.Detector, dispatcher *scheduler.Dispatcher, announcements *announcement.Store, n *notifier.Notifier, redisClient *redis.Client, c *cache.Cache) *AdminHandler {
+	return &AdminHandler{
+		db:          database,
+		queue:       queue,
+		heartbeats:  heartbeats,
+		maintenance: maintenanceStore,
+		rateLimits:  rateLimits,
+		abuse:       detector,
+		dispatch:    dispatcher,
+		announce:    announcements,
+		notifier:    n,
+		redis:       redisClient,
+		cache:       c,
+	}
+}
+
+// ListInvites returns the invite codes' limits and usage; the codes
+// themselves are only shown when created
+func (h *AdminHandler) ListInvites(w http.ResponseWriter, r *http.Request) {
+	invites, err := h.db.ListInvites(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch invites")
+		return
+	}
+
+	respondList(w, invites)
+}
+
+// CreateInvite generates an invite code with a number of uses and an optional
+// expiry. The code is returned once; only its hash is stored.
+func (h *AdminHandler) CreateInvite(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+
+	var req models.CreateInviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	code, err := auth.GenerateInviteCode()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to generate invite code")
+		return
+	}
+	invite, err := h.db.CreateInvite(r.Context(), auth.HashInviteCode(code), req.MaxUses, req.ExpiresAt(time.Now()), user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create invite")
+		return
+	}
+	invite.Code = code
+
+	log.Printf("🎟️ Invite %s for %d signups created by %s", invite.ID, invite.MaxUses, user.Email)
+	respondJSON(w, http.StatusCreated, invite)
+}
+
+// DeleteInvite revokes an invite code
+func (h *AdminHandler) DeleteInvite(w http.ResponseWriter, r *http.Request) {
+	inviteID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid invite ID")
+		return
+	}
+
+	deleted, err := h.db.DeleteInvite(r.Context(), inviteID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete invite")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "Invite not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListWorkers returns the latest heartbeat of each worker seen recently
+func (h *AdminHandler) ListWorkers(w http.ResponseWriter, r *http.Request) {
+	workers, err := h.heartbeats.List(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch worker status")
+		return
+	}
+
+	respondList(w, workers)
+}
+
+// cronHistoryRuns is how many recent runs are returned per cron job
+const cronHistoryRuns = 10
+
+// ListCronJobs returns the worker's cron jobs with their schedules and recent runs
+func (h *AdminHandler) ListCronJobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := cron.ListJobs(r.Context(), h.redis, cronHistoryRuns)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch cron jobs")
+		return
+	}
+
+	respondList(w, jobs)
+}
+
+// SetUserPlan changes a user's subscription plan
+func (h *AdminHandler) SetUserPlan(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req models.SetPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !models.IsValidPlan(req.Plan) {
+		respondError(w, http.StatusBadRequest, "Invalid plan. Must be one of: free, pro")
+		return
+	}
+
+	user, err := h.db.SetUserPlan(r.Context(), userID, models.Plan(req.Plan))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update plan")
+		return
+	}
+	if user == nil {
+		respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	invalidateUser(r.Context(), h.cache, user.ID)
+
+	respondJSON(w, http.StatusOK, user.ToResponse())
+}
+
+// SuspendUser suspends an account with a reason and tells its owner. Suspended
+// users can still sign in and read, but can't make changes, and the worker
+// skips their posts.
+func (h *AdminHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	admin := GetUserFromContext(r.Context())
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+	if admin.ID == userID {
+		respondError(w, http.StatusBadRequest, "You cannot suspend your own account")
+		return
+	}
+
+	var req models.SuspendUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := h.db.SuspendUser(r.Context(), userID, req.Reason)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to suspend user")
+		return
+	}
+	if user == nil {
+		respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	invalidateUser(r.Context(), h.cache, user.ID)
+
+	log.Printf("⛔ Admin %s suspended user %s: %s", admin.Email, user.ID, req.Reason)
+	h.dispatch.SendAccountNotice(r.Context(), user, "Your account has been suspended",
+		"Your account has been suspended and your scheduled posts will not publish. "+
+			"You can still sign in and view your posts.\n\nReason: "+req.Reason)
+
+	respondJSON(w, http.StatusOK, user.ToResponse())
+}
+
+// UnsuspendUser lifts an account's suspension, queues its scheduled posts
+// again and tells its owner. Posts whose time passed while suspended publish
+// right away.
+func (h *AdminHandler) UnsuspendUser(w http.ResponseWriter, r *http.Request) {
+	admin := GetUserFromContext(r.Context())
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	user, err := h.db.UnsuspendUser(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to unsuspend user")
+		return
+	}
+	if user == nil {
+		respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	invalidateUser(r.Context(), h.cache, user.ID)
+
+	// The queue reconciliation would pick these up too, but not for a while
+	refs, err := h.db.ListUserScheduledPostRefs(r.Context(), user.ID)
+	if err == nil {
+		_, err = h.queue.EnqueueMissing(r.Context(), refs)
+	}
+	if err != nil {
+		log.Printf("⚠️ Failed to requeue posts of unsuspended user %s: %v", user.ID, err)
+	}
+
+	log.Printf("✅ Admin %s unsuspended user %s", admin.Email, user.ID)
+	h.dispatch.SendAccountNotice(r.Context(), user, "Your account has been reinstated",
+		"Your account's suspension has been lifted. Your scheduled posts will publish as planned.")
+
+	respondJSON(w, http.StatusOK, user.ToResponse())
+}
+
+// GetMaintenance returns the current maintenance mode state
+func (h *AdminHandler) GetMaintenance(w http.ResponseWriter, r *http.Request) {
+	state, err := h.maintenance.Get(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch maintenance state")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, state)
+}
+
+// SetMaintenance turns maintenance mode on or off. While it is on the API
+// rejects mutations and workers stop publishing.
+func (h *AdminHandler) SetMaintenance(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+
+	var req models.SetMaintenanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Enabled == nil {
+		respondError(w, http.StatusBadRequest, "enabled is required")
+		return
+	}
+
+	var state *maintenance.State
+	var err error
+	if *req.Enabled {
+		state, err = h.maintenance.Enable(r.Context(), trimString(req.Message))
+	} else {
+		state, err = h.maintenance.Disable(r.Context())
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update maintenance state")
+		return
+	}
+
+	log.Printf("🚧 Maintenance mode set to %v by %s", state.Enabled, user.Email)
+	respondJSON(w, http.StatusOK, state)
+}
+
+// ListAnnouncements returns the announcements that haven't expired
+func (h *AdminHandler) ListAnnouncements(w http.ResponseWriter, r *http.Request) {
+	announcements, err := h.announce.Active(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch announcements")
+		return
+	}
+
+	respondList(w, announcements)
+}
+
+// CreateAnnouncement posts a system announcement and broadcasts it to every
+// connected client as an announcement SSE event. Clients connecting later
+// receive it until it expires or is removed.
+func (h *AdminHandler) CreateAnnouncement(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+
+	var req announcement.CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	a, err := h.announce.Create(r.Context(), req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create announcement")
+		return
+	}
+	if data, err := json.Marshal(a); err == nil {
+		h.notifier.Broadcast(notifier.UpdateTypeAnnouncement, data)
+	}
+
+	log.Printf("📢 Announcement %s (%s) posted by %s", a.ID, a.Level, user.Email)
+	respondJSON(w, http.StatusCreated, a)
+}
+
+// DeleteAnnouncement takes an announcement down and tells connected clients
+func (h *AdminHandler) DeleteAnnouncement(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid announcement ID")
+		return
+	}
+
+	deleted, err := h.announce.Delete(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete announcement")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "Announcement not found")
+		return
+	}
+	if data, err := json.Marshal(map[string]uuid.UUID{"id": id}); err == nil {
+		h.notifier.Broadcast(notifier.UpdateTypeAnnouncementRemoved, data)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListRateLimits returns each scope's current rate limit, before plan multipliers
+func (h *AdminHandler) ListRateLimits(w http.ResponseWriter, r *http.Request) {
+	respondList(w, h.rateLimits.List(r.Context(), ""))
+}
+
+// SetRateLimit overrides a scope's rate limit on every API instance, taking
+// effect within seconds and lasting until it is reset
+func (h *AdminHandler) SetRateLimit(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	scope := chi.URLParam(r, "scope")
+
+	var req models.SetRateLimitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	policy, err := h.rateLimits.Set(r.Context(), scope, req.Limit, time.Duration(req.WindowSeconds)*time.Second)
+	if errors.Is(err, ratelimit.ErrUnknownScope) {
+		respondError(w, http.StatusNotFound, "Rate limit scope not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	log.Printf("🚦 Rate limit %s set to %d per %ds by %s", scope, policy.Limit, policy.WindowSeconds, user.Email)
+	respondJSON(w, http.StatusOK, policy)
+}
+
+// ResetRateLimit removes a scope's runtime override, restoring its configured limit
+func (h *AdminHandler) ResetRateLimit(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	scope := chi.URLParam(r, "scope")
+
+	policy, err := h.rateLimits.Reset(r.Context(), scope)
+	if errors.Is(err, ratelimit.ErrUnknownScope) {
+		respondError(w, http.StatusNotFound, "Rate limit scope not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to reset rate limit")
+		return
+	}
+
+	log.Printf("🚦 Rate limit %s reset by %s", scope, user.Email)
+	respondJSON(w, http.StatusOK, policy)
+}
+
+// ListAbuse returns the users and client IPs flagged by abuse detection,
+// highest score first, with the signals that raised their score
+func (h *AdminHandler) ListAbuse(w http.ResponseWriter, r *http.Request) {
+	reports, err := h.abuse.List(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch abuse reports")
+		return
+	}
+
+	respondList(w, reports)
+}
+
+// HoldAbuse puts a subject on hold after review. Held users can't make
+// changes until the hold expires or is cleared.
+func (h *AdminHandler) HoldAbuse(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+
+	subject, err := abuse.ParseSubject(chi.URLParam(r, "subject"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid subject. Use user:<id> or ip:<address>")
+		return
+	}
+
+	report, err := h.abuse.Hold(r.Context(), subject)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to hold subject")
+		return
+	}
+
+	log.Printf("🚨 %s held by %s", subject, user.Email)
+	respondJSON(w, http.StatusOK, report)
+}
+
+// ClearAbuse resets a subject's abuse score and releases any hold, once
+// reviewed as legitimate
+func (h *AdminHandler) ClearAbuse(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+
+	subject, err := abuse.ParseSubject(chi.URLParam(r, "subject"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid subject. Use user:<id> or ip:<address>")
+		return
+	}
+
+	if err := h.abuse.Clear(r.Context(), subject); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to clear subject")
+		return
+	}
+
+	log.Printf("✅ %s cleared by %s", subject, user.Email)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// defaultAnalyticsDays is the range returned when days isn't given
+const defaultAnalyticsDays = 7
+
+// GetAnalytics returns the system-wide time series (posts created, published
+// and failed per hour, signups per day) for the last ?days=N days
+func (h *AdminHandler) GetAnalytics(w http.ResponseWriter, r *http.Request) {
+	days := defaultAnalyticsDays
+	if s := r.URL.Query().Get("days"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > models.MaxAnalyticsDays {
+			respondError(w, http.StatusBadRequest, "days must be between 1 and 90")
+			return
+		}
+		days = n
+	}
+
+	now := time.Now()
+	series, err := h.db.ListSystemMetrics(r.Context(), now.AddDate(0, 0, -days), now)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch analytics")
+		return
+	}
+
+	respondList(w, series)
+}
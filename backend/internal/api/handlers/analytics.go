@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/report"
+)
+
+// defaultReportDays is the range reported when from isn't given, and the
+// days of follower counts returned when days isn't
+const defaultReportDays = 30
+
+// AnalyticsHandler reports the engagement of the workspace's published posts
+// and the follower growth of the user's connected accounts
+type AnalyticsHandler struct {
+	db db.Store
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(database db.Store) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		db: database,
+	}
+}
+
+// Get returns the engagement report of the posts published between ?from
+// and ?to
+func (h *AnalyticsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	rep, ok := h.buildReport(w, r, user)
+	if !ok {
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rep)
+}
+
+// Export downloads the engagement report of the posts published between
+// ?from and ?to as ?format=csv (the default) or pdf
+func (h *AnalyticsHandler) Export(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "pdf" {
+		respondError(w, http.StatusBadRequest, "Invalid format. Must be csv or pdf")
+		return
+	}
+
+	rep, ok := h.buildReport(w, r, user)
+	if !ok {
+		return
+	}
+
+	filename := fmt.Sprintf("analytics-%s-%s.%s", rep.From.Format("20060102"), rep.To.Format("20060102"), format)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	var err error
+	if format == "pdf" {
+		w.Header().Set("Content-Type", "application/pdf")
+		err = report.WritePDF(w, rep)
+	} else {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		err = report.WriteCSV(w, rep)
+	}
+	if err != nil {
+		log.Printf("⚠️ Failed to write analytics export for user %s: %v", user.ID, err)
+	}
+}
+
+// Accounts returns the daily follower counts of the user's connected
+// accounts over the last ?days=N days (UTC), today included
+func (h *AnalyticsHandler) Accounts(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	days := defaultReportDays
+	if s := r.URL.Query().Get("days"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > models.MaxAccountGrowthDays {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("days must be between 1 and %d", models.MaxAccountGrowthDays))
+			return
+		}
+		days = n
+	}
+
+	until := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	counts, err := h.db.ListFollowerCounts(r.Context(), user.ID, until.AddDate(0, 0, -days), until)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch follower counts")
+		return
+	}
+
+	respondList(w, models.NewAccountGrowth(counts))
+}
+
+// buildReport reads the range and builds its report, responding with the
+// error and reporting false if either fails
+func (h *AnalyticsHandler) buildReport(w http.ResponseWriter, r *http.Request, user *models.User) (*models.AnalyticsReport, bool) {
+	from, to, err := parseReportRange(r, time.Now())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	posts, err := h.db.GetHistoryPosts(r.Context(), user.ID, user.WorkspaceID, db.HistoryFilter{
+		Statuses: []models.PostStatus{models.PostStatusPublished},
+		From:     &from,
+		To:       &to,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch analytics")
+		return nil, false
+	}
+
+	return models.NewAnalyticsReport(from, to, posts), true
+}
+
+// parseReportRange reads the from and to query parameters, as RFC3339 times
+// or YYYY-MM-DD days in UTC. To defaults to now and from to 30 days before to.
+func parseReportRange(r *http.Request, now time.Time) (time.Time, time.Time, error) {
+	q := r.URL.Query()
+	from, to := time.Time{}, now
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		s := q.Get(p.name)
+		if s == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			if t, err = time.Parse("2006-01-02", s); err != nil {
+				return from, to, fmt.Errorf("Invalid %s format. Use RFC3339 or YYYY-MM-DD", p.name)
+			}
+		}
+		*p.dst = t
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -defaultReportDays)
+	}
+
+	if !from.Before(to) {
+		return from, to, errors.New("from must be before to")
+	}
+	if to.Sub(from) > models.MaxReportDays*24*time.Hour {
+		return from, to, fmt.Errorf("Range must not exceed %d days", models.MaxReportDays)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/report"
+)
+
+// ExportAuditLog downloads the organization's audit log entries recorded
+// between ?from and ?to as ?format=csv (the default) or json. Entries are
+// streamed as they are read. Only owners may export the log.
+func (h *OrganizationHandler) ExportAuditLog(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, ok := h.authorize(w, r, user, false)
+	if !ok {
+		return
+	}
+	if !h.requireOwner(w, r, orgID, user, "Only owners can export the audit log") {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		respondError(w, http.StatusBadRequest, "Invalid format. Must be csv or json")
+		return
+	}
+
+	from, to, err := parseReportRange(r, time.Now())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("audit-%s-%s.%s", from.Format("20060102"), to.Format("20060102"), format)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	if format == "json" {
+		list := newListWriter(w, r)
+		err := h.db.EachAuditEntry(r.Context(), orgID, from, to, func(e *models.AuditEntry) error {
+			return list.Write(e)
+		})
+		if err != nil {
+			list.Fail(http.StatusInternalServerError, "Failed to export audit log")
+			return
+		}
+		list.Close()
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	out := report.NewAuditCSV(w)
+	err = h.db.EachAuditEntry(r.Context(), orgID, from, to, out.Write)
+	if err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		// Once entries were written the status is already sent, so the
+		// connection is aborted rather than ending what would look like a
+		// complete export
+		if out.Rows() == 0 {
+			respondError(w, http.StatusInternalServerError, "Failed to export audit log")
+			return
+		}
+		log.Printf("❌ Failed to export audit log of organization %s after %d entries, aborting response: %v", orgID, out.Rows(), err)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// recordAudit adds an entry to an organization's audit log. The change has
+// already been made, so a failure is logged rather than failing the request.
+func recordAudit(ctx context.Context, database db.Store, actor *models.User, orgID uuid.UUID, action models.AuditAction, targetID *uuid.UUID, details map[string]any) {
+	err := database.RecordAuditEntry(ctx, db.NewAuditEntry{
+		OrgID:      orgID,
+		ActorID:    actor.ID,
+		ActorEmail: actor.Email,
+		Action:     action,
+		TargetID:   targetID,
+		Details:    details,
+	})
+	if err != nil {
+		log.Printf("⚠️ Failed to record %s in the audit log of organization %s: %v", action, orgID, err)
+	}
+}
+
+// postAuditDetails describes a post in its audit log entries; the content is
+// left out of the log
+func postAuditDetails(post *models.Post) map[string]any {
+	return map[string]any{
+		"channel":      post.Channel,
+		"status":       post.Status,
+		"scheduled_at": post.ScheduledAt,
+	}
+}
+
+// recordPostAudit adds a change to a post to its organization's audit log;
+// personal posts have none
+func recordPostAudit(ctx context.Context, database db.Store, actor *models.User, post *models.Post, action models.AuditAction, details map[string]any) {
+	if post.OrgID == nil {
+		return
+	}
+	recordAudit(ctx, database, actor, *post.OrgID, action, &post.ID, details)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestOrganizationHandler_ExportAuditLog(t *testing.T) {
+	owner := &models.User{ID: uuid.New()}
+	admin := &models.User{ID: uuid.New()}
+	orgID := uuid.New()
+	entry := &models.AuditEntry{ID: uuid.New(), OrgID: orgID, Action: models.AuditMemberRemove, CreatedAt: time.Now()}
+	var failWith error
+
+	store := &dbmock.Store{
+		GetMemberRoleFunc: func(ctx context.Context, org, userID uuid.UUID) (models.OrgRole, error) {
+			switch userID {
+			case owner.ID:
+				return models.OrgRoleOwner, nil
+			case admin.ID:
+				return models.OrgRoleAdmin, nil
+			}
+			return "", nil
+		},
+		EachAuditEntryFunc: func(ctx context.Context, org uuid.UUID, from, to time.Time, fn func(*models.AuditEntry) error) error {
+			if from.Format("2006-01-02") != "2024-05-01" || to.Format("2006-01-02") != "2024-06-01" {
+				t.Errorf("range = %s to %s", from, to)
+			}
+			if failWith != nil {
+				return failWith
+			}
+			return fn(entry)
+		},
+	}
+	h := NewOrganizationHandler(store, models.RetentionPolicy{})
+
+	r := chi.NewRouter()
+	r.Get("/api/organizations/{id}/audit/export", h.ExportAuditLog)
+	get := func(user *models.User, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/organizations/"+orgID.String()+"/audit/export?from=2024-05-01&to=2024-06-01"+query, nil)
+		req = req.WithContext(SetUserInContext(req.Context(), user))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get(owner, "")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Disposition") != `attachment; filename="audit-20240501-20240601.csv"` {
+		t.Fatalf("CSV export = %d %q", rec.Code, rec.Header().Get("Content-Disposition"))
+	}
+	rows, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[1][0] != entry.ID.String() || rows[1][4] != string(models.AuditMemberRemove) {
+		t.Errorf("CSV rows = %v", rows)
+	}
+
+	rec = get(owner, "&format=json")
+	var list struct {
+		Data  []models.AuditEntry `json:"data"`
+		Total int                 `json:"total"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusOK || list.Total != 1 || list.Data[0].ID != entry.ID {
+		t.Errorf("JSON export = %d %s", rec.Code, rec.Body)
+	}
+
+	if rec := get(admin, ""); rec.Code != http.StatusForbidden {
+		t.Errorf("admin export status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	if rec := get(&models.User{ID: uuid.New()}, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("non-member export status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if rec := get(owner, "&format=xml"); rec.Code != http.StatusBadRequest {
+		t.Errorf("xml export status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	failWith = errors.New("connection reset")
+	if rec := get(owner, ""); rec.Code != http.StatusInternalServerError {
+		t.Errorf("failed export status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+}
@@ -8,26 +8,35 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/scheduler/backend/internal/abuse"
 	"github.com/scheduler/backend/internal/auth"
 	"github.com/scheduler/backend/internal/db"
 	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/ratelimit"
+	"github.com/scheduler/backend/internal/tenant"
 )
 
 // AuthHandler handles authentication endpoints
 type AuthHandler struct {
-	db            *db.DB
+	db            db.Store
 	jwtService    *auth.JWTService
 	blacklist     *auth.Blacklist
-	secureCookies bool
+	domainPolicy  *auth.DomainPolicy
+	abuse         *abuse.Detector
+	cookies       AuthCookies
+	inviteOnly    bool // Registration requires an invite code
 }
 
 // NewAuthHandler creates a new auth handler
-func NewAuthHandler(database *db.DB, jwtService *auth.JWTService, blacklist *auth.Blacklist, secureCookies bool) *AuthHandler {
+func NewAuthHandler(database db.Store, jwtService *auth.JWTService, blacklist *auth.Blacklist, domainPolicy *auth.DomainPolicy, detector *abuse.Detector, cookies AuthCookies, inviteOnly bool) *AuthHandler {
 	return &AuthHandler{
 		db:            database,
 		jwtService:    jwtService,
 		blacklist:     blacklist,
-		secureCookies: secureCookies,
+		domainPolicy:  domainPolicy,
+		abuse:         detector,
+		cookies:       cookies,
+		inviteOnly:    inviteOnly,
 	}
 }
 
@@ -55,6 +64,24 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Reject blocked and disposable email domains
+	if h.domainPolicy != nil {
+		switch err := h.domainPolicy.Check(req.Email); err {
+		case nil:
+		case auth.ErrDisposableEmailDomain:
+			respondErrorCode(w, http.StatusBadRequest, "disposable_email", "Disposable email addresses are not allowed. Please use a permanent email address")
+			return
+		default:
+			respondErrorCode(w, http.StatusBadRequest, "email_domain_blocked", "Registration is not allowed for this email domain")
+			return
+		}
+	}
+
+	if h.inviteOnly && req.InviteCode == "" {
+		respondErrorCode(w, http.StatusForbidden, "invite_required", "Registration is invite-only. An invite code is required")
+		return
+	}
+
 	// Check if user already exists
 	existing, err := h.db.GetUserByEmail(r.Context(), req.Email)
 	if err != nil {
@@ -73,12 +100,24 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create user
-	user, err := h.db.CreateUser(r.Context(), req.Email, passwordHash)
+	// Create user, redeeming the invite code while registration is invite-only
+	var user *models.User
+	if h.inviteOnly {
+		user, err = h.db.CreateUserWithInvite(r.Context(), req.Email, passwordHash, auth.HashInviteCode(req.InviteCode))
+	} else {
+		user, err = h.db.CreateUser(r.Context(), req.Email, passwordHash)
+	}
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to create user")
 		return
 	}
+	if user == nil {
+		respondErrorCode(w, http.StatusForbidden, "invite_invalid", "Invite code is invalid, expired or used up")
+		return
+	}
+
+	// Count towards signup bursts from the client's IP
+	h.abuse.RecordSignup(r.Context(), ratelimit.ClientIP(r))
 
 	// Generate tokens
 	tokens, err := h.jwtService.GenerateTokenPair(user.ID, user.Email)
@@ -88,7 +127,7 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Set cookies
-	h.setAuthCookies(w, tokens)
+	h.setAuthCookies(w, r, tokens)
 
 	respondJSON(w, http.StatusCreated, models.AuthResponse{
 		User: user.ToResponse(),
@@ -127,14 +166,14 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Generate tokens
-	tokens, err := h.jwtService.GenerateTokenPair(user.ID, user.Email)
+	tokens, err := h.jwtService.GenerateWorkspaceTokenPair(user.ID, user.Email, nil, req.RememberMe)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to generate tokens")
 		return
 	}
 
 	// Set cookies
-	h.setAuthCookies(w, tokens)
+	h.setAuthCookies(w, r, tokens)
 
 	respondJSON(w, http.StatusOK, models.AuthResponse{
 		User: user.ToResponse(),
@@ -143,11 +182,15 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 
 // Logout handles user logout
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
-	// Get refresh token from cookie and blacklist it
-	if cookie, err := r.Cookie("refresh_token"); err == nil {
+	// Blacklist both tokens, so neither works if it was copied before logout
+	for _, name := range []string{"access_token", "refresh_token"} {
+		cookie, err := r.Cookie(name)
+		if err != nil {
+			continue
+		}
 		claims, err := h.jwtService.ValidateToken(cookie.Value)
 		if err == nil && claims.ID != "" {
-			// Blacklist the refresh token
+			// Blacklist until the token would have expired anyway
 			ttl := time.Until(claims.ExpiresAt.Time)
 			if ttl > 0 {
 				_ = h.blacklist.Add(r.Context(), claims.ID, ttl)
@@ -156,7 +199,7 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Clear cookies
-	h.clearAuthCookies(w)
+	h.clearAuthCookies(w, r)
 
 	respondJSON(w, http.StatusOK, map[string]string{"message": "Logged out successfully"})
 }
@@ -172,7 +215,7 @@ func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 	// Validate refresh token
 	claims, err := h.jwtService.ValidateToken(cookie.Value)
 	if err != nil {
-		h.clearAuthCookies(w)
+		h.clearAuthCookies(w, r)
 		respondError(w, http.StatusUnauthorized, "Invalid refresh token")
 		return
 	}
@@ -180,7 +223,7 @@ func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 	// Check if blacklisted
 	isBlacklisted, err := h.blacklist.IsBlacklisted(r.Context(), claims.ID)
 	if err != nil || isBlacklisted {
-		h.clearAuthCookies(w)
+		h.clearAuthCookies(w, r)
 		respondError(w, http.StatusUnauthorized, "Token revoked")
 		return
 	}
@@ -193,21 +236,21 @@ func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 
 	// Get user to ensure they still exist
 	user, err := h.db.GetUserByID(r.Context(), claims.UserID)
-	if err != nil || user == nil {
-		h.clearAuthCookies(w)
+	if err != nil || user == nil || user.TenantID != tenant.IDFromContext(r.Context()) {
+		h.clearAuthCookies(w, r)
 		respondError(w, http.StatusUnauthorized, "User not found")
 		return
 	}
 
-	// Generate new tokens
-	tokens, err := h.jwtService.GenerateTokenPair(user.ID, user.Email)
+	// Generate new tokens, keeping the selected workspace and remember me
+	tokens, err := h.jwtService.GenerateWorkspaceTokenPair(user.ID, user.Email, claims.WorkspaceID, claims.RememberMe)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to generate tokens")
 		return
 	}
 
 	// Set new cookies
-	h.setAuthCookies(w, tokens)
+	h.setAuthCookies(w, r, tokens)
 
 	respondJSON(w, http.StatusOK, models.AuthResponse{
 		User: user.ToResponse(),
@@ -228,45 +271,13 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 }
 
 // setAuthCookies sets the authentication cookies
-func (h *AuthHandler) setAuthCookies(w http.ResponseWriter, tokens *auth.TokenPair) {
-	http.SetCookie(w, &http.Cookie{
-		Name:     "access_token",
-		Value:    tokens.AccessToken,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   h.secureCookies,
-		SameSite: http.SameSiteStrictMode,
-		MaxAge:   int(h.jwtService.GetAccessTokenTTL().Seconds()),
-	})
-
-	http.SetCookie(w, &http.Cookie{
-		Name:     "refresh_token",
-		Value:    tokens.RefreshToken,
-		Path:     "/api/auth/refresh",
-		HttpOnly: true,
-		Secure:   h.secureCookies,
-		SameSite: http.SameSiteStrictMode,
-		MaxAge:   int(h.jwtService.GetRefreshTokenTTL().Seconds()),
-	})
+func (h *AuthHandler) setAuthCookies(w http.ResponseWriter, r *http.Request, tokens *auth.TokenPair) {
+	h.cookies.write(w, r, h.jwtService, tokens)
 }
 
 // clearAuthCookies clears the authentication cookies
-func (h *AuthHandler) clearAuthCookies(w http.ResponseWriter) {
-	http.SetCookie(w, &http.Cookie{
-		Name:     "access_token",
-		Value:    "",
-		Path:     "/",
-		HttpOnly: true,
-		MaxAge:   -1,
-	})
-
-	http.SetCookie(w, &http.Cookie{
-		Name:     "refresh_token",
-		Value:    "",
-		Path:     "/api/auth/refresh",
-		HttpOnly: true,
-		MaxAge:   -1,
-	})
+func (h *AuthHandler) clearAuthCookies(w http.ResponseWriter, r *http.Request) {
+	h.cookies.clear(w, r)
 }
 
 // isValidEmail performs RFC 5322 compliant email validation
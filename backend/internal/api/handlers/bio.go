@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/auth"
+	"github.com/scheduler/backend/internal/bio"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/feed"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// bioPageTitle is the heading of every bio page
+const bioPageTitle = "Links"
+
+// BioHandler serves users' public link-in-bio pages and counts clicks on
+// their links
+type BioHandler struct {
+	db db.Store
+}
+
+// NewBioHandler creates a new bio page handler
+func NewBioHandler(database db.Store) *BioHandler {
+	return &BioHandler{
+		db: database,
+	}
+}
+
+// Serve renders the bio page for the token in the URL in the format given
+// by the route ("html" or "json")
+func (h *BioHandler) Serve(format string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := chi.URLParam(r, "token")
+		user, ok := h.lookup(w, r, token)
+		if !ok {
+			return
+		}
+
+		links, err := h.db.ListBioLinks(r.Context(), user.ID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch bio page")
+			return
+		}
+
+		page := models.BioPage{Title: bioPageTitle, AvatarURL: user.AvatarURL(), Links: []models.BioPageLink{}}
+		clickBase := requestOrigin(r) + r.URL.Path[:strings.Index(r.URL.Path, token)+len(token)] + "/links/"
+		for _, l := range links {
+			page.Links = append(page.Links, models.BioPageLink{Title: l.Title, URL: clickBase + l.ID.String(), Post: l.Post})
+		}
+
+		var body []byte
+		contentType := "application/json; charset=utf-8"
+		if format == "html" {
+			body, err = bio.HTML(page)
+			contentType = "text/html; charset=utf-8"
+		} else {
+			body, err = json.Marshal(page)
+		}
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to render bio page")
+			return
+		}
+
+		etag := feed.ETag(body)
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "public, max-age=60")
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}
+}
+
+// Click counts a click on a bio page link and redirects to it
+func (h *BioHandler) Click(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.lookup(w, r, chi.URLParam(r, "token"))
+	if !ok {
+		return
+	}
+
+	linkID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Link not found")
+		return
+	}
+
+	url, err := h.db.RecordBioClick(r.Context(), user.ID, linkID)
+	if err != nil {
+		log.Printf("⚠️ Failed to record click on bio link %s: %v", linkID, err)
+		respondError(w, http.StatusInternalServerError, "Failed to follow link")
+		return
+	}
+	if url == nil {
+		respondError(w, http.StatusNotFound, "Link not found")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, *url, http.StatusFound)
+}
+
+// lookup finds the user whose bio page token is in the URL, responding with
+// an error and returning false if there is none
+func (h *BioHandler) lookup(w http.ResponseWriter, r *http.Request, token string) (*models.User, bool) {
+	user, err := h.db.GetUserByBioToken(r.Context(), auth.HashURLToken(token))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to look up bio page")
+		return nil, false
+	}
+	if user == nil {
+		respondError(w, http.StatusNotFound, "Bio page not found")
+		return nil, false
+	}
+	return user, true
+}
+
+// requestOrigin returns the scheme and host the request was made to
+func requestOrigin(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
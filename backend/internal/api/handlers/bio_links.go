@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/auth"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// RotateBio generates a new bio page token, replacing any previous one
+func (h *AccountHandler) RotateBio(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	token, err := auth.GenerateURLToken()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to generate bio page token")
+		return
+	}
+	hash := auth.HashURLToken(token)
+	if err := h.db.SetBioToken(r.Context(), user.ID, &hash); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to save bio page token")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, models.BioTokenResponse{
+		Token:    token,
+		HTMLPath: "/api/bio/" + token,
+		JSONPath: "/api/bio/" + token + "/json",
+	})
+}
+
+// DeleteBio disables the user's bio page; its links are kept
+func (h *AccountHandler) DeleteBio(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	if err := h.db.SetBioToken(r.Context(), user.ID, nil); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to disable bio page")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListBioLinks returns the links on the user's bio page with their clicks
+func (h *AccountHandler) ListBioLinks(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	links, err := h.db.ListBioLinks(r.Context(), user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch bio links")
+		return
+	}
+
+	if links == nil {
+		links = []*models.BioLink{}
+	}
+
+	respondList(w, links)
+}
+
+// CreateBioLink adds a link to the end of the user's bio page, either to any
+// URL or for one of their published posts
+func (h *AccountHandler) CreateBioLink(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	var req models.CreateBioLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.PostID != nil {
+		post, err := h.db.GetPostByID(r.Context(), *req.PostID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch post")
+			return
+		}
+		if post == nil || post.UserID != user.ID {
+			respondError(w, http.StatusNotFound, "Post not found")
+			return
+		}
+		if post.Status != models.PostStatusPublished {
+			respondError(w, http.StatusBadRequest, "Only published posts can be linked")
+			return
+		}
+		req.FillFromPost(post)
+	}
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	link, err := h.db.CreateBioLink(r.Context(), user.ID, req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to save bio link")
+		return
+	}
+	if link == nil {
+		respondError(w, http.StatusConflict, fmt.Sprintf("At most %d bio links are allowed", models.MaxBioLinks))
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, link)
+}
+
+// ReorderBioLinks sets the order of the links on the user's bio page
+func (h *AccountHandler) ReorderBioLinks(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	var req models.ReorderBioLinksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ok, err := h.db.ReorderBioLinks(r.Context(), user.ID, req.LinkIDs)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to reorder bio links")
+		return
+	}
+	if !ok {
+		respondError(w, http.StatusBadRequest, "link_ids must list each of your bio links once")
+		return
+	}
+
+	h.ListBioLinks(w, r)
+}
+
+// DeleteBioLink removes a link from the user's bio page
+func (h *AccountHandler) DeleteBioLink(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid link ID")
+		return
+	}
+
+	deleted, err := h.db.DeleteBioLink(r.Context(), user.ID, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete bio link")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "Bio link not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
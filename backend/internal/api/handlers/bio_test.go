@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/auth"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestBioHandler(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	link := &models.BioLink{ID: uuid.New(), UserID: user.ID, Title: "Shop", URL: "https://shop.example.com/"}
+	clicks := 0
+
+	store := &dbmock.Store{
+		GetUserByBioTokenFunc: func(ctx context.Context, tokenHash string) (*models.User, error) {
+			if tokenHash == auth.HashURLToken("tok") {
+				return user, nil
+			}
+			return nil, nil
+		},
+		ListBioLinksFunc: func(ctx context.Context, userID uuid.UUID) ([]*models.BioLink, error) {
+			return []*models.BioLink{link}, nil
+		},
+		RecordBioClickFunc: func(ctx context.Context, userID, id uuid.UUID) (*string, error) {
+			if userID != user.ID || id != link.ID {
+				return nil, nil
+			}
+			clicks++
+			return &link.URL, nil
+		},
+	}
+	h := NewBioHandler(store)
+
+	r := chi.NewRouter()
+	r.Route("/api/bio/{token}", func(r chi.Router) {
+		r.Get("/", h.Serve("html"))
+		r.Get("/json", h.Serve("json"))
+		r.Get("/links/{id}", h.Click)
+	})
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/api/bio/tok/json")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET json status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var page models.BioPage
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+		t.Fatal(err)
+	}
+	want := "http://example.com/api/bio/tok/links/" + link.ID.String()
+	if len(page.Links) != 1 || page.Links[0].URL != want {
+		t.Fatalf("links = %+v, want the click redirect %s", page.Links, want)
+	}
+
+	if rec := get("/api/bio/tok"); rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Errorf("GET html = %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	rec = get("/api/bio/tok/links/" + link.ID.String())
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != link.URL || clicks != 1 {
+		t.Errorf("click = %d to %q with %d clicks counted, want a redirect to %s", rec.Code, rec.Header().Get("Location"), clicks, link.URL)
+	}
+
+	for _, path := range []string{"/api/bio/nope/json", "/api/bio/tok/links/" + uuid.NewString(), "/api/bio/tok/links/nope"} {
+		if rec := get(path); rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s status = %d, want %d", path, rec.Code, http.StatusNotFound)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/oauth"
+)
+
+// platformHealthWindow is how far back publish outcomes are considered for platform health
+const platformHealthWindow = time.Hour
+
+// ChannelOAuth configures connecting channels through their platforms' OAuth
+type ChannelOAuth struct {
+	Providers   map[models.Channel]*oauth.Provider
+	StateSecret string // Signs the state carried through the platform
+	ReturnURL   string // Where the callback sends the browser, with the outcome in the query
+}
+
+// ChannelHandler handles channel connection endpoints
+type ChannelHandler struct {
+	db    db.Store
+	oauth ChannelOAuth
+}
+
+// NewChannelHandler creates a new channel handler
+func NewChannelHandler(database db.Store, channelOAuth ChannelOAuth) *ChannelHandler {
+	return &ChannelHandler{
+		db:    database,
+		oauth: channelOAuth,
+	}
+}
+
+// List returns the user's connected channel accounts
+func (h *ChannelHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	conns, err := h.db.GetChannelConnections(r.Context(), user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch channel connections")
+		return
+	}
+
+	if conns == nil {
+		conns = []*models.ChannelConnection{}
+	}
+
+	respondList(w, conns)
+}
+
+// Connect stores (or replaces) the access token for a channel account
+func (h *ChannelHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	channel := chi.URLParam(r, "channel")
+	if !models.IsValidChannel(channel) {
+		respondError(w, http.StatusBadRequest, models.InvalidChannelMessage())
+		return
+	}
+
+	var req models.ConnectChannelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	req.AccessToken = trimString(req.AccessToken)
+	if req.AccessToken == "" {
+		respondError(w, http.StatusBadRequest, "access_token is required")
+		return
+	}
+	if err := req.Validate(models.Channel(channel)); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var expiresAt *time.Time
+	if req.TokenExpiresAt != nil {
+		parsed, err := time.Parse(time.RFC3339, *req.TokenExpiresAt)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid token_expires_at format. Use RFC3339")
+			return
+		}
+		expiresAt = &parsed
+	}
+
+	conn, err := h.db.UpsertChannelConnection(r.Context(), user.ID, models.Channel(channel), db.ChannelCredentials{
+		AccountName:    req.AccountName,
+		AccessToken:    req.AccessToken,
+		TokenExpiresAt: expiresAt,
+		Target:         req.Target,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to connect channel")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, conn)
+}
+
+// Authorize starts connecting a channel through its platform's OAuth flow,
+// returning the platform URL to send the user to
+func (h *ChannelHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	channel := chi.URLParam(r, "channel")
+	if !models.IsValidChannel(channel) {
+		respondError(w, http.StatusBadRequest, models.InvalidChannelMessage())
+		return
+	}
+	provider, ok := h.oauth.Providers[models.Channel(channel)]
+	if !ok {
+		respondError(w, http.StatusBadRequest, "Channel does not connect through OAuth; use PUT /api/channels/"+channel)
+		return
+	}
+	if !provider.Configured() {
+		respondError(w, http.StatusServiceUnavailable, "OAuth is not configured for "+channel)
+		return
+	}
+
+	state, err := oauth.SignState(h.oauth.StateSecret, oauth.State{
+		UserID:  user.ID,
+		Channel: channel,
+		Expires: time.Now().Add(oauth.StateTTL),
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to start connection")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, models.AuthorizeChannelResponse{
+		AuthorizeURL: provider.AuthCodeURL(state),
+	})
+}
+
+// Callback completes an OAuth connection when the platform redirects back.
+// The browser arrives without our cookies, so the user is taken from the
+// signed state. It is then sent on to the app with connected=<channel>, or
+// connect_error and channel, in the query.
+func (h *ChannelHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	channel := models.Channel(chi.URLParam(r, "channel"))
+	provider, ok := h.oauth.Providers[channel]
+	if !ok {
+		respondError(w, http.StatusNotFound, "Channel does not connect through OAuth")
+		return
+	}
+
+	q := r.URL.Query()
+	state, err := oauth.VerifyState(h.oauth.StateSecret, q.Get("state"), time.Now())
+	if err != nil || state.Channel != string(channel) {
+		h.returnTo(w, r, channel, "The connection request expired, please try again")
+		return
+	}
+	if q.Get("error") != "" {
+		h.returnTo(w, r, channel, "Access was not granted")
+		return
+	}
+
+	user, err := h.db.GetUserByID(r.Context(), state.UserID)
+	if err != nil || user == nil || user.Suspended() {
+		h.returnTo(w, r, channel, "Failed to connect "+string(channel))
+		return
+	}
+
+	token, err := provider.Exchange(r.Context(), q.Get("code"), time.Now())
+	if err != nil {
+		log.Printf("⚠️ Failed to exchange %s authorization code for user %s: %v", channel, user.ID, err)
+		h.returnTo(w, r, channel, "Failed to connect "+string(channel))
+		return
+	}
+
+	creds := db.ChannelCredentials{
+		AccessToken:    token.AccessToken,
+		TokenExpiresAt: token.ExpiresAt,
+	}
+	if token.RefreshToken != "" {
+		creds.RefreshToken = &token.RefreshToken
+	}
+	if name, err := provider.AccountName(r.Context(), token.AccessToken); err != nil {
+		log.Printf("⚠️ Failed to look up %s account name for user %s: %v", channel, user.ID, err)
+	} else if name != "" {
+		creds.AccountName = &name
+	}
+
+	if _, err := h.db.UpsertChannelConnection(r.Context(), user.ID, channel, creds); err != nil {
+		log.Printf("❌ Failed to save %s connection for user %s: %v", channel, user.ID, err)
+		h.returnTo(w, r, channel, "Failed to connect "+string(channel))
+		return
+	}
+
+	log.Printf("🔗 Connected %s for user %s", channel, user.ID)
+	h.returnTo(w, r, channel, "")
+}
+
+// returnTo redirects the browser back to the app after an OAuth callback,
+// reporting errMsg if the connection failed
+func (h *ChannelHandler) returnTo(w http.ResponseWriter, r *http.Request, channel models.Channel, errMsg string) {
+	target, err := url.Parse(h.oauth.ReturnURL)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Invalid OAuth return URL")
+		return
+	}
+
+	q := target.Query()
+	if errMsg == "" {
+		q.Set("connected", string(channel))
+	} else {
+		q.Set("connect_error", errMsg)
+		q.Set("channel", string(channel))
+	}
+	target.RawQuery = q.Encode()
+
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+// Disconnect removes the user's connection for a channel
+func (h *ChannelHandler) Disconnect(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	channel := chi.URLParam(r, "channel")
+	if !models.IsValidChannel(channel) {
+		respondError(w, http.StatusBadRequest, models.InvalidChannelMessage())
+		return
+	}
+
+	deleted, err := h.db.DeleteChannelConnection(r.Context(), user.ID, models.Channel(channel))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to disconnect channel")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "Channel not connected")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Status reports token validity, last successful publish and platform health for every channel
+func (h *ChannelHandler) Status(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	conns, err := h.db.GetChannelConnections(r.Context(), user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch channel connections")
+		return
+	}
+
+	now := time.Now()
+	activity, err := h.db.GetChannelActivity(r.Context(), now.Add(-platformHealthWindow))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch platform health")
+		return
+	}
+
+	byChannel := make(map[models.Channel]*models.ChannelConnection, len(conns))
+	for _, conn := range conns {
+		byChannel[conn.Channel] = conn
+	}
+
+	statuses := make([]models.ChannelStatus, 0, len(models.ValidChannels()))
+	for _, channel := range models.ValidChannels() {
+		status := models.ChannelStatus{
+			Channel:     channel,
+			TokenStatus: models.TokenStatusMissing,
+			Platform:    models.NewPlatformHealth(activity[channel].Published, activity[channel].Failing),
+		}
+		if conn, ok := byChannel[channel]; ok {
+			status.Connected = true
+			status.AccountName = conn.AccountName
+			status.TokenStatus = conn.TokenStatusAt(now)
+			status.TokenExpiresAt = conn.TokenExpiresAt
+			status.LastPublishedAt = conn.LastPublishedAt
+			status.LastError = conn.LastError
+		}
+		statuses = append(statuses, status)
+	}
+
+	respondList(w, statuses)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/oauth"
+)
+
+func TestChannelHandler_Callback(t *testing.T) {
+	const secret = "state-secret"
+	user := &models.User{ID: uuid.New()}
+
+	platform := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "access",
+			"refresh_token": "refresh",
+			"expires_in":    3600,
+		})
+	}))
+	defer platform.Close()
+
+	provider := oauth.Reddit("client", "secret", "https://api.example.com/api/channels/reddit/callback")
+	provider.TokenURL = platform.URL
+	provider.Identity = func(ctx context.Context, client *http.Client, accessToken string) (string, error) {
+		return "u/scheduler", nil
+	}
+
+	var saved *db.ChannelCredentials
+	store := &dbmock.Store{
+		GetUserByIDFunc: func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+			if id != user.ID {
+				return nil, nil
+			}
+			return user, nil
+		},
+		UpsertChannelConnectionFunc: func(ctx context.Context, userID uuid.UUID, channel models.Channel, creds db.ChannelCredentials) (*models.ChannelConnection, error) {
+			saved = &creds
+			return &models.ChannelConnection{UserID: userID, Channel: channel}, nil
+		},
+	}
+	h := NewChannelHandler(store, ChannelOAuth{
+		Providers:   map[models.Channel]*oauth.Provider{models.ChannelReddit: provider},
+		StateSecret: secret,
+		ReturnURL:   "http://localhost:3000/dashboard?tab=channels",
+	})
+	r := chi.NewRouter()
+	r.Get("/channels/{channel}/callback", h.Callback)
+
+	callback := func(state string) url.Values {
+		req := httptest.NewRequest(http.MethodGet, "/channels/reddit/callback?code=abc&state="+url.QueryEscape(state), nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusFound {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusFound)
+		}
+		location, err := url.Parse(rec.Header().Get("Location"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if location.Path != "/dashboard" || location.Query().Get("tab") != "channels" {
+			t.Errorf("redirected to %s, want the return URL", location)
+		}
+		return location.Query()
+	}
+
+	state, err := oauth.SignState(secret, oauth.State{UserID: user.ID, Channel: "reddit", Expires: time.Now().Add(time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q := callback(state); q.Get("connected") != "reddit" {
+		t.Errorf("query = %v, want connected=reddit", q)
+	}
+	if saved == nil {
+		t.Fatal("connection not saved")
+	}
+	if saved.AccessToken != "access" || saved.RefreshToken == nil || *saved.RefreshToken != "refresh" {
+		t.Errorf("saved tokens = %+v", saved)
+	}
+	if saved.AccountName == nil || *saved.AccountName != "u/scheduler" {
+		t.Errorf("saved account name = %v, want u/scheduler", saved.AccountName)
+	}
+
+	// A state for another channel or signed with another secret is refused
+	saved = nil
+	other, _ := oauth.SignState(secret, oauth.State{UserID: user.ID, Channel: "linkedin", Expires: time.Now().Add(time.Minute)})
+	forged, _ := oauth.SignState("other", oauth.State{UserID: user.ID, Channel: "reddit", Expires: time.Now().Add(time.Minute)})
+	for _, state := range []string{other, forged, ""} {
+		if q := callback(state); q.Get("connect_error") == "" || q.Get("channel") != "reddit" {
+			t.Errorf("query = %v, want connect_error", q)
+		}
+	}
+	if saved != nil {
+		t.Error("connection saved for an invalid state")
+	}
+}
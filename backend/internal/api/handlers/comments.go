@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+)
+
+// CommentHandler handles review comments on posts
+type CommentHandler struct {
+	db       db.Store
+	notifier *notifier.Notifier
+}
+
+// NewCommentHandler creates a new comment handler
+func NewCommentHandler(database db.Store, n *notifier.Notifier) *CommentHandler {
+	return &CommentHandler{
+		db:       database,
+		notifier: n,
+	}
+}
+
+// List returns a post's comments as threads, oldest first
+func (h *CommentHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	post, ok := h.loadPost(w, r, user)
+	if !ok {
+		return
+	}
+
+	comments, err := h.db.ListComments(r.Context(), post.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch comments")
+		return
+	}
+
+	respondList(w, models.BuildCommentThreads(comments))
+}
+
+// Create adds a comment to a post, or a reply when parent_id is set
+func (h *CommentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	post, ok := h.loadPost(w, r, user)
+	if !ok {
+		return
+	}
+
+	var req models.CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.ParentID != nil {
+		exists, err := h.db.CommentExists(r.Context(), post.ID, *req.ParentID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch comment")
+			return
+		}
+		if !exists {
+			respondError(w, http.StatusBadRequest, "Parent comment not found on this post")
+			return
+		}
+	}
+
+	comment, err := h.db.CreateComment(r.Context(), post.ID, user.ID, req.ParentID, req.Body)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create comment")
+		return
+	}
+
+	notifyPostAudience(r.Context(), h.db, h.notifier, post, notifier.UpdateTypeComment, user.ID)
+
+	respondJSON(w, http.StatusCreated, comment)
+}
+
+// Delete removes one of the user's own comments and its replies
+func (h *CommentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	post, ok := h.loadPost(w, r, user)
+	if !ok {
+		return
+	}
+
+	commentID, err := uuid.Parse(chi.URLParam(r, "commentID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	deleted, err := h.db.DeleteComment(r.Context(), post.ID, commentID, user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete comment")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "Comment not found or not yours")
+		return
+	}
+
+	notifyPostAudience(r.Context(), h.db, h.notifier, post, notifier.UpdateTypeComment, user.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// loadPost fetches the post from the URL and checks the user can see it: their
+// own posts, and organization posts in their current workspace
+func (h *CommentHandler) loadPost(w http.ResponseWriter, r *http.Request, user *models.User) (*models.Post, bool) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return nil, false
+	}
+
+	post, err := h.db.GetPostByID(r.Context(), postID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch post")
+		return nil, false
+	}
+	if post == nil {
+		respondError(w, http.StatusNotFound, "Post not found")
+		return nil, false
+	}
+	if post.UserID != user.ID && !post.InWorkspace(user.WorkspaceID) {
+		respondError(w, http.StatusForbidden, "Access denied")
+		return nil, false
+	}
+
+	return post, true
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestCommentHandler_List(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	own := &models.Post{ID: uuid.New(), UserID: user.ID}
+	other := &models.Post{ID: uuid.New(), UserID: uuid.New()}
+
+	store := &dbmock.Store{
+		GetPostByIDFunc: func(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+			switch id {
+			case own.ID:
+				return own, nil
+			case other.ID:
+				return other, nil
+			}
+			return nil, nil
+		},
+		ListCommentsFunc: func(ctx context.Context, postID uuid.UUID) ([]*models.Comment, error) {
+			return []*models.Comment{}, nil
+		},
+	}
+	h := NewCommentHandler(store, nil)
+
+	r := chi.NewRouter()
+	r.Get("/posts/{id}/comments", h.List)
+
+	tests := []struct {
+		name string
+		user *models.User
+		path string
+		want int
+	}{
+		{"own post", user, "/posts/" + own.ID.String() + "/comments", http.StatusOK},
+		{"another user's post", user, "/posts/" + other.ID.String() + "/comments", http.StatusForbidden},
+		{"unknown post", user, "/posts/" + uuid.NewString() + "/comments", http.StatusNotFound},
+		{"invalid post ID", user, "/posts/nope/comments", http.StatusBadRequest},
+		{"not signed in", nil, "/posts/" + own.ID.String() + "/comments", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.user != nil {
+				req = req.WithContext(SetUserInContext(req.Context(), tt.user))
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
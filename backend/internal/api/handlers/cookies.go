@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/scheduler/backend/internal/auth"
+)
+
+// defaultAPIPrefix is where the API is mounted when a request carries no routing context
+const defaultAPIPrefix = "/api"
+
+// AuthCookies configures the session cookies. A frontend on another site than
+// the API needs SameSite "none", which browsers only accept on Secure cookies.
+type AuthCookies struct {
+	Secure   bool
+	SameSite string // strict, lax or none
+	Domain   string // Empty for a host-only cookie
+	Path     string // Prefix the API is served under, "/" unless behind a path-rewriting proxy
+}
+
+func (c AuthCookies) sameSite() http.SameSite {
+	switch c.SameSite {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
+
+func (c AuthCookies) accessPath() string {
+	if c.Path == "" {
+		return "/"
+	}
+	return c.Path
+}
+
+// refreshPath limits the refresh token to the refresh endpoint of the API the
+// request came through, so a versioned mount such as /api/v1 gets its own
+func (c AuthCookies) refreshPath(r *http.Request) string {
+	return path.Join(c.accessPath(), apiPrefix(r), "auth/refresh")
+}
+
+// apiPrefix returns the prefix the request's API routes are mounted under,
+// taken from the first pattern chi matched, e.g. "/api/*"
+func apiPrefix(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || len(rctx.RoutePatterns) == 0 {
+		return defaultAPIPrefix
+	}
+	mount := rctx.RoutePatterns[0]
+	if !strings.HasSuffix(mount, "/*") || mount == "/*" {
+		return defaultAPIPrefix
+	}
+	return strings.TrimSuffix(mount, "/*")
+}
+
+// write sets the access and refresh token cookies
+func (c AuthCookies) write(w http.ResponseWriter, r *http.Request, jwtService *auth.JWTService, tokens *auth.TokenPair) {
+	http.SetCookie(w, c.cookie("access_token", tokens.AccessToken, c.accessPath(), int(jwtService.GetAccessTokenTTL().Seconds())))
+	http.SetCookie(w, c.cookie("refresh_token", tokens.RefreshToken, c.refreshPath(r), int(tokens.RefreshTTL.Seconds())))
+}
+
+// clear expires the access and refresh token cookies. Browsers only replace a
+// cookie with the same domain and path, so they match those written.
+func (c AuthCookies) clear(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, c.cookie("access_token", "", c.accessPath(), -1))
+	http.SetCookie(w, c.cookie("refresh_token", "", c.refreshPath(r), -1))
+}
+
+func (c AuthCookies) cookie(name, value, cookiePath string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     cookiePath,
+		Domain:   c.Domain,
+		HttpOnly: true,
+		Secure:   c.Secure,
+		SameSite: c.sameSite(),
+		MaxAge:   maxAge,
+	}
+}
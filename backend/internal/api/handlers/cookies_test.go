@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/auth"
+)
+
+func TestAuthCookies(t *testing.T) {
+	jwtService := auth.NewJWTService("test-secret-key", 15*time.Minute, 7*24*time.Hour, 30*24*time.Hour)
+	tokens, err := jwtService.GenerateTokenPair(uuid.New(), "test@example.com")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair failed: %v", err)
+	}
+	cookies := AuthCookies{Secure: true, SameSite: "none", Domain: "example.com", Path: "/scheduler"}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", nil)
+	rec := httptest.NewRecorder()
+	cookies.write(rec, req, jwtService, tokens)
+	written := rec.Result().Cookies()
+	if len(written) != 2 {
+		t.Fatalf("Expected 2 cookies, got %d", len(written))
+	}
+	if written[0].Path != "/scheduler" || written[1].Path != "/scheduler/api/auth/refresh" {
+		t.Errorf("Unexpected paths %q and %q", written[0].Path, written[1].Path)
+	}
+	for _, c := range written {
+		if c.SameSite != http.SameSiteNoneMode || !c.Secure || c.Domain != "example.com" {
+			t.Errorf("%s: expected Secure SameSite=None on example.com, got %+v", c.Name, c)
+		}
+	}
+
+	// Clearing has to match the domain and path written
+	rec = httptest.NewRecorder()
+	cookies.clear(rec, req)
+	for i, c := range rec.Result().Cookies() {
+		if c.MaxAge != -1 || c.Path != written[i].Path || c.Domain != written[i].Domain {
+			t.Errorf("%s: clearing cookie doesn't match the one written: %+v", c.Name, c)
+		}
+	}
+
+	if got := (AuthCookies{}).refreshPath(req); got != "/api/auth/refresh" {
+		t.Errorf("Expected default refresh path /api/auth/refresh, got %q", got)
+	}
+}
+
+func TestAuthCookies_VersionedMount(t *testing.T) {
+	var paths []string
+	r := chi.NewRouter()
+	for _, prefix := range []string{"/api", "/api/v1"} {
+		r.Route(prefix, func(r chi.Router) {
+			r.Route("/auth", func(r chi.Router) {
+				r.Post("/login", func(w http.ResponseWriter, r *http.Request) {
+					paths = append(paths, AuthCookies{}.refreshPath(r))
+				})
+			})
+			r.Post("/workspaces/switch", func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, AuthCookies{Path: "/scheduler"}.refreshPath(r))
+			})
+		})
+	}
+
+	for _, target := range []string{"/api/auth/login", "/api/v1/auth/login", "/api/v1/workspaces/switch"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, target, nil))
+	}
+
+	want := []string{"/api/auth/refresh", "/api/v1/auth/refresh", "/scheduler/api/v1/auth/refresh"}
+	if len(paths) != len(want) {
+		t.Fatalf("Expected %d requests routed, got %d", len(want), len(paths))
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("Expected refresh path %q, got %q", want[i], paths[i])
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/scheduler/backend/internal/auth"
+	"github.com/scheduler/backend/internal/cache"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/embed"
+	"github.com/scheduler/backend/internal/feed"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// embedSize is how many of the soonest scheduled posts an embed includes
+const embedSize = 50
+
+// embedTitle is the heading of every schedule embed
+const embedTitle = "Upcoming posts"
+
+// embedContentTypes maps each embed format to its content type
+var embedContentTypes = map[string]string{
+	"html": "text/html; charset=utf-8",
+	"json": "application/json; charset=utf-8",
+}
+
+// EmbedHandler serves users' public embeds of their upcoming schedule. Only
+// each post's title, time and channel are shown, never its content.
+type EmbedHandler struct {
+	db    db.Store
+	cache *cache.Cache
+}
+
+// NewEmbedHandler creates a new schedule embed handler
+func NewEmbedHandler(database db.Store, embedCache *cache.Cache) *EmbedHandler {
+	return &EmbedHandler{
+		db:    database,
+		cache: embedCache,
+	}
+}
+
+// Serve renders the schedule embed for the token in the URL in the format
+// given by the route ("html" or "json")
+func (h *EmbedHandler) Serve(format string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, err := h.db.GetUserByEmbedToken(r.Context(), auth.HashURLToken(chi.URLParam(r, "token")))
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to look up embed")
+			return
+		}
+		if user == nil {
+			respondError(w, http.StatusNotFound, "Embed not found")
+			return
+		}
+
+		body, found := []byte(nil), false
+		if h.cache != nil {
+			body, found = h.cache.GetEmbed(r.Context(), user.TenantID, user.ID, format)
+		}
+		if !found {
+			if body, err = h.render(r, user, format); err != nil {
+				respondError(w, http.StatusInternalServerError, "Failed to render embed")
+				return
+			}
+			if h.cache != nil {
+				if err := h.cache.SetEmbed(r.Context(), user.TenantID, user.ID, format, body); err != nil {
+					log.Printf("⚠️ Failed to cache embed for user %s: %v", user.ID, err)
+				}
+			}
+		}
+
+		etag := feed.ETag(body)
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "public, max-age=60")
+		if format == "json" {
+			// Community calendars fetch the schedule from their own pages
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", embedContentTypes[format])
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}
+}
+
+// render builds the user's embed from the soonest posts scheduled in their
+// personal workspace
+func (h *EmbedHandler) render(r *http.Request, user *models.User, format string) ([]byte, error) {
+	summaries, err := h.db.GetUpcomingPostSummaries(r.Context(), user.ID, nil, db.PostFilter{Limit: embedSize})
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := models.NewEmbedSchedule(embedTitle, summaries)
+	if format == "html" {
+		return embed.HTML(schedule)
+	}
+	return json.Marshal(schedule)
+}
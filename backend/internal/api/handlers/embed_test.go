@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/auth"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestEmbedHandler(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	title := "Launch day"
+	post := &models.PostSummary{ID: uuid.New(), Title: &title, Channel: models.ChannelTwitter, Status: models.PostStatusScheduled, ScheduledAt: time.Now().Add(time.Hour)}
+
+	store := &dbmock.Store{
+		GetUserByEmbedTokenFunc: func(ctx context.Context, tokenHash string) (*models.User, error) {
+			if tokenHash == auth.HashURLToken("tok") {
+				return user, nil
+			}
+			return nil, nil
+		},
+		GetUpcomingPostSummariesFunc: func(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.PostFilter) ([]*models.PostSummary, error) {
+			if userID != user.ID || orgID != nil {
+				t.Errorf("GetUpcomingPostSummaries(%s, %v), want the user's personal workspace", userID, orgID)
+			}
+			return []*models.PostSummary{post}, nil
+		},
+	}
+	h := NewEmbedHandler(store, nil)
+
+	r := chi.NewRouter()
+	r.Route("/api/embeds/{token}", func(r chi.Router) {
+		r.Get("/html", h.Serve("html"))
+		r.Get("/json", h.Serve("json"))
+	})
+	get := func(path, etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/api/embeds/tok/json", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET json status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, `"title":"Launch day"`) || strings.Contains(body, post.ID.String()) || strings.Contains(body, "status") {
+		t.Errorf("embed = %s, want only title, time and channel", body)
+	}
+	if rec.Header().Get("Access-Control-Allow-Origin") != "*" {
+		t.Error("JSON embed can't be fetched from other sites")
+	}
+
+	if rec := get("/api/embeds/tok/json", rec.Header().Get("ETag")); rec.Code != http.StatusNotModified {
+		t.Errorf("revalidation status = %d, want %d", rec.Code, http.StatusNotModified)
+	}
+
+	if rec := get("/api/embeds/tok/html", ""); rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Errorf("GET html = %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	if rec := get("/api/embeds/nope/json", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("GET unknown token status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/scheduler/backend/internal/auth"
+	"github.com/scheduler/backend/internal/cache"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/feed"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// feedSize is how many of the most recent published posts a feed includes
+const feedSize = 50
+
+// feedContentTypes maps each feed format to its content type
+var feedContentTypes = map[string]string{
+	"rss":  "application/rss+xml; charset=utf-8",
+	"json": "application/feed+json; charset=utf-8",
+}
+
+// FeedHandler serves users' public feeds of published posts
+type FeedHandler struct {
+	db      db.Store
+	cache   *cache.Cache
+	siteURL string
+}
+
+// NewFeedHandler creates a new feed handler
+func NewFeedHandler(database db.Store, feedCache *cache.Cache, siteURL string) *FeedHandler {
+	return &FeedHandler{
+		db:      database,
+		cache:   feedCache,
+		siteURL: siteURL,
+	}
+}
+
+// Serve renders the feed for the token in the URL in the format given by the
+// route ("rss" or "json"). Responses carry an ETag so feed readers and embeds
+// can revalidate cheaply.
+func (h *FeedHandler) Serve(format string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, err := h.db.GetUserByFeedToken(r.Context(), auth.HashURLToken(chi.URLParam(r, "token")))
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to look up feed")
+			return
+		}
+		if user == nil {
+			respondError(w, http.StatusNotFound, "Feed not found")
+			return
+		}
+
+		body, found := []byte(nil), false
+		if h.cache != nil {
+			body, found = h.cache.GetFeed(r.Context(), user.TenantID, user.ID, format)
+		}
+		if !found {
+			if body, err = h.render(r, user, format); err != nil {
+				respondError(w, http.StatusInternalServerError, "Failed to render feed")
+				return
+			}
+			if h.cache != nil {
+				if err := h.cache.SetFeed(r.Context(), user.TenantID, user.ID, format, body); err != nil {
+					log.Printf("⚠️ Failed to cache feed for user %s: %v", user.ID, err)
+				}
+			}
+		}
+
+		etag := feed.ETag(body)
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", feedContentTypes[format])
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}
+}
+
+// render builds the user's feed from their most recent published posts
+func (h *FeedHandler) render(r *http.Request, user *models.User, format string) ([]byte, error) {
+	posts, err := h.db.GetFeedPosts(r.Context(), user.ID, feedSize)
+	if err != nil {
+		return nil, err
+	}
+
+	info := feed.Info{
+		Title:       "Published posts",
+		Link:        h.siteURL,
+		Description: "Recently published social posts",
+		FeedURL:     requestOrigin(r) + r.URL.Path,
+	}
+	if format == "rss" {
+		return feed.RSS(info, posts)
+	}
+	return feed.JSON(info, posts)
+}
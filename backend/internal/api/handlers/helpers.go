@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/scheduler/backend/internal/models"
 )
@@ -41,6 +44,63 @@ func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	}
 }
 
+// respondJSONConditional writes data as JSON with ETag and Last-Modified
+// headers, or 304 Not Modified when the request's If-None-Match or, failing
+// that, If-Modified-Since still matches
+func respondJSONConditional(w http.ResponseWriter, r *http.Request, data interface{}, lastModified time.Time) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to encode response")
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	if notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(append(body, '\n'))
+}
+
+// notModified reports whether the request's validators match the current
+// representation. If-Modified-Since is only considered without If-None-Match.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// respondList writes a complete list in the list envelope
+func respondList[T any](w http.ResponseWriter, items []T) {
+	respondPage(w, items, "", len(items))
+}
+
+// respondPage writes one page of a list in the list envelope; nextCursor is
+// empty on the last page and total is negative when unknown
+func respondPage[T any](w http.ResponseWriter, items []T, nextCursor string, total int) {
+	respondJSON(w, http.StatusOK, models.NewListResponse(items, nextCursor, total))
+}
+
 // respondError writes an error response
 func respondError(w http.ResponseWriter, status int, message string) {
 	respondJSON(w, status, models.ErrorResponse{
@@ -48,3 +108,12 @@ func respondError(w http.ResponseWriter, status int, message string) {
 		Message: message,
 	})
 }
+
+// respondErrorCode writes an error response with a machine-readable code
+func respondErrorCode(w http.ResponseWriter, status int, code, message string) {
+	respondJSON(w, status, models.ErrorResponse{
+		Error:   http.StatusText(status),
+		Code:    code,
+		Message: message,
+	})
+}
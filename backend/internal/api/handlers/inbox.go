@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+)
+
+// InboxHandler handles the unified inbox of replies and mentions on
+// published posts, and the keyword monitors that add matches to it
+type InboxHandler struct {
+	db       db.Store
+	notifier *notifier.Notifier
+}
+
+// NewInboxHandler creates a new inbox handler
+func NewInboxHandler(database db.Store, n *notifier.Notifier) *InboxHandler {
+	return &InboxHandler{
+		db:       database,
+		notifier: n,
+	}
+}
+
+// List returns one page of the user's inbox, newest first, optionally only
+// unread items (?unread=true), one ?channel or one ?kind
+func (h *InboxHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	q := r.URL.Query()
+	filter := db.InboxFilter{Limit: models.DefaultPageSize}
+	if u := q.Get("unread"); u != "" {
+		unread, err := strconv.ParseBool(u)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid unread. Must be true or false")
+			return
+		}
+		filter.UnreadOnly = unread
+	}
+	if c := q.Get("channel"); c != "" {
+		if !models.IsValidChannel(c) {
+			respondError(w, http.StatusBadRequest, "Invalid channel")
+			return
+		}
+		channel := models.Channel(c)
+		filter.Channel = &channel
+	}
+	if k := q.Get("kind"); k != "" {
+		kind := models.InboxKind(k)
+		if !kind.IsValid() {
+			respondError(w, http.StatusBadRequest, "Invalid kind. Must be reply, mention or keyword")
+			return
+		}
+		filter.Kind = &kind
+	}
+	if c := q.Get("cursor"); c != "" {
+		cursor, err := models.ParsePostCursor(c)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.After = &cursor
+	}
+	if l := q.Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit < 1 || limit > models.MaxPageSize {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("Invalid limit. Must be between 1 and %d", models.MaxPageSize))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	// One more item than the page tells whether another page follows
+	limit := filter.Limit
+	filter.Limit++
+	items, err := h.db.ListInboxItems(r.Context(), user.ID, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch inbox")
+		return
+	}
+
+	respondPostPage(w, items, limit, models.InboxCursor)
+}
+
+// Unread returns the user's unread inbox count
+func (h *InboxHandler) Unread(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	unread, err := h.db.CountUnreadInbox(r.Context(), user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to count unread items")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, models.InboxCounts{Unread: unread})
+}
+
+// Mark marks the given inbox items read or unread
+func (h *InboxHandler) Mark(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	var req models.MarkInboxRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	changed, err := h.db.MarkInboxItems(r.Context(), user.ID, req.IDs, req.Read)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update inbox")
+		return
+	}
+
+	h.respondCounts(w, r, user.ID, changed)
+}
+
+// MarkAllRead marks every unread inbox item read
+func (h *InboxHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	changed, err := h.db.MarkAllInboxRead(r.Context(), user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update inbox")
+		return
+	}
+
+	h.respondCounts(w, r, user.ID, changed)
+}
+
+// respondCounts responds with the unread count after marking items, and
+// sends it to the user's other sessions when anything changed
+func (h *InboxHandler) respondCounts(w http.ResponseWriter, r *http.Request, userID uuid.UUID, changed int) {
+	unread, err := h.db.CountUnreadInbox(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to count unread items")
+		return
+	}
+
+	counts := models.InboxCounts{Unread: unread}
+	if changed > 0 && h.notifier != nil {
+		data, _ := json.Marshal(counts)
+		h.notifier.NotifyData(userID, notifier.UpdateTypeInbox, data)
+	}
+	respondJSON(w, http.StatusOK, counts)
+}
@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// ListLibrary returns the media shared to the current organization's
+// library, optionally only that filed in ?folder_id or matching ?q
+func (h *MediaHandler) ListLibrary(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, _, ok := h.libraryWorkspace(w, r, user)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	filter := models.LibraryFilter{Query: trimString(q.Get("q"))}
+	if s := q.Get("folder_id"); s != "" {
+		folderID, err := uuid.Parse(s)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid folder ID")
+			return
+		}
+		filter.FolderID = &folderID
+	}
+
+	items, err := h.db.ListLibraryMedia(r.Context(), orgID, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch media library")
+		return
+	}
+
+	if items == nil {
+		items = []*models.LibraryMedia{}
+	}
+
+	respondList(w, items)
+}
+
+// FileLibraryMedia shares one of the user's uploads to the current
+// organization's library, or moves a library item to another folder
+func (h *MediaHandler) FileLibraryMedia(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, _, ok := h.libraryWorkspace(w, r, user)
+	if !ok {
+		return
+	}
+
+	var req models.FileLibraryMediaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	item, err := h.db.FileLibraryMedia(r.Context(), user.ID, orgID, req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update media library")
+		return
+	}
+	if item == nil {
+		respondError(w, http.StatusNotFound, "Media or folder not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, item)
+}
+
+// RemoveLibraryMedia takes media out of the current organization's library.
+// Members may remove what they shared; owners and admins anything.
+func (h *MediaHandler) RemoveLibraryMedia(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, role, ok := h.libraryWorkspace(w, r, user)
+	if !ok {
+		return
+	}
+
+	mediaID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid media ID")
+		return
+	}
+
+	removed, err := h.db.RemoveLibraryMedia(r.Context(), user.ID, orgID, mediaID, role.CanManageMembers())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update media library")
+		return
+	}
+	if !removed {
+		respondError(w, http.StatusNotFound, "Media not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListFolders returns the current organization's library folders
+func (h *MediaHandler) ListFolders(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, _, ok := h.libraryWorkspace(w, r, user)
+	if !ok {
+		return
+	}
+
+	folders, err := h.db.ListMediaFolders(r.Context(), orgID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch folders")
+		return
+	}
+
+	if folders == nil {
+		folders = []*models.MediaFolder{}
+	}
+
+	respondList(w, folders)
+}
+
+// CreateFolder adds a folder to the current organization's library, or
+// returns the folder with the same name
+func (h *MediaHandler) CreateFolder(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, _, ok := h.libraryWorkspace(w, r, user)
+	if !ok {
+		return
+	}
+
+	var req models.CreateMediaFolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	folder, err := h.db.CreateMediaFolder(r.Context(), orgID, user.ID, req.Name)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to save folder")
+		return
+	}
+	if folder == nil {
+		respondError(w, http.StatusConflict, fmt.Sprintf("At most %d folders are allowed", models.MaxMediaFolders))
+		return
+	}
+	recordAudit(r.Context(), h.db, user, orgID, models.AuditMediaFolderCreate, &folder.ID, map[string]any{"name": folder.Name})
+
+	respondJSON(w, http.StatusCreated, folder)
+}
+
+// DeleteFolder removes a library folder, moving its media to the top of the
+// library. Only owners and admins may delete folders.
+func (h *MediaHandler) DeleteFolder(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, role, ok := h.libraryWorkspace(w, r, user)
+	if !ok {
+		return
+	}
+	if !role.CanManageMembers() {
+		respondError(w, http.StatusForbidden, "Only owners and admins can delete folders")
+		return
+	}
+
+	folderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid folder ID")
+		return
+	}
+
+	deleted, err := h.db.DeleteMediaFolder(r.Context(), orgID, folderID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete folder")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "Folder not found")
+		return
+	}
+	recordAudit(r.Context(), h.db, user, orgID, models.AuditMediaFolderDelete, &folderID, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// libraryWorkspace returns the organization whose library the user is
+// working in, and their role in it. Responds with an error and returns false
+// if the user is in their personal workspace or no longer a member.
+func (h *MediaHandler) libraryWorkspace(w http.ResponseWriter, r *http.Request, user *models.User) (uuid.UUID, models.OrgRole, bool) {
+	if user.WorkspaceID == nil {
+		respondError(w, http.StatusBadRequest, "Switch to an organization workspace to use its media library")
+		return uuid.Nil, "", false
+	}
+
+	role, err := h.db.GetMemberRole(r.Context(), *user.WorkspaceID, user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch membership")
+		return uuid.Nil, "", false
+	}
+	if role == "" {
+		respondError(w, http.StatusForbidden, "Not a member of this organization")
+		return uuid.Nil, "", false
+	}
+
+	return *user.WorkspaceID, role, true
+}
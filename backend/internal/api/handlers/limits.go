@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/ratelimit"
+	"github.com/scheduler/backend/internal/usage"
+)
+
+// LimitsHandler reports the caller's rate limits and plan quota consumption
+type LimitsHandler struct {
+	rateLimits *ratelimit.Registry
+	meter      *usage.Meter
+}
+
+// NewLimitsHandler creates a new limits handler
+func NewLimitsHandler(rateLimits *ratelimit.Registry, meter *usage.Meter) *LimitsHandler {
+	return &LimitsHandler{
+		rateLimits: rateLimits,
+		meter:      meter,
+	}
+}
+
+// Get returns the rate limits for the user's plan, the windows they have
+// requests in and their usage against today's quota, so clients can show
+// remaining allowances instead of discovering them through 429s
+func (h *LimitsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	buckets, err := h.rateLimits.Buckets(r.Context(), ratelimit.Client(r), user.Plan)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch rate limits")
+		return
+	}
+
+	today, err := h.meter.Today(r.Context(), user.ID)
+	if err != nil {
+		log.Printf("⚠️ Failed to read today's usage for user %s: %v", user.ID, err)
+	}
+
+	respondJSON(w, http.StatusOK, models.LimitsResponse{
+		RateLimits: h.rateLimits.List(r.Context(), user.Plan),
+		Buckets:    buckets,
+		Plan:       user.Plan,
+		Quota:      user.Plan.Quota(),
+		Today:      today,
+	})
+}
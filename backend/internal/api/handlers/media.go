@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/media"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/scheduler"
+)
+
+// MediaHandler handles media uploads and serves stored media files
+type MediaHandler struct {
+	db    db.Store
+	media *media.Store
+	jobs  *scheduler.JobQueue // Nil when videos aren't transcoded
+}
+
+// NewMediaHandler creates a new media handler. Uploaded videos are queued
+// for transcoding into renditions when jobs is set.
+func NewMediaHandler(database db.Store, mediaStore *media.Store, jobs *scheduler.JobQueue) *MediaHandler {
+	return &MediaHandler{
+		db:    database,
+		media: mediaStore,
+		jobs:  jobs,
+	}
+}
+
+// Upload stores an image or video (multipart field "file") with optional
+// alt text (field "alt_text")
+func (h *MediaHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	// Allow some headroom for multipart framing
+	r.Body = http.MaxBytesReader(w, r.Body, media.MaxVideoBytes+1<<20)
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "File is required (multipart field \"file\")")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, media.MaxVideoBytes+1))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Failed to read file")
+		return
+	}
+	if len(data) > media.MaxVideoBytes {
+		respondError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Videos must not exceed %d MB", media.MaxVideoBytes>>20))
+		return
+	}
+
+	altText, err := normalizeAltText(r.FormValue("alt_text"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id := uuid.New()
+	item := &models.Media{ID: id, UserID: user.ID, Name: models.MediaName(header.Filename), SizeBytes: len(data), AltText: altText}
+	var ext string
+
+	info, err := media.InspectImage(data)
+	switch err {
+	case nil:
+		if len(data) > media.MaxImageBytes {
+			respondError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Image must not exceed %d MB", media.MaxImageBytes>>20))
+			return
+		}
+		item.ContentType, item.Width, item.Height, ext = info.ContentType, info.Width, info.Height, info.Extension
+	case media.ErrUnsupportedImage:
+		video, err := media.InspectVideo(data)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Unsupported media format. Use JPEG, PNG, GIF, MP4 or MOV")
+			return
+		}
+		durationMs := int(video.Duration.Milliseconds())
+		item.ContentType, item.Width, item.Height, ext = video.ContentType, video.Width, video.Height, video.Extension
+		item.DurationMs = &durationMs
+	case media.ErrImageTooLarge:
+		respondError(w, http.StatusBadRequest, "Image dimensions are too large")
+		return
+	default:
+		respondError(w, http.StatusInternalServerError, "Failed to process image")
+		return
+	}
+
+	item.StorageKey = fmt.Sprintf("uploads/%s/%s%s", user.ID, id, ext)
+	if err := h.media.Put(item.StorageKey, data); err != nil {
+		log.Printf("❌ Failed to store media for user %s: %v", user.ID, err)
+		respondError(w, http.StatusInternalServerError, "Failed to store media")
+		return
+	}
+
+	created, err := h.db.CreateMedia(r.Context(), item)
+	if err != nil {
+		_ = h.media.Delete(item.StorageKey)
+		respondError(w, http.StatusInternalServerError, "Failed to save media")
+		return
+	}
+
+	if h.jobs != nil && models.IsVideoContentType(created.ContentType) {
+		payload := scheduler.MediaPayload{MediaID: created.ID, UserID: user.ID}
+		if _, err := h.jobs.Enqueue(r.Context(), scheduler.JobMediaProcess, payload, time.Now()); err != nil {
+			log.Printf("⚠️ Failed to queue renditions of media %s: %v", created.ID, err)
+		}
+	}
+
+	respondJSON(w, http.StatusCreated, created)
+}
+
+// List returns the user's uploaded media
+func (h *MediaHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	items, err := h.db.ListMedia(r.Context(), user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch media")
+		return
+	}
+
+	if items == nil {
+		items = []*models.Media{}
+	}
+
+	respondList(w, items)
+}
+
+// Update sets the default alt text of an uploaded media item
+func (h *MediaHandler) Update(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	mediaID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid media ID")
+		return
+	}
+
+	var req models.UpdateMediaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	var altText *string
+	if req.AltText != nil {
+		if altText, err = normalizeAltText(*req.AltText); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	item, err := h.db.UpdateMediaAltText(r.Context(), user.ID, mediaID, altText)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update media")
+		return
+	}
+	if item == nil {
+		respondError(w, http.StatusNotFound, "Media not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, item)
+}
+
+// Serve streams a media file by key
+func (h *MediaHandler) Serve(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "*")
+	if key == "" || strings.HasPrefix(key, ".") {
+		http.NotFound(w, r)
+		return
+	}
+
+	file, err := h.media.Open(key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	// URLs carry a version parameter, so clients may cache aggressively
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), file)
+}
+
+// normalizeAltText trims alt text, returning nil for empty input
+func normalizeAltText(s string) (*string, error) {
+	s = trimString(s)
+	if s == "" {
+		return nil, nil
+	}
+	if utf8.RuneCountInString(s) > models.MaxAltTextLength {
+		return nil, fmt.Errorf("alt text must not exceed %d characters", models.MaxAltTextLength)
+	}
+	return &s, nil
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/scheduler/backend/internal/clock"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/ratelimit"
+)
+
+// MetaHandler serves the server time and publishing rules
+type MetaHandler struct {
+	dailyLimits models.DailyLimits
+	scheduling  models.SchedulingPolicy
+	rateLimits  *ratelimit.Registry
+	inviteOnly  bool
+	clock       clock.Clock
+}
+
+// NewMetaHandler creates a new meta handler
+func NewMetaHandler(dailyLimits models.DailyLimits, scheduling models.SchedulingPolicy, rateLimits *ratelimit.Registry, inviteOnly bool, clk clock.Clock) *MetaHandler {
+	return &MetaHandler{
+		dailyLimits: dailyLimits,
+		scheduling:  scheduling,
+		rateLimits:  rateLimits,
+		inviteOnly:  inviteOnly,
+		clock:       clk,
+	}
+}
+
+// Get returns the server time, per-channel limits, scheduling horizon, rate
+// limit policy and whether registration is invite-only
+func (h *MetaHandler) Get(w http.ResponseWriter, r *http.Request) {
+	channels := make([]models.ChannelMeta, 0, len(models.ValidChannels()))
+	for _, c := range models.ValidChannels() {
+		channels = append(channels, models.NewChannelMeta(c, h.dailyLimits))
+	}
+
+	respondJSON(w, http.StatusOK, models.Meta{
+		ServerTime: h.clock.Now().UTC(),
+		Channels:   channels,
+		Schedule: models.ScheduleMeta{
+			HorizonDays:      h.scheduling.HorizonDays,
+			PastGraceSeconds: int(h.scheduling.PastGrace / time.Second),
+		},
+		RateLimits: h.rateLimits.List(r.Context(), ""),
+		Quotas:     models.PlanQuotas,
+		InviteOnly: h.inviteOnly,
+
+		PlanRateLimitMultipliers: h.rateLimits.Multipliers(),
+	})
+}
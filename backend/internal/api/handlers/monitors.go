@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// ListMonitors returns the user's keyword monitors
+func (h *InboxHandler) ListMonitors(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	monitors, err := h.db.ListKeywordMonitors(r.Context(), user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch keyword monitors")
+		return
+	}
+
+	if monitors == nil {
+		monitors = []*models.KeywordMonitor{}
+	}
+
+	respondList(w, monitors)
+}
+
+// CreateMonitor adds a keyword monitor, or updates the channels of the
+// user's monitor with the same query
+func (h *InboxHandler) CreateMonitor(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	var req models.CreateKeywordMonitorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	monitor, err := h.db.CreateKeywordMonitor(r.Context(), user.ID, req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to save keyword monitor")
+		return
+	}
+	if monitor == nil {
+		respondError(w, http.StatusConflict, fmt.Sprintf("At most %d keyword monitors are allowed", models.MaxKeywordMonitors))
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, monitor)
+}
+
+// DeleteMonitor removes a keyword monitor and the matches it added
+func (h *InboxHandler) DeleteMonitor(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid monitor ID")
+		return
+	}
+
+	deleted, err := h.db.DeleteKeywordMonitor(r.Context(), user.ID, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete keyword monitor")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "Keyword monitor not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,360 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// maxOrganizationNameLength is the longest organization name accepted
+const maxOrganizationNameLength = 100
+
+// OrganizationHandler handles organization and membership endpoints
+type OrganizationHandler struct {
+	db        db.Store
+	retention models.RetentionPolicy // The defaults organizations may override
+}
+
+// NewOrganizationHandler creates a new organization handler
+func NewOrganizationHandler(database db.Store, retention models.RetentionPolicy) *OrganizationHandler {
+	return &OrganizationHandler{
+		db:        database,
+		retention: retention,
+	}
+}
+
+// Create creates an organization owned by the current user
+func (h *OrganizationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	var req models.CreateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	name := trimString(req.Name)
+	if name == "" {
+		respondError(w, http.StatusBadRequest, "Name is required")
+		return
+	}
+	if len(name) > maxOrganizationNameLength {
+		respondError(w, http.StatusBadRequest, "Name must not exceed 100 characters")
+		return
+	}
+
+	org, err := h.db.CreateOrganization(r.Context(), name, user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create organization")
+		return
+	}
+	recordAudit(r.Context(), h.db, user, org.ID, models.AuditOrganizationCreate, nil, map[string]any{"name": org.Name})
+
+	respondJSON(w, http.StatusCreated, org)
+}
+
+// ListMembers returns the organization's members; any member may view them
+func (h *OrganizationHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, ok := h.authorize(w, r, user, false)
+	if !ok {
+		return
+	}
+
+	members, err := h.db.ListOrganizationMembers(r.Context(), orgID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch members")
+		return
+	}
+
+	respondList(w, members)
+}
+
+// SetMember adds a registered user to the organization or changes their role.
+// Only owners may grant the owner role.
+func (h *OrganizationHandler) SetMember(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, ok := h.authorize(w, r, user, true)
+	if !ok {
+		return
+	}
+
+	var req models.AddMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Role == "" {
+		req.Role = string(models.OrgRoleMember)
+	}
+	if !models.IsValidOrgRole(req.Role) {
+		respondError(w, http.StatusBadRequest, "Invalid role. Must be one of: owner, admin, member")
+		return
+	}
+	role := models.OrgRole(req.Role)
+
+	callerRole, err := h.db.GetMemberRole(r.Context(), orgID, user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch membership")
+		return
+	}
+	if role == models.OrgRoleOwner && callerRole != models.OrgRoleOwner {
+		respondError(w, http.StatusForbidden, "Only owners can add owners")
+		return
+	}
+	if req.CanOverrideWindows && callerRole != models.OrgRoleOwner {
+		respondError(w, http.StatusForbidden, "Only owners can allow publishing window overrides")
+		return
+	}
+
+	member, err := h.db.GetUserByEmail(r.Context(), strings.TrimSpace(req.Email))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Database error")
+		return
+	}
+	if member == nil {
+		respondError(w, http.StatusNotFound, "No user with that email")
+		return
+	}
+	if member.ID == user.ID {
+		respondError(w, http.StatusBadRequest, "You cannot change your own role")
+		return
+	}
+
+	if err := h.db.SetOrganizationMember(r.Context(), orgID, member.ID, role, req.CanOverrideWindows); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update member")
+		return
+	}
+	recordAudit(r.Context(), h.db, user, orgID, models.AuditMemberSet, &member.ID, map[string]any{
+		"email":                member.Email,
+		"role":                 role,
+		"can_override_windows": req.CanOverrideWindows,
+	})
+
+	members, err := h.db.ListOrganizationMembers(r.Context(), orgID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch members")
+		return
+	}
+
+	respondList(w, members)
+}
+
+// RemoveMember removes a member from the organization. Owners cannot be removed.
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, ok := h.authorize(w, r, user, true)
+	if !ok {
+		return
+	}
+
+	memberID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	removed, err := h.db.RemoveOrganizationMember(r.Context(), orgID, memberID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to remove member")
+		return
+	}
+	if !removed {
+		respondError(w, http.StatusNotFound, "Member not found or is an owner")
+		return
+	}
+	recordAudit(r.Context(), h.db, user, orgID, models.AuditMemberRemove, &memberID, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetPublishingWindows returns the organization's publishing windows; any member may view them
+func (h *OrganizationHandler) GetPublishingWindows(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, ok := h.authorize(w, r, user, false)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.db.GetPublishingSchedule(r.Context(), orgID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch publishing windows")
+		return
+	}
+	if schedule == nil {
+		respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, schedule)
+}
+
+// SetPublishingWindows replaces the organization's publishing windows. Only
+// owners may change them; an empty list allows publishing at any time.
+func (h *OrganizationHandler) SetPublishingWindows(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, ok := h.authorize(w, r, user, true)
+	if !ok {
+		return
+	}
+	if !h.requireOwner(w, r, orgID, user, "Only owners can change publishing windows") {
+		return
+	}
+
+	var schedule models.PublishingSchedule
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := schedule.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if schedule.Windows == nil {
+		schedule.Windows = []models.PublishingWindow{}
+	}
+
+	if err := h.db.SetPublishingSchedule(r.Context(), orgID, schedule); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update publishing windows")
+		return
+	}
+	recordAudit(r.Context(), h.db, user, orgID, models.AuditPublishingWindowsUpdate, nil, map[string]any{"publishing_windows": schedule})
+
+	respondJSON(w, http.StatusOK, schedule)
+}
+
+// GetBrandChecklist returns the checks the organization's posts must pass
+// before they can be scheduled; any member may view them
+func (h *OrganizationHandler) GetBrandChecklist(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, ok := h.authorize(w, r, user, false)
+	if !ok {
+		return
+	}
+
+	checklist, err := h.db.GetBrandChecklist(r.Context(), orgID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch brand checklist")
+		return
+	}
+	if checklist == nil {
+		respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, checklist)
+}
+
+// SetBrandChecklist replaces the organization's brand checklist. Only owners
+// and admins may change it; an empty list of checks turns it off.
+func (h *OrganizationHandler) SetBrandChecklist(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, ok := h.authorize(w, r, user, true)
+	if !ok {
+		return
+	}
+
+	var checklist models.BrandChecklist
+	if err := json.NewDecoder(r.Body).Decode(&checklist); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := checklist.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.db.SetBrandChecklist(r.Context(), orgID, checklist); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update brand checklist")
+		return
+	}
+	recordAudit(r.Context(), h.db, user, orgID, models.AuditBrandChecklistUpdate, nil, map[string]any{"brand_checklist": checklist})
+
+	respondJSON(w, http.StatusOK, checklist)
+}
+
+// authorize parses the organization ID from the URL and checks the user is a
+// member, and a manager if manage is set. Responds with an error and returns
+// false otherwise.
+func (h *OrganizationHandler) authorize(w http.ResponseWriter, r *http.Request, user *models.User, manage bool) (uuid.UUID, bool) {
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid organization ID")
+		return uuid.Nil, false
+	}
+
+	role, err := h.db.GetMemberRole(r.Context(), orgID, user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch membership")
+		return uuid.Nil, false
+	}
+	if role == "" {
+		respondError(w, http.StatusNotFound, "Organization not found")
+		return uuid.Nil, false
+	}
+	if manage && !role.CanManageMembers() {
+		respondError(w, http.StatusForbidden, "Only owners and admins can manage members")
+		return uuid.Nil, false
+	}
+
+	return orgID, true
+}
+
+// requireOwner checks the user owns the organization, responding with an
+// error and returning false otherwise
+func (h *OrganizationHandler) requireOwner(w http.ResponseWriter, r *http.Request, orgID uuid.UUID, user *models.User, message string) bool {
+	role, err := h.db.GetMemberRole(r.Context(), orgID, user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch membership")
+		return false
+	}
+	if role != models.OrgRoleOwner {
+		respondError(w, http.StatusForbidden, message)
+		return false
+	}
+	return true
+}
@@ -3,34 +3,53 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/abuse"
+	"github.com/scheduler/backend/internal/auth"
 	"github.com/scheduler/backend/internal/cache"
+	"github.com/scheduler/backend/internal/clock"
 	"github.com/scheduler/backend/internal/db"
 	"github.com/scheduler/backend/internal/models"
 	"github.com/scheduler/backend/internal/notifier"
 	"github.com/scheduler/backend/internal/scheduler"
+	"github.com/scheduler/backend/internal/textmetrics"
 )
 
 // PostHandler handles post endpoints
 type PostHandler struct {
-	db       *db.DB
-	queue    *scheduler.Queue
-	cache    *cache.Cache
-	notifier *notifier.Notifier
+	db             db.Store
+	queue          *scheduler.Queue
+	cache          *cache.Cache
+	notifier       *notifier.Notifier
+	requireAltText bool
+	dailyLimits    models.DailyLimits
+	scheduling     models.SchedulingPolicy
+	abuse          *abuse.Detector
+	dispatch       *scheduler.Dispatcher
+	clock          clock.Clock // Schedule validation and daily limits are checked against it
 }
 
 // NewPostHandler creates a new post handler
-func NewPostHandler(database *db.DB, queue *scheduler.Queue, postCache *cache.Cache, n *notifier.Notifier) *PostHandler {
+func NewPostHandler(database db.Store, queue *scheduler.Queue, postCache *cache.Cache, n *notifier.Notifier, requireAltText bool, dailyLimits models.DailyLimits, scheduling models.SchedulingPolicy, detector *abuse.Detector, dispatcher *scheduler.Dispatcher, clk clock.Clock) *PostHandler {
 	return &PostHandler{
-		db:       database,
-		queue:    queue,
-		cache:    postCache,
-		notifier: n,
+		db:             database,
+		queue:          queue,
+		cache:          postCache,
+		notifier:       n,
+		requireAltText: requireAltText,
+		dailyLimits:    dailyLimits,
+		scheduling:     scheduling,
+		abuse:          detector,
+		dispatch:       dispatcher,
+		clock:          clk,
 	}
 }
 
@@ -48,193 +67,1583 @@ func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Trim and validate content
-	req.Content = trimString(req.Content)
-	if req.Content == "" {
-		respondError(w, http.StatusBadRequest, "Content is required")
+	newPost, violations, warnings, err := h.validateCreate(r.Context(), user, &req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to validate post")
+		return
+	}
+	if len(violations) > 0 {
+		respondViolation(w, violations[0])
+		return
+	}
+
+	post, err := h.createPost(r.Context(), user, newPost)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create post")
+		return
+	}
+
+	// Hint at other posts scheduled close to this one on the same channel
+	resp := models.CreatePostResponse{Post: post, Warnings: warnings}
+	if window := user.ConflictWindow(); window > 0 {
+		conflicts, err := h.db.FindConflictingPosts(r.Context(), user.ID, post.Channel, post.ScheduledAt, window, post.ID)
+		if err != nil {
+			log.Printf("⚠️ Failed to check scheduling conflicts for post %s: %v", post.ID, err)
+		}
+		resp.Conflicts = conflicts
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+const (
+	// maxWebhookBodyBytes caps inbound webhook payloads
+	maxWebhookBodyBytes = 64 << 10
+	// webhookScheduleDelay is how soon a webhook post without scheduled_at publishes
+	webhookScheduleDelay = time.Minute
+)
+
+// CreateFromWebhook creates a post from a user's inbound webhook. The token in
+// the URL identifies the user; the post goes to their personal workspace.
+func (h *PostHandler) CreateFromWebhook(w http.ResponseWriter, r *http.Request) {
+	user, err := h.db.GetUserByWebhookToken(r.Context(), auth.HashURLToken(chi.URLParam(r, "token")))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to look up webhook")
+		return
+	}
+	if user == nil {
+		respondError(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	if user.Suspended() {
+		respondError(w, http.StatusForbidden, "Account is suspended")
+		return
+	}
+
+	var payload models.InboundWebhookRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes)).Decode(&payload); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if payload.ScheduledAt == "" {
+		payload.ScheduledAt = h.clock.Now().Add(webhookScheduleDelay).UTC().Format(time.RFC3339)
+	}
+
+	req := models.CreatePostRequest{
+		Title:       payload.Title,
+		Content:     payload.Content,
+		Channel:     payload.Channel,
+		ScheduledAt: payload.ScheduledAt,
+	}
+	newPost, violations, warnings, err := h.validateCreate(r.Context(), user, &req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to validate post")
+		return
+	}
+	if len(violations) > 0 {
+		respondViolation(w, violations[0])
+		return
+	}
+
+	post, err := h.createPost(r.Context(), user, newPost)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create post")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, models.CreatePostResponse{Post: post, Warnings: warnings})
+}
+
+// createPost stores a validated post, queues it for publishing and notifies the author
+func (h *PostHandler) createPost(ctx context.Context, user *models.User, newPost *db.NewPost) (*models.Post, error) {
+	// Create post in database
+	post, err := h.db.CreatePost(ctx, *newPost)
+	if err != nil {
+		return nil, err
+	}
+
+	recordPostAudit(ctx, h.db, user, post, models.AuditPostCreate, postAuditDetails(post))
+
+	// Compare the content with other accounts' posts
+	h.abuse.RecordContent(ctx, user.ID, post.Content)
+
+	// Add to scheduling queue (async, don't block response); pending posts
+	// are queued once approved
+	if post.Status == models.PostStatusScheduled {
+		go func() {
+			if err := h.queue.Enqueue(context.Background(), post.ID, post.ScheduledAt, post.Priority); err != nil {
+				log.Printf("⚠️ Failed to enqueue post %s: %v", post.ID, err)
+			}
+			h.syncReminder(post)
+		}()
+	}
+
+	// Invalidate cache (async)
+	go func() {
+		if h.cache != nil {
+			_ = h.cache.InvalidatePost(context.Background(), post)
+		}
+	}()
+
+	// Notify SSE clients of the new post (async for Redis pub, sync for local)
+	log.Printf("📢 [POST CREATE] Sending notification for user %s, post %s", user.ID, post.ID)
+	h.notifier.Notify(user.ID, notifier.UpdateTypeCreate)
+	log.Printf("✅ [POST CREATE] Notification sent for user %s", user.ID)
+
+	return post, nil
+}
+
+// syncReminder puts the post's reminder on the reminder wheel, or takes it
+// off when the post has none. Reminders already past are sent right away.
+func (h *PostHandler) syncReminder(post *models.Post) {
+	ctx := context.Background()
+	var err error
+	if remindAt, ok := post.ReminderAt(); ok {
+		err = h.queue.ScheduleReminder(ctx, post.ID, remindAt)
+	} else {
+		err = h.queue.RemoveReminder(ctx, post.ID)
+	}
+	if err != nil {
+		log.Printf("⚠️ Failed to update reminder for post %s: %v", post.ID, err)
+	}
+}
+
+// Validate runs the create validation pipeline and reports every violation
+// without persisting anything, for live form validation
+func (h *PostHandler) Validate(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
 		return
 	}
-	if len(req.Content) < 3 {
-		respondError(w, http.StatusBadRequest, "Content must be at least 3 characters")
+
+	var req models.CreatePostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
-	if len(req.Content) > 5000 {
-		respondError(w, http.StatusBadRequest, "Content must not exceed 5000 characters")
+
+	newPost, violations, warnings, err := h.validateCreate(r.Context(), user, &req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to validate post")
 		return
 	}
 
-	// Trim and validate title if provided
+	resp := models.ValidatePostResponse{
+		Valid:      len(violations) == 0,
+		Violations: violations,
+		Warnings:   warnings,
+	}
+	if resp.Violations == nil {
+		resp.Violations = []models.Violation{}
+	}
+	if models.IsValidChannel(req.Channel) {
+		resp.Length = models.MeasureContent(models.Channel(req.Channel), req.Content)
+	}
+
+	// Surface scheduling conflicts as hints, same as Create
+	if resp.Valid {
+		if window := user.ConflictWindow(); window > 0 {
+			conflicts, err := h.db.FindConflictingPosts(r.Context(), user.ID, newPost.Channel, newPost.ScheduledAt, window, uuid.Nil)
+			if err != nil {
+				log.Printf("⚠️ Failed to check scheduling conflicts for user %s: %v", user.ID, err)
+			}
+			resp.Conflicts = conflicts
+		}
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// validateCreate normalizes a create request and checks it against every rule
+// Create enforces, collecting all violations rather than stopping at the first.
+// Text fields are sanitized first, with a warning for each change. The
+// returned post is only complete when there are no violations; err is
+// reserved for failures that prevent validation from running at all.
+func (h *PostHandler) validateCreate(ctx context.Context, user *models.User, req *models.CreatePostRequest) (*db.NewPost, []models.Violation, []models.ContentWarning, error) {
+	var violations []models.Violation
+	add := func(field, message string) {
+		violations = append(violations, models.Violation{Field: field, Message: message})
+	}
+
+	// Fill in the channel preset before anything is checked, so limits apply
+	// to the content as it will publish
+	if err := h.applyPreset(ctx, user.ID, req); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Strip invisible characters and unsafe links before anything is measured
+	var warnings []models.ContentWarning
+	req.Content, warnings = models.SanitizeText("content", req.Content)
+	if req.Title != nil {
+		title, changes := models.SanitizeText("title", *req.Title)
+		req.Title = &title
+		warnings = append(warnings, changes...)
+	}
+	if req.ABTest != nil {
+		variant, changes := models.SanitizeText("ab_test", req.ABTest.VariantB)
+		req.ABTest.VariantB = variant
+		warnings = append(warnings, changes...)
+	}
+
+	// Trim content and title; an empty title is treated as nil
+	req.Content = trimString(req.Content)
 	if req.Title != nil {
 		trimmed := trimString(*req.Title)
 		if trimmed == "" {
-			req.Title = nil // Treat empty title as nil
+			req.Title = nil
 		} else {
-			if len(trimmed) > 200 {
-				respondError(w, http.StatusBadRequest, "Title must not exceed 200 characters")
-				return
-			}
 			req.Title = &trimmed
 		}
 	}
 
-	// Validate channel
-	if !models.IsValidChannel(req.Channel) {
-		respondError(w, http.StatusBadRequest, "Invalid channel. Must be one of: twitter, linkedin, facebook")
+	// Validate the text's lengths, and the channel's own limit if it is valid
+	channel := models.Channel(req.Channel)
+	validChannel := models.IsValidChannel(req.Channel)
+	violations = append(violations, validatePostText(channel, req.Content, req.Title)...)
+	if !validChannel {
+		add("channel", models.InvalidChannelMessage())
+	}
+
+	postType := models.PostTypeText
+	if req.Type != "" {
+		postType = models.PostType(req.Type)
+	}
+
+	// Resolve media attachments; readiness is checked against the channel below
+	attachments, mediaErr := h.resolveMedia(ctx, user.ID, req.Media)
+
+	if validChannel {
+		if err := models.ValidateTargeting(channel, req.Targeting); err != nil {
+			add("targeting", err.Error())
+		} else if v := models.ValidateChannelFields(channel, req.Title, req.Targeting, attachments); v != nil {
+			violations = append(violations, *v)
+		}
+		if err := models.ValidatePoll(channel, postType, req.Poll); err != nil {
+			add("poll", err.Error())
+		}
+	}
+	if mediaErr != nil {
+		add("media", mediaErr.Error())
+	} else if validChannel {
+		if err := models.ValidatePostMedia(channel, attachments, h.requireAltText); err != nil {
+			add("media", err.Error())
+		}
+	}
+	if validChannel {
+		if err := models.ValidateLocation(channel, req.Location); err != nil {
+			add("location", err.Error())
+		}
+	}
+
+	// Validate evergreen recycling settings
+	if err := models.ValidateRecycle(req.Recycle); err != nil {
+		add("recycle", err.Error())
+	}
+	if !req.Recycle.Enabled() {
+		req.Recycle = nil
+	}
+
+	// Validate the pre-publish reminder
+	if err := models.ValidateRemindBefore(req.RemindBeforeMinutes, false); err != nil {
+		add("remind_before_minutes", err.Error())
+	}
+
+	// Validate the A/B test's second variant against the same channel
+	if validChannel {
+		if err := models.ValidateABTest(channel, req.Content, req.ABTest); err != nil {
+			add("ab_test", err.Error())
+		}
+	}
+
+	// Validate the editorial workflow state and assignee
+	workflowState := models.WorkflowDrafting
+	if req.WorkflowState != "" {
+		if models.IsValidWorkflowState(req.WorkflowState) {
+			workflowState = models.WorkflowState(req.WorkflowState)
+		} else {
+			add("workflow_state", "Invalid workflow_state. Must be one of: idea, drafting, review, approved")
+		}
+	}
+	if workflowState == models.WorkflowApproved && user.WorkspaceID != nil && !user.WorkspaceRole.CanApprove() {
+		add("workflow_state", "Only owners and admins can mark posts approved")
+	}
+	if req.AssigneeID != nil {
+		ok, err := h.canAssign(ctx, user.WorkspaceID, user.ID, *req.AssigneeID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if !ok {
+			add("assignee_id", "Assignee must be a member of the workspace")
+		}
+	}
+
+	// Run the organization's brand checklist. Posts held for approval pass
+	// its approval check once approved.
+	status := initialStatus(user)
+	if mediaErr == nil {
+		brand, err := h.brandViolations(ctx, user.WorkspaceID, models.BrandPost{
+			Title:    req.Title,
+			Content:  req.Content,
+			Media:    attachments,
+			Approved: status != models.PostStatusScheduled || workflowState == models.WorkflowApproved,
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		violations = append(violations, brand...)
+	}
+
+	// Parse and validate scheduled_at, then the organization's publishing
+	// windows and the channel's daily quota for that day
+	var windowOverride bool
+	scheduledAt, err := time.Parse(time.RFC3339, req.ScheduledAt)
+	if err != nil {
+		add("scheduled_at", "Invalid scheduled_at format. Use RFC3339 (e.g., 2024-01-15T14:00:00Z)")
+	} else if err := h.scheduling.Check(user.Plan, scheduledAt, h.clock.Now()); err != nil {
+		add("scheduled_at", err.Error())
+	} else {
+		v, override, err := h.windowViolation(ctx, user.WorkspaceID, user.ID, scheduledAt)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if v != nil {
+			violations = append(violations, *v)
+		}
+		windowOverride = override
+
+		if validChannel {
+			v, err := h.dailyLimitViolation(ctx, user.ID, channel, scheduledAt, uuid.Nil)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if v != nil {
+				violations = append(violations, *v)
+			}
+		}
+	}
+
+	return &db.NewPost{
+		UserID:         user.ID,
+		Title:          req.Title,
+		Content:        req.Content,
+		Channel:        channel,
+		ScheduledAt:    scheduledAt,
+		Targeting:      req.Targeting,
+		Type:           postType,
+		Poll:           req.Poll,
+		Media:          attachments,
+		Location:       req.Location,
+		Recycle:        req.Recycle,
+		Priority:       user.Plan.HasPriorityPublishing(),
+		OrgID:          user.WorkspaceID,
+		Status:         status,
+		WindowOverride: windowOverride,
+		WorkflowState:  workflowState,
+		AssigneeID:     req.AssigneeID,
+		ABTest:         req.ABTest,
+
+		RemindBeforeMinutes: req.RemindBeforeMinutes,
+		NoSignature:         req.NoSignature,
+	}, violations, warnings, nil
+}
+
+// applyPreset fills in a create request from the user's channel presets: the
+// default preset's channel when none is named, then, unless the request skips
+// presets, the channel preset's targeting when the post has none and its
+// footer and hashtags on content and variant B
+func (h *PostHandler) applyPreset(ctx context.Context, userID uuid.UUID, req *models.CreatePostRequest) error {
+	presets, err := h.db.ListChannelPresets(ctx, userID)
+	if err != nil || len(presets) == 0 {
+		return err
+	}
+
+	if req.Channel == "" {
+		for _, p := range presets {
+			if p.IsDefault {
+				req.Channel = string(p.Channel)
+				break
+			}
+		}
+	}
+	if req.SkipPreset {
+		return nil
+	}
+
+	for _, p := range presets {
+		if string(p.Channel) != req.Channel {
+			continue
+		}
+		if req.Targeting == nil && p.Targeting != nil {
+			targeting := *p.Targeting
+			targeting.Audience = append([]string(nil), p.Targeting.Audience...)
+			req.Targeting = &targeting
+		}
+		if content := trimString(req.Content); content != "" {
+			req.Content = p.ApplyContent(content)
+		}
+		if req.ABTest != nil {
+			if variant := trimString(req.ABTest.VariantB); variant != "" {
+				req.ABTest.VariantB = p.ApplyContent(variant)
+			}
+		}
+	}
+	return nil
+}
+
+// initialStatus returns the status of a new post: posts by organization
+// members who can't approve start out pending approval
+func initialStatus(user *models.User) models.PostStatus {
+	if user.WorkspaceID != nil && !user.WorkspaceRole.CanApprove() {
+		return models.PostStatusPendingApproval
+	}
+	return models.PostStatusScheduled
+}
+
+// validatePostText checks trimmed content and title against the length limits
+// every post has, and the content against its channel's limit when the channel
+// is valid. Lengths are counted in graphemes, as users see them.
+func validatePostText(channel models.Channel, content string, title *string) []models.Violation {
+	var violations []models.Violation
+	add := func(field, message string) {
+		violations = append(violations, models.Violation{Field: field, Message: message})
+	}
+
+	switch length := textmetrics.Graphemes(content); {
+	case content == "":
+		add("content", "Content is required")
+	case length < 3:
+		add("content", "Content must be at least 3 characters")
+	case length > 5000:
+		add("content", "Content must not exceed 5000 characters")
+	}
+	if title != nil && textmetrics.Graphemes(*title) > 200 {
+		add("title", "Title must not exceed 200 characters")
+	}
+	if content != "" && models.IsValidChannel(string(channel)) {
+		if err := models.ValidateChannelContent(channel, content); err != nil {
+			add("content", err.Error())
+		}
+	}
+	return violations
+}
+
+// respondViolation responds with a single violation, as a conflict for quota,
+// publishing window and brand checklist violations and a bad request otherwise
+func respondViolation(w http.ResponseWriter, v models.Violation) {
+	if v.Code == dailyLimitCode || v.Code == outsideWindowCode || v.Code == models.BrandCheckCode {
+		respondErrorCode(w, http.StatusConflict, v.Code, v.Message)
+		return
+	}
+	respondError(w, http.StatusBadRequest, v.Message)
+}
+
+// dailyLimitCode is the error code for posts over the channel's daily limit
+const dailyLimitCode = "daily_limit_exceeded"
+
+// dailyLimitViolation returns a violation if the channel's daily limit is already
+// filled on the day of scheduledAt, not counting excludeID
+func (h *PostHandler) dailyLimitViolation(ctx context.Context, userID uuid.UUID, channel models.Channel, scheduledAt time.Time, excludeID uuid.UUID) (*models.Violation, error) {
+	if h.dailyLimits[channel] <= 0 {
+		return nil, nil
+	}
+
+	start, end := models.DayBounds(scheduledAt)
+	count, err := h.db.CountChannelPostsForDay(ctx, userID, channel, start, end, excludeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.dailyLimits.Check(channel, scheduledAt, count); err != nil {
+		return &models.Violation{Field: "scheduled_at", Code: dailyLimitCode, Message: err.Error()}, nil
+	}
+	return nil, nil
+}
+
+// checkDailyLimit responds with an error and returns false if the channel's daily
+// limit is already filled on the day of scheduledAt, not counting excludeID
+func (h *PostHandler) checkDailyLimit(w http.ResponseWriter, ctx context.Context, userID uuid.UUID, channel models.Channel, scheduledAt time.Time, excludeID uuid.UUID) bool {
+	v, err := h.dailyLimitViolation(ctx, userID, channel, scheduledAt, excludeID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check daily limit")
+		return false
+	}
+	if v != nil {
+		respondViolation(w, *v)
+		return false
+	}
+	return true
+}
+
+// outsideWindowCode is the error code for posts scheduled outside the
+// organization's publishing windows
+const outsideWindowCode = "outside_publishing_window"
+
+// windowViolation checks scheduledAt against the publishing windows of the
+// organization orgID (nil for personal posts, which are unrestricted). Outside
+// the windows, it returns a violation unless the user may override them, in
+// which case override is true.
+func (h *PostHandler) windowViolation(ctx context.Context, orgID *uuid.UUID, userID uuid.UUID, scheduledAt time.Time) (*models.Violation, bool, error) {
+	if orgID == nil {
+		return nil, false, nil
+	}
+
+	schedule, err := h.db.GetPublishingSchedule(ctx, *orgID)
+	if err != nil {
+		return nil, false, err
+	}
+	if schedule.Allows(scheduledAt) {
+		return nil, false, nil
+	}
+
+	allowed, err := h.db.CanOverrideWindows(ctx, *orgID, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if allowed {
+		return nil, true, nil
+	}
+
+	return &models.Violation{
+		Field: "scheduled_at",
+		Code:  outsideWindowCode,
+		Message: fmt.Sprintf("scheduled_at is outside the organization's publishing windows; the next window opens at %s",
+			schedule.Next(scheduledAt).Format(time.RFC3339)),
+	}, false, nil
+}
+
+// checkWindow responds with an error and returns false if scheduledAt is
+// outside the organization's publishing windows and the user may not override
+// them. Otherwise it returns whether the post needs an override to publish.
+func (h *PostHandler) checkWindow(w http.ResponseWriter, ctx context.Context, orgID *uuid.UUID, userID uuid.UUID, scheduledAt time.Time) (override bool, ok bool) {
+	v, override, err := h.windowViolation(ctx, orgID, userID, scheduledAt)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check publishing windows")
+		return false, false
+	}
+	if v != nil {
+		respondViolation(w, *v)
+		return false, false
+	}
+	return override, true
+}
+
+// brandViolations runs the brand checklist of the organization orgID, if
+// any, against a post
+func (h *PostHandler) brandViolations(ctx context.Context, orgID *uuid.UUID, p models.BrandPost) ([]models.Violation, error) {
+	if orgID == nil {
+		return nil, nil
+	}
+	checklist, err := h.db.GetBrandChecklist(ctx, *orgID)
+	if err != nil {
+		return nil, err
+	}
+	return checklist.Evaluate(p), nil
+}
+
+// checkBrand responds with the first brand check the post fails and returns
+// false, or returns true if it passes them all
+func (h *PostHandler) checkBrand(w http.ResponseWriter, ctx context.Context, orgID *uuid.UUID, p models.BrandPost) bool {
+	violations, err := h.brandViolations(ctx, orgID, p)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check brand checklist")
+		return false
+	}
+	if len(violations) > 0 {
+		respondViolation(w, violations[0])
+		return false
+	}
+	return true
+}
+
+// resolveMedia looks up the user's media for each attachment request,
+// using the request's alt text when given and the media's default otherwise
+func (h *PostHandler) resolveMedia(ctx context.Context, userID uuid.UUID, reqs []models.MediaAttachmentRequest) ([]models.PostMedia, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, len(reqs))
+	for i, req := range reqs {
+		ids[i] = req.MediaID
+	}
+
+	items, err := h.db.GetMediaByIDs(ctx, userID, ids)
+	if err != nil {
+		return nil, errors.New("failed to look up media")
+	}
+
+	attachments := make([]models.PostMedia, 0, len(reqs))
+	seen := make(map[uuid.UUID]bool, len(reqs))
+	for _, req := range reqs {
+		item, ok := items[req.MediaID]
+		if !ok {
+			return nil, fmt.Errorf("media %s not found", req.MediaID)
+		}
+		if seen[req.MediaID] {
+			return nil, fmt.Errorf("media %s is attached more than once", req.MediaID)
+		}
+		seen[req.MediaID] = true
+
+		altText := item.AltText
+		if req.AltText != nil {
+			if altText, err = normalizeAltText(*req.AltText); err != nil {
+				return nil, err
+			}
+		}
+
+		attachments = append(attachments, models.PostMedia{
+			MediaID:     item.ID,
+			URL:         item.URL,
+			ContentType: item.ContentType,
+			AltText:     altText,
+			Width:       item.Width,
+			Height:      item.Height,
+			DurationMs:  item.DurationMs,
+		})
+	}
+
+	return attachments, nil
+}
+
+// GetUpcoming returns all scheduled posts for the user
+func (h *PostHandler) GetUpcoming(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	filter, err := parsePostFilter(r, user)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	summary, err := parseSummaryFields(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if summary {
+		h.getUpcomingSummaries(w, r, user, filter)
+		return
+	}
+
+	// A ?limit or ?cursor asks for one page; without them the whole list is streamed
+	if filter.Limit > 0 {
+		h.getUpcomingPage(w, r, user, filter)
+		return
+	}
+
+	list := newListWriter(w, r)
+
+	// Try cache first; only unfiltered lists are cached
+	owner := cache.WorkspaceOwner(user)
+	cacheable := h.cache != nil && filter == (db.PostFilter{})
+	if cacheable {
+		if posts, found := h.cache.GetUpcomingPosts(r.Context(), owner); found {
+			writePosts(list, posts)
+			return
+		}
+	}
+
+	posts, ok := streamPosts(r, list, cacheable, func(fn func(*models.Post) error) error {
+		return h.db.EachUpcomingPost(r.Context(), user.ID, user.WorkspaceID, filter, fn)
+	})
+	if !ok {
+		return
+	}
+
+	// Cache the result
+	if cacheable && posts != nil {
+		_ = h.cache.SetUpcomingPosts(r.Context(), owner, posts)
+	}
+
+	list.Close()
+}
+
+// getUpcomingPage responds with the page of upcoming posts the filter's
+// cursor and limit select
+func (h *PostHandler) getUpcomingPage(w http.ResponseWriter, r *http.Request, user *models.User, filter db.PostFilter) {
+	// One more post than the page tells whether another page follows
+	limit := filter.Limit
+	filter.Limit++
+	posts, err := h.db.GetUpcomingPosts(r.Context(), user.ID, user.WorkspaceID, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch posts")
+		return
+	}
+
+	respondPostPage(w, posts, limit, models.CursorAfter)
+}
+
+// getUpcomingSummaries responds with the summaries of the upcoming posts,
+// paged as getUpcomingPage when the filter has a limit
+func (h *PostHandler) getUpcomingSummaries(w http.ResponseWriter, r *http.Request, user *models.User, filter db.PostFilter) {
+	// Only unpaged, unfiltered lists are cached
+	owner := cache.WorkspaceOwner(user)
+	cacheable := h.cache != nil && filter == (db.PostFilter{})
+	if cacheable {
+		if summaries, found := h.cache.GetUpcomingSummaries(r.Context(), owner); found {
+			respondList(w, summaries)
+			return
+		}
+	}
+
+	limit := filter.Limit
+	if limit > 0 {
+		filter.Limit++
+	}
+	summaries, err := h.db.GetUpcomingPostSummaries(r.Context(), user.ID, user.WorkspaceID, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch posts")
+		return
+	}
+
+	if limit > 0 {
+		respondPostPage(w, summaries, limit, func(s *models.PostSummary) models.PostCursor {
+			return models.PostCursor{ScheduledAt: s.ScheduledAt, ID: s.ID}
+		})
+		return
+	}
+
+	if summaries == nil {
+		summaries = []*models.PostSummary{}
+	}
+	if cacheable && len(summaries) <= cache.MaxListPosts {
+		_ = h.cache.SetUpcomingSummaries(r.Context(), owner, summaries)
+	}
+	respondList(w, summaries)
+}
+
+// respondPostPage responds with a page read with one more item than limit,
+// which tells whether another page follows
+func respondPostPage[T any](w http.ResponseWriter, items []T, limit int, cursor func(T) models.PostCursor) {
+	var next string
+	if len(items) > limit {
+		items = items[:limit]
+		next = cursor(items[limit-1]).String()
+	}
+	respondPage(w, items, next, -1)
+}
+
+// GetHistory returns the user's published posts, or failed or canceled ones
+// with ?status, optionally within ?from and ?to
+func (h *PostHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	status, filter, err := parseHistoryFilter(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	summary, err := parseSummaryFields(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Try cache first; only lists without a date range are cached
+	cacheable := h.cache != nil && filter.From == nil && filter.To == nil
+	if summary {
+		h.getHistorySummaries(w, r, user, status, filter, cacheable)
+		return
+	}
+
+	list := newListWriter(w, r)
+	owner := cache.WorkspaceOwner(user)
+	if cacheable {
+		if posts, found := h.cache.GetHistoryPosts(r.Context(), owner, status); found {
+			writePosts(list, posts)
+			return
+		}
+	}
+
+	posts, ok := streamPosts(r, list, cacheable, func(fn func(*models.Post) error) error {
+		return h.db.EachHistoryPost(r.Context(), user.ID, user.WorkspaceID, filter, fn)
+	})
+	if !ok {
+		return
+	}
+
+	// Cache the result
+	if cacheable && posts != nil {
+		_ = h.cache.SetHistoryPosts(r.Context(), owner, status, posts)
+	}
+
+	list.Close()
+}
+
+// getHistorySummaries responds with the summaries of the history posts
+func (h *PostHandler) getHistorySummaries(w http.ResponseWriter, r *http.Request, user *models.User, status string, filter db.HistoryFilter, cacheable bool) {
+	owner := cache.WorkspaceOwner(user)
+	if cacheable {
+		if summaries, found := h.cache.GetHistorySummaries(r.Context(), owner, status); found {
+			respondList(w, summaries)
+			return
+		}
+	}
+
+	summaries, err := h.db.GetHistoryPostSummaries(r.Context(), user.ID, user.WorkspaceID, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch posts")
+		return
+	}
+	if summaries == nil {
+		summaries = []*models.PostSummary{}
+	}
+
+	if cacheable && len(summaries) <= cache.MaxListPosts {
+		_ = h.cache.SetHistorySummaries(r.Context(), owner, status, summaries)
+	}
+	respondList(w, summaries)
+}
+
+// streamPosts writes the posts each reads to list as they are read. With
+// collect set it also returns them, unless there are too many to cache. It
+// reports false, after responding, if the posts couldn't be read.
+func streamPosts(r *http.Request, list *listWriter, collect bool, each func(func(*models.Post) error) error) ([]*models.Post, bool) {
+	var posts []*models.Post
+	if collect {
+		posts = []*models.Post{}
+	}
+
+	err := each(func(post *models.Post) error {
+		if posts != nil {
+			if len(posts) < cache.MaxListPosts {
+				posts = append(posts, post)
+			} else {
+				posts = nil
+			}
+		}
+		return list.Write(post)
+	})
+	if err != nil {
+		// A client that went away needs no response
+		if r.Context().Err() == nil {
+			list.Fail(http.StatusInternalServerError, "Failed to fetch posts")
+		}
+		return nil, false
+	}
+	return posts, true
+}
+
+// writePosts writes a list already in memory, such as a cached one
+func writePosts(list *listWriter, posts []*models.Post) {
+	for _, post := range posts {
+		if err := list.Write(post); err != nil {
+			return
+		}
+	}
+	list.Close()
+}
+
+// GetByID returns a single post by ID, with ETag and Last-Modified headers
+func (h *PostHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	post, err := h.db.GetPostByID(r.Context(), postID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch post")
+		return
+	}
+
+	if post == nil {
+		respondError(w, http.StatusNotFound, "Post not found")
+		return
+	}
+
+	// Check ownership; organization posts are visible to the workspace's members
+	if post.UserID != user.ID && !post.InWorkspace(user.WorkspaceID) {
+		respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	// Clients polling a post's status can revalidate with If-None-Match or If-Modified-Since
+	respondJSONConditional(w, r, post, post.UpdatedAt)
+}
+
+// Update updates a scheduled post
+func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	// Check if post exists and is owned by user
+	existingPost, err := h.db.GetPostByID(r.Context(), postID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch post")
+		return
+	}
+	if existingPost == nil {
+		respondError(w, http.StatusNotFound, "Post not found")
+		return
+	}
+	if existingPost.UserID != user.ID {
+		respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	var req models.UpdatePostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Strip invisible characters and unsafe links from replacement text
+	var warnings []models.ContentWarning
+	if req.Content != nil {
+		content, changes := models.SanitizeText("content", *req.Content)
+		req.Content = &content
+		warnings = append(warnings, changes...)
+	}
+	if req.Title != nil {
+		title, changes := models.SanitizeText("title", *req.Title)
+		req.Title = &title
+		warnings = append(warnings, changes...)
+	}
+
+	// A failed post can be fixed and resent by setting its status back to scheduled
+	if req.Status != nil && *req.Status != string(models.PostStatusScheduled) {
+		respondError(w, http.StatusBadRequest, "Invalid status. Only scheduled is allowed")
+		return
+	}
+	retry := existingPost.Status == models.PostStatusFailed && req.Status != nil
+	if existingPost.Status != models.PostStatusScheduled && !retry {
+		if existingPost.Status == models.PostStatusFailed {
+			respondError(w, http.StatusBadRequest, "Set status to scheduled to retry a failed post")
+			return
+		}
+		respondError(w, http.StatusBadRequest, "Cannot update a post that is not scheduled")
+		return
+	}
+
+	// Validate channel if provided
+	var channel *models.Channel
+	if req.Channel != nil {
+		if !models.IsValidChannel(*req.Channel) {
+			respondError(w, http.StatusBadRequest, models.InvalidChannelMessage())
+			return
+		}
+		ch := models.Channel(*req.Channel)
+		channel = &ch
+	}
+
+	// Validate targeting against the effective channel. Switching to a channel
+	// without targeting support drops existing targeting.
+	effectiveChannel := existingPost.Channel
+	if channel != nil {
+		effectiveChannel = *channel
+	}
+
+	// Check the edited text, or the existing text against a new channel's limit
+	effectiveContent := existingPost.Content
+	if req.Content != nil {
+		effectiveContent = trimString(*req.Content)
+	}
+	effectiveTitle := existingPost.Title
+	if req.Title != nil {
+		if trimmed := trimString(*req.Title); trimmed == "" {
+			effectiveTitle = nil
+		} else {
+			effectiveTitle = &trimmed
+		}
+	}
+	if v := validatePostText(effectiveChannel, effectiveContent, effectiveTitle); len(v) > 0 {
+		respondViolation(w, v[0])
+		return
+	}
+	clearTargeting := req.Targeting == nil && !models.SupportsTargeting(effectiveChannel)
+	if req.Targeting != nil {
+		if err := models.ValidateTargeting(effectiveChannel, req.Targeting); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	} else if existingPost.Targeting != nil && !clearTargeting {
+		existing := *existingPost.Targeting
+		if err := models.ValidateTargeting(effectiveChannel, &existing); err != nil {
+			respondError(w, http.StatusBadRequest, "Existing targeting is not valid for this channel: "+err.Error())
+			return
+		}
+	}
+
+	// Validate post type and poll against the effective channel.
+	// Switching a post to text drops its poll.
+	postType := existingPost.Type
+	var newType *models.PostType
+	if req.Type != nil {
+		postType = models.PostType(*req.Type)
+		newType = &postType
+	}
+	poll := req.Poll
+	if poll == nil && postType == models.PostTypePoll && existingPost.Poll != nil {
+		existing := *existingPost.Poll
+		existing.Options = append([]string(nil), existing.Options...)
+		poll = &existing
+	}
+	if err := models.ValidatePoll(effectiveChannel, postType, poll); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	clearPoll := postType != models.PostTypePoll
+
+	// Resolve replacement attachments, or re-check existing ones against the effective channel
+	attachments := existingPost.Media
+	if req.Media != nil {
+		if attachments, err = h.resolveMedia(r.Context(), user.ID, *req.Media); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if err := models.ValidatePostMedia(effectiveChannel, attachments, h.requireAltText); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var newMedia []models.PostMedia
+	clearMedia := false
+	if req.Media != nil {
+		newMedia = attachments
+		clearMedia = len(attachments) == 0
+	}
+
+	// Check the fields the effective channel requires, such as a Reddit
+	// post's title and subreddit
+	effectiveTargeting := req.Targeting
+	if effectiveTargeting == nil && !clearTargeting {
+		effectiveTargeting = existingPost.Targeting
+	}
+	if v := models.ValidateChannelFields(effectiveChannel, effectiveTitle, effectiveTargeting, attachments); v != nil {
+		respondViolation(w, *v)
+		return
+	}
+
+	// Re-run the organization's brand checklist on the edited post; it was
+	// approved, if required, when it was scheduled
+	if !h.checkBrand(w, r.Context(), existingPost.OrgID, models.BrandPost{
+		Title:    effectiveTitle,
+		Content:  effectiveContent,
+		Media:    attachments,
+		Approved: true,
+	}) {
+		return
+	}
+
+	// Validate location tag. Switching to a channel without location support drops it.
+	if err := models.ValidateLocation(effectiveChannel, req.Location); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	clearLocation := req.Location == nil && !models.SupportsLocation(effectiveChannel)
+
+	// Validate recycling settings; a max_count of zero turns recycling off
+	if err := models.ValidateRecycle(req.Recycle); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	clearRecycle := req.Recycle != nil && !req.Recycle.Enabled()
+	if clearRecycle {
+		req.Recycle = nil
+	}
+
+	// Validate the reminder; zero turns it off
+	if err := models.ValidateRemindBefore(req.RemindBeforeMinutes, true); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Parse and validate scheduled_at if provided
+	var scheduledAt *time.Time
+	if req.ScheduledAt != nil {
+		parsed, err := time.Parse(time.RFC3339, *req.ScheduledAt)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid scheduled_at format. Use RFC3339")
+			return
+		}
+		if err := h.scheduling.Check(user.Plan, parsed, h.clock.Now()); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		scheduledAt = &parsed
+	}
+
+	// A retried post whose time has passed is resent right away
+	if retry && scheduledAt == nil {
+		next := existingPost.ScheduledAt
+		if now := h.clock.Now(); next.Before(now) {
+			next = now
+		}
+		scheduledAt = &next
+	}
+
+	// Re-check the publishing windows when the post moves
+	var windowOverride *bool
+	if scheduledAt != nil {
+		override, ok := h.checkWindow(w, r.Context(), existingPost.OrgID, user.ID, *scheduledAt)
+		if !ok {
+			return
+		}
+		windowOverride = &override
+	}
+
+	// Re-check the daily limit when the post moves to another channel or day
+	if channel != nil || scheduledAt != nil {
+		effectiveTime := existingPost.ScheduledAt
+		if scheduledAt != nil {
+			effectiveTime = *scheduledAt
+		}
+		if !h.checkDailyLimit(w, r.Context(), user.ID, effectiveChannel, effectiveTime, postID) {
+			return
+		}
+	}
+
+	// Update post
+	post, err := h.db.UpdatePost(r.Context(), postID, user.ID, db.PostUpdate{
+		Title:          req.Title,
+		Content:        req.Content,
+		Channel:        channel,
+		ScheduledAt:    scheduledAt,
+		Targeting:      req.Targeting,
+		ClearTargeting: clearTargeting,
+		Type:           newType,
+		Poll:           req.Poll,
+		ClearPoll:      clearPoll,
+		Media:          newMedia,
+		ClearMedia:     clearMedia,
+		Location:       req.Location,
+		ClearLocation:  clearLocation,
+		Recycle:        req.Recycle,
+		ClearRecycle:   clearRecycle,
+		WindowOverride: windowOverride,
+
+		RemindBeforeMinutes: req.RemindBeforeMinutes,
+		NoSignature:         req.NoSignature,
+		Retry:               retry,
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update post")
+		return
+	}
+
+	if post == nil {
+		respondError(w, http.StatusNotFound, "Post not found or cannot be updated")
+		return
+	}
+
+	// Update queue if scheduled_at changed (async)
+	if scheduledAt != nil {
+		go func() {
+			if err := h.queue.Update(context.Background(), post.ID, *scheduledAt, post.Priority); err != nil {
+				log.Printf("⚠️ Failed to update queue for post %s: %v", post.ID, err)
+			}
+		}()
+	}
+
+	recordPostAudit(r.Context(), h.db, user, post, models.AuditPostUpdate, postAuditDetails(post))
+
+	// Move or cancel the reminder if its timing changed (async)
+	if scheduledAt != nil || req.RemindBeforeMinutes != nil {
+		go h.syncReminder(post)
+	}
+
+	// Invalidate cache (async)
+	go func() {
+		if h.cache != nil {
+			_ = h.cache.InvalidatePost(context.Background(), post)
+		}
+	}()
+
+	// Notify SSE clients of the update
+	log.Printf("📢 [POST UPDATE] Sending notification for user %s, post %s", user.ID, post.ID)
+	h.notifier.Notify(user.ID, notifier.UpdateTypeUpdate)
+	log.Printf("✅ [POST UPDATE] Notification sent for user %s", user.ID)
+
+	respondJSON(w, http.StatusOK, models.UpdatePostResponse{Post: post, Warnings: warnings})
+}
+
+// PublishNow moves a scheduled post to the front of the queue to publish immediately
+func (h *PostHandler) PublishNow(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	existingPost, err := h.db.GetPostByID(r.Context(), postID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch post")
+		return
+	}
+	if existingPost == nil {
+		respondError(w, http.StatusNotFound, "Post not found")
+		return
+	}
+	if existingPost.UserID != user.ID {
+		respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+	if existingPost.Status != models.PostStatusScheduled {
+		respondError(w, http.StatusBadRequest, "Cannot publish a post that is not scheduled")
+		return
+	}
+
+	// Publishing now may fall outside the publishing windows or move the post
+	// into today's daily limit
+	windowOverride, ok := h.checkWindow(w, r.Context(), existingPost.OrgID, user.ID, h.clock.Now())
+	if !ok {
+		return
+	}
+	if !h.checkDailyLimit(w, r.Context(), user.ID, existingPost.Channel, h.clock.Now(), postID) {
+		return
+	}
+
+	post, err := h.db.PublishPostNow(r.Context(), postID, user.ID, windowOverride)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to publish post")
+		return
+	}
+	if post == nil {
+		respondError(w, http.StatusNotFound, "Post not found or cannot be published")
+		return
+	}
+
+	if err := h.queue.Enqueue(r.Context(), post.ID, post.ScheduledAt, true); err != nil {
+		log.Printf("⚠️ Failed to enqueue post %s for immediate publishing: %v", post.ID, err)
+		respondError(w, http.StatusInternalServerError, "Failed to queue post")
+		return
+	}
+
+	// Invalidate cache (async)
+	go func() {
+		if h.cache != nil {
+			_ = h.cache.InvalidatePost(context.Background(), post)
+		}
+	}()
+
+	h.notifier.Notify(user.ID, notifier.UpdateTypeUpdate)
+
+	respondJSON(w, http.StatusAccepted, post)
+}
+
+// Delete deletes a scheduled post
+func (h *PostHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
 		return
 	}
 
-	// Parse and validate scheduled_at
-	scheduledAt, err := time.Parse(time.RFC3339, req.ScheduledAt)
+	// Check if post exists and is owned by user
+	existingPost, err := h.db.GetPostByID(r.Context(), postID)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid scheduled_at format. Use RFC3339 (e.g., 2024-01-15T14:00:00Z)")
+		respondError(w, http.StatusInternalServerError, "Failed to fetch post")
 		return
 	}
-
-	if scheduledAt.Before(time.Now()) {
-		respondError(w, http.StatusBadRequest, "scheduled_at must be in the future")
+	if existingPost == nil {
+		respondError(w, http.StatusNotFound, "Post not found")
 		return
 	}
-
-	// Validate scheduled_at is not too far in the future (max 1 year)
-	maxFutureDate := time.Now().AddDate(1, 0, 0)
-	if scheduledAt.After(maxFutureDate) {
-		respondError(w, http.StatusBadRequest, "scheduled_at cannot be more than 1 year in the future")
+	if existingPost.UserID != user.ID {
+		respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+	if existingPost.Status != models.PostStatusScheduled && existingPost.Status != models.PostStatusPendingApproval {
+		respondError(w, http.StatusBadRequest, "Cannot delete a post that is not scheduled")
 		return
 	}
 
-	// Create post in database
-	post, err := h.db.CreatePost(r.Context(), user.ID, req.Title, req.Content, models.Channel(req.Channel), scheduledAt)
+	// Delete from database
+	deleted, err := h.db.DeletePost(r.Context(), postID, user.ID)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to create post")
+		respondError(w, http.StatusInternalServerError, "Failed to delete post")
+		return
+	}
+
+	if !deleted {
+		respondError(w, http.StatusNotFound, "Post not found or cannot be deleted")
 		return
 	}
+	recordPostAudit(r.Context(), h.db, user, existingPost, models.AuditPostDelete, postAuditDetails(existingPost))
 
-	// Add to scheduling queue (async, don't block response)
+	// Remove from queue (async)
 	go func() {
-		if err := h.queue.Enqueue(context.Background(), post.ID, scheduledAt); err != nil {
-			log.Printf("⚠️ Failed to enqueue post %s: %v", post.ID, err)
-		}
+		_ = h.queue.Remove(context.Background(), postID)
 	}()
 
 	// Invalidate cache (async)
 	go func() {
 		if h.cache != nil {
-			_ = h.cache.InvalidateUserPosts(context.Background(), user.ID)
+			_ = h.cache.InvalidatePost(context.Background(), existingPost)
 		}
 	}()
 
-	// Notify SSE clients of the new post (async for Redis pub, sync for local)
-	log.Printf("📢 [POST CREATE] Sending notification for user %s, post %s", user.ID, post.ID)
-	h.notifier.Notify(user.ID, notifier.UpdateTypeCreate)
-	log.Printf("✅ [POST CREATE] Notification sent for user %s", user.ID)
+	// Notify SSE clients of the deletion
+	log.Printf("📢 [POST DELETE] Sending notification for user %s, post %s", user.ID, postID)
+	h.notifier.Notify(user.ID, notifier.UpdateTypeDelete)
+	log.Printf("✅ [POST DELETE] Notification sent for user %s", user.ID)
 
-	respondJSON(w, http.StatusCreated, post)
+	w.WriteHeader(http.StatusNoContent)
 }
 
-// GetUpcoming returns all scheduled posts for the user
-func (h *PostHandler) GetUpcoming(w http.ResponseWriter, r *http.Request) {
+// Abort cancels a post during its undo window, the grace period between the
+// post coming due and the platform call
+func (h *PostHandler) Abort(w http.ResponseWriter, r *http.Request) {
+	h.cancel(w, r, h.db.AbortPublish, "Post is not in its undo window")
+}
+
+// Cancel stops a scheduled, due or pending post from publishing. Unlike
+// Delete, the post is kept with status canceled.
+func (h *PostHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.cancel(w, r, h.db.CancelPost, "Only scheduled or pending posts can be canceled")
+}
+
+// cancel applies a cancellation to the user's post from the URL, responding
+// 409 with conflictMsg when it doesn't apply, and takes the post off the queue
+func (h *PostHandler) cancel(w http.ResponseWriter, r *http.Request, apply func(context.Context, uuid.UUID, uuid.UUID) (*models.Post, error), conflictMsg string) {
 	user := GetUserFromContext(r.Context())
 	if user == nil {
 		respondError(w, http.StatusUnauthorized, "Not authenticated")
 		return
 	}
 
-	// Try cache first
-	if h.cache != nil {
-		if posts, found := h.cache.GetUpcomingPosts(r.Context(), user.ID); found {
-			respondJSON(w, http.StatusOK, posts)
-			return
-		}
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
 	}
 
-	posts, err := h.db.GetUpcomingPosts(r.Context(), user.ID)
+	post, err := apply(r.Context(), postID, user.ID)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch posts")
+		respondError(w, http.StatusInternalServerError, "Failed to cancel post")
 		return
 	}
-
-	if posts == nil {
-		posts = []*models.Post{}
+	if post == nil {
+		respondError(w, http.StatusConflict, conflictMsg)
+		return
 	}
 
-	// Cache the result
-	if h.cache != nil {
-		_ = h.cache.SetUpcomingPosts(r.Context(), user.ID, posts)
+	// Remove synchronously so the worker doesn't pick the post up again
+	if err := h.queue.Remove(r.Context(), postID); err != nil {
+		log.Printf("⚠️ Failed to remove canceled post %s from queue: %v", postID, err)
 	}
 
-	respondJSON(w, http.StatusOK, posts)
+	go func() {
+		if h.cache != nil {
+			_ = h.cache.InvalidatePost(context.Background(), post)
+		}
+	}()
+
+	h.notifier.Notify(user.ID, notifier.UpdateTypeUpdate)
+
+	respondJSON(w, http.StatusOK, post)
 }
 
-// GetHistory returns all published posts for the user
-func (h *PostHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+// Approve schedules a post that is pending approval. Only owners and admins
+// of the post's organization may approve it.
+func (h *PostHandler) Approve(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r.Context())
 	if user == nil {
 		respondError(w, http.StatusUnauthorized, "Not authenticated")
 		return
 	}
 
-	// Try cache first
-	if h.cache != nil {
-		if posts, found := h.cache.GetHistoryPosts(r.Context(), user.ID); found {
-			respondJSON(w, http.StatusOK, posts)
-			return
-		}
+	existingPost, ok := h.loadPendingPost(w, r, user)
+	if !ok {
+		return
 	}
 
-	posts, err := h.db.GetPublishedPosts(r.Context(), user.ID)
-	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch posts")
+	// The post may predate the organization's brand checklist
+	if !h.checkBrand(w, r.Context(), existingPost.OrgID, models.BrandPost{
+		Title:    existingPost.Title,
+		Content:  existingPost.Content,
+		Media:    existingPost.Media,
+		Approved: true,
+	}) {
 		return
 	}
 
-	if posts == nil {
-		posts = []*models.Post{}
+	post, err := h.db.ApprovePost(r.Context(), existingPost.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to approve post")
+		return
 	}
-
-	// Cache the result
-	if h.cache != nil {
-		_ = h.cache.SetHistoryPosts(r.Context(), user.ID, posts)
+	if post == nil {
+		respondError(w, http.StatusConflict, "Post is no longer pending approval")
+		return
 	}
+	recordPostAudit(r.Context(), h.db, user, post, models.AuditPostApprove, postAuditDetails(post))
+
+	// Queue the approved post; one already past its time publishes right away
+	go func() {
+		if err := h.queue.Enqueue(context.Background(), post.ID, post.ScheduledAt, post.Priority); err != nil {
+			log.Printf("⚠️ Failed to enqueue post %s: %v", post.ID, err)
+		}
+		h.syncReminder(post)
+	}()
 
-	respondJSON(w, http.StatusOK, posts)
+	h.notifyReview(post)
+	respondJSON(w, http.StatusOK, post)
 }
 
-// GetByID returns a single post by ID
-func (h *PostHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+// Reject rejects a post that is pending approval with an optional reason
+func (h *PostHandler) Reject(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r.Context())
 	if user == nil {
 		respondError(w, http.StatusUnauthorized, "Not authenticated")
 		return
 	}
 
+	var req models.RejectPostRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+	reason := trimString(req.Reason)
+	if reason == "" {
+		reason = "Rejected by " + user.Email
+	}
+	if len(reason) > 500 {
+		respondError(w, http.StatusBadRequest, "Reason must not exceed 500 characters")
+		return
+	}
+
+	existingPost, ok := h.loadPendingPost(w, r, user)
+	if !ok {
+		return
+	}
+
+	post, err := h.db.RejectPost(r.Context(), existingPost.ID, reason)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to reject post")
+		return
+	}
+	if post == nil {
+		respondError(w, http.StatusConflict, "Post is no longer pending approval")
+		return
+	}
+	details := postAuditDetails(post)
+	details["reason"] = reason
+	recordPostAudit(r.Context(), h.db, user, post, models.AuditPostReject, details)
+
+	h.notifyReview(post)
+	respondJSON(w, http.StatusOK, post)
+}
+
+// loadPendingPost fetches the post in the URL and checks that it is pending
+// approval and that the user can approve it. Responds with an error and
+// returns false otherwise.
+func (h *PostHandler) loadPendingPost(w http.ResponseWriter, r *http.Request, user *models.User) (*models.Post, bool) {
 	postID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid post ID")
-		return
+		return nil, false
 	}
 
 	post, err := h.db.GetPostByID(r.Context(), postID)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch post")
-		return
+		return nil, false
 	}
-
-	if post == nil {
+	if post == nil || post.OrgID == nil {
 		respondError(w, http.StatusNotFound, "Post not found")
-		return
+		return nil, false
 	}
 
-	// Check ownership
-	if post.UserID != user.ID {
-		respondError(w, http.StatusForbidden, "Access denied")
-		return
+	role, err := h.db.GetMemberRole(r.Context(), *post.OrgID, user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch membership")
+		return nil, false
+	}
+	if role == "" {
+		respondError(w, http.StatusNotFound, "Post not found")
+		return nil, false
+	}
+	if !role.CanApprove() {
+		respondError(w, http.StatusForbidden, "Only owners and admins can review posts")
+		return nil, false
+	}
+	if post.Status != models.PostStatusPendingApproval {
+		respondError(w, http.StatusConflict, "Post is not pending approval")
+		return nil, false
 	}
 
-	respondJSON(w, http.StatusOK, post)
+	return post, true
 }
 
-// Update updates a scheduled post
-func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
+// notifyReview tells the post's author that it was approved or rejected, over
+// the channels their preferences route approvals to
+func (h *PostHandler) notifyReview(post *models.Post) {
+	go func() {
+		ctx := context.Background()
+		if h.cache != nil {
+			_ = h.cache.InvalidatePost(ctx, post)
+		}
+
+		author, err := h.db.GetUserByID(ctx, post.UserID)
+		if err != nil || author == nil {
+			log.Printf("⚠️ Failed to look up author of post %s: %v", post.ID, err)
+			return
+		}
+		n := scheduler.Notification{
+			Event:   models.NotifyApproval,
+			PostID:  &post.ID,
+			Subject: "Your post was approved",
+			Body: fmt.Sprintf("Your post scheduled for %s was approved and will publish to %s.",
+				post.ScheduledAt.UTC().Format(time.RFC1123), post.Channel),
+		}
+		if post.Status == models.PostStatusRejected {
+			n.Subject = "Your post was rejected"
+			n.Body = fmt.Sprintf("Your post scheduled for %s was rejected.", post.ScheduledAt.UTC().Format(time.RFC1123))
+			if post.LastError != nil && *post.LastError != "" {
+				n.Body += " Reason: " + *post.LastError
+			}
+		}
+		h.dispatch.Send(ctx, author, n)
+	}()
+	h.notifier.Notify(post.UserID, notifier.UpdateTypeApproval)
+}
+
+// UpdateWorkflow moves a post on the editorial board and changes its assignee.
+// Anyone who can see the post may update it, but in organizations only owners
+// and admins can mark posts approved.
+func (h *PostHandler) UpdateWorkflow(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r.Context())
 	if user == nil {
 		respondError(w, http.StatusUnauthorized, "Not authenticated")
@@ -247,7 +1656,6 @@ func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check if post exists and is owned by user
 	existingPost, err := h.db.GetPostByID(r.Context(), postID)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch post")
@@ -257,144 +1665,206 @@ func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusNotFound, "Post not found")
 		return
 	}
-	if existingPost.UserID != user.ID {
+	if existingPost.UserID != user.ID && !existingPost.InWorkspace(user.WorkspaceID) {
 		respondError(w, http.StatusForbidden, "Access denied")
 		return
 	}
-	if existingPost.Status != models.PostStatusScheduled {
-		respondError(w, http.StatusBadRequest, "Cannot update a post that is not scheduled")
-		return
-	}
 
-	var req models.UpdatePostRequest
+	var req models.UpdateWorkflowRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	// Validate channel if provided
-	var channel *models.Channel
-	if req.Channel != nil {
-		if !models.IsValidChannel(*req.Channel) {
-			respondError(w, http.StatusBadRequest, "Invalid channel. Must be one of: twitter, linkedin, facebook")
+	var state *models.WorkflowState
+	if req.WorkflowState != nil {
+		if !models.IsValidWorkflowState(*req.WorkflowState) {
+			respondError(w, http.StatusBadRequest, "Invalid workflow_state. Must be one of: idea, drafting, review, approved")
+			return
+		}
+		s := models.WorkflowState(*req.WorkflowState)
+		state = &s
+	}
+	if state != nil && *state == models.WorkflowApproved && existingPost.OrgID != nil {
+		role, err := h.db.GetMemberRole(r.Context(), *existingPost.OrgID, user.ID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch membership")
+			return
+		}
+		if !role.CanApprove() {
+			respondError(w, http.StatusForbidden, "Only owners and admins can mark posts approved")
 			return
 		}
-		ch := models.Channel(*req.Channel)
-		channel = &ch
 	}
 
-	// Parse and validate scheduled_at if provided
-	var scheduledAt *time.Time
-	if req.ScheduledAt != nil {
-		parsed, err := time.Parse(time.RFC3339, *req.ScheduledAt)
+	if req.AssigneeID != nil {
+		if req.Unassign {
+			respondError(w, http.StatusBadRequest, "Cannot set assignee_id and unassign together")
+			return
+		}
+		ok, err := h.canAssign(r.Context(), existingPost.OrgID, existingPost.UserID, *req.AssigneeID)
 		if err != nil {
-			respondError(w, http.StatusBadRequest, "Invalid scheduled_at format. Use RFC3339")
+			respondError(w, http.StatusInternalServerError, "Failed to check assignee")
 			return
 		}
-		if parsed.Before(time.Now()) {
-			respondError(w, http.StatusBadRequest, "scheduled_at must be in the future")
+		if !ok {
+			respondError(w, http.StatusBadRequest, "Assignee must be a member of the workspace")
 			return
 		}
-		scheduledAt = &parsed
 	}
 
-	// Update post
-	post, err := h.db.UpdatePost(r.Context(), postID, user.ID, req.Title, req.Content, channel, scheduledAt)
+	post, err := h.db.SetPostWorkflow(r.Context(), postID, state, req.AssigneeID, req.Unassign)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to update post")
+		respondError(w, http.StatusInternalServerError, "Failed to update workflow")
 		return
 	}
-
 	if post == nil {
-		respondError(w, http.StatusNotFound, "Post not found or cannot be updated")
+		respondError(w, http.StatusNotFound, "Post not found")
 		return
 	}
+	recordPostAudit(r.Context(), h.db, user, post, models.AuditPostWorkflow, map[string]any{
+		"workflow_state": post.WorkflowState,
+		"assignee_id":    post.AssigneeID,
+	})
 
-	// Update queue if scheduled_at changed (async)
-	if scheduledAt != nil {
-		go func() {
-			if err := h.queue.Update(context.Background(), post.ID, *scheduledAt); err != nil {
-				log.Printf("⚠️ Failed to update queue for post %s: %v", post.ID, err)
-			}
-		}()
-	}
-
-	// Invalidate cache (async)
 	go func() {
 		if h.cache != nil {
-			_ = h.cache.InvalidateUserPosts(context.Background(), user.ID)
+			_ = h.cache.InvalidatePost(context.Background(), post)
 		}
 	}()
-
-	// Notify SSE clients of the update
-	log.Printf("📢 [POST UPDATE] Sending notification for user %s, post %s", user.ID, post.ID)
-	h.notifier.Notify(user.ID, notifier.UpdateTypeUpdate)
-	log.Printf("✅ [POST UPDATE] Notification sent for user %s", user.ID)
+	notifyPostAudience(r.Context(), h.db, h.notifier, post, notifier.UpdateTypeWorkflow, uuid.Nil)
 
 	respondJSON(w, http.StatusOK, post)
 }
 
-// Delete deletes a scheduled post
-func (h *PostHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	user := GetUserFromContext(r.Context())
-	if user == nil {
-		respondError(w, http.StatusUnauthorized, "Not authenticated")
-		return
+// canAssign reports whether assigneeID may be assigned posts in the workspace:
+// any member of the organization orgID, or only the author authorID for
+// personal posts
+func (h *PostHandler) canAssign(ctx context.Context, orgID *uuid.UUID, authorID, assigneeID uuid.UUID) (bool, error) {
+	if orgID == nil {
+		return assigneeID == authorID, nil
 	}
-
-	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	role, err := h.db.GetMemberRole(ctx, *orgID, assigneeID)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid post ID")
-		return
+		return false, err
 	}
+	return role != "", nil
+}
 
-	// Check if post exists and is owned by user
-	existingPost, err := h.db.GetPostByID(r.Context(), postID)
-	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch post")
-		return
+// parsePostFilter reads the workflow_state and assignee query parameters;
+// assignee may be a user ID or "me"
+func parsePostFilter(r *http.Request, user *models.User) (db.PostFilter, error) {
+	var filter db.PostFilter
+	q := r.URL.Query()
+
+	if s := q.Get("workflow_state"); s != "" {
+		if !models.IsValidWorkflowState(s) {
+			return filter, errors.New("Invalid workflow_state. Must be one of: idea, drafting, review, approved")
+		}
+		state := models.WorkflowState(s)
+		filter.WorkflowState = &state
 	}
-	if existingPost == nil {
-		respondError(w, http.StatusNotFound, "Post not found")
-		return
+
+	switch a := q.Get("assignee"); a {
+	case "":
+	case "me":
+		filter.AssigneeID = &user.ID
+	default:
+		id, err := uuid.Parse(a)
+		if err != nil {
+			return filter, errors.New("Invalid assignee")
+		}
+		filter.AssigneeID = &id
 	}
-	if existingPost.UserID != user.ID {
-		respondError(w, http.StatusForbidden, "Access denied")
-		return
+
+	if c := q.Get("cursor"); c != "" {
+		cursor, err := models.ParsePostCursor(c)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = &cursor
+		filter.Limit = models.DefaultPageSize
 	}
-	if existingPost.Status != models.PostStatusScheduled {
-		respondError(w, http.StatusBadRequest, "Cannot delete a post that is not scheduled")
-		return
+	if l := q.Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit < 1 || limit > models.MaxPageSize {
+			return filter, fmt.Errorf("Invalid limit. Must be between 1 and %d", models.MaxPageSize)
+		}
+		filter.Limit = limit
 	}
 
-	// Delete from database
-	deleted, err := h.db.DeletePost(r.Context(), postID, user.ID)
-	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to delete post")
-		return
-	}
+	return filter, nil
+}
 
-	if !deleted {
-		respondError(w, http.StatusNotFound, "Post not found or cannot be deleted")
-		return
+// parseSummaryFields reports whether ?fields asks for post summaries, the
+// only projection lists offer
+func parseSummaryFields(r *http.Request) (bool, error) {
+	switch r.URL.Query().Get("fields") {
+	case "":
+		return false, nil
+	case "summary":
+		return true, nil
+	default:
+		return false, errors.New("Invalid fields. Must be summary")
 	}
+}
 
-	// Remove from queue (async)
-	go func() {
-		_ = h.queue.Remove(context.Background(), postID)
-	}()
+// parseHistoryFilter reads the status, from and to query parameters, returning
+// the status filter (defaulting to published) alongside the filter
+func parseHistoryFilter(r *http.Request) (string, db.HistoryFilter, error) {
+	var filter db.HistoryFilter
+	q := r.URL.Query()
 
-	// Invalidate cache (async)
-	go func() {
-		if h.cache != nil {
-			_ = h.cache.InvalidateUserPosts(context.Background(), user.ID)
+	status := q.Get("status")
+	if status == "" {
+		status = string(models.PostStatusPublished)
+	}
+	statuses, ok := models.HistoryStatuses(status)
+	if !ok {
+		return "", filter, errors.New("Invalid status. Must be one of: published, failed, canceled, all")
+	}
+	filter.Statuses = statuses
+
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		s := q.Get(p.name)
+		if s == "" {
+			continue
 		}
-	}()
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return "", filter, fmt.Errorf("Invalid %s format. Use RFC3339", p.name)
+		}
+		*p.dst = &t
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return "", filter, errors.New("from must be before to")
+	}
 
-	// Notify SSE clients of the deletion
-	log.Printf("📢 [POST DELETE] Sending notification for user %s, post %s", user.ID, postID)
-	h.notifier.Notify(user.ID, notifier.UpdateTypeDelete)
-	log.Printf("✅ [POST DELETE] Notification sent for user %s", user.ID)
+	return status, filter, nil
+}
 
-	w.WriteHeader(http.StatusNoContent)
+// notifyPostAudience sends a post-scoped update to everyone who can see the
+// post except exclude: its author and, for organization posts, every member
+func notifyPostAudience(ctx context.Context, database db.Store, n *notifier.Notifier, post *models.Post, updateType notifier.UpdateType, exclude uuid.UUID) {
+	recipients := []uuid.UUID{post.UserID}
+	if post.OrgID != nil {
+		members, err := database.ListOrganizationMembers(ctx, *post.OrgID)
+		if err != nil {
+			log.Printf("⚠️ Failed to list members to notify about post %s: %v", post.ID, err)
+		}
+		for _, m := range members {
+			if m.UserID != post.UserID {
+				recipients = append(recipients, m.UserID)
+			}
+		}
+	}
+
+	for _, id := range recipients {
+		if id != exclude {
+			n.NotifyPost(id, updateType, post.ID)
+		}
+	}
 }
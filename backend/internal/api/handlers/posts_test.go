@@ -0,0 +1,344 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/clock"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+	"github.com/scheduler/backend/internal/scheduler"
+)
+
+func TestPostHandler_GetUpcoming_Pages(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	posts := postList(5)
+
+	// The mock pages through posts as the keyset query would
+	store := &dbmock.Store{
+		GetUpcomingPostsFunc: func(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.PostFilter) ([]*models.Post, error) {
+			start := 0
+			if filter.After != nil {
+				for i, p := range posts {
+					if p.ID == filter.After.ID {
+						start = i + 1
+					}
+				}
+			}
+			end := len(posts)
+			if filter.Limit > 0 && start+filter.Limit < end {
+				end = start + filter.Limit
+			}
+			return posts[start:end], nil
+		},
+	}
+	h := NewPostHandler(store, nil, nil, nil, false, nil, models.SchedulingPolicy{}, nil, nil, clock.Real)
+
+	get := func(query string) (int, models.ListResponse[*models.Post]) {
+		req := httptest.NewRequest(http.MethodGet, "/posts/upcoming?"+query, nil)
+		req = req.WithContext(SetUserInContext(req.Context(), user))
+		rec := httptest.NewRecorder()
+		h.GetUpcoming(rec, req)
+
+		var resp models.ListResponse[*models.Post]
+		if rec.Code == http.StatusOK {
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid JSON %q: %v", rec.Body.String(), err)
+			}
+		}
+		return rec.Code, resp
+	}
+
+	var seen []uuid.UUID
+	query := "limit=2"
+	for pages := 0; ; pages++ {
+		if pages > len(posts) {
+			t.Fatal("pagination did not end")
+		}
+		code, resp := get(query)
+		if code != http.StatusOK {
+			t.Fatalf("GET ?%s status = %d, want %d", query, code, http.StatusOK)
+		}
+		if len(resp.Data) > 2 {
+			t.Fatalf("page has %d posts, want at most 2", len(resp.Data))
+		}
+		for _, p := range resp.Data {
+			seen = append(seen, p.ID)
+		}
+		if resp.NextCursor == nil {
+			break
+		}
+		query = "limit=2&cursor=" + *resp.NextCursor
+	}
+
+	if len(seen) != len(posts) {
+		t.Fatalf("paged through %d posts, want %d", len(seen), len(posts))
+	}
+	for i, id := range seen {
+		if id != posts[i].ID {
+			t.Errorf("post %d = %s, want %s", i, id, posts[i].ID)
+		}
+	}
+
+	for _, query := range []string{"limit=0", "limit=501", "limit=x", "cursor=nope"} {
+		if code, _ := get(query); code != http.StatusBadRequest {
+			t.Errorf("GET ?%s status = %d, want %d", query, code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestPostHandler_GetUpcoming_Summary(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	store := &dbmock.Store{
+		GetUpcomingPostSummariesFunc: func(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.PostFilter) ([]*models.PostSummary, error) {
+			return []*models.PostSummary{{ID: uuid.New(), Channel: models.ChannelTwitter, Status: models.PostStatusScheduled}}, nil
+		},
+	}
+	h := NewPostHandler(store, nil, nil, nil, false, nil, models.SchedulingPolicy{}, nil, nil, clock.Real)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/posts/upcoming?"+query, nil)
+		req = req.WithContext(SetUserInContext(req.Context(), user))
+		rec := httptest.NewRecorder()
+		h.GetUpcoming(rec, req)
+		return rec
+	}
+
+	rec := get("fields=summary")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var resp models.ListResponse[map[string]interface{}]
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Data) != 1 {
+		t.Fatalf("got %d summaries, want 1", len(resp.Data))
+	}
+	if _, ok := resp.Data[0]["content"]; ok {
+		t.Error("summary includes content")
+	}
+
+	if rec := get("fields=content"); rec.Code != http.StatusBadRequest {
+		t.Errorf("fields=content status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestPostHandler_Update_TextLimits(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	existing := &models.Post{
+		ID:          uuid.New(),
+		UserID:      user.ID,
+		Channel:     models.ChannelLinkedIn,
+		Content:     strings.Repeat("a", 3000),
+		Status:      models.PostStatusScheduled,
+		ScheduledAt: time.Now().Add(time.Hour),
+	}
+	store := &dbmock.Store{
+		GetPostByIDFunc: func(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+			return existing, nil
+		},
+	}
+	h := NewPostHandler(store, nil, nil, nil, false, nil, models.SchedulingPolicy{}, nil, nil, clock.Real)
+
+	r := chi.NewRouter()
+	r.Put("/posts/{id}", h.Update)
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"channel switch over the new channel's limit", `{"channel": "twitter"}`, "280"},
+		{"content over the channel's limit", `{"content": "` + strings.Repeat("b", 3001) + `"}`, "3000"},
+		{"content too short", `{"content": "  hi  "}`, "at least 3"},
+		{"title too long", `{"title": "` + strings.Repeat("t", 201) + `"}`, "200"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/posts/"+existing.ID.String(), strings.NewReader(tt.body))
+			req = req.WithContext(SetUserInContext(req.Context(), user))
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), tt.want) {
+				t.Errorf("status = %d, body %s; want %d mentioning %q", rec.Code, rec.Body.String(), http.StatusBadRequest, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostHandler_GetByID_Conditional(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	updatedAt := time.Date(2026, 3, 1, 12, 0, 0, 500, time.UTC)
+	post := &models.Post{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Channel:   models.ChannelTwitter,
+		Content:   "Hello",
+		Status:    models.PostStatusScheduled,
+		UpdatedAt: updatedAt,
+	}
+	store := &dbmock.Store{
+		GetPostByIDFunc: func(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+			return post, nil
+		},
+	}
+	h := NewPostHandler(store, nil, nil, nil, false, nil, models.SchedulingPolicy{}, nil, nil, clock.Real)
+
+	r := chi.NewRouter()
+	r.Get("/posts/{id}", h.GetByID)
+
+	get := func(headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/posts/"+post.ID.String(), nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		req = req.WithContext(SetUserInContext(req.Context(), user))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get(nil)
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag == "" || rec.Body.Len() == 0 {
+		t.Fatalf("GET status = %d, ETag %q, body %d bytes; want 200 with an ETag and body", rec.Code, etag, rec.Body.Len())
+	}
+	if got := rec.Header().Get("Last-Modified"); got != updatedAt.Format(http.TimeFormat) {
+		t.Errorf("Last-Modified = %q, want %q", got, updatedAt.Format(http.TimeFormat))
+	}
+
+	modified := updatedAt.Format(http.TimeFormat)
+	earlier := updatedAt.Add(-time.Minute).Format(http.TimeFormat)
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{"matching If-None-Match", map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		{"match in a list", map[string]string{"If-None-Match": `"other", ` + etag}, http.StatusNotModified},
+		{"weak tag", map[string]string{"If-None-Match": "W/" + etag}, http.StatusNotModified},
+		{"mismatched tag", map[string]string{"If-None-Match": `"other"`}, http.StatusOK},
+		{"If-Modified-Since the update", map[string]string{"If-Modified-Since": modified}, http.StatusNotModified},
+		{"If-Modified-Since before the update", map[string]string{"If-Modified-Since": earlier}, http.StatusOK},
+		{"mismatched tag wins over If-Modified-Since", map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": modified}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(tt.headers)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want == http.StatusNotModified && rec.Body.Len() != 0 {
+				t.Errorf("304 has a %d byte body", rec.Body.Len())
+			}
+			if rec.Header().Get("ETag") != etag {
+				t.Errorf("ETag = %q, want %q", rec.Header().Get("ETag"), etag)
+			}
+		})
+	}
+}
+
+func TestPostHandler_Update_Retry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	user := &models.User{ID: uuid.New()}
+
+	// Queue and reminder updates run in the background; their Redis is unreachable
+	rdb := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: 10 * time.Millisecond})
+	t.Cleanup(func() { rdb.Close() })
+	queue := scheduler.NewQueue(rdb, clock.NewFake(now))
+
+	tests := []struct {
+		name        string
+		status      models.PostStatus
+		scheduledAt time.Time
+		body        string
+		want        int
+		wantRetry   bool
+		wantAt      *time.Time // The time passed to UpdatePost; nil for none
+		wantErr     string
+	}{
+		{"failed post in the past is resent now", models.PostStatusFailed, now.Add(-time.Hour), `{"status": "scheduled"}`, http.StatusOK, true, &now, ""},
+		{"failed post keeps a future time", models.PostStatusFailed, now.Add(time.Hour), `{"status": "scheduled"}`, http.StatusOK, true, ptrTime(now.Add(time.Hour)), ""},
+		{"scheduled post isn't retried", models.PostStatusScheduled, now.Add(time.Hour), `{"status": "scheduled"}`, http.StatusOK, false, nil, ""},
+		{"failed post edited without status", models.PostStatusFailed, now.Add(-time.Hour), `{"content": "Fixed it"}`, http.StatusBadRequest, false, nil, "Set status to scheduled"},
+		{"published post", models.PostStatusPublished, now.Add(-time.Hour), `{"status": "scheduled"}`, http.StatusBadRequest, false, nil, "not scheduled"},
+		{"other status", models.PostStatusFailed, now.Add(-time.Hour), `{"status": "published"}`, http.StatusBadRequest, false, nil, "Only scheduled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := &models.Post{
+				ID:          uuid.New(),
+				UserID:      user.ID,
+				Channel:     models.ChannelTwitter,
+				Content:     "Hello world",
+				Type:        models.PostTypeText,
+				Status:      tt.status,
+				ScheduledAt: tt.scheduledAt,
+			}
+			var got *db.PostUpdate
+			store := &dbmock.Store{
+				GetPostByIDFunc: func(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+					return existing, nil
+				},
+				UpdatePostFunc: func(ctx context.Context, id, userID uuid.UUID, update db.PostUpdate) (*models.Post, error) {
+					got = &update
+					updated := *existing
+					updated.Status = models.PostStatusScheduled
+					if update.ScheduledAt != nil {
+						updated.ScheduledAt = *update.ScheduledAt
+					}
+					return &updated, nil
+				},
+			}
+			h := NewPostHandler(store, queue, nil, notifier.NewNotifier(nil), false, nil, models.SchedulingPolicy{}, nil, nil, clock.NewFake(now))
+
+			r := chi.NewRouter()
+			r.Put("/posts/{id}", h.Update)
+			req := httptest.NewRequest(http.MethodPut, "/posts/"+existing.ID.String(), strings.NewReader(tt.body))
+			req = req.WithContext(SetUserInContext(req.Context(), user))
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+			if tt.want != http.StatusOK {
+				if !strings.Contains(rec.Body.String(), tt.wantErr) {
+					t.Errorf("body = %s, want it to mention %q", rec.Body.String(), tt.wantErr)
+				}
+				if got != nil {
+					t.Error("UpdatePost called for a rejected update")
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("UpdatePost not called")
+			}
+			if got.Retry != tt.wantRetry {
+				t.Errorf("Retry = %v, want %v", got.Retry, tt.wantRetry)
+			}
+			switch {
+			case tt.wantAt == nil && got.ScheduledAt != nil:
+				t.Errorf("ScheduledAt = %v, want none", *got.ScheduledAt)
+			case tt.wantAt != nil && (got.ScheduledAt == nil || !got.ScheduledAt.Equal(*tt.wantAt)):
+				t.Errorf("ScheduledAt = %v, want %v", got.ScheduledAt, *tt.wantAt)
+			}
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// ListPresets returns the user's channel presets
+func (h *AccountHandler) ListPresets(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	presets, err := h.db.ListChannelPresets(r.Context(), user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch channel presets")
+		return
+	}
+
+	if presets == nil {
+		presets = []*models.ChannelPreset{}
+	}
+
+	respondList(w, presets)
+}
+
+// SetPreset creates or replaces the user's preset for a channel
+func (h *AccountHandler) SetPreset(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	channel := chi.URLParam(r, "channel")
+	if !models.IsValidChannel(channel) {
+		respondError(w, http.StatusBadRequest, models.InvalidChannelMessage())
+		return
+	}
+
+	var req models.UpdateChannelPresetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := req.Validate(models.Channel(channel)); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	preset, err := h.db.UpsertChannelPreset(r.Context(), user.ID, models.Channel(channel), req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to save channel preset")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, preset)
+}
+
+// DeletePreset removes the user's preset for a channel
+func (h *AccountHandler) DeletePreset(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	channel := chi.URLParam(r, "channel")
+	if !models.IsValidChannel(channel) {
+		respondError(w, http.StatusBadRequest, models.InvalidChannelMessage())
+		return
+	}
+
+	deleted, err := h.db.DeleteChannelPreset(r.Context(), user.ID, models.Channel(channel))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete channel preset")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "Channel preset not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
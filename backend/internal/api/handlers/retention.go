@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// GetRetention returns the organization's retention rules: the defaults, its
+// overrides and the rules in effect. Any member may view them.
+func (h *OrganizationHandler) GetRetention(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, ok := h.authorize(w, r, user, false)
+	if !ok {
+		return
+	}
+
+	overrides, err := h.db.GetRetentionOverrides(r.Context(), orgID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch retention rules")
+		return
+	}
+	if overrides == nil {
+		respondError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, h.retentionSettings(*overrides))
+}
+
+// SetRetention replaces the organization's retention overrides. Only owners
+// may change them; an unset rule follows the default.
+func (h *OrganizationHandler) SetRetention(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, ok := h.authorize(w, r, user, true)
+	if !ok {
+		return
+	}
+	if !h.requireOwner(w, r, orgID, user, "Only owners can change retention rules") {
+		return
+	}
+
+	var overrides models.RetentionOverrides
+	if err := json.NewDecoder(r.Body).Decode(&overrides); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := overrides.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.db.SetRetentionOverrides(r.Context(), orgID, overrides); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update retention rules")
+		return
+	}
+	recordAudit(r.Context(), h.db, user, orgID, models.AuditRetentionUpdate, nil, map[string]any{"retention": overrides})
+
+	respondJSON(w, http.StatusOK, h.retentionSettings(overrides))
+}
+
+// PreviewRetention reports what the retention rules in effect would purge
+// and anonymize in the organization if they ran now, changing nothing. Only
+// owners may preview them.
+func (h *OrganizationHandler) PreviewRetention(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgID, ok := h.authorize(w, r, user, true)
+	if !ok {
+		return
+	}
+	if !h.requireOwner(w, r, orgID, user, "Only owners can preview retention") {
+		return
+	}
+
+	report, err := h.db.ApplyRetention(r.Context(), h.retention, time.Now(), &orgID, true)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to preview retention")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+// retentionSettings describes the rules in effect under the overrides
+func (h *OrganizationHandler) retentionSettings(overrides models.RetentionOverrides) models.RetentionSettings {
+	return models.RetentionSettings{
+		Defaults:  h.retention,
+		Overrides: overrides,
+		Effective: h.retention.With(overrides),
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/announcement"
 	"github.com/scheduler/backend/internal/db"
 	"github.com/scheduler/backend/internal/models"
 	"github.com/scheduler/backend/internal/notifier"
@@ -14,15 +16,17 @@ import (
 
 // SSEHandler handles Server-Sent Events for real-time updates
 type SSEHandler struct {
-	db       *db.DB
-	notifier *notifier.Notifier
+	db            db.Store
+	notifier      *notifier.Notifier
+	announcements *announcement.Store
 }
 
 // NewSSEHandler creates a new SSE handler
-func NewSSEHandler(database *db.DB, n *notifier.Notifier) *SSEHandler {
+func NewSSEHandler(database db.Store, n *notifier.Notifier, announcements *announcement.Store) *SSEHandler {
 	return &SSEHandler{
-		db:       database,
-		notifier: n,
+		db:            database,
+		notifier:      n,
+		announcements: announcements,
 	}
 }
 
@@ -71,6 +75,20 @@ func (h *SSEHandler) StreamPosts(w http.ResponseWriter, r *http.Request) {
 	}
 	flusher.Flush()
 
+	// Replay current announcements, so clients that connect or reconnect
+	// after one was posted still show it
+	active, err := h.announcements.Active(r.Context())
+	if err != nil {
+		log.Printf("⚠️ [SSE] Failed to load announcements: %v", err)
+	}
+	for _, a := range active {
+		data, _ := json.Marshal(a)
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", notifier.UpdateTypeAnnouncement, data); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
 	// Subscribe to notifications for this user
 	log.Printf("🔔 [SSE] User %s subscribed to real-time updates", user.ID)
 	updateChan := h.notifier.Subscribe(user.ID)
@@ -92,11 +110,8 @@ func (h *SSEHandler) StreamPosts(w http.ResponseWriter, r *http.Request) {
 	var lastHistoryHash string
 
 	// Send initial data immediately
-	upcoming, _ := h.db.GetUpcomingPosts(r.Context(), user.ID)
-	history, _ := h.db.GetPublishedPosts(r.Context(), user.ID)
-	if upcoming == nil {
-		upcoming = []*models.Post{}
-	}
+	upcoming, upcomingNext, _ := h.upcomingWindow(r, user)
+	history, _ := h.db.GetPublishedPosts(r.Context(), user.ID, user.WorkspaceID)
 	if history == nil {
 		history = []*models.Post{}
 	}
@@ -105,8 +120,9 @@ func (h *SSEHandler) StreamPosts(w http.ResponseWriter, r *http.Request) {
 	lastHistoryHash = hashPosts(history)
 
 	data := map[string]interface{}{
-		"upcoming": upcoming,
-		"history":  history,
+		"upcoming":             upcoming,
+		"upcoming_next_cursor": upcomingNext,
+		"history":              history,
 	}
 	jsonData, _ := json.Marshal(data)
 	fmt.Fprintf(w, "event: update\ndata: %s\n\n", jsonData)
@@ -116,18 +132,15 @@ func (h *SSEHandler) StreamPosts(w http.ResponseWriter, r *http.Request) {
 	sendUpdate := func() bool {
 		start := time.Now()
 		
-		// Fetch upcoming posts
-		upcoming, err := h.db.GetUpcomingPosts(r.Context(), user.ID)
+		// Fetch the first window of upcoming posts
+		upcoming, upcomingNext, err := h.upcomingWindow(r, user)
 		if err != nil {
 			log.Printf("SSE: ERROR - Failed to fetch upcoming: %v", err)
 			return true // Continue on error
 		}
-		if upcoming == nil {
-			upcoming = []*models.Post{}
-		}
 
 		// Fetch history posts
-		history, err := h.db.GetPublishedPosts(r.Context(), user.ID)
+		history, err := h.db.GetPublishedPosts(r.Context(), user.ID, user.WorkspaceID)
 		if err != nil {
 			log.Printf("SSE: ERROR - Failed to fetch history: %v", err)
 			return true // Continue on error
@@ -146,8 +159,9 @@ func (h *SSEHandler) StreamPosts(w http.ResponseWriter, r *http.Request) {
 			lastHistoryHash = historyHash
 
 			data := map[string]interface{}{
-				"upcoming": upcoming,
-				"history":  history,
+				"upcoming":             upcoming,
+				"upcoming_next_cursor": upcomingNext,
+				"history":              history,
 			}
 
 			jsonData, err := json.Marshal(data)
@@ -180,7 +194,34 @@ func (h *SSEHandler) StreamPosts(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			flusher.Flush()
-		case <-updateChan:
+		case update := <-updateChan:
+			// Broadcasts and events with a body, such as inbox counts,
+			// carry their own data and don't change the post lists
+			if update.UserID == uuid.Nil || update.Data != nil {
+				if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", update.Type, update.Data); err != nil {
+					log.Printf("SSE: ERROR - Failed to write %s event, client disconnected: %v", update.Type, err)
+					return
+				}
+				flusher.Flush()
+				continue
+			}
+
+			// Updates about a single post (comments, workflow changes) name it
+			// so the client can refresh just that post
+			if update.PostID != nil {
+				if _, err := fmt.Fprintf(w, "event: %s\ndata: {\"post_id\":%q}\n\n", update.Type, update.PostID.String()); err != nil {
+					log.Printf("SSE: ERROR - Failed to write %s event, client disconnected: %v", update.Type, err)
+					return
+				}
+				flusher.Flush()
+				// Only workflow changes change the post lists; the other
+				// events are notifications, sent alongside a separate update
+				// when the lists did change
+				if update.Type != notifier.UpdateTypeWorkflow {
+					continue
+				}
+			}
+
 			// Real-time notification received - send update immediately
 			log.Printf("⚡ [SSE] Real-time notification received for user %s, sending update...", user.ID)
 			start := time.Now()
@@ -197,6 +238,28 @@ func (h *SSEHandler) StreamPosts(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// sseUpcomingWindow is how many upcoming posts each update carries; clients
+// fetch later ones from /api/posts/upcoming with the update's cursor
+const sseUpcomingWindow = 100
+
+// upcomingWindow reads the first sseUpcomingWindow upcoming posts, and the
+// cursor of the posts after them, nil if there are none
+func (h *SSEHandler) upcomingWindow(r *http.Request, user *models.User) ([]*models.Post, *string, error) {
+	posts, err := h.db.GetUpcomingPosts(r.Context(), user.ID, user.WorkspaceID, db.PostFilter{Limit: sseUpcomingWindow + 1})
+	if err != nil {
+		return []*models.Post{}, nil, err
+	}
+	if posts == nil {
+		posts = []*models.Post{}
+	}
+	if len(posts) <= sseUpcomingWindow {
+		return posts, nil, nil
+	}
+	posts = posts[:sseUpcomingWindow]
+	next := models.CursorAfter(posts[sseUpcomingWindow-1]).String()
+	return posts, &next, nil
+}
+
 // hashPosts creates a simple hash to detect changes
 func hashPosts(posts []*models.Post) string {
 	if posts == nil || len(posts) == 0 {
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// postList builds n posts as the SSE handler sees them
+func postList(n int) []*models.Post {
+	base := time.Date(2030, 1, 15, 12, 0, 0, 0, time.UTC)
+	posts := make([]*models.Post, n)
+	for i := range posts {
+		posts[i] = &models.Post{
+			ID:          uuid.New(),
+			Status:      models.PostStatusScheduled,
+			ScheduledAt: base.Add(time.Duration(i) * time.Hour),
+			UpdatedAt:   base,
+		}
+	}
+	return posts
+}
+
+func TestHashPosts(t *testing.T) {
+	if got := hashPosts(nil); got != "empty" {
+		t.Errorf("hashPosts(nil) = %q, want %q", got, "empty")
+	}
+
+	posts := postList(3)
+	before := hashPosts(posts)
+	if again := hashPosts(posts); again != before {
+		t.Errorf("hashPosts is not stable: %q then %q", before, again)
+	}
+
+	posts[1].Status = models.PostStatusPublished
+	if after := hashPosts(posts); after == before {
+		t.Error("hashPosts unchanged after a status change")
+	}
+
+	posts[1].Status = models.PostStatusScheduled
+	posts[2].UpdatedAt = posts[2].UpdatedAt.Add(time.Second)
+	if after := hashPosts(posts); after == before {
+		t.Error("hashPosts unchanged after an edit")
+	}
+}
+
+func BenchmarkHashPosts(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		posts := postList(n)
+		b.Run(fmt.Sprintf("posts=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				hashPosts(posts)
+			}
+		})
+	}
+}
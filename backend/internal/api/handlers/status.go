@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/scheduler"
+)
+
+const (
+	// statusCacheTTL is how long a status summary is served before components are probed again
+	statusCacheTTL = 15 * time.Second
+	// statusProbeTimeout bounds each dependency probe
+	statusProbeTimeout = 2 * time.Second
+)
+
+// StatusHandler serves a public, machine-readable summary of component health
+// for status pages. Probes run at most once per statusCacheTTL per instance so
+// polling can't load the database or Redis.
+type StatusHandler struct {
+	db           db.Store
+	redis        *redis.Client
+	heartbeats   *scheduler.HeartbeatStore
+	lagThreshold time.Duration
+
+	mu     sync.Mutex
+	cached *models.SystemStatus
+}
+
+// NewStatusHandler creates a new status handler. Worker lag over lagThreshold
+// marks the worker degraded; zero disables the check.
+func NewStatusHandler(database db.Store, redisClient *redis.Client, heartbeats *scheduler.HeartbeatStore, lagThreshold time.Duration) *StatusHandler {
+	return &StatusHandler{
+		db:           database,
+		redis:        redisClient,
+		heartbeats:   heartbeats,
+		lagThreshold: lagThreshold,
+	}
+}
+
+// Get returns the health of the API, database, Redis, worker and each
+// platform publisher. It responds 503 when any component is down.
+func (h *StatusHandler) Get(w http.ResponseWriter, r *http.Request) {
+	status := h.status(r.Context())
+
+	code := http.StatusOK
+	if status.Status == models.StatusOutage {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(statusCacheTTL/time.Second)))
+	respondJSON(w, code, status)
+}
+
+// status returns the cached summary, probing the components again once it is
+// stale. Probes ignore the caller's cancellation, as the result is shared with
+// every caller until it expires; each is bounded by statusProbeTimeout.
+func (h *StatusHandler) status(ctx context.Context) *models.SystemStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cached != nil && time.Since(h.cached.UpdatedAt) < statusCacheTTL {
+		return h.cached
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	components := []models.Component{
+		{Name: "api", Status: models.StatusOperational},
+		probe(ctx, "database", h.db.Ping),
+		probe(ctx, "redis", func(ctx context.Context) error { return h.redis.Ping(ctx).Err() }),
+	}
+	components = append(components, h.workerComponents(ctx)...)
+
+	h.cached = &models.SystemStatus{
+		Status:     models.OverallStatus(components),
+		UpdatedAt:  time.Now().UTC(),
+		Components: components,
+	}
+	return h.cached
+}
+
+// probe times ping against a dependency, reporting an outage if it fails
+func probe(ctx context.Context, name string, ping func(context.Context) error) models.Component {
+	ctx, cancel := context.WithTimeout(ctx, statusProbeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := ping(ctx)
+	latency := time.Since(start).Milliseconds()
+
+	c := models.Component{Name: name, Status: models.StatusOperational, LatencyMS: &latency}
+	if err != nil {
+		c.Status = models.StatusOutage
+		c.Message = "Unreachable"
+	}
+	return c
+}
+
+// workerComponents summarizes worker heartbeats: the worker itself, and one
+// publisher per channel, degraded while any worker has its circuit open
+func (h *StatusHandler) workerComponents(ctx context.Context) []models.Component {
+	worker := models.Component{Name: "worker", Status: models.StatusOperational}
+	openUntil := make(map[models.Channel]time.Time)
+
+	heartbeats, err := h.heartbeats.List(ctx)
+	if err != nil {
+		worker.Status = models.StatusOutage
+		worker.Message = "Worker status unavailable"
+	} else {
+		alive := 0
+		var paused bool
+		var lag float64
+		for _, hb := range heartbeats {
+			if !hb.Alive {
+				continue
+			}
+			alive++
+			paused = paused || hb.Paused
+			if hb.LagSeconds > lag {
+				lag = hb.LagSeconds
+			}
+			for c, until := range hb.OpenCircuits {
+				if until.After(openUntil[c]) {
+					openUntil[c] = until
+				}
+			}
+		}
+
+		switch {
+		case alive == 0:
+			worker.Status = models.StatusOutage
+			worker.Message = "No worker is running; scheduled posts are not being published"
+		case paused:
+			worker.Status = models.StatusDegraded
+			worker.Message = "Publishing is paused for maintenance"
+		case h.lagThreshold > 0 && lag > h.lagThreshold.Seconds():
+			worker.Status = models.StatusDegraded
+			worker.Message = fmt.Sprintf("Posts are going out up to %s late", time.Duration(lag*float64(time.Second)).Round(time.Second))
+		}
+	}
+
+	components := []models.Component{worker}
+	for _, c := range models.ValidChannels() {
+		publisher := models.Component{Name: "publisher:" + string(c), Status: models.StatusOperational}
+		if until, ok := openUntil[c]; ok {
+			publisher.Status = models.StatusDegraded
+			publisher.Message = fmt.Sprintf("Publishing is deferred after repeated failures until %s", until.UTC().Format(time.RFC3339))
+		}
+		components = append(components, publisher)
+	}
+	return components
+}
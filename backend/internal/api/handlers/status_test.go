@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/scheduler"
+)
+
+// fakeRedis answers the commands the status probes send without a server:
+// PING, and a SCAN and MGET finding one live worker heartbeat. Like a real
+// client, commands fail once their context is done.
+type fakeRedis struct{}
+
+func (fakeRedis) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (fakeRedis) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := ctx.Err(); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+		switch cmd := cmd.(type) {
+		case *redis.StatusCmd:
+			cmd.SetVal("PONG")
+		case *redis.ScanCmd:
+			cmd.SetVal([]string{"worker:heartbeat:1"}, 0)
+		case *redis.SliceCmd:
+			hb, _ := json.Marshal(scheduler.Heartbeat{InstanceID: "1", LastTick: time.Now(), Interval: "10s"})
+			cmd.SetVal([]interface{}{string(hb)})
+		}
+		return nil
+	}
+}
+
+func (fakeRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func TestStatusHandler_Get_CallerCancels(t *testing.T) {
+	store := &dbmock.Store{
+		PingFunc: func(ctx context.Context) error { return ctx.Err() },
+	}
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	redisClient.AddHook(fakeRedis{})
+	defer redisClient.Close()
+	h := NewStatusHandler(store, redisClient, scheduler.NewHeartbeatStore(redisClient), 0)
+
+	// The first caller disconnects before the probes run
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := httptest.NewRecorder()
+	h.Get(rec, httptest.NewRequest(http.MethodGet, "/api/status", nil).WithContext(ctx))
+
+	rec = httptest.NewRecorder()
+	h.Get(rec, httptest.NewRequest(http.MethodGet, "/api/status", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	var status models.SystemStatus
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != models.StatusOperational {
+		t.Errorf("overall status = %q, want %q: %+v", status.Status, models.StatusOperational, status.Components)
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// ndjsonContentType is requested in Accept to receive a list as one JSON
+// object per line, without the list envelope
+const ndjsonContentType = "application/x-ndjson"
+
+// listWriter writes a complete list one item at a time, in the list envelope
+// or as NDJSON, so long lists are never held in memory or marshaled at once.
+// Nothing is written until the first item or Close, so errors before then can
+// still get an error response.
+type listWriter struct {
+	w       http.ResponseWriter
+	enc     *json.Encoder
+	ndjson  bool
+	started bool
+	count   int
+}
+
+// newListWriter chooses the format from the request's Accept header
+func newListWriter(w http.ResponseWriter, r *http.Request) *listWriter {
+	return &listWriter{
+		w:      w,
+		enc:    json.NewEncoder(w),
+		ndjson: strings.Contains(r.Header.Get("Accept"), ndjsonContentType),
+	}
+}
+
+func (l *listWriter) start() error {
+	l.started = true
+	if l.ndjson {
+		l.w.Header().Set("Content-Type", ndjsonContentType)
+		l.w.WriteHeader(http.StatusOK)
+		return nil
+	}
+	l.w.Header().Set("Content-Type", "application/json")
+	l.w.WriteHeader(http.StatusOK)
+	_, err := l.w.Write([]byte(`{"data":[`))
+	return err
+}
+
+// Write appends an item to the list
+func (l *listWriter) Write(item interface{}) error {
+	if !l.started {
+		if err := l.start(); err != nil {
+			return err
+		}
+	}
+	if !l.ndjson && l.count > 0 {
+		if _, err := l.w.Write([]byte(",")); err != nil {
+			return err
+		}
+	}
+	l.count++
+	return l.enc.Encode(item)
+}
+
+// Close ends the list; as respondList, the envelope's total is the item count
+func (l *listWriter) Close() error {
+	if !l.started {
+		if err := l.start(); err != nil {
+			return err
+		}
+	}
+	if l.ndjson {
+		return nil
+	}
+	_, err := fmt.Fprintf(l.w, `],"next_cursor":null,"total":%d}`+"\n", l.count)
+	return err
+}
+
+// Fail reports an error reading the list. Before anything was written it
+// responds with an error; after, the status is already sent, so the connection
+// is aborted rather than ending what would look like a complete list.
+func (l *listWriter) Fail(status int, message string) {
+	if !l.started {
+		respondError(l.w, status, message)
+		return
+	}
+	log.Printf("❌ %s after %d items, aborting response", message, l.count)
+	panic(http.ErrAbortHandler)
+}
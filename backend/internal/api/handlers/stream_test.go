@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/clock"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestPostHandler_GetHistory_Streams(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	posts := postList(3)
+	store := &dbmock.Store{
+		EachHistoryPostFunc: func(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.HistoryFilter, fn func(*models.Post) error) error {
+			for _, p := range posts {
+				if err := fn(p); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	h := NewPostHandler(store, nil, nil, nil, false, nil, models.SchedulingPolicy{}, nil, nil, clock.Real)
+
+	get := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/posts/history", nil)
+		req = req.WithContext(SetUserInContext(req.Context(), user))
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		rec := httptest.NewRecorder()
+		h.GetHistory(rec, req)
+		return rec
+	}
+
+	t.Run("envelope", func(t *testing.T) {
+		rec := get("application/json")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		var resp models.ListResponse[*models.Post]
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid JSON %q: %v", rec.Body.String(), err)
+		}
+		if len(resp.Data) != len(posts) || resp.Total == nil || *resp.Total != len(posts) || resp.NextCursor != nil {
+			t.Errorf("response = %+v, want %d posts and no cursor", resp, len(posts))
+		}
+		for i, p := range resp.Data {
+			if p.ID != posts[i].ID {
+				t.Errorf("post %d = %s, want %s", i, p.ID, posts[i].ID)
+			}
+		}
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		rec := get(ndjsonContentType)
+		if ct := rec.Header().Get("Content-Type"); ct != ndjsonContentType {
+			t.Errorf("Content-Type = %q, want %q", ct, ndjsonContentType)
+		}
+		var ids []uuid.UUID
+		scanner := bufio.NewScanner(rec.Body)
+		for scanner.Scan() {
+			var p models.Post
+			if err := json.Unmarshal(scanner.Bytes(), &p); err != nil {
+				t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+			}
+			ids = append(ids, p.ID)
+		}
+		if len(ids) != len(posts) {
+			t.Fatalf("got %d lines, want %d", len(ids), len(posts))
+		}
+	})
+}
+
+func TestListWriter_Empty(t *testing.T) {
+	rec := httptest.NewRecorder()
+	list := newListWriter(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	list.Close()
+
+	if got, want := strings.TrimSpace(rec.Body.String()), `{"data":[],"next_cursor":null,"total":0}`; got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
+
+func TestListWriter_Fail(t *testing.T) {
+	t.Run("before any item", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		list := newListWriter(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		list.Fail(http.StatusInternalServerError, "Failed to fetch posts")
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+		}
+	})
+
+	t.Run("mid-list aborts", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		list := newListWriter(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		list.Write(map[string]int{"n": 1})
+
+		defer func() {
+			if err, _ := recover().(error); !errors.Is(err, http.ErrAbortHandler) {
+				t.Errorf("recovered %v, want http.ErrAbortHandler", err)
+			}
+		}()
+		list.Fail(http.StatusInternalServerError, "Failed to fetch posts")
+	})
+}
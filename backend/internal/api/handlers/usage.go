@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/usage"
+)
+
+// usageHistoryDays is how many days of rolled-up usage are returned
+const usageHistoryDays = 30
+
+// UsageHandler reports API and publish usage against plan quotas
+type UsageHandler struct {
+	db    db.Store
+	meter *usage.Meter
+}
+
+// NewUsageHandler creates a new usage handler
+func NewUsageHandler(database db.Store, meter *usage.Meter) *UsageHandler {
+	return &UsageHandler{
+		db:    database,
+		meter: meter,
+	}
+}
+
+// Get returns the user's quota, live usage today and the last 30 days of rollups
+func (h *UsageHandler) Get(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	today, err := h.meter.Today(r.Context(), user.ID)
+	if err != nil {
+		log.Printf("⚠️ Failed to read today's usage for user %s: %v", user.ID, err)
+	}
+
+	history, err := h.db.ListUsage(r.Context(), user.ID, time.Now().AddDate(0, 0, -usageHistoryDays))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch usage")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, models.UsageResponse{
+		Plan:    user.Plan,
+		Quota:   user.Plan.Quota(),
+		Today:   today,
+		History: history,
+	})
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/scheduler/backend/internal/auth"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// WorkspaceHandler handles workspace listing and switching
+type WorkspaceHandler struct {
+	db         db.Store
+	jwtService *auth.JWTService
+	cookies    AuthCookies
+}
+
+// NewWorkspaceHandler creates a new workspace handler
+func NewWorkspaceHandler(database db.Store, jwtService *auth.JWTService, cookies AuthCookies) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		db:         database,
+		jwtService: jwtService,
+		cookies:    cookies,
+	}
+}
+
+// List returns the user's personal workspace followed by their organizations
+func (h *WorkspaceHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	orgs, err := h.db.ListWorkspaces(r.Context(), user.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch workspaces")
+		return
+	}
+
+	workspaces := []models.Workspace{{
+		Name:    "Personal",
+		Type:    models.WorkspacePersonal,
+		Role:    models.OrgRoleOwner,
+		Current: user.WorkspaceID == nil,
+	}}
+	for _, ws := range orgs {
+		ws.Current = ws.Matches(user.WorkspaceID)
+		workspaces = append(workspaces, ws)
+	}
+
+	respondList(w, workspaces)
+}
+
+// Switch re-issues the user's tokens scoped to another workspace, so
+// subsequent requests see that workspace's posts
+func (h *WorkspaceHandler) Switch(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		respondError(w, http.StatusUnauthorized, "Not authenticated")
+		return
+	}
+
+	var req models.SwitchWorkspaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	workspace := models.Workspace{
+		Name:    "Personal",
+		Type:    models.WorkspacePersonal,
+		Role:    models.OrgRoleOwner,
+		Current: true,
+	}
+	if req.WorkspaceID != nil {
+		orgs, err := h.db.ListWorkspaces(r.Context(), user.ID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch workspaces")
+			return
+		}
+		found := false
+		for _, ws := range orgs {
+			if ws.Matches(req.WorkspaceID) {
+				workspace, found = ws, true
+				break
+			}
+		}
+		if !found {
+			respondError(w, http.StatusForbidden, "Not a member of this workspace")
+			return
+		}
+		workspace.Current = true
+	}
+
+	tokens, err := h.jwtService.GenerateWorkspaceTokenPair(user.ID, user.Email, req.WorkspaceID, h.rememberMe(r))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to generate tokens")
+		return
+	}
+	h.cookies.write(w, r, h.jwtService, tokens)
+
+	respondJSON(w, http.StatusOK, workspace)
+}
+
+// rememberMe reports whether the session was signed in with remember me, so
+// switching workspace keeps its refresh token lifetime. The auth middleware
+// has already validated the access token.
+func (h *WorkspaceHandler) rememberMe(r *http.Request) bool {
+	cookie, err := r.Cookie("access_token")
+	if err != nil {
+		return false
+	}
+	claims, err := h.jwtService.ValidateToken(cookie.Value)
+	return err == nil && claims.RememberMe
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/abuse"
+	"github.com/scheduler/backend/internal/api/handlers"
+)
+
+// AbuseHold creates a check rejecting mutating requests with 403 from users
+// whose account is on hold, until the hold expires or an admin clears it.
+// Reads keep working, and the check fails open if Redis can't be reached.
+// Must run after Auth.
+func AbuseHold(detector *abuse.Detector) Check {
+	return func(w http.ResponseWriter, r *http.Request, pipe redis.Pipeliner) func(bool) bool {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return nil
+		}
+
+		user := handlers.GetUserFromContext(r.Context())
+		if user == nil {
+			return nil
+		}
+
+		heldUntil := detector.QueueHeldUntil(r.Context(), pipe, user.ID)
+
+		return func(rejected bool) bool {
+			if rejected {
+				return false
+			}
+
+			until, err := heldUntil()
+			if err != nil {
+				log.Printf("⚠️ Failed to check account hold of user %s: %v", user.ID, err)
+				return true
+			}
+			if until == nil {
+				return true
+			}
+
+			body, _ := json.Marshal(map[string]interface{}{
+				"error":      "Forbidden",
+				"message":    "Your account is temporarily on hold pending review",
+				"held_until": until.Format(time.RFC3339),
+			})
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write(body)
+			return false
+		}
+	}
+}
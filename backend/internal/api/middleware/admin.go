@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/scheduler/backend/internal/api/handlers"
+)
+
+// RequireAdmin restricts a route to users whose email is in adminEmails.
+// Must run after Auth.
+func RequireAdmin(adminEmails []string) func(http.Handler) http.Handler {
+	admins := make(map[string]bool, len(adminEmails))
+	for _, email := range adminEmails {
+		admins[strings.ToLower(strings.TrimSpace(email))] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := handlers.GetUserFromContext(r.Context())
+			if user == nil || !admins[strings.ToLower(user.Email)] {
+				http.Error(w, `{"error":"Forbidden","message":"Admin access required"}`, http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/scheduler/backend/internal/api/handlers"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestRequireAdmin(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := RequireAdmin([]string{" Ops@Example.com "})(ok)
+
+	tests := []struct {
+		name string
+		user *models.User
+		want int
+	}{
+		{"admin", &models.User{Email: "ops@example.com"}, http.StatusOK},
+		{"admin email case differs", &models.User{Email: "OPS@example.com"}, http.StatusOK},
+		{"regular user", &models.User{Email: "user@example.com"}, http.StatusForbidden},
+		{"no user", nil, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/workers", nil)
+			if tt.user != nil {
+				req = req.WithContext(handlers.SetUserInContext(req.Context(), tt.user))
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("Expected status %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+}
@@ -1,16 +1,33 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"log"
 	"net/http"
 
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/scheduler/backend/internal/abuse"
 	"github.com/scheduler/backend/internal/api/handlers"
 	"github.com/scheduler/backend/internal/auth"
+	"github.com/scheduler/backend/internal/cache"
 	"github.com/scheduler/backend/internal/db"
 	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/ratelimit"
+	"github.com/scheduler/backend/internal/tenant"
 )
 
-// Auth creates an authentication middleware
-func Auth(jwtService *auth.JWTService, database *db.DB) func(next http.Handler) http.Handler {
+// WorkspaceHeader selects a workspace for a single request, overriding the token's
+const WorkspaceHeader = "X-Workspace"
+
+// Auth creates an authentication middleware. Tokens revoked at logout are
+// rejected, and users are read through the cache so most requests don't query
+// the database; the blacklist check and cache read share one Redis round trip.
+// The cache, blacklist and abuse detector, which invalid tokens are reported
+// to, may each be nil.
+func Auth(jwtService *auth.JWTService, database db.Store, redisClient *redis.Client, users *cache.Cache, blacklist *auth.Blacklist, detector *abuse.Detector) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			cookie, err := r.Cookie("access_token")
@@ -21,26 +38,132 @@ func Auth(jwtService *auth.JWTService, database *db.DB) func(next http.Handler)
 
 			claims, err := jwtService.ValidateToken(cookie.Value)
 			if err != nil {
+				if detector != nil {
+					detector.RecordTokenFailure(r.Context(), ratelimit.ClientIP(r))
+				}
 				http.Error(w, `{"error":"Unauthorized","message":"Invalid or expired token"}`, http.StatusUnauthorized)
 				return
 			}
 
-			// Get user from database
-			user, err := database.GetUserByID(r.Context(), claims.UserID)
+			user, revoked, err := loadSession(r.Context(), database, redisClient, users, blacklist, claims)
+			if errors.Is(err, errSessionCheck) {
+				// Fail closed, as the rate limiter does
+				http.Error(w, `{"error":"Service Unavailable","message":"Session check unavailable"}`, http.StatusServiceUnavailable)
+				return
+			}
+			if revoked {
+				http.Error(w, `{"error":"Unauthorized","message":"Token revoked"}`, http.StatusUnauthorized)
+				return
+			}
 			if err != nil || user == nil {
 				http.Error(w, `{"error":"Unauthorized","message":"User not found"}`, http.StatusUnauthorized)
 				return
 			}
 
+			// Tokens only work on the tenant the user belongs to
+			if user.TenantID != tenant.IDFromContext(r.Context()) {
+				http.Error(w, `{"error":"Unauthorized","message":"User not found"}`, http.StatusUnauthorized)
+				return
+			}
+
+			// Resolve the workspace; the X-Workspace header overrides the token's
+			workspaceID := claims.WorkspaceID
+			fromHeader := false
+			if header := r.Header.Get(WorkspaceHeader); header != "" {
+				id, err := uuid.Parse(header)
+				if err != nil {
+					http.Error(w, `{"error":"Bad Request","message":"Invalid workspace ID"}`, http.StatusBadRequest)
+					return
+				}
+				workspaceID, fromHeader = &id, true
+			}
+
+			var workspaceRole models.OrgRole
+			if workspaceID != nil {
+				workspaceRole, err = database.GetMemberRole(r.Context(), *workspaceID, user.ID)
+				if err != nil {
+					http.Error(w, `{"error":"Internal Server Error","message":"Failed to load workspace"}`, http.StatusInternalServerError)
+					return
+				}
+				if workspaceRole == "" {
+					if fromHeader {
+						http.Error(w, `{"error":"Forbidden","message":"Not a member of this workspace"}`, http.StatusForbidden)
+						return
+					}
+					// Membership was revoked since the token was issued
+					workspaceID = nil
+				}
+			}
+
 			// Add user to context
 			ctx := handlers.SetUserInContext(r.Context(), &models.User{
 				ID:        user.ID,
 				Email:     user.Email,
 				CreatedAt: user.CreatedAt,
 				UpdatedAt: user.UpdatedAt,
+
+				AvatarKey:       user.AvatarKey,
+				AvatarUpdatedAt: user.AvatarUpdatedAt,
+
+				ConflictWindowMinutes: user.ConflictWindowMinutes,
+				Plan:                  user.Plan,
+
+				SuspendedAt:      user.SuspendedAt,
+				SuspensionReason: user.SuspensionReason,
+
+				TenantID: user.TenantID,
+
+				WorkspaceID:   workspaceID,
+				WorkspaceRole: workspaceRole,
 			})
+			setAccessLogUser(ctx, user.ID)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// errSessionCheck means the blacklist couldn't be checked
+var errSessionCheck = errors.New("session check failed")
+
+// loadSession checks the token's JTI against the blacklist and reads its user
+// from the cache, in one pipeline, or from the database when it isn't cached,
+// caching what it read. It reports revoked tokens without loading the user.
+func loadSession(ctx context.Context, database db.Store, redisClient *redis.Client, users *cache.Cache, blacklist *auth.Blacklist, claims *auth.Claims) (*models.User, bool, error) {
+	isBlacklisted := func() (bool, error) { return false, nil }
+	getUser := func() (*models.User, bool) { return nil, false }
+	if blacklist != nil || users != nil {
+		pipe := redisClient.Pipeline()
+		if blacklist != nil {
+			isBlacklisted = blacklist.QueueIsBlacklisted(ctx, pipe, claims.ID)
+		}
+		if users != nil {
+			getUser = users.QueueGetUser(ctx, pipe, claims.UserID)
+		}
+		// Each command's error is read on its own; a missing key fails the pipeline
+		_, _ = pipe.Exec(ctx)
+	}
+
+	revoked, err := isBlacklisted()
+	if err != nil {
+		log.Printf("⚠️ Failed to check token blacklist: %v", err)
+		return nil, false, errSessionCheck
+	}
+	if revoked {
+		return nil, true, nil
+	}
+	if user, found := getUser(); found {
+		return user, false, nil
+	}
+
+	user, err := database.GetUserByID(ctx, claims.UserID)
+	if err != nil || user == nil {
+		return user, false, err
+	}
+	if users != nil {
+		if err := users.SetUser(ctx, user); err != nil {
+			log.Printf("⚠️ Failed to cache user %s: %v", user.ID, err)
+		}
+	}
+	return user, false, nil
+}
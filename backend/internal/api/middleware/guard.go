@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Check is one of the Redis-backed checks Guard runs on a request. It adds the
+// commands it needs to pipe and returns a func to call once pipe has run. That
+// func reports whether the request may continue, having responded if not;
+// with rejected set an earlier check already turned the request away, and it
+// only takes back what it counted. A check with nothing to do for the request
+// returns nil.
+type Check func(w http.ResponseWriter, r *http.Request, pipe redis.Pipeliner) func(rejected bool) bool
+
+// Guard runs checks on each request in order, sending the Redis commands of all
+// of them in one pipeline so a request costs one round trip however many
+// checks it passes. Each check fails open or closed on Redis errors as it
+// would alone.
+func Guard(redisClient *redis.Client, checks ...Check) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pipe := redisClient.Pipeline()
+			decisions := make([]func(bool) bool, 0, len(checks))
+			for _, check := range checks {
+				if decide := check(w, r, pipe); decide != nil {
+					decisions = append(decisions, decide)
+				}
+			}
+
+			// Each check reads its own commands' errors
+			if pipe.Len() > 0 {
+				_, _ = pipe.Exec(r.Context())
+			}
+
+			rejected := false
+			for _, decide := range decisions {
+				if !decide(rejected) {
+					rejected = true
+				}
+			}
+			if rejected {
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestGuard(t *testing.T) {
+	// The checks queue no commands, so the client is never dialed
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	defer client.Close()
+
+	var calls []string
+	check := func(name string, pass bool) Check {
+		return func(w http.ResponseWriter, r *http.Request, pipe redis.Pipeliner) func(bool) bool {
+			return func(rejected bool) bool {
+				if rejected {
+					calls = append(calls, name+" (rejected)")
+					return false
+				}
+				calls = append(calls, name)
+				if !pass {
+					w.WriteHeader(http.StatusTooManyRequests)
+				}
+				return pass
+			}
+		}
+	}
+	skip := func(w http.ResponseWriter, r *http.Request, pipe redis.Pipeliner) func(bool) bool {
+		return nil
+	}
+
+	tests := []struct {
+		name      string
+		checks    []Check
+		wantCode  int
+		wantCalls []string
+	}{
+		{"all pass", []Check{check("limit", true), skip, check("quota", true)}, http.StatusOK, []string{"limit", "quota"}},
+		{"later checks told of rejection", []Check{check("limit", false), check("quota", true), check("hold", true)}, http.StatusTooManyRequests, []string{"limit", "quota (rejected)", "hold (rejected)"}},
+		{"last rejects", []Check{check("limit", true), check("quota", false)}, http.StatusTooManyRequests, []string{"limit", "quota"}},
+		{"no checks", nil, http.StatusOK, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			served := false
+			handler := Guard(client, tt.checks...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				served = true
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/posts", nil))
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, rec.Code)
+			}
+			if served != (tt.wantCode == http.StatusOK) {
+				t.Errorf("Expected served %v, got %v", tt.wantCode == http.StatusOK, served)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("Expected calls %v, got %v", tt.wantCalls, calls)
+			}
+		})
+	}
+}
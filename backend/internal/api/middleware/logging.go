@@ -1,34 +1,83 @@
 package middleware
 
 import (
-	"log"
+	"context"
+	"log/slog"
+	"math/rand"
 	"net/http"
+	"strconv"
 	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/metrics"
 )
 
-// Logger is a middleware that logs HTTP requests
-func Logger(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
+// unmatchedRoute labels requests that matched no route, keeping metric
+// cardinality bounded
+const unmatchedRoute = "unmatched"
 
-		// Create a response writer wrapper to capture status code
-		wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+type accessLogKey struct{}
 
-		next.ServeHTTP(wrapped, r)
+// accessEntry collects request details set by inner middleware
+type accessEntry struct {
+	userID uuid.UUID
+}
 
-		log.Printf(
-			"%s %s %d %s",
-			r.Method,
-			r.URL.Path,
-			wrapped.statusCode,
-			time.Since(start),
-		)
-	})
+// setAccessLogUser records the authenticated user on the request's access log entry
+func setAccessLogUser(ctx context.Context, userID uuid.UUID) {
+	if entry, ok := ctx.Value(accessLogKey{}).(*accessEntry); ok {
+		entry.userID = userID
+	}
+}
+
+// AccessLog logs each request with its route pattern, status, response size,
+// latency and user, and records the latency and size in Prometheus. Routes in
+// sampled are logged for only that fraction of requests (e.g. long-lived SSE
+// streams); metrics are always recorded.
+func AccessLog(sampled map[string]float64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			entry := &accessEntry{}
+			wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+			next.ServeHTTP(wrapped, r.WithContext(context.WithValue(r.Context(), accessLogKey{}, entry)))
+
+			latency := time.Since(start)
+			route := unmatchedRoute
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+			status := strconv.Itoa(wrapped.statusCode)
+
+			metrics.HTTPRequestDuration.WithLabelValues(r.Method, route, status).Observe(latency.Seconds())
+			metrics.HTTPResponseSize.WithLabelValues(r.Method, route).Observe(float64(wrapped.bytes))
+
+			if rate, ok := sampled[route]; ok && rand.Float64() >= rate {
+				return
+			}
+
+			attrs := []any{
+				"method", r.Method,
+				"path", r.URL.Path,
+				"route", route,
+				"status", wrapped.statusCode,
+				"bytes", wrapped.bytes,
+				"latency_ms", float64(latency.Microseconds()) / 1000,
+			}
+			if entry.userID != uuid.Nil {
+				attrs = append(attrs, "user_id", entry.userID)
+			}
+			slog.Info("http request", attrs...)
+		})
+	}
 }
 
 type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
+	bytes      int
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
@@ -36,6 +85,12 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytes += n
+	return n, err
+}
+
 // Flush implements http.Flusher interface for SSE support
 func (rw *responseWriter) Flush() {
 	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func TestAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	userID := uuid.New()
+	r := chi.NewRouter()
+	r.Use(AccessLog(map[string]float64{"/stream": 0}))
+	r.Get("/posts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		setAccessLogUser(r.Context(), userID)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello"))
+	})
+	r.Get("/stream", func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		name string
+		path string
+		want []string // Empty when the request should not be logged
+	}{
+		{"route pattern, status, bytes and user", "/posts/123",
+			[]string{"route=/posts/{id}", "path=/posts/123", "status=201", "bytes=5", "user_id=" + userID.String()}},
+		{"unmatched route", "/nope", []string{"route=unmatched", "status=404"}},
+		{"sampled out", "/stream", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			line := buf.String()
+			if len(tt.want) == 0 && line != "" {
+				t.Errorf("Expected no log line, got %q", line)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(line, want) {
+					t.Errorf("Expected log line to contain %q, got %q", want, line)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/maintenance"
+)
+
+// Maintenance creates a check rejecting mutating requests with 503 while
+// maintenance mode is on. Reads keep working, and the flag fails open if Redis
+// can't be reached.
+func Maintenance(store *maintenance.Store) Check {
+	return func(w http.ResponseWriter, r *http.Request, pipe redis.Pipeliner) func(bool) bool {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return nil
+		}
+
+		get := store.QueueGet(r.Context(), pipe)
+
+		return func(rejected bool) bool {
+			if rejected {
+				return false
+			}
+
+			state, err := get()
+			if err != nil {
+				log.Printf("⚠️ Failed to check maintenance mode: %v", err)
+				return true
+			}
+			if !state.Enabled {
+				return true
+			}
+
+			body, _ := json.Marshal(map[string]interface{}{
+				"error":       "Service Unavailable",
+				"message":     state.Message,
+				"maintenance": true,
+			})
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write(body)
+			return false
+		}
+	}
+}
@@ -1,12 +1,17 @@
 package middleware
 
 import (
-	"context"
 	"fmt"
+	"log"
 	"net/http"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/abuse"
+	"github.com/scheduler/backend/internal/api/handlers"
+	"github.com/scheduler/backend/internal/config"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/ratelimit"
 )
 
 // RateLimiter configuration
@@ -15,71 +20,72 @@ type RateLimiterConfig struct {
 	Window time.Duration // Time window
 }
 
-// RateLimiter creates a rate limiting middleware using Redis
-func RateLimiter(redisClient *redis.Client, config RateLimiterConfig) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := r.Context()
+// Policy describes the configuration for clients, for requests in scope
+func (c RateLimiterConfig) Policy(scope string) models.RateLimitPolicy {
+	return models.RateLimitPolicy{
+		Scope:         scope,
+		Limit:         c.Limit,
+		WindowSeconds: int(c.Window / time.Second),
+	}
+}
 
-			// Get client identifier (IP address)
-			clientIP := r.RemoteAddr
-			if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
-				clientIP = forwarded
+// RateLimit creates a check enforcing the scope's current limit from the
+// registry using Redis. Authenticated users get their plan's multiplier, so
+// the check should run after Auth where there is one. Clients the abuse
+// detector has flagged get a reduced limit; the detector may be nil.
+func RateLimit(limits *ratelimit.Registry, detector *abuse.Detector, scope string) Check {
+	return func(w http.ResponseWriter, r *http.Request, pipe redis.Pipeliner) func(bool) bool {
+		ctx := r.Context()
+
+		var plan models.Plan
+		subjects := []string{abuse.IPSubject(ratelimit.ClientIP(r))}
+		if user := handlers.GetUserFromContext(ctx); user != nil {
+			plan = user.Plan
+			subjects = append(subjects, abuse.UserSubject(user.ID))
+		}
+		policy := limits.Policy(ctx, scope, plan)
+		throttle := func() int { return policy.Limit }
+		if detector != nil {
+			throttle = detector.QueueThrottle(ctx, pipe, policy.Limit, subjects...)
+		}
+
+		// Check and increment the client's counter for this scope and path
+		take := limits.QueueTake(ctx, pipe, ratelimit.Client(r), scope, r.URL.Path, policy.Window())
+
+		return func(rejected bool) bool {
+			if rejected {
+				return false
 			}
 
-			// Create rate limit key
-			key := fmt.Sprintf("ratelimit:%s:%s", clientIP, r.URL.Path)
-
-			// Check and increment counter
-			allowed, remaining, err := checkRateLimit(ctx, redisClient, key, config)
+			policy.Limit = throttle()
+			count, err := take()
 			if err != nil {
 				// Fail closed - reject request when Redis unavailable for security
 				http.Error(w, `{"error":"Service Unavailable","message":"Rate limiting service unavailable"}`, http.StatusServiceUnavailable)
-				return
+				return false
+			}
+			remaining := policy.Limit - count
+			if remaining < 0 {
+				remaining = 0
 			}
 
 			// Set rate limit headers
-			w.Header().Set("X-RateLimit-Limit", fmt.Sprintf("%d", config.Limit))
+			w.Header().Set("X-RateLimit-Limit", fmt.Sprintf("%d", policy.Limit))
 			w.Header().Set("X-RateLimit-Remaining", fmt.Sprintf("%d", remaining))
-			w.Header().Set("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(config.Window).Unix()))
+			w.Header().Set("X-RateLimit-Reset", fmt.Sprintf("%d", time.Now().Add(policy.Window()).Unix()))
 
-			if !allowed {
-				w.Header().Set("Retry-After", fmt.Sprintf("%d", int(config.Window.Seconds())))
+			if count > policy.Limit {
+				w.Header().Set("Retry-After", fmt.Sprintf("%d", policy.WindowSeconds))
 				http.Error(w, `{"error":"Too Many Requests","message":"Rate limit exceeded. Please try again later."}`, http.StatusTooManyRequests)
-				return
+				return false
 			}
-
-			next.ServeHTTP(w, r)
-		})
-	}
-}
-
-func checkRateLimit(ctx context.Context, client *redis.Client, key string, config RateLimiterConfig) (allowed bool, remaining int, err error) {
-	// Use a pipeline for atomic operations
-	pipe := client.Pipeline()
-
-	// Increment the counter
-	incrCmd := pipe.Incr(ctx, key)
-
-	// Set expiration only if this is a new key
-	pipe.Expire(ctx, key, config.Window)
-
-	// Execute pipeline
-	_, err = pipe.Exec(ctx)
-	if err != nil {
-		return false, 0, err
+			return true
+		}
 	}
-
-	count := int(incrCmd.Val())
-	remaining = config.Limit - count
-	if remaining < 0 {
-		remaining = 0
-	}
-
-	return count <= config.Limit, remaining, nil
 }
 
-// Default rate limit configurations
+// Default rate limit configurations, overridden by RATE_LIMITS and at runtime
+// through the admin API
 var (
 	AuthRateLimit = RateLimiterConfig{
 		Limit:  5,
@@ -101,3 +107,22 @@ var (
 		Window: time.Minute,
 	}
 )
+
+// DefaultRateLimits returns the default limit of each scope, with configured
+// overrides applied
+func DefaultRateLimits(overrides map[string]config.RateLimit) map[string]models.RateLimitPolicy {
+	policies := map[string]models.RateLimitPolicy{
+		ratelimit.ScopeLogin:      AuthRateLimit.Policy(ratelimit.ScopeLogin),
+		ratelimit.ScopeRegister:   RegisterRateLimit.Policy(ratelimit.ScopeRegister),
+		ratelimit.ScopePostCreate: CreatePostRateLimit.Policy(ratelimit.ScopePostCreate),
+		ratelimit.ScopeAPI:        APIRateLimit.Policy(ratelimit.ScopeAPI),
+	}
+	for scope, o := range overrides {
+		if _, ok := policies[scope]; !ok {
+			log.Printf("⚠️ Ignoring rate limit for unknown scope %q", scope)
+			continue
+		}
+		policies[scope] = RateLimiterConfig{Limit: o.Limit, Window: o.Window}.Policy(scope)
+	}
+	return policies
+}
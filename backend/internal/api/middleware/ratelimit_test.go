@@ -3,6 +3,8 @@ package middleware
 import (
 	"testing"
 	"time"
+
+	"github.com/scheduler/backend/internal/config"
 )
 
 func TestRateLimiterConfig(t *testing.T) {
@@ -26,3 +28,23 @@ func TestRateLimiterConfig(t *testing.T) {
 		t.Errorf("Expected CreatePostRateLimit.Limit to be 30, got %d", CreatePostRateLimit.Limit)
 	}
 }
+
+func TestDefaultRateLimits(t *testing.T) {
+	policies := DefaultRateLimits(map[string]config.RateLimit{
+		"api":     {Limit: 200, Window: 2 * time.Minute},
+		"unknown": {Limit: 1, Window: time.Second},
+	})
+
+	if len(policies) != 4 {
+		t.Fatalf("Expected 4 scopes, got %d", len(policies))
+	}
+	if api := policies["api"]; api.Limit != 200 || api.WindowSeconds != 120 {
+		t.Errorf("Expected api override 200 per 120s, got %d per %ds", api.Limit, api.WindowSeconds)
+	}
+	if login := policies["login"]; login.Limit != AuthRateLimit.Limit || login.WindowSeconds != 60 {
+		t.Errorf("Expected login default %d per 60s, got %d per %ds", AuthRateLimit.Limit, login.Limit, login.WindowSeconds)
+	}
+	if _, ok := policies["unknown"]; ok {
+		t.Error("Expected unknown scope to be ignored")
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/scheduler/backend/internal/api/handlers"
+)
+
+// Suspension rejects mutating requests with 403 from suspended accounts, which
+// stay read-only until an admin lifts the suspension. Must run after Auth.
+func Suspension() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			user := handlers.GetUserFromContext(r.Context())
+			if user == nil || !user.Suspended() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			suspension := user.Suspension()
+			body, _ := json.Marshal(map[string]interface{}{
+				"error":        "Forbidden",
+				"message":      "Your account is suspended: " + suspension.Reason,
+				"suspended_at": suspension.SuspendedAt.Format(time.RFC3339),
+			})
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write(body)
+		})
+	}
+}
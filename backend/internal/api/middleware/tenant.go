@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/scheduler/backend/internal/tenant"
+)
+
+// Tenant resolves the request's tenant from the X-Tenant header or the
+// hostname and scopes the request context to it
+func Tenant(resolver *tenant.Resolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t, err := resolver.Resolve(r.Context(), r.Header.Get(tenant.Header), r.Host)
+			if errors.Is(err, tenant.ErrUnknownTenant) {
+				http.Error(w, `{"error":"Not Found","message":"Unknown tenant"}`, http.StatusNotFound)
+				return
+			}
+			if err != nil {
+				log.Printf("❌ Failed to resolve tenant: %v", err)
+				http.Error(w, `{"error":"Service Unavailable","message":"Tenant lookup unavailable"}`, http.StatusServiceUnavailable)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(tenant.NewContext(r.Context(), t)))
+		})
+	}
+}
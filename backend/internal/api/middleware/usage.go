@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/api/handlers"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/usage"
+)
+
+// Usage creates a check counting each authenticated request against the
+// user's daily API quota, rejecting requests over it with 429. Requests an
+// earlier check rejected aren't counted. Must run after Auth. Metering fails
+// open if Redis can't be reached.
+func Usage(meter *usage.Meter) Check {
+	return func(w http.ResponseWriter, r *http.Request, pipe redis.Pipeliner) func(bool) bool {
+		user := handlers.GetUserFromContext(r.Context())
+		if user == nil {
+			return nil
+		}
+
+		record := meter.QueueRecordRequest(r.Context(), pipe, user.ID)
+
+		return func(rejected bool) bool {
+			count, err := record()
+			if err != nil {
+				log.Printf("⚠️ Failed to meter request for user %s: %v", user.ID, err)
+				return !rejected
+			}
+			if rejected {
+				if err := meter.ForgetRequest(r.Context(), user.ID); err != nil {
+					log.Printf("⚠️ Failed to uncount rejected request for user %s: %v", user.ID, err)
+				}
+				return false
+			}
+
+			quota := user.Plan.Quota()
+			if quota.APIRequestsPerDay > 0 {
+				w.Header().Set("X-Quota-Limit", fmt.Sprintf("%d", quota.APIRequestsPerDay))
+				w.Header().Set("X-Quota-Remaining", fmt.Sprintf("%d", max(quota.APIRequestsPerDay-count, 0)))
+			}
+
+			if !quota.AllowsRequests(count) {
+				_, end := models.DayBounds(time.Now())
+				w.Header().Set("Retry-After", fmt.Sprintf("%d", int(time.Until(end).Seconds())+1))
+				http.Error(w, `{"error":"Too Many Requests","message":"Daily API quota exceeded for your plan"}`, http.StatusTooManyRequests)
+				return false
+			}
+			return true
+		}
+	}
+}
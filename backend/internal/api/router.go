@@ -2,63 +2,153 @@ package api
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/abuse"
+	"github.com/scheduler/backend/internal/announcement"
 	"github.com/scheduler/backend/internal/api/handlers"
 	"github.com/scheduler/backend/internal/api/middleware"
 	"github.com/scheduler/backend/internal/auth"
 	"github.com/scheduler/backend/internal/cache"
+	"github.com/scheduler/backend/internal/clock"
+	"github.com/scheduler/backend/internal/config"
 	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/maintenance"
+	"github.com/scheduler/backend/internal/media"
+	"github.com/scheduler/backend/internal/models"
 	"github.com/scheduler/backend/internal/notifier"
+	"github.com/scheduler/backend/internal/oauth"
+	"github.com/scheduler/backend/internal/ratelimit"
 	"github.com/scheduler/backend/internal/scheduler"
+	"github.com/scheduler/backend/internal/tenant"
+	"github.com/scheduler/backend/internal/usage"
 )
 
+// tenantCacheTTL is how long the tenant list is cached before reloading
+const tenantCacheTTL = time.Minute
+
 // NewRouter creates and configures the HTTP router
 func NewRouter(
 	database *db.DB,
 	jwtService *auth.JWTService,
 	blacklist *auth.Blacklist,
+	domainPolicy *auth.DomainPolicy,
 	queue *scheduler.Queue,
+	mediaStore *media.Store,
 	redisClient *redis.Client,
-	corsOrigin string,
-	secureCookies bool,
+	cfg *config.Config,
+	clk clock.Clock,
 ) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Initialize cache
 	postCache := cache.NewCache(redisClient)
+	postCache.EnableWarming(database)
 
 	// Initialize notifier for real-time updates (with Redis pub/sub)
 	postNotifier := notifier.NewNotifier(redisClient)
+	if cfg.NotifierSharedChannel {
+		postNotifier.EnableSharedChannel()
+	}
 
 	// Global middleware
-	r.Use(middleware.Logger)
+	r.Use(middleware.AccessLog(map[string]float64{
+		"/api/posts/stream": cfg.SSELogSampleRate,
+	}))
 	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{corsOrigin},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Content-Type", "Authorization"},
+		AllowedOrigins:   []string{cfg.CORSOrigin},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Content-Type", "Authorization", "If-None-Match", "If-Modified-Since", tenant.Header, middleware.WorkspaceHeader, "Last-Event-ID"},
+		ExposedHeaders:   []string{"ETag", "Last-Modified"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
 
+	// Scores clients on abuse heuristics, throttling and holding flagged ones
+	abuseDetector := abuse.NewDetector(redisClient, abuse.Policy{
+		ThrottleScore:      cfg.AbuseThrottleScore,
+		ThrottleMultiplier: cfg.AbuseThrottleMultiplier,
+		HoldScore:          cfg.AbuseHoldScore,
+		HoldDuration:       cfg.AbuseHoldDuration,
+	})
+
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(database, jwtService, blacklist, secureCookies)
-	postHandler := handlers.NewPostHandler(database, queue, postCache, postNotifier)
-	sseHandler := handlers.NewSSEHandler(database, postNotifier)
+	authCookies := handlers.AuthCookies{
+		Secure:   cfg.SecureCookies,
+		SameSite: cfg.CookieSameSite,
+		Domain:   cfg.CookieDomain,
+		Path:     cfg.CookiePath,
+	}
+	authHandler := handlers.NewAuthHandler(database, jwtService, blacklist, domainPolicy, abuseDetector, authCookies, cfg.InviteOnly)
+	dailyLimits := models.NewDailyLimits(cfg.ChannelDailyLimits)
+	scheduling := models.NewSchedulingPolicy(cfg.ScheduleHorizonDays, cfg.SchedulePastGrace)
+	jobQueue := scheduler.NewJobQueue(redisClient)
+	dispatcher := scheduler.NewDispatcher(postNotifier, jobQueue)
+	announcements := announcement.NewStore(redisClient)
+	postHandler := handlers.NewPostHandler(database, queue, postCache, postNotifier, cfg.RequireAltText, dailyLimits, scheduling, abuseDetector, dispatcher, clk)
+	sseHandler := handlers.NewSSEHandler(database, postNotifier, announcements)
+	accountHandler := handlers.NewAccountHandler(database, mediaStore, postCache)
+	var mediaJobs *scheduler.JobQueue
+	if cfg.FFmpegPath != "" {
+		mediaJobs = jobQueue
+	}
+	mediaHandler := handlers.NewMediaHandler(database, mediaStore, mediaJobs)
+	channelHandler := handlers.NewChannelHandler(database, handlers.ChannelOAuth{
+		Providers:   oauthProviders(cfg),
+		StateSecret: cfg.JWTSecret,
+		ReturnURL:   cfg.OAuthReturnURL,
+	})
+	organizationHandler := handlers.NewOrganizationHandler(database, cfg.RetentionPolicy())
+	commentHandler := handlers.NewCommentHandler(database, postNotifier)
+	inboxHandler := handlers.NewInboxHandler(database, postNotifier)
+	feedHandler := handlers.NewFeedHandler(database, postCache, cfg.CORSOrigin)
+	bioHandler := handlers.NewBioHandler(database)
+	embedHandler := handlers.NewEmbedHandler(database, postCache)
+	workspaceHandler := handlers.NewWorkspaceHandler(database, jwtService, authCookies)
+	maintenanceStore := maintenance.NewStore(redisClient)
+	rateLimits := ratelimit.NewRegistry(redisClient, middleware.DefaultRateLimits(cfg.RateLimits), planMultipliers(cfg.RateLimitPlanMultipliers))
+	heartbeats := scheduler.NewHeartbeatStore(redisClient)
+	adminHandler := handlers.NewAdminHandler(database, queue, heartbeats, maintenanceStore, rateLimits, abuseDetector, dispatcher, announcements, postNotifier, redisClient, postCache)
+	usageMeter := usage.NewMeter(redisClient)
+	usageHandler := handlers.NewUsageHandler(database, usageMeter)
+	analyticsHandler := handlers.NewAnalyticsHandler(database)
+	metaHandler := handlers.NewMetaHandler(dailyLimits, scheduling, rateLimits, cfg.InviteOnly, clk)
+	statusHandler := handlers.NewStatusHandler(database, redisClient, heartbeats, cfg.LagAlertThreshold)
+	limitsHandler := handlers.NewLimitsHandler(rateLimits, usageMeter)
 
 	// Auth middleware
-	authMiddleware := middleware.Auth(jwtService, database)
+	authMiddleware := middleware.Auth(jwtService, database, redisClient, postCache, blacklist, abuseDetector)
+
+	// Redis-backed checks, which Guard runs with one round trip per request
+	apiLimit := middleware.RateLimit(rateLimits, abuseDetector, ratelimit.ScopeAPI)
+	createPostLimit := middleware.RateLimit(rateLimits, abuseDetector, ratelimit.ScopePostCreate)
+	usageQuota := middleware.Usage(usageMeter)                  // Counts requests against the user's daily plan quota
+	maintenanceMode := middleware.Maintenance(maintenanceStore) // Rejects mutations while maintenance mode is on
+	abuseHold := middleware.AbuseHold(abuseDetector)            // Rejects mutations from accounts on hold
 
 	// Rate limit middleware
-	authRateLimit := middleware.RateLimiter(redisClient, middleware.AuthRateLimit)
-	registerRateLimit := middleware.RateLimiter(redisClient, middleware.RegisterRateLimit)
-	createPostRateLimit := middleware.RateLimiter(redisClient, middleware.CreatePostRateLimit)
-	apiRateLimit := middleware.RateLimiter(redisClient, middleware.APIRateLimit)
+	authRateLimit := middleware.Guard(redisClient, middleware.RateLimit(rateLimits, abuseDetector, ratelimit.ScopeLogin))
+	registerRateLimit := middleware.Guard(redisClient, middleware.RateLimit(rateLimits, abuseDetector, ratelimit.ScopeRegister))
+	createPostRateLimit := middleware.Guard(redisClient, createPostLimit)
+	apiRateLimit := middleware.Guard(redisClient, apiLimit)
+
+	// The API rate limit and daily quota, and for mutations maintenance mode
+	// and account holds
+	apiGuard := middleware.Guard(redisClient, apiLimit, usageQuota, maintenanceMode, abuseHold)
+	meteredRateLimit := middleware.Guard(redisClient, apiLimit, usageQuota)
+	hookGuard := middleware.Guard(redisClient, createPostLimit, maintenanceMode)
+
+	// Rejects mutations from suspended accounts
+	suspension := middleware.Suspension()
 
 	// Routes
 	r.Route("/api", func(r chi.Router) {
+		// Scope every API request to its tenant
+		r.Use(middleware.Tenant(tenant.NewResolver(database.ListTenants, tenantCacheTTL)))
+
 		// Public auth routes with rate limiting
 		r.Route("/auth", func(r chi.Router) {
 			r.With(registerRateLimit).Post("/register", authHandler.Register)
@@ -76,18 +166,234 @@ func NewRouter(
 		// Protected post routes with rate limiting
 		r.Route("/posts", func(r chi.Router) {
 			r.Use(authMiddleware)
-			r.Use(apiRateLimit)
+			r.Use(apiGuard)
+			r.Use(suspension)
 
 			r.With(createPostRateLimit).Post("/", postHandler.Create)
+			r.Post("/validate", postHandler.Validate)
 			r.Get("/upcoming", postHandler.GetUpcoming)
 			r.Get("/history", postHandler.GetHistory)
 			r.Get("/stream", sseHandler.StreamPosts) // SSE endpoint for real-time updates
 			r.Get("/{id}", postHandler.GetByID)
 			r.Put("/{id}", postHandler.Update)
 			r.Delete("/{id}", postHandler.Delete)
+			r.Post("/{id}/publish-now", postHandler.PublishNow)
+			r.Delete("/{id}/abort", postHandler.Abort)
+			r.Post("/{id}/cancel", postHandler.Cancel)
+			r.Post("/{id}/approve", postHandler.Approve)
+			r.Post("/{id}/reject", postHandler.Reject)
+			r.Put("/{id}/workflow", postHandler.UpdateWorkflow)
+			r.Get("/{id}/ab-test", postHandler.GetABTest)
+			r.Put("/{id}/engagement", postHandler.SetEngagement)
+			r.Get("/{id}/comments", commentHandler.List)
+			r.Post("/{id}/comments", commentHandler.Create)
+			r.Delete("/{id}/comments/{commentID}", commentHandler.Delete)
+		})
+
+		// Inbound webhooks, authenticated by the token in the URL
+		r.Route("/hooks", func(r chi.Router) {
+			r.Use(hookGuard)
+
+			r.Post("/{token}", postHandler.CreateFromWebhook)
+		})
+
+		// Public feeds of published posts, authenticated by the token in the URL
+		r.Route("/feeds/{token}", func(r chi.Router) {
+			r.Use(apiRateLimit)
+
+			r.Get("/rss", feedHandler.Serve("rss"))
+			r.Get("/json", feedHandler.Serve("json"))
+		})
+
+		// Public link-in-bio pages, authenticated by the token in the URL
+		r.Route("/bio/{token}", func(r chi.Router) {
+			r.Use(apiRateLimit)
+
+			r.Get("/", bioHandler.Serve("html"))
+			r.Get("/json", bioHandler.Serve("json"))
+			r.Get("/links/{id}", bioHandler.Click)
+		})
+
+		// Public embeds of upcoming schedules, authenticated by the token in the URL
+		r.Route("/embeds/{token}", func(r chi.Router) {
+			r.Use(apiRateLimit)
+
+			r.Get("/html", embedHandler.Serve("html"))
+			r.Get("/json", embedHandler.Serve("json"))
+		})
+
+		r.Route("/channels", func(r chi.Router) {
+			// OAuth redirects back from the platform, authenticated by the signed state
+			r.With(apiRateLimit).Get("/{channel}/callback", channelHandler.Callback)
+
+			// Protected channel connection routes
+			r.Group(func(r chi.Router) {
+				r.Use(authMiddleware)
+				r.Use(apiGuard)
+				r.Use(suspension)
+
+				r.Get("/", channelHandler.List)
+				r.Get("/status", channelHandler.Status)
+				r.Get("/{channel}/authorize", channelHandler.Authorize)
+				r.Put("/{channel}", channelHandler.Connect)
+				r.Delete("/{channel}", channelHandler.Disconnect)
+			})
+		})
+
+		// Protected inbox routes: replies and mentions on published posts, and
+		// keyword monitor matches
+		r.Route("/inbox", func(r chi.Router) {
+			r.Use(authMiddleware)
+			r.Use(apiGuard)
+			r.Use(suspension)
+
+			r.Get("/", inboxHandler.List)
+			r.Get("/unread", inboxHandler.Unread)
+			r.Post("/read", inboxHandler.Mark)
+			r.Post("/read-all", inboxHandler.MarkAllRead)
+			r.Get("/monitors", inboxHandler.ListMonitors)
+			r.Post("/monitors", inboxHandler.CreateMonitor)
+			r.Delete("/monitors/{id}", inboxHandler.DeleteMonitor)
+		})
+
+		// Protected analytics routes: engagement of published posts and growth of
+		// connected accounts
+		r.Route("/analytics", func(r chi.Router) {
+			r.Use(authMiddleware)
+			r.Use(apiGuard)
+			r.Use(suspension)
+
+			r.Get("/", analyticsHandler.Get)
+			r.Get("/export", analyticsHandler.Export)
+			r.Get("/accounts", analyticsHandler.Accounts)
+		})
+
+		// Protected media upload routes
+		r.Route("/media", func(r chi.Router) {
+			r.Use(authMiddleware)
+			r.Use(apiGuard)
+			r.Use(suspension)
+
+			r.Get("/", mediaHandler.List)
+			r.Post("/", mediaHandler.Upload)
+			r.Put("/{id}", mediaHandler.Update)
+
+			// The current organization workspace's shared library
+			r.Get("/library", mediaHandler.ListLibrary)
+			r.Post("/library", mediaHandler.FileLibraryMedia)
+			r.Delete("/library/{id}", mediaHandler.RemoveLibraryMedia)
+			r.Get("/library/folders", mediaHandler.ListFolders)
+			r.Post("/library/folders", mediaHandler.CreateFolder)
+			r.Delete("/library/folders/{id}", mediaHandler.DeleteFolder)
+		})
+
+		// Protected account routes
+		r.Route("/account", func(r chi.Router) {
+			r.Use(authMiddleware)
+			r.Use(apiGuard)
+			r.Use(suspension)
+
+			r.Put("/avatar", accountHandler.UploadAvatar)
+			r.Delete("/avatar", accountHandler.DeleteAvatar)
+			r.Get("/settings", accountHandler.GetSettings)
+			r.Put("/settings", accountHandler.UpdateSettings)
+			r.Get("/presets", accountHandler.ListPresets)
+			r.Put("/presets/{channel}", accountHandler.SetPreset)
+			r.Delete("/presets/{channel}", accountHandler.DeletePreset)
+			r.Get("/notifications", accountHandler.GetNotifications)
+			r.Patch("/notifications", accountHandler.UpdateNotifications)
+			r.Post("/webhook", accountHandler.RotateWebhook)
+			r.Delete("/webhook", accountHandler.DeleteWebhook)
+			r.Post("/feed", accountHandler.RotateFeed)
+			r.Delete("/feed", accountHandler.DeleteFeed)
+			r.Post("/bio", accountHandler.RotateBio)
+			r.Post("/embed", accountHandler.RotateEmbed)
+			r.Delete("/embed", accountHandler.DeleteEmbed)
+			r.Delete("/bio", accountHandler.DeleteBio)
+			r.Get("/bio/links", accountHandler.ListBioLinks)
+			r.Post("/bio/links", accountHandler.CreateBioLink)
+			r.Put("/bio/links/order", accountHandler.ReorderBioLinks)
+			r.Delete("/bio/links/{id}", accountHandler.DeleteBioLink)
+		})
+
+		// Protected organization and workspace routes
+		r.Route("/organizations", func(r chi.Router) {
+			r.Use(authMiddleware)
+			r.Use(apiGuard)
+			r.Use(suspension)
+
+			r.Post("/", organizationHandler.Create)
+			r.Get("/{id}/members", organizationHandler.ListMembers)
+			r.Put("/{id}/members", organizationHandler.SetMember)
+			r.Delete("/{id}/members/{userID}", organizationHandler.RemoveMember)
+			r.Get("/{id}/publishing-windows", organizationHandler.GetPublishingWindows)
+			r.Put("/{id}/publishing-windows", organizationHandler.SetPublishingWindows)
+			r.Get("/{id}/brand-checklist", organizationHandler.GetBrandChecklist)
+			r.Put("/{id}/brand-checklist", organizationHandler.SetBrandChecklist)
+			r.Get("/{id}/audit/export", organizationHandler.ExportAuditLog)
+			r.Get("/{id}/retention", organizationHandler.GetRetention)
+			r.Put("/{id}/retention", organizationHandler.SetRetention)
+			r.Get("/{id}/retention/preview", organizationHandler.PreviewRetention)
+		})
+
+		r.Route("/workspaces", func(r chi.Router) {
+			r.Use(authMiddleware)
+			r.Use(meteredRateLimit)
+
+			r.Get("/", workspaceHandler.List)
+			r.Post("/switch", workspaceHandler.Switch)
+		})
+
+		// Server time and publishing rules, for client-side validation
+		r.With(apiRateLimit).Get("/meta", metaHandler.Get)
+
+		// Usage against the plan's daily quotas; not itself metered
+		r.Route("/usage", func(r chi.Router) {
+			r.Use(authMiddleware)
+			r.Use(apiRateLimit)
+
+			r.Get("/", usageHandler.Get)
+		})
+
+		// Current rate limit windows and quota consumption; not itself metered
+		r.Route("/limits", func(r chi.Router) {
+			r.Use(authMiddleware)
+			r.Use(apiRateLimit)
+
+			r.Get("/", limitsHandler.Get)
+		})
+
+		// Operator routes, restricted to ADMIN_EMAILS
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(authMiddleware)
+			r.Use(middleware.RequireAdmin(cfg.AdminEmails))
+
+			r.Get("/workers", adminHandler.ListWorkers)
+			r.Get("/cron", adminHandler.ListCronJobs)
+			r.Put("/users/{id}/plan", adminHandler.SetUserPlan)
+			r.Put("/users/{id}/suspension", adminHandler.SuspendUser)
+			r.Delete("/users/{id}/suspension", adminHandler.UnsuspendUser)
+			r.Get("/invites", adminHandler.ListInvites)
+			r.Post("/invites", adminHandler.CreateInvite)
+			r.Delete("/invites/{id}", adminHandler.DeleteInvite)
+			r.Get("/announcements", adminHandler.ListAnnouncements)
+			r.Post("/announcements", adminHandler.CreateAnnouncement)
+			r.Delete("/announcements/{id}", adminHandler.DeleteAnnouncement)
+			r.Get("/maintenance", adminHandler.GetMaintenance)
+			r.Put("/maintenance", adminHandler.SetMaintenance)
+			r.Get("/analytics", adminHandler.GetAnalytics)
+			r.Get("/rate-limits", adminHandler.ListRateLimits)
+			r.Put("/rate-limits/{scope}", adminHandler.SetRateLimit)
+			r.Delete("/rate-limits/{scope}", adminHandler.ResetRateLimit)
+			r.Get("/abuse", adminHandler.ListAbuse)
+			r.Put("/abuse/{subject}/hold", adminHandler.HoldAbuse)
+			r.Delete("/abuse/{subject}", adminHandler.ClearAbuse)
 		})
 	})
 
+	// Public media files (avatars and post attachments)
+	r.Get("/media/*", mediaHandler.Serve)
+
 	// Health check
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -95,5 +401,32 @@ func NewRouter(
 		w.Write([]byte(`{"status":"ok"}`))
 	})
 
+	// Public component health for status pages; outside /api so it isn't tenant-scoped or rate limited
+	r.Get("/api/status", statusHandler.Get)
+
 	return r
 }
+
+// planMultipliers converts configured per-plan rate limit multipliers
+func planMultipliers(configured map[string]float64) map[models.Plan]float64 {
+	multipliers := make(map[models.Plan]float64, len(configured))
+	for plan, m := range configured {
+		multipliers[models.Plan(plan)] = m
+	}
+	return multipliers
+}
+
+// oauthProviders builds the OAuth provider of each channel that connects
+// through one. Providers without credentials stay listed, so authorizing them
+// reports that OAuth isn't configured.
+func oauthProviders(cfg *config.Config) map[models.Channel]*oauth.Provider {
+	redirect := func(c models.Channel) string {
+		return cfg.OAuthRedirectBaseURL + "/channels/" + string(c) + "/callback"
+	}
+	return map[models.Channel]*oauth.Provider{
+		models.ChannelReddit:         oauth.Reddit(cfg.RedditClientID, cfg.RedditClientSecret, redirect(models.ChannelReddit)),
+		models.ChannelGoogleBusiness: oauth.GoogleBusiness(cfg.GoogleClientID, cfg.GoogleClientSecret, redirect(models.ChannelGoogleBusiness)),
+		models.ChannelYouTube:        oauth.YouTube(cfg.GoogleClientID, cfg.GoogleClientSecret, redirect(models.ChannelYouTube)),
+		models.ChannelTikTok:         oauth.TikTok(cfg.TikTokClientKey, cfg.TikTokClientSecret, redirect(models.ChannelTikTok)),
+	}
+}
@@ -0,0 +1,136 @@
+// Package audience polls the follower counts of connected accounts from
+// their platforms. As with publishing, platform calls are simulated: each
+// counter logs the request it would make, and in sandbox mode counters make
+// up a steadily growing count so staging has growth charts to show.
+package audience
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Counter reads a connected account's follower count. A nil count means the
+// platform reported none.
+type Counter interface {
+	Followers(ctx context.Context, conn *models.ChannelConnection) (*int64, error)
+}
+
+// Registry routes connections to the counter for their channel. Channels
+// without one, such as webhooks, have no followers to count.
+type Registry struct {
+	counters map[models.Channel]Counter
+}
+
+// platformEndpoints is the request each channel's follower count is read with
+var platformEndpoints = map[models.Channel]string{
+	models.ChannelTwitter:  "GET /2/users/me?user.fields=public_metrics",
+	models.ChannelLinkedIn: "GET /v2/networkSizes/{organization}?edgeType=CompanyFollowedByMember",
+	models.ChannelFacebook: "GET /me?fields=followers_count",
+	models.ChannelTelegram: "GET /getChatMemberCount?chat_id={target}",
+	models.ChannelYouTube:  "GET /youtube/v3/channels?part=statistics&mine=true",
+	models.ChannelTikTok:   "GET /v2/user/info/?fields=follower_count",
+}
+
+// NewRegistry creates a registry with the platform counter of every channel
+// that has followers
+func NewRegistry() *Registry {
+	r := &Registry{counters: make(map[models.Channel]Counter, len(platformEndpoints))}
+	for channel, endpoint := range platformEndpoints {
+		r.counters[channel] = &platformCounter{channel: channel, endpoint: endpoint}
+	}
+	return r
+}
+
+// NewSandboxRegistry creates a registry whose counters make up follower
+// counts
+func NewSandboxRegistry() *Registry {
+	r := NewRegistry()
+	for channel := range r.counters {
+		r.counters[channel] = NewSandboxCounter()
+	}
+	return r
+}
+
+// Register sets the counter used for a channel
+func (r *Registry) Register(channel models.Channel, c Counter) {
+	r.counters[channel] = c
+}
+
+// Channels returns the channels with a counter, in a stable order
+func (r *Registry) Channels() []models.Channel {
+	channels := make([]models.Channel, 0, len(r.counters))
+	for c := range r.counters {
+		channels = append(channels, c)
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i] < channels[j] })
+	return channels
+}
+
+// Followers reads a connection's follower count using its channel's counter
+func (r *Registry) Followers(ctx context.Context, conn *models.ChannelConnection) (*int64, error) {
+	c, ok := r.counters[conn.Channel]
+	if !ok {
+		return nil, fmt.Errorf("no follower counter registered for channel %s", conn.Channel)
+	}
+	return c.Followers(ctx, conn)
+}
+
+// platformCounter logs the request that reads an account's follower count
+type platformCounter struct {
+	channel  models.Channel
+	endpoint string
+}
+
+// Followers logs the platform request; simulated platforms report no count
+func (c *platformCounter) Followers(ctx context.Context, conn *models.ChannelConnection) (*int64, error) {
+	log.Printf("👥 [AUDIENCE] %s followers of connection %s: %s", c.channel, conn.ID, c.endpoint)
+	return nil, nil
+}
+
+// SandboxCounter makes up follower counts that grow by a few followers a
+// day since the account was connected, from a base picked per connection,
+// so repeated polls on the same day agree
+type SandboxCounter struct {
+	now func() time.Time
+}
+
+// NewSandboxCounter creates a sandbox counter
+func NewSandboxCounter() *SandboxCounter {
+	return &SandboxCounter{now: time.Now}
+}
+
+// Followers makes up the connection's follower count today
+func (c *SandboxCounter) Followers(ctx context.Context, conn *models.ChannelConnection) (*int64, error) {
+	days := int64(c.now().Sub(conn.CreatedAt) / (24 * time.Hour))
+	if days < 0 {
+		days = 0
+	}
+	base := int64(sandboxHash(conn.ID[:], 0) % 5000)
+	growth := int64(sandboxHash(conn.ID[:], 1)%20) + 1
+
+	// A little noise per day, which can lose followers but never below base
+	noise := int64(sandboxHash(conn.ID[:], uint64(c.now().Unix()/86400)+2)%uint64(growth*2)) - growth
+	n := base + growth*days + noise
+	if n < base {
+		n = base
+	}
+	log.Printf("🧪 [SANDBOX] %s made up %d followers for connection %s", conn.Channel, n, conn.ID)
+	return &n, nil
+}
+
+// sandboxHash mixes an ID with a salt into a stable pseudo-random number
+func sandboxHash(id []byte, salt uint64) uint64 {
+	h := fnv.New64a()
+	h.Write(id)
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], salt)
+	h.Write(b[:])
+	return h.Sum64()
+}
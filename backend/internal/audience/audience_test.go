@@ -0,0 +1,43 @@
+package audience
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestSandboxCounter(t *testing.T) {
+	connected := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	conn := &models.ChannelConnection{ID: uuid.New(), Channel: models.ChannelTwitter, CreatedAt: connected}
+
+	c := NewSandboxCounter()
+	count := func(at time.Time) int64 {
+		c.now = func() time.Time { return at }
+		n, err := c.Followers(context.Background(), conn)
+		if err != nil || n == nil {
+			t.Fatalf("Followers = %v, %v", n, err)
+		}
+		return *n
+	}
+
+	morning := count(connected.AddDate(0, 0, 10).Add(8 * time.Hour))
+	if again := count(connected.AddDate(0, 0, 10).Add(8 * time.Hour)); again != morning {
+		t.Errorf("same day counts differ: %d and %d", morning, again)
+	}
+	if later := count(connected.AddDate(0, 0, 100)); later <= morning {
+		t.Errorf("followers after 100 days = %d, want more than %d after 10", later, morning)
+	}
+}
+
+func TestRegistry_Followers(t *testing.T) {
+	r := NewRegistry()
+	if n, err := r.Followers(context.Background(), &models.ChannelConnection{Channel: models.ChannelTwitter}); n != nil || err != nil {
+		t.Errorf("platform counter = %v, %v, want no count", n, err)
+	}
+	if _, err := r.Followers(context.Background(), &models.ChannelConnection{Channel: models.ChannelWebhook}); err == nil {
+		t.Error("counted the followers of a webhook")
+	}
+}
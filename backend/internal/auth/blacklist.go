@@ -28,10 +28,20 @@ func (b *Blacklist) Add(ctx context.Context, jti string, ttl time.Duration) erro
 
 // IsBlacklisted checks if a token JTI is blacklisted
 func (b *Blacklist) IsBlacklisted(ctx context.Context, jti string) (bool, error) {
+	return b.QueueIsBlacklisted(ctx, b.redis, jti)()
+}
+
+// QueueIsBlacklisted adds the check to pipe and returns a func that gives the
+// result IsBlacklisted would, once pipe has run. pipe may be a client, which
+// runs the command at once.
+func (b *Blacklist) QueueIsBlacklisted(ctx context.Context, pipe redis.Cmdable, jti string) func() (bool, error) {
 	key := fmt.Sprintf("blacklist:%s", jti)
-	result, err := b.redis.Exists(ctx, key).Result()
-	if err != nil {
-		return false, err
+	exists := pipe.Exists(ctx, key)
+	return func() (bool, error) {
+		result, err := exists.Result()
+		if err != nil {
+			return false, err
+		}
+		return result > 0, nil
 	}
-	return result > 0, nil
 }
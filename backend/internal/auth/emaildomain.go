@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"strings"
+)
+
+//go:embed disposable_domains.txt
+var disposableDomainsFile string
+
+var (
+	ErrEmailDomainBlocked    = errors.New("email domain is not allowed")
+	ErrDisposableEmailDomain = errors.New("disposable email addresses are not allowed")
+)
+
+// DomainPolicy decides which email domains may be used to register
+type DomainPolicy struct {
+	allow           map[string]bool
+	deny            map[string]bool
+	disposable      map[string]bool
+	blockDisposable bool
+}
+
+// NewDomainPolicy creates a domain policy from allow/deny lists.
+// Allowlisted domains bypass every other check; denylisted domains are
+// always rejected; the built-in disposable set is rejected when blockDisposable is set.
+func NewDomainPolicy(allow, deny []string, blockDisposable bool) *DomainPolicy {
+	return &DomainPolicy{
+		allow:           toDomainSet(allow),
+		deny:            toDomainSet(deny),
+		disposable:      parseDomainList(disposableDomainsFile),
+		blockDisposable: blockDisposable,
+	}
+}
+
+// Check returns an error if the email's domain is not allowed to register
+func (p *DomainPolicy) Check(email string) error {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ErrEmailDomainBlocked
+	}
+	domain := normalizeDomain(email[at+1:])
+
+	if matchDomain(p.allow, domain) {
+		return nil
+	}
+	if matchDomain(p.deny, domain) {
+		return ErrEmailDomainBlocked
+	}
+	if p.blockDisposable && matchDomain(p.disposable, domain) {
+		return ErrDisposableEmailDomain
+	}
+	return nil
+}
+
+// matchDomain reports whether domain or any of its parent domains is in the set
+func matchDomain(set map[string]bool, domain string) bool {
+	for domain != "" {
+		if set[domain] {
+			return true
+		}
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+	return false
+}
+
+func toDomainSet(domains []string) map[string]bool {
+	set := make(map[string]bool, len(domains))
+	for _, d := range domains {
+		if d = normalizeDomain(d); d != "" {
+			set[d] = true
+		}
+	}
+	return set
+}
+
+// parseDomainList parses a newline-separated list, ignoring blanks and # comments
+func parseDomainList(data string) map[string]bool {
+	set := make(map[string]bool)
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		set[normalizeDomain(line)] = true
+	}
+	return set
+}
+
+func normalizeDomain(d string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(d)), ".")
+}
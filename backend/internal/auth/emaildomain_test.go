@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"testing"
+)
+
+func TestDomainPolicy_Check(t *testing.T) {
+	policy := NewDomainPolicy([]string{"yopmail.com"}, []string{"Competitor.io"}, true)
+
+	tests := []struct {
+		email string
+		want  error
+	}{
+		{"user@example.com", nil},
+		{"user@mailinator.com", ErrDisposableEmailDomain},
+		{"user@eu.mailinator.com", ErrDisposableEmailDomain}, // subdomain of listed domain
+		{"user@MAILINATOR.COM", ErrDisposableEmailDomain},    // case insensitive
+		{"user@yopmail.com", nil},                            // allowlist overrides disposable set
+		{"user@competitor.io", ErrEmailDomainBlocked},
+		{"user@team.competitor.io", ErrEmailDomainBlocked},
+		{"not-an-email", ErrEmailDomainBlocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			if got := policy.Check(tt.email); got != tt.want {
+				t.Errorf("Check(%q) = %v, want %v", tt.email, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDomainPolicy_DisposableDisabled(t *testing.T) {
+	policy := NewDomainPolicy(nil, nil, false)
+
+	if err := policy.Check("user@mailinator.com"); err != nil {
+		t.Errorf("Disposable domains should be allowed when blocking is disabled, got: %v", err)
+	}
+}
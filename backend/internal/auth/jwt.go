@@ -15,8 +15,10 @@ var (
 
 // Claims represents the JWT claims
 type Claims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Email  string    `json:"email"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Email       string     `json:"email"`
+	WorkspaceID *uuid.UUID `json:"workspace_id,omitempty"` // Selected organization; nil for the personal workspace
+	RememberMe  bool       `json:"remember_me,omitempty"`  // Refresh tokens last the remember-me TTL
 	jwt.RegisteredClaims
 }
 
@@ -25,14 +27,17 @@ type JWTService struct {
 	secretKey       []byte
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
+	rememberMeTTL   time.Duration
 }
 
-// NewJWTService creates a new JWT service
-func NewJWTService(secret string, accessTTL, refreshTTL time.Duration) *JWTService {
+// NewJWTService creates a new JWT service. Sessions signed in with remember me
+// get refresh tokens lasting rememberMeTTL instead of refreshTTL.
+func NewJWTService(secret string, accessTTL, refreshTTL, rememberMeTTL time.Duration) *JWTService {
 	return &JWTService{
 		secretKey:       []byte(secret),
 		accessTokenTTL:  accessTTL,
 		refreshTokenTTL: refreshTTL,
+		rememberMeTTL:   rememberMeTTL,
 	}
 }
 
@@ -42,17 +47,30 @@ type TokenPair struct {
 	RefreshToken string
 	AccessJTI    string
 	RefreshJTI   string
+	RefreshTTL   time.Duration // Lifetime of the refresh token
 }
 
-// GenerateTokenPair creates a new access and refresh token pair
+// GenerateTokenPair creates a new access and refresh token pair for the personal workspace
 func (s *JWTService) GenerateTokenPair(userID uuid.UUID, email string) (*TokenPair, error) {
+	return s.GenerateWorkspaceTokenPair(userID, email, nil, false)
+}
+
+// GenerateWorkspaceTokenPair creates a new token pair scoped to a workspace.
+// Both tokens carry the workspace and remember me so they survive refreshes.
+func (s *JWTService) GenerateWorkspaceTokenPair(userID uuid.UUID, email string, workspaceID *uuid.UUID, rememberMe bool) (*TokenPair, error) {
 	now := time.Now()
+	refreshTTL := s.refreshTokenTTL
+	if rememberMe {
+		refreshTTL = s.rememberMeTTL
+	}
 
 	// Generate access token
 	accessJTI := uuid.NewString()
 	accessClaims := &Claims{
-		UserID: userID,
-		Email:  email,
+		UserID:      userID,
+		Email:       email,
+		WorkspaceID: workspaceID,
+		RememberMe:  rememberMe,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -67,9 +85,11 @@ func (s *JWTService) GenerateTokenPair(userID uuid.UUID, email string) (*TokenPa
 	// Generate refresh token
 	refreshJTI := uuid.NewString()
 	refreshClaims := &Claims{
-		UserID: userID,
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		RememberMe:  rememberMe,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(s.refreshTokenTTL)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(refreshTTL)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			ID:        refreshJTI,
 		},
@@ -84,6 +104,7 @@ func (s *JWTService) GenerateTokenPair(userID uuid.UUID, email string) (*TokenPa
 		RefreshToken: refreshToken,
 		AccessJTI:    accessJTI,
 		RefreshJTI:   refreshJTI,
+		RefreshTTL:   refreshTTL,
 	}, nil
 }
 
@@ -8,7 +8,7 @@ import (
 )
 
 func TestJWTService_GenerateTokenPair(t *testing.T) {
-	service := NewJWTService("test-secret-key", 15*time.Minute, 7*24*time.Hour)
+	service := NewJWTService("test-secret-key", 15*time.Minute, 7*24*time.Hour, 30*24*time.Hour)
 	
 	userID := uuid.New()
 	email := "test@example.com"
@@ -36,7 +36,7 @@ func TestJWTService_GenerateTokenPair(t *testing.T) {
 }
 
 func TestJWTService_ValidateToken(t *testing.T) {
-	service := NewJWTService("test-secret-key", 15*time.Minute, 7*24*time.Hour)
+	service := NewJWTService("test-secret-key", 15*time.Minute, 7*24*time.Hour, 30*24*time.Hour)
 	
 	userID := uuid.New()
 	email := "test@example.com"
@@ -59,7 +59,7 @@ func TestJWTService_ValidateToken(t *testing.T) {
 }
 
 func TestJWTService_ValidateToken_InvalidToken(t *testing.T) {
-	service := NewJWTService("test-secret-key", 15*time.Minute, 7*24*time.Hour)
+	service := NewJWTService("test-secret-key", 15*time.Minute, 7*24*time.Hour, 30*24*time.Hour)
 	
 	_, err := service.ValidateToken("invalid-token")
 	if err == nil {
@@ -68,8 +68,8 @@ func TestJWTService_ValidateToken_InvalidToken(t *testing.T) {
 }
 
 func TestJWTService_ValidateToken_WrongSecret(t *testing.T) {
-	service1 := NewJWTService("secret-1", 15*time.Minute, 7*24*time.Hour)
-	service2 := NewJWTService("secret-2", 15*time.Minute, 7*24*time.Hour)
+	service1 := NewJWTService("secret-1", 15*time.Minute, 7*24*time.Hour, 30*24*time.Hour)
+	service2 := NewJWTService("secret-2", 15*time.Minute, 7*24*time.Hour, 30*24*time.Hour)
 	
 	tokens, _ := service1.GenerateTokenPair(uuid.New(), "test@example.com")
 	
@@ -81,7 +81,7 @@ func TestJWTService_ValidateToken_WrongSecret(t *testing.T) {
 
 func TestJWTService_ExpiredToken(t *testing.T) {
 	// Create service with very short TTL
-	service := NewJWTService("test-secret-key", 1*time.Millisecond, 1*time.Millisecond)
+	service := NewJWTService("test-secret-key", 1*time.Millisecond, 1*time.Millisecond, 1*time.Millisecond)
 	
 	tokens, _ := service.GenerateTokenPair(uuid.New(), "test@example.com")
 	
@@ -93,3 +93,61 @@ func TestJWTService_ExpiredToken(t *testing.T) {
 		t.Errorf("Expected ErrExpiredToken, got: %v", err)
 	}
 }
+
+func TestJWTService_WorkspaceClaim(t *testing.T) {
+	service := NewJWTService("test-secret-key", 15*time.Minute, 7*24*time.Hour, 30*24*time.Hour)
+	workspaceID := uuid.New()
+
+	tokens, err := service.GenerateWorkspaceTokenPair(uuid.New(), "test@example.com", &workspaceID, false)
+	if err != nil {
+		t.Fatalf("GenerateWorkspaceTokenPair failed: %v", err)
+	}
+
+	for name, token := range map[string]string{"access": tokens.AccessToken, "refresh": tokens.RefreshToken} {
+		claims, err := service.ValidateToken(token)
+		if err != nil {
+			t.Fatalf("ValidateToken(%s) failed: %v", name, err)
+		}
+		if claims.WorkspaceID == nil || *claims.WorkspaceID != workspaceID {
+			t.Errorf("%s token WorkspaceID = %v, want %v", name, claims.WorkspaceID, workspaceID)
+		}
+	}
+
+	personal, _ := service.GenerateTokenPair(uuid.New(), "test@example.com")
+	claims, _ := service.ValidateToken(personal.AccessToken)
+	if claims.WorkspaceID != nil {
+		t.Errorf("Personal token WorkspaceID = %v, want nil", claims.WorkspaceID)
+	}
+}
+
+func TestJWTService_RememberMe(t *testing.T) {
+	service := NewJWTService("test-secret-key", 15*time.Minute, 7*24*time.Hour, 30*24*time.Hour)
+
+	tokens, err := service.GenerateWorkspaceTokenPair(uuid.New(), "test@example.com", nil, true)
+	if err != nil {
+		t.Fatalf("GenerateWorkspaceTokenPair failed: %v", err)
+	}
+	if tokens.RefreshTTL != 30*24*time.Hour {
+		t.Errorf("Expected remember-me refresh TTL 720h, got %v", tokens.RefreshTTL)
+	}
+
+	claims, err := service.ValidateToken(tokens.RefreshToken)
+	if err != nil {
+		t.Fatalf("ValidateToken failed: %v", err)
+	}
+	if !claims.RememberMe {
+		t.Error("Expected refresh token to carry remember me")
+	}
+	if ttl := time.Until(claims.ExpiresAt.Time); ttl < 29*24*time.Hour {
+		t.Errorf("Expected refresh token to last about 30 days, expires in %v", ttl)
+	}
+
+	personal, _ := service.GenerateTokenPair(uuid.New(), "test@example.com")
+	if personal.RefreshTTL != 7*24*time.Hour {
+		t.Errorf("Expected default refresh TTL 168h, got %v", personal.RefreshTTL)
+	}
+	claims, _ = service.ValidateToken(personal.RefreshToken)
+	if claims.RememberMe {
+		t.Error("Expected no remember me without the flag")
+	}
+}
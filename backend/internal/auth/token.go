@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// urlTokenBytes is the entropy of tokens embedded in URLs
+const urlTokenBytes = 32
+
+// GenerateURLToken returns a random hex token for URLs that authenticate by
+// token alone, such as inbound webhooks
+func GenerateURLToken() (string, error) {
+	b := make([]byte, urlTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashURLToken returns the SHA-256 hex digest stored in place of a URL token,
+// so a database leak doesn't expose usable tokens
+func HashURLToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// inviteCodeAlphabet leaves out characters that are easily confused: 0/O, 1/I/L
+const inviteCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+// inviteCodeLength is the number of characters in an invite code, not
+// counting the separating dash
+const inviteCodeLength = 12
+
+// GenerateInviteCode returns a random invite code meant to be typed, such as
+// "7KQ4M9-XH2PRT"
+func GenerateInviteCode() (string, error) {
+	b := make([]byte, inviteCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate invite code: %w", err)
+	}
+
+	code := make([]byte, 0, inviteCodeLength+1)
+	for i, v := range b {
+		if i == inviteCodeLength/2 {
+			code = append(code, '-')
+		}
+		// The modulo bias is negligible for a 31 character alphabet
+		code = append(code, inviteCodeAlphabet[int(v)%len(inviteCodeAlphabet)])
+	}
+	return string(code), nil
+}
+
+// HashInviteCode returns the digest stored in place of an invite code. Case,
+// dashes and spaces are ignored, so codes can be typed loosely.
+func HashInviteCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return HashURLToken(normalized)
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateURLToken(t *testing.T) {
+	a, err := GenerateURLToken()
+	if err != nil {
+		t.Fatalf("GenerateURLToken failed: %v", err)
+	}
+	b, err := GenerateURLToken()
+	if err != nil {
+		t.Fatalf("GenerateURLToken failed: %v", err)
+	}
+
+	if len(a) != urlTokenBytes*2 {
+		t.Errorf("token length = %d, want %d", len(a), urlTokenBytes*2)
+	}
+	if a == b {
+		t.Error("GenerateURLToken returned the same token twice")
+	}
+}
+
+func TestHashURLToken(t *testing.T) {
+	token := "abc123"
+
+	if HashURLToken(token) != HashURLToken(token) {
+		t.Error("HashURLToken is not deterministic")
+	}
+	if HashURLToken(token) == token {
+		t.Error("Hash should not equal the token")
+	}
+	if HashURLToken(token) == HashURLToken("abc124") {
+		t.Error("Different tokens should have different hashes")
+	}
+}
+
+func TestGenerateInviteCode(t *testing.T) {
+	code, err := GenerateInviteCode()
+	if err != nil {
+		t.Fatalf("GenerateInviteCode failed: %v", err)
+	}
+
+	if len(code) != inviteCodeLength+1 || code[inviteCodeLength/2] != '-' {
+		t.Errorf("code = %q, want %d characters split by a dash", code, inviteCodeLength)
+	}
+	for _, c := range strings.ReplaceAll(code, "-", "") {
+		if !strings.ContainsRune(inviteCodeAlphabet, c) {
+			t.Errorf("code %q contains %q, outside the alphabet", code, c)
+		}
+	}
+}
+
+func TestHashInviteCode(t *testing.T) {
+	want := HashInviteCode("7KQ4M9-XH2PRT")
+
+	for _, typed := range []string{"7kq4m9-xh2prt", "7KQ4M9XH2PRT", " 7KQ4M9 XH2PRT "} {
+		if got := HashInviteCode(typed); got != want {
+			t.Errorf("HashInviteCode(%q) differs from the generated code's hash", typed)
+		}
+	}
+	if HashInviteCode("7KQ4M9-XH2PRU") == want {
+		t.Error("Different codes should have different hashes")
+	}
+}
@@ -0,0 +1,157 @@
+// Package backup moves a user's or organization's data between environments
+// as a portable archive: their table rows, read in one consistent snapshot,
+// as gzip-compressed JSON. Uploaded files aren't included; media rows keep
+// their storage keys, so files copied alongside are found again.
+package backup
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db"
+)
+
+// FormatVersion is the version of the archive layout written by Write
+const FormatVersion = 1
+
+// Scope is whose data an archive holds
+type Scope string
+
+const (
+	ScopeUser         Scope = "user"
+	ScopeOrganization Scope = "organization"
+)
+
+// Archive is a backup of one user's or organization's data
+type Archive struct {
+	Format    int          `json:"format"`
+	Scope     Scope        `json:"scope"`
+	Source    string       `json:"source"` // The user's email or the organization's name
+	CreatedAt time.Time    `json:"created_at"`
+	Snapshot  *db.Snapshot `json:"snapshot"`
+}
+
+// Write writes the archive
+func Write(w io.Writer, a *Archive) error {
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(a); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// Read reads an archive written by Write, checking its layout is one this
+// version can restore
+func Read(r io.Reader) (*Archive, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	defer zr.Close()
+
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+	a := &Archive{}
+	if err := db.DecodeSnapshot(data, a); err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	if a.Format != FormatVersion {
+		return nil, fmt.Errorf("archive format %d is not supported; expected %d", a.Format, FormatVersion)
+	}
+	if a.Snapshot == nil || (a.Scope != ScopeUser && a.Scope != ScopeOrganization) {
+		return nil, errors.New("archive is incomplete")
+	}
+	return a, nil
+}
+
+// Target is where an archive is restored
+type Target struct {
+	TenantID uuid.UUID            // The tenant restored into
+	UserID   uuid.UUID            // Owns the restored rows whose user isn't in Users
+	OrgID    *uuid.UUID           // The organization restored into, for organization archives
+	Users    map[string]uuid.UUID // Archived user IDs mapped to users here, matched by email
+}
+
+// Remap gives every row of the snapshot a new ID and points its references at
+// the target, so a restore only ever adds rows: restoring into the
+// environment an archive came from, or twice, makes copies. References to
+// rows outside the archive are cleared, and rows move to the target tenant.
+func Remap(s *db.Snapshot, t Target) {
+	ids := make(map[string]string)
+	for _, rows := range [][]db.Row{s.MediaFolders, s.Media, s.Posts} {
+		for _, row := range rows {
+			if id, ok := row["id"].(string); ok {
+				ids[id] = uuid.NewString()
+			}
+		}
+	}
+	var orgID any
+	if t.OrgID != nil {
+		orgID = t.OrgID.String()
+	}
+	tenantID := t.TenantID.String()
+	if s.User != nil {
+		s.User["tenant_id"] = tenantID
+	}
+	if s.Organization != nil {
+		s.Organization["tenant_id"] = tenantID
+	}
+
+	// user maps an archived user to its user here, or to the target user;
+	// member maps them only if they are here
+	user := func(v any) any {
+		if id, ok := v.(string); ok {
+			if u, ok := t.Users[id]; ok {
+				return u.String()
+			}
+		}
+		return t.UserID.String()
+	}
+	member := func(v any) any {
+		if id, ok := v.(string); ok {
+			if u, ok := t.Users[id]; ok {
+				return u.String()
+			}
+		}
+		return nil
+	}
+	newID := func(v any) any {
+		if id, ok := v.(string); ok {
+			if n, ok := ids[id]; ok {
+				return n
+			}
+		}
+		return nil
+	}
+
+	for _, f := range s.MediaFolders {
+		f["id"], f["org_id"], f["created_by"] = newID(f["id"]), orgID, member(f["created_by"])
+	}
+	for _, m := range s.Media {
+		m["id"], m["user_id"], m["org_id"], m["folder_id"] = newID(m["id"]), user(m["user_id"]), orgID, newID(m["folder_id"])
+	}
+	for _, p := range s.Posts {
+		p["id"], p["user_id"], p["org_id"], p["tenant_id"] = newID(p["id"]), user(p["user_id"]), orgID, tenantID
+		p["assignee_id"] = member(p["assignee_id"])
+		p["recycled_from_id"], p["ab_parent_id"] = newID(p["recycled_from_id"]), newID(p["ab_parent_id"])
+		if media, ok := p["media"].([]any); ok {
+			for _, a := range media {
+				if a, ok := a.(map[string]any); ok {
+					if id := newID(a["media_id"]); id != nil {
+						a["media_id"] = id
+					}
+				}
+			}
+		}
+	}
+	for _, c := range s.ChannelPresets {
+		c["user_id"] = t.UserID.String()
+	}
+}
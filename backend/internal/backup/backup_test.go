@@ -0,0 +1,123 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db"
+)
+
+func TestWriteRead(t *testing.T) {
+	a := &Archive{
+		Format:    FormatVersion,
+		Scope:     ScopeUser,
+		Source:    "user@example.com",
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Snapshot: &db.Snapshot{
+			SchemaVersion: "050",
+			Posts:         []db.Row{{"id": "p1", "retry_count": json.Number("9007199254740993")}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, a); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if got.Scope != ScopeUser || got.Source != a.Source || !got.CreatedAt.Equal(a.CreatedAt) || got.Snapshot.SchemaVersion != "050" {
+		t.Errorf("Read() = %+v, want %+v", got, a)
+	}
+	if n := got.Snapshot.Posts[0]["retry_count"]; n != json.Number("9007199254740993") {
+		t.Errorf("number round-tripped as %v (%T)", n, n)
+	}
+}
+
+func TestReadRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive *Archive
+	}{
+		{"format", &Archive{Format: FormatVersion + 1, Scope: ScopeUser, Snapshot: &db.Snapshot{}}},
+		{"scope", &Archive{Format: FormatVersion, Scope: "team", Snapshot: &db.Snapshot{}}},
+		{"snapshot", &Archive{Format: FormatVersion, Scope: ScopeUser}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, tt.archive); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Read(&buf); err == nil {
+				t.Error("Read() error = nil, want error")
+			}
+		})
+	}
+
+	if _, err := Read(bytes.NewReader([]byte("{}"))); err == nil {
+		t.Error("Read() of plain JSON error = nil, want error")
+	}
+}
+
+func TestRemap(t *testing.T) {
+	owner, member, stranger := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	target, here := uuid.New(), uuid.New()
+	orgID, tenantID := uuid.New(), uuid.New()
+
+	s := &db.Snapshot{
+		User:         db.Row{"conflict_window_minutes": json.Number("30")},
+		Organization: db.Row{"name": "Acme"},
+		MediaFolders: []db.Row{{"id": "f1", "org_id": "old-org", "created_by": stranger}},
+		Media:        []db.Row{{"id": "m1", "user_id": member, "org_id": "old-org", "folder_id": "f1"}},
+		Posts: []db.Row{
+			{"id": "p1", "user_id": owner, "org_id": "old-org", "tenant_id": "old-tenant", "assignee_id": stranger, "recycled_from_id": "gone",
+				"media": []any{map[string]any{"media_id": "m1"}, map[string]any{"media_id": "elsewhere"}}},
+			{"id": "p2", "user_id": stranger, "org_id": "old-org", "assignee_id": member, "ab_parent_id": "p1"},
+		},
+		ChannelPresets: []db.Row{{"user_id": owner, "channel": "twitter"}},
+	}
+	Remap(s, Target{TenantID: tenantID, UserID: target, OrgID: &orgID, Users: map[string]uuid.UUID{member: here}})
+
+	f, m, p1, p2 := s.MediaFolders[0], s.Media[0], s.Posts[0], s.Posts[1]
+	for _, id := range []any{f["id"], m["id"], p1["id"], p2["id"]} {
+		if id == nil || id == "f1" || id == "m1" || id == "p1" || id == "p2" {
+			t.Errorf("id not replaced: %v", id)
+		}
+	}
+	if f["org_id"] != orgID.String() || m["org_id"] != orgID.String() || p1["org_id"] != orgID.String() {
+		t.Error("org_id not set to the target organization")
+	}
+	if p1["tenant_id"] != tenantID.String() || p2["tenant_id"] != tenantID.String() {
+		t.Errorf("post tenant_ids = %v, %v, want %s", p1["tenant_id"], p2["tenant_id"], tenantID)
+	}
+	if s.User["tenant_id"] != tenantID.String() || s.Organization["tenant_id"] != tenantID.String() {
+		t.Errorf("settings tenant_ids = %v, %v, want %s", s.User["tenant_id"], s.Organization["tenant_id"], tenantID)
+	}
+	if f["created_by"] != nil {
+		t.Errorf("created_by of unmatched user = %v, want nil", f["created_by"])
+	}
+	if m["user_id"] != here.String() || m["folder_id"] != f["id"] {
+		t.Errorf("media = %v, want user %s in folder %v", m, here, f["id"])
+	}
+	if p1["user_id"] != target.String() || p2["user_id"] != target.String() {
+		t.Error("posts by unmatched users not given to the target user")
+	}
+	if p1["assignee_id"] != nil || p2["assignee_id"] != here.String() {
+		t.Errorf("assignees = %v, %v", p1["assignee_id"], p2["assignee_id"])
+	}
+	if p1["recycled_from_id"] != nil || p2["ab_parent_id"] != p1["id"] {
+		t.Errorf("post references = %v, %v", p1["recycled_from_id"], p2["ab_parent_id"])
+	}
+	media := p1["media"].([]any)
+	if media[0].(map[string]any)["media_id"] != m["id"] || media[1].(map[string]any)["media_id"] != "elsewhere" {
+		t.Errorf("post media = %v", media)
+	}
+	if s.ChannelPresets[0]["user_id"] != target.String() {
+		t.Errorf("preset user_id = %v", s.ChannelPresets[0]["user_id"])
+	}
+}
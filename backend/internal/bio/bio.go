@@ -0,0 +1,52 @@
+// Package bio renders users' public link-in-bio pages as a standalone HTML
+// document, with no scripts or external assets, so the page loads anywhere a
+// profile links to it.
+package bio
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// pageTemplate lays out a bio page. Link URLs go through the page's click
+// redirect; html/template escapes every value.
+var pageTemplate = template.Must(template.New("bio").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Title}}</title>
+<style>
+body{margin:0;font-family:system-ui,-apple-system,sans-serif;background:#f5f5f7;color:#1d1d1f}
+main{max-width:560px;margin:0 auto;padding:48px 16px;text-align:center}
+img.avatar{width:96px;height:96px;border-radius:50%;object-fit:cover}
+h1{font-size:1.4rem;margin:16px 0 32px}
+a.link{display:block;margin:0 0 12px;padding:16px;border-radius:12px;background:#fff;color:inherit;text-decoration:none;box-shadow:0 1px 3px rgba(0,0,0,.1)}
+a.link:hover{box-shadow:0 2px 8px rgba(0,0,0,.15)}
+.title{font-weight:600}
+.post{display:block;margin-top:6px;font-size:.85rem;color:#6e6e73}
+p.empty{color:#6e6e73}
+</style>
+</head>
+<body>
+<main>
+{{with .AvatarURL}}<img class="avatar" src="{{.}}" alt="">{{end}}
+<h1>{{.Title}}</h1>
+{{range .Links}}<a class="link" href="{{.URL}}" rel="noopener nofollow"><span class="title">{{.Title}}</span>{{with .Post}}<span class="post">{{.Channel}}{{with .PublishedAt}} · {{.Format "Jan 2, 2006"}}{{end}}</span>{{end}}</a>
+{{else}}<p class="empty">No links yet.</p>
+{{end}}</main>
+</body>
+</html>
+`))
+
+// HTML renders a bio page
+func HTML(page models.BioPage) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pageTemplate.Execute(&buf, page); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,48 @@
+package bio
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestHTML(t *testing.T) {
+	published := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+	body, err := HTML(models.BioPage{
+		Title: "Links",
+		Links: []models.BioPageLink{
+			{Title: "Shop <new>", URL: "https://example.com/bio/tok/links/1"},
+			{Title: "Launch day", URL: "https://example.com/bio/tok/links/2", Post: &models.BioLinkPost{Channel: models.ChannelTwitter, PublishedAt: &published}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("HTML() failed: %v", err)
+	}
+	out := string(body)
+
+	for _, want := range []string{
+		"<title>Links</title>",
+		`href="https://example.com/bio/tok/links/1"`,
+		"Shop &lt;new&gt;",
+		"twitter · Jan 15, 2024",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HTML() output missing %q", want)
+		}
+	}
+	if strings.Contains(out, "<script") {
+		t.Error("HTML() output has a script")
+	}
+}
+
+func TestHTML_NoLinks(t *testing.T) {
+	body, err := HTML(models.BioPage{Title: "Links"})
+	if err != nil {
+		t.Fatalf("HTML() failed: %v", err)
+	}
+	if !strings.Contains(string(body), "No links yet.") {
+		t.Error("empty page doesn't say it has no links")
+	}
+}
@@ -13,7 +13,8 @@ import (
 
 // Cache provides Redis-based caching for frequently accessed data
 type Cache struct {
-	redis *redis.Client
+	redis  *redis.Client
+	warmer *warmer // Nil unless EnableWarming was called
 }
 
 // NewCache creates a new cache instance
@@ -27,79 +28,261 @@ func NewCache(redisClient *redis.Client) *Cache {
 const (
 	UpcomingPostsTTL = 30 * time.Second
 	HistoryPostsTTL  = 60 * time.Second
+	FeedTTL          = 5 * time.Minute
+	EmbedTTL         = time.Minute // Embeds show times, so don't serve them stale for long
 )
 
-// Cache key patterns
-func upcomingKey(userID uuid.UUID) string {
-	return fmt.Sprintf("cache:posts:upcoming:%s", userID.String())
+// MaxListPosts is the longest post list cached. Longer lists are read from
+// the database on every request, which streams them rather than holding the
+// whole list in memory.
+const MaxListPosts = 1000
+
+// tagTTL is how long a tag set lives after its last entry was added. It
+// outlasts every entry the set can list.
+const tagTTL = FeedTTL
+
+// Owner identifies a workspace whose posts are cached: a user's personal
+// workspace, or an organization's when OrgID is set. Organization lists are
+// shared by every member; feeds only ever belong to users.
+type Owner struct {
+	TenantID uuid.UUID
+	UserID   uuid.UUID // uuid.Nil for an organization workspace
+	OrgID    uuid.UUID // uuid.Nil for a personal workspace
+}
+
+// WorkspaceOwner returns the owner of the workspace the user is viewing
+func WorkspaceOwner(user *models.User) Owner {
+	if user.WorkspaceID != nil {
+		return Owner{TenantID: user.TenantID, OrgID: *user.WorkspaceID}
+	}
+	return Owner{TenantID: user.TenantID, UserID: user.ID}
+}
+
+// PostOwner returns the owner of the workspace the post belongs to, whose
+// cached lists change with it
+func PostOwner(post *models.Post) Owner {
+	if post.OrgID != nil {
+		return Owner{TenantID: post.TenantID, OrgID: *post.OrgID}
+	}
+	return Owner{TenantID: post.TenantID, UserID: post.UserID}
+}
+
+// orgID returns the organization ID to query the owner's posts with
+func (o Owner) orgID() *uuid.UUID {
+	if o.OrgID == uuid.Nil {
+		return nil
+	}
+	return &o.OrgID
+}
+
+// id names the owner in cache keys. Personal workspaces keep the bare user ID.
+func (o Owner) id() string {
+	if o.OrgID != uuid.Nil {
+		return "org:" + o.OrgID.String()
+	}
+	return o.UserID.String()
+}
+
+// Cache key patterns, namespaced by tenant
+func upcomingKey(o Owner) string {
+	return fmt.Sprintf("cache:%s:posts:upcoming:%s", o.TenantID.String(), o.id())
+}
+
+func historyKey(o Owner, status string) string {
+	return fmt.Sprintf("cache:%s:posts:history:%s:%s", o.TenantID.String(), o.id(), status)
+}
+
+func upcomingSummaryKey(o Owner) string {
+	return fmt.Sprintf("cache:%s:posts:upcoming-summary:%s", o.TenantID.String(), o.id())
+}
+
+func historySummaryKey(o Owner, status string) string {
+	return fmt.Sprintf("cache:%s:posts:history-summary:%s:%s", o.TenantID.String(), o.id(), status)
+}
+
+func feedKey(tenantID, userID uuid.UUID, format string) string {
+	return fmt.Sprintf("cache:%s:feed:%s:%s", tenantID.String(), userID.String(), format)
+}
+
+// embedKey is a user's rendered schedule embed in the given format
+func embedKey(tenantID, userID uuid.UUID, format string) string {
+	return fmt.Sprintf("cache:%s:embed:%s:%s", tenantID.String(), userID.String(), format)
+}
+
+// tagKey is the set of keys cached for an owner, which invalidating the owner
+// deletes
+func tagKey(o Owner) string {
+	return fmt.Sprintf("cache:%s:tags:%s", o.TenantID.String(), o.id())
+}
+
+// GetUpcomingPosts retrieves a workspace's cached upcoming posts
+func (c *Cache) GetUpcomingPosts(ctx context.Context, o Owner) ([]*models.Post, bool) {
+	return c.getPosts(ctx, upcomingKey(o))
+}
+
+// SetUpcomingPosts caches a workspace's upcoming posts
+func (c *Cache) SetUpcomingPosts(ctx context.Context, o Owner, posts []*models.Post) error {
+	return c.setPosts(ctx, o, upcomingKey(o), posts, UpcomingPostsTTL)
+}
+
+// GetHistoryPosts retrieves a workspace's cached history for a status filter
+func (c *Cache) GetHistoryPosts(ctx context.Context, o Owner, status string) ([]*models.Post, bool) {
+	return c.getPosts(ctx, historyKey(o, status))
 }
 
-func historyKey(userID uuid.UUID) string {
-	return fmt.Sprintf("cache:posts:history:%s", userID.String())
+// SetHistoryPosts caches a workspace's history for a status filter
+func (c *Cache) SetHistoryPosts(ctx context.Context, o Owner, status string, posts []*models.Post) error {
+	return c.setPosts(ctx, o, historyKey(o, status), posts, HistoryPostsTTL)
 }
 
-// GetUpcomingPosts retrieves cached upcoming posts for a user
-func (c *Cache) GetUpcomingPosts(ctx context.Context, userID uuid.UUID) ([]*models.Post, bool) {
-	data, err := c.redis.Get(ctx, upcomingKey(userID)).Bytes()
+func (c *Cache) getPosts(ctx context.Context, key string) ([]*models.Post, bool) {
+	data, err := c.redis.Get(ctx, key).Bytes()
 	if err != nil {
 		return nil, false
 	}
 
-	var posts []*models.Post
-	if err := json.Unmarshal(data, &posts); err != nil {
+	posts, err := decodePosts(data)
+	if err != nil {
 		return nil, false
 	}
 
 	return posts, true
 }
 
-// SetUpcomingPosts caches upcoming posts for a user
-func (c *Cache) SetUpcomingPosts(ctx context.Context, userID uuid.UUID, posts []*models.Post) error {
-	data, err := json.Marshal(posts)
+func (c *Cache) setPosts(ctx context.Context, o Owner, key string, posts []*models.Post, ttl time.Duration) error {
+	data, err := encodePosts(posts)
 	if err != nil {
 		return err
 	}
 
-	return c.redis.Set(ctx, upcomingKey(userID), data, UpcomingPostsTTL).Err()
+	return c.set(ctx, o, key, data, ttl)
+}
+
+// GetUpcomingSummaries retrieves a workspace's cached upcoming post summaries
+func (c *Cache) GetUpcomingSummaries(ctx context.Context, o Owner) ([]*models.PostSummary, bool) {
+	return c.getSummaries(ctx, upcomingSummaryKey(o))
+}
+
+// SetUpcomingSummaries caches a workspace's upcoming post summaries
+func (c *Cache) SetUpcomingSummaries(ctx context.Context, o Owner, summaries []*models.PostSummary) error {
+	return c.setSummaries(ctx, o, upcomingSummaryKey(o), summaries, UpcomingPostsTTL)
+}
+
+// GetHistorySummaries retrieves a workspace's cached history summaries for a status filter
+func (c *Cache) GetHistorySummaries(ctx context.Context, o Owner, status string) ([]*models.PostSummary, bool) {
+	return c.getSummaries(ctx, historySummaryKey(o, status))
+}
+
+// SetHistorySummaries caches a workspace's history summaries for a status filter
+func (c *Cache) SetHistorySummaries(ctx context.Context, o Owner, status string, summaries []*models.PostSummary) error {
+	return c.setSummaries(ctx, o, historySummaryKey(o, status), summaries, HistoryPostsTTL)
 }
 
-// GetHistoryPosts retrieves cached published posts for a user
-func (c *Cache) GetHistoryPosts(ctx context.Context, userID uuid.UUID) ([]*models.Post, bool) {
-	data, err := c.redis.Get(ctx, historyKey(userID)).Bytes()
+// Summaries are small, so they are cached as plain JSON
+func (c *Cache) getSummaries(ctx context.Context, key string) ([]*models.PostSummary, bool) {
+	data, err := c.redis.Get(ctx, key).Bytes()
 	if err != nil {
 		return nil, false
 	}
 
-	var posts []*models.Post
-	if err := json.Unmarshal(data, &posts); err != nil {
+	var summaries []*models.PostSummary
+	if err := json.Unmarshal(data, &summaries); err != nil {
 		return nil, false
 	}
-
-	return posts, true
+	return summaries, true
 }
 
-// SetHistoryPosts caches published posts for a user
-func (c *Cache) SetHistoryPosts(ctx context.Context, userID uuid.UUID, posts []*models.Post) error {
-	data, err := json.Marshal(posts)
+func (c *Cache) setSummaries(ctx context.Context, o Owner, key string, summaries []*models.PostSummary, ttl time.Duration) error {
+	data, err := json.Marshal(summaries)
 	if err != nil {
 		return err
 	}
+	return c.set(ctx, o, key, data, ttl)
+}
+
+// set stores an entry and adds its key to the owner's tag set, so that
+// invalidating the owner deletes exactly the entries cached for it
+func (c *Cache) set(ctx context.Context, o Owner, key string, data []byte, ttl time.Duration) error {
+	tag := tagKey(o)
+	pipe := c.redis.TxPipeline()
+	pipe.Set(ctx, key, data, ttl)
+	pipe.SAdd(ctx, tag, key)
+	pipe.Expire(ctx, tag, tagTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetFeed retrieves a user's cached rendered feed in the given format
+func (c *Cache) GetFeed(ctx context.Context, tenantID, userID uuid.UUID, format string) ([]byte, bool) {
+	data, err := c.redis.Get(ctx, feedKey(tenantID, userID, format)).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
 
-	return c.redis.Set(ctx, historyKey(userID), data, HistoryPostsTTL).Err()
+// SetFeed caches a user's rendered feed in the given format
+func (c *Cache) SetFeed(ctx context.Context, tenantID, userID uuid.UUID, format string, body []byte) error {
+	return c.set(ctx, Owner{TenantID: tenantID, UserID: userID}, feedKey(tenantID, userID, format), body, FeedTTL)
 }
 
-// InvalidateUserPosts removes all cached posts for a user
-func (c *Cache) InvalidateUserPosts(ctx context.Context, userID uuid.UUID) error {
-	keys := []string{
-		upcomingKey(userID),
-		historyKey(userID),
+// GetEmbed retrieves a user's cached rendered schedule embed in the given format
+func (c *Cache) GetEmbed(ctx context.Context, tenantID, userID uuid.UUID, format string) ([]byte, bool) {
+	data, err := c.redis.Get(ctx, embedKey(tenantID, userID, format)).Bytes()
+	if err != nil {
+		return nil, false
 	}
+	return data, true
+}
 
-	return c.redis.Del(ctx, keys...).Err()
+// SetEmbed caches a user's rendered schedule embed in the given format
+func (c *Cache) SetEmbed(ctx context.Context, tenantID, userID uuid.UUID, format string, body []byte) error {
+	return c.set(ctx, Owner{TenantID: tenantID, UserID: userID}, embedKey(tenantID, userID, format), body, EmbedTTL)
 }
 
-// InvalidateByPostID finds and invalidates cache for a specific post's user
-// This is useful when the worker publishes a post
-func (c *Cache) InvalidateByUserID(ctx context.Context, userID uuid.UUID) error {
-	return c.InvalidateUserPosts(ctx, userID)
+// invalidateScript deletes the keys listed in each tag set, then the sets.
+// Running as one script, no entry can be added to a set between reading and
+// deleting it and so escape invalidation.
+var invalidateScript = redis.NewScript(`
+for _, tag in ipairs(KEYS) do
+	local keys = redis.call("SMEMBERS", tag)
+	for i = 1, #keys, 500 do
+		redis.call("DEL", unpack(keys, i, math.min(i + 499, #keys)))
+	end
+	redis.call("DEL", tag)
+end
+return 0
+`)
+
+// InvalidateUserPosts removes all cached posts, feeds and embeds of a user's personal workspace
+func (c *Cache) InvalidateUserPosts(ctx context.Context, tenantID, userID uuid.UUID) error {
+	return c.InvalidateOwners(ctx, []Owner{{TenantID: tenantID, UserID: userID}})
+}
+
+// InvalidatePost removes the cached entries that list the post: its author's
+// personal lists, feeds and embeds, or its organization's lists, which every member
+// of the organization reads
+func (c *Cache) InvalidatePost(ctx context.Context, post *models.Post) error {
+	return c.InvalidateOwners(ctx, []Owner{PostOwner(post)})
+}
+
+// InvalidateOwners removes every entry cached for several owners in one round
+// trip, then warms their entries again if warming is enabled
+func (c *Cache) InvalidateOwners(ctx context.Context, owners []Owner) error {
+	if len(owners) == 0 {
+		return nil
+	}
+
+	tags := make([]string, len(owners))
+	for i, o := range owners {
+		tags[i] = tagKey(o)
+	}
+
+	if err := invalidateScript.Run(ctx, c.redis, tags).Err(); err != nil {
+		return err
+	}
+	if c.warmer != nil {
+		c.warmer.schedule(c, owners)
+	}
+	return nil
 }
@@ -0,0 +1,246 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// benchSizes are post list lengths from a light user to a heavy one's history
+var benchSizes = []int{10, 100, 1000}
+
+// samplePosts builds n posts with the optional fields a typical list has set
+func samplePosts(n int) []*models.Post {
+	base := time.Date(2030, 1, 15, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	posts := make([]*models.Post, n)
+	for i := range posts {
+		title := fmt.Sprintf("Post %d", i)
+		published := base.Add(time.Duration(i) * time.Hour)
+		posts[i] = &models.Post{
+			ID:            uuid.New(),
+			UserID:        userID,
+			Title:         &title,
+			Content:       fmt.Sprintf("Scheduled update number %d, with a link https://example.com/posts/%d and a few #hashtags #launch #news", i, i),
+			Channel:       models.ChannelTwitter,
+			Status:        models.PostStatusPublished,
+			ScheduledAt:   published,
+			PublishedAt:   &published,
+			CreatedAt:     base,
+			UpdatedAt:     published,
+			Type:          models.PostTypeText,
+			WorkflowState: models.WorkflowApproved,
+			Media: []models.PostMedia{
+				{MediaID: uuid.New(), URL: fmt.Sprintf("/media/%d.jpg", i), ContentType: "image/jpeg"},
+			},
+		}
+	}
+	return posts
+}
+
+func TestEncodeDecodePosts(t *testing.T) {
+	for _, n := range []int{0, 1, 25} {
+		posts := samplePosts(n)
+		data, err := encodePosts(posts)
+		if err != nil {
+			t.Fatalf("encodePosts(%d posts) error = %v", n, err)
+		}
+		got, err := decodePosts(data)
+		if err != nil {
+			t.Fatalf("decodePosts(%d posts) error = %v", n, err)
+		}
+		if len(got) != n {
+			t.Fatalf("decodePosts returned %d posts, want %d", len(got), n)
+		}
+		for i := range got {
+			if !reflect.DeepEqual(got[i], posts[i]) {
+				t.Errorf("post %d = %+v, want %+v", i, got[i], posts[i])
+			}
+		}
+	}
+}
+
+func TestEncodeDecodePosts_AllFields(t *testing.T) {
+	now := time.Date(2030, 1, 15, 12, 0, 0, 123456789, time.UTC)
+	str := func(s string) *string { return &s }
+	id := func() *uuid.UUID { v := uuid.New(); return &v }
+	minutes := 30
+	variant := models.ABVariantA
+	lat, lng := 51.5, -0.12
+
+	post := &models.Post{
+		ID:                  uuid.New(),
+		UserID:              uuid.New(),
+		Title:               str("Title"),
+		Content:             "Content with unicode ✓",
+		Channel:             models.ChannelLinkedIn,
+		Status:              models.PostStatusFailed,
+		ScheduledAt:         now,
+		PublishedAt:         &now,
+		RetryCount:          3,
+		LastError:           str("rate limited"),
+		NextRetryAt:         &now,
+		CreatedAt:           now.Add(-time.Hour),
+		UpdatedAt:           now,
+		Targeting:           &models.PostTargeting{Visibility: "connections"},
+		Type:                models.PostTypePoll,
+		Poll:                &models.Poll{Options: []string{"a", "b"}, DurationMinutes: 60},
+		Media:               []models.PostMedia{{MediaID: uuid.New(), URL: "/media/1.mp4", ContentType: "video/mp4", AltText: str("alt"), Width: 1080, Height: 1920, DurationMs: &minutes}},
+		Location:            &models.PostLocation{Name: "London", Latitude: &lat, Longitude: &lng},
+		Recycle:             &models.RecycleSettings{IntervalDays: 7, MaxCount: 2},
+		RecycleCount:        1,
+		RecycledFromID:      id(),
+		Priority:            true,
+		OrgID:               id(),
+		WindowOverride:      true,
+		WorkflowState:       models.WorkflowReview,
+		AssigneeID:          id(),
+		ABTest:              &models.ABTest{VariantB: "B", WindowHours: 24, AutoRepost: true},
+		ABVariant:           &variant,
+		ABParentID:          id(),
+		Engagement:          &models.Engagement{Impressions: 100, Likes: 5},
+		RemindBeforeMinutes: &minutes,
+		UndoUntil:           &now,
+		CanceledAt:          &now,
+		NoSignature:         true,
+	}
+
+	data, err := encodePosts([]*models.Post{post})
+	if err != nil {
+		t.Fatalf("encodePosts() error = %v", err)
+	}
+	got, err := decodePosts(data)
+	if err != nil {
+		t.Fatalf("decodePosts() error = %v", err)
+	}
+	if !reflect.DeepEqual(got[0], post) {
+		t.Errorf("decodePosts() = %+v, want %+v", got[0], post)
+	}
+
+	// A field added to Post must be added to the codec too, or it would be
+	// silently dropped from cached lists
+	v := reflect.ValueOf(*post)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.Name != "TenantID" && v.Field(i).IsZero() {
+			t.Errorf("Post.%s is not set in this test; set it and add it to the cache codec", field.Name)
+		}
+	}
+}
+
+func TestDecodePosts_JSON(t *testing.T) {
+	posts := samplePosts(3)
+	data, err := json.Marshal(posts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := decodePosts(data)
+	if err != nil {
+		t.Fatalf("decodePosts(JSON) error = %v", err)
+	}
+	if !reflect.DeepEqual(got, posts) {
+		t.Errorf("decodePosts(JSON) = %+v, want %+v", got, posts)
+	}
+}
+
+func TestDecodePosts_Invalid(t *testing.T) {
+	valid, err := encodePosts(samplePosts(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	future := append([]byte{codecMagic, codecVersion + 1}, valid[2:]...)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"not json", []byte("not json")},
+		{"unknown version", future},
+		{"truncated", valid[:len(valid)-5]},
+		{"trailing bytes", append(append([]byte{}, valid...), 0)},
+		{"huge count", []byte{codecMagic, codecVersion, 0xff, 0xff, 0xff, 0xff, 0x0f}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodePosts(tt.data); err == nil {
+				t.Error("decodePosts() error = nil, want error")
+			}
+		})
+	}
+}
+
+func BenchmarkEncodePosts(b *testing.B) {
+	for _, n := range benchSizes {
+		posts := samplePosts(n)
+		b.Run(fmt.Sprintf("posts=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := encodePosts(posts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDecodePosts(b *testing.B) {
+	for _, n := range benchSizes {
+		data, err := encodePosts(samplePosts(n))
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("posts=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				if _, err := decodePosts(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestOwners(t *testing.T) {
+	tenantID, userID, orgID := uuid.New(), uuid.New(), uuid.New()
+
+	personal := Owner{TenantID: tenantID, UserID: userID}
+	org := Owner{TenantID: tenantID, OrgID: orgID}
+
+	if got := WorkspaceOwner(&models.User{ID: userID, TenantID: tenantID}); got != personal {
+		t.Errorf("WorkspaceOwner(personal) = %+v, want %+v", got, personal)
+	}
+	if got := WorkspaceOwner(&models.User{ID: userID, TenantID: tenantID, WorkspaceID: &orgID}); got != org {
+		t.Errorf("WorkspaceOwner(org) = %+v, want %+v", got, org)
+	}
+	if got := PostOwner(&models.Post{UserID: userID, TenantID: tenantID}); got != personal {
+		t.Errorf("PostOwner(personal) = %+v, want %+v", got, personal)
+	}
+	// An organization post is cached once for the organization, not per member
+	if got := PostOwner(&models.Post{UserID: uuid.New(), TenantID: tenantID, OrgID: &orgID}); got != org {
+		t.Errorf("PostOwner(org) = %+v, want %+v", got, org)
+	}
+
+	if personal.orgID() != nil {
+		t.Errorf("personal orgID() = %v, want nil", personal.orgID())
+	}
+	if id := org.orgID(); id == nil || *id != orgID {
+		t.Errorf("org orgID() = %v, want %s", id, orgID)
+	}
+
+	// Personal keys keep their format; organization keys can't collide with them
+	if got, want := upcomingKey(personal), "cache:"+tenantID.String()+":posts:upcoming:"+userID.String(); got != want {
+		t.Errorf("upcomingKey(personal) = %q, want %q", got, want)
+	}
+	if got, want := upcomingKey(org), "cache:"+tenantID.String()+":posts:upcoming:org:"+orgID.String(); got != want {
+		t.Errorf("upcomingKey(org) = %q, want %q", got, want)
+	}
+	if tagKey(personal) == tagKey(org) {
+		t.Errorf("personal and org tags are both %q", tagKey(org))
+	}
+}
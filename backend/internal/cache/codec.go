@@ -0,0 +1,403 @@
+package cache
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Cached post lists are stored in a compact binary format: encoding/json of
+// long lists dominated the CPU time of cache reads and writes. An entry starts
+// with codecMagic and a format version; entries written as JSON by older
+// releases are still read until they expire.
+//
+// The fields every post carries, and its media, are written directly. The
+// rarely set nested ones (targeting, poll, location, ...) are written together
+// as a JSON object, empty when none is set.
+const (
+	codecMagic   byte = 0xC5 // Never the first byte of a JSON document
+	codecVersion byte = 3    // 2 added no_signature, 3 media dimensions and duration
+)
+
+var errCorruptEntry = errors.New("corrupt cache entry")
+
+// postExtras are the post fields encoded as JSON within a binary entry
+type postExtras struct {
+	Targeting  *models.PostTargeting   `json:"targeting,omitempty"`
+	Poll       *models.Poll            `json:"poll,omitempty"`
+	Location   *models.PostLocation    `json:"location,omitempty"`
+	Recycle    *models.RecycleSettings `json:"recycle,omitempty"`
+	ABTest     *models.ABTest          `json:"ab_test,omitempty"`
+	Engagement *models.Engagement      `json:"engagement,omitempty"`
+}
+
+func (e *postExtras) empty() bool {
+	return e.Targeting == nil && e.Poll == nil && e.Location == nil &&
+		e.Recycle == nil && e.ABTest == nil && e.Engagement == nil
+}
+
+// encodePosts serializes a post list for caching. Like the JSON the API
+// returns, it leaves out TenantID.
+func encodePosts(posts []*models.Post) ([]byte, error) {
+	e := encoder{buf: make([]byte, 0, 64+len(posts)*256)}
+	e.buf = append(e.buf, codecMagic, codecVersion)
+	e.uvarint(uint64(len(posts)))
+	for _, p := range posts {
+		if p == nil {
+			return nil, errors.New("cannot encode a nil post")
+		}
+		if err := e.post(p); err != nil {
+			return nil, err
+		}
+	}
+	return e.buf, nil
+}
+
+// decodePosts reads a post list written by encodePosts, or as JSON by an
+// older release
+func decodePosts(data []byte) ([]*models.Post, error) {
+	if len(data) == 0 || data[0] != codecMagic {
+		var posts []*models.Post
+		if err := json.Unmarshal(data, &posts); err != nil {
+			return nil, err
+		}
+		return posts, nil
+	}
+	if len(data) < 2 {
+		return nil, errCorruptEntry
+	}
+	if data[1] != codecVersion {
+		return nil, fmt.Errorf("unsupported cache entry version %d", data[1])
+	}
+
+	d := decoder{data: data[2:]}
+	n := d.uvarint()
+	// Every post takes well over a byte, which bounds a corrupt count
+	if n > uint64(len(d.data)) {
+		return nil, errCorruptEntry
+	}
+	posts := make([]*models.Post, n)
+	for i := range posts {
+		p, err := d.post()
+		if err != nil {
+			return nil, err
+		}
+		posts[i] = p
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	if len(d.data) != 0 {
+		return nil, errCorruptEntry
+	}
+	return posts, nil
+}
+
+// encoder appends values to a buffer
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) uvarint(v uint64) { e.buf = binary.AppendUvarint(e.buf, v) }
+func (e *encoder) varint(v int64)   { e.buf = binary.AppendVarint(e.buf, v) }
+
+func (e *encoder) bool(v bool) {
+	if v {
+		e.buf = append(e.buf, 1)
+	} else {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *encoder) bytes(v []byte) {
+	e.uvarint(uint64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+func (e *encoder) string(v string) {
+	e.uvarint(uint64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+func (e *encoder) uuid(v uuid.UUID) { e.buf = append(e.buf, v[:]...) }
+
+// time keeps the instant, not the location; decoded times are UTC
+func (e *encoder) time(v time.Time) {
+	if v.IsZero() {
+		e.bool(false)
+		return
+	}
+	e.bool(true)
+	e.varint(v.Unix())
+	e.uvarint(uint64(v.Nanosecond()))
+}
+
+func (e *encoder) optString(v *string) {
+	e.bool(v != nil)
+	if v != nil {
+		e.string(*v)
+	}
+}
+
+func (e *encoder) optUUID(v *uuid.UUID) {
+	e.bool(v != nil)
+	if v != nil {
+		e.uuid(*v)
+	}
+}
+
+func (e *encoder) optTime(v *time.Time) {
+	e.bool(v != nil)
+	if v != nil {
+		e.time(*v)
+	}
+}
+
+func (e *encoder) optInt(v *int) {
+	e.bool(v != nil)
+	if v != nil {
+		e.varint(int64(*v))
+	}
+}
+
+func (e *encoder) post(p *models.Post) error {
+	e.uuid(p.ID)
+	e.uuid(p.UserID)
+	e.optString(p.Title)
+	e.string(p.Content)
+	e.string(string(p.Channel))
+	e.string(string(p.Status))
+	e.time(p.ScheduledAt)
+	e.optTime(p.PublishedAt)
+	e.varint(int64(p.RetryCount))
+	e.optString(p.LastError)
+	e.optTime(p.NextRetryAt)
+	e.time(p.CreatedAt)
+	e.time(p.UpdatedAt)
+	e.string(string(p.Type))
+	e.varint(int64(p.RecycleCount))
+	e.optUUID(p.RecycledFromID)
+	e.bool(p.Priority)
+	e.optUUID(p.OrgID)
+	e.bool(p.WindowOverride)
+	e.string(string(p.WorkflowState))
+	e.optUUID(p.AssigneeID)
+	e.optString((*string)(p.ABVariant))
+	e.optUUID(p.ABParentID)
+	e.optInt(p.RemindBeforeMinutes)
+	e.optTime(p.UndoUntil)
+	e.optTime(p.CanceledAt)
+	e.bool(p.NoSignature)
+
+	e.uvarint(uint64(len(p.Media)))
+	for _, m := range p.Media {
+		e.uuid(m.MediaID)
+		e.string(m.URL)
+		e.string(m.ContentType)
+		e.optString(m.AltText)
+		e.varint(int64(m.Width))
+		e.varint(int64(m.Height))
+		e.optInt(m.DurationMs)
+	}
+
+	extras := postExtras{
+		Targeting:  p.Targeting,
+		Poll:       p.Poll,
+		Location:   p.Location,
+		Recycle:    p.Recycle,
+		ABTest:     p.ABTest,
+		Engagement: p.Engagement,
+	}
+	if extras.empty() {
+		e.bytes(nil)
+		return nil
+	}
+	data, err := json.Marshal(extras)
+	if err != nil {
+		return err
+	}
+	e.bytes(data)
+	return nil
+}
+
+// decoder reads values written by encoder. After the first error every read
+// returns the zero value, so callers check err once at the end.
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) fail() {
+	if d.err == nil {
+		d.err = errCorruptEntry
+	}
+	d.data = nil
+}
+
+func (d *decoder) uvarint() uint64 {
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	v, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *decoder) int() int { return int(d.varint()) }
+
+func (d *decoder) bool() bool {
+	if len(d.data) == 0 {
+		d.fail()
+		return false
+	}
+	v := d.data[0]
+	d.data = d.data[1:]
+	return v == 1
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.uvarint()
+	if n > uint64(len(d.data)) {
+		d.fail()
+		return nil
+	}
+	v := d.data[:n]
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *decoder) string() string { return string(d.bytes()) }
+
+func (d *decoder) uuid() uuid.UUID {
+	var v uuid.UUID
+	if len(d.data) < len(v) {
+		d.fail()
+		return v
+	}
+	copy(v[:], d.data)
+	d.data = d.data[len(v):]
+	return v
+}
+
+func (d *decoder) time() time.Time {
+	if !d.bool() {
+		return time.Time{}
+	}
+	sec := d.varint()
+	nsec := d.uvarint()
+	return time.Unix(sec, int64(nsec)).UTC()
+}
+
+func (d *decoder) optString() *string {
+	if !d.bool() {
+		return nil
+	}
+	v := d.string()
+	return &v
+}
+
+func (d *decoder) optUUID() *uuid.UUID {
+	if !d.bool() {
+		return nil
+	}
+	v := d.uuid()
+	return &v
+}
+
+func (d *decoder) optTime() *time.Time {
+	if !d.bool() {
+		return nil
+	}
+	v := d.time()
+	return &v
+}
+
+func (d *decoder) optInt() *int {
+	if !d.bool() {
+		return nil
+	}
+	v := d.int()
+	return &v
+}
+
+func (d *decoder) post() (*models.Post, error) {
+	p := &models.Post{
+		ID:                  d.uuid(),
+		UserID:              d.uuid(),
+		Title:               d.optString(),
+		Content:             d.string(),
+		Channel:             models.Channel(d.string()),
+		Status:              models.PostStatus(d.string()),
+		ScheduledAt:         d.time(),
+		PublishedAt:         d.optTime(),
+		RetryCount:          d.int(),
+		LastError:           d.optString(),
+		NextRetryAt:         d.optTime(),
+		CreatedAt:           d.time(),
+		UpdatedAt:           d.time(),
+		Type:                models.PostType(d.string()),
+		RecycleCount:        d.int(),
+		RecycledFromID:      d.optUUID(),
+		Priority:            d.bool(),
+		OrgID:               d.optUUID(),
+		WindowOverride:      d.bool(),
+		WorkflowState:       models.WorkflowState(d.string()),
+		AssigneeID:          d.optUUID(),
+		ABVariant:           (*models.ABVariant)(d.optString()),
+		ABParentID:          d.optUUID(),
+		RemindBeforeMinutes: d.optInt(),
+		UndoUntil:           d.optTime(),
+		CanceledAt:          d.optTime(),
+		NoSignature:         d.bool(),
+	}
+
+	if n := d.uvarint(); n > 0 {
+		// Every item takes at least its 16-byte ID
+		if n > uint64(len(d.data)/16) {
+			d.fail()
+			return nil, d.err
+		}
+		p.Media = make([]models.PostMedia, n)
+		for i := range p.Media {
+			p.Media[i] = models.PostMedia{
+				MediaID:     d.uuid(),
+				URL:         d.string(),
+				ContentType: d.string(),
+				AltText:     d.optString(),
+				Width:       d.int(),
+				Height:      d.int(),
+				DurationMs:  d.optInt(),
+			}
+		}
+	}
+
+	if data := d.bytes(); len(data) > 0 {
+		var extras postExtras
+		if err := json.Unmarshal(data, &extras); err != nil {
+			return nil, err
+		}
+		p.Targeting = extras.Targeting
+		p.Poll = extras.Poll
+		p.Location = extras.Location
+		p.Recycle = extras.Recycle
+		p.ABTest = extras.ABTest
+		p.Engagement = extras.Engagement
+	}
+	return p, d.err
+}
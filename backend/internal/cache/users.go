@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// UserTTL bounds how long a change to a user is missed if its invalidation fails
+const UserTTL = time.Minute
+
+func userKey(id uuid.UUID) string {
+	return "cache:users:" + id.String()
+}
+
+// sessionUser is the part of a user the auth middleware puts in the request
+// context. Password hashes, tokens and preferences are never cached.
+type sessionUser struct {
+	ID        uuid.UUID `json:"id"`
+	TenantID  uuid.UUID `json:"tenant_id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	AvatarKey       *string    `json:"avatar_key,omitempty"`
+	AvatarUpdatedAt *time.Time `json:"avatar_updated_at,omitempty"`
+
+	ConflictWindowMinutes int         `json:"conflict_window_minutes"`
+	Plan                  models.Plan `json:"plan"`
+
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty"`
+	SuspensionReason *string    `json:"suspension_reason,omitempty"`
+}
+
+func newSessionUser(user *models.User) sessionUser {
+	return sessionUser{
+		ID:                    user.ID,
+		TenantID:              user.TenantID,
+		Email:                 user.Email,
+		CreatedAt:             user.CreatedAt,
+		UpdatedAt:             user.UpdatedAt,
+		AvatarKey:             user.AvatarKey,
+		AvatarUpdatedAt:       user.AvatarUpdatedAt,
+		ConflictWindowMinutes: user.ConflictWindowMinutes,
+		Plan:                  user.Plan,
+		SuspendedAt:           user.SuspendedAt,
+		SuspensionReason:      user.SuspensionReason,
+	}
+}
+
+func (u sessionUser) user() *models.User {
+	return &models.User{
+		ID:                    u.ID,
+		TenantID:              u.TenantID,
+		Email:                 u.Email,
+		CreatedAt:             u.CreatedAt,
+		UpdatedAt:             u.UpdatedAt,
+		AvatarKey:             u.AvatarKey,
+		AvatarUpdatedAt:       u.AvatarUpdatedAt,
+		ConflictWindowMinutes: u.ConflictWindowMinutes,
+		Plan:                  u.Plan,
+		SuspendedAt:           u.SuspendedAt,
+		SuspensionReason:      u.SuspensionReason,
+	}
+}
+
+// GetUser retrieves a cached user with the fields the auth middleware reads
+func (c *Cache) GetUser(ctx context.Context, id uuid.UUID) (*models.User, bool) {
+	return c.QueueGetUser(ctx, c.redis, id)()
+}
+
+// QueueGetUser adds reading a cached user to pipe and returns a func that
+// gives the result GetUser would, once pipe has run. pipe may be a client,
+// which runs the command at once.
+func (c *Cache) QueueGetUser(ctx context.Context, pipe redis.Cmdable, id uuid.UUID) func() (*models.User, bool) {
+	get := pipe.Get(ctx, userKey(id))
+	return func() (*models.User, bool) {
+		data, err := get.Bytes()
+		if err != nil {
+			return nil, false
+		}
+
+		var u sessionUser
+		if err := json.Unmarshal(data, &u); err != nil {
+			return nil, false
+		}
+		return u.user(), true
+	}
+}
+
+// SetUser caches the fields of a user the auth middleware reads
+func (c *Cache) SetUser(ctx context.Context, user *models.User) error {
+	data, err := json.Marshal(newSessionUser(user))
+	if err != nil {
+		return err
+	}
+	return c.redis.Set(ctx, userKey(user.ID), data, UserTTL).Err()
+}
+
+// InvalidateUser removes a cached user. Call it after changing any field the
+// auth middleware reads: email, avatar, settings, plan or suspension.
+func (c *Cache) InvalidateUser(ctx context.Context, id uuid.UUID) error {
+	return c.redis.Del(ctx, userKey(id)).Err()
+}
@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestSessionUser(t *testing.T) {
+	now := time.Date(2030, 1, 15, 12, 0, 0, 0, time.UTC)
+	avatar, reason, webhook := "avatars/a.png", "spam", "https://example.com/hook"
+	user := &models.User{
+		ID:                    uuid.New(),
+		TenantID:              uuid.New(),
+		Email:                 "user@example.com",
+		PasswordHash:          "$2a$10$secret",
+		CreatedAt:             now,
+		UpdatedAt:             now,
+		AvatarKey:             &avatar,
+		AvatarUpdatedAt:       &now,
+		ConflictWindowMinutes: 30,
+		ReminderWebhookURL:    &webhook,
+		Plan:                  models.PlanPro,
+		SuspendedAt:           &now,
+		SuspensionReason:      &reason,
+	}
+
+	data, err := json.Marshal(newSessionUser(user))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), "secret") || strings.Contains(string(data), webhook) {
+		t.Errorf("Expected credentials and preferences to be left out, got %s", data)
+	}
+
+	var u sessionUser
+	if err := json.Unmarshal(data, &u); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	want := *user
+	want.PasswordHash = ""
+	want.ReminderWebhookURL = nil
+	if got := u.user(); !reflect.DeepEqual(*got, want) {
+		t.Errorf("Expected %+v, got %+v", want, *got)
+	}
+}
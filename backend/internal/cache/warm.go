@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+)
+
+const (
+	// warmDelay lets a burst of invalidations for one workspace settle before warming
+	warmDelay = 100 * time.Millisecond
+	// warmTimeout bounds the queries of a single warm
+	warmTimeout = 5 * time.Second
+)
+
+// warmer repopulates a workspace's upcoming and published history entries
+// after they are invalidated, so the next reader (usually the dashboard refreshing
+// on the SSE update that follows a change) doesn't find a cold cache
+type warmer struct {
+	db db.PostStore
+
+	mu      sync.Mutex
+	running map[Owner]bool // Owners being warmed; true if invalidated again meanwhile
+}
+
+// EnableWarming makes invalidations recompute the upcoming and published
+// history entries in the background
+func (c *Cache) EnableWarming(database db.PostStore) {
+	c.warmer = &warmer{
+		db:      database,
+		running: make(map[Owner]bool),
+	}
+}
+
+// schedule warms each owner's entries in the background. An owner already
+// being warmed is warmed once more afterwards, so the cache never keeps posts
+// read before the latest invalidation.
+func (w *warmer) schedule(c *Cache, owners []Owner) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, o := range owners {
+		if _, ok := w.running[o]; ok {
+			w.running[o] = true
+			continue
+		}
+		w.running[o] = false
+		go w.run(c, o)
+	}
+}
+
+// run warms an owner's entries until no invalidation arrived while warming
+func (w *warmer) run(c *Cache, o Owner) {
+	for {
+		time.Sleep(warmDelay)
+		if err := w.warm(c, o); err != nil {
+			log.Printf("⚠️ Failed to warm cache for workspace %s: %v", o.id(), err)
+		}
+
+		w.mu.Lock()
+		if again := w.running[o]; again {
+			w.running[o] = false
+			w.mu.Unlock()
+			continue
+		}
+		delete(w.running, o)
+		w.mu.Unlock()
+		return
+	}
+}
+
+// warm recomputes the owner's upcoming posts and published history, as read
+// by the workspace without filters. Lists too long to cache are
+// left uncached.
+func (w *warmer) warm(c *Cache, o Owner) error {
+	ctx, cancel := context.WithTimeout(context.Background(), warmTimeout)
+	defer cancel()
+
+	upcoming, err := readList(func(fn func(*models.Post) error) error {
+		return w.db.EachUpcomingPost(ctx, o.UserID, o.orgID(), db.PostFilter{}, fn)
+	})
+	if err != nil {
+		return err
+	}
+	if upcoming != nil {
+		if err := c.SetUpcomingPosts(ctx, o, upcoming); err != nil {
+			return err
+		}
+	}
+
+	status := string(models.PostStatusPublished)
+	statuses, _ := models.HistoryStatuses(status)
+	history, err := readList(func(fn func(*models.Post) error) error {
+		return w.db.EachHistoryPost(ctx, o.UserID, o.orgID(), db.HistoryFilter{Statuses: statuses}, fn)
+	})
+	if err != nil || history == nil {
+		return err
+	}
+	return c.SetHistoryPosts(ctx, o, status, history)
+}
+
+// errListTooLong stops reading a list once it can't be cached
+var errListTooLong = errors.New("list too long to cache")
+
+// readList reads the posts each yields, or returns nil once there are more
+// than MaxListPosts
+func readList(each func(func(*models.Post) error) error) ([]*models.Post, error) {
+	posts := []*models.Post{}
+	err := each(func(post *models.Post) error {
+		if len(posts) == MaxListPosts {
+			return errListTooLong
+		}
+		posts = append(posts, post)
+		return nil
+	})
+	if errors.Is(err, errListTooLong) {
+		return nil, nil
+	}
+	return posts, err
+}
@@ -3,19 +3,148 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/scheduler/backend/internal/models"
 )
 
+// RateLimit allows Limit requests per Window
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
 type Config struct {
 	DatabaseURL     string
 	RedisURL        string
 	JWTSecret       string
 	CORSOrigin      string
 	ServerPort      string
+	MediaDir        string
+	FFmpegPath      string // Transcodes video renditions; empty disables them
+	RequireAltText  bool
 	SecureCookies   bool
+	CookieSameSite  string // strict, lax or none; none needs SecureCookies
+	CookieDomain    string // Empty for host-only cookies
+	CookiePath      string // Prefix the API is served under
 	AccessTokenTTL  time.Duration
 	RefreshTokenTTL time.Duration
+	RememberMeTTL   time.Duration // Refresh token lifetime when signing in with remember me
 	WorkerInterval  time.Duration
+	PublishTimeout  time.Duration
+	UndoWindow      time.Duration // Grace period between a post coming due and publishing; zero disables
+
+	// Migrations the API server applies when it starts: "all", "pre-deploy"
+	// for rolling deployments that run post-deploy migrations with the
+	// migrate command once every instance is updated, or "none"
+	MigrateOnStart string
+
+	// Database connection pool; zero values keep the defaults (25 connections,
+	// 5 idle, 30m lifetime, 5m idle time, 30s health checks, cache_statement
+	// mode with 512 cached statements)
+	DBMaxConns               int
+	DBMinConns               int
+	DBMaxConnLifetime        time.Duration
+	DBMaxConnIdleTime        time.Duration
+	DBHealthCheckPeriod      time.Duration
+	DBQueryExecMode          string
+	DBStatementCacheCapacity int
+
+	// Fraction of SSE stream requests written to the access log
+	SSELogSampleRate float64
+
+	// Also publish SSE updates to the shared Redis channel older versions
+	// listen on; turn off once every instance uses per-user channels
+	NotifierSharedChannel bool
+
+	// Worker and API metrics and publish lag alerting
+	MetricsAddr        string
+	LagAlertThreshold  time.Duration
+	LagAlertWebhookURL string
+
+	// Publishing mode: "live" or "sandbox" (simulated platform responses)
+	PublishMode        string
+	SandboxFailureRate float64
+	SandboxMinLatency  time.Duration
+	SandboxMaxLatency  time.Duration
+	SandboxReplyRate   float64 // Probability a sandbox inbox sync of a post or monitor makes up replies or matches
+
+	// Per-channel publishing circuit breaker
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  time.Duration
+
+	// Most publish retries scheduled per minute across all workers (0 = unlimited)
+	RetryBudgetPerMinute int
+
+	// Registration email domain policy
+	EmailDomainAllowlist  []string
+	EmailDomainDenylist   []string
+	BlockDisposableEmails bool
+
+	// Registration requires an admin-generated invite code, e.g. for a private beta
+	InviteOnly bool
+
+	// Emails of users allowed to call /api/admin endpoints
+	AdminEmails []string
+
+	// Cron job schedule overrides, e.g. "recycle-posts=@every 5m;other=0 3 * * *"
+	CronSchedules map[string]string
+
+	// Request rate limit overrides per scope, e.g. "api=200/1m,login=10/5m", and
+	// per-plan multipliers applied to authenticated users, e.g. "pro=2"
+	RateLimits               map[string]RateLimit
+	RateLimitPlanMultipliers map[string]float64
+
+	// Abuse detection: clients scoring AbuseThrottleScore get rate limits scaled
+	// by AbuseThrottleMultiplier, and users scoring AbuseHoldScore are held from
+	// making changes for AbuseHoldDuration
+	AbuseThrottleScore      int
+	AbuseThrottleMultiplier float64
+	AbuseHoldScore          int
+	AbuseHoldDuration       time.Duration
+
+	// Per-channel daily posting limit overrides, e.g. "linkedin=25,twitter=50"
+	ChannelDailyLimits map[string]int
+
+	// Per-plan scheduling horizon overrides in days, e.g. "free=90,pro=730", and
+	// how far in the past scheduled_at may be to allow for client clock skew
+	ScheduleHorizonDays map[string]int
+	SchedulePastGrace   time.Duration
+
+	// Outgoing email; messages are only logged when SMTPAddr is empty
+	SMTPAddr     string
+	SMTPUsername string
+	SMTPPassword string
+	EmailFrom    string
+
+	// Approval reminders: approvers are reminded about pending posts due within
+	// ApprovalReminderWindow, and ApprovalEscalation ("none", "notify_owners" or
+	// "reject") applies once a post is due within ApprovalEscalationWindow
+	ApprovalReminderWindow   time.Duration
+	ApprovalEscalationWindow time.Duration
+	ApprovalEscalation       string
+
+	// Retention: failed posts are deleted RetentionFailedPostDays after they
+	// last changed, and audit log entries lose their actor after
+	// RetentionAuditAnonymizeMonths, unless an organization overrides them;
+	// zero keeps the data. RetentionDryRun only logs what would be changed.
+	RetentionFailedPostDays       int
+	RetentionAuditAnonymizeMonths int
+	RetentionDryRun               bool
+
+	// OAuth channel connections. Platforms redirect to
+	// OAuthRedirectBaseURL/channels/{channel}/callback, the public URL of the
+	// API, which sends the browser on to OAuthReturnURL in the frontend.
+	OAuthRedirectBaseURL string
+	OAuthReturnURL       string
+	RedditClientID       string
+	RedditClientSecret   string
+	GoogleClientID       string
+	GoogleClientSecret   string
+	TikTokClientKey      string
+	TikTokClientSecret   string
 }
 
 func Load() *Config {
@@ -25,10 +154,143 @@ func Load() *Config {
 		JWTSecret:       getEnvRequired("JWT_SECRET"),
 		CORSOrigin:      getEnv("CORS_ORIGIN", "http://localhost:3000"),
 		ServerPort:      getEnv("SERVER_PORT", "8080"),
+		MediaDir:        getEnv("MEDIA_DIR", "./data/media"),
+		FFmpegPath:      getEnv("FFMPEG_PATH", ""),
+		RequireAltText:  getEnv("REQUIRE_ALT_TEXT", "false") == "true",
 		SecureCookies:   getEnv("SECURE_COOKIES", "false") == "true",
-		AccessTokenTTL:  15 * time.Minute,
-		RefreshTokenTTL: 7 * 24 * time.Hour,
+		CookieSameSite:  strings.ToLower(getEnv("COOKIE_SAMESITE", "strict")),
+		CookieDomain:    getEnv("COOKIE_DOMAIN", ""),
+		CookiePath:      getEnv("COOKIE_PATH", "/"),
+		AccessTokenTTL:  getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL: getEnvDuration("REFRESH_TOKEN_TTL", 7*24*time.Hour),
+		RememberMeTTL:   getEnvDuration("REMEMBER_ME_TTL", 30*24*time.Hour),
 		WorkerInterval:  2 * time.Second, // Reduced to 2 seconds for faster publishing
+		PublishTimeout:  getEnvDuration("PUBLISH_TIMEOUT", 30*time.Second),
+		UndoWindow:      getEnvDuration("PUBLISH_UNDO_WINDOW", 30*time.Second),
+
+		MigrateOnStart: getEnv("MIGRATE_ON_START", "all"),
+
+		DBMaxConns:               getEnvInt("DB_MAX_CONNS", 0),
+		DBMinConns:               getEnvInt("DB_MIN_CONNS", 0),
+		DBMaxConnLifetime:        getEnvDuration("DB_MAX_CONN_LIFETIME", 0),
+		DBMaxConnIdleTime:        getEnvDuration("DB_MAX_CONN_IDLE_TIME", 0),
+		DBHealthCheckPeriod:      getEnvDuration("DB_HEALTH_CHECK_PERIOD", 0),
+		DBQueryExecMode:          getEnv("DB_QUERY_EXEC_MODE", ""),
+		DBStatementCacheCapacity: getEnvInt("DB_STATEMENT_CACHE_CAPACITY", 0),
+
+		SSELogSampleRate:      getEnvFloat("SSE_LOG_SAMPLE_RATE", 0.1),
+		NotifierSharedChannel: getEnv("NOTIFIER_SHARED_CHANNEL", "true") == "true",
+
+		MetricsAddr:        getEnv("METRICS_ADDR", ":9090"),
+		LagAlertThreshold:  getEnvDuration("LAG_ALERT_THRESHOLD", 5*time.Minute),
+		LagAlertWebhookURL: getEnv("LAG_ALERT_WEBHOOK_URL", ""),
+
+		PublishMode:        getEnv("PUBLISH_MODE", "live"),
+		SandboxFailureRate: getEnvFloat("SANDBOX_FAILURE_RATE", 0),
+		SandboxMinLatency:  getEnvDuration("SANDBOX_MIN_LATENCY", 100*time.Millisecond),
+		SandboxMaxLatency:  getEnvDuration("SANDBOX_MAX_LATENCY", time.Second),
+		SandboxReplyRate:   getEnvFloat("SANDBOX_REPLY_RATE", 0.2),
+
+		CircuitBreakerThreshold: getEnvInt("CIRCUIT_BREAKER_THRESHOLD", 5),
+		CircuitBreakerCooldown:  getEnvDuration("CIRCUIT_BREAKER_COOLDOWN", 2*time.Minute),
+		RetryBudgetPerMinute:    getEnvInt("RETRY_BUDGET_PER_MINUTE", 100),
+
+		EmailDomainAllowlist:  getEnvList("EMAIL_DOMAIN_ALLOWLIST"),
+		EmailDomainDenylist:   getEnvList("EMAIL_DOMAIN_DENYLIST"),
+		BlockDisposableEmails: getEnv("BLOCK_DISPOSABLE_EMAILS", "true") == "true",
+
+		InviteOnly: getEnv("INVITE_ONLY", "false") == "true",
+
+		AdminEmails:        getEnvList("ADMIN_EMAILS"),
+		CronSchedules:      getEnvSchedules("CRON_SCHEDULES"),
+		ChannelDailyLimits: getEnvIntMap("CHANNEL_DAILY_LIMITS"),
+
+		RateLimits:               getEnvRateLimits("RATE_LIMITS"),
+		RateLimitPlanMultipliers: getEnvFloatMap("RATE_LIMIT_PLAN_MULTIPLIERS"),
+
+		AbuseThrottleScore:      getEnvInt("ABUSE_THROTTLE_SCORE", 50),
+		AbuseThrottleMultiplier: getEnvFloat("ABUSE_THROTTLE_MULTIPLIER", 0.25),
+		AbuseHoldScore:          getEnvInt("ABUSE_HOLD_SCORE", 100),
+		AbuseHoldDuration:       getEnvDuration("ABUSE_HOLD_DURATION", 24*time.Hour),
+
+		ScheduleHorizonDays: getEnvIntMap("SCHEDULE_HORIZON_DAYS"),
+		SchedulePastGrace:   getEnvDuration("SCHEDULE_PAST_GRACE", time.Minute),
+
+		SMTPAddr:     getEnv("SMTP_ADDR", ""),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		EmailFrom:    getEnv("EMAIL_FROM", "Post Scheduler <noreply@localhost>"),
+
+		ApprovalReminderWindow:   getEnvDuration("APPROVAL_REMINDER_WINDOW", 24*time.Hour),
+		ApprovalEscalationWindow: getEnvDuration("APPROVAL_ESCALATION_WINDOW", 2*time.Hour),
+		ApprovalEscalation:       getEnv("APPROVAL_ESCALATION", "notify_owners"),
+
+		RetentionFailedPostDays:       getEnvInt("RETENTION_FAILED_POST_DAYS", 90),
+		RetentionAuditAnonymizeMonths: getEnvInt("RETENTION_AUDIT_ANONYMIZE_MONTHS", 24),
+		RetentionDryRun:               getEnv("RETENTION_DRY_RUN", "false") == "true",
+
+		OAuthRedirectBaseURL: strings.TrimSuffix(getEnv("OAUTH_REDIRECT_BASE_URL", "http://localhost:8080/api"), "/"),
+		RedditClientID:       getEnv("REDDIT_CLIENT_ID", ""),
+		RedditClientSecret:   getEnv("REDDIT_CLIENT_SECRET", ""),
+		GoogleClientID:       getEnv("GOOGLE_CLIENT_ID", ""),
+		GoogleClientSecret:   getEnv("GOOGLE_CLIENT_SECRET", ""),
+		TikTokClientKey:      getEnv("TIKTOK_CLIENT_KEY", ""),
+		TikTokClientSecret:   getEnv("TIKTOK_CLIENT_SECRET", ""),
+	}
+	cfg.OAuthReturnURL = getEnv("OAUTH_RETURN_URL", cfg.CORSOrigin+"/dashboard")
+
+	switch cfg.MigrateOnStart {
+	case "all", "pre-deploy", "none":
+	default:
+		log.Fatalf("MIGRATE_ON_START must be all, pre-deploy or none, got %q", cfg.MigrateOnStart)
+	}
+	if cfg.PublishMode != "live" && cfg.PublishMode != "sandbox" {
+		log.Fatalf("PUBLISH_MODE must be live or sandbox, got %q", cfg.PublishMode)
+	}
+	if cfg.DBMaxConns > 0 && cfg.DBMinConns > cfg.DBMaxConns {
+		log.Fatal("DB_MIN_CONNS must not exceed DB_MAX_CONNS")
+	}
+	switch cfg.DBQueryExecMode {
+	case "", "cache_statement", "cache_describe", "describe_exec", "exec", "simple_protocol":
+	default:
+		log.Fatalf("DB_QUERY_EXEC_MODE must be cache_statement, cache_describe, describe_exec, exec or simple_protocol, got %q", cfg.DBQueryExecMode)
+	}
+	if cfg.SSELogSampleRate < 0 || cfg.SSELogSampleRate > 1 {
+		log.Fatal("SSE_LOG_SAMPLE_RATE must be between 0 and 1")
+	}
+	if cfg.SandboxFailureRate < 0 || cfg.SandboxFailureRate > 1 {
+		log.Fatal("SANDBOX_FAILURE_RATE must be between 0 and 1")
+	}
+	if cfg.SandboxReplyRate < 0 || cfg.SandboxReplyRate > 1 {
+		log.Fatal("SANDBOX_REPLY_RATE must be between 0 and 1")
+	}
+	switch cfg.ApprovalEscalation {
+	case "none", "notify_owners", "reject":
+	default:
+		log.Fatalf("APPROVAL_ESCALATION must be none, notify_owners or reject, got %q", cfg.ApprovalEscalation)
+	}
+
+	switch cfg.CookieSameSite {
+	case "strict", "lax":
+	case "none":
+		if !cfg.SecureCookies {
+			log.Fatal("COOKIE_SAMESITE=none requires SECURE_COOKIES=true")
+		}
+	default:
+		log.Fatalf("COOKIE_SAMESITE must be strict, lax or none, got %q", cfg.CookieSameSite)
+	}
+	if !strings.HasPrefix(cfg.CookiePath, "/") {
+		log.Fatalf("COOKIE_PATH must start with /, got %q", cfg.CookiePath)
+	}
+
+	if cfg.AccessTokenTTL <= 0 {
+		log.Fatal("ACCESS_TOKEN_TTL must be positive")
+	}
+	if cfg.RefreshTokenTTL < cfg.AccessTokenTTL {
+		log.Fatal("REFRESH_TOKEN_TTL must not be shorter than ACCESS_TOKEN_TTL")
+	}
+	if cfg.RememberMeTTL < cfg.RefreshTokenTTL {
+		log.Fatal("REMEMBER_ME_TTL must not be shorter than REFRESH_TOKEN_TTL")
 	}
 
 	// Validate JWT secret strength
@@ -39,6 +301,22 @@ func Load() *Config {
 	return cfg
 }
 
+// RetentionPolicy returns the default retention rules
+func (c *Config) RetentionPolicy() models.RetentionPolicy {
+	return models.RetentionPolicy{
+		FailedPostDays:       c.RetentionFailedPostDays,
+		AuditAnonymizeMonths: c.RetentionAuditAnonymizeMonths,
+	}
+}
+
+// CronSchedule returns the configured schedule for a cron job, or fallback
+func (c *Config) CronSchedule(name, fallback string) string {
+	if spec, ok := c.CronSchedules[name]; ok {
+		return spec
+	}
+	return fallback
+}
+
 func getEnv(key, fallback string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
@@ -46,6 +324,118 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// getEnvInt parses a non-negative integer environment variable
+func getEnvInt(key string, fallback int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Fatalf("%s: invalid non-negative integer %q", key, value)
+	}
+	return n
+}
+
+// getEnvFloat parses a floating point environment variable
+func getEnvFloat(key string, fallback float64) float64 {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return fallback
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Fatalf("%s: invalid number %q", key, value)
+	}
+	return f
+}
+
+// getEnvDuration parses a duration environment variable such as "90s" or "5m"
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("%s: invalid duration %q", key, value)
+	}
+	return d
+}
+
+// getEnvList parses a comma-separated environment variable, skipping empty entries
+func getEnvList(key string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// getEnvIntMap parses a comma-separated list of key=integer pairs
+func getEnvIntMap(key string) map[string]int {
+	values := make(map[string]int)
+	for _, entry := range getEnvList(key) {
+		k, v, ok := strings.Cut(entry, "=")
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if !ok || err != nil || n < 0 {
+			log.Fatalf("%s: invalid entry %q, expected key=non-negative integer", key, entry)
+		}
+		values[strings.ToLower(strings.TrimSpace(k))] = n
+	}
+	return values
+}
+
+// getEnvFloatMap parses a comma-separated list of key=positive number pairs
+func getEnvFloatMap(key string) map[string]float64 {
+	values := make(map[string]float64)
+	for _, entry := range getEnvList(key) {
+		k, v, ok := strings.Cut(entry, "=")
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if !ok || err != nil || f <= 0 {
+			log.Fatalf("%s: invalid entry %q, expected key=positive number", key, entry)
+		}
+		values[strings.ToLower(strings.TrimSpace(k))] = f
+	}
+	return values
+}
+
+// getEnvRateLimits parses a comma-separated list of scope=limit/window pairs,
+// e.g. "api=200/1m"
+func getEnvRateLimits(key string) map[string]RateLimit {
+	limits := make(map[string]RateLimit)
+	for _, entry := range getEnvList(key) {
+		scope, spec, ok := strings.Cut(entry, "=")
+		limit, window, ok2 := strings.Cut(spec, "/")
+		n, err := strconv.Atoi(strings.TrimSpace(limit))
+		d, err2 := time.ParseDuration(strings.TrimSpace(window))
+		if !ok || !ok2 || err != nil || err2 != nil || n < 1 || d <= 0 {
+			log.Fatalf("%s: invalid entry %q, expected scope=limit/window", key, entry)
+		}
+		limits[strings.ToLower(strings.TrimSpace(scope))] = RateLimit{Limit: n, Window: d}
+	}
+	return limits
+}
+
+// getEnvSchedules parses semicolon-separated name=schedule pairs. Semicolons
+// are used because cron expressions may contain commas.
+func getEnvSchedules(key string) map[string]string {
+	schedules := make(map[string]string)
+	for _, entry := range strings.Split(os.Getenv(key), ";") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		name, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			log.Fatalf("%s: invalid entry %q, expected name=schedule", key, entry)
+		}
+		schedules[strings.TrimSpace(name)] = strings.TrimSpace(spec)
+	}
+	return schedules
+}
+
 func getEnvRequired(key string) string {
 	value, exists := os.LookupEnv(key)
 	if !exists || value == "" {
@@ -0,0 +1,206 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	jobsKey          = "cron:jobs"
+	historyKeyPrefix = "cron:history:"
+	runLockPrefix    = "cron:run:"
+
+	// historySize is how many runs are kept per job
+	historySize = 50
+	// runLockTTL keeps a run slot claimed long enough for other workers to skip it
+	runLockTTL = time.Hour
+)
+
+// Func is a periodic task
+type Func func(ctx context.Context) error
+
+// Run records one execution of a job
+type Run struct {
+	InstanceID string    `json:"instance_id"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// JobStatus describes a registered job and its recent runs
+type JobStatus struct {
+	Name     string    `json:"name"`
+	Schedule string    `json:"schedule"`
+	NextRun  time.Time `json:"next_run"`
+	Runs     []Run     `json:"runs"`
+}
+
+type entry struct {
+	name     string
+	spec     string
+	schedule Schedule
+	fn       Func
+	next     time.Time
+	running  atomic.Bool
+}
+
+// Runner runs periodic jobs in worker mode. When several workers run the same
+// job, a Redis lock per scheduled slot makes sure only one of them executes it.
+type Runner struct {
+	redis      *redis.Client
+	instanceID string
+	entries    []*entry
+}
+
+// NewRunner creates a cron runner
+func NewRunner(redisClient *redis.Client, instanceID string) *Runner {
+	return &Runner{
+		redis:      redisClient,
+		instanceID: instanceID,
+	}
+}
+
+// Register adds a job. A spec of "off" disables it.
+func (r *Runner) Register(name, spec string, fn Func) error {
+	if spec == "off" {
+		log.Printf("⏰ Cron job %s disabled", name)
+		return nil
+	}
+	schedule, err := Parse(spec)
+	if err != nil {
+		return fmt.Errorf("cron job %s: %w", name, err)
+	}
+	r.entries = append(r.entries, &entry{name: name, spec: spec, schedule: schedule, fn: fn})
+	return nil
+}
+
+// Run executes jobs on schedule until ctx is cancelled
+func (r *Runner) Run(ctx context.Context) {
+	if len(r.entries) == 0 {
+		return
+	}
+
+	now := time.Now()
+	for _, e := range r.entries {
+		e.next = e.schedule.Next(now)
+		r.publish(ctx, e)
+		log.Printf("⏰ Cron job %s scheduled (%s), next run %s", e.name, e.spec, e.next.Format(time.RFC3339))
+	}
+
+	for {
+		timer := time.NewTimer(time.Until(r.earliest()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case now = <-timer.C:
+		}
+
+		for _, e := range r.entries {
+			if e.next.IsZero() || e.next.After(now) {
+				continue
+			}
+			slot := e.next
+			e.next = e.schedule.Next(now)
+			r.publish(ctx, e)
+			go r.execute(ctx, e, slot)
+		}
+	}
+}
+
+// earliest returns the soonest next run across jobs
+func (r *Runner) earliest() time.Time {
+	var earliest time.Time
+	for _, e := range r.entries {
+		if !e.next.IsZero() && (earliest.IsZero() || e.next.Before(earliest)) {
+			earliest = e.next
+		}
+	}
+	if earliest.IsZero() {
+		return time.Now().Add(24 * time.Hour)
+	}
+	return earliest
+}
+
+// execute runs a job for a slot unless it is already running here or another worker claimed the slot
+func (r *Runner) execute(ctx context.Context, e *entry, slot time.Time) {
+	if !e.running.CompareAndSwap(false, true) {
+		log.Printf("⏭️ Cron job %s still running, skipping %s", e.name, slot.Format(time.RFC3339))
+		return
+	}
+	defer e.running.Store(false)
+
+	lockKey := fmt.Sprintf("%s%s:%d", runLockPrefix, e.name, slot.Unix())
+	claimed, err := r.redis.SetNX(ctx, lockKey, r.instanceID, runLockTTL).Result()
+	if err != nil {
+		log.Printf("⚠️ Cron job %s: failed to claim run: %v", e.name, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	run := Run{InstanceID: r.instanceID, StartedAt: time.Now()}
+	if err := e.fn(ctx); err != nil {
+		run.Error = err.Error()
+		log.Printf("❌ Cron job %s failed: %v", e.name, err)
+	}
+	run.DurationMs = time.Since(run.StartedAt).Milliseconds()
+
+	data, _ := json.Marshal(run)
+	key := historyKeyPrefix + e.name
+	if _, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, key, data)
+		pipe.LTrim(ctx, key, 0, historySize-1)
+		return nil
+	}); err != nil {
+		log.Printf("⚠️ Cron job %s: failed to record run: %v", e.name, err)
+	}
+}
+
+// publish stores the job's schedule and next run for the admin status endpoint
+func (r *Runner) publish(ctx context.Context, e *entry) {
+	data, _ := json.Marshal(JobStatus{Name: e.name, Schedule: e.spec, NextRun: e.next})
+	if err := r.redis.HSet(ctx, jobsKey, e.name, data).Err(); err != nil {
+		log.Printf("⚠️ Cron job %s: failed to publish status: %v", e.name, err)
+	}
+}
+
+// ListJobs returns every job registered by a worker with up to runs recent runs each
+func ListJobs(ctx context.Context, redisClient *redis.Client, runs int) ([]JobStatus, error) {
+	values, err := redisClient.HGetAll(ctx, jobsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]JobStatus, 0, len(values))
+	for _, v := range values {
+		var job JobStatus
+		if err := json.Unmarshal([]byte(v), &job); err != nil {
+			continue
+		}
+
+		history, err := redisClient.LRange(ctx, historyKeyPrefix+job.Name, 0, int64(runs-1)).Result()
+		if err != nil {
+			return nil, err
+		}
+		job.Runs = []Run{}
+		for _, h := range history {
+			var run Run
+			if err := json.Unmarshal([]byte(h), &run); err == nil {
+				job.Runs = append(job.Runs, run)
+			}
+		}
+		jobs = append(jobs, job)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+	return jobs, nil
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// A/B test operations
+
+// abWindow is a variant's engagement window, read from its variant A post's settings
+const abWindow = `make_interval(hours => (a.ab_test->>'window_hours')::int)`
+
+// GetABTestsToStart returns published variant A posts whose window has passed
+// and whose variant B hasn't been created yet
+func (db *DB) GetABTestsToStart(ctx context.Context, limit int) ([]*models.Post, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT `+postColumns+`
+		FROM posts a
+		WHERE a.ab_variant = 'a' AND a.status = 'published'
+			AND a.published_at + `+abWindow+` <= NOW()
+			AND NOT EXISTS (SELECT 1 FROM posts b WHERE b.ab_parent_id = a.id)
+		ORDER BY a.published_at ASC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPosts(rows)
+}
+
+// StartABVariant creates the scheduled variant B post of a variant A post,
+// due immediately. Returns nil if it was already created by another worker.
+func (db *DB) StartABVariant(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+	return scanPostRow(db.pool.QueryRow(ctx, `
+		INSERT INTO posts (user_id, title, content, channel, scheduled_at, targeting, post_type,
+			poll, media, location, priority, tenant_id, org_id, window_override, workflow_state,
+			assignee_id, no_signature, ab_variant, ab_parent_id)
+		SELECT user_id, title, ab_test->>'variant_b', channel, NOW(), targeting, post_type,
+			poll, media, location, priority, tenant_id, org_id, window_override, workflow_state,
+			assignee_id, no_signature, 'b', id
+		FROM posts
+		WHERE id = $1 AND ab_variant = 'a' AND status = 'published'
+		ON CONFLICT (ab_parent_id) WHERE ab_parent_id IS NOT NULL DO NOTHING
+		RETURNING `+postColumns,
+		id))
+}
+
+// GetABTestsToDecide returns undecided variant A posts whose variant B has
+// failed, or has published and had its window pass
+func (db *DB) GetABTestsToDecide(ctx context.Context, limit int) ([]*models.Post, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT `+postColumns+`
+		FROM posts a
+		WHERE a.ab_variant = 'a' AND a.ab_test->>'decided_at' IS NULL
+			AND EXISTS (
+				SELECT 1 FROM posts b
+				WHERE b.ab_parent_id = a.id AND (b.status = 'failed'
+					OR (b.status = 'published' AND b.published_at + `+abWindow+` <= NOW()))
+			)
+		ORDER BY a.published_at ASC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPosts(rows)
+}
+
+// GetABVariant returns the variant B post of a variant A post, or nil if it
+// hasn't been created yet
+func (db *DB) GetABVariant(ctx context.Context, parentID uuid.UUID) (*models.Post, error) {
+	return scanPostRow(db.pool.QueryRow(ctx, `
+		SELECT `+postColumns+`
+		FROM posts WHERE ab_parent_id = $1
+	`, parentID))
+}
+
+// DecideABTest records the winner of a variant A post's test. Returns false if
+// the test was already decided by another worker.
+func (db *DB) DecideABTest(ctx context.Context, id uuid.UUID, winner models.ABVariant) (bool, error) {
+	tag, err := db.pool.Exec(ctx, `
+		UPDATE posts SET
+			ab_test = ab_test || jsonb_build_object('winner', $2::text, 'decided_at', NOW()),
+			updated_at = NOW()
+		WHERE id = $1 AND ab_test->>'decided_at' IS NULL
+	`, id, winner)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// RepostPost creates a scheduled copy of a published post, due immediately,
+// without its A/B test, recycling or engagement
+func (db *DB) RepostPost(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+	return scanPostRow(db.pool.QueryRow(ctx, `
+		INSERT INTO posts (user_id, title, content, channel, scheduled_at, targeting, post_type,
+			poll, media, location, priority, tenant_id, org_id, window_override, workflow_state, assignee_id, no_signature)
+		SELECT user_id, title, content, channel, NOW(), targeting, post_type,
+			poll, media, location, priority, tenant_id, org_id, window_override, workflow_state, assignee_id, no_signature
+		FROM posts
+		WHERE id = $1 AND status = 'published'
+		RETURNING `+postColumns,
+		id))
+}
+
+// SetPostEngagement stores a published post's engagement. Returns nil if the
+// post isn't published.
+func (db *DB) SetPostEngagement(ctx context.Context, id uuid.UUID, e models.Engagement) (*models.Post, error) {
+	return scanPostRow(db.pool.QueryRow(ctx, `
+		UPDATE posts SET engagement = $2, updated_at = NOW()
+		WHERE id = $1 AND status = 'published'
+		RETURNING `+postColumns,
+		id, e))
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Analytics operations
+
+// dateLayout formats the first day of a month for DATE columns, so the
+// session time zone can't shift it
+const dateLayout = "2006-01-02"
+
+// RollupSystemMetrics recounts the hourly post metrics from hourSince and the
+// daily signup metric from daySince, across all tenants. Buckets are replaced,
+// so overlapping rollups are safe.
+func (db *DB) RollupSystemMetrics(ctx context.Context, hourSince, daySince time.Time) error {
+	batch := &pgx.Batch{}
+	batch.Queue(`
+		INSERT INTO system_metrics (metric, granularity, bucket, value)
+		SELECT 'posts_created', 'hour', date_trunc('hour', created_at), COUNT(*)
+		FROM posts
+		WHERE created_at >= date_trunc('hour', $1::timestamptz)
+		GROUP BY 3
+		ON CONFLICT (metric, granularity, bucket) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()
+	`, hourSince)
+	batch.Queue(`
+		INSERT INTO system_metrics (metric, granularity, bucket, value)
+		SELECT 'posts_published', 'hour', date_trunc('hour', published_at), COUNT(*)
+		FROM posts
+		WHERE status = 'published' AND published_at >= date_trunc('hour', $1::timestamptz)
+		GROUP BY 3
+		ON CONFLICT (metric, granularity, bucket) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()
+	`, hourSince)
+	batch.Queue(`
+		INSERT INTO system_metrics (metric, granularity, bucket, value)
+		SELECT 'posts_failed', 'hour', date_trunc('hour', updated_at), COUNT(*)
+		FROM posts
+		WHERE status = 'failed' AND updated_at >= date_trunc('hour', $1::timestamptz)
+		GROUP BY 3
+		ON CONFLICT (metric, granularity, bucket) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()
+	`, hourSince)
+	batch.Queue(`
+		INSERT INTO system_metrics (metric, granularity, bucket, value)
+		SELECT 'signups', 'day', date_trunc('day', created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC', COUNT(*)
+		FROM users
+		WHERE created_at >= $1
+		GROUP BY 3
+		ON CONFLICT (metric, granularity, bucket) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()
+	`, daySince.UTC().Truncate(24*time.Hour))
+
+	return db.pool.SendBatch(ctx, batch).Close()
+}
+
+// ListSystemMetrics returns every rolled-up series with buckets in [since, until)
+func (db *DB) ListSystemMetrics(ctx context.Context, since, until time.Time) ([]models.MetricSeries, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT metric, granularity, bucket, value
+		FROM system_metrics
+		WHERE bucket >= $1 AND bucket < $2
+		ORDER BY metric, granularity, bucket
+	`, since, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	series := []models.MetricSeries{}
+	for rows.Next() {
+		var metric models.Metric
+		var granularity models.Granularity
+		var p models.MetricPoint
+		if err := rows.Scan(&metric, &granularity, &p.Bucket, &p.Value); err != nil {
+			return nil, err
+		}
+		if n := len(series); n == 0 || series[n-1].Metric != metric || series[n-1].Granularity != granularity {
+			series = append(series, models.MetricSeries{Metric: metric, Granularity: granularity})
+		}
+		last := &series[len(series)-1]
+		last.Points = append(last.Points, p)
+	}
+	return series, rows.Err()
+}
+
+// GetUsersForMonthlyReport returns users who signed up before month ended
+// and haven't been sent its analytics report, where month is the first day
+func (db *DB) GetUsersForMonthlyReport(ctx context.Context, month time.Time, limit int) ([]*models.User, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE (analytics_report_month IS NULL OR analytics_report_month < $1::date)
+			AND created_at < ($1::date + INTERVAL '1 month')
+			AND suspended_at IS NULL
+		ORDER BY id
+		LIMIT $2
+	`, month.Format(dateLayout), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		user, err := userColumnSet.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// MarkMonthlyReportSent records that a user's report for month was sent
+func (db *DB) MarkMonthlyReportSent(ctx context.Context, userID uuid.UUID, month time.Time) error {
+	_, err := db.pool.Exec(ctx, `
+		UPDATE users SET analytics_report_month = $2::date WHERE id = $1
+	`, userID, month.Format(dateLayout))
+	return err
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Follower count operations
+
+// GetConnectionsForFollowerPoll returns connections on the given channels
+// whose follower count hasn't been polled on day (UTC), least recently
+// polled first
+func (db *DB) GetConnectionsForFollowerPoll(ctx context.Context, channels []models.Channel, day time.Time, limit int) ([]*models.ChannelConnection, error) {
+	names := make([]string, len(channels))
+	for i, c := range channels {
+		names[i] = string(c)
+	}
+
+	rows, err := db.pool.Query(ctx, `
+		SELECT id, user_id, channel, account_name, access_token, refresh_token, token_expires_at, target, last_error, last_published_at, created_at, updated_at
+		FROM channel_connections
+		WHERE channel = ANY($1::channel_type[])
+			AND (followers_polled_on IS NULL OR followers_polled_on < $2::date)
+		ORDER BY followers_polled_on ASC NULLS FIRST
+		LIMIT $3
+	`, names, day.Format(dateLayout), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conns []*models.ChannelConnection
+	for rows.Next() {
+		conn := &models.ChannelConnection{}
+		err := rows.Scan(
+			&conn.ID, &conn.UserID, &conn.Channel, &conn.AccountName, &conn.AccessToken, &conn.RefreshToken,
+			&conn.TokenExpiresAt, &conn.Target, &conn.LastError, &conn.LastPublishedAt, &conn.CreatedAt, &conn.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		conns = append(conns, conn)
+	}
+
+	return conns, rows.Err()
+}
+
+// SaveFollowerCounts stores follower counts, replacing any already taken on
+// the same day, and marks the polled connections as polled on day
+func (db *DB) SaveFollowerCounts(ctx context.Context, day time.Time, polled []uuid.UUID, counts []models.FollowerCount) error {
+	n := len(counts)
+	userIDs, channels, names, followers := make([]uuid.UUID, 0, n), make([]string, 0, n), make([]*string, 0, n), make([]int64, 0, n)
+	for _, c := range counts {
+		userIDs = append(userIDs, c.UserID)
+		channels = append(channels, string(c.Channel))
+		names = append(names, c.AccountName)
+		followers = append(followers, c.Followers)
+	}
+
+	batch := &pgx.Batch{}
+	if n > 0 {
+		batch.Queue(`
+			INSERT INTO follower_counts (user_id, channel, day, account_name, followers)
+			SELECT c.user_id, c.channel::channel_type, $1::date, c.account_name, c.followers
+			FROM unnest($2::uuid[], $3::text[], $4::text[], $5::bigint[]) AS c(user_id, channel, account_name, followers)
+			ON CONFLICT (user_id, channel, day) DO UPDATE SET
+				account_name = EXCLUDED.account_name,
+				followers = EXCLUDED.followers,
+				recorded_at = NOW()
+		`, day.Format(dateLayout), userIDs, channels, names, followers)
+	}
+	batch.Queue(`
+		UPDATE channel_connections SET followers_polled_on = $2::date WHERE id = ANY($1)
+	`, polled, day.Format(dateLayout))
+
+	return db.pool.SendBatch(ctx, batch).Close()
+}
+
+// ListFollowerCounts returns a user's follower counts on days in
+// [since, until), oldest first
+func (db *DB) ListFollowerCounts(ctx context.Context, userID uuid.UUID, since, until time.Time) ([]models.FollowerCount, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT channel, account_name, day, followers
+		FROM follower_counts
+		WHERE user_id = $1 AND day >= $2::date AND day < $3::date
+		ORDER BY day, channel
+	`, userID, since.Format(dateLayout), until.Format(dateLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []models.FollowerCount
+	for rows.Next() {
+		c := models.FollowerCount{UserID: userID}
+		if err := rows.Scan(&c.Channel, &c.AccountName, &c.Day, &c.Followers); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Audit log operations
+
+// NewAuditEntry holds the fields of an audit log entry to be recorded
+type NewAuditEntry struct {
+	OrgID      uuid.UUID
+	ActorID    uuid.UUID
+	ActorEmail string
+	Action     models.AuditAction
+	TargetID   *uuid.UUID
+	Details    map[string]any
+}
+
+// RecordAuditEntry adds an entry to an organization's audit log
+func (db *DB) RecordAuditEntry(ctx context.Context, e NewAuditEntry) error {
+	_, err := db.pool.Exec(ctx, `
+		INSERT INTO audit_log (org_id, actor_id, actor_email, action, target_id, details)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, e.OrgID, e.ActorID, e.ActorEmail, e.Action, e.TargetID, e.Details)
+	return err
+}
+
+// EachAuditEntry calls fn with each entry of the organization's audit log
+// recorded in [from, to), oldest first, as it is read, so long exports needn't
+// be held in memory. An error from fn stops the iteration and is returned.
+func (db *DB) EachAuditEntry(ctx context.Context, orgID uuid.UUID, from, to time.Time, fn func(*models.AuditEntry) error) error {
+	rows, err := db.pool.Query(ctx, `
+		SELECT id, org_id, actor_id, actor_email, action, target_id, details, created_at
+		FROM audit_log
+		WHERE org_id = $1 AND created_at >= $2 AND created_at < $3
+		ORDER BY created_at, id
+	`, orgID, from, to)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		e := &models.AuditEntry{}
+		if err := rows.Scan(&e.ID, &e.OrgID, &e.ActorID, &e.ActorEmail, &e.Action, &e.TargetID, &e.Details, &e.CreatedAt); err != nil {
+			return err
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,216 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Backup operations
+
+// Row is a table row keyed by column name, so backups carry every column
+// without listing them. Numbers are kept as json.Number to round-trip exactly.
+type Row = map[string]any
+
+// Snapshot is a user's or organization's data as read at one point in time
+type Snapshot struct {
+	SchemaVersion  string            `json:"schema_version"`         // The latest migration applied
+	User           Row               `json:"user,omitempty"`         // The user's settings, in user snapshots
+	Organization   Row               `json:"organization,omitempty"` // The organization's name and settings, in organization snapshots
+	Members        []SnapshotMember  `json:"members,omitempty"`
+	Users          map[string]string `json:"users"` // Email of each user the rows refer to, by ID
+	Posts          []Row             `json:"posts"`
+	Media          []Row             `json:"media"` // References to stored files; the files themselves aren't included
+	MediaFolders   []Row             `json:"media_folders"`
+	ChannelPresets []Row             `json:"channel_presets"`
+}
+
+// SnapshotMember is an organization member in a snapshot
+type SnapshotMember struct {
+	Email              string `json:"email"`
+	Role               string `json:"role"`
+	CanOverrideWindows bool   `json:"can_override_windows"`
+}
+
+// SchemaVersion returns the latest migration applied to the database
+func (db *DB) SchemaVersion(ctx context.Context) (string, error) {
+	var version string
+	err := db.pool.QueryRow(ctx, `SELECT COALESCE(MAX(version), '') FROM schema_migrations`).Scan(&version)
+	return version, err
+}
+
+// snapshotRows aggregates the rows of table matching where as a JSON array
+func snapshotRows(table, where, order string) string {
+	return `COALESCE((SELECT jsonb_agg(to_jsonb(t) ORDER BY ` + order + `) FROM ` + table + ` t WHERE ` + where + `), '[]')`
+}
+
+// userSnapshotQuery reads the user $1's settings, personal posts, uploads and
+// channel presets. Building it in one statement reads one consistent snapshot.
+var userSnapshotQuery = `
+	SELECT jsonb_build_object(
+		'schema_version', (SELECT MAX(version) FROM schema_migrations),
+		'user', (
+			SELECT jsonb_build_object(
+				'conflict_window_minutes', conflict_window_minutes,
+				'notification_preferences', notification_preferences,
+				'reminder_webhook_url', reminder_webhook_url)
+			FROM users WHERE id = $1),
+		'users', (SELECT jsonb_object_agg(id, email) FROM users WHERE id = $1),
+		'posts', ` + snapshotRows("posts", "t.user_id = $1 AND t.org_id IS NULL", "t.created_at, t.id") + `,
+		'media', ` + snapshotRows("media", "t.user_id = $1", "t.created_at, t.id") + `,
+		'media_folders', '[]'::jsonb,
+		'channel_presets', ` + snapshotRows("channel_presets", "t.user_id = $1", "t.channel") + `
+	)`
+
+// orgSnapshotQuery reads the organization $1's settings, members, posts,
+// library and the media its posts use, in one consistent snapshot
+var orgSnapshotQuery = `
+	SELECT jsonb_build_object(
+		'schema_version', (SELECT MAX(version) FROM schema_migrations),
+		'organization', (
+			SELECT jsonb_build_object(
+				'name', name,
+				'timezone', timezone,
+				'publishing_windows', publishing_windows,
+				'brand_checklist', brand_checklist,
+				'retention', retention)
+			FROM organizations WHERE id = $1),
+		'members', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object('email', u.email, 'role', m.role, 'can_override_windows', m.can_override_windows) ORDER BY u.email)
+			FROM organization_members m JOIN users u ON u.id = m.user_id
+			WHERE m.org_id = $1), '[]'),
+		'users', COALESCE((
+			SELECT jsonb_object_agg(id, email) FROM users WHERE id IN (
+				SELECT user_id FROM organization_members WHERE org_id = $1
+				UNION SELECT user_id FROM posts WHERE org_id = $1
+				UNION SELECT assignee_id FROM posts WHERE org_id = $1
+				UNION SELECT user_id FROM media WHERE org_id = $1
+				UNION SELECT created_by FROM media_folders WHERE org_id = $1)), '{}'),
+		'posts', ` + snapshotRows("posts", "t.org_id = $1", "t.created_at, t.id") + `,
+		'media', ` + snapshotRows("media", `t.org_id = $1 OR EXISTS (
+			SELECT 1 FROM posts p WHERE p.org_id = $1 AND p.media @> jsonb_build_array(jsonb_build_object('media_id', t.id)))`, "t.created_at, t.id") + `,
+		'media_folders', ` + snapshotRows("media_folders", "t.org_id = $1", "t.created_at, t.id") + `,
+		'channel_presets', '[]'::jsonb
+	)`
+
+// SnapshotUser reads a snapshot of the user's personal data, or nil if there
+// is no such user
+func (db *DB) SnapshotUser(ctx context.Context, userID uuid.UUID) (*Snapshot, error) {
+	s, err := db.snapshot(ctx, userSnapshotQuery, userID)
+	if err != nil || s.User == nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SnapshotOrganization reads a snapshot of the organization's data, or nil if
+// there is no such organization
+func (db *DB) SnapshotOrganization(ctx context.Context, orgID uuid.UUID) (*Snapshot, error) {
+	s, err := db.snapshot(ctx, orgSnapshotQuery, orgID)
+	if err != nil || s.Organization == nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (db *DB) snapshot(ctx context.Context, query string, id uuid.UUID) (*Snapshot, error) {
+	var data []byte
+	if err := db.pool.QueryRow(ctx, query, id).Scan(&data); err != nil {
+		return nil, err
+	}
+
+	s := &Snapshot{}
+	if err := DecodeSnapshot(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// DecodeSnapshot decodes JSON into a snapshot, keeping numbers exact
+func DecodeSnapshot(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// RestoreSnapshot adds a snapshot's rows, already given new IDs and pointed
+// at their new owners, and applies its settings to userID's account or to
+// orgID. Everything is sent as one batch, which Postgres runs as a single
+// transaction: either every row is added or none is. Returns the queue
+// entries of the restored scheduled posts.
+func (db *DB) RestoreSnapshot(ctx context.Context, s *Snapshot, userID uuid.UUID, orgID *uuid.UUID) ([]QueuedPostRef, error) {
+	batch := &pgx.Batch{}
+	if s.User != nil {
+		batch.Queue(`
+			UPDATE users u SET
+				conflict_window_minutes = r.conflict_window_minutes,
+				notification_preferences = r.notification_preferences,
+				reminder_webhook_url = r.reminder_webhook_url,
+				updated_at = NOW()
+			FROM jsonb_populate_record(NULL::users, $2) r
+			WHERE u.id = $1 AND u.tenant_id = r.tenant_id
+		`, userID, s.User)
+	}
+	if s.Organization != nil && orgID != nil {
+		batch.Queue(`
+			UPDATE organizations o SET
+				timezone = r.timezone,
+				publishing_windows = r.publishing_windows,
+				brand_checklist = r.brand_checklist,
+				retention = r.retention
+			FROM jsonb_populate_record(NULL::organizations, $2) r
+			WHERE o.id = $1 AND o.tenant_id = r.tenant_id
+		`, *orgID, s.Organization)
+	}
+
+	// Folders before the media filed in them, and media before the posts
+	// that use it; posts are in creation order, so recycled and A/B test
+	// posts follow the posts they refer to
+	for _, t := range []struct {
+		table string
+		rows  []Row
+	}{
+		{"media_folders", s.MediaFolders},
+		{"media", s.Media},
+		{"posts", s.Posts},
+		{"channel_presets", s.ChannelPresets},
+	} {
+		if len(t.rows) == 0 {
+			continue
+		}
+		batch.Queue(`
+			INSERT INTO `+t.table+`
+			SELECT * FROM jsonb_populate_recordset(NULL::`+t.table+`, $1)
+			ON CONFLICT DO NOTHING
+		`, t.rows)
+	}
+
+	ids := make([]string, 0, len(s.Posts))
+	for _, p := range s.Posts {
+		if id, ok := p["id"].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	batch.Queue(`
+		SELECT id, GREATEST(COALESCE(next_retry_at, scheduled_at), undo_until), priority
+		FROM posts
+		WHERE status = 'scheduled' AND id = ANY($1::uuid[])
+	`, ids)
+
+	br := db.pool.SendBatch(ctx, batch)
+	defer br.Close()
+	for i := 0; i < batch.Len()-1; i++ {
+		if _, err := br.Exec(); err != nil {
+			return nil, fmt.Errorf("restore: %w", err)
+		}
+	}
+	rows, err := br.Query()
+	if err != nil {
+		return nil, fmt.Errorf("restore: %w", err)
+	}
+	return scanPostRefs(rows)
+}
@@ -0,0 +1,141 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/tenant"
+)
+
+// Bio page operations
+
+// bioLinkColumns is the column list selected for every bio link query,
+// joined with the post the link is for
+const bioLinkColumns = `l.id, l.user_id, l.post_id, l.title, l.url, l.position, l.clicks, l.created_at,
+	p.channel, p.title, p.content, p.published_at`
+
+// bioLinkFrom is the join every bio link query selects from
+const bioLinkFrom = `bio_links l LEFT JOIN posts p ON p.id = l.post_id`
+
+// scanBioLink scans a row selected with bioLinkColumns
+func scanBioLink(row pgx.Row) (*models.BioLink, error) {
+	l := &models.BioLink{}
+	var channel *models.Channel
+	var content *string
+	post := &models.Post{}
+	if err := row.Scan(&l.ID, &l.UserID, &l.PostID, &l.Title, &l.URL, &l.Position, &l.Clicks, &l.CreatedAt,
+		&channel, &post.Title, &content, &post.PublishedAt); err != nil {
+		return nil, err
+	}
+	if channel != nil && content != nil {
+		post.Channel, post.Content = *channel, *content
+		l.Post = models.NewBioLinkPost(post)
+	}
+	return l, nil
+}
+
+// GetUserByBioToken retrieves the user whose bio page token hash matches
+func (db *DB) GetUserByBioToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	return scanUser(db.pool.QueryRow(ctx, `
+		SELECT `+userColumns+`
+		FROM users WHERE tenant_id = $1 AND bio_token_hash = $2
+	`, tenant.IDFromContext(ctx), tokenHash))
+}
+
+// SetBioToken replaces the user's bio page token hash; nil disables the page
+func (db *DB) SetBioToken(ctx context.Context, userID uuid.UUID, tokenHash *string) error {
+	_, err := db.pool.Exec(ctx, `
+		UPDATE users SET bio_token_hash = $2, updated_at = NOW() WHERE id = $1
+	`, userID, tokenHash)
+	return err
+}
+
+// ListBioLinks retrieves the links on a user's bio page in page order
+func (db *DB) ListBioLinks(ctx context.Context, userID uuid.UUID) ([]*models.BioLink, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT `+bioLinkColumns+`
+		FROM `+bioLinkFrom+`
+		WHERE l.user_id = $1
+		ORDER BY l.position, l.created_at
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []*models.BioLink
+	for rows.Next() {
+		l, err := scanBioLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+	return links, rows.Err()
+}
+
+// CreateBioLink adds a link to the end of a user's bio page. It returns nil
+// if the page already has models.MaxBioLinks links.
+func (db *DB) CreateBioLink(ctx context.Context, userID uuid.UUID, req models.CreateBioLinkRequest) (*models.BioLink, error) {
+	l, err := scanBioLink(db.pool.QueryRow(ctx, `
+		WITH l AS (
+			INSERT INTO bio_links (user_id, post_id, title, url, position)
+			SELECT $1, $2, $3, $4, COALESCE((SELECT MAX(position) + 1 FROM bio_links WHERE user_id = $1), 0)
+			WHERE (SELECT COUNT(*) FROM bio_links WHERE user_id = $1) < $5
+			RETURNING *
+		)
+		SELECT `+bioLinkColumns+`
+		FROM l LEFT JOIN posts p ON p.id = l.post_id
+	`, userID, req.PostID, req.Title, req.URL, models.MaxBioLinks))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return l, err
+}
+
+// ReorderBioLinks numbers a user's bio links in the given order. It returns
+// false, changing nothing, unless ids lists each of their links once.
+func (db *DB) ReorderBioLinks(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (bool, error) {
+	result, err := db.pool.Exec(ctx, `
+		UPDATE bio_links l SET position = o.position
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, position)
+		WHERE l.id = o.id AND l.user_id = $1
+		  AND (SELECT COUNT(*) FROM bio_links WHERE user_id = $1) = cardinality($2::uuid[])
+		  AND (SELECT COUNT(*) FROM bio_links WHERE user_id = $1 AND id = ANY($2)) = cardinality($2::uuid[])
+	`, userID, ids)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == int64(len(ids)) && len(ids) > 0, nil
+}
+
+// DeleteBioLink removes a link from a user's bio page
+func (db *DB) DeleteBioLink(ctx context.Context, userID, id uuid.UUID) (bool, error) {
+	result, err := db.pool.Exec(ctx, `
+		DELETE FROM bio_links WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// RecordBioClick counts a click on a user's bio link and returns where it
+// goes, or nil if there is no such link
+func (db *DB) RecordBioClick(ctx context.Context, userID, id uuid.UUID) (*string, error) {
+	var url string
+	err := db.pool.QueryRow(ctx, `
+		UPDATE bio_links SET clicks = clicks + 1
+		WHERE id = $1 AND user_id = $2
+		RETURNING url
+	`, id, userID).Scan(&url)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &url, nil
+}
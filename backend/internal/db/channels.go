@@ -0,0 +1,264 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Channel connection operations
+
+// ChannelActivity holds system-wide publishing counts for a channel
+type ChannelActivity struct {
+	Published int
+	Failing   int
+}
+
+// ChannelCredentials are what a connection holds to publish as its account
+type ChannelCredentials struct {
+	AccountName    *string
+	AccessToken    string
+	RefreshToken   *string // Only for OAuth connections
+	TokenExpiresAt *time.Time
+	Target         *string // Where to publish, for channels that need it, e.g. a Telegram chat
+}
+
+// UpsertChannelConnection creates or replaces the user's connection for a channel
+func (db *DB) UpsertChannelConnection(ctx context.Context, userID uuid.UUID, channel models.Channel, creds ChannelCredentials) (*models.ChannelConnection, error) {
+	conn := &models.ChannelConnection{}
+	err := db.pool.QueryRow(ctx, `
+		INSERT INTO channel_connections (user_id, channel, account_name, access_token, refresh_token, token_expires_at, target)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, channel) DO UPDATE SET
+			account_name = EXCLUDED.account_name,
+			access_token = EXCLUDED.access_token,
+			refresh_token = EXCLUDED.refresh_token,
+			token_expires_at = EXCLUDED.token_expires_at,
+			target = EXCLUDED.target,
+			last_error = NULL,
+			updated_at = NOW()
+		RETURNING id, user_id, channel, account_name, access_token, refresh_token, token_expires_at, target, last_error, last_published_at, created_at, updated_at
+	`, userID, channel, creds.AccountName, creds.AccessToken, creds.RefreshToken, creds.TokenExpiresAt, creds.Target).Scan(
+		&conn.ID, &conn.UserID, &conn.Channel, &conn.AccountName, &conn.AccessToken, &conn.RefreshToken,
+		&conn.TokenExpiresAt, &conn.Target, &conn.LastError, &conn.LastPublishedAt, &conn.CreatedAt, &conn.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// GetChannelConnections retrieves all channel connections for a user
+func (db *DB) GetChannelConnections(ctx context.Context, userID uuid.UUID) ([]*models.ChannelConnection, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT id, user_id, channel, account_name, access_token, refresh_token, token_expires_at, target, last_error, last_published_at, created_at, updated_at
+		FROM channel_connections
+		WHERE user_id = $1
+		ORDER BY channel
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conns []*models.ChannelConnection
+	for rows.Next() {
+		conn := &models.ChannelConnection{}
+		err := rows.Scan(
+			&conn.ID, &conn.UserID, &conn.Channel, &conn.AccountName, &conn.AccessToken, &conn.RefreshToken,
+			&conn.TokenExpiresAt, &conn.Target, &conn.LastError, &conn.LastPublishedAt, &conn.CreatedAt, &conn.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		conns = append(conns, conn)
+	}
+
+	return conns, rows.Err()
+}
+
+// GetChannelConnection retrieves a user's connection for a single channel
+func (db *DB) GetChannelConnection(ctx context.Context, userID uuid.UUID, channel models.Channel) (*models.ChannelConnection, error) {
+	conn := &models.ChannelConnection{}
+	err := db.pool.QueryRow(ctx, `
+		SELECT id, user_id, channel, account_name, access_token, refresh_token, token_expires_at, target, last_error, last_published_at, created_at, updated_at
+		FROM channel_connections
+		WHERE user_id = $1 AND channel = $2
+	`, userID, channel).Scan(
+		&conn.ID, &conn.UserID, &conn.Channel, &conn.AccountName, &conn.AccessToken, &conn.RefreshToken,
+		&conn.TokenExpiresAt, &conn.Target, &conn.LastError, &conn.LastPublishedAt, &conn.CreatedAt, &conn.UpdatedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// DeleteChannelConnection removes a user's connection for a channel
+func (db *DB) DeleteChannelConnection(ctx context.Context, userID uuid.UUID, channel models.Channel) (bool, error) {
+	result, err := db.pool.Exec(ctx, `
+		DELETE FROM channel_connections WHERE user_id = $1 AND channel = $2
+	`, userID, channel)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// ChannelRef identifies a user's connection to a channel
+type ChannelRef struct {
+	UserID  uuid.UUID
+	Channel models.Channel
+}
+
+// RecordChannelPublishes records successful publishes on the users'
+// connections in a single statement (no-op for those not connected)
+func (db *DB) RecordChannelPublishes(ctx context.Context, refs []ChannelRef) error {
+	userIDs := make([]uuid.UUID, len(refs))
+	channels := make([]string, len(refs))
+	for i, ref := range refs {
+		userIDs[i] = ref.UserID
+		channels[i] = string(ref.Channel)
+	}
+
+	_, err := db.pool.Exec(ctx, `
+		UPDATE channel_connections c SET
+			last_published_at = NOW(),
+			last_error = NULL,
+			updated_at = NOW()
+		FROM unnest($1::uuid[], $2::text[]) AS p(user_id, channel)
+		WHERE c.user_id = p.user_id AND c.channel = p.channel::channel_type
+	`, userIDs, channels)
+	return err
+}
+
+// RecordChannelError records a publish error on the user's connection (no-op if not connected)
+func (db *DB) RecordChannelError(ctx context.Context, userID uuid.UUID, channel models.Channel, errorMsg string) error {
+	_, err := db.pool.Exec(ctx, `
+		UPDATE channel_connections SET
+			last_error = $3,
+			updated_at = NOW()
+		WHERE user_id = $1 AND channel = $2
+	`, userID, channel, errorMsg)
+	return err
+}
+
+// GetChannelActivity returns system-wide publish and failure counts per channel since the given time
+func (db *DB) GetChannelActivity(ctx context.Context, since time.Time) (map[models.Channel]ChannelActivity, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT channel,
+			COUNT(*) FILTER (WHERE status = 'published' AND published_at >= $1),
+			COUNT(*) FILTER (WHERE status = 'failed' OR (status = 'scheduled' AND retry_count > 0))
+		FROM posts
+		WHERE updated_at >= $1
+		GROUP BY channel
+	`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	activity := make(map[models.Channel]ChannelActivity)
+	for rows.Next() {
+		var channel models.Channel
+		var a ChannelActivity
+		if err := rows.Scan(&channel, &a.Published, &a.Failing); err != nil {
+			return nil, err
+		}
+		activity[channel] = a
+	}
+
+	return activity, rows.Err()
+}
+
+// Channel preset operations
+
+// ListChannelPresets retrieves a user's channel presets
+func (db *DB) ListChannelPresets(ctx context.Context, userID uuid.UUID) ([]*models.ChannelPreset, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT channel, is_default, targeting, hashtags, footer, signature, updated_at
+		FROM channel_presets
+		WHERE user_id = $1
+		ORDER BY channel
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var presets []*models.ChannelPreset
+	for rows.Next() {
+		p := &models.ChannelPreset{}
+		if err := rows.Scan(&p.Channel, &p.IsDefault, &p.Targeting, &p.Hashtags, &p.Footer, &p.Signature, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		presets = append(presets, p)
+	}
+
+	return presets, rows.Err()
+}
+
+// UpsertChannelPreset creates or replaces the user's preset for a channel.
+// Making it the default clears the default from the user's other presets.
+func (db *DB) UpsertChannelPreset(ctx context.Context, userID uuid.UUID, channel models.Channel, req models.UpdateChannelPresetRequest) (*models.ChannelPreset, error) {
+	p := &models.ChannelPreset{}
+	err := db.pool.QueryRow(ctx, `
+		WITH cleared AS (
+			UPDATE channel_presets SET is_default = FALSE, updated_at = NOW()
+			WHERE user_id = $1 AND channel <> $2 AND is_default AND $3
+		)
+		INSERT INTO channel_presets (user_id, channel, is_default, targeting, hashtags, footer, signature)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, channel) DO UPDATE SET
+			is_default = EXCLUDED.is_default,
+			targeting = EXCLUDED.targeting,
+			hashtags = EXCLUDED.hashtags,
+			footer = EXCLUDED.footer,
+			signature = EXCLUDED.signature,
+			updated_at = NOW()
+		RETURNING channel, is_default, targeting, hashtags, footer, signature, updated_at
+	`, userID, channel, req.IsDefault, req.Targeting, req.Hashtags, req.Footer, req.Signature).Scan(
+		&p.Channel, &p.IsDefault, &p.Targeting, &p.Hashtags, &p.Footer, &p.Signature, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// GetChannelSignature returns the signature of the user's preset for a
+// channel, or nil if there is none
+func (db *DB) GetChannelSignature(ctx context.Context, userID uuid.UUID, channel models.Channel) (*string, error) {
+	var signature *string
+	err := db.pool.QueryRow(ctx, `
+		SELECT signature FROM channel_presets WHERE user_id = $1 AND channel = $2
+	`, userID, channel).Scan(&signature)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return signature, err
+}
+
+// DeleteChannelPreset removes the user's preset for a channel
+func (db *DB) DeleteChannelPreset(ctx context.Context, userID uuid.UUID, channel models.Channel) (bool, error) {
+	result, err := db.pool.Exec(ctx, `
+		DELETE FROM channel_presets WHERE user_id = $1 AND channel = $2
+	`, userID, channel)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// column pairs a selected column with the field of T it is scanned into
+type column[T any] struct {
+	name  string
+	field func(*T) any
+}
+
+// col declares a column of T
+func col[T any](name string, field func(*T) any) column[T] {
+	return column[T]{name: name, field: field}
+}
+
+// columnSet is the column list selected for every query of one model, and how
+// to scan it. Declaring each column next to its field keeps the SELECT list
+// and the Scan arguments in the same order, so adding a column is one line.
+//
+// Queries are still written inline; pgx prepares and caches each distinct
+// statement per connection (see PoolConfig.QueryExecMode).
+type columnSet[T any] struct {
+	list    string // Comma-separated, for SELECT and RETURNING
+	columns []column[T]
+}
+
+// columns declares the column set of T
+func columns[T any](cols ...column[T]) columnSet[T] {
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = c.name
+	}
+	return columnSet[T]{list: strings.Join(names, ", "), columns: cols}
+}
+
+// scan scans a row selected with the set's list into a new T
+func (s columnSet[T]) scan(row pgx.Row) (*T, error) {
+	v := new(T)
+	if err := row.Scan(s.dest(v)...); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// dest returns the Scan destinations of the set's columns in v, for queries
+// that select more than the set
+func (s columnSet[T]) dest(v *T) []any {
+	dest := make([]any, len(s.columns), len(s.columns)+1)
+	for i, c := range s.columns {
+		dest[i] = c.field(v)
+	}
+	return dest
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeRow scans values into the destinations in order
+type fakeRow struct {
+	values []any
+	err    error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(dest) != len(r.values) {
+		return errors.New("wrong number of destinations")
+	}
+	for i, v := range r.values {
+		switch d := dest[i].(type) {
+		case *string:
+			*d = v.(string)
+		case *int:
+			*d = v.(int)
+		default:
+			return errors.New("unexpected destination type")
+		}
+	}
+	return nil
+}
+
+func TestColumnSet(t *testing.T) {
+	type item struct {
+		Name  string
+		Count int
+	}
+	set := columns(
+		col("name", func(i *item) any { return &i.Name }),
+		col("count", func(i *item) any { return &i.Count }),
+	)
+
+	if set.list != "name, count" {
+		t.Errorf("list = %q, want %q", set.list, "name, count")
+	}
+
+	got, err := set.scan(fakeRow{values: []any{"a", 3}})
+	if err != nil {
+		t.Fatalf("scan() error = %v", err)
+	}
+	if *got != (item{Name: "a", Count: 3}) {
+		t.Errorf("scan() = %+v, want {a 3}", *got)
+	}
+
+	if _, err := set.scan(fakeRow{err: errors.New("boom")}); err == nil {
+		t.Error("scan() error = nil, want the row's error")
+	}
+}
+
+func TestColumnSets_Unique(t *testing.T) {
+	for name, list := range map[string]string{
+		"post":    postColumns,
+		"user":    userColumns,
+		"invite":  inviteColumns,
+		"media":   mediaColumns,
+		"inbox":   inboxColumns,
+		"monitor": monitorColumns,
+	} {
+		seen := make(map[string]bool)
+		for _, c := range strings.Split(list, ", ") {
+			if seen[c] {
+				t.Errorf("%s columns select %s twice", name, c)
+			}
+			seen[c] = true
+		}
+	}
+}
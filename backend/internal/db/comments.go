@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Comment operations
+
+// CreateComment adds a comment to a post, returning it with its author's email
+func (db *DB) CreateComment(ctx context.Context, postID, userID uuid.UUID, parentID *uuid.UUID, body string) (*models.Comment, error) {
+	c := &models.Comment{}
+	err := db.pool.QueryRow(ctx, `
+		WITH c AS (
+			INSERT INTO post_comments (post_id, user_id, parent_id, body)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, post_id, user_id, parent_id, body, created_at
+		)
+		SELECT c.id, c.post_id, c.user_id, u.email, c.parent_id, c.body, c.created_at
+		FROM c JOIN users u ON u.id = c.user_id
+	`, postID, userID, parentID, body).Scan(
+		&c.ID, &c.PostID, &c.UserID, &c.AuthorEmail, &c.ParentID, &c.Body, &c.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.Replies = []*models.Comment{}
+	return c, nil
+}
+
+// ListComments returns a post's comments, oldest first
+func (db *DB) ListComments(ctx context.Context, postID uuid.UUID) ([]*models.Comment, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT c.id, c.post_id, c.user_id, u.email, c.parent_id, c.body, c.created_at
+		FROM post_comments c
+		JOIN users u ON u.id = c.user_id
+		WHERE c.post_id = $1
+		ORDER BY c.created_at, c.id
+	`, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*models.Comment
+	for rows.Next() {
+		c := &models.Comment{}
+		if err := rows.Scan(&c.ID, &c.PostID, &c.UserID, &c.AuthorEmail, &c.ParentID, &c.Body, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+	return comments, rows.Err()
+}
+
+// CommentExists reports whether the comment belongs to the post
+func (db *DB) CommentExists(ctx context.Context, postID, commentID uuid.UUID) (bool, error) {
+	var exists bool
+	err := db.pool.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM post_comments WHERE id = $1 AND post_id = $2)
+	`, commentID, postID).Scan(&exists)
+	return exists, err
+}
+
+// DeleteComment deletes the user's own comment on a post along with its
+// replies, returning false if there was no such comment
+func (db *DB) DeleteComment(ctx context.Context, postID, commentID, userID uuid.UUID) (bool, error) {
+	result, err := db.pool.Exec(ctx, `
+		DELETE FROM post_comments WHERE id = $1 AND post_id = $2 AND user_id = $3
+	`, commentID, postID, userID)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
@@ -2,10 +2,7 @@ package db
 
 import (
 	"context"
-	"embed"
 	"fmt"
-	"io/fs"
-	"sort"
 	"strings"
 	"time"
 
@@ -13,11 +10,9 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/tenant"
 )
 
-//go:embed migrations/*.sql
-var migrationsFS embed.FS
-
 // DB wraps the database connection pool
 type DB struct {
 	pool *pgxpool.Pool
@@ -26,21 +21,85 @@ type DB struct {
 // PostWithRetry is an alias for models.Post used in worker retry logic
 type PostWithRetry = models.Post
 
+// PoolConfig sizes the connection pool; zero fields keep their defaults
+type PoolConfig struct {
+	MaxConns          int32         // Default 25
+	MinConns          int32         // Connections kept open while idle; default 5
+	MaxConnLifetime   time.Duration // Default 30m
+	MaxConnIdleTime   time.Duration // Default 5m
+	HealthCheckPeriod time.Duration // Default 30s
+
+	// QueryExecMode is how queries are sent: "cache_statement" (the default,
+	// prepared statements cached per connection), "cache_describe",
+	// "describe_exec", "exec" or "simple_protocol". Behind a transaction-mode
+	// connection pooler such as PgBouncer use "exec" or "simple_protocol".
+	QueryExecMode string
+	// StatementCacheCapacity is how many statements each connection caches
+	// in the cache_statement and cache_describe modes; default 512
+	StatementCacheCapacity int
+}
+
+// queryExecModes are the accepted PoolConfig.QueryExecMode values
+var queryExecModes = map[string]pgx.QueryExecMode{
+	"cache_statement": pgx.QueryExecModeCacheStatement,
+	"cache_describe":  pgx.QueryExecModeCacheDescribe,
+	"describe_exec":   pgx.QueryExecModeDescribeExec,
+	"exec":            pgx.QueryExecModeExec,
+	"simple_protocol": pgx.QueryExecModeSimpleProtocol,
+}
+
+// apply sets the pool settings on config, validating them
+func (p PoolConfig) apply(config *pgxpool.Config) error {
+	config.MaxConns = 25
+	config.MinConns = 5
+	config.MaxConnLifetime = 30 * time.Minute
+	config.MaxConnIdleTime = 5 * time.Minute
+	config.HealthCheckPeriod = 30 * time.Second
+
+	if p.MaxConns > 0 {
+		config.MaxConns = p.MaxConns
+	}
+	if p.MinConns > 0 {
+		config.MinConns = p.MinConns
+	}
+	// A small MaxConns alone lowers the default MinConns with it
+	if config.MinConns > config.MaxConns {
+		config.MinConns = config.MaxConns
+	}
+	if p.MaxConnLifetime > 0 {
+		config.MaxConnLifetime = p.MaxConnLifetime
+	}
+	if p.MaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = p.MaxConnIdleTime
+	}
+	if p.HealthCheckPeriod > 0 {
+		config.HealthCheckPeriod = p.HealthCheckPeriod
+	}
+
+	if p.QueryExecMode != "" {
+		mode, ok := queryExecModes[p.QueryExecMode]
+		if !ok {
+			return fmt.Errorf("unknown query exec mode %q", p.QueryExecMode)
+		}
+		config.ConnConfig.DefaultQueryExecMode = mode
+	}
+	if p.StatementCacheCapacity > 0 {
+		config.ConnConfig.StatementCacheCapacity = p.StatementCacheCapacity
+		config.ConnConfig.DescriptionCacheCapacity = p.StatementCacheCapacity
+	}
+	return nil
+}
+
 // New creates a new database connection
-func New(ctx context.Context, databaseURL string) (*DB, error) {
-	// Parse config to set optimal pool settings
+func New(ctx context.Context, databaseURL string, poolConfig PoolConfig) (*DB, error) {
 	config, err := pgxpool.ParseConfig(databaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse database URL: %w", err)
 	}
-	
-	// Optimize connection pool for low latency
-	config.MaxConns = 25                          // Increase max connections
-	config.MinConns = 5                           // Keep minimum connections ready
-	config.MaxConnLifetime = 30 * time.Minute     // Connection lifetime
-	config.MaxConnIdleTime = 5 * time.Minute      // Idle connection timeout
-	config.HealthCheckPeriod = 30 * time.Second   // Health check interval
-	
+	if err := poolConfig.apply(config); err != nil {
+		return nil, err
+	}
+
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection pool: %w", err)
@@ -59,257 +118,793 @@ func (db *DB) Close() {
 	db.pool.Close()
 }
 
-// RunMigrations runs all database migrations
-func (db *DB) RunMigrations(ctx context.Context) error {
-	// Create migrations table if not exists
-	_, err := db.pool.Exec(ctx, `
-		CREATE TABLE IF NOT EXISTS schema_migrations (
-			version VARCHAR(255) PRIMARY KEY,
-			applied_at TIMESTAMPTZ DEFAULT NOW()
-		)
-	`)
-	if err != nil {
-		return fmt.Errorf("failed to create migrations table: %w", err)
-	}
+// Ping checks the database is reachable
+func (db *DB) Ping(ctx context.Context) error {
+	return db.pool.Ping(ctx)
+}
+
+// Tenant operations
 
-	// Read migration files
-	entries, err := fs.ReadDir(migrationsFS, "migrations")
+// ListTenants returns every tenant
+func (db *DB) ListTenants(ctx context.Context) ([]*models.Tenant, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT id, slug, name, hostnames, created_at
+		FROM tenants ORDER BY slug
+	`)
 	if err != nil {
-		return fmt.Errorf("failed to read migrations: %w", err)
+		return nil, err
 	}
+	defer rows.Close()
 
-	// Sort and filter for .up.sql files
-	var upMigrations []string
-	for _, entry := range entries {
-		if strings.HasSuffix(entry.Name(), ".up.sql") {
-			upMigrations = append(upMigrations, entry.Name())
+	var tenants []*models.Tenant
+	for rows.Next() {
+		t := &models.Tenant{}
+		if err := rows.Scan(&t.ID, &t.Slug, &t.Name, &t.Hostnames, &t.CreatedAt); err != nil {
+			return nil, err
 		}
+		tenants = append(tenants, t)
 	}
-	sort.Strings(upMigrations)
+	return tenants, rows.Err()
+}
 
-	// Run each migration
-	for _, filename := range upMigrations {
-		version := strings.TrimSuffix(filename, ".up.sql")
+// tenantFilter returns the ID of the context's tenant, or nil for unscoped
+// contexts, for queries that filter with ($n::uuid IS NULL OR tenant_id = $n)
+func tenantFilter(ctx context.Context) *uuid.UUID {
+	if t := tenant.FromContext(ctx); t != nil {
+		return &t.ID
+	}
+	return nil
+}
 
-		// Check if already applied
-		var exists bool
-		err := db.pool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version = $1)", version).Scan(&exists)
-		if err != nil {
-			return fmt.Errorf("failed to check migration status: %w", err)
-		}
-		if exists {
-			continue
-		}
+// Organization operations
 
-		// Read and execute migration
-		content, err := fs.ReadFile(migrationsFS, "migrations/"+filename)
-		if err != nil {
-			return fmt.Errorf("failed to read migration %s: %w", filename, err)
-		}
+// CreateOrganization creates an organization in the context's tenant with ownerID as its owner
+func (db *DB) CreateOrganization(ctx context.Context, name string, ownerID uuid.UUID) (*models.Organization, error) {
+	org := &models.Organization{}
+	err := db.pool.QueryRow(ctx, `
+		WITH org AS (
+			INSERT INTO organizations (tenant_id, name)
+			VALUES ($1, $2)
+			RETURNING id, name, created_at
+		), owner AS (
+			INSERT INTO organization_members (org_id, user_id, role)
+			SELECT id, $3, 'owner' FROM org
+		)
+		SELECT id, name, created_at FROM org
+	`, tenant.IDFromContext(ctx), name, ownerID).Scan(&org.ID, &org.Name, &org.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
 
-		_, err = db.pool.Exec(ctx, string(content))
-		if err != nil {
-			return fmt.Errorf("failed to run migration %s: %w", filename, err)
-		}
+// ListWorkspaces returns the organizations the user belongs to as workspaces
+func (db *DB) ListWorkspaces(ctx context.Context, userID uuid.UUID) ([]models.Workspace, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT o.id, o.name, m.role
+		FROM organization_members m
+		JOIN organizations o ON o.id = m.org_id
+		WHERE m.user_id = $1
+		ORDER BY o.name
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-		// Mark as applied
-		_, err = db.pool.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version)
-		if err != nil {
-			return fmt.Errorf("failed to record migration %s: %w", filename, err)
+	var workspaces []models.Workspace
+	for rows.Next() {
+		var id uuid.UUID
+		ws := models.Workspace{Type: models.WorkspaceOrganization}
+		if err := rows.Scan(&id, &ws.Name, &ws.Role); err != nil {
+			return nil, err
 		}
-
-		fmt.Printf("Applied migration: %s\n", filename)
+		ws.ID = &id
+		workspaces = append(workspaces, ws)
 	}
-
-	return nil
+	return workspaces, rows.Err()
 }
 
-// User operations
-
-// CreateUser creates a new user
-func (db *DB) CreateUser(ctx context.Context, email, passwordHash string) (*models.User, error) {
-	user := &models.User{}
+// GetMemberRole returns the user's role in the organization, or "" if they are not a member
+func (db *DB) GetMemberRole(ctx context.Context, orgID, userID uuid.UUID) (models.OrgRole, error) {
+	var role models.OrgRole
 	err := db.pool.QueryRow(ctx, `
-		INSERT INTO users (email, password_hash)
-		VALUES ($1, $2)
-		RETURNING id, email, password_hash, created_at, updated_at
-	`, email, passwordHash).Scan(&user.ID, &user.Email, &user.PasswordHash, &user.CreatedAt, &user.UpdatedAt)
+		SELECT role FROM organization_members
+		WHERE org_id = $1 AND user_id = $2
+	`, orgID, userID).Scan(&role)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	return role, err
+}
 
+// ListOrganizationMembers returns the organization's members, oldest first
+func (db *DB) ListOrganizationMembers(ctx context.Context, orgID uuid.UUID) ([]models.OrganizationMember, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT m.user_id, u.email, m.role, m.can_override_windows, m.created_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.org_id = $1
+		ORDER BY m.created_at
+	`, orgID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	return user, nil
+	members := []models.OrganizationMember{}
+	for rows.Next() {
+		var m models.OrganizationMember
+		if err := rows.Scan(&m.UserID, &m.Email, &m.Role, &m.CanOverrideWindows, &m.JoinedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
 }
 
-// GetUserByEmail retrieves a user by email
-func (db *DB) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
-	user := &models.User{}
+// SetOrganizationMember adds the user to the organization or changes their
+// role and publishing window override
+func (db *DB) SetOrganizationMember(ctx context.Context, orgID, userID uuid.UUID, role models.OrgRole, canOverrideWindows bool) error {
+	_, err := db.pool.Exec(ctx, `
+		INSERT INTO organization_members (org_id, user_id, role, can_override_windows)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (org_id, user_id) DO UPDATE SET
+			role = EXCLUDED.role,
+			can_override_windows = EXCLUDED.can_override_windows
+	`, orgID, userID, role, canOverrideWindows)
+	return err
+}
+
+// CanOverrideWindows reports whether the member may schedule posts outside the
+// organization's publishing windows
+func (db *DB) CanOverrideWindows(ctx context.Context, orgID, userID uuid.UUID) (bool, error) {
+	var allowed bool
 	err := db.pool.QueryRow(ctx, `
-		SELECT id, email, password_hash, created_at, updated_at
-		FROM users WHERE email = $1
-	`, email).Scan(&user.ID, &user.Email, &user.PasswordHash, &user.CreatedAt, &user.UpdatedAt)
+		SELECT role = 'owner' OR can_override_windows FROM organization_members
+		WHERE org_id = $1 AND user_id = $2
+	`, orgID, userID).Scan(&allowed)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	return allowed, err
+}
 
+// GetPublishingSchedule returns the organization's publishing windows, or nil
+// if there is no such organization
+func (db *DB) GetPublishingSchedule(ctx context.Context, orgID uuid.UUID) (*models.PublishingSchedule, error) {
+	s := &models.PublishingSchedule{}
+	err := db.pool.QueryRow(ctx, `
+		SELECT timezone, publishing_windows FROM organizations WHERE id = $1
+	`, orgID).Scan(&s.Timezone, &s.Windows)
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
+	return s, nil
+}
 
-	return user, nil
+// SetPublishingSchedule replaces the organization's publishing windows
+func (db *DB) SetPublishingSchedule(ctx context.Context, orgID uuid.UUID, s models.PublishingSchedule) error {
+	if s.Windows == nil {
+		s.Windows = []models.PublishingWindow{}
+	}
+	_, err := db.pool.Exec(ctx, `
+		UPDATE organizations SET timezone = $2, publishing_windows = $3 WHERE id = $1
+	`, orgID, s.Timezone, s.Windows)
+	return err
 }
 
-// GetUserByID retrieves a user by ID
-func (db *DB) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
-	user := &models.User{}
+// GetBrandChecklist returns the organization's brand safety checklist, or nil
+// if there is no such organization
+func (db *DB) GetBrandChecklist(ctx context.Context, orgID uuid.UUID) (*models.BrandChecklist, error) {
+	c := &models.BrandChecklist{}
 	err := db.pool.QueryRow(ctx, `
-		SELECT id, email, password_hash, created_at, updated_at
-		FROM users WHERE id = $1
-	`, id).Scan(&user.ID, &user.Email, &user.PasswordHash, &user.CreatedAt, &user.UpdatedAt)
-
+		SELECT brand_checklist FROM organizations WHERE id = $1
+	`, orgID).Scan(c)
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
-
-	return user, nil
+	return c, nil
 }
 
-// Post operations
-
-// CreatePost creates a new scheduled post
-func (db *DB) CreatePost(ctx context.Context, userID uuid.UUID, title *string, content string, channel models.Channel, scheduledAt time.Time) (*models.Post, error) {
-	post := &models.Post{}
-	err := db.pool.QueryRow(ctx, `
-		INSERT INTO posts (user_id, title, content, channel, scheduled_at)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, user_id, title, content, channel, status, scheduled_at, published_at, created_at, updated_at
-	`, userID, title, content, channel, scheduledAt).Scan(
-		&post.ID, &post.UserID, &post.Title, &post.Content, &post.Channel,
-		&post.Status, &post.ScheduledAt, &post.PublishedAt, &post.CreatedAt, &post.UpdatedAt,
-	)
+// SetBrandChecklist replaces the organization's brand safety checklist
+func (db *DB) SetBrandChecklist(ctx context.Context, orgID uuid.UUID, c models.BrandChecklist) error {
+	_, err := db.pool.Exec(ctx, `
+		UPDATE organizations SET brand_checklist = $2 WHERE id = $1
+	`, orgID, c)
+	return err
+}
 
+// RemoveOrganizationMember removes a non-owner member, returning false if
+// there was no such member
+func (db *DB) RemoveOrganizationMember(ctx context.Context, orgID, userID uuid.UUID) (bool, error) {
+	result, err := db.pool.Exec(ctx, `
+		DELETE FROM organization_members
+		WHERE org_id = $1 AND user_id = $2 AND role <> 'owner'
+	`, orgID, userID)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
-
-	return post, nil
+	return result.RowsAffected() > 0, nil
 }
 
-// GetPostByID retrieves a post by ID
-func (db *DB) GetPostByID(ctx context.Context, id uuid.UUID) (*models.Post, error) {
-	post := &models.Post{}
-	err := db.pool.QueryRow(ctx, `
-		SELECT id, user_id, title, content, channel, status, scheduled_at, published_at, created_at, updated_at
-		FROM posts WHERE id = $1
-	`, id).Scan(
-		&post.ID, &post.UserID, &post.Title, &post.Content, &post.Channel,
-		&post.Status, &post.ScheduledAt, &post.PublishedAt, &post.CreatedAt, &post.UpdatedAt,
-	)
+// User operations
 
+// userColumnSet is selected for every user query
+var userColumnSet = columns(
+	col("id", func(u *models.User) any { return &u.ID }),
+	col("email", func(u *models.User) any { return &u.Email }),
+	col("password_hash", func(u *models.User) any { return &u.PasswordHash }),
+	col("created_at", func(u *models.User) any { return &u.CreatedAt }),
+	col("updated_at", func(u *models.User) any { return &u.UpdatedAt }),
+	col("avatar_key", func(u *models.User) any { return &u.AvatarKey }),
+	col("avatar_updated_at", func(u *models.User) any { return &u.AvatarUpdatedAt }),
+	col("conflict_window_minutes", func(u *models.User) any { return &u.ConflictWindowMinutes }),
+	col("plan", func(u *models.User) any { return &u.Plan }),
+	col("tenant_id", func(u *models.User) any { return &u.TenantID }),
+	col("reminder_webhook_url", func(u *models.User) any { return &u.ReminderWebhookURL }),
+	col("notification_preferences", func(u *models.User) any { return &u.NotificationPreferences }),
+	col("suspended_at", func(u *models.User) any { return &u.SuspendedAt }),
+	col("suspension_reason", func(u *models.User) any { return &u.SuspensionReason }),
+)
+
+// userColumns is the column list selected for every user query
+var userColumns = userColumnSet.list
+
+// scanUser scans a row selected with userColumns, returning nil if no row was found
+func scanUser(row pgx.Row) (*models.User, error) {
+	user, err := userColumnSet.scan(row)
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	}
-	if err != nil {
-		return nil, err
+	return user, err
+}
+
+// CreateUser creates a new user in the context's tenant
+func (db *DB) CreateUser(ctx context.Context, email, passwordHash string) (*models.User, error) {
+	return scanUser(db.pool.QueryRow(ctx, `
+		INSERT INTO users (email, password_hash, tenant_id)
+		VALUES ($1, $2, $3)
+		RETURNING `+userColumns,
+		email, passwordHash, tenant.IDFromContext(ctx)))
+}
+
+// GetUserByEmail retrieves a user by email within the context's tenant
+func (db *DB) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	return scanUser(db.pool.QueryRow(ctx, `
+		SELECT `+userColumns+`
+		FROM users WHERE tenant_id = $1 AND email = $2
+	`, tenant.IDFromContext(ctx), email))
+}
+
+// GetUserByWebhookToken retrieves the user in the context's tenant whose
+// inbound webhook token hashes to tokenHash
+func (db *DB) GetUserByWebhookToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	return scanUser(db.pool.QueryRow(ctx, `
+		SELECT `+userColumns+`
+		FROM users WHERE tenant_id = $1 AND inbound_webhook_token_hash = $2
+	`, tenant.IDFromContext(ctx), tokenHash))
+}
+
+// SetWebhookToken replaces the user's inbound webhook token hash; nil disables the webhook
+func (db *DB) SetWebhookToken(ctx context.Context, userID uuid.UUID, tokenHash *string) error {
+	_, err := db.pool.Exec(ctx, `
+		UPDATE users SET inbound_webhook_token_hash = $2, updated_at = NOW() WHERE id = $1
+	`, userID, tokenHash)
+	return err
+}
+
+// GetUserByFeedToken retrieves the user in the context's tenant whose public
+// feed token hashes to tokenHash
+func (db *DB) GetUserByFeedToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	return scanUser(db.pool.QueryRow(ctx, `
+		SELECT `+userColumns+`
+		FROM users WHERE tenant_id = $1 AND feed_token_hash = $2
+	`, tenant.IDFromContext(ctx), tokenHash))
+}
+
+// SetFeedToken replaces the user's public feed token hash; nil disables the feed
+func (db *DB) SetFeedToken(ctx context.Context, userID uuid.UUID, tokenHash *string) error {
+	_, err := db.pool.Exec(ctx, `
+		UPDATE users SET feed_token_hash = $2, updated_at = NOW() WHERE id = $1
+	`, userID, tokenHash)
+	return err
+}
+
+// GetUserByEmbedToken retrieves the user whose schedule embed token hash matches
+func (db *DB) GetUserByEmbedToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	return scanUser(db.pool.QueryRow(ctx, `
+		SELECT `+userColumns+`
+		FROM users WHERE tenant_id = $1 AND embed_token_hash = $2
+	`, tenant.IDFromContext(ctx), tokenHash))
+}
+
+// SetEmbedToken replaces the user's schedule embed token hash; nil disables the embed
+func (db *DB) SetEmbedToken(ctx context.Context, userID uuid.UUID, tokenHash *string) error {
+	_, err := db.pool.Exec(ctx, `
+		UPDATE users SET embed_token_hash = $2, updated_at = NOW() WHERE id = $1
+	`, userID, tokenHash)
+	return err
+}
+
+// GetUserByID retrieves a user by ID
+func (db *DB) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	return scanUser(db.pool.QueryRow(ctx, `
+		SELECT `+userColumns+`
+		FROM users WHERE id = $1
+	`, id))
+}
+
+// SetUserAvatar sets or clears (nil key) the user's avatar
+func (db *DB) SetUserAvatar(ctx context.Context, id uuid.UUID, avatarKey *string) (*models.User, error) {
+	return scanUser(db.pool.QueryRow(ctx, `
+		UPDATE users SET
+			avatar_key = $2,
+			avatar_updated_at = CASE WHEN $2::varchar IS NULL THEN NULL ELSE NOW() END,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+userColumns,
+		id, avatarKey))
+}
+
+// SetUserPlan changes the user's subscription plan
+func (db *DB) SetUserPlan(ctx context.Context, id uuid.UUID, plan models.Plan) (*models.User, error) {
+	return scanUser(db.pool.QueryRow(ctx, `
+		UPDATE users SET
+			plan = $2,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+userColumns,
+		id, plan))
+}
+
+// UpdateUserSettings updates the user's account settings; nil fields are left unchanged
+func (db *DB) UpdateUserSettings(ctx context.Context, id uuid.UUID, req models.UpdateAccountSettingsRequest) (*models.User, error) {
+	return scanUser(db.pool.QueryRow(ctx, `
+		UPDATE users SET
+			conflict_window_minutes = COALESCE($2, conflict_window_minutes),
+			reminder_webhook_url = CASE WHEN $3::text IS NULL THEN reminder_webhook_url ELSE NULLIF($3, '') END,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+userColumns,
+		id, req.ConflictWindowMinutes, req.ReminderWebhookURL))
+}
+
+// SuspendUser suspends the user's account with the given reason, keeping the
+// original suspension time if they are already suspended
+func (db *DB) SuspendUser(ctx context.Context, id uuid.UUID, reason string) (*models.User, error) {
+	return scanUser(db.pool.QueryRow(ctx, `
+		UPDATE users SET
+			suspended_at = COALESCE(suspended_at, NOW()),
+			suspension_reason = $2,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+userColumns,
+		id, reason))
+}
+
+// UnsuspendUser lifts the user's suspension
+func (db *DB) UnsuspendUser(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	return scanUser(db.pool.QueryRow(ctx, `
+		UPDATE users SET
+			suspended_at = NULL,
+			suspension_reason = NULL,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+userColumns,
+		id))
+}
+
+// SetNotificationPreferences replaces the user's notification preferences
+func (db *DB) SetNotificationPreferences(ctx context.Context, id uuid.UUID, prefs models.NotificationPreferences) (*models.User, error) {
+	return scanUser(db.pool.QueryRow(ctx, `
+		UPDATE users SET
+			notification_preferences = $2,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+userColumns,
+		id, prefs))
+}
+
+// Post operations
+
+// postColumnSet is selected for every post query
+var postColumnSet = columns(
+	col("id", func(p *models.Post) any { return &p.ID }),
+	col("user_id", func(p *models.Post) any { return &p.UserID }),
+	col("title", func(p *models.Post) any { return &p.Title }),
+	col("content", func(p *models.Post) any { return &p.Content }),
+	col("channel", func(p *models.Post) any { return &p.Channel }),
+	col("status", func(p *models.Post) any { return &p.Status }),
+	col("scheduled_at", func(p *models.Post) any { return &p.ScheduledAt }),
+	col("published_at", func(p *models.Post) any { return &p.PublishedAt }),
+	col("retry_count", func(p *models.Post) any { return &p.RetryCount }),
+	col("last_error", func(p *models.Post) any { return &p.LastError }),
+	col("next_retry_at", func(p *models.Post) any { return &p.NextRetryAt }),
+	col("targeting", func(p *models.Post) any { return &p.Targeting }),
+	col("post_type", func(p *models.Post) any { return &p.Type }),
+	col("poll", func(p *models.Post) any { return &p.Poll }),
+	col("media", func(p *models.Post) any { return &p.Media }),
+	col("location", func(p *models.Post) any { return &p.Location }),
+	col("recycle", func(p *models.Post) any { return &p.Recycle }),
+	col("recycle_count", func(p *models.Post) any { return &p.RecycleCount }),
+	col("recycled_from_id", func(p *models.Post) any { return &p.RecycledFromID }),
+	col("priority", func(p *models.Post) any { return &p.Priority }),
+	col("tenant_id", func(p *models.Post) any { return &p.TenantID }),
+	col("org_id", func(p *models.Post) any { return &p.OrgID }),
+	col("window_override", func(p *models.Post) any { return &p.WindowOverride }),
+	col("workflow_state", func(p *models.Post) any { return &p.WorkflowState }),
+	col("assignee_id", func(p *models.Post) any { return &p.AssigneeID }),
+	col("ab_test", func(p *models.Post) any { return &p.ABTest }),
+	col("ab_variant", func(p *models.Post) any { return &p.ABVariant }),
+	col("ab_parent_id", func(p *models.Post) any { return &p.ABParentID }),
+	col("engagement", func(p *models.Post) any { return &p.Engagement }),
+	col("remind_before_minutes", func(p *models.Post) any { return &p.RemindBeforeMinutes }),
+	col("undo_until", func(p *models.Post) any { return &p.UndoUntil }),
+	col("canceled_at", func(p *models.Post) any { return &p.CanceledAt }),
+	col("no_signature", func(p *models.Post) any { return &p.NoSignature }),
+	col("created_at", func(p *models.Post) any { return &p.CreatedAt }),
+	col("updated_at", func(p *models.Post) any { return &p.UpdatedAt }),
+)
+
+// postColumns is the column list selected for every post query
+var postColumns = postColumnSet.list
+
+// scanPost scans a row selected with postColumns into a post
+func scanPost(row pgx.Row) (*models.Post, error) {
+	return postColumnSet.scan(row)
+}
+
+// postSummaryColumnSet is selected for post summaries
+var postSummaryColumnSet = columns(
+	col("id", func(p *models.PostSummary) any { return &p.ID }),
+	col("title", func(p *models.PostSummary) any { return &p.Title }),
+	col("channel", func(p *models.PostSummary) any { return &p.Channel }),
+	col("status", func(p *models.PostSummary) any { return &p.Status }),
+	col("scheduled_at", func(p *models.PostSummary) any { return &p.ScheduledAt }),
+)
+
+// postSummaryColumns is the column list selected for post summaries
+var postSummaryColumns = postSummaryColumnSet.list
+
+// scanPostSummaries scans all rows selected with postSummaryColumns
+func scanPostSummaries(rows pgx.Rows) ([]*models.PostSummary, error) {
+	defer rows.Close()
+
+	var summaries []*models.PostSummary
+	for rows.Next() {
+		summary, err := postSummaryColumnSet.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
 	}
 
-	return post, nil
+	return summaries, rows.Err()
 }
 
-// GetUpcomingPosts retrieves scheduled posts for a user
-func (db *DB) GetUpcomingPosts(ctx context.Context, userID uuid.UUID) ([]*models.Post, error) {
-	rows, err := db.pool.Query(ctx, `
-		SELECT id, user_id, title, content, channel, status, scheduled_at, published_at, created_at, updated_at
-		FROM posts 
-		WHERE user_id = $1 AND status = 'scheduled'
-		ORDER BY scheduled_at ASC
-	`, userID)
-	if err != nil {
-		return nil, err
+// scanPostRow scans a single post, returning nil if no row was found
+func scanPostRow(row pgx.Row) (*models.Post, error) {
+	post, err := scanPost(row)
+	if err == pgx.ErrNoRows {
+		return nil, nil
 	}
+	return post, err
+}
+
+// scanPosts scans all rows selected with postColumns
+func scanPosts(rows pgx.Rows) ([]*models.Post, error) {
 	defer rows.Close()
 
 	var posts []*models.Post
 	for rows.Next() {
-		post := &models.Post{}
-		err := rows.Scan(
-			&post.ID, &post.UserID, &post.Title, &post.Content, &post.Channel,
-			&post.Status, &post.ScheduledAt, &post.PublishedAt, &post.CreatedAt, &post.UpdatedAt,
-		)
+		post, err := scanPost(rows)
 		if err != nil {
 			return nil, err
 		}
 		posts = append(posts, post)
 	}
 
-	return posts, nil
+	return posts, rows.Err()
 }
 
-// GetPublishedPosts retrieves published posts for a user
-func (db *DB) GetPublishedPosts(ctx context.Context, userID uuid.UUID) ([]*models.Post, error) {
-	rows, err := db.pool.Query(ctx, `
-		SELECT id, user_id, title, content, channel, status, scheduled_at, published_at, created_at, updated_at
-		FROM posts 
-		WHERE user_id = $1 AND status = 'published'
-		ORDER BY published_at DESC
-	`, userID)
-	if err != nil {
-		return nil, err
-	}
+// eachPost calls fn with each row selected with postColumns, stopping at the
+// first error
+func eachPost(rows pgx.Rows, fn func(*models.Post) error) error {
 	defer rows.Close()
 
-	var posts []*models.Post
 	for rows.Next() {
-		post := &models.Post{}
-		err := rows.Scan(
-			&post.ID, &post.UserID, &post.Title, &post.Content, &post.Channel,
-			&post.Status, &post.ScheduledAt, &post.PublishedAt, &post.CreatedAt, &post.UpdatedAt,
-		)
+		post, err := scanPost(rows)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err := fn(post); err != nil {
+			return err
 		}
+	}
+
+	return rows.Err()
+}
+
+// NewPost holds the fields of a post to be created
+type NewPost struct {
+	UserID              uuid.UUID
+	Title               *string
+	Content             string
+	Channel             models.Channel
+	ScheduledAt         time.Time
+	Targeting           *models.PostTargeting
+	Type                models.PostType
+	Poll                *models.Poll
+	Media               []models.PostMedia
+	Location            *models.PostLocation
+	Recycle             *models.RecycleSettings
+	Priority            bool
+	OrgID               *uuid.UUID
+	Status              models.PostStatus    // Defaults to scheduled
+	WindowOverride      bool                 // Publishes outside the organization's publishing windows
+	WorkflowState       models.WorkflowState // Defaults to drafting
+	AssigneeID          *uuid.UUID
+	ABTest              *models.ABTest // Makes the post variant A of an A/B test
+	RemindBeforeMinutes *int
+	NoSignature         bool // Publishes without the channel preset's signature
+}
+
+// PostUpdate holds the fields of a post to be updated; nil fields are left unchanged
+type PostUpdate struct {
+	Title               *string
+	Content             *string
+	Channel             *models.Channel
+	ScheduledAt         *time.Time
+	Targeting           *models.PostTargeting
+	ClearTargeting      bool
+	Type                *models.PostType
+	Poll                *models.Poll
+	ClearPoll           bool
+	Media               []models.PostMedia // Replaces attachments when non-empty
+	ClearMedia          bool
+	Location            *models.PostLocation
+	ClearLocation       bool
+	Recycle             *models.RecycleSettings
+	ClearRecycle        bool
+	WindowOverride      *bool
+	RemindBeforeMinutes *int // Zero turns the reminder off
+	NoSignature         *bool
+	Retry               bool // Reschedule a failed post with a fresh retry budget
+}
+
+// CreatePost creates a new scheduled post in the context's tenant
+func (db *DB) CreatePost(ctx context.Context, p NewPost) (*models.Post, error) {
+	if p.Type == "" {
+		p.Type = models.PostTypeText
+	}
+	if p.Status == "" {
+		p.Status = models.PostStatusScheduled
+	}
+	if p.WorkflowState == "" {
+		p.WorkflowState = models.WorkflowDrafting
+	}
+	var abVariant *models.ABVariant
+	if p.ABTest != nil {
+		v := models.ABVariantA
+		abVariant = &v
+	}
+	return scanPost(db.pool.QueryRow(ctx, `
+		INSERT INTO posts (user_id, title, content, channel, scheduled_at, targeting, post_type, poll, media, location, recycle, priority, tenant_id, org_id, status, window_override, workflow_state, assignee_id, ab_test, ab_variant, remind_before_minutes, no_signature)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
+		RETURNING `+postColumns,
+		p.UserID, p.Title, p.Content, p.Channel, p.ScheduledAt, p.Targeting, p.Type, p.Poll, p.Media, p.Location, p.Recycle, p.Priority,
+		tenant.IDFromContext(ctx), p.OrgID, p.Status, p.WindowOverride, p.WorkflowState, p.AssigneeID, p.ABTest, abVariant, p.RemindBeforeMinutes, p.NoSignature))
+}
+
+// GetPostByID retrieves a post by ID. Contexts scoped to a tenant only see
+// that tenant's posts.
+func (db *DB) GetPostByID(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+	return scanPostRow(db.pool.QueryRow(ctx, `
+		SELECT `+postColumns+`
+		FROM posts WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)
+	`, id, tenantFilter(ctx)))
+}
+
+// workspaceFilter matches the user's personal posts when $2 is NULL, or all
+// posts of the organization $2 otherwise
+const workspaceFilter = `(($2::uuid IS NULL AND user_id = $1 AND org_id IS NULL) OR org_id = $2)`
+
+// PostFilter narrows a post listing; zero fields match every post
+type PostFilter struct {
+	WorkflowState *models.WorkflowState
+	AssigneeID    *uuid.UUID
+
+	After *models.PostCursor // Only posts after this position
+	Limit int                // At most this many posts; zero for all
+}
+
+// GetUpcomingPosts retrieves scheduled posts in a workspace: the user's
+// personal posts when orgID is nil, or the organization's posts
+func (db *DB) GetUpcomingPosts(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter PostFilter) ([]*models.Post, error) {
+	var posts []*models.Post
+	err := db.EachUpcomingPost(ctx, userID, orgID, filter, func(post *models.Post) error {
 		posts = append(posts, post)
+		return nil
+	})
+	return posts, err
+}
+
+// EachUpcomingPost calls fn with each post GetUpcomingPosts would return, as
+// it is read, so long lists needn't be held in memory. An error from fn stops
+// the iteration and is returned.
+func (db *DB) EachUpcomingPost(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter PostFilter, fn func(*models.Post) error) error {
+	query, args := upcomingQuery(postColumns, userID, orgID, filter)
+	rows, err := db.pool.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return eachPost(rows, fn)
+}
+
+// GetUpcomingPostSummaries retrieves the summaries of the posts
+// GetUpcomingPosts would return, without reading their content
+func (db *DB) GetUpcomingPostSummaries(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter PostFilter) ([]*models.PostSummary, error) {
+	query, args := upcomingQuery(postSummaryColumns, userID, orgID, filter)
+	rows, err := db.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPostSummaries(rows)
+}
+
+// upcomingQuery selects columns of a workspace's scheduled posts.
+//
+// The query is built for the filter rather than using workspaceFilter, so each
+// variant can walk idx_posts_upcoming_keyset or idx_posts_org_upcoming_keyset
+// from the cursor and stop at the limit instead of scanning every scheduled post.
+func upcomingQuery(columns string, userID uuid.UUID, orgID *uuid.UUID, filter PostFilter) (string, []interface{}) {
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := []string{"status = 'scheduled'"}
+	if orgID == nil {
+		conditions = append(conditions, "user_id = "+arg(userID), "org_id IS NULL")
+	} else {
+		conditions = append(conditions, "org_id = "+arg(*orgID))
+	}
+	if filter.WorkflowState != nil {
+		conditions = append(conditions, "workflow_state = "+arg(*filter.WorkflowState))
+	}
+	if filter.AssigneeID != nil {
+		conditions = append(conditions, "assignee_id = "+arg(*filter.AssigneeID))
+	}
+	if filter.After != nil {
+		conditions = append(conditions, fmt.Sprintf("(scheduled_at, id) > (%s, %s)", arg(filter.After.ScheduledAt), arg(filter.After.ID)))
 	}
 
-	return posts, nil
+	query := `
+		SELECT ` + columns + `
+		FROM posts
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY scheduled_at ASC, id ASC`
+	if filter.Limit > 0 {
+		query += " LIMIT " + arg(filter.Limit)
+	}
+	return query, args
+}
+
+// GetPublishedPosts retrieves published posts in a workspace, as GetUpcomingPosts
+func (db *DB) GetPublishedPosts(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID) ([]*models.Post, error) {
+	return db.GetHistoryPosts(ctx, userID, orgID, HistoryFilter{Statuses: []models.PostStatus{models.PostStatusPublished}})
+}
+
+// HistoryFilter narrows a history listing to statuses and a time range,
+// matched against when each post was published, canceled or last failed
+type HistoryFilter struct {
+	Statuses []models.PostStatus
+	From     *time.Time // Inclusive
+	To       *time.Time // Exclusive
+}
+
+// historyTime is when a post left the schedule
+const historyTime = `COALESCE(published_at, canceled_at, updated_at)`
+
+// GetHistoryPosts retrieves finished posts in a workspace, as
+// GetUpcomingPosts, most recent first
+func (db *DB) GetHistoryPosts(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter HistoryFilter) ([]*models.Post, error) {
+	var posts []*models.Post
+	err := db.EachHistoryPost(ctx, userID, orgID, filter, func(post *models.Post) error {
+		posts = append(posts, post)
+		return nil
+	})
+	return posts, err
 }
 
-// UpdatePost updates a scheduled post
-func (db *DB) UpdatePost(ctx context.Context, id uuid.UUID, userID uuid.UUID, title *string, content *string, channel *models.Channel, scheduledAt *time.Time) (*models.Post, error) {
+// EachHistoryPost calls fn with each post GetHistoryPosts would return, as
+// EachUpcomingPost
+func (db *DB) EachHistoryPost(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter HistoryFilter, fn func(*models.Post) error) error {
+	rows, err := db.pool.Query(ctx, historyQuery(postColumns), historyArgs(userID, orgID, filter)...)
+	if err != nil {
+		return err
+	}
+
+	return eachPost(rows, fn)
+}
+
+// GetHistoryPostSummaries retrieves the summaries of the posts
+// GetHistoryPosts would return, without reading their content
+func (db *DB) GetHistoryPostSummaries(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter HistoryFilter) ([]*models.PostSummary, error) {
+	rows, err := db.pool.Query(ctx, historyQuery(postSummaryColumns), historyArgs(userID, orgID, filter)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPostSummaries(rows)
+}
+
+// historyQuery selects columns of a workspace's finished posts, taking the
+// arguments from historyArgs
+func historyQuery(columns string) string {
+	return `
+		SELECT ` + columns + `
+		FROM posts 
+		WHERE ` + workspaceFilter + ` AND status::text = ANY($3)
+			AND ($4::timestamptz IS NULL OR ` + historyTime + ` >= $4)
+			AND ($5::timestamptz IS NULL OR ` + historyTime + ` < $5)
+		ORDER BY ` + historyTime + ` DESC`
+}
+
+func historyArgs(userID uuid.UUID, orgID *uuid.UUID, filter HistoryFilter) []interface{} {
+	statuses := make([]string, len(filter.Statuses))
+	for i, s := range filter.Statuses {
+		statuses[i] = string(s)
+	}
+	return []interface{}{userID, orgID, statuses, filter.From, filter.To}
+}
+
+// UpdatePost updates a scheduled post, or a failed one being retried
+func (db *DB) UpdatePost(ctx context.Context, id uuid.UUID, userID uuid.UUID, u PostUpdate) (*models.Post, error) {
 	// Only update fields that are provided
-	post := &models.Post{}
-	err := db.pool.QueryRow(ctx, `
+	return scanPostRow(db.pool.QueryRow(ctx, `
 		UPDATE posts SET
 			title = COALESCE($3, title),
 			content = COALESCE($4, content),
 			channel = COALESCE($5, channel),
 			scheduled_at = COALESCE($6, scheduled_at),
+			undo_until = CASE WHEN $6::timestamptz IS NULL THEN undo_until END,
+			targeting = CASE WHEN $8 THEN NULL ELSE COALESCE($7, targeting) END,
+			post_type = COALESCE($9, post_type),
+			poll = CASE WHEN $11 THEN NULL ELSE COALESCE($10, poll) END,
+			media = CASE WHEN $13 THEN NULL ELSE COALESCE($12, media) END,
+			location = CASE WHEN $15 THEN NULL ELSE COALESCE($14, location) END,
+			recycle = CASE WHEN $17 THEN NULL ELSE COALESCE($16, recycle) END,
+			window_override = COALESCE($18, window_override),
+			remind_before_minutes = CASE WHEN $19::int IS NULL THEN remind_before_minutes ELSE NULLIF($19, 0) END,
+			no_signature = COALESCE($21, no_signature),
+			status = 'scheduled',
+			retry_count = CASE WHEN $20 THEN 0 ELSE retry_count END,
+			next_retry_at = CASE WHEN $20 THEN NULL ELSE next_retry_at END,
+			last_error = CASE WHEN $20 THEN NULL ELSE last_error END,
 			updated_at = NOW()
-		WHERE id = $1 AND user_id = $2 AND status = 'scheduled'
-		RETURNING id, user_id, title, content, channel, status, scheduled_at, published_at, created_at, updated_at
-	`, id, userID, title, content, channel, scheduledAt).Scan(
-		&post.ID, &post.UserID, &post.Title, &post.Content, &post.Channel,
-		&post.Status, &post.ScheduledAt, &post.PublishedAt, &post.CreatedAt, &post.UpdatedAt,
-	)
-
-	if err == pgx.ErrNoRows {
-		return nil, nil
-	}
-	if err != nil {
-		return nil, err
-	}
-
-	return post, nil
+		WHERE id = $1 AND user_id = $2 AND status = CASE WHEN $20 THEN 'failed' ELSE 'scheduled' END::post_status
+		RETURNING `+postColumns,
+		id, userID, u.Title, u.Content, u.Channel, u.ScheduledAt,
+		u.Targeting, u.ClearTargeting, u.Type, u.Poll, u.ClearPoll,
+		u.Media, u.ClearMedia, u.Location, u.ClearLocation, u.Recycle, u.ClearRecycle, u.WindowOverride,
+		u.RemindBeforeMinutes, u.Retry, u.NoSignature))
 }
 
 // DeletePost deletes a scheduled post
 func (db *DB) DeletePost(ctx context.Context, id uuid.UUID, userID uuid.UUID) (bool, error) {
 	result, err := db.pool.Exec(ctx, `
-		DELETE FROM posts WHERE id = $1 AND user_id = $2 AND status = 'scheduled'
+		DELETE FROM posts WHERE id = $1 AND user_id = $2 AND status IN ('scheduled', 'pending_approval')
 	`, id, userID)
 	if err != nil {
 		return false, err
@@ -318,29 +913,38 @@ func (db *DB) DeletePost(ctx context.Context, id uuid.UUID, userID uuid.UUID) (b
 	return result.RowsAffected() > 0, nil
 }
 
-// PublishPost marks a post as published (used by worker)
-func (db *DB) PublishPost(ctx context.Context, id uuid.UUID) (*models.Post, error) {
-	post := &models.Post{}
-	err := db.pool.QueryRow(ctx, `
+// PublishPosts marks posts as published in a single statement (used by
+// worker), returning those that were still scheduled
+func (db *DB) PublishPosts(ctx context.Context, ids []uuid.UUID) ([]*models.Post, error) {
+	rows, err := db.pool.Query(ctx, `
 		UPDATE posts SET
 			status = 'published',
 			published_at = NOW(),
 			updated_at = NOW()
-		WHERE id = $1 AND status = 'scheduled'
-		RETURNING id, user_id, title, content, channel, status, scheduled_at, published_at, created_at, updated_at
-	`, id).Scan(
-		&post.ID, &post.UserID, &post.Title, &post.Content, &post.Channel,
-		&post.Status, &post.ScheduledAt, &post.PublishedAt, &post.CreatedAt, &post.UpdatedAt,
-	)
-
-	if err == pgx.ErrNoRows {
-		return nil, nil
+		WHERE id = ANY($1) AND status = 'scheduled'
+		RETURNING `+postColumns,
+		ids)
+	if err != nil {
+		return nil, err
 	}
+
+	return scanPosts(rows)
+}
+
+// GetFeedPosts retrieves the user's most recently published personal posts
+func (db *DB) GetFeedPosts(ctx context.Context, userID uuid.UUID, limit int) ([]*models.Post, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT `+postColumns+`
+		FROM posts
+		WHERE user_id = $1 AND org_id IS NULL AND status = 'published'
+		ORDER BY published_at DESC
+		LIMIT $2
+	`, userID, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	return post, nil
+	return scanPosts(rows)
 }
 
 // MarkPostFailed marks a post as failed with an error message
@@ -350,7 +954,7 @@ func (db *DB) MarkPostFailed(ctx context.Context, id uuid.UUID, errorMsg string)
 			status = 'failed', 
 			last_error = $2,
 			updated_at = NOW()
-		WHERE id = $1
+		WHERE id = $1 AND status = 'scheduled'
 	`, id, errorMsg)
 	return err
 }
@@ -370,32 +974,13 @@ func (db *DB) ScheduleRetry(ctx context.Context, id uuid.UUID, nextRetryAt time.
 
 // GetPostForRetry retrieves a post with retry info for the worker
 func (db *DB) GetPostForRetry(ctx context.Context, id uuid.UUID) (*models.Post, error) {
-	post := &models.Post{}
-	err := db.pool.QueryRow(ctx, `
-		SELECT id, user_id, title, content, channel, status, scheduled_at, published_at, 
-			   retry_count, last_error, next_retry_at, created_at, updated_at
-		FROM posts WHERE id = $1
-	`, id).Scan(
-		&post.ID, &post.UserID, &post.Title, &post.Content, &post.Channel,
-		&post.Status, &post.ScheduledAt, &post.PublishedAt,
-		&post.RetryCount, &post.LastError, &post.NextRetryAt,
-		&post.CreatedAt, &post.UpdatedAt,
-	)
-
-	if err == pgx.ErrNoRows {
-		return nil, nil
-	}
-	if err != nil {
-		return nil, err
-	}
-
-	return post, nil
+	return db.GetPostByID(ctx, id)
 }
 
 // GetDuePosts retrieves posts that are due for publishing (for worker without Redis)
 func (db *DB) GetDuePosts(ctx context.Context, limit int) ([]*models.Post, error) {
 	rows, err := db.pool.Query(ctx, `
-		SELECT id, user_id, title, content, channel, status, scheduled_at, published_at, created_at, updated_at
+		SELECT `+postColumns+`
 		FROM posts 
 		WHERE status = 'scheduled' AND scheduled_at <= NOW()
 		ORDER BY scheduled_at ASC
@@ -404,20 +989,295 @@ func (db *DB) GetDuePosts(ctx context.Context, limit int) ([]*models.Post, error
 	if err != nil {
 		return nil, err
 	}
+
+	return scanPosts(rows)
+}
+
+// GetRecyclablePosts retrieves published posts whose recycle interval has elapsed
+// and that have not been recycled yet
+func (db *DB) GetRecyclablePosts(ctx context.Context, limit int) ([]*models.Post, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT `+postColumns+`
+		FROM posts
+		WHERE status = 'published' AND recycle IS NOT NULL AND recycled_at IS NULL
+			AND recycle_count < (recycle->>'max_count')::int
+			AND published_at + (recycle->>'interval_days')::int * INTERVAL '1 day' <= NOW()
+		ORDER BY published_at ASC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPosts(rows)
+}
+
+// RecyclePost marks a published post as recycled and creates a scheduled copy of it,
+// due immediately. Returns nil if the post was already recycled by another worker.
+func (db *DB) RecyclePost(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+	return scanPostRow(db.pool.QueryRow(ctx, `
+		WITH source AS (
+			UPDATE posts SET recycled_at = NOW()
+			WHERE id = $1 AND status = 'published' AND recycled_at IS NULL
+			RETURNING *
+		)
+		INSERT INTO posts (user_id, title, content, channel, scheduled_at, targeting, post_type,
+			poll, media, location, recycle, recycle_count, recycled_from_id, priority, tenant_id, org_id)
+		SELECT user_id, title, content, channel, NOW(), targeting, post_type,
+			poll, media, location, recycle, recycle_count + 1, id, priority, tenant_id, org_id
+		FROM source
+		RETURNING `+postColumns,
+		id))
+}
+
+// FindConflictingPosts returns the user's scheduled posts on a channel within
+// window of scheduledAt, excluding the post with excludeID
+func (db *DB) FindConflictingPosts(ctx context.Context, userID uuid.UUID, channel models.Channel, scheduledAt time.Time, window time.Duration, excludeID uuid.UUID) ([]models.PostConflict, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT id, title, scheduled_at
+		FROM posts
+		WHERE user_id = $1 AND channel = $2 AND status = 'scheduled' AND id <> $5
+			AND scheduled_at BETWEEN $3::timestamptz - $4::interval AND $3::timestamptz + $4::interval
+		ORDER BY scheduled_at ASC
+	`, userID, channel, scheduledAt, window, excludeID)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
-	var posts []*models.Post
+	var conflicts []models.PostConflict
 	for rows.Next() {
-		post := &models.Post{}
-		err := rows.Scan(
-			&post.ID, &post.UserID, &post.Title, &post.Content, &post.Channel,
-			&post.Status, &post.ScheduledAt, &post.PublishedAt, &post.CreatedAt, &post.UpdatedAt,
+		var c models.PostConflict
+		if err := rows.Scan(&c.PostID, &c.Title, &c.ScheduledAt); err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, c)
+	}
+
+	return conflicts, rows.Err()
+}
+
+// CountChannelPostsForDay counts the user's scheduled and published posts on a channel
+// between start and end, by publish time when published and scheduled time otherwise
+func (db *DB) CountChannelPostsForDay(ctx context.Context, userID uuid.UUID, channel models.Channel, start, end time.Time, excludeID uuid.UUID) (int, error) {
+	var count int
+	err := db.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM posts
+		WHERE user_id = $1 AND channel = $2 AND id <> $5
+			AND status IN ('scheduled', 'published')
+			AND COALESCE(published_at, scheduled_at) >= $3
+			AND COALESCE(published_at, scheduled_at) < $4
+	`, userID, channel, start, end, excludeID).Scan(&count)
+	return count, err
+}
+
+// CountPublishedChannelPosts counts the user's posts published on a channel since the given time
+func (db *DB) CountPublishedChannelPosts(ctx context.Context, userID uuid.UUID, channel models.Channel, since time.Time) (int, error) {
+	var count int
+	err := db.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM posts
+		WHERE user_id = $1 AND channel = $2 AND status = 'published' AND published_at >= $3
+	`, userID, channel, since).Scan(&count)
+	return count, err
+}
+
+// DeferPost moves a scheduled post to a later time, recording why (used by worker)
+func (db *DB) DeferPost(ctx context.Context, id uuid.UUID, scheduledAt time.Time, reason string) error {
+	_, err := db.pool.Exec(ctx, `
+		UPDATE posts SET
+			scheduled_at = $2,
+			last_error = $3,
+			undo_until = NULL,
+			updated_at = NOW()
+		WHERE id = $1 AND status = 'scheduled'
+	`, id, scheduledAt, reason)
+	return err
+}
+
+// PublishPostNow reschedules a scheduled post to now in the priority lane.
+// windowOverride lets it publish outside the organization's publishing windows.
+func (db *DB) PublishPostNow(ctx context.Context, id uuid.UUID, userID uuid.UUID, windowOverride bool) (*models.Post, error) {
+	return scanPostRow(db.pool.QueryRow(ctx, `
+		UPDATE posts SET
+			scheduled_at = NOW(),
+			priority = true,
+			window_override = window_override OR $3,
+			updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND status = 'scheduled'
+		RETURNING `+postColumns,
+		id, userID, windowOverride))
+}
+
+// StartUndoWindow holds a due post until the end of its undo window. Returns
+// false if the post is no longer scheduled or its window already started.
+func (db *DB) StartUndoWindow(ctx context.Context, id uuid.UUID, until time.Time) (bool, error) {
+	result, err := db.pool.Exec(ctx, `
+		UPDATE posts SET undo_until = $2, updated_at = NOW()
+		WHERE id = $1 AND status = 'scheduled' AND undo_until IS NULL
+	`, id, until)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// AbortPublish cancels a post while it is in its undo window. Returns nil if
+// the post isn't the user's or its window has closed.
+func (db *DB) AbortPublish(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Post, error) {
+	return scanPostRow(db.pool.QueryRow(ctx, `
+		UPDATE posts SET
+			status = 'canceled',
+			canceled_at = NOW(),
+			next_retry_at = NULL,
+			undo_until = NULL,
+			updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND status = 'scheduled' AND undo_until > NOW()
+		RETURNING `+postColumns,
+		id, userID))
+}
+
+// CancelPost stops a scheduled or pending post from publishing and keeps it
+// with status canceled. Returns nil if the post isn't the user's or can no
+// longer be canceled.
+func (db *DB) CancelPost(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Post, error) {
+	return scanPostRow(db.pool.QueryRow(ctx, `
+		UPDATE posts SET
+			status = 'canceled',
+			canceled_at = NOW(),
+			next_retry_at = NULL,
+			undo_until = NULL,
+			updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND status IN ('scheduled', 'pending_approval')
+		RETURNING `+postColumns,
+		id, userID))
+}
+
+// SetPostWorkflow moves a post on the editorial board and changes its
+// assignee; nil fields are left unchanged. Returns nil if there is no such post.
+func (db *DB) SetPostWorkflow(ctx context.Context, id uuid.UUID, state *models.WorkflowState, assigneeID *uuid.UUID, unassign bool) (*models.Post, error) {
+	return scanPostRow(db.pool.QueryRow(ctx, `
+		UPDATE posts SET
+			workflow_state = COALESCE($2, workflow_state),
+			assignee_id = CASE WHEN $4 THEN NULL ELSE COALESCE($3, assignee_id) END,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+postColumns,
+		id, state, assigneeID, unassign))
+}
+
+// ApprovePost moves a pending post to scheduled. Returns nil if the post is not pending.
+func (db *DB) ApprovePost(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+	return scanPostRow(db.pool.QueryRow(ctx, `
+		UPDATE posts SET status = 'scheduled', updated_at = NOW()
+		WHERE id = $1 AND status = 'pending_approval'
+		RETURNING `+postColumns,
+		id))
+}
+
+// RejectPost marks a pending post rejected with the reason as its last error.
+// Returns nil if the post is not pending.
+func (db *DB) RejectPost(ctx context.Context, id uuid.UUID, reason string) (*models.Post, error) {
+	return scanPostRow(db.pool.QueryRow(ctx, `
+		UPDATE posts SET status = 'rejected', last_error = $2, updated_at = NOW()
+		WHERE id = $1 AND status = 'pending_approval'
+		RETURNING `+postColumns,
+		id, reason))
+}
+
+// ClaimApprovalReminders marks and returns pending organization posts due
+// before dueBefore whose approvers have not been reminded yet
+func (db *DB) ClaimApprovalReminders(ctx context.Context, dueBefore time.Time, limit int) ([]*models.Post, error) {
+	rows, err := db.pool.Query(ctx, `
+		UPDATE posts SET approval_reminded_at = NOW()
+		WHERE id IN (
+			SELECT id FROM posts
+			WHERE status = 'pending_approval' AND org_id IS NOT NULL
+				AND approval_reminded_at IS NULL AND scheduled_at <= $1
+			ORDER BY scheduled_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
 		)
-		if err != nil {
+		RETURNING `+postColumns,
+		dueBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanPosts(rows)
+}
+
+// ClaimApprovalEscalations marks and returns pending organization posts due
+// before dueBefore that have not been escalated yet
+func (db *DB) ClaimApprovalEscalations(ctx context.Context, dueBefore time.Time, limit int) ([]*models.Post, error) {
+	rows, err := db.pool.Query(ctx, `
+		UPDATE posts SET approval_escalated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM posts
+			WHERE status = 'pending_approval' AND org_id IS NOT NULL
+				AND approval_escalated_at IS NULL AND scheduled_at <= $1
+			ORDER BY scheduled_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+postColumns,
+		dueBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanPosts(rows)
+}
+
+// QueuedPostRef is the queue entry a scheduled post should have
+type QueuedPostRef struct {
+	ID       uuid.UUID
+	RunAt    time.Time // Next retry time if retrying, otherwise scheduled_at; at least the end of the undo window
+	Priority bool
+}
+
+// ListScheduledPostRefs pages through all scheduled posts ordered by ID,
+// starting after afterID (use uuid.Nil for the first page). Posts of suspended
+// accounts are left out; they aren't queued until the account is unsuspended.
+func (db *DB) ListScheduledPostRefs(ctx context.Context, afterID uuid.UUID, limit int) ([]QueuedPostRef, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT p.id, GREATEST(COALESCE(p.next_retry_at, p.scheduled_at), p.undo_until), p.priority
+		FROM posts p
+		JOIN users u ON u.id = p.user_id
+		WHERE p.status = 'scheduled' AND p.id > $1 AND u.suspended_at IS NULL
+		ORDER BY p.id ASC
+		LIMIT $2
+	`, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanPostRefs(rows)
+}
+
+// ListUserScheduledPostRefs returns the queue entries of all of a user's scheduled posts
+func (db *DB) ListUserScheduledPostRefs(ctx context.Context, userID uuid.UUID) ([]QueuedPostRef, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT id, GREATEST(COALESCE(next_retry_at, scheduled_at), undo_until), priority
+		FROM posts
+		WHERE status = 'scheduled' AND user_id = $1
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanPostRefs(rows)
+}
+
+// scanPostRefs scans rows of post ID, run time and priority
+func scanPostRefs(rows pgx.Rows) ([]QueuedPostRef, error) {
+	defer rows.Close()
+
+	var refs []QueuedPostRef
+	for rows.Next() {
+		var ref QueuedPostRef
+		if err := rows.Scan(&ref.ID, &ref.RunAt, &ref.Priority); err != nil {
 			return nil, err
 		}
-		posts = append(posts, post)
+		refs = append(refs, ref)
 	}
 
-	return posts, nil
+	return refs, rows.Err()
 }
@@ -0,0 +1,4 @@
+// Package dbmock provides a stand-in for db.Store so handlers and the worker
+// can be unit tested without Postgres. Store is generated from
+// internal/db/store.go; run go generate in internal/db after changing it.
+package dbmock
@@ -0,0 +1,181 @@
+// Command gen writes dbmock.Store, a stand-in for db.Store whose methods call
+// function fields. Run it with go generate in internal/db after changing
+// store.go.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"strings"
+)
+
+// dbImport is the package the interfaces are declared in
+const dbImport = "github.com/scheduler/backend/internal/db"
+
+func main() {
+	src := flag.String("src", "store.go", "file declaring the Store interface")
+	out := flag.String("out", "dbmock/store.go", "file to write the mock to")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, *src, nil, 0)
+	if err != nil {
+		log.Fatalf("parse %s: %v", *src, err)
+	}
+
+	interfaces := make(map[string]*ast.InterfaceType)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if it, ok := ts.Type.(*ast.InterfaceType); ok {
+				interfaces[ts.Name.Name] = it
+			}
+		}
+	}
+	if interfaces["Store"] == nil {
+		log.Fatalf("%s declares no Store interface", *src)
+	}
+
+	var methods []*ast.Field
+	collectMethods(interfaces, "Store", &methods)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by dbmock/gen from %s. DO NOT EDIT.\n\n", *src)
+	buf.WriteString("package dbmock\n\nimport (\n")
+	var std, external []string
+	for _, imp := range append(file.Imports, &ast.ImportSpec{Path: &ast.BasicLit{Value: fmt.Sprintf("%q", dbImport)}}) {
+		if strings.Contains(imp.Path.Value, ".") {
+			external = append(external, imp.Path.Value)
+		} else {
+			std = append(std, imp.Path.Value)
+		}
+	}
+	fmt.Fprintf(&buf, "\t%s\n\n\t%s\n)\n\n", strings.Join(std, "\n\t"), strings.Join(external, "\n\t"))
+
+	buf.WriteString("// Store is a db.Store whose methods call the function field of the same\n")
+	buf.WriteString("// name with Func appended. Calling a method whose field is nil panics.\n")
+	buf.WriteString("type Store struct {\n")
+	for _, m := range methods {
+		fmt.Fprintf(&buf, "\t%sFunc %s\n", m.Names[0].Name, typeString(fset, m.Type))
+	}
+	buf.WriteString("}\n\nvar _ db.Store = (*Store)(nil)\n")
+
+	for _, m := range methods {
+		name := m.Names[0].Name
+		fn := m.Type.(*ast.FuncType)
+		params, args := paramList(fset, fn.Params)
+		call := fmt.Sprintf("mock.%sFunc(%s)", name, args)
+		if fn.Results != nil && len(fn.Results.List) > 0 {
+			call = "return " + call
+		}
+		fmt.Fprintf(&buf, "\n// %s calls %sFunc\n", name, name)
+		fmt.Fprintf(&buf, "func (mock *Store) %s(%s)%s {\n", name, params, resultString(fset, fn.Results))
+		fmt.Fprintf(&buf, "\tif mock.%sFunc == nil {\n\t\tpanic(\"dbmock: unexpected call to %s\")\n\t}\n", name, name)
+		fmt.Fprintf(&buf, "\t%s\n}\n", call)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("format mock: %v\n%s", err, buf.String())
+	}
+	if err := os.WriteFile(*out, formatted, 0o644); err != nil {
+		log.Fatalf("write %s: %v", *out, err)
+	}
+}
+
+// collectMethods appends the methods of the named interface, expanding the
+// interfaces it embeds, in declaration order
+func collectMethods(interfaces map[string]*ast.InterfaceType, name string, methods *[]*ast.Field) {
+	it, ok := interfaces[name]
+	if !ok {
+		log.Fatalf("interface %s is not declared in the source file", name)
+	}
+	for _, field := range it.Methods.List {
+		if len(field.Names) == 0 {
+			collectMethods(interfaces, field.Type.(*ast.Ident).Name, methods)
+			continue
+		}
+		qualify(field.Type)
+		*methods = append(*methods, field)
+	}
+}
+
+// qualify rewrites the db package's own types, such as NewPost, to db.NewPost
+func qualify(node ast.Node) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.SelectorExpr:
+			return false // Already qualified
+		case *ast.Field:
+			if ident, ok := x.Type.(*ast.Ident); ok && ast.IsExported(ident.Name) {
+				x.Type = &ast.SelectorExpr{X: ast.NewIdent("db"), Sel: ident}
+			}
+		case *ast.StarExpr:
+			if ident, ok := x.X.(*ast.Ident); ok && ast.IsExported(ident.Name) {
+				x.X = &ast.SelectorExpr{X: ast.NewIdent("db"), Sel: ident}
+			}
+		case *ast.ArrayType:
+			if ident, ok := x.Elt.(*ast.Ident); ok && ast.IsExported(ident.Name) {
+				x.Elt = &ast.SelectorExpr{X: ast.NewIdent("db"), Sel: ident}
+			}
+		case *ast.MapType:
+			if ident, ok := x.Value.(*ast.Ident); ok && ast.IsExported(ident.Name) {
+				x.Value = &ast.SelectorExpr{X: ast.NewIdent("db"), Sel: ident}
+			}
+		}
+		return true
+	})
+}
+
+// paramList returns the parameters as declared and the names to call with
+func paramList(fset *token.FileSet, params *ast.FieldList) (string, string) {
+	var decl, args []string
+	for i, field := range params.List {
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("p%d", i))}
+		}
+		var fieldNames []string
+		for _, n := range names {
+			fieldNames = append(fieldNames, n.Name)
+			args = append(args, n.Name)
+		}
+		decl = append(decl, strings.Join(fieldNames, ", ")+" "+typeString(fset, field.Type))
+	}
+	return strings.Join(decl, ", "), strings.Join(args, ", ")
+}
+
+// resultString returns the results of a method signature, with a leading space
+func resultString(fset *token.FileSet, results *ast.FieldList) string {
+	if results == nil || len(results.List) == 0 {
+		return ""
+	}
+	var types []string
+	for _, field := range results.List {
+		types = append(types, typeString(fset, field.Type))
+	}
+	if len(types) == 1 {
+		return " " + types[0]
+	}
+	return " (" + strings.Join(types, ", ") + ")"
+}
+
+func typeString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, expr); err != nil {
+		log.Fatalf("print type: %v", err)
+	}
+	return buf.String()
+}
@@ -0,0 +1,1209 @@
+// Code generated by dbmock/gen from store.go. DO NOT EDIT.
+
+package dbmock
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Store is a db.Store whose methods call the function field of the same
+// name with Func appended. Calling a method whose field is nil panics.
+type Store struct {
+	CreateUserFunc                    func(ctx context.Context, email, passwordHash string) (*models.User, error)
+	CreateUserWithInviteFunc          func(ctx context.Context, email, passwordHash, codeHash string) (*models.User, error)
+	GetUserByIDFunc                   func(ctx context.Context, id uuid.UUID) (*models.User, error)
+	GetUserByEmailFunc                func(ctx context.Context, email string) (*models.User, error)
+	GetUserByWebhookTokenFunc         func(ctx context.Context, tokenHash string) (*models.User, error)
+	GetUserByFeedTokenFunc            func(ctx context.Context, tokenHash string) (*models.User, error)
+	SetWebhookTokenFunc               func(ctx context.Context, userID uuid.UUID, tokenHash *string) error
+	SetFeedTokenFunc                  func(ctx context.Context, userID uuid.UUID, tokenHash *string) error
+	GetUserByEmbedTokenFunc           func(ctx context.Context, tokenHash string) (*models.User, error)
+	SetEmbedTokenFunc                 func(ctx context.Context, userID uuid.UUID, tokenHash *string) error
+	GetUserByBioTokenFunc             func(ctx context.Context, tokenHash string) (*models.User, error)
+	SetBioTokenFunc                   func(ctx context.Context, userID uuid.UUID, tokenHash *string) error
+	SetUserAvatarFunc                 func(ctx context.Context, id uuid.UUID, avatarKey *string) (*models.User, error)
+	SetUserPlanFunc                   func(ctx context.Context, id uuid.UUID, plan models.Plan) (*models.User, error)
+	UpdateUserSettingsFunc            func(ctx context.Context, id uuid.UUID, req models.UpdateAccountSettingsRequest) (*models.User, error)
+	SetNotificationPreferencesFunc    func(ctx context.Context, id uuid.UUID, prefs models.NotificationPreferences) (*models.User, error)
+	SuspendUserFunc                   func(ctx context.Context, id uuid.UUID, reason string) (*models.User, error)
+	UnsuspendUserFunc                 func(ctx context.Context, id uuid.UUID) (*models.User, error)
+	CreateInviteFunc                  func(ctx context.Context, codeHash string, maxUses int, expiresAt *time.Time, createdBy uuid.UUID) (*models.Invite, error)
+	ListInvitesFunc                   func(ctx context.Context) ([]*models.Invite, error)
+	DeleteInviteFunc                  func(ctx context.Context, id uuid.UUID) (bool, error)
+	CreatePostFunc                    func(ctx context.Context, p db.NewPost) (*models.Post, error)
+	GetPostByIDFunc                   func(ctx context.Context, id uuid.UUID) (*models.Post, error)
+	GetPostForRetryFunc               func(ctx context.Context, id uuid.UUID) (*models.Post, error)
+	GetUpcomingPostsFunc              func(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.PostFilter) ([]*models.Post, error)
+	GetPublishedPostsFunc             func(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID) ([]*models.Post, error)
+	GetHistoryPostsFunc               func(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.HistoryFilter) ([]*models.Post, error)
+	EachUpcomingPostFunc              func(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.PostFilter, fn func(*models.Post) error) error
+	EachHistoryPostFunc               func(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.HistoryFilter, fn func(*models.Post) error) error
+	GetUpcomingPostSummariesFunc      func(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.PostFilter) ([]*models.PostSummary, error)
+	GetHistoryPostSummariesFunc       func(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.HistoryFilter) ([]*models.PostSummary, error)
+	GetFeedPostsFunc                  func(ctx context.Context, userID uuid.UUID, limit int) ([]*models.Post, error)
+	UpdatePostFunc                    func(ctx context.Context, id uuid.UUID, userID uuid.UUID, u db.PostUpdate) (*models.Post, error)
+	DeletePostFunc                    func(ctx context.Context, id uuid.UUID, userID uuid.UUID) (bool, error)
+	FindConflictingPostsFunc          func(ctx context.Context, userID uuid.UUID, channel models.Channel, scheduledAt time.Time, window time.Duration, excludeID uuid.UUID) ([]models.PostConflict, error)
+	CountChannelPostsForDayFunc       func(ctx context.Context, userID uuid.UUID, channel models.Channel, start, end time.Time, excludeID uuid.UUID) (int, error)
+	CountPublishedChannelPostsFunc    func(ctx context.Context, userID uuid.UUID, channel models.Channel, since time.Time) (int, error)
+	PublishPostsFunc                  func(ctx context.Context, ids []uuid.UUID) ([]*models.Post, error)
+	PublishPostNowFunc                func(ctx context.Context, id uuid.UUID, userID uuid.UUID, windowOverride bool) (*models.Post, error)
+	MarkPostFailedFunc                func(ctx context.Context, id uuid.UUID, errorMsg string) error
+	ScheduleRetryFunc                 func(ctx context.Context, id uuid.UUID, nextRetryAt time.Time, errorMsg string) error
+	DeferPostFunc                     func(ctx context.Context, id uuid.UUID, scheduledAt time.Time, reason string) error
+	StartUndoWindowFunc               func(ctx context.Context, id uuid.UUID, until time.Time) (bool, error)
+	AbortPublishFunc                  func(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Post, error)
+	CancelPostFunc                    func(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Post, error)
+	ListScheduledPostRefsFunc         func(ctx context.Context, afterID uuid.UUID, limit int) ([]db.QueuedPostRef, error)
+	ListUserScheduledPostRefsFunc     func(ctx context.Context, userID uuid.UUID) ([]db.QueuedPostRef, error)
+	SetPostWorkflowFunc               func(ctx context.Context, id uuid.UUID, state *models.WorkflowState, assigneeID *uuid.UUID, unassign bool) (*models.Post, error)
+	ApprovePostFunc                   func(ctx context.Context, id uuid.UUID) (*models.Post, error)
+	RejectPostFunc                    func(ctx context.Context, id uuid.UUID, reason string) (*models.Post, error)
+	ClaimApprovalRemindersFunc        func(ctx context.Context, dueBefore time.Time, limit int) ([]*models.Post, error)
+	ClaimApprovalEscalationsFunc      func(ctx context.Context, dueBefore time.Time, limit int) ([]*models.Post, error)
+	GetRecyclablePostsFunc            func(ctx context.Context, limit int) ([]*models.Post, error)
+	RecyclePostFunc                   func(ctx context.Context, id uuid.UUID) (*models.Post, error)
+	GetABTestsToStartFunc             func(ctx context.Context, limit int) ([]*models.Post, error)
+	StartABVariantFunc                func(ctx context.Context, id uuid.UUID) (*models.Post, error)
+	GetABTestsToDecideFunc            func(ctx context.Context, limit int) ([]*models.Post, error)
+	GetABVariantFunc                  func(ctx context.Context, parentID uuid.UUID) (*models.Post, error)
+	DecideABTestFunc                  func(ctx context.Context, id uuid.UUID, winner models.ABVariant) (bool, error)
+	RepostPostFunc                    func(ctx context.Context, id uuid.UUID) (*models.Post, error)
+	SetPostEngagementFunc             func(ctx context.Context, id uuid.UUID, e models.Engagement) (*models.Post, error)
+	CreateOrganizationFunc            func(ctx context.Context, name string, ownerID uuid.UUID) (*models.Organization, error)
+	ListWorkspacesFunc                func(ctx context.Context, userID uuid.UUID) ([]models.Workspace, error)
+	GetMemberRoleFunc                 func(ctx context.Context, orgID, userID uuid.UUID) (models.OrgRole, error)
+	ListOrganizationMembersFunc       func(ctx context.Context, orgID uuid.UUID) ([]models.OrganizationMember, error)
+	SetOrganizationMemberFunc         func(ctx context.Context, orgID, userID uuid.UUID, role models.OrgRole, canOverrideWindows bool) error
+	RemoveOrganizationMemberFunc      func(ctx context.Context, orgID, userID uuid.UUID) (bool, error)
+	CanOverrideWindowsFunc            func(ctx context.Context, orgID, userID uuid.UUID) (bool, error)
+	GetPublishingScheduleFunc         func(ctx context.Context, orgID uuid.UUID) (*models.PublishingSchedule, error)
+	SetPublishingScheduleFunc         func(ctx context.Context, orgID uuid.UUID, s models.PublishingSchedule) error
+	GetBrandChecklistFunc             func(ctx context.Context, orgID uuid.UUID) (*models.BrandChecklist, error)
+	SetBrandChecklistFunc             func(ctx context.Context, orgID uuid.UUID, c models.BrandChecklist) error
+	RecordAuditEntryFunc              func(ctx context.Context, e db.NewAuditEntry) error
+	EachAuditEntryFunc                func(ctx context.Context, orgID uuid.UUID, from, to time.Time, fn func(*models.AuditEntry) error) error
+	GetRetentionOverridesFunc         func(ctx context.Context, orgID uuid.UUID) (*models.RetentionOverrides, error)
+	SetRetentionOverridesFunc         func(ctx context.Context, orgID uuid.UUID, o models.RetentionOverrides) error
+	ApplyRetentionFunc                func(ctx context.Context, defaults models.RetentionPolicy, now time.Time, orgID *uuid.UUID, dryRun bool) (models.RetentionReport, error)
+	UpsertChannelConnectionFunc       func(ctx context.Context, userID uuid.UUID, channel models.Channel, creds db.ChannelCredentials) (*models.ChannelConnection, error)
+	GetChannelConnectionsFunc         func(ctx context.Context, userID uuid.UUID) ([]*models.ChannelConnection, error)
+	GetChannelConnectionFunc          func(ctx context.Context, userID uuid.UUID, channel models.Channel) (*models.ChannelConnection, error)
+	DeleteChannelConnectionFunc       func(ctx context.Context, userID uuid.UUID, channel models.Channel) (bool, error)
+	RecordChannelPublishesFunc        func(ctx context.Context, refs []db.ChannelRef) error
+	RecordChannelErrorFunc            func(ctx context.Context, userID uuid.UUID, channel models.Channel, errorMsg string) error
+	GetChannelActivityFunc            func(ctx context.Context, since time.Time) (map[models.Channel]db.ChannelActivity, error)
+	ListChannelPresetsFunc            func(ctx context.Context, userID uuid.UUID) ([]*models.ChannelPreset, error)
+	UpsertChannelPresetFunc           func(ctx context.Context, userID uuid.UUID, channel models.Channel, req models.UpdateChannelPresetRequest) (*models.ChannelPreset, error)
+	GetChannelSignatureFunc           func(ctx context.Context, userID uuid.UUID, channel models.Channel) (*string, error)
+	DeleteChannelPresetFunc           func(ctx context.Context, userID uuid.UUID, channel models.Channel) (bool, error)
+	GetConnectionsForFollowerPollFunc func(ctx context.Context, channels []models.Channel, day time.Time, limit int) ([]*models.ChannelConnection, error)
+	SaveFollowerCountsFunc            func(ctx context.Context, day time.Time, polled []uuid.UUID, counts []models.FollowerCount) error
+	ListFollowerCountsFunc            func(ctx context.Context, userID uuid.UUID, since, until time.Time) ([]models.FollowerCount, error)
+	CreateCommentFunc                 func(ctx context.Context, postID, userID uuid.UUID, parentID *uuid.UUID, body string) (*models.Comment, error)
+	ListCommentsFunc                  func(ctx context.Context, postID uuid.UUID) ([]*models.Comment, error)
+	CommentExistsFunc                 func(ctx context.Context, postID, commentID uuid.UUID) (bool, error)
+	DeleteCommentFunc                 func(ctx context.Context, postID, commentID, userID uuid.UUID) (bool, error)
+	CreateMediaFunc                   func(ctx context.Context, m *models.Media) (*models.Media, error)
+	GetMediaByIDsFunc                 func(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Media, error)
+	ListMediaFunc                     func(ctx context.Context, userID uuid.UUID) ([]*models.Media, error)
+	UpdateMediaAltTextFunc            func(ctx context.Context, userID, id uuid.UUID, altText *string) (*models.Media, error)
+	SetMediaRenditionsFunc            func(ctx context.Context, id uuid.UUID, renditions []models.MediaRendition) error
+	ListMediaFoldersFunc              func(ctx context.Context, orgID uuid.UUID) ([]*models.MediaFolder, error)
+	CreateMediaFolderFunc             func(ctx context.Context, orgID, userID uuid.UUID, name string) (*models.MediaFolder, error)
+	DeleteMediaFolderFunc             func(ctx context.Context, orgID, id uuid.UUID) (bool, error)
+	ListLibraryMediaFunc              func(ctx context.Context, orgID uuid.UUID, filter models.LibraryFilter) ([]*models.LibraryMedia, error)
+	FileLibraryMediaFunc              func(ctx context.Context, userID, orgID uuid.UUID, req models.FileLibraryMediaRequest) (*models.Media, error)
+	RemoveLibraryMediaFunc            func(ctx context.Context, userID, orgID, id uuid.UUID, manage bool) (bool, error)
+	GetPostsForInboxSyncFunc          func(ctx context.Context, channels []models.Channel, publishedSince, syncedBefore time.Time, limit int) ([]*models.Post, error)
+	MarkInboxSyncedFunc               func(ctx context.Context, postIDs []uuid.UUID, at time.Time) error
+	AddInboxItemsFunc                 func(ctx context.Context, items []models.InboxItem) (int, error)
+	ListInboxItemsFunc                func(ctx context.Context, userID uuid.UUID, filter db.InboxFilter) ([]*models.InboxItem, error)
+	CountUnreadInboxFunc              func(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkInboxItemsFunc                func(ctx context.Context, userID uuid.UUID, ids []uuid.UUID, read bool) (int, error)
+	MarkAllInboxReadFunc              func(ctx context.Context, userID uuid.UUID) (int, error)
+	ListKeywordMonitorsFunc           func(ctx context.Context, userID uuid.UUID) ([]*models.KeywordMonitor, error)
+	CreateKeywordMonitorFunc          func(ctx context.Context, userID uuid.UUID, req models.CreateKeywordMonitorRequest) (*models.KeywordMonitor, error)
+	DeleteKeywordMonitorFunc          func(ctx context.Context, userID, id uuid.UUID) (bool, error)
+	GetKeywordMonitorsToPollFunc      func(ctx context.Context, polledBefore time.Time, limit int) ([]*models.KeywordMonitor, error)
+	MarkKeywordMonitorsPolledFunc     func(ctx context.Context, ids []uuid.UUID, at time.Time) error
+	SaveUsageDayFunc                  func(ctx context.Context, day time.Time, counts map[uuid.UUID]models.UsageCounts) error
+	ListUsageFunc                     func(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.UsageDay, error)
+	RollupSystemMetricsFunc           func(ctx context.Context, hourSince, daySince time.Time) error
+	ListSystemMetricsFunc             func(ctx context.Context, since, until time.Time) ([]models.MetricSeries, error)
+	GetUsersForMonthlyReportFunc      func(ctx context.Context, month time.Time, limit int) ([]*models.User, error)
+	MarkMonthlyReportSentFunc         func(ctx context.Context, userID uuid.UUID, month time.Time) error
+	ListBioLinksFunc                  func(ctx context.Context, userID uuid.UUID) ([]*models.BioLink, error)
+	CreateBioLinkFunc                 func(ctx context.Context, userID uuid.UUID, req models.CreateBioLinkRequest) (*models.BioLink, error)
+	ReorderBioLinksFunc               func(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (bool, error)
+	DeleteBioLinkFunc                 func(ctx context.Context, userID, id uuid.UUID) (bool, error)
+	RecordBioClickFunc                func(ctx context.Context, userID, id uuid.UUID) (*string, error)
+	PingFunc                          func(ctx context.Context) error
+	ListTenantsFunc                   func(ctx context.Context) ([]*models.Tenant, error)
+}
+
+var _ db.Store = (*Store)(nil)
+
+// CreateUser calls CreateUserFunc
+func (mock *Store) CreateUser(ctx context.Context, email, passwordHash string) (*models.User, error) {
+	if mock.CreateUserFunc == nil {
+		panic("dbmock: unexpected call to CreateUser")
+	}
+	return mock.CreateUserFunc(ctx, email, passwordHash)
+}
+
+// CreateUserWithInvite calls CreateUserWithInviteFunc
+func (mock *Store) CreateUserWithInvite(ctx context.Context, email, passwordHash, codeHash string) (*models.User, error) {
+	if mock.CreateUserWithInviteFunc == nil {
+		panic("dbmock: unexpected call to CreateUserWithInvite")
+	}
+	return mock.CreateUserWithInviteFunc(ctx, email, passwordHash, codeHash)
+}
+
+// GetUserByID calls GetUserByIDFunc
+func (mock *Store) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("dbmock: unexpected call to GetUserByID")
+	}
+	return mock.GetUserByIDFunc(ctx, id)
+}
+
+// GetUserByEmail calls GetUserByEmailFunc
+func (mock *Store) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	if mock.GetUserByEmailFunc == nil {
+		panic("dbmock: unexpected call to GetUserByEmail")
+	}
+	return mock.GetUserByEmailFunc(ctx, email)
+}
+
+// GetUserByWebhookToken calls GetUserByWebhookTokenFunc
+func (mock *Store) GetUserByWebhookToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	if mock.GetUserByWebhookTokenFunc == nil {
+		panic("dbmock: unexpected call to GetUserByWebhookToken")
+	}
+	return mock.GetUserByWebhookTokenFunc(ctx, tokenHash)
+}
+
+// GetUserByFeedToken calls GetUserByFeedTokenFunc
+func (mock *Store) GetUserByFeedToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	if mock.GetUserByFeedTokenFunc == nil {
+		panic("dbmock: unexpected call to GetUserByFeedToken")
+	}
+	return mock.GetUserByFeedTokenFunc(ctx, tokenHash)
+}
+
+// SetWebhookToken calls SetWebhookTokenFunc
+func (mock *Store) SetWebhookToken(ctx context.Context, userID uuid.UUID, tokenHash *string) error {
+	if mock.SetWebhookTokenFunc == nil {
+		panic("dbmock: unexpected call to SetWebhookToken")
+	}
+	return mock.SetWebhookTokenFunc(ctx, userID, tokenHash)
+}
+
+// SetFeedToken calls SetFeedTokenFunc
+func (mock *Store) SetFeedToken(ctx context.Context, userID uuid.UUID, tokenHash *string) error {
+	if mock.SetFeedTokenFunc == nil {
+		panic("dbmock: unexpected call to SetFeedToken")
+	}
+	return mock.SetFeedTokenFunc(ctx, userID, tokenHash)
+}
+
+// GetUserByEmbedToken calls GetUserByEmbedTokenFunc
+func (mock *Store) GetUserByEmbedToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	if mock.GetUserByEmbedTokenFunc == nil {
+		panic("dbmock: unexpected call to GetUserByEmbedToken")
+	}
+	return mock.GetUserByEmbedTokenFunc(ctx, tokenHash)
+}
+
+// SetEmbedToken calls SetEmbedTokenFunc
+func (mock *Store) SetEmbedToken(ctx context.Context, userID uuid.UUID, tokenHash *string) error {
+	if mock.SetEmbedTokenFunc == nil {
+		panic("dbmock: unexpected call to SetEmbedToken")
+	}
+	return mock.SetEmbedTokenFunc(ctx, userID, tokenHash)
+}
+
+// GetUserByBioToken calls GetUserByBioTokenFunc
+func (mock *Store) GetUserByBioToken(ctx context.Context, tokenHash string) (*models.User, error) {
+	if mock.GetUserByBioTokenFunc == nil {
+		panic("dbmock: unexpected call to GetUserByBioToken")
+	}
+	return mock.GetUserByBioTokenFunc(ctx, tokenHash)
+}
+
+// SetBioToken calls SetBioTokenFunc
+func (mock *Store) SetBioToken(ctx context.Context, userID uuid.UUID, tokenHash *string) error {
+	if mock.SetBioTokenFunc == nil {
+		panic("dbmock: unexpected call to SetBioToken")
+	}
+	return mock.SetBioTokenFunc(ctx, userID, tokenHash)
+}
+
+// SetUserAvatar calls SetUserAvatarFunc
+func (mock *Store) SetUserAvatar(ctx context.Context, id uuid.UUID, avatarKey *string) (*models.User, error) {
+	if mock.SetUserAvatarFunc == nil {
+		panic("dbmock: unexpected call to SetUserAvatar")
+	}
+	return mock.SetUserAvatarFunc(ctx, id, avatarKey)
+}
+
+// SetUserPlan calls SetUserPlanFunc
+func (mock *Store) SetUserPlan(ctx context.Context, id uuid.UUID, plan models.Plan) (*models.User, error) {
+	if mock.SetUserPlanFunc == nil {
+		panic("dbmock: unexpected call to SetUserPlan")
+	}
+	return mock.SetUserPlanFunc(ctx, id, plan)
+}
+
+// UpdateUserSettings calls UpdateUserSettingsFunc
+func (mock *Store) UpdateUserSettings(ctx context.Context, id uuid.UUID, req models.UpdateAccountSettingsRequest) (*models.User, error) {
+	if mock.UpdateUserSettingsFunc == nil {
+		panic("dbmock: unexpected call to UpdateUserSettings")
+	}
+	return mock.UpdateUserSettingsFunc(ctx, id, req)
+}
+
+// SetNotificationPreferences calls SetNotificationPreferencesFunc
+func (mock *Store) SetNotificationPreferences(ctx context.Context, id uuid.UUID, prefs models.NotificationPreferences) (*models.User, error) {
+	if mock.SetNotificationPreferencesFunc == nil {
+		panic("dbmock: unexpected call to SetNotificationPreferences")
+	}
+	return mock.SetNotificationPreferencesFunc(ctx, id, prefs)
+}
+
+// SuspendUser calls SuspendUserFunc
+func (mock *Store) SuspendUser(ctx context.Context, id uuid.UUID, reason string) (*models.User, error) {
+	if mock.SuspendUserFunc == nil {
+		panic("dbmock: unexpected call to SuspendUser")
+	}
+	return mock.SuspendUserFunc(ctx, id, reason)
+}
+
+// UnsuspendUser calls UnsuspendUserFunc
+func (mock *Store) UnsuspendUser(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	if mock.UnsuspendUserFunc == nil {
+		panic("dbmock: unexpected call to UnsuspendUser")
+	}
+	return mock.UnsuspendUserFunc(ctx, id)
+}
+
+// CreateInvite calls CreateInviteFunc
+func (mock *Store) CreateInvite(ctx context.Context, codeHash string, maxUses int, expiresAt *time.Time, createdBy uuid.UUID) (*models.Invite, error) {
+	if mock.CreateInviteFunc == nil {
+		panic("dbmock: unexpected call to CreateInvite")
+	}
+	return mock.CreateInviteFunc(ctx, codeHash, maxUses, expiresAt, createdBy)
+}
+
+// ListInvites calls ListInvitesFunc
+func (mock *Store) ListInvites(ctx context.Context) ([]*models.Invite, error) {
+	if mock.ListInvitesFunc == nil {
+		panic("dbmock: unexpected call to ListInvites")
+	}
+	return mock.ListInvitesFunc(ctx)
+}
+
+// DeleteInvite calls DeleteInviteFunc
+func (mock *Store) DeleteInvite(ctx context.Context, id uuid.UUID) (bool, error) {
+	if mock.DeleteInviteFunc == nil {
+		panic("dbmock: unexpected call to DeleteInvite")
+	}
+	return mock.DeleteInviteFunc(ctx, id)
+}
+
+// CreatePost calls CreatePostFunc
+func (mock *Store) CreatePost(ctx context.Context, p db.
+	NewPost) (*models.Post, error) {
+	if mock.CreatePostFunc == nil {
+		panic("dbmock: unexpected call to CreatePost")
+	}
+	return mock.CreatePostFunc(ctx, p)
+}
+
+// GetPostByID calls GetPostByIDFunc
+func (mock *Store) GetPostByID(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+	if mock.GetPostByIDFunc == nil {
+		panic("dbmock: unexpected call to GetPostByID")
+	}
+	return mock.GetPostByIDFunc(ctx, id)
+}
+
+// GetPostForRetry calls GetPostForRetryFunc
+func (mock *Store) GetPostForRetry(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+	if mock.GetPostForRetryFunc == nil {
+		panic("dbmock: unexpected call to GetPostForRetry")
+	}
+	return mock.GetPostForRetryFunc(ctx, id)
+}
+
+// GetUpcomingPosts calls GetUpcomingPostsFunc
+func (mock *Store) GetUpcomingPosts(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.
+	PostFilter) ([]*models.Post, error) {
+	if mock.GetUpcomingPostsFunc == nil {
+		panic("dbmock: unexpected call to GetUpcomingPosts")
+	}
+	return mock.GetUpcomingPostsFunc(ctx, userID, orgID, filter)
+}
+
+// GetPublishedPosts calls GetPublishedPostsFunc
+func (mock *Store) GetPublishedPosts(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID) ([]*models.Post, error) {
+	if mock.GetPublishedPostsFunc == nil {
+		panic("dbmock: unexpected call to GetPublishedPosts")
+	}
+	return mock.GetPublishedPostsFunc(ctx, userID, orgID)
+}
+
+// GetHistoryPosts calls GetHistoryPostsFunc
+func (mock *Store) GetHistoryPosts(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.
+	HistoryFilter) ([]*models.Post, error) {
+	if mock.GetHistoryPostsFunc == nil {
+		panic("dbmock: unexpected call to GetHistoryPosts")
+	}
+	return mock.GetHistoryPostsFunc(ctx, userID, orgID, filter)
+}
+
+// EachUpcomingPost calls EachUpcomingPostFunc
+func (mock *Store) EachUpcomingPost(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.
+	PostFilter, fn func(*models.Post) error) error {
+	if mock.EachUpcomingPostFunc == nil {
+		panic("dbmock: unexpected call to EachUpcomingPost")
+	}
+	return mock.EachUpcomingPostFunc(ctx, userID, orgID, filter, fn)
+}
+
+// EachHistoryPost calls EachHistoryPostFunc
+func (mock *Store) EachHistoryPost(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.
+	HistoryFilter, fn func(*models.Post) error) error {
+	if mock.EachHistoryPostFunc == nil {
+		panic("dbmock: unexpected call to EachHistoryPost")
+	}
+	return mock.EachHistoryPostFunc(ctx, userID, orgID, filter, fn)
+}
+
+// GetUpcomingPostSummaries calls GetUpcomingPostSummariesFunc
+func (mock *Store) GetUpcomingPostSummaries(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.
+	PostFilter) ([]*models.PostSummary, error) {
+	if mock.GetUpcomingPostSummariesFunc == nil {
+		panic("dbmock: unexpected call to GetUpcomingPostSummaries")
+	}
+	return mock.GetUpcomingPostSummariesFunc(ctx, userID, orgID, filter)
+}
+
+// GetHistoryPostSummaries calls GetHistoryPostSummariesFunc
+func (mock *Store) GetHistoryPostSummaries(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.
+	HistoryFilter) ([]*models.PostSummary, error) {
+	if mock.GetHistoryPostSummariesFunc == nil {
+		panic("dbmock: unexpected call to GetHistoryPostSummaries")
+	}
+	return mock.GetHistoryPostSummariesFunc(ctx, userID, orgID, filter)
+}
+
+// GetFeedPosts calls GetFeedPostsFunc
+func (mock *Store) GetFeedPosts(ctx context.Context, userID uuid.UUID, limit int) ([]*models.Post, error) {
+	if mock.GetFeedPostsFunc == nil {
+		panic("dbmock: unexpected call to GetFeedPosts")
+	}
+	return mock.GetFeedPostsFunc(ctx, userID, limit)
+}
+
+// UpdatePost calls UpdatePostFunc
+func (mock *Store) UpdatePost(ctx context.Context, id uuid.UUID, userID uuid.UUID, u db.
+	PostUpdate) (*models.Post, error) {
+	if mock.UpdatePostFunc == nil {
+		panic("dbmock: unexpected call to UpdatePost")
+	}
+	return mock.UpdatePostFunc(ctx, id, userID, u)
+}
+
+// DeletePost calls DeletePostFunc
+func (mock *Store) DeletePost(ctx context.Context, id uuid.UUID, userID uuid.UUID) (bool, error) {
+	if mock.DeletePostFunc == nil {
+		panic("dbmock: unexpected call to DeletePost")
+	}
+	return mock.DeletePostFunc(ctx, id, userID)
+}
+
+// FindConflictingPosts calls FindConflictingPostsFunc
+func (mock *Store) FindConflictingPosts(ctx context.Context, userID uuid.UUID, channel models.Channel, scheduledAt time.Time, window time.Duration, excludeID uuid.UUID) ([]models.PostConflict, error) {
+	if mock.FindConflictingPostsFunc == nil {
+		panic("dbmock: unexpected call to FindConflictingPosts")
+	}
+	return mock.FindConflictingPostsFunc(ctx, userID, channel, scheduledAt, window, excludeID)
+}
+
+// CountChannelPostsForDay calls CountChannelPostsForDayFunc
+func (mock *Store) CountChannelPostsForDay(ctx context.Context, userID uuid.UUID, channel models.Channel, start, end time.Time, excludeID uuid.UUID) (int, error) {
+	if mock.CountChannelPostsForDayFunc == nil {
+		panic("dbmock: unexpected call to CountChannelPostsForDay")
+	}
+	return mock.CountChannelPostsForDayFunc(ctx, userID, channel, start, end, excludeID)
+}
+
+// CountPublishedChannelPosts calls CountPublishedChannelPostsFunc
+func (mock *Store) CountPublishedChannelPosts(ctx context.Context, userID uuid.UUID, channel models.Channel, since time.Time) (int, error) {
+	if mock.CountPublishedChannelPostsFunc == nil {
+		panic("dbmock: unexpected call to CountPublishedChannelPosts")
+	}
+	return mock.CountPublishedChannelPostsFunc(ctx, userID, channel, since)
+}
+
+// PublishPosts calls PublishPostsFunc
+func (mock *Store) PublishPosts(ctx context.Context, ids []uuid.UUID) ([]*models.Post, error) {
+	if mock.PublishPostsFunc == nil {
+		panic("dbmock: unexpected call to PublishPosts")
+	}
+	return mock.PublishPostsFunc(ctx, ids)
+}
+
+// PublishPostNow calls PublishPostNowFunc
+func (mock *Store) PublishPostNow(ctx context.Context, id uuid.UUID, userID uuid.UUID, windowOverride bool) (*models.Post, error) {
+	if mock.PublishPostNowFunc == nil {
+		panic("dbmock: unexpected call to PublishPostNow")
+	}
+	return mock.PublishPostNowFunc(ctx, id, userID, windowOverride)
+}
+
+// MarkPostFailed calls MarkPostFailedFunc
+func (mock *Store) MarkPostFailed(ctx context.Context, id uuid.UUID, errorMsg string) error {
+	if mock.MarkPostFailedFunc == nil {
+		panic("dbmock: unexpected call to MarkPostFailed")
+	}
+	return mock.MarkPostFailedFunc(ctx, id, errorMsg)
+}
+
+// ScheduleRetry calls ScheduleRetryFunc
+func (mock *Store) ScheduleRetry(ctx context.Context, id uuid.UUID, nextRetryAt time.Time, errorMsg string) error {
+	if mock.ScheduleRetryFunc == nil {
+		panic("dbmock: unexpected call to ScheduleRetry")
+	}
+	return mock.ScheduleRetryFunc(ctx, id, nextRetryAt, errorMsg)
+}
+
+// DeferPost calls DeferPostFunc
+func (mock *Store) DeferPost(ctx context.Context, id uuid.UUID, scheduledAt time.Time, reason string) error {
+	if mock.DeferPostFunc == nil {
+		panic("dbmock: unexpected call to DeferPost")
+	}
+	return mock.DeferPostFunc(ctx, id, scheduledAt, reason)
+}
+
+// StartUndoWindow calls StartUndoWindowFunc
+func (mock *Store) StartUndoWindow(ctx context.Context, id uuid.UUID, until time.Time) (bool, error) {
+	if mock.StartUndoWindowFunc == nil {
+		panic("dbmock: unexpected call to StartUndoWindow")
+	}
+	return mock.StartUndoWindowFunc(ctx, id, until)
+}
+
+// AbortPublish calls AbortPublishFunc
+func (mock *Store) AbortPublish(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Post, error) {
+	if mock.AbortPublishFunc == nil {
+		panic("dbmock: unexpected call to AbortPublish")
+	}
+	return mock.AbortPublishFunc(ctx, id, userID)
+}
+
+// CancelPost calls CancelPostFunc
+func (mock *Store) CancelPost(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Post, error) {
+	if mock.CancelPostFunc == nil {
+		panic("dbmock: unexpected call to CancelPost")
+	}
+	return mock.CancelPostFunc(ctx, id, userID)
+}
+
+// ListScheduledPostRefs calls ListScheduledPostRefsFunc
+func (mock *Store) ListScheduledPostRefs(ctx context.Context, afterID uuid.UUID, limit int) ([]db.QueuedPostRef, error) {
+	if mock.ListScheduledPostRefsFunc == nil {
+		panic("dbmock: unexpected call to ListScheduledPostRefs")
+	}
+	return mock.ListScheduledPostRefsFunc(ctx, afterID, limit)
+}
+
+// ListUserScheduledPostRefs calls ListUserScheduledPostRefsFunc
+func (mock *Store) ListUserScheduledPostRefs(ctx context.Context, userID uuid.UUID) ([]db.QueuedPostRef, error) {
+	if mock.ListUserScheduledPostRefsFunc == nil {
+		panic("dbmock: unexpected call to ListUserScheduledPostRefs")
+	}
+	return mock.ListUserScheduledPostRefsFunc(ctx, userID)
+}
+
+// SetPostWorkflow calls SetPostWorkflowFunc
+func (mock *Store) SetPostWorkflow(ctx context.Context, id uuid.UUID, state *models.WorkflowState, assigneeID *uuid.UUID, unassign bool) (*models.Post, error) {
+	if mock.SetPostWorkflowFunc == nil {
+		panic("dbmock: unexpected call to SetPostWorkflow")
+	}
+	return mock.SetPostWorkflowFunc(ctx, id, state, assigneeID, unassign)
+}
+
+// ApprovePost calls ApprovePostFunc
+func (mock *Store) ApprovePost(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+	if mock.ApprovePostFunc == nil {
+		panic("dbmock: unexpected call to ApprovePost")
+	}
+	return mock.ApprovePostFunc(ctx, id)
+}
+
+// RejectPost calls RejectPostFunc
+func (mock *Store) RejectPost(ctx context.Context, id uuid.UUID, reason string) (*models.Post, error) {
+	if mock.RejectPostFunc == nil {
+		panic("dbmock: unexpected call to RejectPost")
+	}
+	return mock.RejectPostFunc(ctx, id, reason)
+}
+
+// ClaimApprovalReminders calls ClaimApprovalRemindersFunc
+func (mock *Store) ClaimApprovalReminders(ctx context.Context, dueBefore time.Time, limit int) ([]*models.Post, error) {
+	if mock.ClaimApprovalRemindersFunc == nil {
+		panic("dbmock: unexpected call to ClaimApprovalReminders")
+	}
+	return mock.ClaimApprovalRemindersFunc(ctx, dueBefore, limit)
+}
+
+// ClaimApprovalEscalations calls ClaimApprovalEscalationsFunc
+func (mock *Store) ClaimApprovalEscalations(ctx context.Context, dueBefore time.Time, limit int) ([]*models.Post, error) {
+	if mock.ClaimApprovalEscalationsFunc == nil {
+		panic("dbmock: unexpected call to ClaimApprovalEscalations")
+	}
+	return mock.ClaimApprovalEscalationsFunc(ctx, dueBefore, limit)
+}
+
+// GetRecyclablePosts calls GetRecyclablePostsFunc
+func (mock *Store) GetRecyclablePosts(ctx context.Context, limit int) ([]*models.Post, error) {
+	if mock.GetRecyclablePostsFunc == nil {
+		panic("dbmock: unexpected call to GetRecyclablePosts")
+	}
+	return mock.GetRecyclablePostsFunc(ctx, limit)
+}
+
+// RecyclePost calls RecyclePostFunc
+func (mock *Store) RecyclePost(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+	if mock.RecyclePostFunc == nil {
+		panic("dbmock: unexpected call to RecyclePost")
+	}
+	return mock.RecyclePostFunc(ctx, id)
+}
+
+// GetABTestsToStart calls GetABTestsToStartFunc
+func (mock *Store) GetABTestsToStart(ctx context.Context, limit int) ([]*models.Post, error) {
+	if mock.GetABTestsToStartFunc == nil {
+		panic("dbmock: unexpected call to GetABTestsToStart")
+	}
+	return mock.GetABTestsToStartFunc(ctx, limit)
+}
+
+// StartABVariant calls StartABVariantFunc
+func (mock *Store) StartABVariant(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+	if mock.StartABVariantFunc == nil {
+		panic("dbmock: unexpected call to StartABVariant")
+	}
+	return mock.StartABVariantFunc(ctx, id)
+}
+
+// GetABTestsToDecide calls GetABTestsToDecideFunc
+func (mock *Store) GetABTestsToDecide(ctx context.Context, limit int) ([]*models.Post, error) {
+	if mock.GetABTestsToDecideFunc == nil {
+		panic("dbmock: unexpected call to GetABTestsToDecide")
+	}
+	return mock.GetABTestsToDecideFunc(ctx, limit)
+}
+
+// GetABVariant calls GetABVariantFunc
+func (mock *Store) GetABVariant(ctx context.Context, parentID uuid.UUID) (*models.Post, error) {
+	if mock.GetABVariantFunc == nil {
+		panic("dbmock: unexpected call to GetABVariant")
+	}
+	return mock.GetABVariantFunc(ctx, parentID)
+}
+
+// DecideABTest calls DecideABTestFunc
+func (mock *Store) DecideABTest(ctx context.Context, id uuid.UUID, winner models.ABVariant) (bool, error) {
+	if mock.DecideABTestFunc == nil {
+		panic("dbmock: unexpected call to DecideABTest")
+	}
+	return mock.DecideABTestFunc(ctx, id, winner)
+}
+
+// RepostPost calls RepostPostFunc
+func (mock *Store) RepostPost(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+	if mock.RepostPostFunc == nil {
+		panic("dbmock: unexpected call to RepostPost")
+	}
+	return mock.RepostPostFunc(ctx, id)
+}
+
+// SetPostEngagement calls SetPostEngagementFunc
+func (mock *Store) SetPostEngagement(ctx context.Context, id uuid.UUID, e models.Engagement) (*models.Post, error) {
+	if mock.SetPostEngagementFunc == nil {
+		panic("dbmock: unexpected call to SetPostEngagement")
+	}
+	return mock.SetPostEngagementFunc(ctx, id, e)
+}
+
+// CreateOrganization calls CreateOrganizationFunc
+func (mock *Store) CreateOrganization(ctx context.Context, name string, ownerID uuid.UUID) (*models.Organization, error) {
+	if mock.CreateOrganizationFunc == nil {
+		panic("dbmock: unexpected call to CreateOrganization")
+	}
+	return mock.CreateOrganizationFunc(ctx, name, ownerID)
+}
+
+// ListWorkspaces calls ListWorkspacesFunc
+func (mock *Store) ListWorkspaces(ctx context.Context, userID uuid.UUID) ([]models.Workspace, error) {
+	if mock.ListWorkspacesFunc == nil {
+		panic("dbmock: unexpected call to ListWorkspaces")
+	}
+	return mock.ListWorkspacesFunc(ctx, userID)
+}
+
+// GetMemberRole calls GetMemberRoleFunc
+func (mock *Store) GetMemberRole(ctx context.Context, orgID, userID uuid.UUID) (models.OrgRole, error) {
+	if mock.GetMemberRoleFunc == nil {
+		panic("dbmock: unexpected call to GetMemberRole")
+	}
+	return mock.GetMemberRoleFunc(ctx, orgID, userID)
+}
+
+// ListOrganizationMembers calls ListOrganizationMembersFunc
+func (mock *Store) ListOrganizationMembers(ctx context.Context, orgID uuid.UUID) ([]models.OrganizationMember, error) {
+	if mock.ListOrganizationMembersFunc == nil {
+		panic("dbmock: unexpected call to ListOrganizationMembers")
+	}
+	return mock.ListOrganizationMembersFunc(ctx, orgID)
+}
+
+// SetOrganizationMember calls SetOrganizationMemberFunc
+func (mock *Store) SetOrganizationMember(ctx context.Context, orgID, userID uuid.UUID, role models.OrgRole, canOverrideWindows bool) error {
+	if mock.SetOrganizationMemberFunc == nil {
+		panic("dbmock: unexpected call to SetOrganizationMember")
+	}
+	return mock.SetOrganizationMemberFunc(ctx, orgID, userID, role, canOverrideWindows)
+}
+
+// RemoveOrganizationMember calls RemoveOrganizationMemberFunc
+func (mock *Store) RemoveOrganizationMember(ctx context.Context, orgID, userID uuid.UUID) (bool, error) {
+	if mock.RemoveOrganizationMemberFunc == nil {
+		panic("dbmock: unexpected call to RemoveOrganizationMember")
+	}
+	return mock.RemoveOrganizationMemberFunc(ctx, orgID, userID)
+}
+
+// CanOverrideWindows calls CanOverrideWindowsFunc
+func (mock *Store) CanOverrideWindows(ctx context.Context, orgID, userID uuid.UUID) (bool, error) {
+	if mock.CanOverrideWindowsFunc == nil {
+		panic("dbmock: unexpected call to CanOverrideWindows")
+	}
+	return mock.CanOverrideWindowsFunc(ctx, orgID, userID)
+}
+
+// GetPublishingSchedule calls GetPublishingScheduleFunc
+func (mock *Store) GetPublishingSchedule(ctx context.Context, orgID uuid.UUID) (*models.PublishingSchedule, error) {
+	if mock.GetPublishingScheduleFunc == nil {
+		panic("dbmock: unexpected call to GetPublishingSchedule")
+	}
+	return mock.GetPublishingScheduleFunc(ctx, orgID)
+}
+
+// SetPublishingSchedule calls SetPublishingScheduleFunc
+func (mock *Store) SetPublishingSchedule(ctx context.Context, orgID uuid.UUID, s models.PublishingSchedule) error {
+	if mock.SetPublishingScheduleFunc == nil {
+		panic("dbmock: unexpected call to SetPublishingSchedule")
+	}
+	return mock.SetPublishingScheduleFunc(ctx, orgID, s)
+}
+
+// GetBrandChecklist calls GetBrandChecklistFunc
+func (mock *Store) GetBrandChecklist(ctx context.Context, orgID uuid.UUID) (*models.BrandChecklist, error) {
+	if mock.GetBrandChecklistFunc == nil {
+		panic("dbmock: unexpected call to GetBrandChecklist")
+	}
+	return mock.GetBrandChecklistFunc(ctx, orgID)
+}
+
+// SetBrandChecklist calls SetBrandChecklistFunc
+func (mock *Store) SetBrandChecklist(ctx context.Context, orgID uuid.UUID, c models.BrandChecklist) error {
+	if mock.SetBrandChecklistFunc == nil {
+		panic("dbmock: unexpected call to SetBrandChecklist")
+	}
+	return mock.SetBrandChecklistFunc(ctx, orgID, c)
+}
+
+// RecordAuditEntry calls RecordAuditEntryFunc
+func (mock *Store) RecordAuditEntry(ctx context.Context, e db.
+	NewAuditEntry) error {
+	if mock.RecordAuditEntryFunc == nil {
+		panic("dbmock: unexpected call to RecordAuditEntry")
+	}
+	return mock.RecordAuditEntryFunc(ctx, e)
+}
+
+// EachAuditEntry calls EachAuditEntryFunc
+func (mock *Store) EachAuditEntry(ctx context.Context, orgID uuid.UUID, from, to time.Time, fn func(*models.AuditEntry) error) error {
+	if mock.EachAuditEntryFunc == nil {
+		panic("dbmock: unexpected call to EachAuditEntry")
+	}
+	return mock.EachAuditEntryFunc(ctx, orgID, from, to, fn)
+}
+
+// GetRetentionOverrides calls GetRetentionOverridesFunc
+func (mock *Store) GetRetentionOverrides(ctx context.Context, orgID uuid.UUID) (*models.RetentionOverrides, error) {
+	if mock.GetRetentionOverridesFunc == nil {
+		panic("dbmock: unexpected call to GetRetentionOverrides")
+	}
+	return mock.GetRetentionOverridesFunc(ctx, orgID)
+}
+
+// SetRetentionOverrides calls SetRetentionOverridesFunc
+func (mock *Store) SetRetentionOverrides(ctx context.Context, orgID uuid.UUID, o models.RetentionOverrides) error {
+	if mock.SetRetentionOverridesFunc == nil {
+		panic("dbmock: unexpected call to SetRetentionOverrides")
+	}
+	return mock.SetRetentionOverridesFunc(ctx, orgID, o)
+}
+
+// ApplyRetention calls ApplyRetentionFunc
+func (mock *Store) ApplyRetention(ctx context.Context, defaults models.RetentionPolicy, now time.Time, orgID *uuid.UUID, dryRun bool) (models.RetentionReport, error) {
+	if mock.ApplyRetentionFunc == nil {
+		panic("dbmock: unexpected call to ApplyRetention")
+	}
+	return mock.ApplyRetentionFunc(ctx, defaults, now, orgID, dryRun)
+}
+
+// UpsertChannelConnection calls UpsertChannelConnectionFunc
+func (mock *Store) UpsertChannelConnection(ctx context.Context, userID uuid.UUID, channel models.Channel, creds db.
+	ChannelCredentials) (*models.ChannelConnection, error) {
+	if mock.UpsertChannelConnectionFunc == nil {
+		panic("dbmock: unexpected call to UpsertChannelConnection")
+	}
+	return mock.UpsertChannelConnectionFunc(ctx, userID, channel, creds)
+}
+
+// GetChannelConnections calls GetChannelConnectionsFunc
+func (mock *Store) GetChannelConnections(ctx context.Context, userID uuid.UUID) ([]*models.ChannelConnection, error) {
+	if mock.GetChannelConnectionsFunc == nil {
+		panic("dbmock: unexpected call to GetChannelConnections")
+	}
+	return mock.GetChannelConnectionsFunc(ctx, userID)
+}
+
+// GetChannelConnection calls GetChannelConnectionFunc
+func (mock *Store) GetChannelConnection(ctx context.Context, userID uuid.UUID, channel models.Channel) (*models.ChannelConnection, error) {
+	if mock.GetChannelConnectionFunc == nil {
+		panic("dbmock: unexpected call to GetChannelConnection")
+	}
+	return mock.GetChannelConnectionFunc(ctx, userID, channel)
+}
+
+// DeleteChannelConnection calls DeleteChannelConnectionFunc
+func (mock *Store) DeleteChannelConnection(ctx context.Context, userID uuid.UUID, channel models.Channel) (bool, error) {
+	if mock.DeleteChannelConnectionFunc == nil {
+		panic("dbmock: unexpected call to DeleteChannelConnection")
+	}
+	return mock.DeleteChannelConnectionFunc(ctx, userID, channel)
+}
+
+// RecordChannelPublishes calls RecordChannelPublishesFunc
+func (mock *Store) RecordChannelPublishes(ctx context.Context, refs []db.ChannelRef) error {
+	if mock.RecordChannelPublishesFunc == nil {
+		panic("dbmock: unexpected call to RecordChannelPublishes")
+	}
+	return mock.RecordChannelPublishesFunc(ctx, refs)
+}
+
+// RecordChannelError calls RecordChannelErrorFunc
+func (mock *Store) RecordChannelError(ctx context.Context, userID uuid.UUID, channel models.Channel, errorMsg string) error {
+	if mock.RecordChannelErrorFunc == nil {
+		panic("dbmock: unexpected call to RecordChannelError")
+	}
+	return mock.RecordChannelErrorFunc(ctx, userID, channel, errorMsg)
+}
+
+// GetChannelActivity calls GetChannelActivityFunc
+func (mock *Store) GetChannelActivity(ctx context.Context, since time.Time) (map[models.Channel]db.ChannelActivity, error) {
+	if mock.GetChannelActivityFunc == nil {
+		panic("dbmock: unexpected call to GetChannelActivity")
+	}
+	return mock.GetChannelActivityFunc(ctx, since)
+}
+
+// ListChannelPresets calls ListChannelPresetsFunc
+func (mock *Store) ListChannelPresets(ctx context.Context, userID uuid.UUID) ([]*models.ChannelPreset, error) {
+	if mock.ListChannelPresetsFunc == nil {
+		panic("dbmock: unexpected call to ListChannelPresets")
+	}
+	return mock.ListChannelPresetsFunc(ctx, userID)
+}
+
+// UpsertChannelPreset calls UpsertChannelPresetFunc
+func (mock *Store) UpsertChannelPreset(ctx context.Context, userID uuid.UUID, channel models.Channel, req models.UpdateChannelPresetRequest) (*models.ChannelPreset, error) {
+	if mock.UpsertChannelPresetFunc == nil {
+		panic("dbmock: unexpected call to UpsertChannelPreset")
+	}
+	return mock.UpsertChannelPresetFunc(ctx, userID, channel, req)
+}
+
+// GetChannelSignature calls GetChannelSignatureFunc
+func (mock *Store) GetChannelSignature(ctx context.Context, userID uuid.UUID, channel models.Channel) (*string, error) {
+	if mock.GetChannelSignatureFunc == nil {
+		panic("dbmock: unexpected call to GetChannelSignature")
+	}
+	return mock.GetChannelSignatureFunc(ctx, userID, channel)
+}
+
+// DeleteChannelPreset calls DeleteChannelPresetFunc
+func (mock *Store) DeleteChannelPreset(ctx context.Context, userID uuid.UUID, channel models.Channel) (bool, error) {
+	if mock.DeleteChannelPresetFunc == nil {
+		panic("dbmock: unexpected call to DeleteChannelPreset")
+	}
+	return mock.DeleteChannelPresetFunc(ctx, userID, channel)
+}
+
+// GetConnectionsForFollowerPoll calls GetConnectionsForFollowerPollFunc
+func (mock *Store) GetConnectionsForFollowerPoll(ctx context.Context, channels []models.Channel, day time.Time, limit int) ([]*models.ChannelConnection, error) {
+	if mock.GetConnectionsForFollowerPollFunc == nil {
+		panic("dbmock: unexpected call to GetConnectionsForFollowerPoll")
+	}
+	return mock.GetConnectionsForFollowerPollFunc(ctx, channels, day, limit)
+}
+
+// SaveFollowerCounts calls SaveFollowerCountsFunc
+func (mock *Store) SaveFollowerCounts(ctx context.Context, day time.Time, polled []uuid.UUID, counts []models.FollowerCount) error {
+	if mock.SaveFollowerCountsFunc == nil {
+		panic("dbmock: unexpected call to SaveFollowerCounts")
+	}
+	return mock.SaveFollowerCountsFunc(ctx, day, polled, counts)
+}
+
+// ListFollowerCounts calls ListFollowerCountsFunc
+func (mock *Store) ListFollowerCounts(ctx context.Context, userID uuid.UUID, since, until time.Time) ([]models.FollowerCount, error) {
+	if mock.ListFollowerCountsFunc == nil {
+		panic("dbmock: unexpected call to ListFollowerCounts")
+	}
+	return mock.ListFollowerCountsFunc(ctx, userID, since, until)
+}
+
+// CreateComment calls CreateCommentFunc
+func (mock *Store) CreateComment(ctx context.Context, postID, userID uuid.UUID, parentID *uuid.UUID, body string) (*models.Comment, error) {
+	if mock.CreateCommentFunc == nil {
+		panic("dbmock: unexpected call to CreateComment")
+	}
+	return mock.CreateCommentFunc(ctx, postID, userID, parentID, body)
+}
+
+// ListComments calls ListCommentsFunc
+func (mock *Store) ListComments(ctx context.Context, postID uuid.UUID) ([]*models.Comment, error) {
+	if mock.ListCommentsFunc == nil {
+		panic("dbmock: unexpected call to ListComments")
+	}
+	return mock.ListCommentsFunc(ctx, postID)
+}
+
+// CommentExists calls CommentExistsFunc
+func (mock *Store) CommentExists(ctx context.Context, postID, commentID uuid.UUID) (bool, error) {
+	if mock.CommentExistsFunc == nil {
+		panic("dbmock: unexpected call to CommentExists")
+	}
+	return mock.CommentExistsFunc(ctx, postID, commentID)
+}
+
+// DeleteComment calls DeleteCommentFunc
+func (mock *Store) DeleteComment(ctx context.Context, postID, commentID, userID uuid.UUID) (bool, error) {
+	if mock.DeleteCommentFunc == nil {
+		panic("dbmock: unexpected call to DeleteComment")
+	}
+	return mock.DeleteCommentFunc(ctx, postID, commentID, userID)
+}
+
+// CreateMedia calls CreateMediaFunc
+func (mock *Store) CreateMedia(ctx context.Context, m *models.Media) (*models.Media, error) {
+	if mock.CreateMediaFunc == nil {
+		panic("dbmock: unexpected call to CreateMedia")
+	}
+	return mock.CreateMediaFunc(ctx, m)
+}
+
+// GetMediaByIDs calls GetMediaByIDsFunc
+func (mock *Store) GetMediaByIDs(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Media, error) {
+	if mock.GetMediaByIDsFunc == nil {
+		panic("dbmock: unexpected call to GetMediaByIDs")
+	}
+	return mock.GetMediaByIDsFunc(ctx, userID, ids)
+}
+
+// ListMedia calls ListMediaFunc
+func (mock *Store) ListMedia(ctx context.Context, userID uuid.UUID) ([]*models.Media, error) {
+	if mock.ListMediaFunc == nil {
+		panic("dbmock: unexpected call to ListMedia")
+	}
+	return mock.ListMediaFunc(ctx, userID)
+}
+
+// UpdateMediaAltText calls UpdateMediaAltTextFunc
+func (mock *Store) UpdateMediaAltText(ctx context.Context, userID, id uuid.UUID, altText *string) (*models.Media, error) {
+	if mock.UpdateMediaAltTextFunc == nil {
+		panic("dbmock: unexpected call to UpdateMediaAltText")
+	}
+	return mock.UpdateMediaAltTextFunc(ctx, userID, id, altText)
+}
+
+// SetMediaRenditions calls SetMediaRenditionsFunc
+func (mock *Store) SetMediaRenditions(ctx context.Context, id uuid.UUID, renditions []models.MediaRendition) error {
+	if mock.SetMediaRenditionsFunc == nil {
+		panic("dbmock: unexpected call to SetMediaRenditions")
+	}
+	return mock.SetMediaRenditionsFunc(ctx, id, renditions)
+}
+
+// ListMediaFolders calls ListMediaFoldersFunc
+func (mock *Store) ListMediaFolders(ctx context.Context, orgID uuid.UUID) ([]*models.MediaFolder, error) {
+	if mock.ListMediaFoldersFunc == nil {
+		panic("dbmock: unexpected call to ListMediaFolders")
+	}
+	return mock.ListMediaFoldersFunc(ctx, orgID)
+}
+
+// CreateMediaFolder calls CreateMediaFolderFunc
+func (mock *Store) CreateMediaFolder(ctx context.Context, orgID, userID uuid.UUID, name string) (*models.MediaFolder, error) {
+	if mock.CreateMediaFolderFunc == nil {
+		panic("dbmock: unexpected call to CreateMediaFolder")
+	}
+	return mock.CreateMediaFolderFunc(ctx, orgID, userID, name)
+}
+
+// DeleteMediaFolder calls DeleteMediaFolderFunc
+func (mock *Store) DeleteMediaFolder(ctx context.Context, orgID, id uuid.UUID) (bool, error) {
+	if mock.DeleteMediaFolderFunc == nil {
+		panic("dbmock: unexpected call to DeleteMediaFolder")
+	}
+	return mock.DeleteMediaFolderFunc(ctx, orgID, id)
+}
+
+// ListLibraryMedia calls ListLibraryMediaFunc
+func (mock *Store) ListLibraryMedia(ctx context.Context, orgID uuid.UUID, filter models.LibraryFilter) ([]*models.LibraryMedia, error) {
+	if mock.ListLibraryMediaFunc == nil {
+		panic("dbmock: unexpected call to ListLibraryMedia")
+	}
+	return mock.ListLibraryMediaFunc(ctx, orgID, filter)
+}
+
+// FileLibraryMedia calls FileLibraryMediaFunc
+func (mock *Store) FileLibraryMedia(ctx context.Context, userID, orgID uuid.UUID, req models.FileLibraryMediaRequest) (*models.Media, error) {
+	if mock.FileLibraryMediaFunc == nil {
+		panic("dbmock: unexpected call to FileLibraryMedia")
+	}
+	return mock.FileLibraryMediaFunc(ctx, userID, orgID, req)
+}
+
+// RemoveLibraryMedia calls RemoveLibraryMediaFunc
+func (mock *Store) RemoveLibraryMedia(ctx context.Context, userID, orgID, id uuid.UUID, manage bool) (bool, error) {
+	if mock.RemoveLibraryMediaFunc == nil {
+		panic("dbmock: unexpected call to RemoveLibraryMedia")
+	}
+	return mock.RemoveLibraryMediaFunc(ctx, userID, orgID, id, manage)
+}
+
+// GetPostsForInboxSync calls GetPostsForInboxSyncFunc
+func (mock *Store) GetPostsForInboxSync(ctx context.Context, channels []models.Channel, publishedSince, syncedBefore time.Time, limit int) ([]*models.Post, error) {
+	if mock.GetPostsForInboxSyncFunc == nil {
+		panic("dbmock: unexpected call to GetPostsForInboxSync")
+	}
+	return mock.GetPostsForInboxSyncFunc(ctx, channels, publishedSince, syncedBefore, limit)
+}
+
+// MarkInboxSynced calls MarkInboxSyncedFunc
+func (mock *Store) MarkInboxSynced(ctx context.Context, postIDs []uuid.UUID, at time.Time) error {
+	if mock.MarkInboxSyncedFunc == nil {
+		panic("dbmock: unexpected call to MarkInboxSynced")
+	}
+	return mock.MarkInboxSyncedFunc(ctx, postIDs, at)
+}
+
+// AddInboxItems calls AddInboxItemsFunc
+func (mock *Store) AddInboxItems(ctx context.Context, items []models.InboxItem) (int, error) {
+	if mock.AddInboxItemsFunc == nil {
+		panic("dbmock: unexpected call to AddInboxItems")
+	}
+	return mock.AddInboxItemsFunc(ctx, items)
+}
+
+// ListInboxItems calls ListInboxItemsFunc
+func (mock *Store) ListInboxItems(ctx context.Context, userID uuid.UUID, filter db.
+	InboxFilter) ([]*models.InboxItem, error) {
+	if mock.ListInboxItemsFunc == nil {
+		panic("dbmock: unexpected call to ListInboxItems")
+	}
+	return mock.ListInboxItemsFunc(ctx, userID, filter)
+}
+
+// CountUnreadInbox calls CountUnreadInboxFunc
+func (mock *Store) CountUnreadInbox(ctx context.Context, userID uuid.UUID) (int, error) {
+	if mock.CountUnreadInboxFunc == nil {
+		panic("dbmock: unexpected call to CountUnreadInbox")
+	}
+	return mock.CountUnreadInboxFunc(ctx, userID)
+}
+
+// MarkInboxItems calls MarkInboxItemsFunc
+func (mock *Store) MarkInboxItems(ctx context.Context, userID uuid.UUID, ids []uuid.UUID, read bool) (int, error) {
+	if mock.MarkInboxItemsFunc == nil {
+		panic("dbmock: unexpected call to MarkInboxItems")
+	}
+	return mock.MarkInboxItemsFunc(ctx, userID, ids, read)
+}
+
+// MarkAllInboxRead calls MarkAllInboxReadFunc
+func (mock *Store) MarkAllInboxRead(ctx context.Context, userID uuid.UUID) (int, error) {
+	if mock.MarkAllInboxReadFunc == nil {
+		panic("dbmock: unexpected call to MarkAllInboxRead")
+	}
+	return mock.MarkAllInboxReadFunc(ctx, userID)
+}
+
+// ListKeywordMonitors calls ListKeywordMonitorsFunc
+func (mock *Store) ListKeywordMonitors(ctx context.Context, userID uuid.UUID) ([]*models.KeywordMonitor, error) {
+	if mock.ListKeywordMonitorsFunc == nil {
+		panic("dbmock: unexpected call to ListKeywordMonitors")
+	}
+	return mock.ListKeywordMonitorsFunc(ctx, userID)
+}
+
+// CreateKeywordMonitor calls CreateKeywordMonitorFunc
+func (mock *Store) CreateKeywordMonitor(ctx context.Context, userID uuid.UUID, req models.CreateKeywordMonitorRequest) (*models.KeywordMonitor, error) {
+	if mock.CreateKeywordMonitorFunc == nil {
+		panic("dbmock: unexpected call to CreateKeywordMonitor")
+	}
+	return mock.CreateKeywordMonitorFunc(ctx, userID, req)
+}
+
+// DeleteKeywordMonitor calls DeleteKeywordMonitorFunc
+func (mock *Store) DeleteKeywordMonitor(ctx context.Context, userID, id uuid.UUID) (bool, error) {
+	if mock.DeleteKeywordMonitorFunc == nil {
+		panic("dbmock: unexpected call to DeleteKeywordMonitor")
+	}
+	return mock.DeleteKeywordMonitorFunc(ctx, userID, id)
+}
+
+// GetKeywordMonitorsToPoll calls GetKeywordMonitorsToPollFunc
+func (mock *Store) GetKeywordMonitorsToPoll(ctx context.Context, polledBefore time.Time, limit int) ([]*models.KeywordMonitor, error) {
+	if mock.GetKeywordMonitorsToPollFunc == nil {
+		panic("dbmock: unexpected call to GetKeywordMonitorsToPoll")
+	}
+	return mock.GetKeywordMonitorsToPollFunc(ctx, polledBefore, limit)
+}
+
+// MarkKeywordMonitorsPolled calls MarkKeywordMonitorsPolledFunc
+func (mock *Store) MarkKeywordMonitorsPolled(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	if mock.MarkKeywordMonitorsPolledFunc == nil {
+		panic("dbmock: unexpected call to MarkKeywordMonitorsPolled")
+	}
+	return mock.MarkKeywordMonitorsPolledFunc(ctx, ids, at)
+}
+
+// SaveUsageDay calls SaveUsageDayFunc
+func (mock *Store) SaveUsageDay(ctx context.Context, day time.Time, counts map[uuid.UUID]models.UsageCounts) error {
+	if mock.SaveUsageDayFunc == nil {
+		panic("dbmock: unexpected call to SaveUsageDay")
+	}
+	return mock.SaveUsageDayFunc(ctx, day, counts)
+}
+
+// ListUsage calls ListUsageFunc
+func (mock *Store) ListUsage(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.UsageDay, error) {
+	if mock.ListUsageFunc == nil {
+		panic("dbmock: unexpected call to ListUsage")
+	}
+	return mock.ListUsageFunc(ctx, userID, since)
+}
+
+// RollupSystemMetrics calls RollupSystemMetricsFunc
+func (mock *Store) RollupSystemMetrics(ctx context.Context, hourSince, daySince time.Time) error {
+	if mock.RollupSystemMetricsFunc == nil {
+		panic("dbmock: unexpected call to RollupSystemMetrics")
+	}
+	return mock.RollupSystemMetricsFunc(ctx, hourSince, daySince)
+}
+
+// ListSystemMetrics calls ListSystemMetricsFunc
+func (mock *Store) ListSystemMetrics(ctx context.Context, since, until time.Time) ([]models.MetricSeries, error) {
+	if mock.ListSystemMetricsFunc == nil {
+		panic("dbmock: unexpected call to ListSystemMetrics")
+	}
+	return mock.ListSystemMetricsFunc(ctx, since, until)
+}
+
+// GetUsersForMonthlyReport calls GetUsersForMonthlyReportFunc
+func (mock *Store) GetUsersForMonthlyReport(ctx context.Context, month time.Time, limit int) ([]*models.User, error) {
+	if mock.GetUsersForMonthlyReportFunc == nil {
+		panic("dbmock: unexpected call to GetUsersForMonthlyReport")
+	}
+	return mock.GetUsersForMonthlyReportFunc(ctx, month, limit)
+}
+
+// MarkMonthlyReportSent calls MarkMonthlyReportSentFunc
+func (mock *Store) MarkMonthlyReportSent(ctx context.Context, userID uuid.UUID, month time.Time) error {
+	if mock.MarkMonthlyReportSentFunc == nil {
+		panic("dbmock: unexpected call to MarkMonthlyReportSent")
+	}
+	return mock.MarkMonthlyReportSentFunc(ctx, userID, month)
+}
+
+// ListBioLinks calls ListBioLinksFunc
+func (mock *Store) ListBioLinks(ctx context.Context, userID uuid.UUID) ([]*models.BioLink, error) {
+	if mock.ListBioLinksFunc == nil {
+		panic("dbmock: unexpected call to ListBioLinks")
+	}
+	return mock.ListBioLinksFunc(ctx, userID)
+}
+
+// CreateBioLink calls CreateBioLinkFunc
+func (mock *Store) CreateBioLink(ctx context.Context, userID uuid.UUID, req models.CreateBioLinkRequest) (*models.BioLink, error) {
+	if mock.CreateBioLinkFunc == nil {
+		panic("dbmock: unexpected call to CreateBioLink")
+	}
+	return mock.CreateBioLinkFunc(ctx, userID, req)
+}
+
+// ReorderBioLinks calls ReorderBioLinksFunc
+func (mock *Store) ReorderBioLinks(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (bool, error) {
+	if mock.ReorderBioLinksFunc == nil {
+		panic("dbmock: unexpected call to ReorderBioLinks")
+	}
+	return mock.ReorderBioLinksFunc(ctx, userID, ids)
+}
+
+// DeleteBioLink calls DeleteBioLinkFunc
+func (mock *Store) DeleteBioLink(ctx context.Context, userID, id uuid.UUID) (bool, error) {
+	if mock.DeleteBioLinkFunc == nil {
+		panic("dbmock: unexpected call to DeleteBioLink")
+	}
+	return mock.DeleteBioLinkFunc(ctx, userID, id)
+}
+
+// RecordBioClick calls RecordBioClickFunc
+func (mock *Store) RecordBioClick(ctx context.Context, userID, id uuid.UUID) (*string, error) {
+	if mock.RecordBioClickFunc == nil {
+		panic("dbmock: unexpected call to RecordBioClick")
+	}
+	return mock.RecordBioClickFunc(ctx, userID, id)
+}
+
+// Ping calls PingFunc
+func (mock *Store) Ping(ctx context.Context) error {
+	if mock.PingFunc == nil {
+		panic("dbmock: unexpected call to Ping")
+	}
+	return mock.PingFunc(ctx)
+}
+
+// ListTenants calls ListTenantsFunc
+func (mock *Store) ListTenants(ctx context.Context) ([]*models.Tenant, error) {
+	if mock.ListTenantsFunc == nil {
+		panic("dbmock: unexpected call to ListTenants")
+	}
+	return mock.ListTenantsFunc(ctx)
+}
@@ -0,0 +1,192 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Inbox operations
+
+// inboxColumnSet is selected for every inbox query
+var inboxColumnSet = columns(
+	col("id", func(i *models.InboxItem) any { return &i.ID }),
+	col("user_id", func(i *models.InboxItem) any { return &i.UserID }),
+	col("post_id", func(i *models.InboxItem) any { return &i.PostID }),
+	col("monitor_id", func(i *models.InboxItem) any { return &i.MonitorID }),
+	col("channel", func(i *models.InboxItem) any { return &i.Channel }),
+	col("kind", func(i *models.InboxItem) any { return &i.Kind }),
+	col("external_id", func(i *models.InboxItem) any { return &i.ExternalID }),
+	col("author", func(i *models.InboxItem) any { return &i.Author }),
+	col("content", func(i *models.InboxItem) any { return &i.Content }),
+	col("url", func(i *models.InboxItem) any { return &i.URL }),
+	col("received_at", func(i *models.InboxItem) any { return &i.ReceivedAt }),
+	col("read_at", func(i *models.InboxItem) any { return &i.ReadAt }),
+	col("created_at", func(i *models.InboxItem) any { return &i.CreatedAt }),
+)
+
+// inboxColumns is the column list selected for every inbox query
+var inboxColumns = inboxColumnSet.list
+
+// InboxFilter narrows an inbox listing
+type InboxFilter struct {
+	UnreadOnly bool
+	Channel    *models.Channel
+	Kind       *models.InboxKind
+
+	After *models.PostCursor // Only items after this position, see models.InboxCursor
+	Limit int                // At most this many items; zero for all
+}
+
+// GetPostsForInboxSync returns published posts on the given channels that
+// were published since publishedSince and haven't had their replies pulled
+// since syncedBefore, least recently synced first
+func (db *DB) GetPostsForInboxSync(ctx context.Context, channels []models.Channel, publishedSince, syncedBefore time.Time, limit int) ([]*models.Post, error) {
+	names := make([]string, len(channels))
+	for i, c := range channels {
+		names[i] = string(c)
+	}
+
+	rows, err := db.pool.Query(ctx, `
+		SELECT `+postColumns+`
+		FROM posts
+		WHERE status = 'published' AND published_at >= $2
+			AND channel = ANY($1::channel_type[])
+			AND (inbox_synced_at IS NULL OR inbox_synced_at < $3)
+		ORDER BY inbox_synced_at ASC NULLS FIRST, published_at DESC
+		LIMIT $4
+	`, names, publishedSince, syncedBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPosts(rows)
+}
+
+// MarkInboxSynced records that the posts' replies were pulled at the given time
+func (db *DB) MarkInboxSynced(ctx context.Context, postIDs []uuid.UUID, at time.Time) error {
+	_, err := db.pool.Exec(ctx, `
+		UPDATE posts SET inbox_synced_at = $2 WHERE id = ANY($1)
+	`, postIDs, at)
+	return err
+}
+
+// AddInboxItems stores items pulled from platforms, skipping replies already
+// in the inbox and keyword matches the user already has, and returns how many
+// were new
+func (db *DB) AddInboxItems(ctx context.Context, items []models.InboxItem) (int, error) {
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	n := len(items)
+	userIDs, postIDs, monitorIDs := make([]uuid.UUID, n), make([]*uuid.UUID, n), make([]*uuid.UUID, n)
+	channels, kinds, externalIDs := make([]string, n), make([]string, n), make([]string, n)
+	authors, contents, urls := make([]string, n), make([]string, n), make([]*string, n)
+	receivedAt := make([]time.Time, n)
+	for i, item := range items {
+		userIDs[i], postIDs[i], monitorIDs[i] = item.UserID, item.PostID, item.MonitorID
+		channels[i], kinds[i], externalIDs[i] = string(item.Channel), string(item.Kind), item.ExternalID
+		authors[i], contents[i], urls[i] = item.Author, item.Content, item.URL
+		receivedAt[i] = item.ReceivedAt
+	}
+
+	result, err := db.pool.Exec(ctx, `
+		INSERT INTO inbox_items (user_id, post_id, monitor_id, channel, kind, external_id, author, content, url, received_at)
+		SELECT i.user_id, i.post_id, i.monitor_id, i.channel::channel_type, i.kind, i.external_id, i.author, i.content, i.url, i.received_at
+		FROM unnest($1::uuid[], $2::uuid[], $3::uuid[], $4::text[], $5::text[], $6::text[], $7::text[], $8::text[], $9::text[], $10::timestamptz[])
+			AS i(user_id, post_id, monitor_id, channel, kind, external_id, author, content, url, received_at)
+		ON CONFLICT DO NOTHING
+	`, userIDs, postIDs, monitorIDs, channels, kinds, externalIDs, authors, contents, urls, receivedAt)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// ListInboxItems returns a user's inbox, newest first
+func (db *DB) ListInboxItems(ctx context.Context, userID uuid.UUID, filter InboxFilter) ([]*models.InboxItem, error) {
+	args := []interface{}{userID}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := []string{"user_id = $1"}
+	if filter.UnreadOnly {
+		conditions = append(conditions, "read_at IS NULL")
+	}
+	if filter.Channel != nil {
+		conditions = append(conditions, "channel = "+arg(*filter.Channel))
+	}
+	if filter.Kind != nil {
+		conditions = append(conditions, "kind = "+arg(*filter.Kind))
+	}
+	if filter.After != nil {
+		conditions = append(conditions, fmt.Sprintf("(received_at, id) < (%s, %s)", arg(filter.After.ScheduledAt), arg(filter.After.ID)))
+	}
+
+	query := `
+		SELECT ` + inboxColumns + `
+		FROM inbox_items
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY received_at DESC, id DESC`
+	if filter.Limit > 0 {
+		query += " LIMIT " + arg(filter.Limit)
+	}
+
+	rows, err := db.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*models.InboxItem
+	for rows.Next() {
+		item, err := inboxColumnSet.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// CountUnreadInbox returns how many of a user's inbox items are unread
+func (db *DB) CountUnreadInbox(ctx context.Context, userID uuid.UUID) (int, error) {
+	var n int
+	err := db.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM inbox_items WHERE user_id = $1 AND read_at IS NULL
+	`, userID).Scan(&n)
+	return n, err
+}
+
+// MarkInboxItems marks the given items of a user's inbox read or unread,
+// returning how many changed
+func (db *DB) MarkInboxItems(ctx context.Context, userID uuid.UUID, ids []uuid.UUID, read bool) (int, error) {
+	result, err := db.pool.Exec(ctx, `
+		UPDATE inbox_items SET
+			read_at = CASE WHEN $3::boolean THEN NOW() END
+		WHERE user_id = $1 AND id = ANY($2) AND (read_at IS NULL) = $3
+	`, userID, ids, read)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// MarkAllInboxRead marks every unread item of a user's inbox read, returning
+// how many changed
+func (db *DB) MarkAllInboxRead(ctx context.Context, userID uuid.UUID) (int, error) {
+	result, err := db.pool.Exec(ctx, `
+		UPDATE inbox_items SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL
+	`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
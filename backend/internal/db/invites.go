@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/tenant"
+)
+
+// Invite operations
+
+// inviteColumnSet is selected for every invite query
+var inviteColumnSet = columns(
+	col("id", func(i *models.Invite) any { return &i.ID }),
+	col("max_uses", func(i *models.Invite) any { return &i.MaxUses }),
+	col("uses", func(i *models.Invite) any { return &i.Uses }),
+	col("expires_at", func(i *models.Invite) any { return &i.ExpiresAt }),
+	col("created_by", func(i *models.Invite) any { return &i.CreatedBy }),
+	col("created_at", func(i *models.Invite) any { return &i.CreatedAt }),
+)
+
+// inviteColumns is the column list selected for every invite query
+var inviteColumns = inviteColumnSet.list
+
+// scanInvite scans a row selected with inviteColumns
+func scanInvite(row pgx.Row) (*models.Invite, error) {
+	return inviteColumnSet.scan(row)
+}
+
+// CreateInvite stores a new invite in the context's tenant under the hash of its code
+func (db *DB) CreateInvite(ctx context.Context, codeHash string, maxUses int, expiresAt *time.Time, createdBy uuid.UUID) (*models.Invite, error) {
+	return scanInvite(db.pool.QueryRow(ctx, `
+		INSERT INTO invites (tenant_id, code_hash, max_uses, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+inviteColumns,
+		tenant.IDFromContext(ctx), codeHash, maxUses, expiresAt, createdBy))
+}
+
+// ListInvites returns the context tenant's invites, newest first
+func (db *DB) ListInvites(ctx context.Context) ([]*models.Invite, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT `+inviteColumns+`
+		FROM invites
+		WHERE tenant_id = $1
+		ORDER BY created_at DESC
+	`, tenant.IDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invites []*models.Invite
+	for rows.Next() {
+		i, err := scanInvite(rows)
+		if err != nil {
+			return nil, err
+		}
+		invites = append(invites, i)
+	}
+	return invites, rows.Err()
+}
+
+// DeleteInvite revokes an invite in the context's tenant; users who
+// registered with it are kept. Reports whether it existed.
+func (db *DB) DeleteInvite(ctx context.Context, id uuid.UUID) (bool, error) {
+	result, err := db.pool.Exec(ctx, `
+		DELETE FROM invites WHERE id = $1 AND tenant_id = $2
+	`, id, tenant.IDFromContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// CreateUserWithInvite redeems the invite whose code hashes to codeHash and
+// creates the user in one statement, so a failed signup doesn't use up the
+// invite. Returns nil if the invite doesn't exist, has expired or is used up.
+func (db *DB) CreateUserWithInvite(ctx context.Context, email, passwordHash, codeHash string) (*models.User, error) {
+	return scanUser(db.pool.QueryRow(ctx, `
+		WITH invite AS (
+			UPDATE invites SET uses = uses + 1
+			WHERE tenant_id = $3 AND code_hash = $4 AND uses < max_uses
+				AND (expires_at IS NULL OR expires_at > NOW())
+			RETURNING id
+		)
+		INSERT INTO users (email, password_hash, tenant_id, invite_id)
+		SELECT $1, $2, $3, id FROM invite
+		RETURNING `+userColumns,
+		email, passwordHash, tenant.IDFromContext(ctx), codeHash))
+}
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Media library operations
+
+// mediaFolderColumns is the column list selected for every media folder
+// query, counting the media filed in each
+const mediaFolderColumns = `f.id, f.org_id, f.name,
+	(SELECT COUNT(*) FROM media m WHERE m.folder_id = f.id AND m.org_id = f.org_id),
+	f.created_by, f.created_at`
+
+// scanMediaFolder scans a row selected with mediaFolderColumns
+func scanMediaFolder(row pgx.Row) (*models.MediaFolder, error) {
+	f := &models.MediaFolder{}
+	if err := row.Scan(&f.ID, &f.OrgID, &f.Name, &f.MediaCount, &f.CreatedBy, &f.CreatedAt); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ListMediaFolders retrieves an organization's library folders by name
+func (db *DB) ListMediaFolders(ctx context.Context, orgID uuid.UUID) ([]*models.MediaFolder, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT `+mediaFolderColumns+`
+		FROM media_folders f
+		WHERE f.org_id = $1
+		ORDER BY lower(f.name), f.id
+	`, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var folders []*models.MediaFolder
+	for rows.Next() {
+		f, err := scanMediaFolder(rows)
+		if err != nil {
+			return nil, err
+		}
+		folders = append(folders, f)
+	}
+	return folders, rows.Err()
+}
+
+// CreateMediaFolder adds a folder to an organization's library, or returns
+// the folder with the same name. It returns nil if the library already has
+// models.MaxMediaFolders others.
+func (db *DB) CreateMediaFolder(ctx context.Context, orgID, userID uuid.UUID, name string) (*models.MediaFolder, error) {
+	f, err := scanMediaFolder(db.pool.QueryRow(ctx, `
+		WITH f AS (
+			INSERT INTO media_folders (org_id, name, created_by)
+			SELECT $1, $2, $3
+			WHERE (SELECT COUNT(*) FROM media_folders WHERE org_id = $1 AND name <> $2) < $4
+			ON CONFLICT (org_id, name) DO UPDATE SET name = EXCLUDED.name
+			RETURNING *
+		)
+		SELECT `+mediaFolderColumns+` FROM f
+	`, orgID, name, userID, models.MaxMediaFolders))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return f, err
+}
+
+// DeleteMediaFolder removes a library folder, moving its media to the top of
+// the library
+func (db *DB) DeleteMediaFolder(ctx context.Context, orgID, id uuid.UUID) (bool, error) {
+	result, err := db.pool.Exec(ctx, `
+		DELETE FROM media_folders WHERE id = $1 AND org_id = $2
+	`, id, orgID)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ListLibraryMedia retrieves the media shared to an organization's library,
+// newest first, with the number of posts each is attached to
+func (db *DB) ListLibraryMedia(ctx context.Context, orgID uuid.UUID, filter models.LibraryFilter) ([]*models.LibraryMedia, error) {
+	args := []any{orgID}
+	where := "org_id = $1"
+	if filter.FolderID != nil {
+		args = append(args, *filter.FolderID)
+		where += fmt.Sprintf(" AND folder_id = $%d", len(args))
+	}
+	if filter.Query != "" {
+		args = append(args, filter.Query)
+		where += fmt.Sprintf(" AND (strpos(lower(coalesce(name, '')), lower($%[1]d)) > 0 OR strpos(lower(coalesce(alt_text, '')), lower($%[1]d)) > 0)", len(args))
+	}
+
+	rows, err := db.pool.Query(ctx, `
+		SELECT `+mediaColumns+`,
+			(SELECT COUNT(*) FROM posts p WHERE p.media @> jsonb_build_array(jsonb_build_object('media_id', media.id)))
+		FROM media
+		WHERE `+where+`
+		ORDER BY created_at DESC, id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*models.LibraryMedia
+	for rows.Next() {
+		item := &models.LibraryMedia{}
+		if err := rows.Scan(append(mediaColumnSet.dest(&item.Media), &item.UsageCount)...); err != nil {
+			return nil, err
+		}
+		item.URL = models.MediaURL(item.StorageKey)
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// FileLibraryMedia files media in a folder of an organization's library
+// (nil for the top). Members may share their own uploads that aren't in
+// another library, and move anything already in this one. It returns nil if
+// the media or folder isn't found.
+func (db *DB) FileLibraryMedia(ctx context.Context, userID, orgID uuid.UUID, req models.FileLibraryMediaRequest) (*models.Media, error) {
+	m, err := scanMedia(db.pool.QueryRow(ctx, `
+		UPDATE media SET
+			org_id = $3,
+			folder_id = $4,
+			updated_at = NOW()
+		WHERE id = $1
+		  AND ((user_id = $2 AND org_id IS NULL) OR org_id = $3)
+		  AND ($4::uuid IS NULL OR EXISTS (SELECT 1 FROM media_folders WHERE id = $4 AND org_id = $3))
+		RETURNING `+mediaColumns,
+		req.MediaID, userID, orgID, req.FolderID))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return m, err
+}
+
+// RemoveLibraryMedia takes media out of an organization's library. Posts it
+// is attached to keep it; only new attachments are prevented. Unless manage
+// is set, only media the user uploaded is removed.
+func (db *DB) RemoveLibraryMedia(ctx context.Context, userID, orgID, id uuid.UUID, manage bool) (bool, error) {
+	result, err := db.pool.Exec(ctx, `
+		UPDATE media SET
+			org_id = NULL,
+			folder_id = NULL,
+			updated_at = NOW()
+		WHERE id = $1 AND org_id = $2 AND ($4 OR user_id = $3)
+	`, id, orgID, userID, manage)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
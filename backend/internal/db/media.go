@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Media operations
+
+// mediaColumnSet is selected for every media query
+var mediaColumnSet = columns(
+	col("id", func(m *models.Media) any { return &m.ID }),
+	col("user_id", func(m *models.Media) any { return &m.UserID }),
+	col("name", func(m *models.Media) any { return &m.Name }),
+	col("org_id", func(m *models.Media) any { return &m.OrgID }),
+	col("folder_id", func(m *models.Media) any { return &m.FolderID }),
+	col("storage_key", func(m *models.Media) any { return &m.StorageKey }),
+	col("content_type", func(m *models.Media) any { return &m.ContentType }),
+	col("width", func(m *models.Media) any { return &m.Width }),
+	col("height", func(m *models.Media) any { return &m.Height }),
+	col("duration_ms", func(m *models.Media) any { return &m.DurationMs }),
+	col("size_bytes", func(m *models.Media) any { return &m.SizeBytes }),
+	col("alt_text", func(m *models.Media) any { return &m.AltText }),
+	col("renditions", func(m *models.Media) any { return &m.Renditions }),
+	col("created_at", func(m *models.Media) any { return &m.CreatedAt }),
+	col("updated_at", func(m *models.Media) any { return &m.UpdatedAt }),
+)
+
+// mediaColumns is the column list selected for every media query
+var mediaColumns = mediaColumnSet.list
+
+// scanMedia scans a row selected with mediaColumns into a media item
+func scanMedia(row pgx.Row) (*models.Media, error) {
+	m, err := mediaColumnSet.scan(row)
+	if err != nil {
+		return nil, err
+	}
+	m.URL = models.MediaURL(m.StorageKey)
+	return m, nil
+}
+
+// CreateMedia records an uploaded media file
+func (db *DB) CreateMedia(ctx context.Context, m *models.Media) (*models.Media, error) {
+	return scanMedia(db.pool.QueryRow(ctx, `
+		INSERT INTO media (id, user_id, name, storage_key, content_type, width, height, duration_ms, size_bytes, alt_text)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING `+mediaColumns,
+		m.ID, m.UserID, m.Name, m.StorageKey, m.ContentType, m.Width, m.Height, m.DurationMs, m.SizeBytes, m.AltText))
+}
+
+// GetMediaByIDs retrieves the given media items a user may attach, keyed by
+// ID: their own uploads and those shared to the libraries of organizations
+// they belong to
+func (db *DB) GetMediaByIDs(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Media, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT `+mediaColumns+`
+		FROM media
+		WHERE id = ANY($2)
+		  AND (user_id = $1 OR org_id IN (SELECT org_id FROM organization_members WHERE user_id = $1))
+	`, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make(map[uuid.UUID]*models.Media, len(ids))
+	for rows.Next() {
+		m, err := scanMedia(rows)
+		if err != nil {
+			return nil, err
+		}
+		items[m.ID] = m
+	}
+
+	return items, rows.Err()
+}
+
+// ListMedia retrieves a user's uploaded media, newest first
+func (db *DB) ListMedia(ctx context.Context, userID uuid.UUID) ([]*models.Media, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT `+mediaColumns+`
+		FROM media
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*models.Media
+	for rows.Next() {
+		m, err := scanMedia(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, m)
+	}
+
+	return items, rows.Err()
+}
+
+// UpdateMediaAltText sets the default alt text of a user's media item
+func (db *DB) UpdateMediaAltText(ctx context.Context, userID, id uuid.UUID, altText *string) (*models.Media, error) {
+	m, err := scanMedia(db.pool.QueryRow(ctx, `
+		UPDATE media SET
+			alt_text = $3,
+			updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+mediaColumns,
+		id, userID, altText))
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return m, err
+}
+
+// SetMediaRenditions records the renditions transcoded from a video
+func (db *DB) SetMediaRenditions(ctx context.Context, id uuid.UUID, renditions []models.MediaRendition) error {
+	_, err := db.pool.Exec(ctx, `
+		UPDATE media SET
+			renditions = $2,
+			updated_at = NOW()
+		WHERE id = $1
+	`, id, renditions)
+	return err
+}
@@ -0,0 +1,197 @@
+package db
+
+import (
+	"bufio"
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// MigrationPhase is when a migration runs relative to rolling out the code
+// that needs it. Pre-deploy migrations expand the schema (new tables,
+// nullable or defaulted columns) so old and new code both work against it;
+// post-deploy migrations contract it (dropping or renaming what only old code
+// used) once no instance runs the old code.
+type MigrationPhase string
+
+const (
+	MigrationPreDeploy  MigrationPhase = "pre-deploy"
+	MigrationPostDeploy MigrationPhase = "post-deploy"
+)
+
+// migrationPhaseHeader marks a migration's phase in a leading comment line,
+// e.g. "-- phase: post-deploy". Migrations without one are pre-deploy.
+const migrationPhaseHeader = "-- phase:"
+
+// Migration is an embedded schema migration
+type Migration struct {
+	Version string         `json:"version"`
+	Phase   MigrationPhase `json:"phase"`
+	Applied bool           `json:"applied"`
+	sql     string
+}
+
+// Migrations returns the embedded migrations in version order
+func Migrations() ([]*Migration, error) {
+	return readMigrations(migrationsFS)
+}
+
+// readMigrations reads the *.up.sql files in fsys's migrations directory
+func readMigrations(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var migrations []*Migration
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".up.sql") {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, "migrations/"+entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+		phase, err := migrationPhase(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", entry.Name(), err)
+		}
+		migrations = append(migrations, &Migration{
+			Version: strings.TrimSuffix(entry.Name(), ".up.sql"),
+			Phase:   phase,
+			sql:     string(content),
+		})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// migrationPhase reads the phase header from a migration's leading comments
+func migrationPhase(sql string) (MigrationPhase, error) {
+	scanner := bufio.NewScanner(strings.NewReader(sql))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "--") {
+			break
+		}
+		if value, ok := strings.CutPrefix(line, migrationPhaseHeader); ok {
+			switch phase := MigrationPhase(strings.TrimSpace(value)); phase {
+			case MigrationPreDeploy, MigrationPostDeploy:
+				return phase, nil
+			default:
+				return "", fmt.Errorf("unknown phase %q, must be pre-deploy or post-deploy", phase)
+			}
+		}
+	}
+	return MigrationPreDeploy, nil
+}
+
+// PlanMigrations returns the unapplied migrations to run in phase:
+// pre-deploy runs pending pre-deploy migrations, post-deploy runs everything
+// pending. A pre-deploy run fails if a post-deploy migration is pending ahead
+// of one it would apply, as the previous release must finish contracting the
+// schema before the next one expands it.
+func PlanMigrations(migrations []*Migration, phase MigrationPhase) ([]*Migration, error) {
+	if phase != MigrationPreDeploy && phase != MigrationPostDeploy {
+		return nil, fmt.Errorf("unknown phase %q, must be pre-deploy or post-deploy", phase)
+	}
+
+	var plan []*Migration
+	var blocking *Migration
+	for _, m := range migrations {
+		if m.Applied {
+			continue
+		}
+		if phase == MigrationPreDeploy && m.Phase == MigrationPostDeploy {
+			if blocking == nil {
+				blocking = m
+			}
+			continue
+		}
+		if blocking != nil {
+			return nil, fmt.Errorf("post-deploy migration %s must be applied before pre-deploy migration %s", blocking.Version, m.Version)
+		}
+		plan = append(plan, m)
+	}
+	return plan, nil
+}
+
+// MigrationStatus returns the embedded migrations, marking those applied
+func (db *DB) MigrationStatus(ctx context.Context) ([]*Migration, error) {
+	// Create migrations table if not exists
+	_, err := db.pool.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version VARCHAR(255) PRIMARY KEY,
+			applied_at TIMESTAMPTZ DEFAULT NOW()
+		)
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.pool.Query(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to check migration status: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]bool)
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, m := range migrations {
+		m.Applied = applied[m.Version]
+	}
+	return migrations, nil
+}
+
+// RunMigrations runs the database migrations pending in phase, returning
+// those applied
+func (db *DB) RunMigrations(ctx context.Context, phase MigrationPhase) ([]*Migration, error) {
+	migrations, err := db.MigrationStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := PlanMigrations(migrations, phase)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, m := range plan {
+		if _, err := db.pool.Exec(ctx, m.sql); err != nil {
+			return plan[:i], fmt.Errorf("failed to run migration %s: %w", m.Version, err)
+		}
+
+		// Mark as applied
+		if _, err := db.pool.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", m.Version); err != nil {
+			return plan[:i], fmt.Errorf("failed to record migration %s: %w", m.Version, err)
+		}
+		m.Applied = true
+
+		fmt.Printf("Applied %s migration: %s\n", m.Phase, m.Version)
+	}
+
+	return plan, nil
+}
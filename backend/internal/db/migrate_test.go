@@ -0,0 +1,157 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMigrationPhase(t *testing.T) {
+	tests := []struct {
+		name    string
+		sql     string
+		want    MigrationPhase
+		wantErr bool
+	}{
+		{"no header", "ALTER TABLE posts ADD COLUMN x TEXT;", MigrationPreDeploy, false},
+		{"post-deploy", "-- phase: post-deploy\nALTER TABLE posts DROP COLUMN x;", MigrationPostDeploy, false},
+		{"after other comments", "\n-- Drop the old column\n--phase:   pre-deploy\nSELECT 1;", MigrationPreDeploy, false},
+		{"header after statements", "SELECT 1;\n-- phase: post-deploy", MigrationPreDeploy, false},
+		{"unknown", "-- phase: later\nSELECT 1;", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := migrationPhase(tt.sql)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("migrationPhase() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("migrationPhase() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("Migrations() error = %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("Migrations() returned none")
+	}
+	for i, m := range migrations {
+		if i > 0 && migrations[i-1].Version >= m.Version {
+			t.Errorf("migration %s is out of order", m.Version)
+		}
+	}
+}
+
+func TestPlanMigrations(t *testing.T) {
+	m := func(version string, phase MigrationPhase, applied bool) *Migration {
+		return &Migration{Version: version, Phase: phase, Applied: applied}
+	}
+	versions := func(plan []*Migration) string {
+		var v []string
+		for _, m := range plan {
+			v = append(v, m.Version)
+		}
+		return strings.Join(v, ",")
+	}
+
+	tests := []struct {
+		name       string
+		migrations []*Migration
+		phase      MigrationPhase
+		want       string
+		wantErr    bool
+	}{
+		{
+			"pre-deploy skips trailing contract",
+			[]*Migration{m("1", MigrationPreDeploy, true), m("2", MigrationPreDeploy, false), m("3", MigrationPostDeploy, false)},
+			MigrationPreDeploy, "2", false,
+		},
+		{
+			"post-deploy runs everything pending",
+			[]*Migration{m("1", MigrationPreDeploy, true), m("2", MigrationPreDeploy, false), m("3", MigrationPostDeploy, false)},
+			MigrationPostDeploy, "2,3", false,
+		},
+		{
+			"pre-deploy blocked by earlier contract",
+			[]*Migration{m("1", MigrationPostDeploy, false), m("2", MigrationPreDeploy, false)},
+			MigrationPreDeploy, "", true,
+		},
+		{
+			"applied contract doesn't block",
+			[]*Migration{m("1", MigrationPostDeploy, true), m("2", MigrationPreDeploy, false)},
+			MigrationPreDeploy, "2", false,
+		},
+		{
+			"nothing pending",
+			[]*Migration{m("1", MigrationPreDeploy, true)},
+			MigrationPostDeploy, "", false,
+		},
+		{
+			"unknown phase",
+			nil,
+			"later", "", true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := PlanMigrations(tt.migrations, tt.phase)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlanMigrations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := versions(plan); got != tt.want {
+				t.Errorf("PlanMigrations() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadMigrations_PostDeploySkippedBeforeDeploy(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/001_create_posts.up.sql":        {Data: []byte("CREATE TABLE posts (id UUID, body TEXT);")},
+		"migrations/001_create_posts.down.sql":      {Data: []byte("DROP TABLE posts;")},
+		"migrations/002_add_content.up.sql":         {Data: []byte("-- Replaces body\nALTER TABLE posts ADD COLUMN content TEXT;")},
+		"migrations/003_drop_body.up.sql":           {Data: []byte("-- phase: post-deploy\nALTER TABLE posts DROP COLUMN body;")},
+		"migrations/003_drop_body.down.sql":         {Data: []byte("ALTER TABLE posts ADD COLUMN body TEXT;")},
+		"migrations/004_add_content_index.up.sql":   {Data: []byte("CREATE INDEX ON posts (content);")},
+		"migrations/004_add_content_index.down.sql": {Data: []byte("DROP INDEX posts_content_idx;")},
+	}
+	migrations, err := readMigrations(fsys)
+	if err != nil {
+		t.Fatalf("readMigrations() error = %v", err)
+	}
+	if len(migrations) != 4 || migrations[2].Version != "003_drop_body" || migrations[2].Phase != MigrationPostDeploy {
+		t.Fatalf("readMigrations() = %+v, want 003_drop_body third and post-deploy", migrations)
+	}
+
+	// Before the deploy, the expansion runs and the contraction waits
+	migrations[0].Applied = true
+	plan, err := PlanMigrations(migrations[:3], MigrationPreDeploy)
+	if err != nil {
+		t.Fatalf("PlanMigrations(pre-deploy) error = %v", err)
+	}
+	if len(plan) != 1 || plan[0].Version != "002_add_content" {
+		t.Errorf("PlanMigrations(pre-deploy) = %+v, want only 002_add_content", plan)
+	}
+
+	// After it, the contraction runs
+	migrations[1].Applied = true
+	plan, err = PlanMigrations(migrations[:3], MigrationPostDeploy)
+	if err != nil {
+		t.Fatalf("PlanMigrations(post-deploy) error = %v", err)
+	}
+	if len(plan) != 1 || plan[0].Version != "003_drop_body" {
+		t.Errorf("PlanMigrations(post-deploy) = %+v, want only 003_drop_body", plan)
+	}
+
+	// The next release's expansion waits for the contraction
+	if _, err := PlanMigrations(migrations, MigrationPreDeploy); err == nil {
+		t.Error("PlanMigrations(pre-deploy) with 003 pending before 004 error = nil, want error")
+	}
+}
@@ -0,0 +1,3 @@
+-- Remove avatar columns from users table
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_updated_at;
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_key;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_posts_channel_updated;
+DROP TABLE IF EXISTS channel_connections;
@@ -0,0 +1,17 @@
+-- Create channel connections table (one connected account per user and channel)
+CREATE TABLE IF NOT EXISTS channel_connections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel channel_type NOT NULL,
+    account_name VARCHAR(255),
+    access_token TEXT NOT NULL,
+    token_expires_at TIMESTAMPTZ,
+    last_error TEXT,
+    last_published_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (user_id, channel)
+);
+
+-- Index for system-wide platform health queries
+CREATE INDEX IF NOT EXISTS idx_posts_channel_updated ON posts(channel, updated_at);
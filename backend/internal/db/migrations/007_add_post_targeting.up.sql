@@ -0,0 +1,2 @@
+-- Add optional audience targeting metadata to posts
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS targeting JSONB;
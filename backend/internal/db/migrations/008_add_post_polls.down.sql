@@ -0,0 +1,3 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS poll;
+ALTER TABLE posts DROP COLUMN IF EXISTS post_type;
+DROP TYPE IF EXISTS post_type;
@@ -0,0 +1,6 @@
+-- Create post type enum
+CREATE TYPE post_type AS ENUM ('text', 'poll');
+
+-- Add post type and structured poll data to posts
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS post_type post_type NOT NULL DEFAULT 'text';
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS poll JSONB;
@@ -0,0 +1,2 @@
+-- Add optional place/geo tag to posts
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS location JSONB;
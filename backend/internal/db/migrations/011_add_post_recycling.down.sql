@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_posts_recyclable;
+ALTER TABLE posts DROP COLUMN IF EXISTS recycled_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS recycled_from_id;
+ALTER TABLE posts DROP COLUMN IF EXISTS recycle_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS recycle;
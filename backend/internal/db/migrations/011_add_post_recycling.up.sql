@@ -0,0 +1,9 @@
+-- Evergreen recycling: published posts are copied and rescheduled after an interval
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS recycle JSONB;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS recycle_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS recycled_from_id UUID REFERENCES posts(id) ON DELETE SET NULL;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS recycled_at TIMESTAMPTZ;
+
+-- Speeds up the worker's scan for published posts waiting to be recycled
+CREATE INDEX IF NOT EXISTS idx_posts_recyclable ON posts(published_at)
+    WHERE status = 'published' AND recycle IS NOT NULL AND recycled_at IS NULL;
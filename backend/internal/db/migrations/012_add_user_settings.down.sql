@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_posts_user_channel_scheduled;
+ALTER TABLE users DROP COLUMN IF EXISTS conflict_window_minutes;
@@ -0,0 +1,6 @@
+-- Per-user scheduling preferences
+ALTER TABLE users ADD COLUMN IF NOT EXISTS conflict_window_minutes INTEGER NOT NULL DEFAULT 15;
+
+-- Speeds up conflict lookups for a channel around a time
+CREATE INDEX IF NOT EXISTS idx_posts_user_channel_scheduled ON posts(user_id, channel, scheduled_at)
+    WHERE status = 'scheduled';
@@ -0,0 +1,3 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS priority;
+ALTER TABLE users DROP COLUMN IF EXISTS plan;
+DROP TYPE IF EXISTS user_plan;
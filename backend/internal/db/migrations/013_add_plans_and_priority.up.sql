@@ -0,0 +1,7 @@
+-- Subscription plans; paid plans get the priority publishing lane
+CREATE TYPE user_plan AS ENUM ('free', 'pro');
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS plan user_plan NOT NULL DEFAULT 'free';
+
+-- Posts in the priority lane are claimed ahead of others when both are due
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS priority BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_posts_org_status;
+ALTER TABLE posts DROP COLUMN IF EXISTS org_id;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
+DROP TYPE IF EXISTS org_role;
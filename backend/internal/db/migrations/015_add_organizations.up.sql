@@ -0,0 +1,24 @@
+-- Organizations let teams share a workspace of posts
+CREATE TYPE org_role AS ENUM ('owner', 'admin', 'member');
+
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE organization_members (
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role org_role NOT NULL DEFAULT 'member',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (org_id, user_id)
+);
+
+CREATE INDEX idx_organization_members_user_id ON organization_members(user_id);
+
+-- Posts created in an organization workspace; NULL for personal posts
+ALTER TABLE posts ADD COLUMN org_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_posts_org_status ON posts(org_id, status) WHERE org_id IS NOT NULL;
@@ -0,0 +1,5 @@
+-- Enum values can't be dropped, so pending and rejected posts are marked failed
+UPDATE posts SET status = 'failed' WHERE status IN ('pending_approval', 'rejected');
+
+ALTER TABLE posts DROP COLUMN IF EXISTS approval_escalated_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS approval_reminded_at;
@@ -0,0 +1,8 @@
+-- Posts by organization members wait for an owner or admin to approve them
+ALTER TYPE post_status ADD VALUE IF NOT EXISTS 'pending_approval';
+ALTER TYPE post_status ADD VALUE IF NOT EXISTS 'rejected';
+
+-- When approvers were last reminded about, and escalated, a pending post
+ALTER TABLE posts
+    ADD COLUMN approval_reminded_at TIMESTAMPTZ,
+    ADD COLUMN approval_escalated_at TIMESTAMPTZ;
@@ -0,0 +1,4 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS window_override;
+ALTER TABLE organization_members DROP COLUMN IF EXISTS can_override_windows;
+ALTER TABLE organizations DROP COLUMN IF EXISTS publishing_windows;
+ALTER TABLE organizations DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,8 @@
+-- Organization publishing windows: posts may only publish inside them unless
+-- scheduled by a member allowed to override
+ALTER TABLE organizations ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+ALTER TABLE organizations ADD COLUMN publishing_windows JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE organization_members ADD COLUMN can_override_windows BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE posts ADD COLUMN window_override BOOLEAN NOT NULL DEFAULT FALSE;
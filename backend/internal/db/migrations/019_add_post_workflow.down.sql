@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_posts_assignee_id;
+ALTER TABLE posts DROP COLUMN IF EXISTS assignee_id;
+ALTER TABLE posts DROP COLUMN IF EXISTS workflow_state;
+DROP TYPE IF EXISTS workflow_state;
@@ -0,0 +1,7 @@
+-- Editorial workflow, tracked separately from the publish status
+CREATE TYPE workflow_state AS ENUM ('idea', 'drafting', 'review', 'approved');
+
+ALTER TABLE posts ADD COLUMN workflow_state workflow_state NOT NULL DEFAULT 'drafting';
+ALTER TABLE posts ADD COLUMN assignee_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_posts_assignee_id ON posts(assignee_id) WHERE assignee_id IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN IF EXISTS inbound_webhook_token_hash;
@@ -0,0 +1,3 @@
+-- Per-user inbound webhook token for creating posts from automations.
+-- Only the SHA-256 hash of the token is stored.
+ALTER TABLE users ADD COLUMN inbound_webhook_token_hash VARCHAR(64) UNIQUE;
@@ -0,0 +1,2 @@
+-- Per-user public feed token; only the SHA-256 hash of the token is stored
+ALTER TABLE users ADD COLUMN feed_token_hash VARCHAR(64) UNIQUE;
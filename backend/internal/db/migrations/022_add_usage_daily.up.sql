@@ -0,0 +1,9 @@
+-- Daily per-user usage, rolled up from the Redis counters
+CREATE TABLE usage_daily (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    api_requests BIGINT NOT NULL DEFAULT 0,
+    publishes BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (user_id, day)
+);
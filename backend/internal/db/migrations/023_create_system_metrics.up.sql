@@ -0,0 +1,28 @@
+-- System-wide time series for admin analytics, rolled up from posts and users
+CREATE TABLE system_metrics (
+    metric TEXT NOT NULL,
+    granularity TEXT NOT NULL CHECK (granularity IN ('hour', 'day')),
+    bucket TIMESTAMPTZ NOT NULL,
+    value BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (metric, granularity, bucket)
+);
+
+CREATE INDEX IF NOT EXISTS idx_posts_created_at ON posts(created_at);
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at);
+
+-- Backfill from existing data; the worker's rollup keeps the series current
+INSERT INTO system_metrics (metric, granularity, bucket, value)
+SELECT 'posts_created', 'hour', date_trunc('hour', created_at), COUNT(*) FROM posts GROUP BY 3;
+
+INSERT INTO system_metrics (metric, granularity, bucket, value)
+SELECT 'posts_published', 'hour', date_trunc('hour', published_at), COUNT(*) FROM posts
+WHERE status = 'published' AND published_at IS NOT NULL GROUP BY 3;
+
+INSERT INTO system_metrics (metric, granularity, bucket, value)
+SELECT 'posts_failed', 'hour', date_trunc('hour', updated_at), COUNT(*) FROM posts
+WHERE status = 'failed' AND updated_at IS NOT NULL GROUP BY 3;
+
+INSERT INTO system_metrics (metric, granularity, bucket, value)
+SELECT 'signups', 'day', date_trunc('day', created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC', COUNT(*) FROM users
+WHERE created_at IS NOT NULL GROUP BY 3;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_posts_ab_parent;
+ALTER TABLE posts DROP COLUMN IF EXISTS engagement;
+ALTER TABLE posts DROP COLUMN IF EXISTS ab_parent_id;
+ALTER TABLE posts DROP COLUMN IF EXISTS ab_variant;
+ALTER TABLE posts DROP COLUMN IF EXISTS ab_test;
@@ -0,0 +1,8 @@
+-- A/B tests: variant A carries the test settings and variant B links back to it
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS ab_test JSONB;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS ab_variant TEXT CHECK (ab_variant IN ('a', 'b'));
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS ab_parent_id UUID REFERENCES posts(id) ON DELETE SET NULL;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS engagement JSONB;
+
+-- At most one variant B per test
+CREATE UNIQUE INDEX IF NOT EXISTS idx_posts_ab_parent ON posts(ab_parent_id) WHERE ab_parent_id IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN IF EXISTS reminder_webhook_url;
+ALTER TABLE posts DROP COLUMN IF EXISTS remind_before_minutes;
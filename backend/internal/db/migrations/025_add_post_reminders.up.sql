@@ -0,0 +1,3 @@
+-- Optional reminder before a post publishes, and where to send reminder webhooks
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS remind_before_minutes INTEGER;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS reminder_webhook_url TEXT;
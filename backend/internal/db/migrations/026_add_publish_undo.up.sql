@@ -0,0 +1,2 @@
+-- End of the undo window of a post that has come due; publishing waits until then
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS undo_until TIMESTAMPTZ;
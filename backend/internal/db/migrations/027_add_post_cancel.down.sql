@@ -0,0 +1,4 @@
+-- Enum values can't be dropped, so canceled posts are marked failed
+UPDATE posts SET status = 'failed' WHERE status = 'canceled';
+
+ALTER TABLE posts DROP COLUMN IF EXISTS canceled_at;
@@ -0,0 +1,4 @@
+-- Canceled posts are kept, unlike deleted ones, so there is a record of them
+ALTER TYPE post_status ADD VALUE IF NOT EXISTS 'canceled';
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS canceled_at TIMESTAMPTZ;
@@ -0,0 +1,3 @@
+-- Routes of notification events to email, webhook and in-app; events left
+-- out use their default route
+ALTER TABLE users ADD COLUMN IF NOT EXISTS notification_preferences JSONB NOT NULL DEFAULT '{}';
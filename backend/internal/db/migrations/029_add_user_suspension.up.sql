@@ -0,0 +1,3 @@
+-- Suspended accounts are read-only and their posts don't publish
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspension_reason TEXT;
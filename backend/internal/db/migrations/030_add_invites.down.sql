@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS invite_id;
+
+DROP TABLE IF EXISTS invites;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_posts_org_upcoming_keyset;
+DROP INDEX IF EXISTS idx_posts_upcoming_keyset;
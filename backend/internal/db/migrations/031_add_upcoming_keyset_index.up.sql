@@ -0,0 +1,4 @@
+-- Upcoming posts are listed in (scheduled_at, id) order and paged by keyset,
+-- so these indexes let a page start at its cursor and stop at its limit
+CREATE INDEX IF NOT EXISTS idx_posts_upcoming_keyset ON posts(user_id, status, scheduled_at, id) WHERE org_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_posts_org_upcoming_keyset ON posts(org_id, status, scheduled_at, id) WHERE org_id IS NOT NULL;
@@ -0,0 +1,12 @@
+-- Per-user defaults applied to new posts on each channel; at most one preset
+-- per user is the default channel
+CREATE TABLE IF NOT EXISTS channel_presets (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel channel_type NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    targeting JSONB,
+    hashtags TEXT[] NOT NULL DEFAULT '{}',
+    footer TEXT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, channel)
+);
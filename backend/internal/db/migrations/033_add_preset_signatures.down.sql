@@ -0,0 +1,2 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS no_signature;
+ALTER TABLE channel_presets DROP COLUMN IF EXISTS signature;
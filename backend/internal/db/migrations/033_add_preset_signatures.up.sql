@@ -0,0 +1,3 @@
+-- Signatures are appended to posts as they publish, unless a post opts out
+ALTER TABLE channel_presets ADD COLUMN IF NOT EXISTS signature TEXT;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS no_signature BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE channel_connections DROP COLUMN IF EXISTS refresh_token;
+-- Postgres can't drop an enum value, so 'reddit' stays in channel_type
@@ -0,0 +1,5 @@
+-- Reddit submissions, connected through OAuth
+ALTER TYPE channel_type ADD VALUE IF NOT EXISTS 'reddit';
+
+-- OAuth connections renew short lived access tokens with a refresh token
+ALTER TABLE channel_connections ADD COLUMN IF NOT EXISTS refresh_token TEXT;
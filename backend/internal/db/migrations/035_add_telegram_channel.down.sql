@@ -0,0 +1,2 @@
+ALTER TABLE channel_connections DROP COLUMN IF EXISTS target;
+-- Postgres can't drop an enum value, so 'telegram' stays in channel_type
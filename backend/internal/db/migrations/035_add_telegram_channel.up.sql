@@ -0,0 +1,6 @@
+-- Telegram channels, published to with the user's own bot
+ALTER TYPE channel_type ADD VALUE IF NOT EXISTS 'telegram';
+
+-- Where on the platform a connection publishes, for channels whose credentials
+-- don't say, such as the chat a Telegram bot posts to
+ALTER TABLE channel_connections ADD COLUMN IF NOT EXISTS target TEXT;
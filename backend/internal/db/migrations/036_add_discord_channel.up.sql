@@ -0,0 +1,2 @@
+-- Discord channels, posted to through a webhook or a bot
+ALTER TYPE channel_type ADD VALUE IF NOT EXISTS 'discord';
@@ -0,0 +1 @@
+-- Postgres can't drop an enum value, so 'webhook' stays in channel_type
@@ -0,0 +1,2 @@
+-- Webhook channels, delivered to the user's own HTTPS endpoint
+ALTER TYPE channel_type ADD VALUE IF NOT EXISTS 'webhook';
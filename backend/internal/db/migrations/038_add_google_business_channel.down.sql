@@ -0,0 +1 @@
+-- Postgres can't drop an enum value, so 'google_business' stays in channel_type
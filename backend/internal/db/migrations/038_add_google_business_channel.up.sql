@@ -0,0 +1,2 @@
+-- Google Business Profile channels, connected through Google OAuth
+ALTER TYPE channel_type ADD VALUE IF NOT EXISTS 'google_business';
@@ -0,0 +1,3 @@
+ALTER TABLE media DROP COLUMN IF EXISTS renditions;
+ALTER TABLE media DROP COLUMN IF EXISTS duration_ms;
+-- Postgres can't drop an enum value, so 'youtube' stays in channel_type
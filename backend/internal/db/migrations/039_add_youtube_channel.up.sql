@@ -0,0 +1,6 @@
+-- YouTube channels: community posts and Shorts
+ALTER TYPE channel_type ADD VALUE IF NOT EXISTS 'youtube';
+
+-- Video uploads: their length, and the renditions transcoded from them
+ALTER TABLE media ADD COLUMN IF NOT EXISTS duration_ms INTEGER;
+ALTER TABLE media ADD COLUMN IF NOT EXISTS renditions JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1 @@
+-- Postgres can't drop an enum value, so 'tiktok' stays in channel_type
@@ -0,0 +1,2 @@
+-- TikTok channels: videos through the Content Posting API
+ALTER TYPE channel_type ADD VALUE IF NOT EXISTS 'tiktok';
@@ -0,0 +1,2 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS inbox_synced_at;
+DROP TABLE IF EXISTS inbox_items;
@@ -0,0 +1,22 @@
+-- Unified inbox: replies and mentions pulled from platforms on published posts
+CREATE TABLE IF NOT EXISTS inbox_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    channel channel_type NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('reply', 'mention')),
+    external_id TEXT NOT NULL,
+    author TEXT NOT NULL,
+    content TEXT NOT NULL,
+    url TEXT,
+    received_at TIMESTAMPTZ NOT NULL,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (post_id, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_inbox_items_user ON inbox_items(user_id, received_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_inbox_items_unread ON inbox_items(user_id) WHERE read_at IS NULL;
+
+-- When each published post's replies were last pulled
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS inbox_synced_at TIMESTAMPTZ;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_inbox_items_keyword_dedupe;
+DELETE FROM inbox_items WHERE monitor_id IS NOT NULL;
+ALTER TABLE inbox_items DROP CONSTRAINT IF EXISTS inbox_items_source_check;
+ALTER TABLE inbox_items DROP CONSTRAINT IF EXISTS inbox_items_kind_check;
+ALTER TABLE inbox_items ADD CONSTRAINT inbox_items_kind_check CHECK (kind IN ('reply', 'mention'));
+ALTER TABLE inbox_items DROP COLUMN IF EXISTS monitor_id;
+ALTER TABLE inbox_items ALTER COLUMN post_id SET NOT NULL;
+
+DROP TABLE IF EXISTS keyword_monitors;
@@ -0,0 +1,22 @@
+-- Keywords and handles users monitor on platforms; matches land in the inbox
+CREATE TABLE IF NOT EXISTS keyword_monitors (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    query TEXT NOT NULL,
+    channels TEXT[] NOT NULL,
+    last_polled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (user_id, query)
+);
+
+CREATE INDEX IF NOT EXISTS idx_keyword_monitors_polled ON keyword_monitors(last_polled_at NULLS FIRST);
+
+-- Keyword matches belong to a monitor rather than a post
+ALTER TABLE inbox_items ALTER COLUMN post_id DROP NOT NULL;
+ALTER TABLE inbox_items ADD COLUMN IF NOT EXISTS monitor_id UUID REFERENCES keyword_monitors(id) ON DELETE CASCADE;
+ALTER TABLE inbox_items DROP CONSTRAINT IF EXISTS inbox_items_kind_check;
+ALTER TABLE inbox_items ADD CONSTRAINT inbox_items_kind_check CHECK (kind IN ('reply', 'mention', 'keyword'));
+ALTER TABLE inbox_items ADD CONSTRAINT inbox_items_source_check CHECK ((post_id IS NULL) <> (monitor_id IS NULL));
+
+-- A platform post matching several monitors shows up once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_inbox_items_keyword_dedupe ON inbox_items(user_id, channel, external_id) WHERE monitor_id IS NOT NULL;
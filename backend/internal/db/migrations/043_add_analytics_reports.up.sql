@@ -0,0 +1,2 @@
+-- The first day of the last month whose analytics report was emailed
+ALTER TABLE users ADD COLUMN IF NOT EXISTS analytics_report_month DATE;
@@ -0,0 +1,2 @@
+ALTER TABLE channel_connections DROP COLUMN IF EXISTS followers_polled_on;
+DROP TABLE IF EXISTS follower_counts;
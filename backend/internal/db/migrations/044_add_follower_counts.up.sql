@@ -0,0 +1,13 @@
+-- Daily follower counts of users' connected accounts, one per channel per day
+CREATE TABLE IF NOT EXISTS follower_counts (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel channel_type NOT NULL,
+    day DATE NOT NULL,
+    account_name TEXT,
+    followers BIGINT NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, channel, day)
+);
+
+-- The last day (UTC) each connection's follower count was polled
+ALTER TABLE channel_connections ADD COLUMN IF NOT EXISTS followers_polled_on DATE;
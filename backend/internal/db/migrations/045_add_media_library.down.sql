@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_posts_media;
+DROP INDEX IF EXISTS idx_media_org_id;
+
+ALTER TABLE media DROP COLUMN IF EXISTS folder_id;
+ALTER TABLE media DROP COLUMN IF EXISTS org_id;
+ALTER TABLE media DROP COLUMN IF EXISTS name;
+
+DROP TABLE IF EXISTS media_folders;
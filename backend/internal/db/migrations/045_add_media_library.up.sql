@@ -0,0 +1,20 @@
+-- Folders of an organization's shared media library
+CREATE TABLE IF NOT EXISTS media_folders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (org_id, name)
+);
+
+-- Uploads shared to an organization's library, and where they are filed.
+-- Deleting a folder moves its media back to the top of the library.
+ALTER TABLE media ADD COLUMN IF NOT EXISTS name VARCHAR(255);
+ALTER TABLE media ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations(id) ON DELETE SET NULL;
+ALTER TABLE media ADD COLUMN IF NOT EXISTS folder_id UUID REFERENCES media_folders(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_media_org_id ON media(org_id, created_at DESC) WHERE org_id IS NOT NULL;
+
+-- Usage counts look up the posts an item is attached to
+CREATE INDEX IF NOT EXISTS idx_posts_media ON posts USING GIN (media jsonb_path_ops);
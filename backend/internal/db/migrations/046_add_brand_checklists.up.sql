@@ -0,0 +1,2 @@
+-- Checks an organization's posts must pass before they can be scheduled
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS brand_checklist JSONB NOT NULL DEFAULT '{"checks": [], "banned_words": []}';
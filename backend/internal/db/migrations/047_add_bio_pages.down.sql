@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bio_links;
+ALTER TABLE users DROP COLUMN IF EXISTS bio_token_hash;
@@ -0,0 +1,17 @@
+-- Public link-in-bio pages, authenticated by the token in the URL
+ALTER TABLE users ADD COLUMN IF NOT EXISTS bio_token_hash VARCHAR(64) UNIQUE;
+
+-- Links shown on a user's bio page, optionally for one of their published
+-- posts, with the clicks counted through the page's redirect
+CREATE TABLE IF NOT EXISTS bio_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    post_id UUID REFERENCES posts(id) ON DELETE CASCADE,
+    title VARCHAR(100) NOT NULL,
+    url TEXT NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    clicks BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bio_links_user_id ON bio_links(user_id, position);
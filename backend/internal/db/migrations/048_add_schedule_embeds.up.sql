@@ -0,0 +1,2 @@
+-- Public embeds of users' upcoming schedules, authenticated by the token in the URL
+ALTER TABLE users ADD COLUMN IF NOT EXISTS embed_token_hash VARCHAR(64) UNIQUE;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_posts_failed_updated_at;
+ALTER TABLE organizations DROP COLUMN IF EXISTS retention;
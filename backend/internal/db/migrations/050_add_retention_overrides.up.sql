@@ -0,0 +1,5 @@
+-- Organization overrides of the default retention rules
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS retention JSONB NOT NULL DEFAULT '{}';
+
+-- Retention looks up failed posts by when they last changed
+CREATE INDEX IF NOT EXISTS idx_posts_failed_updated_at ON posts(updated_at) WHERE status = 'failed';
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Keyword monitor operations
+
+// monitorColumns is the column list selected for every keyword monitor query
+const monitorColumns = `id, user_id, query, channels, last_polled_at, created_at`
+
+// scanMonitor scans a row selected with monitorColumns
+func scanMonitor(row pgx.Row) (*models.KeywordMonitor, error) {
+	m := &models.KeywordMonitor{}
+	var channels []string
+	if err := row.Scan(&m.ID, &m.UserID, &m.Query, &channels, &m.LastPolledAt, &m.CreatedAt); err != nil {
+		return nil, err
+	}
+	m.Channels = make([]models.Channel, len(channels))
+	for i, c := range channels {
+		m.Channels[i] = models.Channel(c)
+	}
+	return m, nil
+}
+
+// scanMonitors scans every row selected with monitorColumns and closes rows
+func scanMonitors(rows pgx.Rows) ([]*models.KeywordMonitor, error) {
+	defer rows.Close()
+
+	var monitors []*models.KeywordMonitor
+	for rows.Next() {
+		m, err := scanMonitor(rows)
+		if err != nil {
+			return nil, err
+		}
+		monitors = append(monitors, m)
+	}
+	return monitors, rows.Err()
+}
+
+// ListKeywordMonitors retrieves a user's keyword monitors, oldest first
+func (db *DB) ListKeywordMonitors(ctx context.Context, userID uuid.UUID) ([]*models.KeywordMonitor, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT `+monitorColumns+`
+		FROM keyword_monitors
+		WHERE user_id = $1
+		ORDER BY created_at, id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanMonitors(rows)
+}
+
+// CreateKeywordMonitor adds a keyword monitor for a user, or updates the
+// channels of the one with the same query. It returns nil if the user
+// already has models.MaxKeywordMonitors others.
+func (db *DB) CreateKeywordMonitor(ctx context.Context, userID uuid.UUID, req models.CreateKeywordMonitorRequest) (*models.KeywordMonitor, error) {
+	channels := make([]string, len(req.Channels))
+	for i, c := range req.Channels {
+		channels[i] = string(c)
+	}
+
+	m, err := scanMonitor(db.pool.QueryRow(ctx, `
+		INSERT INTO keyword_monitors (user_id, query, channels)
+		SELECT $1, $2, $3
+		WHERE (SELECT COUNT(*) FROM keyword_monitors WHERE user_id = $1 AND query <> $2) < $4
+		ON CONFLICT (user_id, query) DO UPDATE SET channels = EXCLUDED.channels
+		RETURNING `+monitorColumns+`
+	`, userID, req.Query, channels, models.MaxKeywordMonitors))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return m, err
+}
+
+// DeleteKeywordMonitor removes a user's keyword monitor and its matches
+func (db *DB) DeleteKeywordMonitor(ctx context.Context, userID, id uuid.UUID) (bool, error) {
+	result, err := db.pool.Exec(ctx, `
+		DELETE FROM keyword_monitors WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// GetKeywordMonitorsToPoll returns keyword monitors not polled since
+// polledBefore, least recently polled first
+func (db *DB) GetKeywordMonitorsToPoll(ctx context.Context, polledBefore time.Time, limit int) ([]*models.KeywordMonitor, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT `+monitorColumns+`
+		FROM keyword_monitors
+		WHERE last_polled_at IS NULL OR last_polled_at < $1
+		ORDER BY last_polled_at ASC NULLS FIRST
+		LIMIT $2
+	`, polledBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanMonitors(rows)
+}
+
+// MarkKeywordMonitorsPolled records that the monitors were polled at the
+// given time
+func (db *DB) MarkKeywordMonitorsPolled(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	_, err := db.pool.Exec(ctx, `
+		UPDATE keyword_monitors SET last_polled_at = $2 WHERE id = ANY($1)
+	`, ids, at)
+	return err
+}
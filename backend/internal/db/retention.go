@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Retention operations
+
+// GetRetentionOverrides returns the organization's overrides of the default
+// retention rules, or nil if there is no such organization
+func (db *DB) GetRetentionOverrides(ctx context.Context, orgID uuid.UUID) (*models.RetentionOverrides, error) {
+	o := &models.RetentionOverrides{}
+	err := db.pool.QueryRow(ctx, `
+		SELECT retention FROM organizations WHERE id = $1
+	`, orgID).Scan(o)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// SetRetentionOverrides replaces the organization's retention overrides
+func (db *DB) SetRetentionOverrides(ctx context.Context, orgID uuid.UUID, o models.RetentionOverrides) error {
+	_, err := db.pool.Exec(ctx, `
+		UPDATE organizations SET retention = $2 WHERE id = $1
+	`, orgID, o)
+	return err
+}
+
+// retentionRules selects what each retention rule applies to. Each query
+// selects the IDs of the rows due as of $1 under the default policy value $2,
+// or the value of the row's organization's override; $3 optionally restricts
+// them to one organization. Audit entries are due while they still have an
+// actor or any of the personal details named in $4.
+var retentionRules = struct{ failedPosts, auditEntries string }{
+	failedPosts: `
+		SELECT p.id FROM posts p
+		LEFT JOIN organizations o ON o.id = p.org_id
+		CROSS JOIN LATERAL (SELECT COALESCE((o.retention->>'failed_post_days')::int, $2) AS days) r
+		WHERE p.status = 'failed' AND r.days > 0
+		  AND p.updated_at < $1::timestamptz - make_interval(days => r.days)
+		  AND ($3::uuid IS NULL OR p.org_id = $3)`,
+	auditEntries: `
+		SELECT a.id FROM audit_log a
+		JOIN organizations o ON o.id = a.org_id
+		CROSS JOIN LATERAL (SELECT COALESCE((o.retention->>'audit_anonymize_months')::int, $2) AS months) r
+		WHERE r.months > 0
+		  AND a.created_at < $1::timestamptz - make_interval(months => r.months)
+		  AND (a.actor_id IS NOT NULL OR a.actor_email IS NOT NULL OR a.details ?| $4::text[])
+		  AND ($3::uuid IS NULL OR a.org_id = $3)`,
+}
+
+// ApplyRetention deletes failed posts and anonymizes audit log entries past
+// their retention, under the default policy or their organization's
+// overrides. With orgID set only that organization's data is affected. A dry
+// run changes nothing and reports what would have been.
+func (db *DB) ApplyRetention(ctx context.Context, defaults models.RetentionPolicy, now time.Time, orgID *uuid.UUID, dryRun bool) (models.RetentionReport, error) {
+	report := models.RetentionReport{DryRun: dryRun, RunAt: now}
+
+	posts := `DELETE FROM posts WHERE id IN (` + retentionRules.failedPosts + `)`
+	audit := `
+		UPDATE audit_log SET actor_id = NULL, actor_email = NULL, details = details - $4::text[]
+		WHERE id IN (` + retentionRules.auditEntries + `)`
+	if dryRun {
+		posts = `SELECT COUNT(*) FROM (` + retentionRules.failedPosts + `) due`
+		audit = `SELECT COUNT(*) FROM (` + retentionRules.auditEntries + `) due`
+	}
+
+	for _, rule := range []struct {
+		name  string
+		query string
+		args  []any
+		count *int64
+	}{
+		{"purge failed posts", posts, []any{now, defaults.FailedPostDays, orgID}, &report.FailedPostsPurged},
+		{"anonymize audit log", audit, []any{now, defaults.AuditAnonymizeMonths, orgID, models.AuditPersonalDetails}, &report.AuditEntriesAnonymized},
+	} {
+		n, err := db.retentionCount(ctx, rule.query, dryRun, rule.args)
+		if err != nil {
+			return report, fmt.Errorf("%s: %w", rule.name, err)
+		}
+		*rule.count = n
+	}
+
+	return report, nil
+}
+
+// retentionCount runs a retention rule's query, returning the rows it
+// counted in a dry run or changed otherwise
+func (db *DB) retentionCount(ctx context.Context, query string, dryRun bool, args []any) (int64, error) {
+	if dryRun {
+		var n int64
+		err := db.pool.QueryRow(ctx, query, args...).Scan(&n)
+		return n, err
+	}
+	result, err := db.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
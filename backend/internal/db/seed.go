@@ -0,0 +1,25 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// SetPostOutcome records a post as published at publishedAt, or as failed with
+// lastError after retries, bypassing the worker. Only the seed command uses it,
+// to give demo posts a believable history.
+func (db *DB) SetPostOutcome(ctx context.Context, id uuid.UUID, status models.PostStatus, publishedAt *time.Time, lastError *string, retries int) error {
+	_, err := db.pool.Exec(ctx, `
+		UPDATE posts SET
+			status = $2,
+			published_at = $3,
+			last_error = $4,
+			retry_count = $5,
+			updated_at = COALESCE($3, scheduled_at)
+		WHERE id = $1
+	`, id, status, publishedAt, lastError, retries)
+	return err
+}
@@ -0,0 +1,211 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+//go:generate go run ./dbmock/gen -src store.go -out dbmock/store.go
+
+// Store is the database as handlers and the worker see it. *DB implements it
+// against Postgres; dbmock.Store stands in for it in unit tests.
+type Store interface {
+	UserStore
+	PostStore
+	OrganizationStore
+	ChannelStore
+	CommentStore
+	MediaStore
+	InboxStore
+	MetricsStore
+	BioStore
+
+	Ping(ctx context.Context) error
+	ListTenants(ctx context.Context) ([]*models.Tenant, error)
+}
+
+var _ Store = (*DB)(nil)
+
+// UserStore reads and writes user accounts and the invites that create them
+type UserStore interface {
+	CreateUser(ctx context.Context, email, passwordHash string) (*models.User, error)
+	CreateUserWithInvite(ctx context.Context, email, passwordHash, codeHash string) (*models.User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	GetUserByWebhookToken(ctx context.Context, tokenHash string) (*models.User, error)
+	GetUserByFeedToken(ctx context.Context, tokenHash string) (*models.User, error)
+	SetWebhookToken(ctx context.Context, userID uuid.UUID, tokenHash *string) error
+	SetFeedToken(ctx context.Context, userID uuid.UUID, tokenHash *string) error
+	GetUserByEmbedToken(ctx context.Context, tokenHash string) (*models.User, error)
+	SetEmbedToken(ctx context.Context, userID uuid.UUID, tokenHash *string) error
+	GetUserByBioToken(ctx context.Context, tokenHash string) (*models.User, error)
+	SetBioToken(ctx context.Context, userID uuid.UUID, tokenHash *string) error
+	SetUserAvatar(ctx context.Context, id uuid.UUID, avatarKey *string) (*models.User, error)
+	SetUserPlan(ctx context.Context, id uuid.UUID, plan models.Plan) (*models.User, error)
+	UpdateUserSettings(ctx context.Context, id uuid.UUID, req models.UpdateAccountSettingsRequest) (*models.User, error)
+	SetNotificationPreferences(ctx context.Context, id uuid.UUID, prefs models.NotificationPreferences) (*models.User, error)
+	SuspendUser(ctx context.Context, id uuid.UUID, reason string) (*models.User, error)
+	UnsuspendUser(ctx context.Context, id uuid.UUID) (*models.User, error)
+
+	CreateInvite(ctx context.Context, codeHash string, maxUses int, expiresAt *time.Time, createdBy uuid.UUID) (*models.Invite, error)
+	ListInvites(ctx context.Context) ([]*models.Invite, error)
+	DeleteInvite(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+// PostStore reads and writes posts through their lifecycle: scheduling,
+// review, publishing, retries, recycling and A/B tests
+type PostStore interface {
+	CreatePost(ctx context.Context, p NewPost) (*models.Post, error)
+	GetPostByID(ctx context.Context, id uuid.UUID) (*models.Post, error)
+	GetPostForRetry(ctx context.Context, id uuid.UUID) (*models.Post, error)
+	GetUpcomingPosts(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter PostFilter) ([]*models.Post, error)
+	GetPublishedPosts(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID) ([]*models.Post, error)
+	GetHistoryPosts(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter HistoryFilter) ([]*models.Post, error)
+	EachUpcomingPost(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter PostFilter, fn func(*models.Post) error) error
+	EachHistoryPost(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter HistoryFilter, fn func(*models.Post) error) error
+	GetUpcomingPostSummaries(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter PostFilter) ([]*models.PostSummary, error)
+	GetHistoryPostSummaries(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter HistoryFilter) ([]*models.PostSummary, error)
+	GetFeedPosts(ctx context.Context, userID uuid.UUID, limit int) ([]*models.Post, error)
+	UpdatePost(ctx context.Context, id uuid.UUID, userID uuid.UUID, u PostUpdate) (*models.Post, error)
+	DeletePost(ctx context.Context, id uuid.UUID, userID uuid.UUID) (bool, error)
+	FindConflictingPosts(ctx context.Context, userID uuid.UUID, channel models.Channel, scheduledAt time.Time, window time.Duration, excludeID uuid.UUID) ([]models.PostConflict, error)
+	CountChannelPostsForDay(ctx context.Context, userID uuid.UUID, channel models.Channel, start, end time.Time, excludeID uuid.UUID) (int, error)
+	CountPublishedChannelPosts(ctx context.Context, userID uuid.UUID, channel models.Channel, since time.Time) (int, error)
+
+	PublishPosts(ctx context.Context, ids []uuid.UUID) ([]*models.Post, error)
+	PublishPostNow(ctx context.Context, id uuid.UUID, userID uuid.UUID, windowOverride bool) (*models.Post, error)
+	MarkPostFailed(ctx context.Context, id uuid.UUID, errorMsg string) error
+	ScheduleRetry(ctx context.Context, id uuid.UUID, nextRetryAt time.Time, errorMsg string) error
+	DeferPost(ctx context.Context, id uuid.UUID, scheduledAt time.Time, reason string) error
+	StartUndoWindow(ctx context.Context, id uuid.UUID, until time.Time) (bool, error)
+	AbortPublish(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Post, error)
+	CancelPost(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Post, error)
+	ListScheduledPostRefs(ctx context.Context, afterID uuid.UUID, limit int) ([]QueuedPostRef, error)
+	ListUserScheduledPostRefs(ctx context.Context, userID uuid.UUID) ([]QueuedPostRef, error)
+
+	SetPostWorkflow(ctx context.Context, id uuid.UUID, state *models.WorkflowState, assigneeID *uuid.UUID, unassign bool) (*models.Post, error)
+	ApprovePost(ctx context.Context, id uuid.UUID) (*models.Post, error)
+	RejectPost(ctx context.Context, id uuid.UUID, reason string) (*models.Post, error)
+	ClaimApprovalReminders(ctx context.Context, dueBefore time.Time, limit int) ([]*models.Post, error)
+	ClaimApprovalEscalations(ctx context.Context, dueBefore time.Time, limit int) ([]*models.Post, error)
+
+	GetRecyclablePosts(ctx context.Context, limit int) ([]*models.Post, error)
+	RecyclePost(ctx context.Context, id uuid.UUID) (*models.Post, error)
+
+	GetABTestsToStart(ctx context.Context, limit int) ([]*models.Post, error)
+	StartABVariant(ctx context.Context, id uuid.UUID) (*models.Post, error)
+	GetABTestsToDecide(ctx context.Context, limit int) ([]*models.Post, error)
+	GetABVariant(ctx context.Context, parentID uuid.UUID) (*models.Post, error)
+	DecideABTest(ctx context.Context, id uuid.UUID, winner models.ABVariant) (bool, error)
+	RepostPost(ctx context.Context, id uuid.UUID) (*models.Post, error)
+	SetPostEngagement(ctx context.Context, id uuid.UUID, e models.Engagement) (*models.Post, error)
+}
+
+// OrganizationStore reads and writes organizations, their members, publishing
+// windows, audit logs and retention rules
+type OrganizationStore interface {
+	CreateOrganization(ctx context.Context, name string, ownerID uuid.UUID) (*models.Organization, error)
+	ListWorkspaces(ctx context.Context, userID uuid.UUID) ([]models.Workspace, error)
+	GetMemberRole(ctx context.Context, orgID, userID uuid.UUID) (models.OrgRole, error)
+	ListOrganizationMembers(ctx context.Context, orgID uuid.UUID) ([]models.OrganizationMember, error)
+	SetOrganizationMember(ctx context.Context, orgID, userID uuid.UUID, role models.OrgRole, canOverrideWindows bool) error
+	RemoveOrganizationMember(ctx context.Context, orgID, userID uuid.UUID) (bool, error)
+	CanOverrideWindows(ctx context.Context, orgID, userID uuid.UUID) (bool, error)
+	GetPublishingSchedule(ctx context.Context, orgID uuid.UUID) (*models.PublishingSchedule, error)
+	SetPublishingSchedule(ctx context.Context, orgID uuid.UUID, s models.PublishingSchedule) error
+	GetBrandChecklist(ctx context.Context, orgID uuid.UUID) (*models.BrandChecklist, error)
+	SetBrandChecklist(ctx context.Context, orgID uuid.UUID, c models.BrandChecklist) error
+	RecordAuditEntry(ctx context.Context, e NewAuditEntry) error
+	EachAuditEntry(ctx context.Context, orgID uuid.UUID, from, to time.Time, fn func(*models.AuditEntry) error) error
+	GetRetentionOverrides(ctx context.Context, orgID uuid.UUID) (*models.RetentionOverrides, error)
+	SetRetentionOverrides(ctx context.Context, orgID uuid.UUID, o models.RetentionOverrides) error
+	ApplyRetention(ctx context.Context, defaults models.RetentionPolicy, now time.Time, orgID *uuid.UUID, dryRun bool) (models.RetentionReport, error)
+}
+
+// ChannelStore reads and writes users' connected social accounts, their
+// daily follower counts and per-channel presets
+type ChannelStore interface {
+	UpsertChannelConnection(ctx context.Context, userID uuid.UUID, channel models.Channel, creds ChannelCredentials) (*models.ChannelConnection, error)
+	GetChannelConnections(ctx context.Context, userID uuid.UUID) ([]*models.ChannelConnection, error)
+	GetChannelConnection(ctx context.Context, userID uuid.UUID, channel models.Channel) (*models.ChannelConnection, error)
+	DeleteChannelConnection(ctx context.Context, userID uuid.UUID, channel models.Channel) (bool, error)
+	RecordChannelPublishes(ctx context.Context, refs []ChannelRef) error
+	RecordChannelError(ctx context.Context, userID uuid.UUID, channel models.Channel, errorMsg string) error
+	GetChannelActivity(ctx context.Context, since time.Time) (map[models.Channel]ChannelActivity, error)
+
+	ListChannelPresets(ctx context.Context, userID uuid.UUID) ([]*models.ChannelPreset, error)
+	UpsertChannelPreset(ctx context.Context, userID uuid.UUID, channel models.Channel, req models.UpdateChannelPresetRequest) (*models.ChannelPreset, error)
+	GetChannelSignature(ctx context.Context, userID uuid.UUID, channel models.Channel) (*string, error)
+	DeleteChannelPreset(ctx context.Context, userID uuid.UUID, channel models.Channel) (bool, error)
+
+	GetConnectionsForFollowerPoll(ctx context.Context, channels []models.Channel, day time.Time, limit int) ([]*models.ChannelConnection, error)
+	SaveFollowerCounts(ctx context.Context, day time.Time, polled []uuid.UUID, counts []models.FollowerCount) error
+	ListFollowerCounts(ctx context.Context, userID uuid.UUID, since, until time.Time) ([]models.FollowerCount, error)
+}
+
+// CommentStore reads and writes review comments on posts
+type CommentStore interface {
+	CreateComment(ctx context.Context, postID, userID uuid.UUID, parentID *uuid.UUID, body string) (*models.Comment, error)
+	ListComments(ctx context.Context, postID uuid.UUID) ([]*models.Comment, error)
+	CommentExists(ctx context.Context, postID, commentID uuid.UUID) (bool, error)
+	DeleteComment(ctx context.Context, postID, commentID, userID uuid.UUID) (bool, error)
+}
+
+// MediaStore reads and writes users' uploaded media and the organization
+// libraries it is shared to
+type MediaStore interface {
+	CreateMedia(ctx context.Context, m *models.Media) (*models.Media, error)
+	GetMediaByIDs(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Media, error)
+	ListMedia(ctx context.Context, userID uuid.UUID) ([]*models.Media, error)
+	UpdateMediaAltText(ctx context.Context, userID, id uuid.UUID, altText *string) (*models.Media, error)
+	SetMediaRenditions(ctx context.Context, id uuid.UUID, renditions []models.MediaRendition) error
+
+	ListMediaFolders(ctx context.Context, orgID uuid.UUID) ([]*models.MediaFolder, error)
+	CreateMediaFolder(ctx context.Context, orgID, userID uuid.UUID, name string) (*models.MediaFolder, error)
+	DeleteMediaFolder(ctx context.Context, orgID, id uuid.UUID) (bool, error)
+	ListLibraryMedia(ctx context.Context, orgID uuid.UUID, filter models.LibraryFilter) ([]*models.LibraryMedia, error)
+	FileLibraryMedia(ctx context.Context, userID, orgID uuid.UUID, req models.FileLibraryMediaRequest) (*models.Media, error)
+	RemoveLibraryMedia(ctx context.Context, userID, orgID, id uuid.UUID, manage bool) (bool, error)
+}
+
+// InboxStore reads and writes the replies, mentions and keyword matches
+// pulled into users' inboxes, and which posts and keyword monitors they were
+// pulled for
+type InboxStore interface {
+	GetPostsForInboxSync(ctx context.Context, channels []models.Channel, publishedSince, syncedBefore time.Time, limit int) ([]*models.Post, error)
+	MarkInboxSynced(ctx context.Context, postIDs []uuid.UUID, at time.Time) error
+	AddInboxItems(ctx context.Context, items []models.InboxItem) (int, error)
+	ListInboxItems(ctx context.Context, userID uuid.UUID, filter InboxFilter) ([]*models.InboxItem, error)
+	CountUnreadInbox(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkInboxItems(ctx context.Context, userID uuid.UUID, ids []uuid.UUID, read bool) (int, error)
+	MarkAllInboxRead(ctx context.Context, userID uuid.UUID) (int, error)
+
+	ListKeywordMonitors(ctx context.Context, userID uuid.UUID) ([]*models.KeywordMonitor, error)
+	CreateKeywordMonitor(ctx context.Context, userID uuid.UUID, req models.CreateKeywordMonitorRequest) (*models.KeywordMonitor, error)
+	DeleteKeywordMonitor(ctx context.Context, userID, id uuid.UUID) (bool, error)
+	GetKeywordMonitorsToPoll(ctx context.Context, polledBefore time.Time, limit int) ([]*models.KeywordMonitor, error)
+	MarkKeywordMonitorsPolled(ctx context.Context, ids []uuid.UUID, at time.Time) error
+}
+
+// MetricsStore reads and writes usage and system metric rollups, and which
+// monthly analytics reports were sent
+type MetricsStore interface {
+	SaveUsageDay(ctx context.Context, day time.Time, counts map[uuid.UUID]models.UsageCounts) error
+	ListUsage(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.UsageDay, error)
+	RollupSystemMetrics(ctx context.Context, hourSince, daySince time.Time) error
+	ListSystemMetrics(ctx context.Context, since, until time.Time) ([]models.MetricSeries, error)
+	GetUsersForMonthlyReport(ctx context.Context, month time.Time, limit int) ([]*models.User, error)
+	MarkMonthlyReportSent(ctx context.Context, userID uuid.UUID, month time.Time) error
+}
+
+// BioStore reads and writes the links on users' public bio pages
+type BioStore interface {
+	ListBioLinks(ctx context.Context, userID uuid.UUID) ([]*models.BioLink, error)
+	CreateBioLink(ctx context.Context, userID uuid.UUID, req models.CreateBioLinkRequest) (*models.BioLink, error)
+	ReorderBioLinks(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (bool, error)
+	DeleteBioLink(ctx context.Context, userID, id uuid.UUID) (bool, error)
+	RecordBioClick(ctx context.Context, userID, id uuid.UUID) (*string, error)
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Usage operations
+
+// SaveUsageDay stores each user's usage for the UTC day containing day,
+// replacing earlier rollups of the same day
+func (db *DB) SaveUsageDay(ctx context.Context, day time.Time, counts map[uuid.UUID]models.UsageCounts) error {
+	batch := &pgx.Batch{}
+	for userID, c := range counts {
+		batch.Queue(`
+			INSERT INTO usage_daily (user_id, day, api_requests, publishes)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, day) DO UPDATE SET
+				api_requests = GREATEST(usage_daily.api_requests, EXCLUDED.api_requests),
+				publishes = GREATEST(usage_daily.publishes, EXCLUDED.publishes),
+				updated_at = NOW()
+		`, userID, day.UTC().Format("2006-01-02"), c.APIRequests, c.Publishes)
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+	return db.pool.SendBatch(ctx, batch).Close()
+}
+
+// ListUsage returns the user's rolled-up usage since the given day, most recent first
+func (db *DB) ListUsage(ctx context.Context, userID uuid.UUID, since time.Time) ([]models.UsageDay, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT to_char(day, 'YYYY-MM-DD'), api_requests, publishes
+		FROM usage_daily
+		WHERE user_id = $1 AND day >= $2
+		ORDER BY day DESC
+	`, userID, since.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := []models.UsageDay{}
+	for rows.Next() {
+		var d models.UsageDay
+		if err := rows.Scan(&d.Day, &d.APIRequests, &d.Publishes); err != nil {
+			return nil, err
+		}
+		days = append(days, d)
+	}
+	return days, rows.Err()
+}
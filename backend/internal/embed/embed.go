@@ -0,0 +1,79 @@
+// Package embed renders users' public upcoming schedules as a standalone HTML
+// document, with no scripts or external assets, so community calendars can
+// frame it as is.
+package embed
+
+import (
+	"bytes"
+	"html/template"
+	"time"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// scheduleTemplate lays out an upcoming schedule, grouping posts by UTC day.
+// html/template escapes every value.
+var scheduleTemplate = template.Must(template.New("embed").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Title}}</title>
+<style>
+body{margin:0;font-family:system-ui,-apple-system,sans-serif;background:transparent;color:#1d1d1f}
+main{padding:12px}
+h1{font-size:1.1rem;margin:0 0 12px}
+h2{font-size:.8rem;margin:16px 0 6px;color:#6e6e73;text-transform:uppercase;letter-spacing:.04em}
+ol{list-style:none;margin:0;padding:0}
+li{display:flex;gap:8px;padding:8px 0;border-bottom:1px solid #e5e5ea}
+time{flex:none;width:5.5em;color:#6e6e73;font-variant-numeric:tabular-nums}
+.channel{flex:none;font-size:.8rem;color:#6e6e73}
+.title{flex:1;min-width:0;overflow:hidden;text-overflow:ellipsis;white-space:nowrap}
+p.empty{color:#6e6e73}
+</style>
+</head>
+<body>
+<main>
+<h1>{{.Title}}</h1>
+{{range .Days}}<h2>{{.Date.Format "Mon, Jan 2"}}</h2>
+<ol>
+{{range .Posts}}<li><time datetime="{{.ScheduledAt.Format "2006-01-02T15:04:05Z07:00"}}">{{.ScheduledAt.Format "15:04"}} UTC</time><span class="title">{{with .Title}}{{.}}{{else}}Untitled post{{end}}</span><span class="channel">{{.Channel}}</span></li>
+{{end}}</ol>
+{{else}}<p class="empty">Nothing scheduled yet.</p>
+{{end}}</main>
+</body>
+</html>
+`))
+
+// scheduleDay is the posts scheduled on one UTC day
+type scheduleDay struct {
+	Date  time.Time
+	Posts []models.EmbedPost
+}
+
+// HTML renders an upcoming schedule
+func HTML(s models.EmbedSchedule) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := scheduleTemplate.Execute(&buf, struct {
+		Title string
+		Days  []scheduleDay
+	}{s.Title, groupByDay(s.Posts)}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// groupByDay splits posts, soonest first, into their UTC days
+func groupByDay(posts []models.EmbedPost) []scheduleDay {
+	var days []scheduleDay
+	for _, p := range posts {
+		p.ScheduledAt = p.ScheduledAt.UTC()
+		date := p.ScheduledAt.Truncate(24 * time.Hour)
+		if len(days) == 0 || !days[len(days)-1].Date.Equal(date) {
+			days = append(days, scheduleDay{Date: date})
+		}
+		days[len(days)-1].Posts = append(days[len(days)-1].Posts, p)
+	}
+	return days
+}
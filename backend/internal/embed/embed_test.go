@@ -0,0 +1,55 @@
+package embed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestHTML(t *testing.T) {
+	title := "Launch <day>"
+	body, err := HTML(models.EmbedSchedule{
+		Title: "Upcoming posts",
+		Posts: []models.EmbedPost{
+			{Title: &title, Channel: models.ChannelTwitter, ScheduledAt: time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)},
+			{Channel: models.ChannelLinkedIn, ScheduledAt: time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)},
+			{Channel: models.ChannelTwitter, ScheduledAt: time.Date(2024, 1, 16, 8, 0, 0, 0, time.FixedZone("CET", 3600))},
+		},
+	})
+	if err != nil {
+		t.Fatalf("HTML() failed: %v", err)
+	}
+	out := string(body)
+
+	for _, want := range []string{
+		"<title>Upcoming posts</title>",
+		"Launch &lt;day&gt;",
+		"Untitled post",
+		`datetime="2024-01-15T09:30:00Z">09:30 UTC`,
+		"Mon, Jan 15",
+		"Tue, Jan 16",
+		"07:00 UTC",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HTML() output missing %q", want)
+		}
+	}
+	if n := strings.Count(out, "<h2>"); n != 2 {
+		t.Errorf("HTML() rendered %d days, want 2", n)
+	}
+	if strings.Contains(out, "<script") {
+		t.Error("HTML() output has a script")
+	}
+}
+
+func TestHTML_Empty(t *testing.T) {
+	body, err := HTML(models.EmbedSchedule{Title: "Upcoming posts"})
+	if err != nil {
+		t.Fatalf("HTML() failed: %v", err)
+	}
+	if !strings.Contains(string(body), "Nothing scheduled yet.") {
+		t.Error("empty schedule doesn't say nothing is scheduled")
+	}
+}
@@ -0,0 +1,153 @@
+package feed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// maxTitleLength is how much content is used as an item title when a post has none
+const maxTitleLength = 80
+
+// Info describes the feed itself
+type Info struct {
+	Title       string
+	Link        string // Site the feed belongs to
+	Description string
+	FeedURL     string // Where this feed is served from
+}
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Description string  `xml:"description"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Category    string  `xml:"category"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// RSS renders published posts, newest first, as an RSS 2.0 document
+func RSS(info Info, posts []*models.Post) ([]byte, error) {
+	doc := rssDocument{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       info.Title,
+			Link:        info.Link,
+			Description: info.Description,
+			Items:       []rssItem{},
+		},
+	}
+	if len(posts) > 0 {
+		doc.Channel.LastBuildDate = publishedAt(posts[0]).Format(time.RFC1123Z)
+	}
+
+	for _, p := range posts {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       itemTitle(p),
+			Description: p.Content,
+			GUID:        rssGUID{Value: p.ID.String()},
+			PubDate:     publishedAt(p).Format(time.RFC1123Z),
+			Category:    string(p.Channel),
+		})
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+type jsonFeed struct {
+	Version     string     `json:"version"`
+	Title       string     `json:"title"`
+	HomePageURL string     `json:"home_page_url,omitempty"`
+	FeedURL     string     `json:"feed_url,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Items       []jsonItem `json:"items"`
+}
+
+type jsonItem struct {
+	ID            string   `json:"id"`
+	Title         string   `json:"title,omitempty"`
+	ContentText   string   `json:"content_text"`
+	DatePublished string   `json:"date_published"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+// JSON renders published posts, newest first, as a JSON Feed 1.1 document
+func JSON(info Info, posts []*models.Post) ([]byte, error) {
+	doc := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       info.Title,
+		HomePageURL: info.Link,
+		FeedURL:     info.FeedURL,
+		Description: info.Description,
+		Items:       []jsonItem{},
+	}
+
+	for _, p := range posts {
+		item := jsonItem{
+			ID:            p.ID.String(),
+			ContentText:   p.Content,
+			DatePublished: publishedAt(p).Format(time.RFC3339),
+			Tags:          []string{string(p.Channel)},
+		}
+		if p.Title != nil {
+			item.Title = *p.Title
+		}
+		doc.Items = append(doc.Items, item)
+	}
+
+	return json.Marshal(doc)
+}
+
+// ETag returns a strong entity tag for a rendered feed
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// itemTitle is the post's title, or the start of its content
+func itemTitle(p *models.Post) string {
+	if p.Title != nil && *p.Title != "" {
+		return *p.Title
+	}
+	title := strings.Join(strings.Fields(p.Content), " ")
+	if utf8.RuneCountInString(title) <= maxTitleLength {
+		return title
+	}
+	return string([]rune(title)[:maxTitleLength-1]) + "…"
+}
+
+// publishedAt falls back to the scheduled time for posts without a publish time
+func publishedAt(p *models.Post) time.Time {
+	if p.PublishedAt != nil {
+		return p.PublishedAt.UTC()
+	}
+	return p.ScheduledAt.UTC()
+}
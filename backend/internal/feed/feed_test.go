@@ -0,0 +1,78 @@
+package feed
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func testPosts() []*models.Post {
+	title := "Launch day"
+	published := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+	return []*models.Post{
+		{ID: uuid.New(), Title: &title, Content: "We are live <3", Channel: models.ChannelTwitter, PublishedAt: &published},
+		{ID: uuid.New(), Content: strings.Repeat("word ", 40), Channel: models.ChannelLinkedIn, ScheduledAt: published.Add(-time.Hour)},
+	}
+}
+
+func TestRSS(t *testing.T) {
+	posts := testPosts()
+	body, err := RSS(Info{Title: "Published posts", Link: "https://example.com"}, posts)
+	if err != nil {
+		t.Fatalf("RSS() failed: %v", err)
+	}
+	out := string(body)
+
+	for _, want := range []string{
+		`<rss version="2.0">`,
+		"<title>Launch day</title>",
+		"We are live &lt;3",
+		"<pubDate>Mon, 15 Jan 2024 14:00:00 +0000</pubDate>",
+		`<guid isPermaLink="false">` + posts[0].ID.String() + "</guid>",
+		"<category>linkedin</category>",
+		"…</title>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("RSS() output missing %q", want)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	posts := testPosts()
+	body, err := JSON(Info{Title: "Published posts", FeedURL: "https://example.com/feed.json"}, posts)
+	if err != nil {
+		t.Fatalf("JSON() failed: %v", err)
+	}
+
+	var doc jsonFeed
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("JSON() produced invalid JSON: %v", err)
+	}
+	if doc.Version != "https://jsonfeed.org/version/1.1" || len(doc.Items) != 2 {
+		t.Fatalf("JSON() = %+v, want JSON Feed 1.1 with 2 items", doc)
+	}
+	if doc.Items[0].Title != "Launch day" || doc.Items[1].Title != "" {
+		t.Errorf("item titles = %q, %q", doc.Items[0].Title, doc.Items[1].Title)
+	}
+	if doc.Items[1].DatePublished != "2024-01-15T13:00:00Z" {
+		t.Errorf("date_published without published_at = %q, want scheduled time", doc.Items[1].DatePublished)
+	}
+}
+
+func TestETag(t *testing.T) {
+	a, b := ETag([]byte("feed a")), ETag([]byte("feed b"))
+	if a == b {
+		t.Error("ETag() is the same for different bodies")
+	}
+	if a != ETag([]byte("feed a")) {
+		t.Error("ETag() is not deterministic")
+	}
+	if !strings.HasPrefix(a, `"`) || !strings.HasSuffix(a, `"`) {
+		t.Errorf("ETag() = %s, want a quoted tag", a)
+	}
+}
@@ -0,0 +1,247 @@
+// Package inbox pulls replies and mentions on published posts, and matches
+// of users' keyword monitors, from their platforms. As with publishing,
+// platform calls are simulated: each fetcher and searcher logs the request
+// it would make, and in sandbox mode they make up replies and matches so
+// staging exercises the inbox end to end.
+package inbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Fetcher pulls the replies and mentions on one channel's published posts
+type Fetcher interface {
+	Fetch(ctx context.Context, post *models.Post) ([]models.InboxItem, error)
+}
+
+// Searcher finds platform posts matching a keyword monitor
+type Searcher interface {
+	Search(ctx context.Context, monitor *models.KeywordMonitor, since time.Time) ([]models.InboxItem, error)
+}
+
+// Registry routes posts to the fetcher for their channel, and keyword
+// monitors to the searcher of each channel they follow. Channels without a
+// fetcher, such as webhooks, have no inbox.
+type Registry struct {
+	fetchers  map[models.Channel]Fetcher
+	searchers map[models.Channel]Searcher
+}
+
+// platformEndpoints is the request each channel's replies are read with,
+// given the post's platform ID
+var platformEndpoints = map[models.Channel]string{
+	models.ChannelTwitter:  "GET /2/tweets/search/recent?query=conversation_id:%s",
+	models.ChannelLinkedIn: "GET /v2/socialActions/%s/comments",
+	models.ChannelFacebook: "GET /%s/comments",
+	models.ChannelReddit:   "GET /comments/%s",
+	models.ChannelYouTube:  "GET /youtube/v3/commentThreads?videoId=%s",
+}
+
+// searchEndpoints is the request each channel is searched with, given the
+// monitor's escaped query and the time of the previous search
+var searchEndpoints = map[models.Channel]string{
+	models.ChannelTwitter: "GET /2/tweets/search/recent?query=%s&start_time=%s",
+	models.ChannelReddit:  "GET /search?q=%s&sort=new&after=%s",
+	models.ChannelYouTube: "GET /youtube/v3/search?q=%s&order=date&publishedAfter=%s",
+}
+
+// NewRegistry creates a registry with the platform fetcher of every channel
+// that has replies, and the platform searcher of every channel that can be
+// searched
+func NewRegistry() *Registry {
+	r := &Registry{
+		fetchers:  make(map[models.Channel]Fetcher, len(platformEndpoints)),
+		searchers: make(map[models.Channel]Searcher, len(searchEndpoints)),
+	}
+	for channel, endpoint := range platformEndpoints {
+		r.fetchers[channel] = &platformFetcher{channel: channel, endpoint: endpoint}
+	}
+	for channel, endpoint := range searchEndpoints {
+		r.searchers[channel] = &platformSearcher{channel: channel, endpoint: endpoint}
+	}
+	return r
+}
+
+// NewSandboxRegistry creates a registry whose fetchers and searchers make up
+// replies and matches, to a post or monitor with probability replyRate per
+// fetch or search
+func NewSandboxRegistry(replyRate float64) *Registry {
+	r := NewRegistry()
+	for channel := range r.fetchers {
+		r.fetchers[channel] = NewSandboxFetcher(channel, replyRate)
+	}
+	for channel := range r.searchers {
+		r.searchers[channel] = NewSandboxFetcher(channel, replyRate)
+	}
+	return r
+}
+
+// Register sets the fetcher used for a channel
+func (r *Registry) Register(channel models.Channel, f Fetcher) {
+	r.fetchers[channel] = f
+}
+
+// RegisterSearcher sets the searcher used for a channel
+func (r *Registry) RegisterSearcher(channel models.Channel, s Searcher) {
+	r.searchers[channel] = s
+}
+
+// Channels returns the channels with a fetcher, in a stable order
+func (r *Registry) Channels() []models.Channel {
+	channels := make([]models.Channel, 0, len(r.fetchers))
+	for c := range r.fetchers {
+		channels = append(channels, c)
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i] < channels[j] })
+	return channels
+}
+
+// Fetch pulls a post's replies and mentions using its channel's fetcher
+func (r *Registry) Fetch(ctx context.Context, post *models.Post) ([]models.InboxItem, error) {
+	f, ok := r.fetchers[post.Channel]
+	if !ok {
+		return nil, fmt.Errorf("no inbox fetcher registered for channel %s", post.Channel)
+	}
+	return f.Fetch(ctx, post)
+}
+
+// Search finds the posts on a channel matching a keyword monitor since the
+// given time, using the channel's searcher
+func (r *Registry) Search(ctx context.Context, channel models.Channel, monitor *models.KeywordMonitor, since time.Time) ([]models.InboxItem, error) {
+	s, ok := r.searchers[channel]
+	if !ok {
+		return nil, fmt.Errorf("no keyword searcher registered for channel %s", channel)
+	}
+	return s.Search(ctx, monitor, since)
+}
+
+// platformFetcher logs the request that reads a post's replies
+type platformFetcher struct {
+	channel  models.Channel
+	endpoint string
+}
+
+// Fetch logs the platform request; simulated platforms have no replies
+func (f *platformFetcher) Fetch(ctx context.Context, post *models.Post) ([]models.InboxItem, error) {
+	log.Printf("📥 [INBOX] %s replies of post %s: %s", f.channel, post.ID, fmt.Sprintf(f.endpoint, post.ID))
+	return nil, nil
+}
+
+// platformSearcher logs the request that searches a channel for a monitor
+type platformSearcher struct {
+	channel  models.Channel
+	endpoint string
+}
+
+// Search logs the platform request; simulated platforms have no matches
+func (s *platformSearcher) Search(ctx context.Context, monitor *models.KeywordMonitor, since time.Time) ([]models.InboxItem, error) {
+	log.Printf("🔎 [INBOX] %s search for monitor %s: %s", s.channel, monitor.ID, fmt.Sprintf(s.endpoint, url.QueryEscape(monitor.Query), since.UTC().Format(time.RFC3339)))
+	return nil, nil
+}
+
+// Sandbox replies and matches are drawn from these
+var (
+	sandboxAuthors  = []string{"alex_k", "priya.codes", "sam_ships", "jordan", "mei_lin"}
+	sandboxReplies  = []string{"Love this!", "Where can I read more?", "Great news, congrats to the team", "Does this work with the free plan?", "Thanks for sharing 🙌"}
+	sandboxMentions = []string{"@you have you seen this thread?", "Looping in @you for this one"}
+	sandboxMatches  = []string{"Has anyone tried %s yet?", "Switched to %s last month, no regrets", "%s is down again?", "Comparing %s with the alternatives, thoughts?"}
+)
+
+// SandboxFetcher makes up one to three replies to a post at random, some
+// of them mentions, received between its publish time and now. As a
+// Searcher it makes up matches of a keyword monitor the same way.
+type SandboxFetcher struct {
+	channel   models.Channel
+	replyRate float64
+	now       func() time.Time
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewSandboxFetcher creates a sandbox fetcher for a channel
+func NewSandboxFetcher(channel models.Channel, replyRate float64) *SandboxFetcher {
+	return &SandboxFetcher{
+		channel:   channel,
+		replyRate: replyRate,
+		now:       time.Now,
+		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Fetch makes up the post's new replies, if any
+func (f *SandboxFetcher) Fetch(ctx context.Context, post *models.Post) ([]models.InboxItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.rng.Float64() >= f.replyRate {
+		return nil, nil
+	}
+
+	now := f.now()
+	published := now
+	if post.PublishedAt != nil && post.PublishedAt.Before(now) {
+		published = *post.PublishedAt
+	}
+
+	items := make([]models.InboxItem, 1+f.rng.Intn(3))
+	for i := range items {
+		kind, content := models.InboxKindReply, sandboxReplies[f.rng.Intn(len(sandboxReplies))]
+		if f.rng.Intn(4) == 0 {
+			kind, content = models.InboxKindMention, sandboxMentions[f.rng.Intn(len(sandboxMentions))]
+		}
+		items[i] = models.InboxItem{
+			UserID:     post.UserID,
+			PostID:     &post.ID,
+			Channel:    f.channel,
+			Kind:       kind,
+			ExternalID: "sandbox-" + uuid.NewString(),
+			Author:     sandboxAuthors[f.rng.Intn(len(sandboxAuthors))],
+			Content:    content,
+			ReceivedAt: published.Add(time.Duration(f.rng.Int63n(int64(now.Sub(published)) + 1))),
+		}
+	}
+	log.Printf("🧪 [SANDBOX] %s made up %d replies to post %s", f.channel, len(items), post.ID)
+	return items, nil
+}
+
+// Search makes up the monitor's new matches since the given time, if any
+func (f *SandboxFetcher) Search(ctx context.Context, monitor *models.KeywordMonitor, since time.Time) ([]models.InboxItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.rng.Float64() >= f.replyRate {
+		return nil, nil
+	}
+
+	now := f.now()
+	if since.After(now) {
+		since = now
+	}
+
+	items := make([]models.InboxItem, 1+f.rng.Intn(3))
+	for i := range items {
+		items[i] = models.InboxItem{
+			UserID:     monitor.UserID,
+			MonitorID:  &monitor.ID,
+			Channel:    f.channel,
+			Kind:       models.InboxKindKeyword,
+			ExternalID: "sandbox-" + uuid.NewString(),
+			Author:     sandboxAuthors[f.rng.Intn(len(sandboxAuthors))],
+			Content:    fmt.Sprintf(sandboxMatches[f.rng.Intn(len(sandboxMatches))], monitor.Query),
+			ReceivedAt: since.Add(time.Duration(f.rng.Int63n(int64(now.Sub(since)) + 1))),
+		}
+	}
+	log.Printf("🧪 [SANDBOX] %s made up %d matches of monitor %s", f.channel, len(items), monitor.ID)
+	return items, nil
+}
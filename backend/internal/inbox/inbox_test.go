@@ -0,0 +1,96 @@
+package inbox
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestSandboxFetcher(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	published := now.Add(-time.Hour)
+	post := &models.Post{ID: uuid.New(), UserID: uuid.New(), Channel: models.ChannelTwitter, PublishedAt: &published}
+
+	f := NewSandboxFetcher(models.ChannelTwitter, 1)
+	f.now = func() time.Time { return now }
+	items, err := f.Fetch(context.Background(), post)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) < 1 || len(items) > 3 {
+		t.Fatalf("got %d items, want 1 to 3", len(items))
+	}
+	for _, item := range items {
+		if item.PostID == nil || *item.PostID != post.ID || item.UserID != post.UserID || item.Channel != models.ChannelTwitter || item.ExternalID == "" {
+			t.Errorf("item = %+v", item)
+		}
+		if item.ReceivedAt.Before(published) || item.ReceivedAt.After(now) {
+			t.Errorf("received at %v, want between publishing and now", item.ReceivedAt)
+		}
+	}
+
+	if items, _ := NewSandboxFetcher(models.ChannelTwitter, 0).Fetch(context.Background(), post); len(items) != 0 {
+		t.Errorf("reply rate 0 made up %d items", len(items))
+	}
+}
+
+func TestRegistry_Channels(t *testing.T) {
+	channels := NewRegistry().Channels()
+	if len(channels) != len(platformEndpoints) {
+		t.Fatalf("channels = %v", channels)
+	}
+	for i := 1; i < len(channels); i++ {
+		if channels[i-1] >= channels[i] {
+			t.Errorf("channels not sorted: %v", channels)
+		}
+	}
+	if _, err := NewRegistry().Fetch(context.Background(), &models.Post{Channel: models.ChannelWebhook}); err == nil {
+		t.Error("fetched replies of a webhook post")
+	}
+}
+
+func TestSandboxFetcher_Search(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-15 * time.Minute)
+	monitor := &models.KeywordMonitor{ID: uuid.New(), UserID: uuid.New(), Query: "acme"}
+
+	f := NewSandboxFetcher(models.ChannelReddit, 1)
+	f.now = func() time.Time { return now }
+	items, err := f.Search(context.Background(), monitor, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) < 1 || len(items) > 3 {
+		t.Fatalf("got %d items, want 1 to 3", len(items))
+	}
+	for _, item := range items {
+		if item.MonitorID == nil || *item.MonitorID != monitor.ID || item.PostID != nil || item.Kind != models.InboxKindKeyword {
+			t.Errorf("item = %+v", item)
+		}
+		if !strings.Contains(item.Content, "acme") {
+			t.Errorf("content %q doesn't match the query", item.Content)
+		}
+		if item.ReceivedAt.Before(since) || item.ReceivedAt.After(now) {
+			t.Errorf("received at %v, want between the last search and now", item.ReceivedAt)
+		}
+	}
+}
+
+func TestRegistry_SearchesMonitorChannels(t *testing.T) {
+	r := NewRegistry()
+	for _, c := range models.MonitorChannels() {
+		if _, ok := r.searchers[c]; !ok {
+			t.Errorf("no searcher for monitor channel %s", c)
+		}
+	}
+	if len(r.searchers) != len(models.MonitorChannels()) {
+		t.Errorf("%d searchers, want one per monitor channel", len(r.searchers))
+	}
+	if _, err := r.Search(context.Background(), models.ChannelFacebook, &models.KeywordMonitor{}, time.Now()); err == nil {
+		t.Error("searched a channel without a searcher")
+	}
+}
@@ -0,0 +1,48 @@
+package mailer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildMessage(t *testing.T) {
+	out := string(buildMessage("Scheduler <noreply@example.com>", Message{
+		To:      "user@example.com",
+		Subject: "Hello\r\nBcc: attacker@example.com",
+		Body:    "line one\nline two",
+	}))
+
+	if strings.Contains(out, "\r\nBcc:") {
+		t.Errorf("Subject newlines should be stripped to prevent header injection:\n%s", out)
+	}
+	if !strings.Contains(out, "Subject: Hello  Bcc: attacker@example.com\r\n") {
+		t.Errorf("Subject header missing or malformed:\n%s", out)
+	}
+	if !strings.HasSuffix(out, "\r\n\r\nline one\r\nline two") {
+		t.Errorf("Body should follow a blank line with CRLF line endings:\n%q", out)
+	}
+}
+
+func TestSend_LogsWithoutRelay(t *testing.T) {
+	if err := New("", "", "", "noreply@example.com").Send(Message{To: "user@example.com", Subject: "Hi"}); err != nil {
+		t.Errorf("Send without relay should only log, got: %v", err)
+	}
+}
+
+func TestBuildMessage_Attachments(t *testing.T) {
+	out := string(buildMessage("noreply@example.com", Message{
+		To:          "user@example.com",
+		Subject:     "Report",
+		Body:        "See attached",
+		Attachments: []Attachment{{Filename: "report.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4")}},
+	}))
+
+	if !strings.Contains(out, "Content-Type: multipart/mixed; boundary=") {
+		t.Errorf("message with attachments should be multipart/mixed:\n%s", out)
+	}
+	for _, want := range []string{"See attached", `attachment; filename=report.pdf`, "JVBERi0xLjQ="} {
+		if !strings.Contains(out, want) {
+			t.Errorf("message missing %q:\n%s", want, out)
+		}
+	}
+}
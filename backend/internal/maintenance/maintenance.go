@@ -0,0 +1,90 @@
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	stateKey = "maintenance:state"
+
+	// DefaultMessage is shown to users when maintenance is enabled without a message
+	DefaultMessage = "Scheduled maintenance is in progress. Please try again shortly."
+)
+
+// State is the platform-wide maintenance flag
+type State struct {
+	Enabled bool       `json:"enabled"`
+	Message string     `json:"message,omitempty"`
+	Since   *time.Time `json:"since,omitempty"`
+}
+
+// Store reads and writes the maintenance flag in Redis, so every API
+// instance and worker sees the same value
+type Store struct {
+	redis *redis.Client
+}
+
+// NewStore creates a new maintenance store
+func NewStore(redisClient *redis.Client) *Store {
+	return &Store{
+		redis: redisClient,
+	}
+}
+
+// Get returns the current maintenance state; maintenance is off if it was never set
+func (s *Store) Get(ctx context.Context) (*State, error) {
+	return s.QueueGet(ctx, s.redis)()
+}
+
+// QueueGet adds reading the state to pipe and returns a func that gives the
+// result Get would, once pipe has run. pipe may be a client, which runs the
+// command at once.
+func (s *Store) QueueGet(ctx context.Context, pipe redis.Cmdable) func() (*State, error) {
+	get := pipe.Get(ctx, stateKey)
+	return func() (*State, error) {
+		data, err := get.Bytes()
+		if errors.Is(err, redis.Nil) {
+			return &State{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var state State
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, err
+		}
+		return &state, nil
+	}
+}
+
+// Enable turns maintenance mode on with the given user-facing message
+func (s *Store) Enable(ctx context.Context, message string) (*State, error) {
+	if message == "" {
+		message = DefaultMessage
+	}
+	now := time.Now().UTC()
+	state := &State{Enabled: true, Message: message, Since: &now}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.redis.Set(ctx, stateKey, data, 0).Err(); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Disable turns maintenance mode off
+func (s *Store) Disable(ctx context.Context) (*State, error) {
+	if err := s.redis.Del(ctx, stateKey).Err(); err != nil {
+		return nil, err
+	}
+	return &State{}, nil
+}
@@ -0,0 +1,141 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif"  // Register GIF decoder
+	_ "image/jpeg" // Register JPEG decoder
+	"image/png"
+)
+
+const (
+	// AvatarSize is the width and height of processed avatars in pixels
+	AvatarSize = 256
+	// MaxImageBytes is the largest image upload accepted
+	MaxImageBytes = 5 << 20
+	// maxImageDimension guards against decompression bombs
+	maxImageDimension = 4096
+)
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image format")
+	ErrImageTooLarge    = errors.New("image dimensions are too large")
+)
+
+// ProcessAvatar decodes an uploaded image, crops it to a centered square,
+// resizes it to AvatarSize and re-encodes it as PNG
+func ProcessAvatar(data []byte) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if cfg.Width > maxImageDimension || cfg.Height > maxImageDimension {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	dst := resize(src, centerSquare(src.Bounds()), AvatarSize)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// centerSquare returns the largest square centered within bounds
+func centerSquare(bounds image.Rectangle) image.Rectangle {
+	w, h := bounds.Dx(), bounds.Dy()
+	if w > h {
+		offset := (w - h) / 2
+		return image.Rect(bounds.Min.X+offset, bounds.Min.Y, bounds.Min.X+offset+h, bounds.Max.Y)
+	}
+	offset := (h - w) / 2
+	return image.Rect(bounds.Min.X, bounds.Min.Y+offset, bounds.Max.X, bounds.Min.Y+offset+w)
+}
+
+// resize scales the src region to a size x size image using box filtering.
+// Each destination pixel averages the source pixels it covers, falling back
+// to the nearest pixel when upscaling.
+func resize(src image.Image, region image.Rectangle, size int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	w, h := region.Dx(), region.Dy()
+
+	for y := 0; y < size; y++ {
+		sy0 := region.Min.Y + y*h/size
+		sy1 := region.Min.Y + (y+1)*h/size
+		if sy1 <= sy0 {
+			sy1 = sy0 + 1
+		}
+		for x := 0; x < size; x++ {
+			sx0 := region.Min.X + x*w/size
+			sx1 := region.Min.X + (x+1)*w/size
+			if sx1 <= sx0 {
+				sx1 = sx0 + 1
+			}
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					b += uint64(pb)
+					a += uint64(pa)
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+
+	return dst
+}
+
+// ImageInfo describes an uploaded image
+type ImageInfo struct {
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+}
+
+// imageFormats maps decoder format names to content types and file extensions
+var imageFormats = map[string]struct{ contentType, ext string }{
+	"jpeg": {"image/jpeg", ".jpg"},
+	"png":  {"image/png", ".png"},
+	"gif":  {"image/gif", ".gif"},
+}
+
+// InspectImage validates that data is a supported image and returns its metadata
+func InspectImage(data []byte) (*ImageInfo, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	f, ok := imageFormats[format]
+	if !ok {
+		return nil, ErrUnsupportedImage
+	}
+	if cfg.Width > maxImageDimension || cfg.Height > maxImageDimension {
+		return nil, ErrImageTooLarge
+	}
+	return &ImageInfo{
+		ContentType: f.contentType,
+		Extension:   f.ext,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+	}, nil
+}
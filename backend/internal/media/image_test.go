@@ -0,0 +1,69 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func encodeTestPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestProcessAvatar(t *testing.T) {
+	tests := []struct {
+		name string
+		w, h int
+	}{
+		{"landscape", 800, 400},
+		{"portrait", 300, 900},
+		{"small upscale", 64, 64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := ProcessAvatar(encodeTestPNG(t, tt.w, tt.h))
+			if err != nil {
+				t.Fatalf("ProcessAvatar failed: %v", err)
+			}
+
+			img, err := png.Decode(bytes.NewReader(out))
+			if err != nil {
+				t.Fatalf("Output is not a valid PNG: %v", err)
+			}
+			if b := img.Bounds(); b.Dx() != AvatarSize || b.Dy() != AvatarSize {
+				t.Errorf("Expected %dx%d avatar, got %dx%d", AvatarSize, AvatarSize, b.Dx(), b.Dy())
+			}
+			if r, _, _, _ := img.At(AvatarSize/2, AvatarSize/2).RGBA(); r>>8 != 255 {
+				t.Errorf("Expected colour to be preserved, got red=%d", r>>8)
+			}
+		})
+	}
+}
+
+func TestProcessAvatar_InvalidImage(t *testing.T) {
+	if _, err := ProcessAvatar([]byte("not an image")); err != ErrUnsupportedImage {
+		t.Errorf("Expected ErrUnsupportedImage, got: %v", err)
+	}
+}
+
+func TestCenterSquare(t *testing.T) {
+	got := centerSquare(image.Rect(0, 0, 200, 100))
+	want := image.Rect(50, 0, 150, 100)
+	if got != want {
+		t.Errorf("centerSquare = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+)
+
+// Rendition is a derived copy of a video that a platform needs in a
+// particular shape, encoded as H.264 and AAC in MP4
+type Rendition struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// RenditionVertical is the 9:16 full HD rendition for vertical video
+// platforms, such as YouTube Shorts
+const RenditionVertical = "vertical"
+
+// VideoRenditions are made for every uploaded video
+var VideoRenditions = []Rendition{
+	{Name: RenditionVertical, Width: 1080, Height: 1920},
+}
+
+// RenditionKey returns the storage key of a rendition of the media stored
+// under key
+func RenditionKey(key, name string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "-" + name + ".mp4"
+}
+
+// Transcoder makes a rendition of the video file at src in dst
+type Transcoder interface {
+	Transcode(ctx context.Context, src, dst string, r Rendition) error
+}
+
+// FFmpeg transcodes with the ffmpeg binary at Path
+type FFmpeg struct {
+	Path string
+}
+
+// Transcode scales the video to fit the rendition, padding the rest with
+// black rather than cropping, and writes it atomically to dst
+func (f *FFmpeg) Transcode(ctx context.Context, src, dst string, r Rendition) error {
+	tmp := dst + ".tmp.mp4"
+	defer os.Remove(tmp)
+
+	out, err := exec.CommandContext(ctx, f.Path, r.ffmpegArgs(src, tmp)...).CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if len(msg) > 500 {
+			msg = msg[len(msg)-500:]
+		}
+		return fmt.Errorf("ffmpeg %s rendition: %w: %s", r.Name, err, msg)
+	}
+	return os.Rename(tmp, dst)
+}
+
+func (r Rendition) ffmpegArgs(src, dst string) []string {
+	filter := fmt.Sprintf("scale=%[1]d:%[2]d:force_original_aspect_ratio=decrease,pad=%[1]d:%[2]d:(ow-iw)/2:(oh-ih)/2,setsar=1", r.Width, r.Height)
+	return []string{
+		"-hide_banner", "-loglevel", "error",
+		"-i", src,
+		"-vf", filter,
+		"-c:v", "libx264", "-preset", "veryfast", "-crf", "23", "-pix_fmt", "yuv420p",
+		"-c:a", "aac", "-b:a", "128k",
+		"-movflags", "+faststart",
+		"-y", dst,
+	}
+}
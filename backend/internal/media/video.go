@@ -0,0 +1,172 @@
+package media
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// MaxVideoBytes is the largest video upload accepted
+const MaxVideoBytes = 128 << 20
+
+var ErrUnsupportedVideo = errors.New("unsupported video format")
+
+// VideoInfo describes an uploaded video. Width and Height are as displayed,
+// after the track's rotation.
+type VideoInfo struct {
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	Duration    time.Duration
+}
+
+// InspectVideo validates that data is an MP4 or QuickTime video and returns
+// its metadata, read from the movie header and the first video track
+func InspectVideo(data []byte) (*VideoInfo, error) {
+	boxes, ok := readBoxes(data)
+	if !ok || len(boxes) == 0 || boxes[0].typ != "ftyp" || len(boxes[0].body) < 4 {
+		return nil, ErrUnsupportedVideo
+	}
+	info := &VideoInfo{ContentType: "video/mp4", Extension: ".mp4"}
+	if string(boxes[0].body[:4]) == "qt  " {
+		info.ContentType, info.Extension = "video/quicktime", ".mov"
+	}
+
+	moov, ok := findBox(boxes, "moov")
+	if !ok {
+		return nil, ErrUnsupportedVideo
+	}
+	children, ok := readBoxes(moov.body)
+	if !ok {
+		return nil, ErrUnsupportedVideo
+	}
+
+	mvhd, ok := findBox(children, "mvhd")
+	if !ok {
+		return nil, ErrUnsupportedVideo
+	}
+	duration, ok := movieDuration(mvhd.body)
+	if !ok {
+		return nil, ErrUnsupportedVideo
+	}
+	info.Duration = duration
+
+	for _, trak := range children {
+		if trak.typ != "trak" {
+			continue
+		}
+		w, h, ok := videoTrackSize(trak.body)
+		if ok {
+			info.Width, info.Height = w, h
+			return info, nil
+		}
+	}
+	return nil, ErrUnsupportedVideo
+}
+
+// box is an ISO base media file format box
+type box struct {
+	typ  string
+	body []byte
+}
+
+// readBoxes splits data into consecutive boxes
+func readBoxes(data []byte) ([]box, bool) {
+	var boxes []box
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return nil, false
+		}
+		size := uint64(binary.BigEndian.Uint32(data))
+		typ := string(data[4:8])
+		header := uint64(8)
+		switch size {
+		case 0: // Extends to the end of the data
+			size = uint64(len(data))
+		case 1: // 64-bit size follows the type
+			if len(data) < 16 {
+				return nil, false
+			}
+			size, header = binary.BigEndian.Uint64(data[8:]), 16
+		}
+		if size < header || size > uint64(len(data)) {
+			return nil, false
+		}
+		boxes = append(boxes, box{typ: typ, body: data[header:size]})
+		data = data[size:]
+	}
+	return boxes, true
+}
+
+func findBox(boxes []box, typ string) (box, bool) {
+	for _, b := range boxes {
+		if b.typ == typ {
+			return b, true
+		}
+	}
+	return box{}, false
+}
+
+// movieDuration reads the duration of an mvhd box
+func movieDuration(b []byte) (time.Duration, bool) {
+	var timescale, duration uint64
+	switch {
+	case len(b) >= 20 && b[0] == 0:
+		timescale = uint64(binary.BigEndian.Uint32(b[12:]))
+		duration = uint64(binary.BigEndian.Uint32(b[16:]))
+	case len(b) >= 32 && b[0] == 1:
+		timescale = uint64(binary.BigEndian.Uint32(b[20:]))
+		duration = binary.BigEndian.Uint64(b[24:])
+	default:
+		return 0, false
+	}
+	if timescale == 0 {
+		return 0, false
+	}
+	seconds := duration / timescale
+	rest := duration % timescale
+	return time.Duration(seconds)*time.Second + time.Duration(rest*uint64(time.Second)/timescale), true
+}
+
+// videoTrackSize returns the display size of a trak box if it is a video
+// track, swapping width and height for tracks rotated a quarter turn
+func videoTrackSize(trak []byte) (int, int, bool) {
+	children, ok := readBoxes(trak)
+	if !ok {
+		return 0, 0, false
+	}
+	mdia, ok := findBox(children, "mdia")
+	if !ok {
+		return 0, 0, false
+	}
+	mdiaChildren, ok := readBoxes(mdia.body)
+	if !ok {
+		return 0, 0, false
+	}
+	hdlr, ok := findBox(mdiaChildren, "hdlr")
+	if !ok || len(hdlr.body) < 12 || string(hdlr.body[8:12]) != "vide" {
+		return 0, 0, false
+	}
+
+	tkhd, ok := findBox(children, "tkhd")
+	if !ok {
+		return 0, 0, false
+	}
+	// The 3x3 matrix and 16.16 fixed point width and height end the box
+	matrix := 40
+	if len(tkhd.body) > 0 && tkhd.body[0] == 1 {
+		matrix = 52
+	}
+	if len(tkhd.body) < matrix+44 {
+		return 0, 0, false
+	}
+	m := tkhd.body[matrix:]
+	w := int(binary.BigEndian.Uint32(m[36:]) >> 16)
+	h := int(binary.BigEndian.Uint32(m[40:]) >> 16)
+	a, d := binary.BigEndian.Uint32(m[0:]), binary.BigEndian.Uint32(m[16:])
+	if a == 0 && d == 0 {
+		w, h = h, w
+	}
+	return w, h, w > 0 && h > 0
+}
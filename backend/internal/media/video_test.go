@@ -0,0 +1,93 @@
+package media
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func testBox(typ string, body ...[]byte) []byte {
+	size := 8
+	for _, b := range body {
+		size += len(b)
+	}
+	out := binary.BigEndian.AppendUint32(nil, uint32(size))
+	out = append(out, typ...)
+	for _, b := range body {
+		out = append(out, b...)
+	}
+	return out
+}
+
+// testVideo builds a minimal MP4 with a movie header and one track
+func testVideo(brand, handler string, w, h int, rotated bool, seconds uint32) []byte {
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:], 1000) // Timescale
+	binary.BigEndian.PutUint32(mvhd[16:], seconds*1000+500)
+
+	tkhd := make([]byte, 84)
+	matrix := tkhd[40:]
+	if rotated {
+		binary.BigEndian.PutUint32(matrix[4:], 0x00010000)
+		binary.BigEndian.PutUint32(matrix[12:], 0xFFFF0000)
+	} else {
+		binary.BigEndian.PutUint32(matrix[0:], 0x00010000)
+		binary.BigEndian.PutUint32(matrix[16:], 0x00010000)
+	}
+	binary.BigEndian.PutUint32(matrix[32:], 0x40000000)
+	binary.BigEndian.PutUint32(tkhd[76:], uint32(w)<<16)
+	binary.BigEndian.PutUint32(tkhd[80:], uint32(h)<<16)
+
+	hdlr := make([]byte, 25)
+	copy(hdlr[8:], handler)
+
+	trak := testBox("trak", testBox("tkhd", tkhd), testBox("mdia", testBox("hdlr", hdlr)))
+	moov := testBox("moov", testBox("mvhd", mvhd), trak)
+	ftyp := testBox("ftyp", []byte(brand), make([]byte, 4))
+	return append(append(ftyp, testBox("mdat", make([]byte, 32))...), moov...)
+}
+
+func TestInspectVideo(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+		w, h        int
+	}{
+		{"mp4", testVideo("isom", "vide", 1920, 1080, false, 12), "video/mp4", 1920, 1080},
+		{"rotated phone video", testVideo("mp42", "vide", 1920, 1080, true, 12), "video/mp4", 1080, 1920},
+		{"quicktime", testVideo("qt  ", "vide", 1280, 720, false, 12), "video/quicktime", 1280, 720},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := InspectVideo(tt.data)
+			if err != nil {
+				t.Fatalf("InspectVideo failed: %v", err)
+			}
+			if info.ContentType != tt.contentType || info.Width != tt.w || info.Height != tt.h {
+				t.Errorf("info = %+v, want %s %dx%d", info, tt.contentType, tt.w, tt.h)
+			}
+			if info.Duration != 12500*time.Millisecond {
+				t.Errorf("Duration = %v, want 12.5s", info.Duration)
+			}
+		})
+	}
+}
+
+func TestInspectVideo_Unsupported(t *testing.T) {
+	audio := testVideo("isom", "soun", 0, 0, false, 12)
+	truncated := testVideo("isom", "vide", 1920, 1080, false, 12)
+	truncated = truncated[:len(truncated)-10]
+
+	for name, data := range map[string][]byte{
+		"png":       encodeTestPNG(t, 10, 10),
+		"audio":     audio,
+		"truncated": truncated,
+		"empty":     nil,
+	} {
+		if _, err := InspectVideo(data); err != ErrUnsupportedVideo {
+			t.Errorf("%s: err = %v, want ErrUnsupportedVideo", name, err)
+		}
+	}
+}
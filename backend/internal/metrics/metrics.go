@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "scheduler"
+
+// PublishLag tracks how long after scheduled_at each post was actually published
+var PublishLag = promauto.NewSummaryVec(prometheus.SummaryOpts{
+	Namespace:  namespace,
+	Name:       "publish_lag_seconds",
+	Help:       "Delay between a post's scheduled time and its publish time.",
+	Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+}, []string{"channel"})
+
+// LagAlerts counts publish lag alerts fired
+var LagAlerts = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "publish_lag_alerts_total",
+	Help:      "Publish lag alerts fired because lag exceeded the configured threshold.",
+}, []string{"channel"})
+
+// CircuitOpen is 1 while a channel's publishing circuit breaker is open
+var CircuitOpen = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Name:      "channel_circuit_open",
+	Help:      "Whether the channel's publishing circuit breaker is open (1) or closed (0).",
+}, []string{"channel"})
+
+// PublishTimeouts counts publish attempts abandoned at the publish deadline
+var PublishTimeouts = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "publish_timeouts_total",
+	Help:      "Publish attempts that exceeded the per-post publish timeout.",
+}, []string{"channel"})
+
+// RetriesDeferred counts publish retries moved later to stay within the retry budget
+var RetriesDeferred = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "publish_retries_deferred_total",
+	Help:      "Publish retries moved to a later minute because the per-minute retry budget was used up.",
+})
+
+// HTTPRequestDuration tracks API request latency per route
+var HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "http_request_duration_seconds",
+	Help:      "Latency of API requests by method, route pattern and status.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"method", "route", "status"})
+
+// HTTPResponseSize tracks API response body sizes per route
+var HTTPResponseSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "http_response_size_bytes",
+	Help:      "Size of API response bodies by method and route pattern.",
+	Buckets:   prometheus.ExponentialBuckets(128, 4, 8),
+}, []string{"method", "route"})
+
+// RedisCommandDuration tracks Redis round trips by command; a pipeline is one
+// round trip, labelled "pipeline"
+var RedisCommandDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "redis_command_duration_seconds",
+	Help:      "Latency of Redis round trips by command, with pipelines as \"pipeline\".",
+	Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 14),
+}, []string{"command"})
+
+// RedisErrors counts failed Redis round trips by command. Missing keys aren't failures.
+var RedisErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "redis_errors_total",
+	Help:      "Redis round trips that failed, by command.",
+}, []string{"command"})
+
+// RedisPipelineCommands tracks how many commands each pipeline batches
+var RedisPipelineCommands = promauto.NewHistogram(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "redis_pipeline_commands",
+	Help:      "Commands sent in each Redis pipeline.",
+	Buckets:   prometheus.ExponentialBuckets(1, 2, 8),
+})
+
+// NotifierChannels is the number of per-user Redis channels the notifier is
+// subscribed to, one per user with an open SSE stream on this instance
+var NotifierChannels = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Name:      "notifier_redis_channels",
+	Help:      "Per-user Redis pub/sub channels this instance's notifier is subscribed to.",
+})
+
+// Handler serves metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
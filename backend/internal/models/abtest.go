@@ -0,0 +1,208 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/textmetrics"
+)
+
+const (
+	// DefaultABWindowHours is how long each variant collects engagement when unset
+	DefaultABWindowHours = 24
+	// MaxABWindowHours is the longest engagement window of a variant
+	MaxABWindowHours = 7 * 24
+)
+
+// ABVariant identifies one side of an A/B test, or its outcome
+type ABVariant string
+
+const (
+	ABVariantA     ABVariant = "a"
+	ABVariantB     ABVariant = "b"
+	ABTie          ABVariant = "tie"
+	ABInconclusive ABVariant = "inconclusive" // A variant failed or has no engagement reported
+)
+
+// ABTest attaches a second content variant to a post. The post publishes
+// variant A; once its window has passed the worker publishes variant B, and
+// once B's window has passed it picks the variant with the better engagement.
+type ABTest struct {
+	VariantB    string `json:"variant_b"`
+	WindowHours int    `json:"window_hours"`
+	AutoRepost  bool   `json:"auto_repost"` // Repost the winner once the test completes
+
+	Winner    *ABVariant `json:"winner,omitempty"`
+	DecidedAt *time.Time `json:"decided_at,omitempty"`
+}
+
+// Window returns how long each variant collects engagement
+func (t *ABTest) Window() time.Duration {
+	return time.Duration(t.WindowHours) * time.Hour
+}
+
+// ValidateABTest checks an A/B test against the post's channel and variant A
+// content, trimming variant B and defaulting the window. Nil tests are valid.
+func ValidateABTest(c Channel, content string, t *ABTest) error {
+	if t == nil {
+		return nil
+	}
+
+	t.VariantB = strings.TrimSpace(t.VariantB)
+	if n := textmetrics.Graphemes(t.VariantB); n < 3 {
+		return errors.New("variant_b must be at least 3 characters")
+	} else if n > 5000 {
+		return errors.New("variant_b must not exceed 5000 characters")
+	}
+	if t.VariantB == content {
+		return errors.New("variant_b must differ from content")
+	}
+	if err := ValidateChannelContent(c, t.VariantB); err != nil {
+		return fmt.Errorf("variant_b: %w", err)
+	}
+
+	if t.WindowHours == 0 {
+		t.WindowHours = DefaultABWindowHours
+	}
+	if t.WindowHours < 1 || t.WindowHours > MaxABWindowHours {
+		return fmt.Errorf("ab_test window_hours must be between 1 and %d", MaxABWindowHours)
+	}
+
+	t.Winner = nil
+	t.DecidedAt = nil
+	return nil
+}
+
+// Engagement is a published post's performance as reported by the platform
+type Engagement struct {
+	Impressions int64 `json:"impressions"`
+	Likes       int64 `json:"likes"`
+	Comments    int64 `json:"comments"`
+	Shares      int64 `json:"shares"`
+	Clicks      int64 `json:"clicks"`
+}
+
+// Validate checks that no count is negative
+func (e Engagement) Validate() error {
+	if e.Impressions < 0 || e.Likes < 0 || e.Comments < 0 || e.Shares < 0 || e.Clicks < 0 {
+		return errors.New("engagement counts must not be negative")
+	}
+	return nil
+}
+
+// Interactions returns the total likes, comments, shares and clicks
+func (e Engagement) Interactions() int64 {
+	return e.Likes + e.Comments + e.Shares + e.Clicks
+}
+
+// Rate returns interactions per impression, or zero without impressions
+func (e Engagement) Rate() float64 {
+	if e.Impressions <= 0 {
+		return 0
+	}
+	return float64(e.Interactions()) / float64(e.Impressions)
+}
+
+// CompareEngagement picks the better of two variants' engagement: by
+// engagement rate when both report impressions, by interactions otherwise
+func CompareEngagement(a, b *Engagement) ABVariant {
+	if a == nil || b == nil {
+		return ABInconclusive
+	}
+
+	var scoreA, scoreB float64
+	if a.Impressions > 0 && b.Impressions > 0 {
+		scoreA, scoreB = a.Rate(), b.Rate()
+	} else {
+		scoreA, scoreB = float64(a.Interactions()), float64(b.Interactions())
+	}
+
+	switch {
+	case scoreA > scoreB:
+		return ABVariantA
+	case scoreB > scoreA:
+		return ABVariantB
+	default:
+		return ABTie
+	}
+}
+
+// ABVariantResult is one variant's post and performance
+type ABVariantResult struct {
+	Variant        ABVariant   `json:"variant"`
+	PostID         *uuid.UUID  `json:"post_id,omitempty"` // Nil until variant B is created
+	Content        string      `json:"content"`
+	Status         PostStatus  `json:"status,omitempty"`
+	PublishedAt    *time.Time  `json:"published_at,omitempty"`
+	Engagement     *Engagement `json:"engagement,omitempty"`
+	EngagementRate float64     `json:"engagement_rate"`
+}
+
+// ABComparison reports the progress and outcome of a post's A/B test
+type ABComparison struct {
+	Status      string             `json:"status"` // collecting_a, collecting_b or complete
+	WindowHours int                `json:"window_hours"`
+	NextStepAt  *time.Time         `json:"next_step_at,omitempty"` // When variant B publishes or the winner is picked
+	Leader      ABVariant          `json:"leader"`                 // Better variant on engagement reported so far
+	Winner      *ABVariant         `json:"winner,omitempty"`
+	AutoRepost  bool               `json:"auto_repost"`
+	Variants    [2]ABVariantResult `json:"variants"`
+}
+
+// NewABComparison summarizes the test of post a, whose variant B post is b
+// (nil until it has been created)
+func NewABComparison(a, b *Post) ABComparison {
+	test := a.ABTest
+	c := ABComparison{
+		Status:      "collecting_a",
+		WindowHours: test.WindowHours,
+		Winner:      test.Winner,
+		AutoRepost:  test.AutoRepost,
+		Variants: [2]ABVariantResult{
+			variantResult(ABVariantA, a),
+			{Variant: ABVariantB, Content: test.VariantB},
+		},
+	}
+	if a.PublishedAt != nil {
+		next := a.PublishedAt.Add(test.Window())
+		c.NextStepAt = &next
+	}
+
+	var bEngagement *Engagement
+	if b != nil {
+		c.Variants[1] = variantResult(ABVariantB, b)
+		bEngagement = b.Engagement
+		c.Status = "collecting_b"
+		c.NextStepAt = nil
+		if b.PublishedAt != nil {
+			next := b.PublishedAt.Add(test.Window())
+			c.NextStepAt = &next
+		}
+	}
+
+	c.Leader = CompareEngagement(a.Engagement, bEngagement)
+	if test.DecidedAt != nil {
+		c.Status = "complete"
+		c.NextStepAt = nil
+	}
+	return c
+}
+
+// variantResult reports a variant's post and engagement
+func variantResult(v ABVariant, p *Post) ABVariantResult {
+	r := ABVariantResult{
+		Variant:     v,
+		PostID:      &p.ID,
+		Content:     p.Content,
+		Status:      p.Status,
+		PublishedAt: p.PublishedAt,
+		Engagement:  p.Engagement,
+	}
+	if p.Engagement != nil {
+		r.EngagementRate = p.Engagement.Rate()
+	}
+	return r
+}
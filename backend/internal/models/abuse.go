@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// AbuseReport is a user or client IP flagged by abuse detection, for admin review
+type AbuseReport struct {
+	Subject   string         `json:"subject"` // "user:<id>" or "ip:<address>"
+	Score     int            `json:"score"`
+	Signals   map[string]int `json:"signals"`   // Times each heuristic fired, e.g. "duplicate_content"
+	Throttled bool           `json:"throttled"` // Rate limits are reduced
+	HeldUntil *time.Time     `json:"held_until,omitempty"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// MaxAnalyticsDays is the longest range the admin analytics endpoint returns
+const MaxAnalyticsDays = 90
+
+// Metric names a system-wide time series
+type Metric string
+
+const (
+	MetricPostsCreated   Metric = "posts_created"
+	MetricPostsPublished Metric = "posts_published"
+	MetricPostsFailed    Metric = "posts_failed" // Posts whose latest attempt failed, by time of failure
+	MetricSignups        Metric = "signups"
+)
+
+// Granularity is the bucket size of a time series
+type Granularity string
+
+const (
+	GranularityHour Granularity = "hour"
+	GranularityDay  Granularity = "day"
+)
+
+// MetricPoint is one bucket of a time series
+type MetricPoint struct {
+	Bucket time.Time `json:"bucket"`
+	Value  int64     `json:"value"`
+}
+
+// MetricSeries is a rolled-up system-wide time series
+type MetricSeries struct {
+	Metric      Metric        `json:"metric"`
+	Granularity Granularity   `json:"granularity"`
+	Points      []MetricPoint `json:"points"` // Oldest first; empty buckets are omitted
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxAccountGrowthDays is the longest range of follower counts returned
+const MaxAccountGrowthDays = 366
+
+// FollowerCount is a connected account's follower count on one day (UTC)
+type FollowerCount struct {
+	UserID      uuid.UUID `json:"-"`
+	Channel     Channel   `json:"channel"`
+	AccountName *string   `json:"account_name,omitempty"` // The account's name when counted
+	Day         time.Time `json:"day"`
+	Followers   int64     `json:"followers"`
+}
+
+// FollowerPoint is one day of an account's growth chart
+type FollowerPoint struct {
+	Day       time.Time `json:"day"`
+	Followers int64     `json:"followers"`
+}
+
+// AccountGrowth is the follower counts of one channel's account over a range
+type AccountGrowth struct {
+	Channel     Channel         `json:"channel"`
+	AccountName *string         `json:"account_name,omitempty"` // As of the latest count
+	Followers   int64           `json:"followers"`              // Latest count
+	Change      int64           `json:"change"`                 // Since the first count in the range
+	Points      []FollowerPoint `json:"points"`                 // Oldest first; days without a count are omitted
+}
+
+// NewAccountGrowth groups follower counts by channel, in channel order
+func NewAccountGrowth(counts []FollowerCount) []AccountGrowth {
+	byChannel := make(map[Channel]*AccountGrowth)
+	latest := make(map[Channel]time.Time)
+	for _, c := range counts {
+		g := byChannel[c.Channel]
+		if g == nil {
+			g = &AccountGrowth{Channel: c.Channel}
+			byChannel[c.Channel] = g
+		}
+		g.Points = append(g.Points, FollowerPoint{Day: c.Day, Followers: c.Followers})
+		if day, ok := latest[c.Channel]; !ok || !c.Day.Before(day) {
+			latest[c.Channel] = c.Day
+			g.AccountName = c.AccountName
+		}
+	}
+
+	accounts := make([]AccountGrowth, 0, len(byChannel))
+	for _, g := range byChannel {
+		sort.Slice(g.Points, func(i, j int) bool { return g.Points[i].Day.Before(g.Points[j].Day) })
+		first, last := g.Points[0], g.Points[len(g.Points)-1]
+		g.Followers = last.Followers
+		g.Change = last.Followers - first.Followers
+		accounts = append(accounts, *g)
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Channel < accounts[j].Channel })
+	return accounts
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditAction is a change recorded in an organization's audit log
+type AuditAction string
+
+const (
+	AuditOrganizationCreate      AuditAction = "organization.create"
+	AuditMemberSet               AuditAction = "member.set"    // Added, or their role changed
+	AuditMemberRemove            AuditAction = "member.remove" // Target is the member's user ID
+	AuditPublishingWindowsUpdate AuditAction = "publishing_windows.update"
+	AuditBrandChecklistUpdate    AuditAction = "brand_checklist.update"
+	AuditRetentionUpdate         AuditAction = "retention.update"
+	AuditMediaFolderCreate       AuditAction = "media_folder.create"
+	AuditMediaFolderDelete       AuditAction = "media_folder.delete"
+	AuditPostCreate              AuditAction = "post.create"
+	AuditPostUpdate              AuditAction = "post.update"
+	AuditPostDelete              AuditAction = "post.delete"
+	AuditPostApprove             AuditAction = "post.approve"
+	AuditPostReject              AuditAction = "post.reject"
+	AuditPostWorkflow            AuditAction = "post.workflow"
+)
+
+// AuditEntry is one change in an organization's audit log
+type AuditEntry struct {
+	ID         uuid.UUID      `json:"id"`
+	OrgID      uuid.UUID      `json:"org_id"`
+	ActorID    *uuid.UUID     `json:"actor_id,omitempty"`    // Unset once the account is deleted
+	ActorEmail *string        `json:"actor_email,omitempty"` // As it was at the time
+	Action     AuditAction    `json:"action"`
+	TargetID   *uuid.UUID     `json:"target_id,omitempty"` // The member, folder or post acted on
+	Details    map[string]any `json:"details,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/textmetrics"
+)
+
+// Bio page limits
+const (
+	// MaxBioLinks is the most links a user's bio page may show
+	MaxBioLinks = 50
+	// MaxBioLinkTitleLength is the longest link title
+	MaxBioLinkTitleLength = 100
+	// MaxBioLinkURLLength is the longest link URL
+	MaxBioLinkURLLength = 2048
+)
+
+// BioLink is a link on a user's public bio page, optionally for one of their
+// published posts
+type BioLink struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"-"`
+	PostID    *uuid.UUID   `json:"post_id,omitempty"`
+	Post      *BioLinkPost `json:"post,omitempty"` // Set when the link is for a post
+	Title     string       `json:"title"`
+	URL       string       `json:"url"`
+	Position  int          `json:"position"`
+	Clicks    int64        `json:"clicks"` // Through the bio page
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// BioLinkPost is the published post a bio link is for
+type BioLinkPost struct {
+	Channel     Channel    `json:"channel"`
+	Excerpt     string     `json:"excerpt"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+// NewBioLinkPost describes a post for its bio link
+func NewBioLinkPost(p *Post) *BioLinkPost {
+	return &BioLinkPost{Channel: p.Channel, Excerpt: postExcerpt(p), PublishedAt: p.PublishedAt}
+}
+
+// CreateBioLinkRequest represents the request to add a link to the bio
+// page. A link for a post defaults its title to the post's and its URL to
+// the first link in the post's content.
+type CreateBioLinkRequest struct {
+	Title  string     `json:"title"`
+	URL    string     `json:"url"`
+	PostID *uuid.UUID `json:"post_id"`
+}
+
+// FillFromPost defaults the title to the post's title or excerpt and the URL
+// to the first link in its content
+func (r *CreateBioLinkRequest) FillFromPost(p *Post) {
+	if strings.TrimSpace(r.Title) == "" {
+		r.Title = postExcerpt(p)
+	}
+	if strings.TrimSpace(r.URL) == "" {
+		r.URL = textmetrics.FirstLink(p.Content)
+	}
+}
+
+// Validate checks the title and URL, trimming them
+func (r *CreateBioLinkRequest) Validate() error {
+	r.Title = strings.TrimSpace(r.Title)
+	r.URL = strings.TrimSpace(r.URL)
+	if r.Title == "" {
+		return errors.New("title is required")
+	}
+	if utf8.RuneCountInString(r.Title) > MaxBioLinkTitleLength {
+		return fmt.Errorf("title must not exceed %d characters", MaxBioLinkTitleLength)
+	}
+	if r.URL == "" {
+		return errors.New("url is required")
+	}
+	if len(r.URL) > MaxBioLinkURLLength || !isHTTPURL(r.URL) {
+		return errors.New("url must be an http or https URL")
+	}
+	return nil
+}
+
+// ReorderBioLinksRequest sets the order of the links on the bio page
+type ReorderBioLinksRequest struct {
+	LinkIDs []uuid.UUID `json:"link_ids"` // Every link, first shown first
+}
+
+// BioTokenResponse returns a newly generated bio page token. The token is
+// only shown once.
+type BioTokenResponse struct {
+	Token    string `json:"token"`
+	HTMLPath string `json:"html_path"`
+	JSONPath string `json:"json_path"`
+}
+
+// BioPage is a user's public bio page
+type BioPage struct {
+	Title     string        `json:"title"`
+	AvatarURL *string       `json:"avatar_url,omitempty"`
+	Links     []BioPageLink `json:"links"`
+}
+
+// BioPageLink is a link as shown on the public bio page. Its URL goes
+// through the page's redirect, which counts the click.
+type BioPageLink struct {
+	Title string       `json:"title"`
+	URL   string       `json:"url"`
+	Post  *BioLinkPost `json:"post,omitempty"`
+}
@@ -0,0 +1,169 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/scheduler/backend/internal/textmetrics"
+)
+
+// Brand checklist limits
+const (
+	// MaxBannedWords is the most banned words or phrases a checklist may list
+	MaxBannedWords = 200
+	// MaxBannedWordLength is the longest banned word or phrase
+	MaxBannedWordLength = 100
+)
+
+// BrandCheck is a check an organization requires its posts to pass before
+// they can be scheduled
+type BrandCheck string
+
+const (
+	BrandCheckLink        BrandCheck = "link"         // The content has a link
+	BrandCheckMedia       BrandCheck = "media"        // The post has an attachment
+	BrandCheckBannedWords BrandCheck = "banned_words" // The title and content use none of the banned words
+	BrandCheckApproval    BrandCheck = "approval"     // The post has been approved
+)
+
+// brandRule checks a post against one brand check, returning the failure
+type brandRule func(c *BrandChecklist, p BrandPost) *Violation
+
+// brandRules are the rules behind each brand check, run in checklist order
+var brandRules = map[BrandCheck]brandRule{
+	BrandCheckLink:        checkBrandLink,
+	BrandCheckMedia:       checkBrandMedia,
+	BrandCheckBannedWords: checkBannedWords,
+	BrandCheckApproval:    checkBrandApproval,
+}
+
+// BrandChecklist is an organization's brand safety checklist. A checklist
+// without checks lets every post through.
+type BrandChecklist struct {
+	Checks      []BrandCheck `json:"checks"`
+	BannedWords []string     `json:"banned_words"` // Matched as whole words, ignoring case
+}
+
+// BrandPost is the part of a post its brand checks look at
+type BrandPost struct {
+	Title   *string
+	Content string
+	Media   []PostMedia
+
+	// Approved is set when the post was approved on the editorial board or
+	// by a reviewer, or isn't being scheduled without review: posts held for
+	// approval pass the approval check once approved
+	Approved bool
+}
+
+// BrandCheckCode is the error code of a failed brand check
+const BrandCheckCode = "brand_check_failed"
+
+// Validate checks the checklist, removing duplicate checks and banned words
+func (c *BrandChecklist) Validate() error {
+	checks := make([]BrandCheck, 0, len(c.Checks))
+	seen := make(map[BrandCheck]bool, len(c.Checks))
+	for _, check := range c.Checks {
+		if _, ok := brandRules[check]; !ok {
+			return fmt.Errorf("unknown check %q. Must be one of: link, media, banned_words, approval", check)
+		}
+		if !seen[check] {
+			seen[check] = true
+			checks = append(checks, check)
+		}
+	}
+	c.Checks = checks
+
+	words := make([]string, 0, len(c.BannedWords))
+	seenWords := make(map[string]bool, len(c.BannedWords))
+	for _, w := range c.BannedWords {
+		w = strings.TrimSpace(w)
+		if w == "" {
+			continue
+		}
+		if utf8.RuneCountInString(w) > MaxBannedWordLength {
+			return fmt.Errorf("banned words must not exceed %d characters", MaxBannedWordLength)
+		}
+		key := normalizeWords(w)
+		if key == "" {
+			return fmt.Errorf("banned word %q must include a letter or digit", w)
+		}
+		if !seenWords[key] {
+			seenWords[key] = true
+			words = append(words, w)
+		}
+	}
+	if len(words) > MaxBannedWords {
+		return fmt.Errorf("at most %d banned words are allowed", MaxBannedWords)
+	}
+	if seen[BrandCheckBannedWords] && len(words) == 0 {
+		return errors.New("banned_words is required for the banned_words check")
+	}
+	c.BannedWords = words
+	return nil
+}
+
+// Evaluate runs the checklist against a post, returning a violation for each
+// check it fails
+func (c *BrandChecklist) Evaluate(p BrandPost) []Violation {
+	if c == nil {
+		return nil
+	}
+	var violations []Violation
+	for _, check := range c.Checks {
+		if rule, ok := brandRules[check]; ok {
+			if v := rule(c, p); v != nil {
+				violations = append(violations, *v)
+			}
+		}
+	}
+	return violations
+}
+
+func checkBrandLink(c *BrandChecklist, p BrandPost) *Violation {
+	if textmetrics.HasLink(p.Content) {
+		return nil
+	}
+	return &Violation{Field: "content", Code: BrandCheckCode, Message: "Brand checklist: content must include a link"}
+}
+
+func checkBrandMedia(c *BrandChecklist, p BrandPost) *Violation {
+	if len(p.Media) > 0 {
+		return nil
+	}
+	return &Violation{Field: "media", Code: BrandCheckCode, Message: "Brand checklist: post must have an image or video attached"}
+}
+
+func checkBannedWords(c *BrandChecklist, p BrandPost) *Violation {
+	text := p.Content
+	if p.Title != nil {
+		text = *p.Title + "\n" + text
+	}
+	// Pad with spaces so each banned word matches whole words only
+	normalized := " " + normalizeWords(text) + " "
+	for _, w := range c.BannedWords {
+		if strings.Contains(normalized, " "+normalizeWords(w)+" ") {
+			return &Violation{Field: "content", Code: BrandCheckCode, Message: fmt.Sprintf("Brand checklist: %q is a banned word", w)}
+		}
+	}
+	return nil
+}
+
+func checkBrandApproval(c *BrandChecklist, p BrandPost) *Violation {
+	if p.Approved {
+		return nil
+	}
+	return &Violation{Field: "workflow_state", Code: BrandCheckCode, Message: "Brand checklist: post must be approved before it is scheduled"}
+}
+
+// normalizeWords lowercases text and joins its words with single spaces,
+// dropping punctuation
+func normalizeWords(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return strings.Join(words, " ")
+}
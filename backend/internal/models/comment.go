@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxCommentLength is the longest comment body accepted
+const MaxCommentLength = 2000
+
+// Comment is a review comment on a post. Replies are nested under the
+// comment they answer when a thread is listed.
+type Comment struct {
+	ID          uuid.UUID  `json:"id"`
+	PostID      uuid.UUID  `json:"post_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	AuthorEmail string     `json:"author_email"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+	Body        string     `json:"body"`
+	CreatedAt   time.Time  `json:"created_at"`
+	Replies     []*Comment `json:"replies"`
+}
+
+// CreateCommentRequest represents the request to comment on a post or reply to a comment
+type CreateCommentRequest struct {
+	Body     string     `json:"body"`
+	ParentID *uuid.UUID `json:"parent_id"`
+}
+
+// Validate trims the body and checks its length
+func (r *CreateCommentRequest) Validate() error {
+	r.Body = strings.TrimSpace(r.Body)
+	if r.Body == "" {
+		return errors.New("body is required")
+	}
+	if utf8.RuneCountInString(r.Body) > MaxCommentLength {
+		return fmt.Errorf("body must not exceed %d characters", MaxCommentLength)
+	}
+	return nil
+}
+
+// BuildCommentThreads nests comments under their parents, keeping the given
+// order among siblings. Comments whose parent is missing are treated as roots.
+func BuildCommentThreads(comments []*Comment) []*Comment {
+	byID := make(map[uuid.UUID]*Comment, len(comments))
+	for _, c := range comments {
+		c.Replies = []*Comment{}
+		byID[c.ID] = c
+	}
+
+	roots := []*Comment{}
+	for _, c := range comments {
+		if c.ParentID != nil {
+			if parent, ok := byID[*c.ParentID]; ok {
+				parent.Replies = append(parent.Replies, c)
+				continue
+			}
+		}
+		roots = append(roots, c)
+	}
+	return roots
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Discord embed limits
+const (
+	MaxDiscordEmbedTitleLength  = 256
+	MaxDiscordEmbedFooterLength = 2048
+)
+
+// DiscordEmbed formats a Discord post as a rich embed: the content becomes
+// its description and the first image its picture
+type DiscordEmbed struct {
+	Title  string `json:"title,omitempty"`  // Defaults to the post's title
+	URL    string `json:"url,omitempty"`    // Link on the title
+	Color  string `json:"color,omitempty"`  // Side bar color as #RRGGBB
+	Footer string `json:"footer,omitempty"` // Small text under the embed
+}
+
+// ColorValue returns the embed color as the integer Discord expects, or 0
+// for the default
+func (e *DiscordEmbed) ColorValue() int {
+	if e.Color == "" {
+		return 0
+	}
+	n, _ := strconv.ParseInt(strings.TrimPrefix(e.Color, "#"), 16, 32)
+	return int(n)
+}
+
+var (
+	// discordWebhookURL matches a channel webhook URL, capturing its ID
+	discordWebhookURL = regexp.MustCompile(`^https://(?:(?:ptb|canary)\.)?discord(?:app)?\.com/api/(?:v[0-9]+/)?webhooks/([0-9]{17,20})/[A-Za-z0-9_-]{60,100}$`)
+	// discordBotToken matches a bot token: three base64url parts joined by dots
+	discordBotToken = regexp.MustCompile(`^[A-Za-z0-9_-]{20,}\.[A-Za-z0-9_-]{4,}\.[A-Za-z0-9_-]{20,}$`)
+	// discordSnowflake matches a Discord ID, such as a channel's
+	discordSnowflake = regexp.MustCompile(`^[0-9]{17,20}$`)
+	// hexColor matches a #RRGGBB color
+	hexColor = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+)
+
+// DiscordWebhookID returns the ID of a Discord webhook URL, and false if
+// token is not one. Connections hold either a webhook URL or a bot token.
+func DiscordWebhookID(token string) (string, bool) {
+	m := discordWebhookURL.FindStringSubmatch(token)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// validateDiscordConnection checks a Discord connection, which posts either
+// through a channel webhook (access_token is the webhook URL, which names
+// the channel itself) or as a bot (access_token is the bot token and target
+// the ID of a channel the bot can send messages in)
+func validateDiscordConnection(r *ConnectChannelRequest) error {
+	if id, ok := DiscordWebhookID(r.AccessToken); ok {
+		if r.Target != nil {
+			return errors.New("target is not used with a Discord webhook URL, which already names its channel")
+		}
+		if r.AccountName == nil {
+			name := "Webhook " + id
+			r.AccountName = &name
+		}
+		return nil
+	}
+
+	if !discordBotToken.MatchString(r.AccessToken) {
+		return errors.New("access_token must be a Discord webhook URL or bot token")
+	}
+	if r.Target == nil {
+		return errors.New("target is required for a Discord bot: the ID of the channel to post in")
+	}
+	if !discordSnowflake.MatchString(*r.Target) {
+		return errors.New("target must be a Discord channel ID")
+	}
+	if r.AccountName == nil {
+		r.AccountName = r.Target
+	}
+	return nil
+}
+
+// validateDiscordTargeting checks the embed options of a Discord post.
+// Discord posts have no visibility, destination or audience.
+func validateDiscordTargeting(t *PostTargeting) error {
+	if t.Visibility != "" || t.Destination != "" || t.PageID != "" || len(t.Audience) > 0 ||
+		t.Subreddit != "" || t.FlairID != "" || t.FlairText != "" ||
+		t.Location != "" || t.Offer != nil || t.CallToAction != nil || t.MadeForKids {
+		return fmt.Errorf("targeting for %s only supports embed", ChannelDiscord)
+	}
+
+	e := t.Embed
+	if e == nil {
+		return nil
+	}
+	e.Title = strings.TrimSpace(e.Title)
+	if utf8.RuneCountInString(e.Title) > MaxDiscordEmbedTitleLength {
+		return fmt.Errorf("targeting embed title must not exceed %d characters", MaxDiscordEmbedTitleLength)
+	}
+	e.URL = strings.TrimSpace(e.URL)
+	if e.URL != "" && !strings.HasPrefix(e.URL, "https://") && !strings.HasPrefix(e.URL, "http://") {
+		return errors.New("targeting embed url must be an http or https URL")
+	}
+	e.Color = strings.TrimSpace(e.Color)
+	if e.Color != "" && !hexColor.MatchString(e.Color) {
+		return errors.New("targeting embed color must be a hex color like #5865F2")
+	}
+	e.Footer = strings.TrimSpace(e.Footer)
+	if utf8.RuneCountInString(e.Footer) > MaxDiscordEmbedFooterLength {
+		return fmt.Errorf("targeting embed footer must not exceed %d characters", MaxDiscordEmbedFooterLength)
+	}
+	return nil
+}
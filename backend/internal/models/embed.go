@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// EmbedTokenResponse returns a newly generated schedule embed token. The
+// token is only shown once.
+type EmbedTokenResponse struct {
+	Token    string `json:"token"`
+	HTMLPath string `json:"html_path"`
+	JSONPath string `json:"json_path"`
+}
+
+// EmbedPost is an upcoming post as a public schedule embed shows it, with
+// its content left out
+type EmbedPost struct {
+	Title       *string   `json:"title,omitempty"`
+	Channel     Channel   `json:"channel"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+// EmbedSchedule is a user's upcoming schedule as embedded on other sites
+type EmbedSchedule struct {
+	Title string      `json:"title"`
+	Posts []EmbedPost `json:"posts"` // Soonest first
+}
+
+// NewEmbedSchedule redacts upcoming post summaries for a public embed
+func NewEmbedSchedule(title string, summaries []*PostSummary) EmbedSchedule {
+	s := EmbedSchedule{Title: title, Posts: make([]EmbedPost, 0, len(summaries))}
+	for _, p := range summaries {
+		s.Posts = append(s.Posts, EmbedPost{Title: p.Title, Channel: p.Channel, ScheduledAt: p.ScheduledAt})
+	}
+	return s
+}
@@ -0,0 +1,9 @@
+package models
+
+// FeedTokenResponse returns a newly generated public feed token. The token is
+// only shown once.
+type FeedTokenResponse struct {
+	Token    string `json:"token"`
+	RSSPath  string `json:"rss_path"`
+	JSONPath string `json:"json_path"`
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Google Business Profile offer limits
+const (
+	MaxBusinessOfferTitleLength = 58
+	MaxBusinessCouponLength     = 58
+	MaxBusinessTermsLength      = 5000
+)
+
+// businessLocation matches a location's resource name
+var businessLocation = regexp.MustCompile(`^accounts/[0-9]+/locations/[0-9]+$`)
+
+// BusinessActionTypes are the call to action buttons a Google Business
+// Profile update can show. CALL dials the location's phone number and takes
+// no URL.
+var BusinessActionTypes = []string{"BOOK", "ORDER", "SHOP", "LEARN_MORE", "SIGN_UP", "CALL"}
+
+// BusinessOffer turns a Google Business Profile post into an offer
+type BusinessOffer struct {
+	Title      string `json:"title"`
+	StartDate  string `json:"start_date"` // YYYY-MM-DD
+	EndDate    string `json:"end_date"`   // YYYY-MM-DD, inclusive
+	CouponCode string `json:"coupon_code,omitempty"`
+	RedeemURL  string `json:"redeem_url,omitempty"`
+	Terms      string `json:"terms,omitempty"`
+}
+
+// CallToAction is the button on a Google Business Profile update
+type CallToAction struct {
+	ActionType string `json:"action_type"`
+	URL        string `json:"url,omitempty"`
+}
+
+// validateGoogleBusinessTargeting checks the location and the offer or call
+// to action of a Google Business Profile post. A post without an offer is
+// an update.
+func validateGoogleBusinessTargeting(t *PostTargeting) error {
+	if t.Visibility != "" || t.Destination != "" || t.PageID != "" || len(t.Audience) > 0 ||
+		t.Subreddit != "" || t.FlairID != "" || t.FlairText != "" || t.Embed != nil || t.MadeForKids {
+		return fmt.Errorf("targeting for %s only supports location, offer and call_to_action", ChannelGoogleBusiness)
+	}
+
+	t.Location = strings.Trim(strings.TrimSpace(t.Location), "/")
+	if !businessLocation.MatchString(t.Location) {
+		return errors.New("targeting location must be a location name like accounts/123/locations/456")
+	}
+
+	if t.Offer != nil && t.CallToAction != nil {
+		return errors.New("targeting call_to_action is not supported on offers; use offer redeem_url")
+	}
+	if t.Offer != nil {
+		return validateBusinessOffer(t.Offer)
+	}
+	if a := t.CallToAction; a != nil {
+		a.ActionType = strings.ToUpper(strings.TrimSpace(a.ActionType))
+		if !containsString(BusinessActionTypes, a.ActionType) {
+			return fmt.Errorf("targeting call_to_action action_type must be one of: %s", strings.Join(BusinessActionTypes, ", "))
+		}
+		a.URL = strings.TrimSpace(a.URL)
+		if a.ActionType == "CALL" && a.URL != "" {
+			return errors.New("targeting call_to_action url is not used with CALL")
+		}
+		if a.ActionType != "CALL" && !isHTTPURL(a.URL) {
+			return errors.New("targeting call_to_action url must be an http or https URL")
+		}
+	}
+	return nil
+}
+
+func validateBusinessOffer(o *BusinessOffer) error {
+	o.Title = strings.TrimSpace(o.Title)
+	if o.Title == "" || utf8.RuneCountInString(o.Title) > MaxBusinessOfferTitleLength {
+		return fmt.Errorf("targeting offer title must be between 1 and %d characters", MaxBusinessOfferTitleLength)
+	}
+	start, err := time.Parse(time.DateOnly, o.StartDate)
+	if err != nil {
+		return errors.New("targeting offer start_date must be a date as YYYY-MM-DD")
+	}
+	end, err := time.Parse(time.DateOnly, o.EndDate)
+	if err != nil {
+		return errors.New("targeting offer end_date must be a date as YYYY-MM-DD")
+	}
+	if end.Before(start) {
+		return errors.New("targeting offer end_date must not be before start_date")
+	}
+	o.CouponCode = strings.TrimSpace(o.CouponCode)
+	if utf8.RuneCountInString(o.CouponCode) > MaxBusinessCouponLength {
+		return fmt.Errorf("targeting offer coupon_code must not exceed %d characters", MaxBusinessCouponLength)
+	}
+	o.RedeemURL = strings.TrimSpace(o.RedeemURL)
+	if o.RedeemURL != "" && !isHTTPURL(o.RedeemURL) {
+		return errors.New("targeting offer redeem_url must be an http or https URL")
+	}
+	o.Terms = strings.TrimSpace(o.Terms)
+	if utf8.RuneCountInString(o.Terms) > MaxBusinessTermsLength {
+		return fmt.Errorf("targeting offer terms must not exceed %d characters", MaxBusinessTermsLength)
+	}
+	return nil
+}
+
+// isHTTPURL reports whether s is an absolute http or https URL
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Host != "" && (u.Scheme == "https" || u.Scheme == "http")
+}
@@ -0,0 +1,21 @@
+package models
+
+// HistoryStatusAll lists published, failed and canceled posts together
+const HistoryStatusAll = "all"
+
+// HistoryStatuses returns the post statuses the history lists for a status
+// filter; an empty filter lists published posts. Returns false if the filter
+// is unknown.
+func HistoryStatuses(filter string) ([]PostStatus, bool) {
+	switch filter {
+	case "", string(PostStatusPublished):
+		return []PostStatus{PostStatusPublished}, true
+	case string(PostStatusFailed):
+		return []PostStatus{PostStatusFailed}, true
+	case string(PostStatusCanceled):
+		return []PostStatus{PostStatusCanceled}, true
+	case HistoryStatusAll:
+		return []PostStatus{PostStatusPublished, PostStatusFailed, PostStatusCanceled}, true
+	}
+	return nil, false
+}
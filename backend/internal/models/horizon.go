@@ -0,0 +1,51 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultScheduleHorizonDays is how many days ahead each plan may schedule posts
+var DefaultScheduleHorizonDays = map[Plan]int{
+	PlanFree: 365,
+	PlanPro:  365,
+}
+
+// SchedulingPolicy bounds when a post may be scheduled
+type SchedulingPolicy struct {
+	HorizonDays map[Plan]int  // Missing plans fall back to the free plan's horizon
+	PastGrace   time.Duration // Tolerated client clock skew; such posts publish right away
+}
+
+// NewSchedulingPolicy returns the default horizons with per-plan overrides applied
+func NewSchedulingPolicy(horizonOverrides map[string]int, pastGrace time.Duration) SchedulingPolicy {
+	horizons := make(map[Plan]int, len(DefaultScheduleHorizonDays))
+	for p, days := range DefaultScheduleHorizonDays {
+		horizons[p] = days
+	}
+	for p, days := range horizonOverrides {
+		horizons[Plan(p)] = days
+	}
+	return SchedulingPolicy{HorizonDays: horizons, PastGrace: pastGrace}
+}
+
+// Horizon returns how many days ahead the plan may schedule posts
+func (s SchedulingPolicy) Horizon(p Plan) int {
+	if days, ok := s.HorizonDays[p]; ok {
+		return days
+	}
+	return s.HorizonDays[PlanFree]
+}
+
+// Check returns an error if a post on plan p may not be scheduled at t, given
+// the current time now
+func (s SchedulingPolicy) Check(p Plan, t, now time.Time) error {
+	if t.Before(now.Add(-s.PastGrace)) {
+		return errors.New("scheduled_at must be in the future")
+	}
+	if days := s.Horizon(p); t.After(now.AddDate(0, 0, days)) {
+		return fmt.Errorf("scheduled_at cannot be more than %d days in the future", days)
+	}
+	return nil
+}
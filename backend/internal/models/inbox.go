@@ -0,0 +1,89 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Inbox limits
+const (
+	// InboxSyncWindow is how long after publishing a post's replies are pulled
+	InboxSyncWindow = 7 * 24 * time.Hour
+	// MaxInboxMarkIDs is the most inbox items marked read or unread at once
+	MaxInboxMarkIDs = 500
+)
+
+// InboxKind is what an inbox item is
+type InboxKind string
+
+const (
+	InboxKindReply   InboxKind = "reply"   // A reply or comment on the published post
+	InboxKindMention InboxKind = "mention" // A mention of the account in the post's thread
+	InboxKindKeyword InboxKind = "keyword" // A platform post matching one of the user's keyword monitors
+)
+
+// ValidInboxKinds returns all valid inbox kinds
+func ValidInboxKinds() []InboxKind {
+	return []InboxKind{InboxKindReply, InboxKindMention, InboxKindKeyword}
+}
+
+// IsValid checks if the inbox kind is valid
+func (k InboxKind) IsValid() bool {
+	for _, valid := range ValidInboxKinds() {
+		if k == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// InboxItem is a reply or mention on a published post, or a match of a
+// keyword monitor, pulled from its platform into the user's inbox
+type InboxItem struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	PostID     *uuid.UUID `json:"post_id,omitempty"`    // Set for replies and mentions
+	MonitorID  *uuid.UUID `json:"monitor_id,omitempty"` // Set for keyword matches
+	Channel    Channel    `json:"channel"`
+	Kind       InboxKind  `json:"kind"`
+	ExternalID string     `json:"external_id"` // The platform's ID, unique per post or per user's channel
+	Author     string     `json:"author"`
+	Content    string     `json:"content"`
+	URL        *string    `json:"url,omitempty"` // Where to answer it on the platform
+	ReceivedAt time.Time  `json:"received_at"`   // When it was posted on the platform
+	ReadAt     *time.Time `json:"read_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// InboxCursor returns the cursor of the inbox page following item. Inbox
+// pages are ordered newest first by received time, so the cursor's time is
+// the item's received time.
+func InboxCursor(item *InboxItem) PostCursor {
+	return PostCursor{ScheduledAt: item.ReceivedAt, ID: item.ID}
+}
+
+// MarkInboxRequest marks inbox items read or unread
+type MarkInboxRequest struct {
+	IDs  []uuid.UUID `json:"ids"`
+	Read bool        `json:"read"`
+}
+
+// Validate checks the number of items
+func (r *MarkInboxRequest) Validate() error {
+	if len(r.IDs) == 0 {
+		return errors.New("ids is required")
+	}
+	if len(r.IDs) > MaxInboxMarkIDs {
+		return fmt.Errorf("at most %d ids can be marked at once", MaxInboxMarkIDs)
+	}
+	return nil
+}
+
+// InboxCounts is the body of inbox SSE events and the unread count endpoint
+type InboxCounts struct {
+	Unread int `json:"unread"`
+	New    int `json:"new,omitempty"` // Items pulled by the sync that sent the event
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxInviteUses is the most registrations a single invite code allows
+	MaxInviteUses = 1000
+	// MaxInviteExpiryHours is the longest an invite code may stay valid
+	MaxInviteExpiryHours = 90 * 24
+)
+
+// Invite is an admin-generated code that allows registration while the
+// server is invite-only
+type Invite struct {
+	ID        uuid.UUID  `json:"id"`
+	Code      string     `json:"code,omitempty"` // Only returned when the invite is created
+	MaxUses   int        `json:"max_uses"`
+	Uses      int        `json:"uses"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// CreateInviteRequest represents an admin request to generate an invite code
+type CreateInviteRequest struct {
+	MaxUses        int `json:"max_uses"`         // Defaults to 1
+	ExpiresInHours int `json:"expires_in_hours"` // 0 never expires
+}
+
+// Validate checks the limits, defaulting to a single use
+func (r *CreateInviteRequest) Validate() error {
+	if r.MaxUses == 0 {
+		r.MaxUses = 1
+	}
+	if r.MaxUses < 1 || r.MaxUses > MaxInviteUses {
+		return fmt.Errorf("max_uses must be between 1 and %d", MaxInviteUses)
+	}
+	if r.ExpiresInHours < 0 || r.ExpiresInHours > MaxInviteExpiryHours {
+		return fmt.Errorf("expires_in_hours must be between 0 and %d", MaxInviteExpiryHours)
+	}
+	return nil
+}
+
+// ExpiresAt returns when an invite created at now expires, or nil if it doesn't
+func (r *CreateInviteRequest) ExpiresAt(now time.Time) *time.Time {
+	if r.ExpiresInHours == 0 {
+		return nil
+	}
+	at := now.Add(time.Duration(r.ExpiresInHours) * time.Hour).UTC()
+	return &at
+}
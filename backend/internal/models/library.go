@@ -0,0 +1,86 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Media library limits
+const (
+	// MaxMediaFolders is the most folders an organization's library may have
+	MaxMediaFolders = 100
+	// MaxMediaFolderNameLength is the longest folder name
+	MaxMediaFolderNameLength = 100
+	// MaxMediaNameLength is the longest uploaded file name kept
+	MaxMediaNameLength = 255
+)
+
+// MediaFolder groups media in an organization's shared library
+type MediaFolder struct {
+	ID         uuid.UUID  `json:"id"`
+	OrgID      uuid.UUID  `json:"org_id"`
+	Name       string     `json:"name"`
+	MediaCount int        `json:"media_count"`
+	CreatedBy  *uuid.UUID `json:"created_by,omitempty"` // Nil once its creator's account is deleted
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// LibraryMedia is a media item in an organization's library, with the number
+// of posts it is attached to
+type LibraryMedia struct {
+	Media
+	UsageCount int `json:"usage_count"`
+}
+
+// LibraryFilter narrows a library listing
+type LibraryFilter struct {
+	FolderID *uuid.UUID // Only media filed in this folder
+	Query    string     // Only media whose name or alt text contains this, ignoring case
+}
+
+// CreateMediaFolderRequest represents the request to add a library folder
+type CreateMediaFolderRequest struct {
+	Name string `json:"name"`
+}
+
+// Validate checks the folder name, trimming it
+func (r *CreateMediaFolderRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if utf8.RuneCountInString(r.Name) > MaxMediaFolderNameLength {
+		return fmt.Errorf("name must not exceed %d characters", MaxMediaFolderNameLength)
+	}
+	return nil
+}
+
+// FileLibraryMediaRequest shares an upload to the workspace's library, or
+// moves a library item to another folder. A nil folder_id files it at the
+// top of the library.
+type FileLibraryMediaRequest struct {
+	MediaID  uuid.UUID  `json:"media_id"`
+	FolderID *uuid.UUID `json:"folder_id"`
+}
+
+// MediaName cleans an uploaded file name for display and search, returning
+// nil if nothing is left
+func MediaName(filename string) *string {
+	// Browsers send a base name, but some clients send a full path
+	if i := strings.LastIndexAny(filename, `/\`); i >= 0 {
+		filename = filename[i+1:]
+	}
+	filename = strings.TrimSpace(strings.ToValidUTF8(filename, ""))
+	if filename == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(filename) > MaxMediaNameLength {
+		filename = string([]rune(filename)[:MaxMediaNameLength])
+	}
+	return &filename
+}
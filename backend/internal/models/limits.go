@@ -0,0 +1,63 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// DailyLimits caps how many posts a user may publish per channel per UTC day.
+// A missing or zero entry means the channel is unlimited.
+type DailyLimits map[Channel]int
+
+// DefaultDailyLimits mirrors each platform's posting guidance
+var DefaultDailyLimits = DailyLimits{
+	ChannelTwitter:        50,
+	ChannelLinkedIn:       25,
+	ChannelFacebook:       25,
+	ChannelReddit:         10,
+	ChannelTelegram:       50,
+	ChannelDiscord:        50,
+	ChannelGoogleBusiness: 10,
+	ChannelYouTube:        6,  // Each Short upload costs a sixth of the default daily API quota
+	ChannelTikTok:         15, // TikTok caps posts through the API per creator per day
+}
+
+// NewDailyLimits returns the default limits with per-channel overrides applied
+func NewDailyLimits(overrides map[string]int) DailyLimits {
+	limits := make(DailyLimits, len(DefaultDailyLimits))
+	for c, n := range DefaultDailyLimits {
+		limits[c] = n
+	}
+	for c, n := range overrides {
+		limits[Channel(c)] = n
+	}
+	return limits
+}
+
+// Check returns a DailyLimitError if count posts already fill the channel's limit for day
+func (l DailyLimits) Check(c Channel, day time.Time, count int) error {
+	limit := l[c]
+	if limit <= 0 || count < limit {
+		return nil
+	}
+	start, _ := DayBounds(day)
+	return &DailyLimitError{Channel: c, Limit: limit, Day: start}
+}
+
+// DayBounds returns the start and end of the UTC day containing t
+func DayBounds(t time.Time) (time.Time, time.Time) {
+	start := t.UTC().Truncate(24 * time.Hour)
+	return start, start.Add(24 * time.Hour)
+}
+
+// DailyLimitError reports that a channel's daily posting limit has been reached
+type DailyLimitError struct {
+	Channel Channel
+	Limit   int
+	Day     time.Time
+}
+
+func (e *DailyLimitError) Error() string {
+	return fmt.Sprintf("daily limit reached: %s allows at most %d posts per day (%s UTC)",
+		e.Channel, e.Limit, e.Day.Format("2006-01-02"))
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ListResponse is the envelope of every list endpoint. NextCursor is set when
+// more items follow and is passed back as ?cursor to fetch them.
+type ListResponse[T any] struct {
+	Data       []T     `json:"data"`
+	NextCursor *string `json:"next_cursor"`
+	Total      *int    `json:"total,omitempty"` // Items across all pages, when known
+}
+
+// NewListResponse wraps one page of items; an empty nextCursor marks the last
+// page and a negative total leaves it out
+func NewListResponse[T any](items []T, nextCursor string, total int) ListResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+	resp := ListResponse[T]{Data: items}
+	if nextCursor != "" {
+		resp.NextCursor = &nextCursor
+	}
+	if total >= 0 {
+		resp.Total = &total
+	}
+	return resp
+}
+
+// Page sizes of paginated lists
+const (
+	DefaultPageSize = 50  // When ?cursor is given without ?limit
+	MaxPageSize     = 500 // Largest ?limit accepted
+)
+
+// PostCursor is a position in a list of posts ordered by scheduled time, then
+// ID. Pages continue after it, so posts added or removed before it don't shift
+// later pages.
+type PostCursor struct {
+	ScheduledAt time.Time
+	ID          uuid.UUID
+}
+
+// CursorAfter returns the cursor of the page following post
+func CursorAfter(post *Post) PostCursor {
+	return PostCursor{ScheduledAt: post.ScheduledAt, ID: post.ID}
+}
+
+// String encodes the cursor as an opaque ?cursor value
+func (c PostCursor) String() string {
+	raw := strconv.FormatInt(c.ScheduledAt.UnixNano(), 10) + ":" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParsePostCursor decodes a ?cursor value made by PostCursor.String
+func ParsePostCursor(s string) (PostCursor, error) {
+	invalid := errors.New("Invalid cursor")
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return PostCursor{}, invalid
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return PostCursor{}, invalid
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return PostCursor{}, invalid
+	}
+	postID, err := uuid.Parse(id)
+	if err != nil {
+		return PostCursor{}, invalid
+	}
+	return PostCursor{ScheduledAt: time.Unix(0, n).UTC(), ID: postID}, nil
+}
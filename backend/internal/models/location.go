@@ -0,0 +1,63 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxLocationNameLength is the longest place name accepted on a post
+const MaxLocationNameLength = 100
+
+// PostLocation is an optional place or geo tag attached to a post
+type PostLocation struct {
+	Name      string   `json:"name,omitempty"`      // Human-readable place name
+	PlaceID   string   `json:"place_id,omitempty"`  // Platform place identifier
+	Latitude  *float64 `json:"latitude,omitempty"`  // Exact coordinates, paired with longitude
+	Longitude *float64 `json:"longitude,omitempty"` // Exact coordinates, paired with latitude
+}
+
+// locationChannels lists the channels whose publishers forward location tags
+var locationChannels = map[Channel]bool{
+	ChannelTwitter: true,
+}
+
+// SupportsLocation reports whether a channel accepts location tags
+func SupportsLocation(c Channel) bool {
+	return locationChannels[c]
+}
+
+// ValidateLocation checks a location tag for the channel and trims its fields.
+// A nil location is always valid.
+func ValidateLocation(c Channel, l *PostLocation) error {
+	if l == nil {
+		return nil
+	}
+	if !locationChannels[c] {
+		return fmt.Errorf("location is not supported for %s", c)
+	}
+
+	l.Name = strings.TrimSpace(l.Name)
+	l.PlaceID = strings.TrimSpace(l.PlaceID)
+
+	if utf8.RuneCountInString(l.Name) > MaxLocationNameLength {
+		return fmt.Errorf("location name must not exceed %d characters", MaxLocationNameLength)
+	}
+	if (l.Latitude == nil) != (l.Longitude == nil) {
+		return errors.New("location latitude and longitude must be provided together")
+	}
+	if l.Latitude != nil {
+		if *l.Latitude < -90 || *l.Latitude > 90 {
+			return errors.New("location latitude must be between -90 and 90")
+		}
+		if *l.Longitude < -180 || *l.Longitude > 180 {
+			return errors.New("location longitude must be between -180 and 180")
+		}
+	}
+	if l.PlaceID == "" && l.Latitude == nil {
+		return errors.New("location requires a place_id or coordinates")
+	}
+
+	return nil
+}
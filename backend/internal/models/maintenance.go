@@ -0,0 +1,7 @@
+package models
+
+// SetMaintenanceRequest represents an admin request to toggle maintenance mode
+type SetMaintenanceRequest struct {
+	Enabled *bool  `json:"enabled"`
+	Message string `json:"message"` // Shown to users; a default is used when empty
+}
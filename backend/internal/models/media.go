@@ -0,0 +1,178 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Media represents an uploaded image or video that can be attached to posts
+type Media struct {
+	ID          uuid.UUID        `json:"id"`
+	UserID      uuid.UUID        `json:"user_id"`
+	Name        *string          `json:"name,omitempty"`      // Uploaded file name
+	OrgID       *uuid.UUID       `json:"org_id,omitempty"`    // Organization whose library it is shared to
+	FolderID    *uuid.UUID       `json:"folder_id,omitempty"` // Library folder; nil at the top of the library
+	StorageKey  string           `json:"-"`
+	URL         string           `json:"url"`
+	ContentType string           `json:"content_type"`
+	Width       int              `json:"width"`
+	Height      int              `json:"height"`
+	DurationMs  *int             `json:"duration_ms,omitempty"` // Videos only
+	SizeBytes   int              `json:"size_bytes"`
+	AltText     *string          `json:"alt_text,omitempty"`
+	Renditions  []MediaRendition `json:"renditions,omitempty"` // Transcoded copies of a video, added after upload
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// MediaRendition is a copy of a video transcoded for platforms that need a
+// particular shape, such as the 9:16 "vertical" rendition
+type MediaRendition struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
+
+// Rendition returns the named rendition of the media, if it has been made
+func (m *Media) Rendition(name string) (MediaRendition, bool) {
+	for _, r := range m.Renditions {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return MediaRendition{}, false
+}
+
+// IsVideoContentType reports whether a media content type is a video
+func IsVideoContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, "video/")
+}
+
+// PostMedia is a media attachment on a post, with the alt text chosen at attach time
+type PostMedia struct {
+	MediaID     uuid.UUID `json:"media_id"`
+	URL         string    `json:"url"`
+	ContentType string    `json:"content_type"`
+	AltText     *string   `json:"alt_text,omitempty"`
+	Width       int       `json:"width,omitempty"`
+	Height      int       `json:"height,omitempty"`
+	DurationMs  *int      `json:"duration_ms,omitempty"`
+}
+
+// IsVideo reports whether the attachment is a video
+func (m PostMedia) IsVideo() bool {
+	return IsVideoContentType(m.ContentType)
+}
+
+// Duration returns a video attachment's length, if known. Attachments made
+// before durations were recorded don't have one.
+func (m PostMedia) Duration() (time.Duration, bool) {
+	if m.DurationMs == nil {
+		return 0, false
+	}
+	return time.Duration(*m.DurationMs) * time.Millisecond, true
+}
+
+// MediaAttachmentRequest attaches uploaded media to a post, optionally overriding its alt text
+type MediaAttachmentRequest struct {
+	MediaID uuid.UUID `json:"media_id"`
+	AltText *string   `json:"alt_text"`
+}
+
+// UpdateMediaRequest represents the request to update media metadata
+type UpdateMediaRequest struct {
+	AltText *string `json:"alt_text"`
+}
+
+// MediaURL returns the public URL for a media storage key
+func MediaURL(storageKey string) string {
+	return "/media/" + storageKey
+}
+
+// MaxAltTextLength is the longest alt text accepted on upload, before per-channel limits
+const MaxAltTextLength = 5000
+
+// MediaConstraints describes a channel's limits for attachments
+type MediaConstraints struct {
+	MaxAttachments   int
+	MaxAltTextLength int
+	MinVideoDuration time.Duration
+	MaxVideoDuration time.Duration // Zero for no limit
+	VideoAlone       bool          // A video can't be posted with other attachments
+}
+
+// mediaConstraints lists attachment limits per channel
+var mediaConstraints = map[Channel]MediaConstraints{
+	ChannelTwitter:        {MaxAttachments: 4, MaxAltTextLength: 1000},
+	ChannelLinkedIn:       {MaxAttachments: 9, MaxAltTextLength: 4086},
+	ChannelFacebook:       {MaxAttachments: 10, MaxAltTextLength: 1000},
+	ChannelReddit:         {MaxAttachments: 20, MaxAltTextLength: 180},  // Gallery captions
+	ChannelTelegram:       {MaxAttachments: 10, MaxAltTextLength: 1024}, // Album captions
+	ChannelDiscord:        {MaxAttachments: 10, MaxAltTextLength: 1024}, // Attachment descriptions
+	ChannelWebhook:        {MaxAttachments: 10, MaxAltTextLength: 1000},
+	ChannelGoogleBusiness: {MaxAttachments: 1, MaxAltTextLength: 1000}, // Alt text isn't sent; Google has none
+	ChannelYouTube:        {MaxAttachments: 5, MaxAltTextLength: 1000, MaxVideoDuration: MaxYouTubeShortDuration, VideoAlone: true},
+	ChannelTikTok:         {MaxAttachments: 1, MaxAltTextLength: 1000, MinVideoDuration: MinTikTokVideoDuration, MaxVideoDuration: MaxTikTokVideoDuration},
+}
+
+// GetMediaConstraints returns the attachment limits for a channel
+func GetMediaConstraints(c Channel) (MediaConstraints, bool) {
+	mc, ok := mediaConstraints[c]
+	return mc, ok
+}
+
+// ValidatePostMedia checks attachments against the channel's limits.
+// When requireAltText is set, every attachment must have alt text.
+func ValidatePostMedia(c Channel, attachments []PostMedia, requireAltText bool) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	mc, ok := mediaConstraints[c]
+	if !ok {
+		return fmt.Errorf("media attachments are not supported for %s", c)
+	}
+	if len(attachments) > mc.MaxAttachments {
+		return fmt.Errorf("%s posts support at most %d media attachments", c, mc.MaxAttachments)
+	}
+
+	for _, m := range attachments {
+		if !m.IsVideo() {
+			continue
+		}
+		if mc.VideoAlone && len(attachments) > 1 {
+			return fmt.Errorf("%s posts with a video can't have other attachments", c)
+		}
+		d, ok := m.Duration()
+		if !ok {
+			continue
+		}
+		if d < mc.MinVideoDuration {
+			return fmt.Errorf("%s videos must be at least %v long", c, mc.MinVideoDuration)
+		}
+		if mc.MaxVideoDuration > 0 && d > mc.MaxVideoDuration {
+			return fmt.Errorf("%s videos must not be longer than %v", c, mc.MaxVideoDuration)
+		}
+	}
+
+	for _, m := range attachments {
+		if m.AltText == nil || *m.AltText == "" {
+			if requireAltText {
+				return errors.New("alt text is required for every media attachment")
+			}
+			continue
+		}
+		if utf8.RuneCountInString(*m.AltText) > mc.MaxAltTextLength {
+			return fmt.Errorf("%s alt text must not exceed %d characters", c, mc.MaxAltTextLength)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package models
+
+import "time"
+
+// ChannelMeta describes a channel's publishing rules for clients to validate against
+type ChannelMeta struct {
+	Channel           Channel          `json:"channel"`
+	MaxContentLength  int              `json:"max_content_length"`
+	LengthCounting    string           `json:"length_counting"` // How content is counted against max_content_length
+	MaxAttachments    int              `json:"max_attachments"`
+	MaxAltTextLength  int              `json:"max_alt_text_length"`
+	MinVideoSeconds   int              `json:"min_video_seconds,omitempty"`
+	MaxVideoSeconds   int              `json:"max_video_seconds,omitempty"` // Zero means no limit
+	DailyLimit        int              `json:"daily_limit"`                 // Zero means unlimited
+	SupportsTargeting bool             `json:"supports_targeting"`
+	SupportsLocation  bool             `json:"supports_location"`
+	Poll              *PollConstraints `json:"poll,omitempty"` // Nil if the channel has no polls
+}
+
+// NewChannelMeta collects the rules of channel c under the given daily limits
+func NewChannelMeta(c Channel, limits DailyLimits) ChannelMeta {
+	m := ChannelMeta{
+		Channel:           c,
+		MaxContentLength:  ChannelContentLimits[c],
+		LengthCounting:    LengthCounting(c),
+		DailyLimit:        limits[c],
+		SupportsTargeting: SupportsTargeting(c),
+		SupportsLocation:  SupportsLocation(c),
+	}
+	if mc, ok := GetMediaConstraints(c); ok {
+		m.MaxAttachments = mc.MaxAttachments
+		m.MaxAltTextLength = mc.MaxAltTextLength
+		m.MinVideoSeconds = int(mc.MinVideoDuration / time.Second)
+		m.MaxVideoSeconds = int(mc.MaxVideoDuration / time.Second)
+	}
+	if pc, ok := GetPollConstraints(c); ok {
+		m.Poll = &pc
+	}
+	return m
+}
+
+// ScheduleMeta describes when posts may be scheduled
+type ScheduleMeta struct {
+	HorizonDays      map[Plan]int `json:"horizon_days"`
+	PastGraceSeconds int          `json:"past_grace_seconds"`
+}
+
+// RateLimitPolicy describes one of the API's request rate limits
+type RateLimitPolicy struct {
+	Scope         string `json:"scope"` // Requests the limit applies to
+	Limit         int    `json:"limit"`
+	WindowSeconds int    `json:"window_seconds"`
+	Overridden    bool   `json:"overridden,omitempty"` // Adjusted at runtime by an admin
+}
+
+// Window returns the policy's window as a duration
+func (p RateLimitPolicy) Window() time.Duration {
+	return time.Duration(p.WindowSeconds) * time.Second
+}
+
+// Meta is the server's time and publishing rules, so clients can validate
+// requests without hard-coding them
+type Meta struct {
+	ServerTime               time.Time           `json:"server_time"`
+	Channels                 []ChannelMeta       `json:"channels"`
+	Schedule                 ScheduleMeta        `json:"schedule"`
+	RateLimits               []RateLimitPolicy   `json:"rate_limits"`
+	PlanRateLimitMultipliers map[Plan]float64    `json:"plan_rate_limit_multipliers,omitempty"`
+	Quotas                   map[Plan]UsageQuota `json:"quotas"`      // Daily usage quota of each plan
+	InviteOnly               bool                `json:"invite_only"` // Registration requires an invite_code
+}
+
+// SetRateLimitRequest represents an admin request to change a scope's rate limit
+type SetRateLimitRequest struct {
+	Limit         int `json:"limit"`
+	WindowSeconds int `json:"window_seconds"`
+}
+
+// RateLimitBucket is a client's use of one rate limit window
+type RateLimitBucket struct {
+	Scope     string    `json:"scope"`
+	Path      string    `json:"path"`
+	Limit     int       `json:"limit"`
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+// NewRateLimitBucket reports used requests to path against policy, in a
+// window ending at resetAt
+func NewRateLimitBucket(policy RateLimitPolicy, path string, used int, resetAt time.Time) RateLimitBucket {
+	remaining := policy.Limit - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	return RateLimitBucket{
+		Scope:     policy.Scope,
+		Path:      path,
+		Limit:     policy.Limit,
+		Used:      used,
+		Remaining: remaining,
+		ResetAt:   resetAt.UTC().Truncate(time.Second),
+	}
+}
+
+// LimitsResponse represents the caller's rate limits and quota consumption
+type LimitsResponse struct {
+	RateLimits []RateLimitPolicy `json:"rate_limits"` // Limits for the caller's plan
+	Buckets    []RateLimitBucket `json:"buckets"`     // Windows with requests from the caller's address
+
+	Plan  Plan        `json:"plan"`
+	Quota UsageQuota  `json:"quota"`
+	Today UsageCounts `json:"today"`
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +15,25 @@ type User struct {
 	PasswordHash string    `json:"-"` // Never expose in JSON
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+
+	AvatarKey       *string    `json:"-"` // Media store key of the processed avatar
+	AvatarUpdatedAt *time.Time `json:"-"`
+
+	ConflictWindowMinutes int     `json:"-"` // See AccountSettings
+	ReminderWebhookURL    *string `json:"-"`
+
+	NotificationPreferences NotificationPreferences `json:"-"`
+
+	SuspendedAt      *time.Time `json:"-"` // Set while an admin has suspended the account
+	SuspensionReason *string    `json:"-"`
+
+	Plan Plan `json:"plan"`
+
+	TenantID uuid.UUID `json:"-"`
+
+	// Request-scoped workspace, set by the auth middleware
+	WorkspaceID   *uuid.UUID `json:"-"` // Organization ID; nil for the personal workspace
+	WorkspaceRole OrgRole    `json:"-"`
 }
 
 // PostStatus represents the status of a post
@@ -22,20 +43,41 @@ const (
 	PostStatusScheduled PostStatus = "scheduled"
 	PostStatusPublished PostStatus = "published"
 	PostStatusFailed    PostStatus = "failed"
+
+	PostStatusPendingApproval PostStatus = "pending_approval" // Waiting for an organization owner or admin
+	PostStatusRejected        PostStatus = "rejected"
+
+	PostStatusCanceled PostStatus = "canceled" // Stopped before publishing; kept for the record
 )
 
 // Channel represents a social media channel
 type Channel string
 
 const (
-	ChannelTwitter  Channel = "twitter"
-	ChannelLinkedIn Channel = "linkedin"
-	ChannelFacebook Channel = "facebook"
+	ChannelTwitter        Channel = "twitter"
+	ChannelLinkedIn       Channel = "linkedin"
+	ChannelFacebook       Channel = "facebook"
+	ChannelReddit         Channel = "reddit"
+	ChannelTelegram       Channel = "telegram"
+	ChannelDiscord        Channel = "discord"
+	ChannelWebhook        Channel = "webhook"         // Delivered to the user's own HTTPS endpoint
+	ChannelGoogleBusiness Channel = "google_business" // Google Business Profile
+	ChannelYouTube        Channel = "youtube"         // Community posts, and Shorts for posts with a video
+	ChannelTikTok         Channel = "tiktok"          // Videos, through the Content Posting API
 )
 
 // ValidChannels returns all valid channel values
 func ValidChannels() []Channel {
-	return []Channel{ChannelTwitter, ChannelLinkedIn, ChannelFacebook}
+	return []Channel{ChannelTwitter, ChannelLinkedIn, ChannelFacebook, ChannelReddit, ChannelTelegram, ChannelDiscord, ChannelWebhook, ChannelGoogleBusiness, ChannelYouTube, ChannelTikTok}
+}
+
+// InvalidChannelMessage is the error for a channel value that isn't valid
+func InvalidChannelMessage() string {
+	names := make([]string, 0, len(ValidChannels()))
+	for _, c := range ValidChannels() {
+		names = append(names, string(c))
+	}
+	return "Invalid channel. Must be one of: " + strings.Join(names, ", ")
 }
 
 // IsValidChannel checks if a channel value is valid
@@ -63,14 +105,72 @@ type Post struct {
 	NextRetryAt *time.Time `json:"next_retry_at,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
+
+	Targeting *PostTargeting `json:"targeting,omitempty"`
+	Type      PostType       `json:"type"`
+	Poll      *Poll          `json:"poll,omitempty"`
+	Media     []PostMedia    `json:"media,omitempty"`
+	Location  *PostLocation  `json:"location,omitempty"`
+
+	Recycle        *RecycleSettings `json:"recycle,omitempty"`
+	RecycleCount   int              `json:"recycle_count,omitempty"`    // Number of times this content has been recycled
+	RecycledFromID *uuid.UUID       `json:"recycled_from_id,omitempty"` // Post this one was recycled from
+
+	Priority bool `json:"priority,omitempty"` // Queued in the priority lane
+
+	TenantID       uuid.UUID  `json:"-"`
+	OrgID          *uuid.UUID `json:"org_id,omitempty"`          // Organization workspace the post belongs to
+	WindowOverride bool       `json:"window_override,omitempty"` // Publishes outside the organization's publishing windows
+
+	WorkflowState WorkflowState `json:"workflow_state"`
+	AssigneeID    *uuid.UUID    `json:"assignee_id,omitempty"`
+
+	ABTest     *ABTest     `json:"ab_test,omitempty"`      // Set on variant A of an A/B test
+	ABVariant  *ABVariant  `json:"ab_variant,omitempty"`   // Which side of an A/B test the post is
+	ABParentID *uuid.UUID  `json:"ab_parent_id,omitempty"` // Variant A post, on variant B posts
+	Engagement *Engagement `json:"engagement,omitempty"`
+
+	RemindBeforeMinutes *int `json:"remind_before_minutes,omitempty"` // Remind the author this long before publishing
+
+	UndoUntil  *time.Time `json:"undo_until,omitempty"` // Publishing can be aborted until then
+	CanceledAt *time.Time `json:"canceled_at,omitempty"`
+
+	NoSignature bool `json:"no_signature,omitempty"` // Published without the channel preset's signature
+}
+
+// PostSummary is the projection of a post returned by list endpoints with
+// ?fields=summary, for views such as the calendar that don't show content
+type PostSummary struct {
+	ID          uuid.UUID  `json:"id"`
+	Title       *string    `json:"title,omitempty"`
+	Channel     Channel    `json:"channel"`
+	Status      PostStatus `json:"status"`
+	ScheduledAt time.Time  `json:"scheduled_at"`
 }
 
 // CreatePostRequest represents the request to create a post
 type CreatePostRequest struct {
 	Title       *string `json:"title"`
 	Content     string  `json:"content"`
-	Channel     string  `json:"channel"`
+	Channel     string  `json:"channel"` // Defaults to the channel of the user's default preset
 	ScheduledAt string  `json:"scheduled_at"`
+
+	Targeting *PostTargeting           `json:"targeting"`
+	Type      string                   `json:"type"` // Defaults to "text"
+	Poll      *Poll                    `json:"poll"`
+	Media     []MediaAttachmentRequest `json:"media"`
+	Location  *PostLocation            `json:"location"`
+	Recycle   *RecycleSettings         `json:"recycle"`
+
+	WorkflowState string     `json:"workflow_state"` // Defaults to "drafting"
+	AssigneeID    *uuid.UUID `json:"assignee_id"`
+
+	ABTest *ABTest `json:"ab_test"`
+
+	RemindBeforeMinutes *int `json:"remind_before_minutes"`
+
+	SkipPreset  bool `json:"skip_preset"`  // Leave out the channel preset's targeting, footer and hashtags
+	NoSignature bool `json:"no_signature"` // Publish without the channel preset's signature
 }
 
 // UpdatePostRequest represents the request to update a post
@@ -79,18 +179,32 @@ type UpdatePostRequest struct {
 	Content     *string `json:"content"`
 	Channel     *string `json:"channel"`
 	ScheduledAt *string `json:"scheduled_at"`
+
+	Targeting *PostTargeting            `json:"targeting"`
+	Type      *string                   `json:"type"`
+	Poll      *Poll                     `json:"poll"`
+	Media     *[]MediaAttachmentRequest `json:"media"` // An empty list removes all attachments
+	Location  *PostLocation             `json:"location"`
+	Recycle   *RecycleSettings          `json:"recycle"` // A max_count of zero disables recycling
+
+	RemindBeforeMinutes *int  `json:"remind_before_minutes"` // Zero turns the reminder off
+	NoSignature         *bool `json:"no_signature"`
+
+	Status *string `json:"status"` // "scheduled" resends a failed post
 }
 
 // RegisterRequest represents a user registration request
 type RegisterRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	InviteCode string `json:"invite_code,omitempty"` // Required while registration is invite-only
 }
 
 // LoginRequest represents a user login request
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	RememberMe bool   `json:"remember_me,omitempty"` // Keep the session for REMEMBER_ME_TTL rather than REFRESH_TOKEN_TTL
 }
 
 // AuthResponse represents the response after successful auth
@@ -102,7 +216,11 @@ type AuthResponse struct {
 type UserResponse struct {
 	ID        uuid.UUID `json:"id"`
 	Email     string    `json:"email"`
+	AvatarURL *string   `json:"avatar_url,omitempty"`
+	Plan      Plan      `json:"plan"`
 	CreatedAt time.Time `json:"created_at"`
+
+	Suspension *Suspension `json:"suspension,omitempty"` // Set while the account is suspended
 }
 
 // ToResponse converts a User to UserResponse
@@ -110,12 +228,165 @@ func (u *User) ToResponse() *UserResponse {
 	return &UserResponse{
 		ID:        u.ID,
 		Email:     u.Email,
+		AvatarURL: u.AvatarURL(),
+		Plan:      u.Plan,
 		CreatedAt: u.CreatedAt,
+
+		Suspension: u.Suspension(),
 	}
 }
 
+// AvatarURL returns the public URL of the user's avatar, or nil if none is set.
+// The path is stable per user; the version parameter busts browser caches on change.
+func (u *User) AvatarURL() *string {
+	if u.AvatarKey == nil {
+		return nil
+	}
+	url := "/media/" + *u.AvatarKey
+	if u.AvatarUpdatedAt != nil {
+		url += fmt.Sprintf("?v=%d", u.AvatarUpdatedAt.Unix())
+	}
+	return &url
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error   string `json:"error"`
+	Code    string `json:"code,omitempty"` // Machine-readable error code
 	Message string `json:"message,omitempty"`
 }
+
+// ChannelConnection represents a user's connected account on a channel
+type ChannelConnection struct {
+	ID              uuid.UUID  `json:"id"`
+	UserID          uuid.UUID  `json:"user_id"`
+	Channel         Channel    `json:"channel"`
+	AccountName     *string    `json:"account_name,omitempty"`
+	AccessToken     string     `json:"-"` // Never expose in JSON
+	RefreshToken    *string    `json:"-"` // Set by OAuth connections whose access tokens are short lived
+	TokenExpiresAt  *time.Time `json:"token_expires_at,omitempty"`
+	Target          *string    `json:"target,omitempty"` // Where the connection publishes, e.g. a Telegram chat
+	LastError       *string    `json:"last_error,omitempty"`
+	LastPublishedAt *time.Time `json:"last_published_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// ConnectChannelRequest represents the request to connect a channel account
+type ConnectChannelRequest struct {
+	AccountName    *string `json:"account_name"`
+	AccessToken    string  `json:"access_token"`
+	TokenExpiresAt *string `json:"token_expires_at"`
+	Target         *string `json:"target"` // Required by channels that publish to a chat or channel the token doesn't name
+}
+
+// Validate checks the credentials and target against channel c, trimming
+// the target. The access token must already be trimmed.
+func (r *ConnectChannelRequest) Validate(c Channel) error {
+	if r.Target != nil {
+		target := strings.TrimSpace(*r.Target)
+		r.Target = &target
+		if target == "" {
+			r.Target = nil
+		}
+	}
+
+	switch c {
+	case ChannelTelegram:
+		return validateTelegramConnection(r)
+	case ChannelDiscord:
+		return validateDiscordConnection(r)
+	case ChannelWebhook:
+		return validateWebhookConnection(r)
+	}
+	if r.Target != nil {
+		return fmt.Errorf("target is not supported for %s", c)
+	}
+	return nil
+}
+
+// AuthorizeChannelResponse is where to send the user to connect a channel
+// through its platform's OAuth flow
+type AuthorizeChannelResponse struct {
+	AuthorizeURL string `json:"authorize_url"`
+}
+
+// TokenStatus describes the validity of a connection's access token
+type TokenStatus string
+
+const (
+	TokenStatusValid    TokenStatus = "valid"
+	TokenStatusExpiring TokenStatus = "expiring"
+	TokenStatusExpired  TokenStatus = "expired"
+	TokenStatusMissing  TokenStatus = "missing"
+)
+
+// PlatformStatus describes the observed health of a platform API
+type PlatformStatus string
+
+const (
+	PlatformStatusOperational PlatformStatus = "operational"
+	PlatformStatusDegraded    PlatformStatus = "degraded"
+	PlatformStatusUnknown     PlatformStatus = "unknown"
+)
+
+// PlatformHealth summarizes recent system-wide publishing outcomes for a channel
+type PlatformHealth struct {
+	Status          PlatformStatus `json:"status"`
+	RecentPublishes int            `json:"recent_publishes"`
+	RecentFailures  int            `json:"recent_failures"`
+}
+
+// ChannelStatus reports the health of a user's connection to a channel
+type ChannelStatus struct {
+	Channel         Channel        `json:"channel"`
+	Connected       bool           `json:"connected"`
+	AccountName     *string        `json:"account_name,omitempty"`
+	TokenStatus     TokenStatus    `json:"token_status"`
+	TokenExpiresAt  *time.Time     `json:"token_expires_at,omitempty"`
+	LastPublishedAt *time.Time     `json:"last_published_at,omitempty"`
+	LastError       *string        `json:"last_error,omitempty"`
+	Platform        PlatformHealth `json:"platform"`
+}
+
+// TokenExpiryWarning is how far ahead of expiry a token is reported as expiring
+const TokenExpiryWarning = 72 * time.Hour
+
+// Thresholds for reporting a platform as degraded
+const (
+	degradedMinFailures = 5
+)
+
+// TokenStatusAt reports the validity of the connection's token at the given time
+func (c *ChannelConnection) TokenStatusAt(now time.Time) TokenStatus {
+	if c.AccessToken == "" {
+		return TokenStatusMissing
+	}
+	if c.TokenExpiresAt == nil {
+		return TokenStatusValid
+	}
+	if !c.TokenExpiresAt.After(now) {
+		return TokenStatusExpired
+	}
+	if c.TokenExpiresAt.Sub(now) <= TokenExpiryWarning {
+		return TokenStatusExpiring
+	}
+	return TokenStatusValid
+}
+
+// NewPlatformHealth derives a platform status from recent publish outcomes.
+// A platform is degraded when failures are frequent and outnumber successes.
+func NewPlatformHealth(publishes, failures int) PlatformHealth {
+	status := PlatformStatusOperational
+	switch {
+	case publishes == 0 && failures == 0:
+		status = PlatformStatusUnknown
+	case failures >= degradedMinFailures && failures > publishes:
+		status = PlatformStatusDegraded
+	}
+	return PlatformHealth{
+		Status:          status,
+		RecentPublishes: publishes,
+		RecentFailures:  failures,
+	}
+}
@@ -1,7 +1,12 @@
 package models
 
 import (
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
 )
 
 func TestIsValidChannel(t *testing.T) {
@@ -12,12 +17,18 @@ func TestIsValidChannel(t *testing.T) {
 		{"twitter", true},
 		{"linkedin", true},
 		{"facebook", true},
+		{"reddit", true},
+		{"telegram", true},
+		{"discord", true},
+		{"webhook", true},
+		{"google_business", true},
+		{"youtube", true},
+		{"tiktok", true},
 		{"instagram", false},
-		{"tiktok", false},
 		{"", false},
 		{"Twitter", false}, // case sensitive
 	}
-	
+
 	for _, tt := range tests {
 		t.Run(tt.channel, func(t *testing.T) {
 			if got := IsValidChannel(tt.channel); got != tt.valid {
@@ -29,17 +40,24 @@ func TestIsValidChannel(t *testing.T) {
 
 func TestValidChannels(t *testing.T) {
 	channels := ValidChannels()
-	
-	if len(channels) != 3 {
-		t.Errorf("Expected 3 channels, got %d", len(channels))
+
+	if len(channels) != 10 {
+		t.Errorf("Expected 10 channels, got %d", len(channels))
 	}
-	
+
 	expected := map[Channel]bool{
-		ChannelTwitter:  true,
-		ChannelLinkedIn: true,
-		ChannelFacebook: true,
+		ChannelTwitter:        true,
+		ChannelLinkedIn:       true,
+		ChannelFacebook:       true,
+		ChannelReddit:         true,
+		ChannelTelegram:       true,
+		ChannelDiscord:        true,
+		ChannelWebhook:        true,
+		ChannelGoogleBusiness: true,
+		ChannelYouTube:        true,
+		ChannelTikTok:         true,
 	}
-	
+
 	for _, ch := range channels {
 		if !expected[ch] {
 			t.Errorf("Unexpected channel: %v", ch)
@@ -52,13 +70,1393 @@ func TestUserToResponse(t *testing.T) {
 		Email:        "test@example.com",
 		PasswordHash: "secret-hash",
 	}
-	
+
 	response := user.ToResponse()
-	
+
 	if response.Email != user.Email {
 		t.Errorf("Email mismatch: got %v, want %v", response.Email, user.Email)
 	}
-	
+
 	// PasswordHash should not be exposed
 	// This is verified by the struct not having PasswordHash field
 }
+
+func TestChannelConnection_TokenStatusAt(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	soon := now.Add(24 * time.Hour)
+	later := now.Add(30 * 24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		token     string
+		expiresAt *time.Time
+		want      TokenStatus
+	}{
+		{"missing token", "", nil, TokenStatusMissing},
+		{"no expiry", "tok", nil, TokenStatusValid},
+		{"expired", "tok", &past, TokenStatusExpired},
+		{"expiring soon", "tok", &soon, TokenStatusExpiring},
+		{"valid", "tok", &later, TokenStatusValid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := &ChannelConnection{AccessToken: tt.token, TokenExpiresAt: tt.expiresAt}
+			if got := conn.TokenStatusAt(now); got != tt.want {
+				t.Errorf("TokenStatusAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnectChannelRequest_Validate(t *testing.T) {
+	str := func(s string) *string { return &s }
+	token := "123456789:AAHdqTcvCH1vGWJxfSeofSAs0K5PALDsaw"
+	webhook := "https://discord.com/api/webhooks/123456789012345678/" + strings.Repeat("x", 68)
+	botToken := "MTIzNDU2Nzg5MDEyMzQ1Njc4.GabcDe.abcdefghijklmnopqrstuvwxyz0123456789AB"
+
+	tests := []struct {
+		name    string
+		channel Channel
+		req     ConnectChannelRequest
+		wantErr bool
+	}{
+		{"telegram channel username", ChannelTelegram, ConnectChannelRequest{AccessToken: token, Target: str(" @launch_news ")}, false},
+		{"telegram chat id", ChannelTelegram, ConnectChannelRequest{AccessToken: token, Target: str("-1001234567890")}, false},
+		{"telegram without target", ChannelTelegram, ConnectChannelRequest{AccessToken: token, Target: str("  ")}, true},
+		{"telegram bad target", ChannelTelegram, ConnectChannelRequest{AccessToken: token, Target: str("t.me/launch")}, true},
+		{"telegram bad token", ChannelTelegram, ConnectChannelRequest{AccessToken: "not-a-bot-token", Target: str("@launch_news")}, true},
+		{"discord webhook", ChannelDiscord, ConnectChannelRequest{AccessToken: webhook}, false},
+		{"discord webhook with target", ChannelDiscord, ConnectChannelRequest{AccessToken: webhook, Target: str("123456789012345678")}, true},
+		{"discord bot", ChannelDiscord, ConnectChannelRequest{AccessToken: botToken, Target: str("123456789012345678")}, false},
+		{"discord bot without target", ChannelDiscord, ConnectChannelRequest{AccessToken: botToken}, true},
+		{"discord bot bad target", ChannelDiscord, ConnectChannelRequest{AccessToken: botToken, Target: str("#general")}, true},
+		{"discord other url", ChannelDiscord, ConnectChannelRequest{AccessToken: "https://example.com/api/webhooks/1/2"}, true},
+		{"webhook endpoint", ChannelWebhook, ConnectChannelRequest{AccessToken: "0123456789abcdef", Target: str("https://hooks.example.com/scheduler")}, false},
+		{"webhook short secret", ChannelWebhook, ConnectChannelRequest{AccessToken: "secret", Target: str("https://hooks.example.com/scheduler")}, true},
+		{"webhook http endpoint", ChannelWebhook, ConnectChannelRequest{AccessToken: "0123456789abcdef", Target: str("http://hooks.example.com/scheduler")}, true},
+		{"webhook private endpoint", ChannelWebhook, ConnectChannelRequest{AccessToken: "0123456789abcdef", Target: str("https://10.0.0.5/hook")}, true},
+		{"webhook localhost endpoint", ChannelWebhook, ConnectChannelRequest{AccessToken: "0123456789abcdef", Target: str("https://localhost:8443/hook")}, true},
+		{"webhook without endpoint", ChannelWebhook, ConnectChannelRequest{AccessToken: "0123456789abcdef"}, true},
+		{"target on other channel", ChannelLinkedIn, ConnectChannelRequest{AccessToken: "tok", Target: str("page")}, true},
+		{"no target on other channel", ChannelLinkedIn, ConnectChannelRequest{AccessToken: "tok"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate(tt.channel)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	req := ConnectChannelRequest{AccessToken: token, Target: str(" @launch_news ")}
+	if err := req.Validate(ChannelTelegram); err != nil {
+		t.Fatal(err)
+	}
+	if *req.Target != "@launch_news" || req.AccountName == nil || *req.AccountName != "@launch_news" {
+		t.Errorf("target = %q, account name = %v, want both @launch_news", *req.Target, req.AccountName)
+	}
+}
+
+func TestNewPlatformHealth(t *testing.T) {
+	tests := []struct {
+		publishes, failures int
+		want                PlatformStatus
+	}{
+		{0, 0, PlatformStatusUnknown},
+		{10, 0, PlatformStatusOperational},
+		{10, 6, PlatformStatusOperational},
+		{2, 3, PlatformStatusOperational}, // too few failures to judge
+		{1, 8, PlatformStatusDegraded},
+	}
+
+	for _, tt := range tests {
+		if got := NewPlatformHealth(tt.publishes, tt.failures).Status; got != tt.want {
+			t.Errorf("NewPlatformHealth(%d, %d) = %v, want %v", tt.publishes, tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTargeting(t *testing.T) {
+	tests := []struct {
+		name      string
+		channel   Channel
+		targeting *PostTargeting
+		wantErr   bool
+	}{
+		{"nil targeting", ChannelTwitter, nil, false},
+		{"twitter unsupported", ChannelTwitter, &PostTargeting{Visibility: "public"}, true},
+		{"linkedin defaults", ChannelLinkedIn, &PostTargeting{}, false},
+		{"linkedin connections", ChannelLinkedIn, &PostTargeting{Visibility: "connections"}, false},
+		{"linkedin page connections", ChannelLinkedIn, &PostTargeting{Destination: "page", PageID: "123", Visibility: "connections"}, true},
+		{"facebook page with audience", ChannelFacebook, &PostTargeting{Destination: "page", PageID: "p1", Audience: []string{"US", "GB"}}, false},
+		{"facebook page without id", ChannelFacebook, &PostTargeting{Destination: "page"}, true},
+		{"facebook profile with audience", ChannelFacebook, &PostTargeting{Audience: []string{"US"}}, true},
+		{"facebook friends", ChannelFacebook, &PostTargeting{Visibility: "friends"}, false},
+		{"unknown destination", ChannelFacebook, &PostTargeting{Destination: "group"}, true},
+		{"reddit subreddit", ChannelReddit, &PostTargeting{Subreddit: "golang"}, false},
+		{"reddit flair", ChannelReddit, &PostTargeting{Subreddit: "golang", FlairID: uuid.NewString(), FlairText: "News"}, false},
+		{"reddit flair text without id", ChannelReddit, &PostTargeting{Subreddit: "golang", FlairText: "News"}, true},
+		{"reddit bad flair id", ChannelReddit, &PostTargeting{Subreddit: "golang", FlairID: "news"}, true},
+		{"reddit bad subreddit", ChannelReddit, &PostTargeting{Subreddit: "go lang"}, true},
+		{"reddit missing subreddit", ChannelReddit, &PostTargeting{}, true},
+		{"reddit visibility", ChannelReddit, &PostTargeting{Subreddit: "golang", Visibility: "public"}, true},
+		{"subreddit on linkedin", ChannelLinkedIn, &PostTargeting{Subreddit: "golang"}, true},
+		{"discord embed", ChannelDiscord, &PostTargeting{Embed: &DiscordEmbed{Title: "Release", URL: "https://example.com", Color: "#5865F2", Footer: "v2.1"}}, false},
+		{"discord bad color", ChannelDiscord, &PostTargeting{Embed: &DiscordEmbed{Color: "blurple"}}, true},
+		{"discord bad url", ChannelDiscord, &PostTargeting{Embed: &DiscordEmbed{URL: "javascript:alert(1)"}}, true},
+		{"discord visibility", ChannelDiscord, &PostTargeting{Visibility: "public"}, true},
+		{"embed on linkedin", ChannelLinkedIn, &PostTargeting{Embed: &DiscordEmbed{}}, true},
+		{"embed on reddit", ChannelReddit, &PostTargeting{Subreddit: "golang", Embed: &DiscordEmbed{}}, true},
+		{"business update", ChannelGoogleBusiness, &PostTargeting{Location: "accounts/1/locations/2"}, false},
+		{"business call to action", ChannelGoogleBusiness, &PostTargeting{Location: "accounts/1/locations/2", CallToAction: &CallToAction{ActionType: "book", URL: "https://example.com/book"}}, false},
+		{"business call", ChannelGoogleBusiness, &PostTargeting{Location: "accounts/1/locations/2", CallToAction: &CallToAction{ActionType: "CALL"}}, false},
+		{"business action without url", ChannelGoogleBusiness, &PostTargeting{Location: "accounts/1/locations/2", CallToAction: &CallToAction{ActionType: "SHOP"}}, true},
+		{"business unknown action", ChannelGoogleBusiness, &PostTargeting{Location: "accounts/1/locations/2", CallToAction: &CallToAction{ActionType: "DONATE", URL: "https://example.com"}}, true},
+		{"business offer", ChannelGoogleBusiness, &PostTargeting{Location: "accounts/1/locations/2", Offer: &BusinessOffer{Title: "20% off", StartDate: "2024-06-01", EndDate: "2024-06-30", CouponCode: "SUMMER20"}}, false},
+		{"business offer ends before start", ChannelGoogleBusiness, &PostTargeting{Location: "accounts/1/locations/2", Offer: &BusinessOffer{Title: "20% off", StartDate: "2024-06-30", EndDate: "2024-06-01"}}, true},
+		{"business offer without title", ChannelGoogleBusiness, &PostTargeting{Location: "accounts/1/locations/2", Offer: &BusinessOffer{StartDate: "2024-06-01", EndDate: "2024-06-30"}}, true},
+		{"business offer with call to action", ChannelGoogleBusiness, &PostTargeting{Location: "accounts/1/locations/2", Offer: &BusinessOffer{Title: "20% off", StartDate: "2024-06-01", EndDate: "2024-06-30"}, CallToAction: &CallToAction{ActionType: "CALL"}}, true},
+		{"business bad location", ChannelGoogleBusiness, &PostTargeting{Location: "Main Street store"}, true},
+		{"location on facebook", ChannelFacebook, &PostTargeting{Location: "accounts/1/locations/2"}, true},
+		{"youtube unlisted for kids", ChannelYouTube, &PostTargeting{Visibility: "unlisted", MadeForKids: true}, false},
+		{"youtube bad privacy", ChannelYouTube, &PostTargeting{Visibility: "friends"}, true},
+		{"youtube subreddit", ChannelYouTube, &PostTargeting{Subreddit: "golang"}, true},
+		{"made for kids on facebook", ChannelFacebook, &PostTargeting{MadeForKids: true}, true},
+		{"tiktok followers", ChannelTikTok, &PostTargeting{Visibility: "followers"}, false},
+		{"tiktok page", ChannelTikTok, &PostTargeting{Destination: "page", PageID: "p1"}, true},
+		{"tiktok bad privacy", ChannelTikTok, &PostTargeting{Visibility: "unlisted"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTargeting(tt.channel, tt.targeting)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTargeting() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTargeting_Defaults(t *testing.T) {
+	targeting := &PostTargeting{}
+	if err := ValidateTargeting(ChannelLinkedIn, targeting); err != nil {
+		t.Fatalf("ValidateTargeting failed: %v", err)
+	}
+
+	if targeting.Destination != DestinationProfile {
+		t.Errorf("Expected default destination %q, got %q", DestinationProfile, targeting.Destination)
+	}
+	if targeting.Visibility != "public" {
+		t.Errorf("Expected default visibility %q, got %q", "public", targeting.Visibility)
+	}
+}
+
+func TestValidateTargeting_RedditPrefix(t *testing.T) {
+	targeting := &PostTargeting{Subreddit: " /r/golang "}
+	if err := ValidateTargeting(ChannelReddit, targeting); err != nil {
+		t.Fatalf("ValidateTargeting failed: %v", err)
+	}
+	if targeting.Subreddit != "golang" {
+		t.Errorf("Subreddit = %q, want %q", targeting.Subreddit, "golang")
+	}
+}
+
+func TestValidateChannelFields(t *testing.T) {
+	title := "Weekly thread"
+	tests := []struct {
+		name      string
+		channel   Channel
+		title     *string
+		targeting *PostTargeting
+		media     []PostMedia
+		wantField string
+	}{
+		{"twitter needs nothing", ChannelTwitter, nil, nil, nil, ""},
+		{"reddit complete", ChannelReddit, &title, &PostTargeting{Subreddit: "golang"}, nil, ""},
+		{"reddit without title", ChannelReddit, nil, &PostTargeting{Subreddit: "golang"}, nil, "title"},
+		{"reddit without subreddit", ChannelReddit, &title, nil, nil, "targeting"},
+		{"google business with location", ChannelGoogleBusiness, nil, &PostTargeting{Location: "accounts/1/locations/2"}, nil, ""},
+		{"google business without location", ChannelGoogleBusiness, nil, nil, nil, "targeting"},
+		{"youtube community post", ChannelYouTube, nil, nil, []PostMedia{{ContentType: "image/png"}}, ""},
+		{"youtube private community post", ChannelYouTube, nil, &PostTargeting{Visibility: "private"}, nil, "targeting"},
+		{"youtube short", ChannelYouTube, &title, &PostTargeting{Visibility: "private"}, []PostMedia{{ContentType: "video/mp4"}}, ""},
+		{"youtube short without title", ChannelYouTube, nil, nil, []PostMedia{{ContentType: "video/mp4"}}, "title"},
+		{"tiktok video", ChannelTikTok, nil, nil, []PostMedia{{ContentType: "video/quicktime"}}, ""},
+		{"tiktok image", ChannelTikTok, nil, nil, []PostMedia{{ContentType: "image/png"}}, "media"},
+		{"tiktok without media", ChannelTikTok, nil, nil, nil, "media"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := ValidateChannelFields(tt.channel, tt.title, tt.targeting, tt.media)
+			switch {
+			case tt.wantField == "" && v != nil:
+				t.Errorf("unexpected violation %+v", v)
+			case tt.wantField != "" && (v == nil || v.Field != tt.wantField):
+				t.Errorf("violation = %+v, want field %q", v, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestValidatePoll(t *testing.T) {
+	tests := []struct {
+		name     string
+		channel  Channel
+		postType PostType
+		poll     *Poll
+		wantErr  bool
+	}{
+		{"text without poll", ChannelLinkedIn, PostTypeText, nil, false},
+		{"text with poll", ChannelTwitter, PostTypeText, &Poll{Options: []string{"a", "b"}, DurationMinutes: 60}, true},
+		{"valid twitter poll", ChannelTwitter, PostTypePoll, &Poll{Options: []string{"Yes", "No"}, DurationMinutes: 1440}, false},
+		{"poll on linkedin", ChannelLinkedIn, PostTypePoll, &Poll{Options: []string{"Yes", "No"}, DurationMinutes: 60}, true},
+		{"missing poll", ChannelTwitter, PostTypePoll, nil, true},
+		{"too few options", ChannelTwitter, PostTypePoll, &Poll{Options: []string{"Yes"}, DurationMinutes: 60}, true},
+		{"too many options", ChannelTwitter, PostTypePoll, &Poll{Options: []string{"a", "b", "c", "d", "e"}, DurationMinutes: 60}, true},
+		{"option too long", ChannelTwitter, PostTypePoll, &Poll{Options: []string{"a", "this option is far too long for twitter"}, DurationMinutes: 60}, true},
+		{"duplicate options", ChannelTwitter, PostTypePoll, &Poll{Options: []string{"Yes", " yes "}, DurationMinutes: 60}, true},
+		{"duration too short", ChannelTwitter, PostTypePoll, &Poll{Options: []string{"a", "b"}, DurationMinutes: 1}, true},
+		{"duration too long", ChannelTwitter, PostTypePoll, &Poll{Options: []string{"a", "b"}, DurationMinutes: 20000}, true},
+		{"unknown type", ChannelTwitter, PostType("video"), nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePoll(tt.channel, tt.postType, tt.poll)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePoll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePostMedia(t *testing.T) {
+	alt := "A chart of quarterly revenue"
+	empty := ""
+	longAlt := strings.Repeat("a", 1001)
+	short, long, tooShort := 45_000, 200_000, 2_000
+
+	tests := []struct {
+		name           string
+		channel        Channel
+		attachments    []PostMedia
+		requireAltText bool
+		wantErr        bool
+	}{
+		{"no attachments", ChannelTwitter, nil, true, false},
+		{"with alt text", ChannelTwitter, []PostMedia{{AltText: &alt}}, true, false},
+		{"missing alt text allowed", ChannelTwitter, []PostMedia{{}}, false, false},
+		{"missing alt text required", ChannelTwitter, []PostMedia{{AltText: &empty}}, true, true},
+		{"alt text too long for twitter", ChannelTwitter, []PostMedia{{AltText: &longAlt}}, false, true},
+		{"alt text fits linkedin", ChannelLinkedIn, []PostMedia{{AltText: &longAlt}}, false, false},
+		{"too many attachments", ChannelTwitter, make([]PostMedia, 5), false, true},
+		{"youtube short", ChannelYouTube, []PostMedia{{ContentType: "video/mp4", DurationMs: &short}}, false, false},
+		{"youtube video too long", ChannelYouTube, []PostMedia{{ContentType: "video/mp4", DurationMs: &long}}, false, true},
+		{"youtube video with image", ChannelYouTube, []PostMedia{{ContentType: "video/mp4"}, {ContentType: "image/png"}}, false, true},
+		{"youtube images", ChannelYouTube, []PostMedia{{ContentType: "image/png"}, {ContentType: "image/jpeg"}}, false, false},
+		{"tiktok video", ChannelTikTok, []PostMedia{{ContentType: "video/mp4", DurationMs: &long}}, false, false},
+		{"tiktok video too short", ChannelTikTok, []PostMedia{{ContentType: "video/mp4", DurationMs: &tooShort}}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePostMedia(tt.channel, tt.attachments, tt.requireAltText)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePostMedia() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLocation(t *testing.T) {
+	lat, lng := 51.5072, -0.1276
+	badLat := 91.0
+
+	tests := []struct {
+		name     string
+		channel  Channel
+		location *PostLocation
+		wantErr  bool
+	}{
+		{"nil location", ChannelLinkedIn, nil, false},
+		{"place id", ChannelTwitter, &PostLocation{Name: "London", PlaceID: "3eb2c704fe8a50cb"}, false},
+		{"coordinates", ChannelTwitter, &PostLocation{Latitude: &lat, Longitude: &lng}, false},
+		{"unsupported channel", ChannelLinkedIn, &PostLocation{PlaceID: "abc"}, true},
+		{"name only", ChannelTwitter, &PostLocation{Name: "London"}, true},
+		{"latitude without longitude", ChannelTwitter, &PostLocation{Latitude: &lat}, true},
+		{"latitude out of range", ChannelTwitter, &PostLocation{Latitude: &badLat, Longitude: &lng}, true},
+		{"name too long", ChannelTwitter, &PostLocation{Name: strings.Repeat("x", 101), PlaceID: "abc"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLocation(tt.channel, tt.location)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLocation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRecycle(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *RecycleSettings
+		wantErr  bool
+	}{
+		{"nil settings", nil, false},
+		{"disabled", &RecycleSettings{MaxCount: 0}, false},
+		{"valid", &RecycleSettings{IntervalDays: 30, MaxCount: 3}, false},
+		{"negative max count", &RecycleSettings{IntervalDays: 30, MaxCount: -1}, true},
+		{"max count too high", &RecycleSettings{IntervalDays: 30, MaxCount: MaxRecycleCount + 1}, true},
+		{"interval too short", &RecycleSettings{IntervalDays: 0, MaxCount: 1}, true},
+		{"interval too long", &RecycleSettings{IntervalDays: MaxRecycleIntervalDays + 1, MaxCount: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRecycle(tt.settings)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRecycle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateAccountSettingsRequest_Validate(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	strPtr := func(v string) *string { return &v }
+
+	tests := []struct {
+		name    string
+		window  *int
+		webhook *string
+		wantErr bool
+	}{
+		{"unchanged", nil, nil, false},
+		{"disabled", intPtr(0), nil, false},
+		{"one hour", intPtr(60), nil, false},
+		{"negative", intPtr(-1), nil, true},
+		{"too wide", intPtr(MaxConflictWindowMinutes + 1), nil, true},
+		{"reminder webhook", nil, strPtr("https://hooks.example.com/remind"), false},
+		{"remove reminder webhook", nil, strPtr(""), false},
+		{"relative webhook", nil, strPtr("/remind"), true},
+		{"non-http webhook", nil, strPtr("ftp://example.com/remind"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := UpdateAccountSettingsRequest{ConflictWindowMinutes: tt.window, ReminderWebhookURL: tt.webhook}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDailyLimits_Check(t *testing.T) {
+	limits := NewDailyLimits(map[string]int{"facebook": 0, "linkedin": 2})
+	day := time.Date(2024, 1, 15, 22, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		channel Channel
+		count   int
+		wantErr bool
+	}{
+		{"under limit", ChannelLinkedIn, 1, false},
+		{"at limit", ChannelLinkedIn, 2, true},
+		{"default limit", ChannelTwitter, DefaultDailyLimits[ChannelTwitter], true},
+		{"override disables limit", ChannelFacebook, 1000, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := limits.Check(tt.channel, day, tt.count)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDayBounds(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	start, end := DayBounds(time.Date(2024, 1, 16, 1, 0, 0, 0, loc)) // 23:00 UTC on the 15th
+
+	if want := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("start = %v, want %v", start, want)
+	}
+	if want := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Errorf("end = %v, want %v", end, want)
+	}
+}
+
+func TestValidateChannelContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel Channel
+		content string
+		wantErr bool
+	}{
+		{"tweet at limit", ChannelTwitter, strings.Repeat("a", 280), false},
+		{"tweet over limit", ChannelTwitter, strings.Repeat("a", 281), true},
+		{"multibyte counted as characters", ChannelTwitter, strings.Repeat("é", 280), false},
+		{"long linkedin post", ChannelLinkedIn, strings.Repeat("a", 1000), false},
+		{"emoji weigh two on twitter", ChannelTwitter, strings.Repeat("👍", 140), false},
+		{"emoji over twitter limit", ChannelTwitter, strings.Repeat("👍", 141), true},
+		{"links weigh 23 on twitter", ChannelTwitter, strings.Repeat("https://example.com/"+strings.Repeat("x", 100)+" ", 11), false},
+		{"zwj sequences count once", ChannelLinkedIn, strings.Repeat("👨‍👩‍👧", 3000), false},
+		{"tiktok caption over limit", ChannelTikTok, strings.Repeat("a", 2201), true},
+		{"unknown channel", Channel("myspace"), strings.Repeat("a", 10000), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateChannelContent(tt.channel, tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateChannelContent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPost_InWorkspace(t *testing.T) {
+	orgID, otherID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name      string
+		postOrg   *uuid.UUID
+		workspace *uuid.UUID
+		want      bool
+	}{
+		{"same organization", &orgID, &orgID, true},
+		{"other organization", &orgID, &otherID, false},
+		{"personal post in organization workspace", nil, &orgID, false},
+		{"organization post in personal workspace", &orgID, nil, false},
+		{"personal post in personal workspace", nil, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			post := &Post{OrgID: tt.postOrg}
+			if got := post.InWorkspace(tt.workspace); got != tt.want {
+				t.Errorf("InWorkspace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublishingSchedule_Validate(t *testing.T) {
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+	tests := []struct {
+		name     string
+		schedule PublishingSchedule
+		wantErr  bool
+	}{
+		{"no windows", PublishingSchedule{}, false},
+		{"weekday office hours", PublishingSchedule{Timezone: "Europe/Berlin", Windows: []PublishingWindow{{Days: weekdays, Start: "08:00", End: "18:00"}}}, false},
+		{"until end of day", PublishingSchedule{Windows: []PublishingWindow{{Days: []time.Weekday{time.Saturday}, Start: "20:00", End: "24:00"}}}, false},
+		{"unknown timezone", PublishingSchedule{Timezone: "Mars/Olympus"}, true},
+		{"no days", PublishingSchedule{Windows: []PublishingWindow{{Start: "08:00", End: "18:00"}}}, true},
+		{"invalid day", PublishingSchedule{Windows: []PublishingWindow{{Days: []time.Weekday{7}, Start: "08:00", End: "18:00"}}}, true},
+		{"ends before start", PublishingSchedule{Windows: []PublishingWindow{{Days: weekdays, Start: "18:00", End: "08:00"}}}, true},
+		{"malformed time", PublishingSchedule{Windows: []PublishingWindow{{Days: weekdays, Start: "8am", End: "18:00"}}}, true},
+		{"out of range time", PublishingSchedule{Windows: []PublishingWindow{{Days: weekdays, Start: "08:00", End: "25:00"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedule.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPublishingSchedule_AllowsAndNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	schedule := &PublishingSchedule{
+		Timezone: "Europe/Berlin",
+		Windows: []PublishingWindow{{
+			Days:  []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+			Start: "08:00",
+			End:   "18:00",
+		}},
+	}
+
+	tests := []struct {
+		name      string
+		at        time.Time
+		wantAllow bool
+		wantNext  time.Time
+	}{
+		{"inside window", time.Date(2024, 1, 15, 10, 0, 0, 0, berlin), true, time.Date(2024, 1, 15, 10, 0, 0, 0, berlin)},
+		{"window start is inclusive", time.Date(2024, 1, 15, 8, 0, 0, 0, berlin), true, time.Date(2024, 1, 15, 8, 0, 0, 0, berlin)},
+		{"window end is exclusive", time.Date(2024, 1, 15, 18, 0, 0, 0, berlin), false, time.Date(2024, 1, 16, 8, 0, 0, 0, berlin)},
+		{"before window same day", time.Date(2024, 1, 15, 6, 30, 0, 0, berlin), false, time.Date(2024, 1, 15, 8, 0, 0, 0, berlin)},
+		{"friday evening waits for monday", time.Date(2024, 1, 19, 19, 0, 0, 0, berlin), false, time.Date(2024, 1, 22, 8, 0, 0, 0, berlin)},
+		{"checked in the schedule's timezone", time.Date(2024, 1, 15, 7, 30, 0, 0, time.UTC), true, time.Date(2024, 1, 15, 7, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := schedule.Allows(tt.at); got != tt.wantAllow {
+				t.Errorf("Allows() = %v, want %v", got, tt.wantAllow)
+			}
+			if got := schedule.Next(tt.at); !got.Equal(tt.wantNext) {
+				t.Errorf("Next() = %v, want %v", got, tt.wantNext)
+			}
+		})
+	}
+
+	var unrestricted *PublishingSchedule
+	if !unrestricted.Allows(time.Now()) {
+		t.Error("Allows() on a nil schedule = false, want true")
+	}
+}
+
+func TestCreateCommentRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{"trimmed", "  Looks good  ", "Looks good", false},
+		{"empty", "", "", true},
+		{"whitespace only", " \n\t ", "", true},
+		{"at limit", strings.Repeat("é", MaxCommentLength), strings.Repeat("é", MaxCommentLength), false},
+		{"too long", strings.Repeat("a", MaxCommentLength+1), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := CreateCommentRequest{Body: tt.body}
+			err := req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && req.Body != tt.want {
+				t.Errorf("Validate() body = %q, want %q", req.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCommentThreads(t *testing.T) {
+	root1, root2, reply, nested, orphanParent := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	comments := []*Comment{
+		{ID: root1},
+		{ID: reply, ParentID: &root1},
+		{ID: root2},
+		{ID: nested, ParentID: &reply},
+		{ID: uuid.New(), ParentID: &orphanParent},
+	}
+
+	threads := BuildCommentThreads(comments)
+
+	if len(threads) != 3 {
+		t.Fatalf("BuildCommentThreads() returned %d roots, want 3", len(threads))
+	}
+	if threads[0].ID != root1 || threads[1].ID != root2 || threads[2].ParentID == nil {
+		t.Errorf("BuildCommentThreads() roots out of order")
+	}
+	if len(threads[0].Replies) != 1 || threads[0].Replies[0].ID != reply {
+		t.Fatalf("root1 replies = %v, want [reply]", threads[0].Replies)
+	}
+	if len(threads[0].Replies[0].Replies) != 1 || threads[0].Replies[0].Replies[0].ID != nested {
+		t.Errorf("reply replies = %v, want [nested]", threads[0].Replies[0].Replies)
+	}
+	if threads[1].Replies == nil || len(threads[1].Replies) != 0 {
+		t.Errorf("root2 replies = %v, want empty slice", threads[1].Replies)
+	}
+}
+
+func TestIsValidWorkflowState(t *testing.T) {
+	tests := []struct {
+		state string
+		want  bool
+	}{
+		{"idea", true},
+		{"drafting", true},
+		{"review", true},
+		{"approved", true},
+		{"published", false},
+		{"Review", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			if got := IsValidWorkflowState(tt.state); got != tt.want {
+				t.Errorf("IsValidWorkflowState(%q) = %v, want %v", tt.state, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsageQuota(t *testing.T) {
+	free := PlanFree.Quota()
+	if got := Plan("enterprise").Quota(); got != free {
+		t.Errorf("unknown plan quota = %+v, want free quota %+v", got, free)
+	}
+
+	tests := []struct {
+		name      string
+		quota     UsageQuota
+		requests  int64
+		publishes int64
+		wantReq   bool
+		wantPub   bool
+	}{
+		{"under quota", UsageQuota{APIRequestsPerDay: 10, PublishesPerDay: 2}, 5, 1, true, true},
+		{"at quota", UsageQuota{APIRequestsPerDay: 10, PublishesPerDay: 2}, 10, 2, true, false},
+		{"over quota", UsageQuota{APIRequestsPerDay: 10, PublishesPerDay: 2}, 11, 3, false, false},
+		{"unlimited", UsageQuota{}, 1 << 40, 1 << 40, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.quota.AllowsRequests(tt.requests); got != tt.wantReq {
+				t.Errorf("AllowsRequests(%d) = %v, want %v", tt.requests, got, tt.wantReq)
+			}
+			if got := tt.quota.AllowsPublish(tt.publishes); got != tt.wantPub {
+				t.Errorf("AllowsPublish(%d) = %v, want %v", tt.publishes, got, tt.wantPub)
+			}
+		})
+	}
+}
+
+func TestValidateABTest(t *testing.T) {
+	tests := []struct {
+		name       string
+		channel    Channel
+		test       *ABTest
+		wantErr    bool
+		wantWindow int
+	}{
+		{"nil", ChannelTwitter, nil, false, 0},
+		{"defaults window", ChannelTwitter, &ABTest{VariantB: "  Variant B  "}, false, DefaultABWindowHours},
+		{"custom window", ChannelLinkedIn, &ABTest{VariantB: "Variant B", WindowHours: 48}, false, 48},
+		{"too short", ChannelTwitter, &ABTest{VariantB: "ab"}, true, 0},
+		{"same as A", ChannelTwitter, &ABTest{VariantB: "Variant A"}, true, 0},
+		{"over channel limit", ChannelTwitter, &ABTest{VariantB: strings.Repeat("b", 281)}, true, 0},
+		{"window too long", ChannelTwitter, &ABTest{VariantB: "Variant B", WindowHours: MaxABWindowHours + 1}, true, 0},
+		{"negative window", ChannelTwitter, &ABTest{VariantB: "Variant B", WindowHours: -1}, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateABTest(tt.channel, "Variant A", tt.test)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateABTest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.test != nil {
+				if tt.test.WindowHours != tt.wantWindow {
+					t.Errorf("WindowHours = %d, want %d", tt.test.WindowHours, tt.wantWindow)
+				}
+				if tt.test.VariantB != "Variant B" {
+					t.Errorf("VariantB = %q, want trimmed", tt.test.VariantB)
+				}
+			}
+		})
+	}
+}
+
+func TestCompareEngagement(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *Engagement
+		want ABVariant
+	}{
+		{"missing B", &Engagement{Likes: 10}, nil, ABInconclusive},
+		{"higher rate wins", &Engagement{Impressions: 1000, Likes: 50}, &Engagement{Impressions: 100, Likes: 10}, ABVariantB},
+		{"interactions without impressions", &Engagement{Likes: 5, Shares: 5}, &Engagement{Impressions: 100, Likes: 8}, ABVariantA},
+		{"tie", &Engagement{Impressions: 100, Clicks: 5}, &Engagement{Impressions: 200, Comments: 10}, ABTie},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareEngagement(tt.a, tt.b); got != tt.want {
+				t.Errorf("CompareEngagement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewABComparison(t *testing.T) {
+	published := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	a := &Post{
+		ID:          uuid.New(),
+		Content:     "Variant A",
+		Status:      PostStatusPublished,
+		PublishedAt: &published,
+		ABTest:      &ABTest{VariantB: "Variant B", WindowHours: 24},
+		Engagement:  &Engagement{Impressions: 100, Likes: 5},
+	}
+
+	c := NewABComparison(a, nil)
+	if c.Status != "collecting_a" || c.NextStepAt == nil || !c.NextStepAt.Equal(published.Add(24*time.Hour)) {
+		t.Errorf("before variant B: status %q, next step %v", c.Status, c.NextStepAt)
+	}
+	if c.Variants[1].PostID != nil || c.Variants[1].Content != "Variant B" {
+		t.Errorf("before variant B: variant B = %+v", c.Variants[1])
+	}
+
+	b := &Post{ID: uuid.New(), Content: "Variant B", Status: PostStatusScheduled}
+	c = NewABComparison(a, b)
+	if c.Status != "collecting_b" || c.NextStepAt != nil || c.Leader != ABInconclusive {
+		t.Errorf("variant B scheduled: status %q, next step %v, leader %v", c.Status, c.NextStepAt, c.Leader)
+	}
+
+	b.Status = PostStatusPublished
+	bPublished := published.Add(25 * time.Hour)
+	b.PublishedAt = &bPublished
+	b.Engagement = &Engagement{Impressions: 100, Likes: 9}
+	winner, decided := ABVariantB, bPublished.Add(24*time.Hour)
+	a.ABTest.Winner, a.ABTest.DecidedAt = &winner, &decided
+	c = NewABComparison(a, b)
+	if c.Status != "complete" || c.NextStepAt != nil || c.Leader != ABVariantB || c.Winner == nil || *c.Winner != ABVariantB {
+		t.Errorf("decided: %+v", c)
+	}
+	if c.Variants[1].EngagementRate != 0.09 {
+		t.Errorf("variant B engagement rate = %v, want 0.09", c.Variants[1].EngagementRate)
+	}
+}
+
+func TestValidateRemindBefore(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name      string
+		minutes   *int
+		allowZero bool
+		wantErr   bool
+	}{
+		{"unset", nil, false, false},
+		{"fifteen minutes", intPtr(15), false, false},
+		{"zero on create", intPtr(0), false, true},
+		{"zero on update", intPtr(0), true, false},
+		{"negative", intPtr(-5), true, true},
+		{"too early", intPtr(MaxRemindBeforeMinutes + 1), false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRemindBefore(tt.minutes, tt.allowZero); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRemindBefore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPost_ReminderAt(t *testing.T) {
+	scheduled := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+	post := &Post{ScheduledAt: scheduled}
+	if _, ok := post.ReminderAt(); ok {
+		t.Error("ReminderAt() without a reminder should report none")
+	}
+
+	minutes := 30
+	post.RemindBeforeMinutes = &minutes
+	at, ok := post.ReminderAt()
+	if !ok || !at.Equal(scheduled.Add(-30*time.Minute)) {
+		t.Errorf("ReminderAt() = %v, %v; want %v", at, ok, scheduled.Add(-30*time.Minute))
+	}
+}
+
+func TestHistoryStatuses(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   []PostStatus
+		ok     bool
+	}{
+		{"", []PostStatus{PostStatusPublished}, true},
+		{"published", []PostStatus{PostStatusPublished}, true},
+		{"failed", []PostStatus{PostStatusFailed}, true},
+		{"canceled", []PostStatus{PostStatusCanceled}, true},
+		{"all", []PostStatus{PostStatusPublished, PostStatusFailed, PostStatusCanceled}, true},
+		{"scheduled", nil, false},
+		{"Failed", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			got, ok := HistoryStatuses(tt.filter)
+			if ok != tt.ok {
+				t.Fatalf("HistoryStatuses(%q) ok = %v, want %v", tt.filter, ok, tt.ok)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("HistoryStatuses(%q) = %v, want %v", tt.filter, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("HistoryStatuses(%q) = %v, want %v", tt.filter, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSchedulingPolicy_Check(t *testing.T) {
+	policy := NewSchedulingPolicy(map[string]int{"pro": 730}, time.Minute)
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		plan    Plan
+		at      time.Time
+		wantErr bool
+	}{
+		{"future", PlanFree, now.Add(time.Hour), false},
+		{"within skew grace", PlanFree, now.Add(-30 * time.Second), false},
+		{"past grace", PlanFree, now.Add(-2 * time.Minute), true},
+		{"free at horizon", PlanFree, now.AddDate(0, 0, 365), false},
+		{"free past horizon", PlanFree, now.AddDate(0, 0, 366), true},
+		{"pro override", PlanPro, now.AddDate(0, 0, 700), false},
+		{"unknown plan uses free", Plan("enterprise"), now.AddDate(0, 0, 400), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.plan, tt.at, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewChannelMeta(t *testing.T) {
+	limits := NewDailyLimits(map[string]int{"twitter": 10})
+
+	twitter := NewChannelMeta(ChannelTwitter, limits)
+	if twitter.MaxContentLength != 280 || twitter.MaxAttachments != 4 || twitter.DailyLimit != 10 {
+		t.Errorf("twitter meta = %+v", twitter)
+	}
+	if twitter.Poll == nil || twitter.Poll.MaxOptions != 4 {
+		t.Errorf("twitter poll = %+v, want max 4 options", twitter.Poll)
+	}
+
+	facebook := NewChannelMeta(ChannelFacebook, limits)
+	if facebook.Poll != nil {
+		t.Errorf("facebook poll = %+v, want nil", facebook.Poll)
+	}
+	if facebook.DailyLimit != DefaultDailyLimits[ChannelFacebook] {
+		t.Errorf("facebook daily limit = %d, want %d", facebook.DailyLimit, DefaultDailyLimits[ChannelFacebook])
+	}
+}
+
+func TestNewListResponse(t *testing.T) {
+	empty := NewListResponse[int](nil, "", 0)
+	if empty.Data == nil || len(empty.Data) != 0 {
+		t.Errorf("Data = %v, want empty non-nil slice", empty.Data)
+	}
+	if empty.NextCursor != nil {
+		t.Errorf("NextCursor = %q, want nil on the last page", *empty.NextCursor)
+	}
+	if empty.Total == nil || *empty.Total != 0 {
+		t.Errorf("Total = %v, want 0", empty.Total)
+	}
+
+	page := NewListResponse([]int{1, 2}, "abc", -1)
+	if page.NextCursor == nil || *page.NextCursor != "abc" {
+		t.Errorf("NextCursor = %v, want abc", page.NextCursor)
+	}
+	if page.Total != nil {
+		t.Errorf("Total = %d, want omitted when unknown", *page.Total)
+	}
+}
+
+func TestNewRateLimitBucket(t *testing.T) {
+	policy := RateLimitPolicy{Scope: "post_create", Limit: 30, WindowSeconds: 60}
+	reset := time.Date(2024, 1, 15, 12, 0, 30, 500, time.UTC)
+
+	b := NewRateLimitBucket(policy, "/api/posts", 25, reset)
+	if b.Scope != "post_create" || b.Limit != 30 || b.Used != 25 || b.Remaining != 5 {
+		t.Errorf("bucket = %+v, want 25 of 30 used", b)
+	}
+	if !b.ResetAt.Equal(reset.Truncate(time.Second)) {
+		t.Errorf("ResetAt = %v, want %v", b.ResetAt, reset.Truncate(time.Second))
+	}
+
+	if over := NewRateLimitBucket(policy, "/api/posts", 40, reset); over.Remaining != 0 {
+		t.Errorf("Remaining = %d, want 0 over the limit", over.Remaining)
+	}
+}
+
+func TestNotificationPreferences_Apply(t *testing.T) {
+	on, off := true, false
+	stored := NotificationPreferences{NotifyPublishSuccess: {Webhook: true}}
+
+	if got := stored.Route(NotifyPublishFailure); got != (NotificationRoute{Email: true, InApp: true}) {
+		t.Errorf("Route(publish_failure) = %+v, want the default", got)
+	}
+
+	req := UpdateNotificationPreferencesRequest{
+		NotifyPublishSuccess: {InApp: &on},
+		NotifyApproval:       {Email: &off},
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+	updated := req.Apply(stored)
+
+	tests := []struct {
+		event NotificationEvent
+		want  NotificationRoute
+	}{
+		{NotifyPublishSuccess, NotificationRoute{Webhook: true, InApp: true}},
+		{NotifyPublishFailure, NotificationRoute{Email: true, InApp: true}},
+		{NotifyApproval, NotificationRoute{InApp: true}},
+		{NotifyDigest, NotificationRoute{Email: true}},
+	}
+	for _, tt := range tests {
+		if got := updated[tt.event]; got != tt.want {
+			t.Errorf("%s = %+v, want %+v", tt.event, got, tt.want)
+		}
+	}
+	if !updated.UsesWebhook() {
+		t.Error("UsesWebhook() = false, want true")
+	}
+
+	if err := (UpdateNotificationPreferencesRequest{"reminder": {Email: &on}}).Validate(); err == nil {
+		t.Error("Validate() accepted an unknown event")
+	}
+}
+
+func TestUser_Suspension(t *testing.T) {
+	user := &User{}
+	if user.Suspended() || user.Suspension() != nil {
+		t.Fatal("new user reported as suspended")
+	}
+
+	at := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	reason := "Spam"
+	user.SuspendedAt, user.SuspensionReason = &at, &reason
+	if got := user.ToResponse().Suspension; got == nil || !got.SuspendedAt.Equal(at) || got.Reason != "Spam" {
+		t.Errorf("Suspension = %+v, want suspended at %v for Spam", got, at)
+	}
+
+	tests := []struct {
+		reason  string
+		wantErr bool
+	}{
+		{"  Repeated spam  ", false},
+		{"   ", true},
+		{strings.Repeat("a", 501), true},
+	}
+	for _, tt := range tests {
+		req := SuspendUserRequest{Reason: tt.reason}
+		if err := req.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q) error = %v, wantErr %v", tt.reason, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCreateInviteRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      CreateInviteRequest
+		wantUses int
+		wantErr  bool
+	}{
+		{"defaults to one use", CreateInviteRequest{}, 1, false},
+		{"many uses with expiry", CreateInviteRequest{MaxUses: 50, ExpiresInHours: 72}, 50, false},
+		{"too many uses", CreateInviteRequest{MaxUses: MaxInviteUses + 1}, 0, true},
+		{"negative expiry", CreateInviteRequest{ExpiresInHours: -1}, 0, true},
+		{"expiry too far out", CreateInviteRequest{ExpiresInHours: MaxInviteExpiryHours + 1}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.req.MaxUses != tt.wantUses {
+				t.Errorf("MaxUses = %d, want %d", tt.req.MaxUses, tt.wantUses)
+			}
+		})
+	}
+
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	if at := (&CreateInviteRequest{}).ExpiresAt(now); at != nil {
+		t.Errorf("ExpiresAt = %v, want nil without expiry", at)
+	}
+	if at := (&CreateInviteRequest{ExpiresInHours: 24}).ExpiresAt(now); at == nil || !at.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("ExpiresAt = %v, want a day later", at)
+	}
+}
+
+func TestOverallStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []Component
+		want       ComponentStatus
+	}{
+		{"no components", nil, StatusOperational},
+		{"all operational", []Component{{Status: StatusOperational}, {Status: StatusOperational}}, StatusOperational},
+		{"one degraded", []Component{{Status: StatusOperational}, {Status: StatusDegraded}}, StatusDegraded},
+		{"outage wins", []Component{{Status: StatusOutage}, {Status: StatusDegraded}}, StatusOutage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OverallStatus(tt.components); got != tt.want {
+				t.Errorf("OverallStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostCursor(t *testing.T) {
+	post := &Post{ID: uuid.New(), ScheduledAt: time.Date(2030, 1, 15, 12, 0, 0, 123456789, time.UTC)}
+	cursor := CursorAfter(post)
+
+	got, err := ParsePostCursor(cursor.String())
+	if err != nil {
+		t.Fatalf("ParsePostCursor() error = %v", err)
+	}
+	if got.ID != post.ID || !got.ScheduledAt.Equal(post.ScheduledAt) {
+		t.Errorf("ParsePostCursor() = %+v, want %+v", got, cursor)
+	}
+
+	for _, s := range []string{"", "!!!", "bm9jb2xvbg", "MTIzOm5vdC1hLXV1aWQ"} {
+		if _, err := ParsePostCursor(s); err == nil {
+			t.Errorf("ParsePostCursor(%q) error = nil, want error", s)
+		}
+	}
+}
+
+func TestUpdateChannelPresetRequest_Validate(t *testing.T) {
+	strPtr := func(v string) *string { return &v }
+
+	tests := []struct {
+		name    string
+		channel Channel
+		req     UpdateChannelPresetRequest
+		wantErr bool
+	}{
+		{"default only", ChannelTwitter, UpdateChannelPresetRequest{IsDefault: true}, false},
+		{"hashtags", ChannelTwitter, UpdateChannelPresetRequest{Hashtags: []string{"#golang", "café_2"}}, false},
+		{"footer", ChannelLinkedIn, UpdateChannelPresetRequest{Footer: strPtr("Posted with love")}, false},
+		{"page targeting", ChannelFacebook, UpdateChannelPresetRequest{Targeting: &PostTargeting{Destination: DestinationPage, PageID: "123"}}, false},
+		{"empty", ChannelTwitter, UpdateChannelPresetRequest{Footer: strPtr("  ")}, true},
+		{"hashtag with space", ChannelTwitter, UpdateChannelPresetRequest{Hashtags: []string{"two words"}}, true},
+		{"empty hashtag", ChannelTwitter, UpdateChannelPresetRequest{Hashtags: []string{"#"}}, true},
+		{"too many hashtags", ChannelTwitter, UpdateChannelPresetRequest{Hashtags: strings.Split(strings.Repeat("a,", MaxPresetHashtags)+"a", ",")}, true},
+		{"footer too long", ChannelTwitter, UpdateChannelPresetRequest{Footer: strPtr(strings.Repeat("a", MaxPresetFooterLength+1))}, true},
+		{"targeting unsupported", ChannelTwitter, UpdateChannelPresetRequest{Targeting: &PostTargeting{Visibility: "public"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(tt.channel); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	req := UpdateChannelPresetRequest{Hashtags: []string{" #Go ", "go", "news"}, Footer: strPtr(" Footer ")}
+	if err := req.Validate(ChannelTwitter); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if strings.Join(req.Hashtags, ",") != "Go,news" {
+		t.Errorf("Expected hashtags normalized and deduplicated, got %v", req.Hashtags)
+	}
+	if *req.Footer != "Footer" {
+		t.Errorf("Expected trimmed footer, got %q", *req.Footer)
+	}
+}
+
+func TestChannelPreset_ApplyContent(t *testing.T) {
+	footer := "Read more on our blog"
+	preset := &ChannelPreset{Hashtags: []string{"go", "news"}, Footer: &footer}
+
+	tests := []struct {
+		name    string
+		preset  *ChannelPreset
+		content string
+		want    string
+	}{
+		{"footer and hashtags", preset, "Hello", "Hello\n\nRead more on our blog\n\n#go #news"},
+		{"hashtag already present", preset, "Hello #News", "Hello #News\n\nRead more on our blog\n\n#go"},
+		{"longer hashtag doesn't count", preset, "Hello #golang #newsroom", "Hello #golang #newsroom\n\nRead more on our blog\n\n#go #news"},
+		{"hashtags only", &ChannelPreset{Hashtags: []string{"go"}}, "Hello", "Hello\n\n#go"},
+		{"nothing to add", &ChannelPreset{IsDefault: true}, "Hello", "Hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.preset.ApplyContent(tt.content); got != tt.want {
+				t.Errorf("ApplyContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignContent(t *testing.T) {
+	signature := "Sent from our newsletter: example.com/news"
+
+	tests := []struct {
+		name      string
+		channel   Channel
+		content   string
+		signature *string
+		want      string
+		wantOK    bool
+	}{
+		{"appended", ChannelLinkedIn, "Hello", &signature, "Hello\n\n" + signature, true},
+		{"no signature", ChannelLinkedIn, "Hello", nil, "Hello", false},
+		{"fits exactly", ChannelTwitter, strings.Repeat("a", 280-2-len(signature)), &signature, strings.Repeat("a", 280-2-len(signature)) + "\n\n" + signature, true},
+		{"over the limit", ChannelTwitter, strings.Repeat("a", 280-1-len(signature)), &signature, strings.Repeat("a", 280-1-len(signature)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := SignContent(tt.channel, tt.content, tt.signature)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("SignContent() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestMarkInboxRequest_Validate(t *testing.T) {
+	if err := (&MarkInboxRequest{}).Validate(); err == nil {
+		t.Error("accepted no ids")
+	}
+	if err := (&MarkInboxRequest{IDs: []uuid.UUID{uuid.New()}, Read: true}).Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+	if err := (&MarkInboxRequest{IDs: make([]uuid.UUID, MaxInboxMarkIDs+1)}).Validate(); err == nil {
+		t.Error("accepted too many ids")
+	}
+}
+
+func TestCreateKeywordMonitorRequest_Validate(t *testing.T) {
+	req := CreateKeywordMonitorRequest{Query: "  @acme  "}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+	if req.Query != "@acme" || len(req.Channels) != len(MonitorChannels()) {
+		t.Errorf("req = %+v, want a trimmed query on every monitor channel", req)
+	}
+
+	req = CreateKeywordMonitorRequest{Query: "acme", Channels: []Channel{ChannelReddit, ChannelReddit}}
+	if err := req.Validate(); err != nil || len(req.Channels) != 1 {
+		t.Errorf("Validate() = %v, channels %v, want duplicates dropped", err, req.Channels)
+	}
+
+	for _, bad := range []CreateKeywordMonitorRequest{
+		{Query: " "},
+		{Query: "@"},
+		{Query: strings.Repeat("a", MaxMonitorQueryLength+1)},
+		{Query: "acme", Channels: []Channel{ChannelWebhook}},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", bad)
+		}
+	}
+}
+
+func TestNewAnalyticsReport(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	title := "Launch day"
+	early, late := from.Add(time.Hour), from.Add(48*time.Hour)
+	posts := []*Post{
+		{ID: uuid.New(), Channel: ChannelTwitter, Content: "Second\nmore", PublishedAt: &late, Engagement: &Engagement{Impressions: 100, Likes: 5}},
+		{ID: uuid.New(), Channel: ChannelTwitter, Title: &title, Content: "ignored", PublishedAt: &early, Engagement: &Engagement{Impressions: 100, Shares: 5}},
+		{ID: uuid.New(), Channel: ChannelLinkedIn, Content: strings.Repeat("a", 100), PublishedAt: &early},
+	}
+
+	r := NewAnalyticsReport(from, to, posts)
+	if r.Total.Posts != 3 || r.Total.Reported != 2 || r.Total.Impressions != 200 || r.Total.EngagementRate != 0.05 {
+		t.Errorf("total = %+v", r.Total)
+	}
+	if len(r.Channels) != 2 || r.Channels[0].Channel != ChannelLinkedIn || r.Channels[1].Posts != 2 {
+		t.Errorf("channels = %+v, want linkedin then twitter", r.Channels)
+	}
+	if r.Posts[0].Excerpt != "Launch day" || r.Posts[2].Excerpt != "Second" {
+		t.Errorf("posts = %+v, want oldest first with title or first line excerpts", r.Posts)
+	}
+	if n := utf8.RuneCountInString(r.Posts[1].Excerpt); n != reportExcerptLength {
+		t.Errorf("long excerpt has %d characters, want %d", n, reportExcerptLength)
+	}
+}
+
+func TestReportMonth(t *testing.T) {
+	from, to := ReportMonth(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC))
+	if !from.Equal(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)) || !to.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ReportMonth = %v, %v, want December 2023", from, to)
+	}
+}
+
+func TestNewAccountGrowth(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	oldName, newName := "old", "new"
+	growth := NewAccountGrowth([]FollowerCount{
+		{Channel: ChannelTwitter, AccountName: &newName, Day: day.AddDate(0, 0, 2), Followers: 130},
+		{Channel: ChannelTwitter, AccountName: &oldName, Day: day, Followers: 100},
+		{Channel: ChannelFacebook, Day: day, Followers: 40},
+	})
+
+	if len(growth) != 2 || growth[0].Channel != ChannelFacebook {
+		t.Fatalf("growth = %+v, want facebook then twitter", growth)
+	}
+	tw := growth[1]
+	if tw.Followers != 130 || tw.Change != 30 || tw.AccountName == nil || *tw.AccountName != "new" {
+		t.Errorf("twitter = %+v, want the latest count and name with the change since the first", tw)
+	}
+	if len(tw.Points) != 2 || !tw.Points[0].Day.Equal(day) {
+		t.Errorf("points = %+v, want oldest first", tw.Points)
+	}
+}
+
+func TestMediaName(t *testing.T) {
+	tests := map[string]string{
+		"photo.png":                "photo.png",
+		`C:\Users\me\Launch 1.jpg`: "Launch 1.jpg",
+		"  /tmp/clip.mp4 ":         "clip.mp4",
+		strings.Repeat("é", 300):   strings.Repeat("é", MaxMediaNameLength),
+	}
+	for in, want := range tests {
+		if got := MediaName(in); got == nil || *got != want {
+			t.Errorf("MediaName(%.20q) = %v, want %.20q", in, got, want)
+		}
+	}
+	if got := MediaName("uploads/ "); got != nil {
+		t.Errorf("MediaName of a blank base name = %q, want nil", *got)
+	}
+}
+
+func TestCreateMediaFolderRequest_Validate(t *testing.T) {
+	req := CreateMediaFolderRequest{Name: "  Brand assets "}
+	if err := req.Validate(); err != nil || req.Name != "Brand assets" {
+		t.Errorf("Validate() = %v, name %q, want trimmed", err, req.Name)
+	}
+	for _, name := range []string{" ", strings.Repeat("x", MaxMediaFolderNameLength+1)} {
+		req := CreateMediaFolderRequest{Name: name}
+		if err := req.Validate(); err == nil {
+			t.Errorf("Validate(%.10q) = nil, want an error", name)
+		}
+	}
+}
+
+func TestBrandChecklist_Validate(t *testing.T) {
+	c := BrandChecklist{
+		Checks:      []BrandCheck{BrandCheckLink, BrandCheckBannedWords, BrandCheckLink},
+		BannedWords: []string{" Cheap ", "cheap", "", "free  trial"},
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+	if len(c.Checks) != 2 || len(c.BannedWords) != 2 || c.BannedWords[0] != "Cheap" {
+		t.Errorf("checklist = %+v, want duplicates and blanks removed", c)
+	}
+
+	for _, bad := range []BrandChecklist{
+		{Checks: []BrandCheck{"spelling"}},
+		{Checks: []BrandCheck{BrandCheckBannedWords}},
+		{BannedWords: []string{"!!!"}},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", bad)
+		}
+	}
+}
+
+func TestBrandChecklist_Evaluate(t *testing.T) {
+	c := &BrandChecklist{
+		Checks:      []BrandCheck{BrandCheckLink, BrandCheckMedia, BrandCheckBannedWords, BrandCheckApproval},
+		BannedWords: []string{"free trial", "cheap"},
+	}
+	ok := BrandPost{
+		Content:  "Cheapest launch yet: https://example.com",
+		Media:    []PostMedia{{MediaID: uuid.New()}},
+		Approved: true,
+	}
+	if v := c.Evaluate(ok); len(v) != 0 {
+		t.Errorf("Evaluate(passing post) = %+v, want none; banned words match whole words", v)
+	}
+
+	title := "Start your FREE trial"
+	failing := BrandPost{Title: &title, Content: "No link here"}
+	v := c.Evaluate(failing)
+	if len(v) != 4 {
+		t.Fatalf("Evaluate(failing post) = %+v, want every check failed", v)
+	}
+	if v[0].Field != "content" || v[1].Field != "media" || v[3].Field != "workflow_state" || v[2].Code != BrandCheckCode {
+		t.Errorf("violations = %+v, want checklist order", v)
+	}
+
+	var none *BrandChecklist
+	if v := none.Evaluate(failing); v != nil {
+		t.Errorf("nil checklist = %+v, want no violations", v)
+	}
+}
+
+func TestCreateBioLinkRequest(t *testing.T) {
+	title := "Launch day"
+	post := &Post{Title: &title, Content: "We're live: www.example.com/launch."}
+	req := CreateBioLinkRequest{}
+	req.FillFromPost(post)
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+	if req.Title != title || req.URL != "https://www.example.com/launch" {
+		t.Errorf("request = %+v, want the post's title and first link", req)
+	}
+
+	for _, bad := range []CreateBioLinkRequest{
+		{Title: "x"},
+		{Title: "x", URL: "javascript:alert(1)"},
+		{Title: strings.Repeat("x", MaxBioLinkTitleLength+1), URL: "https://example.com"},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", bad)
+		}
+	}
+}
+
+func TestRetentionPolicy_With(t *testing.T) {
+	defaults := RetentionPolicy{FailedPostDays: 90, AuditAnonymizeMonths: 24}
+	keep, days := 0, 30
+
+	if got := defaults.With(RetentionOverrides{}); got != defaults {
+		t.Errorf("no overrides = %+v, want the defaults", got)
+	}
+	got := defaults.With(RetentionOverrides{FailedPostDays: &days, AuditAnonymizeMonths: &keep})
+	if want := (RetentionPolicy{FailedPostDays: 30}); got != want {
+		t.Errorf("overridden = %+v, want %+v", got, want)
+	}
+}
+
+func TestRetentionOverrides_Validate(t *testing.T) {
+	ok, negative, tooLong := 30, -1, MaxAuditAnonymizeMonths+1
+	for _, tc := range []struct {
+		o    RetentionOverrides
+		fail bool
+	}{
+		{RetentionOverrides{}, false},
+		{RetentionOverrides{FailedPostDays: &ok, AuditAnonymizeMonths: &ok}, false},
+		{RetentionOverrides{FailedPostDays: &negative}, true},
+		{RetentionOverrides{AuditAnonymizeMonths: &tooLong}, true},
+	} {
+		if err := tc.o.Validate(); (err != nil) != tc.fail {
+			t.Errorf("Validate(%+v) = %v, want failure %v", tc.o, err, tc.fail)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Keyword monitor limits
+const (
+	// MaxKeywordMonitors is the most keyword monitors a user may have
+	MaxKeywordMonitors = 20
+	// MaxMonitorQueryLength is the longest keyword or handle monitored
+	MaxMonitorQueryLength = 100
+)
+
+// MonitorChannels returns the channels whose platforms can be searched for
+// keywords and handles
+func MonitorChannels() []Channel {
+	return []Channel{ChannelTwitter, ChannelReddit, ChannelYouTube}
+}
+
+// IsMonitorChannel checks if a channel can be searched by keyword monitors
+func IsMonitorChannel(c Channel) bool {
+	for _, m := range MonitorChannels() {
+		if c == m {
+			return true
+		}
+	}
+	return false
+}
+
+// KeywordMonitor is a keyword, brand or handle a user follows on platforms
+// other than through their own posts; matches land in their inbox
+type KeywordMonitor struct {
+	ID           uuid.UUID  `json:"id"`
+	UserID       uuid.UUID  `json:"user_id"`
+	Query        string     `json:"query"`    // A keyword or phrase, or an @handle
+	Channels     []Channel  `json:"channels"` // Platforms searched
+	LastPolledAt *time.Time `json:"last_polled_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// IsHandle reports whether the monitor follows an @handle rather than a
+// keyword
+func (m *KeywordMonitor) IsHandle() bool {
+	return strings.HasPrefix(m.Query, "@")
+}
+
+// CreateKeywordMonitorRequest represents the request to add a keyword monitor
+type CreateKeywordMonitorRequest struct {
+	Query    string    `json:"query"`
+	Channels []Channel `json:"channels"` // Empty for every searchable channel
+}
+
+// Validate checks the query and channels, trimming the query and defaulting
+// to every channel that can be searched
+func (r *CreateKeywordMonitorRequest) Validate() error {
+	r.Query = strings.TrimSpace(r.Query)
+	if r.Query == "" || r.Query == "@" {
+		return errors.New("query is required")
+	}
+	if utf8.RuneCountInString(r.Query) > MaxMonitorQueryLength {
+		return fmt.Errorf("query must not exceed %d characters", MaxMonitorQueryLength)
+	}
+
+	if len(r.Channels) == 0 {
+		r.Channels = MonitorChannels()
+		return nil
+	}
+	channels := make([]Channel, 0, len(r.Channels))
+	seen := make(map[Channel]bool, len(r.Channels))
+	for _, c := range r.Channels {
+		if !IsMonitorChannel(c) {
+			return fmt.Errorf("channel %q can't be monitored", c)
+		}
+		if !seen[c] {
+			seen[c] = true
+			channels = append(channels, c)
+		}
+	}
+	r.Channels = channels
+	return nil
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationEvent is a kind of event users are notified about
+type NotificationEvent string
+
+const (
+	NotifyPublishSuccess NotificationEvent = "publish_success" // A post was published
+	NotifyPublishFailure NotificationEvent = "publish_failure" // A post failed after its last retry
+	NotifyApproval       NotificationEvent = "approval"        // A post needs review, or was approved or rejected
+	NotifyDigest         NotificationEvent = "digest"          // A periodic summary of the account's posts
+)
+
+// NotificationEvents lists every event, in the order preferences are shown
+var NotificationEvents = []NotificationEvent{NotifyPublishSuccess, NotifyPublishFailure, NotifyApproval, NotifyDigest}
+
+// NotificationRoute is the channels an event is delivered over
+type NotificationRoute struct {
+	Email   bool `json:"email"`
+	Webhook bool `json:"webhook"` // POSTed to the account's reminder_webhook_url
+	InApp   bool `json:"in_app"`  // An SSE event naming the post
+}
+
+// defaultNotificationRoutes applies to events a user hasn't configured
+var defaultNotificationRoutes = map[NotificationEvent]NotificationRoute{
+	NotifyPublishSuccess: {InApp: true},
+	NotifyPublishFailure: {Email: true, InApp: true},
+	NotifyApproval:       {Email: true, InApp: true},
+	NotifyDigest:         {Email: true},
+}
+
+// NotificationPreferences routes each event to the channels that deliver it.
+// Events missing from the map use their default route.
+type NotificationPreferences map[NotificationEvent]NotificationRoute
+
+// Route returns the channels the event is delivered over
+func (p NotificationPreferences) Route(e NotificationEvent) NotificationRoute {
+	if route, ok := p[e]; ok {
+		return route
+	}
+	return defaultNotificationRoutes[e]
+}
+
+// Resolve returns the route of every event, defaults included
+func (p NotificationPreferences) Resolve() NotificationPreferences {
+	resolved := make(NotificationPreferences, len(NotificationEvents))
+	for _, e := range NotificationEvents {
+		resolved[e] = p.Route(e)
+	}
+	return resolved
+}
+
+// NotificationRouteUpdate changes some of an event's channels; nil fields are left unchanged
+type NotificationRouteUpdate struct {
+	Email   *bool `json:"email"`
+	Webhook *bool `json:"webhook"`
+	InApp   *bool `json:"in_app"`
+}
+
+// UpdateNotificationPreferencesRequest represents the request to change the
+// routes of some events
+type UpdateNotificationPreferencesRequest map[NotificationEvent]NotificationRouteUpdate
+
+// Validate rejects unknown events
+func (r UpdateNotificationPreferencesRequest) Validate() error {
+	for e := range r {
+		if _, ok := defaultNotificationRoutes[e]; !ok {
+			return fmt.Errorf("unknown event %q. Must be one of: publish_success, publish_failure, approval, digest", e)
+		}
+	}
+	return nil
+}
+
+// Apply returns the preferences with the request's changes, every event resolved
+func (r UpdateNotificationPreferencesRequest) Apply(p NotificationPreferences) NotificationPreferences {
+	updated := p.Resolve()
+	for e, change := range r {
+		route := updated[e]
+		if change.Email != nil {
+			route.Email = *change.Email
+		}
+		if change.Webhook != nil {
+			route.Webhook = *change.Webhook
+		}
+		if change.InApp != nil {
+			route.InApp = *change.InApp
+		}
+		updated[e] = route
+	}
+	return updated
+}
+
+// UsesWebhook reports whether any event is routed to the webhook
+func (p NotificationPreferences) UsesWebhook() bool {
+	for _, e := range NotificationEvents {
+		if p.Route(e).Webhook {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationWebhook is the body of a notification webhook
+type NotificationWebhook struct {
+	Event   NotificationEvent `json:"event"`
+	PostID  *uuid.UUID        `json:"post_id,omitempty"`
+	Subject string            `json:"subject"`
+	Message string            `json:"message"`
+	SentAt  time.Time         `json:"sent_at"`
+}
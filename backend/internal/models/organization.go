@@ -0,0 +1,104 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrgRole is a member's role within an organization
+type OrgRole string
+
+const (
+	OrgRoleOwner  OrgRole = "owner"
+	OrgRoleAdmin  OrgRole = "admin"
+	OrgRoleMember OrgRole = "member"
+)
+
+// IsValidOrgRole checks if a role value is valid
+func IsValidOrgRole(r string) bool {
+	switch OrgRole(r) {
+	case OrgRoleOwner, OrgRoleAdmin, OrgRoleMember:
+		return true
+	}
+	return false
+}
+
+// CanManageMembers reports whether the role may add and remove members
+func (r OrgRole) CanManageMembers() bool {
+	return r == OrgRoleOwner || r == OrgRoleAdmin
+}
+
+// CanApprove reports whether the role may approve posts; posts by other
+// members wait in pending_approval
+func (r OrgRole) CanApprove() bool {
+	return r == OrgRoleOwner || r == OrgRoleAdmin
+}
+
+// Organization is a team whose members share a workspace of posts
+type Organization struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// InWorkspace reports whether the post belongs to the organization workspace orgID
+func (p *Post) InWorkspace(orgID *uuid.UUID) bool {
+	return p.OrgID != nil && orgID != nil && *p.OrgID == *orgID
+}
+
+// OrganizationMember is a user's membership in an organization
+type OrganizationMember struct {
+	UserID             uuid.UUID `json:"user_id"`
+	Email              string    `json:"email"`
+	Role               OrgRole   `json:"role"`
+	CanOverrideWindows bool      `json:"can_override_windows"` // May schedule outside publishing windows
+	JoinedAt           time.Time `json:"joined_at"`
+}
+
+// WorkspaceType distinguishes a user's own posts from an organization's
+type WorkspaceType string
+
+const (
+	WorkspacePersonal     WorkspaceType = "personal"
+	WorkspaceOrganization WorkspaceType = "organization"
+)
+
+// Workspace is a set of posts a user can switch to: their personal posts,
+// or those of an organization they belong to
+type Workspace struct {
+	ID      *uuid.UUID    `json:"id"` // Organization ID; nil for the personal workspace
+	Name    string        `json:"name"`
+	Type    WorkspaceType `json:"type"`
+	Role    OrgRole       `json:"role"`
+	Current bool          `json:"current"`
+}
+
+// Matches reports whether the workspace is the organization orgID
+func (ws Workspace) Matches(orgID *uuid.UUID) bool {
+	return ws.ID != nil && orgID != nil && *ws.ID == *orgID
+}
+
+// CreateOrganizationRequest represents the request to create an organization
+type CreateOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+// AddMemberRequest represents the request to add a user to an organization
+// or change their role
+type AddMemberRequest struct {
+	Email              string `json:"email"`
+	Role               string `json:"role"`
+	CanOverrideWindows bool   `json:"can_override_windows"`
+}
+
+// SwitchWorkspaceRequest represents the request to switch workspaces;
+// a nil workspace_id switches to the personal workspace
+type SwitchWorkspaceRequest struct {
+	WorkspaceID *uuid.UUID `json:"workspace_id"`
+}
+
+// RejectPostRequest represents the request to reject a pending post
+type RejectPostRequest struct {
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,24 @@
+package models
+
+// Plan is a user's subscription plan
+type Plan string
+
+const (
+	PlanFree Plan = "free"
+	PlanPro  Plan = "pro"
+)
+
+// IsValidPlan checks if a plan value is valid
+func IsValidPlan(p string) bool {
+	return p == string(PlanFree) || p == string(PlanPro)
+}
+
+// HasPriorityPublishing reports whether the plan's posts use the priority queue lane
+func (p Plan) HasPriorityPublishing() bool {
+	return p == PlanPro
+}
+
+// SetPlanRequest represents an admin request to change a user's plan
+type SetPlanRequest struct {
+	Plan string `json:"plan"`
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// PostType represents the kind of content a post carries
+type PostType string
+
+const (
+	PostTypeText PostType = "text"
+	PostTypePoll PostType = "poll"
+)
+
+// Poll holds the options and duration of a poll post
+type Poll struct {
+	Options         []string `json:"options"`
+	DurationMinutes int      `json:"duration_minutes"`
+}
+
+// PollConstraints describes a channel's limits for polls
+type PollConstraints struct {
+	MinOptions         int `json:"min_options"`
+	MaxOptions         int `json:"max_options"`
+	MaxOptionLength    int `json:"max_option_length"`
+	MinDurationMinutes int `json:"min_duration_minutes"`
+	MaxDurationMinutes int `json:"max_duration_minutes"`
+}
+
+// pollConstraints lists the channels that support polls and their limits
+var pollConstraints = map[Channel]PollConstraints{
+	ChannelTwitter: {
+		MinOptions:         2,
+		MaxOptions:         4,
+		MaxOptionLength:    25,
+		MinDurationMinutes: 5,
+		MaxDurationMinutes: 7 * 24 * 60,
+	},
+}
+
+// GetPollConstraints returns the poll limits for a channel, if it supports polls
+func GetPollConstraints(c Channel) (PollConstraints, bool) {
+	pc, ok := pollConstraints[c]
+	return pc, ok
+}
+
+// ValidatePoll checks a post's type and poll data against the channel's constraints
+// and normalizes option whitespace
+func ValidatePoll(c Channel, t PostType, p *Poll) error {
+	switch t {
+	case PostTypeText:
+		if p != nil {
+			return errors.New("poll is only allowed when type is poll")
+		}
+		return nil
+	case PostTypePoll:
+	default:
+		return errors.New("type must be one of: text, poll")
+	}
+
+	pc, ok := pollConstraints[c]
+	if !ok {
+		return fmt.Errorf("polls are not supported for %s", c)
+	}
+	if p == nil {
+		return errors.New("poll is required when type is poll")
+	}
+
+	if len(p.Options) < pc.MinOptions || len(p.Options) > pc.MaxOptions {
+		return fmt.Errorf("%s polls must have between %d and %d options", c, pc.MinOptions, pc.MaxOptions)
+	}
+
+	seen := make(map[string]bool, len(p.Options))
+	for i, option := range p.Options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			return errors.New("poll options must not be empty")
+		}
+		if utf8.RuneCountInString(option) > pc.MaxOptionLength {
+			return fmt.Errorf("%s poll options must not exceed %d characters", c, pc.MaxOptionLength)
+		}
+		key := strings.ToLower(option)
+		if seen[key] {
+			return errors.New("poll options must be unique")
+		}
+		seen[key] = true
+		p.Options[i] = option
+	}
+
+	if p.DurationMinutes < pc.MinDurationMinutes || p.DurationMinutes > pc.MaxDurationMinutes {
+		return fmt.Errorf("%s poll duration must be between %d and %d minutes", c, pc.MinDurationMinutes, pc.MaxDurationMinutes)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxPresetHashtags is the most hashtags a channel preset may append
+	MaxPresetHashtags = 30
+	// MaxHashtagLength is the longest hashtag accepted, without its #
+	MaxHashtagLength = 100
+	// MaxPresetFooterLength is the longest footer a channel preset may append
+	MaxPresetFooterLength = 500
+	// MaxSignatureLength is the longest signature a channel preset may append
+	MaxSignatureLength = 280
+)
+
+// ChannelPreset holds a user's defaults for new posts to one channel. They
+// are applied when a post is created unless the request sets skip_preset.
+type ChannelPreset struct {
+	Channel   Channel        `json:"channel"`
+	IsDefault bool           `json:"is_default"`          // Used for new posts that name no channel
+	Targeting *PostTargeting `json:"targeting,omitempty"` // Used when a post has no targeting of its own
+	Hashtags  []string       `json:"hashtags"`            // Appended to the content, without the #
+	Footer    *string        `json:"footer,omitempty"`    // Appended to the content, before the hashtags
+	Signature *string        `json:"signature,omitempty"` // Appended as the post publishes, when it fits
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// UpdateChannelPresetRequest represents the request to set a channel preset
+type UpdateChannelPresetRequest struct {
+	IsDefault bool           `json:"is_default"`
+	Targeting *PostTargeting `json:"targeting"`
+	Hashtags  []string       `json:"hashtags"`
+	Footer    *string        `json:"footer"`    // Empty or nil for none
+	Signature *string        `json:"signature"` // Empty or nil for none
+}
+
+// Validate checks the preset against the channel, normalizing hashtags to
+// their bare text and dropping an empty footer or signature
+func (r *UpdateChannelPresetRequest) Validate(c Channel) error {
+	if err := ValidateTargeting(c, r.Targeting); err != nil {
+		return err
+	}
+
+	if len(r.Hashtags) > MaxPresetHashtags {
+		return fmt.Errorf("hashtags must not exceed %d entries", MaxPresetHashtags)
+	}
+	hashtags := make([]string, 0, len(r.Hashtags))
+	seen := make(map[string]bool, len(r.Hashtags))
+	for _, tag := range r.Hashtags {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		if !validHashtag(tag) {
+			return fmt.Errorf("invalid hashtag %q: use 1 to %d letters, digits or underscores", tag, MaxHashtagLength)
+		}
+		if key := strings.ToLower(tag); !seen[key] {
+			seen[key] = true
+			hashtags = append(hashtags, tag)
+		}
+	}
+	r.Hashtags = hashtags
+
+	if r.Footer != nil {
+		footer := strings.TrimSpace(*r.Footer)
+		if utf8.RuneCountInString(footer) > MaxPresetFooterLength {
+			return fmt.Errorf("footer must not exceed %d characters", MaxPresetFooterLength)
+		}
+		if footer == "" {
+			r.Footer = nil
+		} else {
+			r.Footer = &footer
+		}
+	}
+
+	if r.Signature != nil {
+		signature := strings.TrimSpace(*r.Signature)
+		if utf8.RuneCountInString(signature) > MaxSignatureLength {
+			return fmt.Errorf("signature must not exceed %d characters", MaxSignatureLength)
+		}
+		if signature == "" {
+			r.Signature = nil
+		} else {
+			r.Signature = &signature
+		}
+	}
+
+	if r.Targeting == nil && len(r.Hashtags) == 0 && r.Footer == nil && r.Signature == nil && !r.IsDefault {
+		return errors.New("preset must set is_default, targeting, hashtags, a footer or a signature")
+	}
+	return nil
+}
+
+func validHashtag(tag string) bool {
+	if tag == "" || utf8.RuneCountInString(tag) > MaxHashtagLength {
+		return false
+	}
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyContent appends the preset's footer and any of its hashtags the
+// content doesn't already contain
+func (p *ChannelPreset) ApplyContent(content string) string {
+	var parts []string
+	if p.Footer != nil {
+		parts = append(parts, *p.Footer)
+	}
+
+	lower := strings.ToLower(content)
+	var tags []string
+	for _, tag := range p.Hashtags {
+		if !containsHashtag(lower, strings.ToLower(tag)) {
+			tags = append(tags, "#"+tag)
+		}
+	}
+	if len(tags) > 0 {
+		parts = append(parts, strings.Join(tags, " "))
+	}
+
+	if len(parts) == 0 {
+		return content
+	}
+	return content + "\n\n" + strings.Join(parts, "\n\n")
+}
+
+// SignContent appends a channel preset's signature to content published to
+// the channel. It reports false and returns content unchanged when there is no
+// signature or the signed content would be over the channel's length limit.
+func SignContent(c Channel, content string, signature *string) (string, bool) {
+	if signature == nil {
+		return content, false
+	}
+	signed := content + "\n\n" + *signature
+	if ValidateChannelContent(c, signed) != nil {
+		return content, false
+	}
+	return signed, true
+}
+
+// containsHashtag reports whether #tag appears in content as a whole hashtag,
+// not the start of a longer one. Both are lower case.
+func containsHashtag(content, tag string) bool {
+	needle := "#" + tag
+	for i := 0; ; {
+		j := strings.Index(content[i:], needle)
+		if j < 0 {
+			return false
+		}
+		end := i + j + len(needle)
+		next, _ := utf8.DecodeRuneInString(content[end:])
+		if end == len(content) || !(unicode.IsLetter(next) || unicode.IsDigit(next) || next == '_') {
+			return true
+		}
+		i = end
+	}
+}
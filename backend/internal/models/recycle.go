@@ -0,0 +1,41 @@
+package models
+
+import "fmt"
+
+const (
+	// MinRecycleIntervalDays is the shortest gap between a publish and its recycled copy
+	MinRecycleIntervalDays = 1
+	// MaxRecycleIntervalDays is the longest gap between a publish and its recycled copy
+	MaxRecycleIntervalDays = 365
+	// MaxRecycleCount is the most times a post may be recycled
+	MaxRecycleCount = 10
+)
+
+// RecycleSettings controls evergreen recycling of a post. After the post is
+// published, the worker schedules a copy IntervalDays later, up to MaxCount times.
+type RecycleSettings struct {
+	IntervalDays int `json:"interval_days"`
+	MaxCount     int `json:"max_count"` // Zero disables recycling
+}
+
+// Enabled reports whether the settings request any recycling
+func (r *RecycleSettings) Enabled() bool {
+	return r != nil && r.MaxCount > 0
+}
+
+// ValidateRecycle checks recycle settings. Nil or disabled settings are always valid.
+func ValidateRecycle(r *RecycleSettings) error {
+	if !r.Enabled() {
+		if r != nil && r.MaxCount < 0 {
+			return fmt.Errorf("recycle max_count must be between 0 and %d", MaxRecycleCount)
+		}
+		return nil
+	}
+	if r.MaxCount > MaxRecycleCount {
+		return fmt.Errorf("recycle max_count must be between 0 and %d", MaxRecycleCount)
+	}
+	if r.IntervalDays < MinRecycleIntervalDays || r.IntervalDays > MaxRecycleIntervalDays {
+		return fmt.Errorf("recycle interval_days must be between %d and %d", MinRecycleIntervalDays, MaxRecycleIntervalDays)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxRedditFlairTextLength is the longest flair text Reddit accepts
+const MaxRedditFlairTextLength = 64
+
+// subredditName matches Reddit's community names: 3 to 21 letters, digits
+// or underscores
+var subredditName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_]{2,20}$`)
+
+// validateRedditTargeting checks the subreddit and flair of a Reddit post,
+// accepting the subreddit with or without its r/ prefix. Reddit posts have
+// no visibility, destination or audience.
+func validateRedditTargeting(t *PostTargeting) error {
+	if t.Visibility != "" || t.Destination != "" || t.PageID != "" || len(t.Audience) > 0 || t.Embed != nil ||
+		t.Location != "" || t.Offer != nil || t.CallToAction != nil || t.MadeForKids {
+		return fmt.Errorf("targeting for %s only supports subreddit, flair_id and flair_text", ChannelReddit)
+	}
+
+	t.Subreddit = strings.TrimSpace(t.Subreddit)
+	t.Subreddit = strings.TrimPrefix(strings.TrimPrefix(t.Subreddit, "/"), "r/")
+	if !subredditName.MatchString(t.Subreddit) {
+		return errors.New("targeting subreddit must be 3 to 21 letters, digits or underscores")
+	}
+
+	t.FlairID = strings.TrimSpace(t.FlairID)
+	if t.FlairID != "" {
+		if _, err := uuid.Parse(t.FlairID); err != nil {
+			return errors.New("targeting flair_id must be a flair template ID")
+		}
+	}
+	t.FlairText = strings.TrimSpace(t.FlairText)
+	if t.FlairText != "" && t.FlairID == "" {
+		return errors.New("targeting flair_text requires a flair_id")
+	}
+	if utf8.RuneCountInString(t.FlairText) > MaxRedditFlairTextLength {
+		return fmt.Errorf("targeting flair_text must not exceed %d characters", MaxRedditFlairTextLength)
+	}
+	return nil
+}
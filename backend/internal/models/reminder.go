@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxRemindBeforeMinutes is the earliest a reminder may be sent before publishing
+const MaxRemindBeforeMinutes = 7 * 24 * 60
+
+// ValidateRemindBefore checks a post's remind_before_minutes. Nil is valid;
+// zero is only valid on updates, where it turns the reminder off.
+func ValidateRemindBefore(minutes *int, allowZero bool) error {
+	if minutes == nil || (allowZero && *minutes == 0) {
+		return nil
+	}
+	if *minutes < 1 || *minutes > MaxRemindBeforeMinutes {
+		return fmt.Errorf("remind_before_minutes must be between 1 and %d", MaxRemindBeforeMinutes)
+	}
+	return nil
+}
+
+// ReminderAt returns when the post's reminder is due, if it has one
+func (p *Post) ReminderAt() (time.Time, bool) {
+	if p.RemindBeforeMinutes == nil || *p.RemindBeforeMinutes <= 0 {
+		return time.Time{}, false
+	}
+	return p.ScheduledAt.Add(-time.Duration(*p.RemindBeforeMinutes) * time.Minute), true
+}
+
+// ValidateWebhookURL checks that a user-supplied outbound webhook URL is an
+// absolute http(s) URL
+func ValidateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return errors.New("webhook URL must be an absolute http or https URL")
+	}
+	return nil
+}
+
+// PostReminder is the body of a reminder webhook
+type PostReminder struct {
+	Event       string    `json:"event"` // Always "post.reminder"
+	PostID      uuid.UUID `json:"post_id"`
+	Title       *string   `json:"title,omitempty"`
+	Content     string    `json:"content"`
+	Channel     Channel   `json:"channel"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxReportDays is the longest range an analytics report covers
+	MaxReportDays = 366
+	// reportExcerptLength is the most characters of a post shown in reports
+	reportExcerptLength = 80
+)
+
+// EngagementTotals sums the engagement of a set of published posts
+type EngagementTotals struct {
+	Posts    int `json:"posts"`
+	Reported int `json:"reported"` // Posts with engagement reported
+	Engagement
+	EngagementRate float64 `json:"engagement_rate"` // Interactions per impression of the reported posts
+}
+
+// add counts a published post and its engagement, if reported
+func (t *EngagementTotals) add(e *Engagement) {
+	t.Posts++
+	if e == nil {
+		return
+	}
+	t.Reported++
+	t.Impressions += e.Impressions
+	t.Likes += e.Likes
+	t.Comments += e.Comments
+	t.Shares += e.Shares
+	t.Clicks += e.Clicks
+	t.EngagementRate = t.Engagement.Rate()
+}
+
+// ChannelEngagement is the engagement totals of one channel
+type ChannelEngagement struct {
+	Channel Channel `json:"channel"`
+	EngagementTotals
+}
+
+// PostEngagement is one published post's row in an analytics report
+type PostEngagement struct {
+	PostID      uuid.UUID   `json:"post_id"`
+	Channel     Channel     `json:"channel"`
+	Excerpt     string      `json:"excerpt"` // The title, or the start of the content
+	PublishedAt time.Time   `json:"published_at"`
+	Engagement  *Engagement `json:"engagement,omitempty"`
+}
+
+// AnalyticsReport summarizes the engagement of the posts published in
+// [From, To)
+type AnalyticsReport struct {
+	From     time.Time           `json:"from"`
+	To       time.Time           `json:"to"`
+	Total    EngagementTotals    `json:"total"`
+	Channels []ChannelEngagement `json:"channels"` // By channel name
+	Posts    []PostEngagement    `json:"posts"`    // Oldest first
+}
+
+// NewAnalyticsReport builds the report of the given published posts
+func NewAnalyticsReport(from, to time.Time, posts []*Post) *AnalyticsReport {
+	r := &AnalyticsReport{
+		From:     from,
+		To:       to,
+		Channels: []ChannelEngagement{},
+		Posts:    make([]PostEngagement, 0, len(posts)),
+	}
+
+	byChannel := make(map[Channel]*EngagementTotals)
+	for _, p := range posts {
+		publishedAt := p.ScheduledAt
+		if p.PublishedAt != nil {
+			publishedAt = *p.PublishedAt
+		}
+		r.Posts = append(r.Posts, PostEngagement{
+			PostID:      p.ID,
+			Channel:     p.Channel,
+			Excerpt:     postExcerpt(p),
+			PublishedAt: publishedAt,
+			Engagement:  p.Engagement,
+		})
+
+		r.Total.add(p.Engagement)
+		if byChannel[p.Channel] == nil {
+			byChannel[p.Channel] = &EngagementTotals{}
+		}
+		byChannel[p.Channel].add(p.Engagement)
+	}
+
+	for c, totals := range byChannel {
+		r.Channels = append(r.Channels, ChannelEngagement{Channel: c, EngagementTotals: *totals})
+	}
+	sort.Slice(r.Channels, func(i, j int) bool { return r.Channels[i].Channel < r.Channels[j].Channel })
+	sort.SliceStable(r.Posts, func(i, j int) bool { return r.Posts[i].PublishedAt.Before(r.Posts[j].PublishedAt) })
+	return r
+}
+
+// postExcerpt returns a post's title, or the first line of its content cut
+// to reportExcerptLength characters
+func postExcerpt(p *Post) string {
+	text := p.Content
+	if p.Title != nil && strings.TrimSpace(*p.Title) != "" {
+		text = *p.Title
+	}
+	text = strings.TrimSpace(text)
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = strings.TrimSpace(text[:i])
+	}
+	if utf8.RuneCountInString(text) <= reportExcerptLength {
+		return text
+	}
+	return string([]rune(text)[:reportExcerptLength-1]) + "…"
+}
+
+// ReportMonth returns the calendar month before the one containing t, in
+// t's location, as the range [from, to)
+func ReportMonth(t time.Time) (from, to time.Time) {
+	to = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return to.AddDate(0, -1, 0), to
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Retention limits
+const (
+	// MaxFailedPostDays is the longest failed posts may be kept for
+	MaxFailedPostDays = 3650
+	// MaxAuditAnonymizeMonths is the longest audit log actors may be kept for
+	MaxAuditAnonymizeMonths = 120
+)
+
+// AuditPersonalDetails are the audit entry details anonymized along with the
+// actor: members' emails, and rejection reasons, which may name the reviewer
+var AuditPersonalDetails = []string{"email", "reason"}
+
+// RetentionPolicy is how long user data is kept. Zero keeps it forever.
+type RetentionPolicy struct {
+	FailedPostDays       int `json:"failed_post_days"`       // Failed posts are deleted this long after they last changed
+	AuditAnonymizeMonths int `json:"audit_anonymize_months"` // Audit entries lose their actor and personal details after this long
+}
+
+// With returns the policy with an organization's overrides applied
+func (p RetentionPolicy) With(o RetentionOverrides) RetentionPolicy {
+	if o.FailedPostDays != nil {
+		p.FailedPostDays = *o.FailedPostDays
+	}
+	if o.AuditAnonymizeMonths != nil {
+		p.AuditAnonymizeMonths = *o.AuditAnonymizeMonths
+	}
+	return p
+}
+
+// RetentionOverrides are an organization's own retention rules; unset rules
+// follow the default policy
+type RetentionOverrides struct {
+	FailedPostDays       *int `json:"failed_post_days,omitempty"`
+	AuditAnonymizeMonths *int `json:"audit_anonymize_months,omitempty"`
+}
+
+// Validate checks each override is in range
+func (o RetentionOverrides) Validate() error {
+	if d := o.FailedPostDays; d != nil && (*d < 0 || *d > MaxFailedPostDays) {
+		return fmt.Errorf("failed_post_days must be between 0 and %d", MaxFailedPostDays)
+	}
+	if m := o.AuditAnonymizeMonths; m != nil && (*m < 0 || *m > MaxAuditAnonymizeMonths) {
+		return fmt.Errorf("audit_anonymize_months must be between 0 and %d", MaxAuditAnonymizeMonths)
+	}
+	return nil
+}
+
+// RetentionSettings describes an organization's retention rules
+type RetentionSettings struct {
+	Defaults  RetentionPolicy    `json:"defaults"`
+	Overrides RetentionOverrides `json:"overrides"`
+	Effective RetentionPolicy    `json:"effective"`
+}
+
+// RetentionReport counts what a retention run did, or would do in a dry run
+type RetentionReport struct {
+	DryRun                 bool      `json:"dry_run"`
+	RunAt                  time.Time `json:"run_at"`
+	FailedPostsPurged      int64     `json:"failed_posts_purged"`
+	AuditEntriesAnonymized int64     `json:"audit_entries_anonymized"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultConflictWindowMinutes is the conflict window for new accounts
+	DefaultConflictWindowMinutes = 15
+	// MaxConflictWindowMinutes is the widest conflict window a user may configure
+	MaxConflictWindowMinutes = 24 * 60
+)
+
+// AccountSettings holds a user's scheduling preferences
+type AccountSettings struct {
+	// ConflictWindowMinutes warns when a new post lands within this many minutes
+	// of another scheduled post on the same channel. Zero disables the check.
+	ConflictWindowMinutes int `json:"conflict_window_minutes"`
+
+	// ReminderWebhookURL receives a POST for each post reminder, alongside
+	// the email and SSE event, and for notifications routed to the webhook
+	ReminderWebhookURL *string `json:"reminder_webhook_url"`
+}
+
+// UpdateAccountSettingsRequest represents the request to update account settings
+type UpdateAccountSettingsRequest struct {
+	ConflictWindowMinutes *int    `json:"conflict_window_minutes"`
+	ReminderWebhookURL    *string `json:"reminder_webhook_url"` // An empty string removes it
+}
+
+// Validate checks the requested settings
+func (r *UpdateAccountSettingsRequest) Validate() error {
+	if m := r.ConflictWindowMinutes; m != nil && (*m < 0 || *m > MaxConflictWindowMinutes) {
+		return fmt.Errorf("conflict_window_minutes must be between 0 and %d", MaxConflictWindowMinutes)
+	}
+	if u := r.ReminderWebhookURL; u != nil && *u != "" {
+		if err := ValidateWebhookURL(*u); err != nil {
+			return fmt.Errorf("reminder_webhook_url: %w", err)
+		}
+	}
+	return nil
+}
+
+// Settings returns the user's account settings
+func (u *User) Settings() AccountSettings {
+	return AccountSettings{
+		ConflictWindowMinutes: u.ConflictWindowMinutes,
+		ReminderWebhookURL:    u.ReminderWebhookURL,
+	}
+}
+
+// ConflictWindow returns the user's conflict window as a duration
+func (u *User) ConflictWindow() time.Duration {
+	return time.Duration(u.ConflictWindowMinutes) * time.Minute
+}
+
+// PostConflict is another scheduled post close to a new post on the same channel
+type PostConflict struct {
+	PostID      uuid.UUID `json:"post_id"`
+	Title       *string   `json:"title,omitempty"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+// CreatePostResponse is the created post plus any scheduling conflicts and
+// changes made to its text
+type CreatePostResponse struct {
+	*Post
+	Conflicts []PostConflict   `json:"conflicts,omitempty"`
+	Warnings  []ContentWarning `json:"warnings,omitempty"`
+}
+
+// UpdatePostResponse is the updated post plus any changes made to its text
+type UpdatePostResponse struct {
+	*Post
+	Warnings []ContentWarning `json:"warnings,omitempty"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// ComponentStatus is the health of one part of the system, as shown on a status page
+type ComponentStatus string
+
+const (
+	StatusOperational ComponentStatus = "operational"
+	StatusDegraded    ComponentStatus = "degraded" // Working, but slow or partly unavailable
+	StatusOutage      ComponentStatus = "outage"
+)
+
+// severity orders statuses from healthy to down
+var severity = map[ComponentStatus]int{
+	StatusOperational: 0,
+	StatusDegraded:    1,
+	StatusOutage:      2,
+}
+
+// Component is the health of one part of the system
+type Component struct {
+	Name      string          `json:"name"`
+	Status    ComponentStatus `json:"status"`
+	Message   string          `json:"message,omitempty"`
+	LatencyMS *int64          `json:"latency_ms,omitempty"` // Round trip of the health probe, where there is one
+}
+
+// SystemStatus is the public summary served by GET /api/status
+type SystemStatus struct {
+	Status     ComponentStatus `json:"status"` // The worst status of any component
+	UpdatedAt  time.Time       `json:"updated_at"`
+	Components []Component     `json:"components"`
+}
+
+// OverallStatus returns the worst status of the components, operational if there are none
+func OverallStatus(components []Component) ComponentStatus {
+	overall := StatusOperational
+	for _, c := range components {
+		if severity[c.Status] > severity[overall] {
+			overall = c.Status
+		}
+	}
+	return overall
+}
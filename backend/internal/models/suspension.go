@@ -0,0 +1,52 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// maxSuspensionReasonLength is the longest suspension reason accepted
+const maxSuspensionReasonLength = 500
+
+// Suspension describes why and since when an account is suspended. Suspended
+// users can sign in and read their data, but can't change anything and their
+// posts don't publish.
+type Suspension struct {
+	SuspendedAt time.Time `json:"suspended_at"`
+	Reason      string    `json:"reason"`
+}
+
+// SuspendUserRequest represents the request to suspend an account
+type SuspendUserRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Validate trims the reason and checks it is given
+func (r *SuspendUserRequest) Validate() error {
+	r.Reason = strings.TrimSpace(r.Reason)
+	if r.Reason == "" {
+		return errors.New("reason is required")
+	}
+	if len(r.Reason) > maxSuspensionReasonLength {
+		return errors.New("reason must not exceed 500 characters")
+	}
+	return nil
+}
+
+// Suspended reports whether the account is suspended
+func (u *User) Suspended() bool {
+	return u.SuspendedAt != nil
+}
+
+// Suspension returns the account's suspension, or nil if it isn't suspended
+func (u *User) Suspension() *Suspension {
+	if u.SuspendedAt == nil {
+		return nil
+	}
+	s := &Suspension{SuspendedAt: *u.SuspendedAt}
+	if u.SuspensionReason != nil {
+		s.Reason = *u.SuspensionReason
+	}
+	return s
+}
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Targeting destinations
+const (
+	DestinationProfile = "profile"
+	DestinationPage    = "page"
+)
+
+// MaxAudienceSegments is the maximum number of audience segments on a post
+const MaxAudienceSegments = 10
+
+// PostTargeting holds optional audience targeting for channels that support it
+type PostTargeting struct {
+	Visibility  string   `json:"visibility,omitempty"`  // Who can see the post (channel specific)
+	Destination string   `json:"destination,omitempty"` // "profile" (default) or "page"
+	PageID      string   `json:"page_id,omitempty"`     // Required when publishing to a page
+	Audience    []string `json:"audience,omitempty"`    // Audience segments, e.g. country codes (pages only)
+
+	// Reddit: the subreddit to submit to and an optional post flair
+	Subreddit string `json:"subreddit,omitempty"`  // Without the r/ prefix
+	FlairID   string `json:"flair_id,omitempty"`   // A flair template of the subreddit
+	FlairText string `json:"flair_text,omitempty"` // Text for an editable flair template
+
+	// Discord: post as a rich embed rather than a plain message
+	Embed *DiscordEmbed `json:"embed,omitempty"`
+
+	// YouTube: whether a Short is declared as made for kids. Its privacy is
+	// the visibility.
+	MadeForKids bool `json:"made_for_kids,omitempty"`
+
+	// Google Business Profile: the location to post on, and an offer or a
+	// call to action button for an update
+	Location     string         `json:"location,omitempty"` // accounts/{id}/locations/{id}
+	Offer        *BusinessOffer `json:"offer,omitempty"`
+	CallToAction *CallToAction  `json:"call_to_action,omitempty"`
+}
+
+// channelVisibilities lists the allowed visibility values per channel and destination
+var channelVisibilities = map[Channel]map[string][]string{
+	ChannelLinkedIn: {
+		DestinationProfile: {"public", "connections"},
+		DestinationPage:    {"public"},
+	},
+	ChannelFacebook: {
+		DestinationProfile: {"public", "friends", "only_me"},
+		DestinationPage:    {"public"},
+	},
+	ChannelTikTok: {
+		DestinationProfile: {"public", "friends", "followers", "private"}, // Keys of TikTokPrivacyLevels
+	},
+}
+
+// SupportsTargeting reports whether a channel accepts targeting metadata
+func SupportsTargeting(c Channel) bool {
+	if c == ChannelReddit || c == ChannelDiscord || c == ChannelGoogleBusiness || c == ChannelYouTube {
+		return true
+	}
+	_, ok := channelVisibilities[c]
+	return ok
+}
+
+// ValidateTargeting checks targeting metadata against the channel's rules
+// and fills in defaults. A nil targeting is always valid.
+func ValidateTargeting(c Channel, t *PostTargeting) error {
+	if t == nil {
+		return nil
+	}
+	if c == ChannelReddit {
+		return validateRedditTargeting(t)
+	}
+	if c == ChannelDiscord {
+		return validateDiscordTargeting(t)
+	}
+	if c == ChannelGoogleBusiness {
+		return validateGoogleBusinessTargeting(t)
+	}
+	if c == ChannelYouTube {
+		return validateYouTubeTargeting(t)
+	}
+	if t.MadeForKids {
+		return fmt.Errorf("targeting made_for_kids is only supported for %s", ChannelYouTube)
+	}
+	if t.Embed != nil {
+		return fmt.Errorf("targeting embed is only supported for %s", ChannelDiscord)
+	}
+	if t.Location != "" || t.Offer != nil || t.CallToAction != nil {
+		return fmt.Errorf("targeting location, offer and call_to_action are only supported for %s", ChannelGoogleBusiness)
+	}
+	if t.Subreddit != "" || t.FlairID != "" || t.FlairText != "" {
+		return fmt.Errorf("targeting subreddit and flair are only supported for %s", ChannelReddit)
+	}
+
+	destinations, ok := channelVisibilities[c]
+	if !ok {
+		return fmt.Errorf("targeting is not supported for %s", c)
+	}
+
+	if t.Destination == "" {
+		t.Destination = DestinationProfile
+	}
+	visibilities, ok := destinations[t.Destination]
+	if !ok {
+		return errors.New("targeting destination must be one of: profile, page")
+	}
+
+	t.PageID = strings.TrimSpace(t.PageID)
+	if t.Destination == DestinationPage && t.PageID == "" {
+		return errors.New("targeting page_id is required when destination is page")
+	}
+	if t.Destination == DestinationProfile && t.PageID != "" {
+		return errors.New("targeting page_id is only allowed when destination is page")
+	}
+
+	if t.Visibility == "" {
+		t.Visibility = visibilities[0]
+	}
+	if !containsString(visibilities, t.Visibility) {
+		return fmt.Errorf("targeting visibility for %s %s must be one of: %s", c, t.Destination, strings.Join(visibilities, ", "))
+	}
+
+	if len(t.Audience) > 0 && t.Destination != DestinationPage {
+		return errors.New("targeting audience is only supported for page posts")
+	}
+	if len(t.Audience) > MaxAudienceSegments {
+		return fmt.Errorf("targeting audience must not exceed %d segments", MaxAudienceSegments)
+	}
+	for i, segment := range t.Audience {
+		segment = strings.TrimSpace(segment)
+		if segment == "" || len(segment) > 64 {
+			return errors.New("targeting audience segments must be between 1 and 64 characters")
+		}
+		t.Audience[i] = segment
+	}
+
+	return nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+)
+
+// MaxTelegramCaptionLength is the longest caption Telegram sends with media.
+// Longer content follows the media as its own message.
+const MaxTelegramCaptionLength = 1024
+
+var (
+	// telegramBotToken matches the tokens BotFather issues: the bot's ID, a
+	// colon and its secret
+	telegramBotToken = regexp.MustCompile(`^[0-9]+:[A-Za-z0-9_-]{30,}$`)
+	// telegramChat matches a public channel's @username or a numeric chat ID,
+	// negative for channels and groups
+	telegramChat = regexp.MustCompile(`^(@[A-Za-z][A-Za-z0-9_]{4,31}|-?[0-9]{1,20})$`)
+)
+
+// validateTelegramConnection checks a bot token and the chat it posts to.
+// The bot must be an administrator of the channel to post in it.
+func validateTelegramConnection(r *ConnectChannelRequest) error {
+	if !telegramBotToken.MatchString(r.AccessToken) {
+		return errors.New("access_token must be a Telegram bot token from @BotFather")
+	}
+	if r.Target == nil {
+		return errors.New("target is required for telegram: the channel's @username or chat ID")
+	}
+	if !telegramChat.MatchString(*r.Target) {
+		return errors.New("target must be a Telegram channel @username or numeric chat ID")
+	}
+	if r.AccountName == nil {
+		r.AccountName = r.Target
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTenantSlug is the tenant requests fall back to when none is resolved
+const DefaultTenantSlug = "default"
+
+// DefaultTenantID is the ID of the default tenant, created by migration
+var DefaultTenantID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// Tenant is an isolated white-label deployment with its own users and posts
+type Tenant struct {
+	ID        uuid.UUID `json:"id"`
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	Hostnames []string  `json:"hostnames"`
+	CreatedAt time.Time `json:"created_at"`
+}
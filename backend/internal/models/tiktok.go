@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// TikTok video limits for the Content Posting API
+const (
+	MinTikTokVideoDuration = 3 * time.Second
+	MaxTikTokVideoDuration = 10 * time.Minute
+)
+
+// TikTokPrivacyLevels maps a TikTok post's visibility to the API's
+// privacy_level. Apps that haven't passed TikTok's audit can only post
+// privately.
+var TikTokPrivacyLevels = map[string]string{
+	"public":    "PUBLIC_TO_EVERYONE",
+	"friends":   "MUTUAL_FOLLOW_FRIENDS",
+	"followers": "FOLLOWER_OF_CREATOR",
+	"private":   "SELF_ONLY",
+}
+
+// validateTikTokFields checks that a TikTok post has the video it publishes
+func validateTikTokFields(media []PostMedia) *Violation {
+	if len(media) == 0 || !media[0].IsVideo() {
+		return &Violation{Field: "media", Message: "TikTok posts need a video"}
+	}
+	return nil
+}
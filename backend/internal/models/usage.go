@@ -0,0 +1,51 @@
+package models
+
+// UsageQuota caps a plan's daily usage; zero means unlimited
+type UsageQuota struct {
+	APIRequestsPerDay int64 `json:"api_requests_per_day"`
+	PublishesPerDay   int64 `json:"publishes_per_day"`
+}
+
+// PlanQuotas are the daily usage quotas of each plan
+var PlanQuotas = map[Plan]UsageQuota{
+	PlanFree: {APIRequestsPerDay: 5000, PublishesPerDay: 50},
+	PlanPro:  {APIRequestsPerDay: 50000, PublishesPerDay: 1000},
+}
+
+// Quota returns the plan's daily usage quota, falling back to the free plan's
+func (p Plan) Quota() UsageQuota {
+	if q, ok := PlanQuotas[p]; ok {
+		return q
+	}
+	return PlanQuotas[PlanFree]
+}
+
+// AllowsRequests reports whether count API requests fit in the quota
+func (q UsageQuota) AllowsRequests(count int64) bool {
+	return q.APIRequestsPerDay <= 0 || count <= q.APIRequestsPerDay
+}
+
+// AllowsPublish reports whether another post may publish after count publishes today
+func (q UsageQuota) AllowsPublish(count int64) bool {
+	return q.PublishesPerDay <= 0 || count < q.PublishesPerDay
+}
+
+// UsageCounts is a user's metered usage over one UTC day
+type UsageCounts struct {
+	APIRequests int64 `json:"api_requests"`
+	Publishes   int64 `json:"publishes"`
+}
+
+// UsageDay is a rolled-up day of usage
+type UsageDay struct {
+	Day string `json:"day"` // YYYY-MM-DD (UTC)
+	UsageCounts
+}
+
+// UsageResponse represents a user's quota, live usage today and recent history
+type UsageResponse struct {
+	Plan    Plan        `json:"plan"`
+	Quota   UsageQuota  `json:"quota"`
+	Today   UsageCounts `json:"today"`
+	History []UsageDay  `json:"history"` // Most recent first, from daily rollups
+}
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/scheduler/backend/internal/sanitize"
+	"github.com/scheduler/backend/internal/textmetrics"
+)
+
+// ChannelContentLimits is the longest post content each channel accepts, in
+// characters as ContentLength counts them
+var ChannelContentLimits = map[Channel]int{
+	ChannelTwitter:        280,
+	ChannelLinkedIn:       3000,
+	ChannelFacebook:       5000,
+	ChannelReddit:         40000,
+	ChannelTelegram:       4096,
+	ChannelDiscord:        2000,
+	ChannelWebhook:        10000,
+	ChannelGoogleBusiness: 1500,
+	ChannelYouTube:        5000,
+	ChannelTikTok:         2200,
+}
+
+// Length counting methods, as reported in channel metadata
+const (
+	CountingGraphemes = "graphemes" // User-perceived characters
+	CountingWeighted  = "weighted"  // Twitter's weighted length: links 23, emoji and CJK 2
+)
+
+// LengthCounting returns how channel c counts content against its limit
+func LengthCounting(c Channel) string {
+	if c == ChannelTwitter {
+		return CountingWeighted
+	}
+	return CountingGraphemes
+}
+
+// ContentLength measures content as channel c counts it against its limit
+func ContentLength(c Channel, content string) int {
+	if LengthCounting(c) == CountingWeighted {
+		return textmetrics.TwitterLength(content)
+	}
+	return textmetrics.Graphemes(content)
+}
+
+// ValidateChannelContent checks content against the channel's length limit
+func ValidateChannelContent(c Channel, content string) error {
+	limit, ok := ChannelContentLimits[c]
+	if !ok {
+		return nil
+	}
+	if n := ContentLength(c, content); n > limit {
+		return fmt.Errorf("content must not exceed %d characters for %s (got %d)", limit, c, n)
+	}
+	return nil
+}
+
+// ValidateChannelFields checks for fields channel c requires on every post,
+// returning the first one missing: Reddit submissions need a title and a
+// subreddit, Google Business Profile posts a location and YouTube Shorts a
+// title
+func ValidateChannelFields(c Channel, title *string, t *PostTargeting, media []PostMedia) *Violation {
+	switch c {
+	case ChannelReddit:
+		if title == nil || *title == "" {
+			return &Violation{Field: "title", Message: fmt.Sprintf("Title is required for %s", c)}
+		}
+		if t == nil || t.Subreddit == "" {
+			return &Violation{Field: "targeting", Message: fmt.Sprintf("targeting subreddit is required for %s", c)}
+		}
+	case ChannelGoogleBusiness:
+		if t == nil || t.Location == "" {
+			return &Violation{Field: "targeting", Message: fmt.Sprintf("targeting location is required for %s", c)}
+		}
+	case ChannelYouTube:
+		return validateYouTubeFields(title, t, media)
+	case ChannelTikTok:
+		return validateTikTokFields(media)
+	}
+	return nil
+}
+
+// Violation is a single validation failure on a post request
+type Violation struct {
+	Field   string `json:"field"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidatePostResponse reports every violation found in a post request
+// without creating the post
+type ValidatePostResponse struct {
+	Valid      bool             `json:"valid"`
+	Violations []Violation      `json:"violations"`
+	Conflicts  []PostConflict   `json:"conflicts,omitempty"`
+	Length     *ContentMeasure  `json:"length,omitempty"` // Set when the channel is valid
+	Warnings   []ContentWarning `json:"warnings,omitempty"`
+}
+
+// ContentWarning reports a change made to a post's text before it was
+// saved, such as invisible characters removed
+type ContentWarning struct {
+	Field string `json:"field"`
+	sanitize.Warning
+}
+
+// SanitizeText cleans one text field of a post request, returning the
+// cleaned text and a warning for each kind of change
+func SanitizeText(field, s string) (string, []ContentWarning) {
+	s, changes := sanitize.Text(s)
+	var warnings []ContentWarning
+	for _, w := range changes {
+		warnings = append(warnings, ContentWarning{Field: field, Warning: w})
+	}
+	return s, warnings
+}
+
+// ContentMeasure is post content measured as its channel counts it, for
+// clients to show a character counter that agrees with the server
+type ContentMeasure struct {
+	Length   int    `json:"length"`
+	Limit    int    `json:"limit"`
+	Counting string `json:"counting"`
+}
+
+// MeasureContent measures content against channel c's limit
+func MeasureContent(c Channel, content string) *ContentMeasure {
+	return &ContentMeasure{
+		Length:   ContentLength(c, content),
+		Limit:    ChannelContentLimits[c],
+		Counting: LengthCounting(c),
+	}
+}
@@ -0,0 +1,17 @@
+package models
+
+// InboundWebhookRequest is the minimal payload accepted by a user's inbound
+// webhook. scheduled_at defaults to shortly after the request.
+type InboundWebhookRequest struct {
+	Content     string  `json:"content"`
+	Channel     string  `json:"channel"`
+	Title       *string `json:"title"`
+	ScheduledAt string  `json:"scheduled_at"`
+}
+
+// WebhookTokenResponse returns a newly generated inbound webhook token. The
+// token is only shown once.
+type WebhookTokenResponse struct {
+	Token string `json:"token"`
+	Path  string `json:"path"` // POST a JSON payload here to create a post
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MinWebhookSecretLength is the shortest signing secret a webhook channel accepts
+const MinWebhookSecretLength = 16
+
+// WebhookEventPublish is the event of a webhook channel delivery
+const WebhookEventPublish = "post.publish"
+
+// WebhookPublish is the body POSTed to a webhook channel's endpoint when a
+// post publishes
+type WebhookPublish struct {
+	Event       string         `json:"event"` // Always "post.publish"
+	PostID      uuid.UUID      `json:"post_id"`
+	Title       *string        `json:"title,omitempty"`
+	Content     string         `json:"content"`
+	Media       []PostMedia    `json:"media,omitempty"`
+	Targeting   *PostTargeting `json:"targeting,omitempty"`
+	ScheduledAt time.Time      `json:"scheduled_at"`
+	SentAt      time.Time      `json:"sent_at"`
+}
+
+// validateWebhookConnection checks a webhook channel: target is the HTTPS
+// endpoint posts are delivered to and access_token the secret their
+// signatures are keyed with
+func validateWebhookConnection(r *ConnectChannelRequest) error {
+	if len(r.AccessToken) < MinWebhookSecretLength {
+		return fmt.Errorf("access_token must be a signing secret of at least %d characters", MinWebhookSecretLength)
+	}
+	if r.Target == nil {
+		return errors.New("target is required for webhook: the HTTPS endpoint to deliver posts to")
+	}
+	u, err := ValidateWebhookEndpoint(*r.Target)
+	if err != nil {
+		return fmt.Errorf("target: %w", err)
+	}
+	if r.AccountName == nil {
+		host := u.Hostname()
+		r.AccountName = &host
+	}
+	return nil
+}
+
+// ValidateWebhookEndpoint checks that a webhook channel endpoint is an HTTPS
+// URL on a public host. Hostnames are checked again when they are resolved
+// at delivery.
+func ValidateWebhookEndpoint(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil {
+		return nil, errors.New("webhook endpoint must be an absolute https URL without credentials")
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".internal") {
+		return nil, errors.New("webhook endpoint must be a public host")
+	}
+	if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+		return nil, errors.New("webhook endpoint must be a public host")
+	}
+	return u, nil
+}
+
+// IsPublicIP reports whether ip is routable on the internet, as opposed to
+// loopback, private, link-local or unspecified
+func IsPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast())
+}
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxPublishingWindows is the most windows an organization may define
+const MaxPublishingWindows = 14
+
+// PublishingWindow is a daily time range, on the given weekdays, during which
+// an organization's posts may publish
+type PublishingWindow struct {
+	Days  []time.Weekday `json:"days"`  // 0 = Sunday … 6 = Saturday
+	Start string         `json:"start"` // "HH:MM", inclusive
+	End   string         `json:"end"`   // "HH:MM", exclusive; "24:00" for end of day
+}
+
+// PublishingSchedule is an organization's set of publishing windows in its
+// timezone. A schedule without windows allows publishing at any time.
+type PublishingSchedule struct {
+	Timezone string             `json:"timezone"` // IANA name, e.g. "Europe/Berlin"
+	Windows  []PublishingWindow `json:"windows"`
+}
+
+// Validate checks the timezone and windows, defaulting the timezone to UTC
+func (s *PublishingSchedule) Validate() error {
+	if s.Timezone == "" {
+		s.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q", s.Timezone)
+	}
+	if len(s.Windows) > MaxPublishingWindows {
+		return fmt.Errorf("at most %d publishing windows are allowed", MaxPublishingWindows)
+	}
+
+	for i, w := range s.Windows {
+		if len(w.Days) == 0 {
+			return fmt.Errorf("window %d must include at least one day", i+1)
+		}
+		for _, d := range w.Days {
+			if d < time.Sunday || d > time.Saturday {
+				return fmt.Errorf("window %d has an invalid day %d (0 = Sunday … 6 = Saturday)", i+1, d)
+			}
+		}
+		start, err := parseClock(w.Start)
+		if err != nil {
+			return fmt.Errorf("window %d start: %w", i+1, err)
+		}
+		end, err := parseClock(w.End)
+		if err != nil {
+			return fmt.Errorf("window %d end: %w", i+1, err)
+		}
+		if start >= end {
+			return fmt.Errorf("window %d must end after it starts", i+1)
+		}
+	}
+	return nil
+}
+
+// Allows reports whether t falls inside one of the schedule's windows
+func (s *PublishingSchedule) Allows(t time.Time) bool {
+	if s == nil || len(s.Windows) == 0 {
+		return true
+	}
+
+	local := t.In(s.location())
+	minute := local.Hour()*60 + local.Minute()
+	for _, w := range s.Windows {
+		start, end := w.bounds()
+		if w.includes(local.Weekday()) && minute >= start && minute < end {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the earliest time at or after t that the schedule allows
+func (s *PublishingSchedule) Next(t time.Time) time.Time {
+	if s.Allows(t) {
+		return t
+	}
+
+	local := t.In(s.location())
+	var next time.Time
+	for day := 0; day <= 7; day++ {
+		date := local.AddDate(0, 0, day)
+		for _, w := range s.Windows {
+			if !w.includes(date.Weekday()) {
+				continue
+			}
+			start, _ := w.bounds()
+			candidate := time.Date(date.Year(), date.Month(), date.Day(), start/60, start%60, 0, 0, local.Location())
+			if candidate.After(t) && (next.IsZero() || candidate.Before(next)) {
+				next = candidate
+			}
+		}
+		if !next.IsZero() {
+			return next
+		}
+	}
+	return t
+}
+
+// location returns the schedule's timezone, falling back to UTC
+func (s *PublishingSchedule) location() *time.Location {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// includes reports whether the window applies on weekday d
+func (w PublishingWindow) includes(d time.Weekday) bool {
+	for _, day := range w.Days {
+		if day == d {
+			return true
+		}
+	}
+	return false
+}
+
+// bounds returns the window's start and end as minutes since midnight.
+// Windows are validated before they are stored, so parse errors are ignored.
+func (w PublishingWindow) bounds() (int, int) {
+	start, _ := parseClock(w.Start)
+	end, _ := parseClock(w.End)
+	return start, end
+}
+
+// parseClock parses "HH:MM" into minutes since midnight, accepting "24:00"
+func parseClock(s string) (int, error) {
+	var h, m int
+	if n, err := fmt.Sscanf(s, "%d:%d", &h, &m); err != nil || n != 2 || len(s) != 5 {
+		return 0, fmt.Errorf("invalid time %q, use HH:MM", s)
+	}
+	if h == 24 && m == 0 {
+		return 24 * 60, nil
+	}
+	if h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, errors.New("time must be between 00:00 and 24:00")
+	}
+	return h*60 + m, nil
+}
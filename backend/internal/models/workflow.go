@@ -0,0 +1,31 @@
+package models
+
+import "github.com/google/uuid"
+
+// WorkflowState is a post's place on the editorial board, independent of
+// whether it has been published
+type WorkflowState string
+
+const (
+	WorkflowIdea     WorkflowState = "idea"
+	WorkflowDrafting WorkflowState = "drafting"
+	WorkflowReview   WorkflowState = "review"
+	WorkflowApproved WorkflowState = "approved"
+)
+
+// IsValidWorkflowState checks if a workflow state value is valid
+func IsValidWorkflowState(s string) bool {
+	switch WorkflowState(s) {
+	case WorkflowIdea, WorkflowDrafting, WorkflowReview, WorkflowApproved:
+		return true
+	}
+	return false
+}
+
+// UpdateWorkflowRequest represents the request to move a post on the
+// editorial board or change its assignee
+type UpdateWorkflowRequest struct {
+	WorkflowState *string    `json:"workflow_state"`
+	AssigneeID    *uuid.UUID `json:"assignee_id"`
+	Unassign      bool       `json:"unassign"` // Clears the assignee
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// YouTube limits
+const (
+	MaxYouTubeTitleLength   = 100
+	MaxYouTubeShortDuration = 3 * time.Minute
+)
+
+// YouTubePrivacyStatuses are the privacy settings of a Short. Community
+// posts are always public.
+var YouTubePrivacyStatuses = []string{"public", "unlisted", "private"}
+
+// IsYouTubeShort reports whether a YouTube post is a Short, which it is when
+// it has a video; otherwise it is a community post
+func IsYouTubeShort(media []PostMedia) bool {
+	for _, m := range media {
+		if m.IsVideo() {
+			return true
+		}
+	}
+	return false
+}
+
+// validateYouTubeTargeting checks a YouTube post's privacy, given as
+// visibility, and its made for kids declaration
+func validateYouTubeTargeting(t *PostTargeting) error {
+	if t.Destination != "" || t.PageID != "" || len(t.Audience) > 0 ||
+		t.Subreddit != "" || t.FlairID != "" || t.FlairText != "" || t.Embed != nil ||
+		t.Location != "" || t.Offer != nil || t.CallToAction != nil {
+		return fmt.Errorf("targeting for %s only supports visibility and made_for_kids", ChannelYouTube)
+	}
+	if t.Visibility == "" {
+		t.Visibility = YouTubePrivacyStatuses[0]
+	}
+	if !containsString(YouTubePrivacyStatuses, t.Visibility) {
+		return fmt.Errorf("targeting visibility for %s must be one of: %s", ChannelYouTube, strings.Join(YouTubePrivacyStatuses, ", "))
+	}
+	return nil
+}
+
+// validateYouTubeFields checks that a Short has a title YouTube accepts and
+// that community posts, which can't be private, are public
+func validateYouTubeFields(title *string, t *PostTargeting, media []PostMedia) *Violation {
+	if !IsYouTubeShort(media) {
+		if t != nil && t.Visibility != "" && t.Visibility != "public" {
+			return &Violation{Field: "targeting", Message: "YouTube community posts are always public; only Shorts can be unlisted or private"}
+		}
+		return nil
+	}
+	if title == nil || *title == "" {
+		return &Violation{Field: "title", Message: "Title is required for YouTube Shorts"}
+	}
+	if utf8.RuneCountInString(*title) > MaxYouTubeTitleLength {
+		return &Violation{Field: "title", Message: fmt.Sprintf("YouTube titles must not exceed %d characters", MaxYouTubeTitleLength)}
+	}
+	return nil
+}
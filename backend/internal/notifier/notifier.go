@@ -8,53 +8,130 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/metrics"
 )
 
 const (
-	// Redis channel for post updates
+	// Prefix of the Redis channels for post updates: one per user, and one
+	// for broadcasts. Versions before per-user channels published every
+	// update to this channel itself; see EnableSharedChannel.
 	postUpdateChannel = "post_updates"
+
+	// Redis channel for broadcasts to every user; user updates go to
+	// userChannel(userID)
+	broadcastChannel = postUpdateChannel + ":broadcast"
 )
 
+// userChannel is the Redis channel for one user's updates
+func userChannel(userID uuid.UUID) string {
+	return postUpdateChannel + ":" + userID.String()
+}
+
+// updateChannel is the Redis channel an update for userID is published to;
+// uuid.Nil for broadcasts
+func updateChannel(userID uuid.UUID) string {
+	if userID == uuid.Nil {
+		return broadcastChannel
+	}
+	return userChannel(userID)
+}
+
 // PostUpdate represents a notification about a post change
 type PostUpdate struct {
-	UserID uuid.UUID  `json:"user_id"`
-	Type   UpdateType `json:"type"`
+	UserID uuid.UUID       `json:"user_id"` // uuid.Nil for broadcasts to every user
+	Type   UpdateType      `json:"type"`
+	PostID *uuid.UUID      `json:"post_id,omitempty"` // Set for updates about a single post, such as comments
+	Data   json.RawMessage `json:"data,omitempty"`    // Event body of broadcasts and of user events that carry their own
 }
 
 // UpdateType represents the type of update
 type UpdateType string
 
 const (
-	UpdateTypeCreate  UpdateType = "create"
-	UpdateTypeUpdate  UpdateType = "update"
-	UpdateTypeDelete  UpdateType = "delete"
-	UpdateTypePublish UpdateType = "publish"
+	UpdateTypeCreate   UpdateType = "create"
+	UpdateTypeUpdate   UpdateType = "update"
+	UpdateTypeDelete   UpdateType = "delete"
+	UpdateTypePublish  UpdateType = "publish"
+	UpdateTypeApproval UpdateType = "approval" // A pending post was approved, rejected or needs review
+	UpdateTypeComment  UpdateType = "comment"  // A comment was added to or removed from a post
+	UpdateTypeWorkflow UpdateType = "workflow" // A post moved on the editorial board or was reassigned
+	UpdateTypeReminder UpdateType = "reminder" // A post the user asked to be reminded about publishes soon
+	UpdateTypeFailure  UpdateType = "failure"  // A post failed after its last retry
+	UpdateTypeDigest   UpdateType = "digest"   // A periodic summary is available
+	UpdateTypeAccount  UpdateType = "account"  // The account was suspended or unsuspended
+	UpdateTypeInbox    UpdateType = "inbox"    // Inbox items arrived or were marked read; carries models.InboxCounts
+
+	UpdateTypeAnnouncement        UpdateType = "announcement"         // Broadcast: an admin posted a system announcement
+	UpdateTypeAnnouncementRemoved UpdateType = "announcement_removed" // Broadcast: an announcement was taken down
 )
 
-// Notifier broadcasts post updates to SSE clients
+// Notifier broadcasts post updates to SSE clients. Each process only
+// subscribes to the Redis channels of users with a local subscriber, from the
+// first one's Subscribe to the last one's Unsubscribe, so the updates it reads
+// and decodes grow with its own connections rather than with activity across
+// every instance.
 type Notifier struct {
 	mu          sync.RWMutex
 	subscribers map[uuid.UUID][]chan PostUpdate
-	redis       *redis.Client
-	pubsub      *redis.PubSub
+	redis       publisher
+	pubsub      subscription
+
+	// channelMu serializes changes to the Redis subscriptions in channels,
+	// the users whose channel pubsub is subscribed to
+	channelMu sync.Mutex
+	channels  map[uuid.UUID]bool
+
+	// sharedChannel also publishes every update to postUpdateChannel
+	sharedChannel bool
+}
+
+// publisher publishes to Redis channels; *redis.Client implements it
+type publisher interface {
+	Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd
+}
+
+// subscription is a Redis pub/sub connection whose channels can change;
+// *redis.PubSub implements it
+type subscription interface {
+	Subscribe(ctx context.Context, channels ...string) error
+	Unsubscribe(ctx context.Context, channels ...string) error
+	Channel(opts ...redis.ChannelOption) <-chan *redis.Message
+	Close() error
 }
 
 // NewNotifier creates a new notifier
 func NewNotifier(redisClient *redis.Client) *Notifier {
+	if redisClient == nil {
+		return newNotifier(nil, nil)
+	}
+	// Start listening to Redis pub/sub for updates from other processes
+	return newNotifier(redisClient, redisClient.Subscribe(context.Background(), broadcastChannel))
+}
+
+// newNotifier creates a notifier publishing with pub and listening on sub,
+// which is subscribed to the broadcast channel; both are nil for a notifier
+// that only notifies local subscribers
+func newNotifier(pub publisher, sub subscription) *Notifier {
 	n := &Notifier{
 		subscribers: make(map[uuid.UUID][]chan PostUpdate),
-		redis:       redisClient,
+		redis:       pub,
+		pubsub:      sub,
+		channels:    make(map[uuid.UUID]bool),
 	}
-
-	// Start listening to Redis pub/sub for updates from worker
-	if redisClient != nil {
-		n.pubsub = redisClient.Subscribe(context.Background(), postUpdateChannel)
+	if sub != nil {
 		go n.listenRedis()
 	}
-
 	return n
 }
 
+// EnableSharedChannel also publishes every update to the shared channel that
+// versions before per-user channels listen on, so their instances keep
+// receiving updates while a rolling deployment replaces them. Call before
+// publishing.
+func (n *Notifier) EnableSharedChannel() {
+	n.sharedChannel = true
+}
+
 // listenRedis listens for updates from Redis pub/sub (from worker process)
 func (n *Notifier) listenRedis() {
 	log.Println("🔊 [NOTIFIER] Started listening to Redis pub/sub for cross-process notifications")
@@ -68,7 +145,7 @@ func (n *Notifier) listenRedis() {
 
 		log.Printf("📨 [NOTIFIER] Received Redis update for user %s (type: %s)", update.UserID, update.Type)
 		// Broadcast to local subscribers
-		subscriberCount := n.notifyLocal(update.UserID, update.Type)
+		subscriberCount := n.notifyLocal(update)
 		log.Printf("📬 [NOTIFIER] Forwarded to %d local subscribers", subscriberCount)
 	}
 	log.Println("🔇 [NOTIFIER] Stopped listening to Redis pub/sub")
@@ -77,17 +154,20 @@ func (n *Notifier) listenRedis() {
 // Subscribe creates a new channel for receiving updates for a specific user
 func (n *Notifier) Subscribe(userID uuid.UUID) chan PostUpdate {
 	n.mu.Lock()
-	defer n.mu.Unlock()
-
 	ch := make(chan PostUpdate, 10) // Buffered channel to prevent blocking
 	n.subscribers[userID] = append(n.subscribers[userID], ch)
+	first := len(n.subscribers[userID]) == 1
+	n.mu.Unlock()
+
+	if first {
+		n.syncChannel(userID)
+	}
 	return ch
 }
 
 // Unsubscribe removes a channel from receiving updates
 func (n *Notifier) Unsubscribe(userID uuid.UUID, ch chan PostUpdate) {
 	n.mu.Lock()
-	defer n.mu.Unlock()
 
 	subscribers := n.subscribers[userID]
 	for i, sub := range subscribers {
@@ -100,29 +180,109 @@ func (n *Notifier) Unsubscribe(userID uuid.UUID, ch chan PostUpdate) {
 	}
 
 	// Clean up empty subscriber lists
-	if len(n.subscribers[userID]) == 0 {
+	last := len(n.subscribers[userID]) == 0
+	if last {
 		delete(n.subscribers, userID)
 	}
+	n.mu.Unlock()
+
+	if last {
+		n.syncChannel(userID)
+	}
+}
+
+// syncChannel subscribes to the user's Redis channel if they have local
+// subscribers and unsubscribes if not. Changes are serialized and follow the
+// subscriber count at the time, so a Subscribe racing the last Unsubscribe
+// leaves the channel subscribed.
+func (n *Notifier) syncChannel(userID uuid.UUID) {
+	if n.pubsub == nil {
+		return
+	}
+
+	n.channelMu.Lock()
+	defer n.channelMu.Unlock()
+
+	want := n.SubscriberCount(userID) > 0
+	if want == n.channels[userID] {
+		return
+	}
+
+	var err error
+	if want {
+		err = n.pubsub.Subscribe(context.Background(), userChannel(userID))
+	} else {
+		err = n.pubsub.Unsubscribe(context.Background(), userChannel(userID))
+	}
+	if err != nil {
+		// The pubsub connection resubscribes to its channels when it
+		// reconnects; a failed unsubscribe only costs unwanted messages
+		log.Printf("⚠️ [NOTIFIER] Failed to update Redis subscription for user %s: %v", userID, err)
+	}
+	if want {
+		n.channels[userID] = true
+	} else {
+		delete(n.channels, userID)
+	}
+	metrics.NotifierChannels.Set(float64(len(n.channels)))
 }
 
 // Notify sends an update to all subscribers for a specific user
 // This also publishes to Redis so worker instances can notify
 func (n *Notifier) Notify(userID uuid.UUID, updateType UpdateType) {
-	update := PostUpdate{
+	n.publish(PostUpdate{
 		UserID: userID,
 		Type:   updateType,
-	}
+	})
+}
+
+// NotifyPost sends an update about a single post to all subscribers for a specific user
+func (n *Notifier) NotifyPost(userID uuid.UUID, updateType UpdateType, postID uuid.UUID) {
+	n.publish(PostUpdate{
+		UserID: userID,
+		Type:   updateType,
+		PostID: &postID,
+	})
+}
+
+// NotifyData sends an event with the given body to all subscribers for a
+// specific user; it doesn't refresh their post lists
+func (n *Notifier) NotifyData(userID uuid.UUID, updateType UpdateType, data json.RawMessage) {
+	n.publish(PostUpdate{
+		UserID: userID,
+		Type:   updateType,
+		Data:   data,
+	})
+}
+
+// Broadcast sends an event with the given body to every connected user
+func (n *Notifier) Broadcast(updateType UpdateType, data json.RawMessage) {
+	n.publish(PostUpdate{
+		UserID: uuid.Nil,
+		Type:   updateType,
+		Data:   data,
+	})
+}
+
+// publish delivers an update to local subscribers and to other processes via Redis
+func (n *Notifier) publish(update PostUpdate) {
+	userID, updateType := update.UserID, update.Type
 
 	// Notify local subscribers
-	subscriberCount := n.notifyLocal(userID, updateType)
+	subscriberCount := n.notifyLocal(update)
 	log.Printf("📤 [NOTIFIER] Notified %d local subscribers for user %s (type: %s)", subscriberCount, userID, updateType)
 
 	// Publish to Redis for cross-process communication
 	if n.redis != nil {
 		data, err := json.Marshal(update)
 		if err == nil {
-			result := n.redis.Publish(context.Background(), postUpdateChannel, data)
+			result := n.redis.Publish(context.Background(), updateChannel(userID), data)
 			receivers, _ := result.Result()
+			if n.sharedChannel {
+				if err := n.redis.Publish(context.Background(), postUpdateChannel, data).Err(); err != nil {
+					log.Printf("⚠️ [NOTIFIER] Failed to publish to the shared channel: %v", err)
+				}
+			}
 			log.Printf("📡 [NOTIFIER] Published to Redis, %d receivers (user: %s, type: %s)", receivers, userID, updateType)
 		} else {
 			log.Printf("❌ [NOTIFIER] Failed to marshal update: %v", err)
@@ -131,20 +291,22 @@ func (n *Notifier) Notify(userID uuid.UUID, updateType UpdateType) {
 }
 
 // notifyLocal sends updates to local subscribers only
-func (n *Notifier) notifyLocal(userID uuid.UUID, updateType UpdateType) int {
+func (n *Notifier) notifyLocal(update PostUpdate) int {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
 
+	userID := update.UserID
 	subscribers := n.subscribers[userID]
+	if userID == uuid.Nil {
+		subscribers = nil
+		for _, subs := range n.subscribers {
+			subscribers = append(subscribers, subs...)
+		}
+	}
 	if len(subscribers) == 0 {
 		return 0
 	}
 
-	update := PostUpdate{
-		UserID: userID,
-		Type:   updateType,
-	}
-
 	sent := 0
 	// Send to all subscribers (non-blocking)
 	for _, ch := range subscribers {
@@ -0,0 +1,255 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeBroker is an in-memory Redis pub/sub shared by the notifiers of
+// several processes
+type fakeBroker struct {
+	mu   sync.Mutex
+	subs []*fakeSubscription
+}
+
+func (b *fakeBroker) Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var receivers int64
+	for _, s := range b.subs {
+		if s.subscribed(channel) {
+			s.messages <- &redis.Message{Channel: channel, Payload: string(message.([]byte))}
+			receivers++
+		}
+	}
+	cmd := redis.NewIntCmd(ctx)
+	cmd.SetVal(receivers)
+	return cmd
+}
+
+// subscribe opens a pub/sub connection subscribed to channels
+func (b *fakeBroker) subscribe(channels ...string) *fakeSubscription {
+	s := &fakeSubscription{channels: make(map[string]bool), messages: make(chan *redis.Message, 100)}
+	s.Subscribe(context.Background(), channels...)
+	b.mu.Lock()
+	b.subs = append(b.subs, s)
+	b.mu.Unlock()
+	return s
+}
+
+// fakeSubscription is a connection to a fakeBroker
+type fakeSubscription struct {
+	mu       sync.Mutex
+	channels map[string]bool
+	messages chan *redis.Message
+
+	// unsubscribing, when set, is called at the start of each Unsubscribe
+	unsubscribing func()
+}
+
+func (s *fakeSubscription) Subscribe(ctx context.Context, channels ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range channels {
+		s.channels[c] = true
+	}
+	return nil
+}
+
+func (s *fakeSubscription) Unsubscribe(ctx context.Context, channels ...string) error {
+	if s.unsubscribing != nil {
+		s.unsubscribing()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range channels {
+		delete(s.channels, c)
+	}
+	return nil
+}
+
+func (s *fakeSubscription) Channel(opts ...redis.ChannelOption) <-chan *redis.Message {
+	return s.messages
+}
+
+func (s *fakeSubscription) Close() error {
+	close(s.messages)
+	return nil
+}
+
+func (s *fakeSubscription) subscribed(channel string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.channels[channel]
+}
+
+func TestNotifier_SubscribesUserChannelWhileSubscribed(t *testing.T) {
+	broker := &fakeBroker{}
+	sub := broker.subscribe(broadcastChannel)
+	n := newNotifier(broker, sub)
+	defer n.Close()
+
+	userID := uuid.New()
+	channel := userChannel(userID)
+	if sub.subscribed(channel) {
+		t.Fatal("subscribed to the user's channel before they connected")
+	}
+
+	first := n.Subscribe(userID)
+	if !sub.subscribed(channel) {
+		t.Fatal("not subscribed to the user's channel after their first subscriber")
+	}
+
+	second := n.Subscribe(userID)
+	n.Unsubscribe(userID, first)
+	if !sub.subscribed(channel) {
+		t.Fatal("unsubscribed from the user's channel while they have a subscriber")
+	}
+
+	n.Unsubscribe(userID, second)
+	if sub.subscribed(channel) {
+		t.Error("still subscribed to the user's channel after their last subscriber")
+	}
+	if !sub.subscribed(broadcastChannel) {
+		t.Error("unsubscribed from the broadcast channel")
+	}
+}
+
+func TestNotifier_SubscribeRacingLastUnsubscribe(t *testing.T) {
+	broker := &fakeBroker{}
+	sub := broker.subscribe(broadcastChannel)
+	n := newNotifier(broker, sub)
+	defer n.Close()
+
+	userID := uuid.New()
+	ch := n.Subscribe(userID)
+
+	// A new subscriber arrives while the last one's unsubscribe is in flight:
+	// it is registered, then waits for the change to finish
+	done := make(chan struct{})
+	sub.unsubscribing = func() {
+		sub.unsubscribing = nil
+		go func() {
+			n.Subscribe(userID)
+			close(done)
+		}()
+		deadline := time.Now().Add(time.Second)
+		for n.SubscriberCount(userID) == 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	n.Unsubscribe(userID, ch)
+	<-done
+
+	if n.SubscriberCount(userID) != 1 {
+		t.Fatalf("SubscriberCount() = %d, want 1", n.SubscriberCount(userID))
+	}
+	if !sub.subscribed(userChannel(userID)) {
+		t.Error("not subscribed to the user's channel though they have a subscriber")
+	}
+}
+
+func TestNotifier_ConcurrentSubscribers(t *testing.T) {
+	broker := &fakeBroker{}
+	sub := broker.subscribe(broadcastChannel)
+	n := newNotifier(broker, sub)
+	defer n.Close()
+
+	userID := uuid.New()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				n.Unsubscribe(userID, n.Subscribe(userID))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if sub.subscribed(userChannel(userID)) {
+		t.Error("still subscribed to the user's channel after every subscriber left")
+	}
+
+	n.Subscribe(userID)
+	if !sub.subscribed(userChannel(userID)) {
+		t.Error("not subscribed to the user's channel after a new subscriber")
+	}
+}
+
+// receive returns the next update on ch, or false if none arrives soon
+func receive(ch chan PostUpdate) (PostUpdate, bool) {
+	select {
+	case update := <-ch:
+		return update, true
+	case <-time.After(100 * time.Millisecond):
+		return PostUpdate{}, false
+	}
+}
+
+func TestNotifier_PublishesToUserChannels(t *testing.T) {
+	broker := &fakeBroker{}
+	alice, bob := uuid.New(), uuid.New()
+
+	// Alice and Bob are connected to different API instances; the worker
+	// publishes their updates
+	api1 := newNotifier(broker, broker.subscribe(broadcastChannel))
+	defer api1.Close()
+	api2 := newNotifier(broker, broker.subscribe(broadcastChannel))
+	defer api2.Close()
+	worker := newNotifier(broker, broker.subscribe(broadcastChannel))
+	defer worker.Close()
+	aliceCh, bobCh := api1.Subscribe(alice), api2.Subscribe(bob)
+
+	postID := uuid.New()
+	worker.NotifyPost(alice, UpdateTypePublish, postID)
+
+	update, ok := receive(aliceCh)
+	if !ok || update.UserID != alice || update.PostID == nil || *update.PostID != postID {
+		t.Errorf("Alice received %+v, %v; want her publish update", update, ok)
+	}
+	if update, ok := receive(bobCh); ok {
+		t.Errorf("Bob received Alice's update %+v", update)
+	}
+
+	worker.Broadcast(UpdateTypeAnnouncement, []byte(`{"id":"1"}`))
+	for name, ch := range map[string]chan PostUpdate{"Alice": aliceCh, "Bob": bobCh} {
+		if update, ok := receive(ch); !ok || update.Type != UpdateTypeAnnouncement {
+			t.Errorf("%s received %+v, %v; want the announcement", name, update, ok)
+		}
+	}
+}
+
+func TestNotifier_SharedChannel(t *testing.T) {
+	broker := &fakeBroker{}
+	// An instance running a version before per-user channels
+	legacy := broker.subscribe(postUpdateChannel)
+	worker := newNotifier(broker, broker.subscribe(broadcastChannel))
+	defer worker.Close()
+
+	worker.Notify(uuid.New(), UpdateTypeCreate)
+	select {
+	case msg := <-legacy.messages:
+		t.Errorf("shared channel received %q without EnableSharedChannel", msg.Payload)
+	default:
+	}
+
+	worker.EnableSharedChannel()
+	userID := uuid.New()
+	worker.Notify(userID, UpdateTypeCreate)
+	select {
+	case msg := <-legacy.messages:
+		if msg.Channel != postUpdateChannel {
+			t.Errorf("message on %q, want %q", msg.Channel, postUpdateChannel)
+		}
+	default:
+		t.Error("shared channel received nothing with EnableSharedChannel")
+	}
+}
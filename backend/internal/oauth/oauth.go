@@ -0,0 +1,186 @@
+// Package oauth connects channel accounts with the OAuth 2.0 authorization
+// code flow: the user is sent to the platform to approve access, and the
+// platform redirects back with a code that is exchanged for tokens.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// userAgent identifies the scheduler to platforms that require one, such as Reddit
+const userAgent = "web:post-scheduler:v1"
+
+// Provider is a platform's OAuth endpoints and the scheduler's client credentials
+type Provider struct {
+	AuthURL      string
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	AuthParams   url.Values // Extra authorize URL parameters, e.g. Reddit's duration=permanent
+
+	// ClientIDParam names the client ID in the authorize URL and token form,
+	// for platforms that don't call it client_id; empty for client_id
+	ClientIDParam string
+
+	// ScopeSeparator joins the scopes; empty for a space as in RFC 6749
+	ScopeSeparator string
+
+	// BasicAuth sends the client credentials to the token endpoint in an
+	// Authorization header rather than the form body
+	BasicAuth bool
+
+	// Identity fetches the connected account's display name with a new
+	// access token; nil leaves the name unset
+	Identity func(ctx context.Context, client *http.Client, accessToken string) (string, error)
+
+	Client *http.Client // Nil for http.DefaultClient
+}
+
+// Token is the result of exchanging an authorization code
+type Token struct {
+	AccessToken  string
+	RefreshToken string     // Empty if the platform issued none
+	ExpiresAt    *time.Time // Nil if the token doesn't expire
+}
+
+// ErrNotConfigured is returned for a provider without client credentials
+var ErrNotConfigured = errors.New("oauth client credentials are not configured")
+
+// Configured reports whether the provider has client credentials
+func (p *Provider) Configured() bool {
+	return p != nil && p.ClientID != "" && p.ClientSecret != ""
+}
+
+// AuthCodeURL returns the platform URL to send the user to, carrying state
+// back to the redirect URL
+func (p *Provider) AuthCodeURL(state string) string {
+	q := url.Values{}
+	q.Set(p.clientIDParam(), p.ClientID)
+	q.Set("response_type", "code")
+	q.Set("redirect_uri", p.RedirectURL)
+	q.Set("scope", strings.Join(p.Scopes, p.scopeSeparator()))
+	q.Set("state", state)
+	for k, v := range p.AuthParams {
+		q[k] = v
+	}
+	return p.AuthURL + "?" + q.Encode()
+}
+
+// tokenResponse is the token endpoint's JSON body (RFC 6749 section 5)
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	Error        string `json:"error"`
+}
+
+// Exchange trades an authorization code for tokens
+func (p *Provider) Exchange(ctx context.Context, code string, now time.Time) (*Token, error) {
+	if !p.Configured() {
+		return nil, ErrNotConfigured
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.RedirectURL)
+	if !p.BasicAuth {
+		form.Set(p.clientIDParam(), p.ClientID)
+		form.Set("client_secret", p.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	if p.BasicAuth {
+		req.SetBasicAuth(p.ClientID, p.ClientSecret)
+	}
+
+	resp, err := p.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body tokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("token response: %s: %w", resp.Status, err)
+	}
+	if resp.StatusCode != http.StatusOK || body.Error != "" || body.AccessToken == "" {
+		if body.Error != "" {
+			return nil, fmt.Errorf("token request rejected: %s", body.Error)
+		}
+		return nil, fmt.Errorf("token request rejected: %s", resp.Status)
+	}
+
+	token := &Token{AccessToken: body.AccessToken, RefreshToken: body.RefreshToken}
+	if body.ExpiresIn > 0 {
+		expiresAt := now.Add(time.Duration(body.ExpiresIn) * time.Second)
+		token.ExpiresAt = &expiresAt
+	}
+	return token, nil
+}
+
+// AccountName returns the display name of the account a token belongs to,
+// or "" if the provider can't look it up
+func (p *Provider) AccountName(ctx context.Context, accessToken string) (string, error) {
+	if p.Identity == nil {
+		return "", nil
+	}
+	return p.Identity(ctx, p.client(), accessToken)
+}
+
+func (p *Provider) clientIDParam() string {
+	if p.ClientIDParam != "" {
+		return p.ClientIDParam
+	}
+	return "client_id"
+}
+
+func (p *Provider) scopeSeparator() string {
+	if p.ScopeSeparator != "" {
+		return p.ScopeSeparator
+	}
+	return " "
+}
+
+func (p *Provider) client() *http.Client {
+	if p.Client != nil {
+		return p.Client
+	}
+	return http.DefaultClient
+}
+
+// getJSON fetches url with a bearer token and decodes the JSON response into v
+func getJSON(ctx context.Context, client *http.Client, url, accessToken string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
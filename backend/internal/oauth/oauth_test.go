@@ -0,0 +1,162 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	p := Reddit("client", "secret", "https://api.example.com/api/channels/reddit/callback")
+
+	u, err := url.Parse(p.AuthCodeURL("state123"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := u.Scheme + "://" + u.Host + u.Path; got != RedditAuthURL {
+		t.Errorf("authorize endpoint = %s, want %s", got, RedditAuthURL)
+	}
+
+	q := u.Query()
+	want := map[string]string{
+		"client_id":     "client",
+		"response_type": "code",
+		"redirect_uri":  "https://api.example.com/api/channels/reddit/callback",
+		"scope":         "identity submit flair",
+		"state":         "state123",
+		"duration":      "permanent",
+	}
+	for k, v := range want {
+		if q.Get(k) != v {
+			t.Errorf("%s = %q, want %q", k, q.Get(k), v)
+		}
+	}
+}
+
+func TestGoogleBusiness_AuthCodeURL(t *testing.T) {
+	p := GoogleBusiness("client", "secret", "https://api.example.com/api/channels/google_business/callback")
+
+	u, err := url.Parse(p.AuthCodeURL("state123"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("scope") != googleBusinessScope || q.Get("access_type") != "offline" || q.Get("prompt") != "consent" {
+		t.Errorf("query = %v, want the business.manage scope with offline access", q)
+	}
+}
+
+func TestYouTube_AuthCodeURL(t *testing.T) {
+	p := YouTube("client", "secret", "https://api.example.com/api/channels/youtube/callback")
+
+	u, err := url.Parse(p.AuthCodeURL("state123"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("scope") != youTubeUploadScope+" "+youTubeReadonlyScope || q.Get("access_type") != "offline" {
+		t.Errorf("query = %v, want the upload and readonly scopes with offline access", q)
+	}
+}
+
+func TestTikTok_AuthCodeURL(t *testing.T) {
+	p := TikTok("key", "secret", "https://api.example.com/api/channels/tiktok/callback")
+
+	u, err := url.Parse(p.AuthCodeURL("state123"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("client_key") != "key" || q.Get("client_id") != "" {
+		t.Errorf("query = %v, want the client key as client_key", q)
+	}
+	if q.Get("scope") != "user.info.basic,video.publish" {
+		t.Errorf("scope = %q, want comma separated scopes", q.Get("scope"))
+	}
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "client" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("client_secret") != "" {
+			t.Errorf("unexpected form %v", r.PostForm)
+		}
+		if r.PostForm.Get("code") != "good" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "access",
+			"refresh_token": "refresh",
+			"expires_in":    3600,
+		})
+	}))
+	defer srv.Close()
+
+	p := Reddit("client", "secret", "https://api.example.com/callback")
+	p.TokenURL = srv.URL
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	token, err := p.Exchange(context.Background(), "good", now)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if token.AccessToken != "access" || token.RefreshToken != "refresh" {
+		t.Errorf("token = %+v", token)
+	}
+	if token.ExpiresAt == nil || !token.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("ExpiresAt = %v, want %v", token.ExpiresAt, now.Add(time.Hour))
+	}
+
+	if _, err := p.Exchange(context.Background(), "bad", now); err == nil {
+		t.Error("Exchange accepted a rejected code")
+	}
+
+	p.ClientSecret = ""
+	if _, err := p.Exchange(context.Background(), "good", now); err != ErrNotConfigured {
+		t.Errorf("Exchange without credentials = %v, want ErrNotConfigured", err)
+	}
+}
+
+func TestState(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	s := State{UserID: uuid.New(), Channel: "reddit", Expires: now.Add(StateTTL)}
+
+	value, err := SignState("secret", s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := VerifyState("secret", value, now)
+	if err != nil {
+		t.Fatalf("VerifyState: %v", err)
+	}
+	if got.UserID != s.UserID || got.Channel != s.Channel || !got.Expires.Equal(s.Expires) {
+		t.Errorf("VerifyState = %+v, want %+v", got, s)
+	}
+
+	if _, err := VerifyState("other", value, now); err != ErrInvalidState {
+		t.Errorf("wrong secret: err = %v, want ErrInvalidState", err)
+	}
+	if _, err := VerifyState("secret", value, now.Add(StateTTL)); err != ErrInvalidState {
+		t.Errorf("expired: err = %v, want ErrInvalidState", err)
+	}
+	forged := uuid.New().String() + value[len(s.UserID.String()):]
+	if _, err := VerifyState("secret", forged, now); err != ErrInvalidState {
+		t.Errorf("forged user: err = %v, want ErrInvalidState", err)
+	}
+}
@@ -0,0 +1,41 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Reddit's OAuth endpoints
+const (
+	RedditAuthURL  = "https://www.reddit.com/api/v1/authorize"
+	RedditTokenURL = "https://www.reddit.com/api/v1/access_token"
+	redditMeURL    = "https://oauth.reddit.com/api/v1/me"
+)
+
+// Reddit returns the provider for a Reddit web app. Access tokens last an
+// hour; duration=permanent has Reddit issue a refresh token as well.
+func Reddit(clientID, clientSecret, redirectURL string) *Provider {
+	return &Provider{
+		AuthURL:      RedditAuthURL,
+		TokenURL:     RedditTokenURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"identity", "submit", "flair"},
+		AuthParams:   url.Values{"duration": {"permanent"}},
+		BasicAuth:    true,
+		Identity:     redditIdentity,
+	}
+}
+
+// redditIdentity returns the u/ name of the token's account
+func redditIdentity(ctx context.Context, client *http.Client, accessToken string) (string, error) {
+	var me struct {
+		Name string `json:"name"`
+	}
+	if err := getJSON(ctx, client, redditMeURL, accessToken, &me); err != nil {
+		return "", err
+	}
+	return "u/" + me.Name, nil
+}
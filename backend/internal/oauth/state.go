@@ -0,0 +1,84 @@
+package oauth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StateTTL is how long a user has to approve access on the platform
+const StateTTL = 10 * time.Minute
+
+// ErrInvalidState is returned for a state that is malformed, forged or expired
+var ErrInvalidState = errors.New("invalid or expired oauth state")
+
+// State is the signed value carried through the platform's authorize page. It
+// names the user who started the flow, so the callback, which the platform
+// redirects the browser to without our auth cookies, knows whose account to
+// connect.
+type State struct {
+	UserID  uuid.UUID
+	Channel string
+	Expires time.Time
+}
+
+// SignState encodes s as userID.channel.expiry.nonce and appends an HMAC of
+// it under secret
+func SignState(secret string, s State) (string, error) {
+	nonce := make([]byte, 12)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	payload := strings.Join([]string{
+		s.UserID.String(),
+		s.Channel,
+		strconv.FormatInt(s.Expires.Unix(), 10),
+		base64.RawURLEncoding.EncodeToString(nonce),
+	}, ".")
+	return payload + "." + stateMAC(secret, payload), nil
+}
+
+// VerifyState checks the signature and expiry of a state from SignState
+func VerifyState(secret, value string, now time.Time) (State, error) {
+	i := strings.LastIndexByte(value, '.')
+	if i < 0 {
+		return State{}, ErrInvalidState
+	}
+	payload, mac := value[:i], value[i+1:]
+	if !hmac.Equal([]byte(mac), []byte(stateMAC(secret, payload))) {
+		return State{}, ErrInvalidState
+	}
+
+	parts := strings.Split(payload, ".")
+	if len(parts) != 4 {
+		return State{}, ErrInvalidState
+	}
+	userID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return State{}, ErrInvalidState
+	}
+	expires, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return State{}, ErrInvalidState
+	}
+	s := State{UserID: userID, Channel: parts[1], Expires: time.Unix(expires, 0)}
+	if !now.Before(s.Expires) {
+		return State{}, ErrInvalidState
+	}
+	return s, nil
+}
+
+// stateMAC signs payload under a key derived from secret, so a state can't
+// be mistaken for any other value signed with the same secret
+func stateMAC(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte("oauth-state:"+secret))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
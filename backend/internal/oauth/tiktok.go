@@ -0,0 +1,45 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+)
+
+// TikTok's OAuth endpoints
+const (
+	TikTokAuthURL  = "https://www.tiktok.com/v2/auth/authorize/"
+	TikTokTokenURL = "https://open.tiktokapis.com/v2/oauth/token/"
+	tikTokUserInfo = "https://open.tiktokapis.com/v2/user/info/?fields=display_name"
+)
+
+// TikTok returns the provider for a TikTok app with the Content Posting API
+// added. TikTok calls the client ID a client key and separates scopes with
+// commas. Access tokens last a day and come with a refresh token.
+func TikTok(clientKey, clientSecret, redirectURL string) *Provider {
+	return &Provider{
+		AuthURL:        TikTokAuthURL,
+		TokenURL:       TikTokTokenURL,
+		ClientID:       clientKey,
+		ClientSecret:   clientSecret,
+		RedirectURL:    redirectURL,
+		Scopes:         []string{"user.info.basic", "video.publish"},
+		ClientIDParam:  "client_key",
+		ScopeSeparator: ",",
+		Identity:       tikTokIdentity,
+	}
+}
+
+// tikTokIdentity returns the display name of the token's account
+func tikTokIdentity(ctx context.Context, client *http.Client, accessToken string) (string, error) {
+	var body struct {
+		Data struct {
+			User struct {
+				DisplayName string `json:"display_name"`
+			} `json:"user"`
+		} `json:"data"`
+	}
+	if err := getJSON(ctx, client, tikTokUserInfo, accessToken, &body); err != nil {
+		return "", err
+	}
+	return body.Data.User.DisplayName, nil
+}
@@ -0,0 +1,132 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// DiscordPublisher posts to a Discord channel through the connection's
+// webhook, or as its bot
+type DiscordPublisher struct {
+	connections Connections
+}
+
+// discordCall is a request to the Discord API. Endpoint hides the webhook
+// token, since a webhook URL is a credential.
+type discordCall struct {
+	Endpoint string         `json:"endpoint"`
+	Message  discordMessage `json:"message"`
+}
+
+// discordMessage mirrors the payload_json of POST /webhooks/{id}/{token}
+// and POST /channels/{id}/messages, with the media uploaded as files
+type discordMessage struct {
+	Content         string                 `json:"content,omitempty"`
+	Embeds          []discordEmbed         `json:"embeds,omitempty"`
+	Attachments     []discordAttachment    `json:"attachments,omitempty"`
+	AllowedMentions discordAllowedMentions `json:"allowed_mentions"`
+}
+
+type discordEmbed struct {
+	Title       string               `json:"title,omitempty"`
+	Description string               `json:"description,omitempty"`
+	URL         string               `json:"url,omitempty"`
+	Color       int                  `json:"color,omitempty"`
+	Footer      *discordEmbedFooter  `json:"footer,omitempty"`
+	Image       *discordEmbedPicture `json:"image,omitempty"`
+}
+
+type discordEmbedFooter struct {
+	Text string `json:"text"`
+}
+
+type discordEmbedPicture struct {
+	URL string `json:"url"`
+}
+
+type discordAttachment struct {
+	ID          int    `json:"id"`
+	Filename    string `json:"filename"`
+	Description string `json:"description,omitempty"` // Alt text
+}
+
+// discordAllowedMentions controls who a message pings. Scheduled posts only
+// ping the users they mention, never @everyone or a role.
+type discordAllowedMentions struct {
+	Parse []string `json:"parse"`
+}
+
+// Publish sends the post as a message, or as an embed when its targeting
+// asks for one, with the media attached
+func (p *DiscordPublisher) Publish(ctx context.Context, post *models.Post) error {
+	conn, err := connection(ctx, p.connections, post)
+	if err != nil {
+		return err
+	}
+
+	var endpoint string
+	if id, ok := models.DiscordWebhookID(conn.AccessToken); ok {
+		endpoint = fmt.Sprintf("POST /webhooks/%s/:token?wait=true", id)
+	} else if conn.Target != nil && *conn.Target != "" {
+		endpoint = fmt.Sprintf("POST /channels/%s/messages", *conn.Target)
+	} else {
+		return errors.New("discord connection has neither a webhook URL nor a target channel")
+	}
+
+	logPayload(models.ChannelDiscord, post, discordCall{Endpoint: endpoint, Message: discordMessageFor(post)})
+	return nil
+}
+
+// discordMessageFor builds the message for a post. With an embed, the first
+// image is shown inside it.
+func discordMessageFor(post *models.Post) discordMessage {
+	msg := discordMessage{AllowedMentions: discordAllowedMentions{Parse: []string{"users"}}}
+	for i, m := range post.Media {
+		a := discordAttachment{ID: i, Filename: discordFilename(i, m)}
+		if m.AltText != nil {
+			a.Description = *m.AltText
+		}
+		msg.Attachments = append(msg.Attachments, a)
+	}
+
+	var options *models.DiscordEmbed
+	if post.Targeting != nil {
+		options = post.Targeting.Embed
+	}
+	if options == nil {
+		msg.Content = post.Content
+		return msg
+	}
+
+	embed := discordEmbed{
+		Title:       options.Title,
+		Description: post.Content,
+		URL:         options.URL,
+		Color:       options.ColorValue(),
+	}
+	if embed.Title == "" && post.Title != nil {
+		embed.Title = *post.Title
+	}
+	if options.Footer != "" {
+		embed.Footer = &discordEmbedFooter{Text: options.Footer}
+	}
+	for i, m := range post.Media {
+		if strings.HasPrefix(m.ContentType, "image/") {
+			embed.Image = &discordEmbedPicture{URL: "attachment://" + msg.Attachments[i].Filename}
+			break
+		}
+	}
+	msg.Embeds = []discordEmbed{embed}
+	return msg
+}
+
+// discordFilename names an upload after its media URL, prefixed with its
+// position so that names are unique within the message
+func discordFilename(i int, m models.PostMedia) string {
+	return fmt.Sprintf("%d-%s", i+1, path.Base(m.URL))
+}
@@ -0,0 +1,47 @@
+package publisher
+
+import (
+	"testing"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestDiscordMessageFor(t *testing.T) {
+	alt := "Release notes screenshot"
+	title := "v2.1 is out"
+	media := []models.PostMedia{
+		{URL: "https://cdn.example.com/clip.mp4", ContentType: "video/mp4"},
+		{URL: "https://cdn.example.com/notes.png", ContentType: "image/png", AltText: &alt},
+	}
+
+	plain := discordMessageFor(&models.Post{Content: "Hello", Media: media})
+	if plain.Content != "Hello" || len(plain.Embeds) != 0 {
+		t.Errorf("plain message = %+v", plain)
+	}
+	if len(plain.Attachments) != 2 || plain.Attachments[1].Filename != "2-notes.png" || plain.Attachments[1].Description != alt {
+		t.Errorf("attachments = %+v", plain.Attachments)
+	}
+	for _, p := range plain.AllowedMentions.Parse {
+		if p == "everyone" || p == "roles" {
+			t.Errorf("allowed mentions parse %q", p)
+		}
+	}
+
+	post := &models.Post{
+		Content:   "Hello",
+		Title:     &title,
+		Media:     media,
+		Targeting: &models.PostTargeting{Embed: &models.DiscordEmbed{Color: "#5865F2", Footer: "Changelog"}},
+	}
+	msg := discordMessageFor(post)
+	if msg.Content != "" || len(msg.Embeds) != 1 {
+		t.Fatalf("embed message = %+v", msg)
+	}
+	embed := msg.Embeds[0]
+	if embed.Title != title || embed.Description != "Hello" || embed.Color != 0x5865F2 || embed.Footer == nil || embed.Footer.Text != "Changelog" {
+		t.Errorf("embed = %+v", embed)
+	}
+	if embed.Image == nil || embed.Image.URL != "attachment://2-notes.png" {
+		t.Errorf("embed image = %+v, want the first image", embed.Image)
+	}
+}
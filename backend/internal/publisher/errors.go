@@ -0,0 +1,62 @@
+package publisher
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// RateLimitError is returned when a platform rejects a publish because the
+// account is rate limited. RetryAfter is how long the platform asked to wait,
+// from its Retry-After header; zero when it didn't say.
+type RateLimitError struct {
+	Channel    models.Channel
+	RetryAfter time.Duration
+	Message    string
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s rate limited: %s (retry after %v)", e.Channel, e.Message, e.RetryAfter)
+	}
+	return fmt.Sprintf("%s rate limited: %s", e.Channel, e.Message)
+}
+
+// AsRateLimit returns the rate limit error wrapped in err, if any
+func AsRateLimit(err error) (*RateLimitError, bool) {
+	var rl *RateLimitError
+	if errors.As(err, &rl) {
+		return rl, true
+	}
+	return nil, false
+}
+
+// ParseRetryAfter parses a Retry-After header, given either as a number of
+// seconds or as an HTTP date, into the delay from now. Dates in the past give
+// a zero delay.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	at, err := time.Parse(time.RFC1123, value)
+	if err != nil {
+		return 0, false
+	}
+	if d := at.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
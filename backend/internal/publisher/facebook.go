@@ -0,0 +1,70 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// FacebookPublisher publishes posts to Facebook profiles and pages
+type FacebookPublisher struct{}
+
+// facebookPayload mirrors the body of POST /{node-id}/feed
+type facebookPayload struct {
+	Node      string             `json:"node"`
+	Message   string             `json:"message"`
+	Privacy   *facebookPrivacy   `json:"privacy,omitempty"`
+	Targeting *facebookTargeting `json:"targeting,omitempty"`
+	Photos    []facebookPhoto    `json:"attached_media,omitempty"`
+}
+
+type facebookPhoto struct {
+	URL     string `json:"url"`
+	AltText string `json:"alt_text_custom,omitempty"`
+}
+
+type facebookPrivacy struct {
+	Value string `json:"value"`
+}
+
+type facebookTargeting struct {
+	Countries []string `json:"countries"`
+}
+
+// facebookPrivacyValues maps our visibility values to Facebook privacy settings
+var facebookPrivacyValues = map[string]string{
+	"public":  "EVERYONE",
+	"friends": "ALL_FRIENDS",
+	"only_me": "SELF",
+}
+
+// Publish publishes a Facebook post, honouring targeting metadata
+func (p *FacebookPublisher) Publish(ctx context.Context, post *models.Post) error {
+	payload := facebookPayload{
+		Node:    "me",
+		Message: post.Content,
+	}
+
+	if t := post.Targeting; t != nil {
+		if t.Destination == models.DestinationPage {
+			// Page posts are always public; audience narrows who sees them in feed
+			payload.Node = t.PageID
+			if len(t.Audience) > 0 {
+				payload.Targeting = &facebookTargeting{Countries: t.Audience}
+			}
+		} else if v, ok := facebookPrivacyValues[t.Visibility]; ok {
+			payload.Privacy = &facebookPrivacy{Value: v}
+		}
+	}
+
+	for _, m := range post.Media {
+		photo := facebookPhoto{URL: m.URL}
+		if m.AltText != nil {
+			photo.AltText = *m.AltText
+		}
+		payload.Photos = append(payload.Photos, photo)
+	}
+
+	logPayload(models.ChannelFacebook, post, payload)
+	return nil
+}
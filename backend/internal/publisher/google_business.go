@@ -0,0 +1,100 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// GoogleBusinessPublisher posts updates and offers to Google Business Profile
+// locations
+type GoogleBusinessPublisher struct{}
+
+// businessRequest is POST https://mybusiness.googleapis.com/v4/{parent}/localPosts
+type businessRequest struct {
+	Parent    string            `json:"parent"`
+	LocalPost businessLocalPost `json:"local_post"`
+}
+
+// businessLocalPost mirrors the LocalPost resource
+type businessLocalPost struct {
+	Summary      string                `json:"summary"`
+	TopicType    string                `json:"topicType"` // "STANDARD" for updates, or "OFFER"
+	CallToAction *businessCallToAction `json:"callToAction,omitempty"`
+	Event        *businessEvent        `json:"event,omitempty"` // An offer's title and dates
+	Offer        *businessOffer        `json:"offer,omitempty"`
+	Media        []businessMedia       `json:"media,omitempty"`
+}
+
+type businessCallToAction struct {
+	ActionType string `json:"actionType"`
+	URL        string `json:"url,omitempty"`
+}
+
+type businessEvent struct {
+	Title    string           `json:"title"`
+	Schedule businessSchedule `json:"schedule"`
+}
+
+type businessSchedule struct {
+	StartDate businessDate `json:"startDate"`
+	EndDate   businessDate `json:"endDate"`
+}
+
+type businessDate struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+type businessOffer struct {
+	CouponCode      string `json:"couponCode,omitempty"`
+	RedeemOnlineURL string `json:"redeemOnlineUrl,omitempty"`
+	TermsConditions string `json:"termsConditions,omitempty"`
+}
+
+type businessMedia struct {
+	MediaFormat string `json:"mediaFormat"` // "PHOTO" or "VIDEO"
+	SourceURL   string `json:"sourceUrl"`
+}
+
+// Publish posts the content to the targeted location as an update, or as an
+// offer when the targeting has one
+func (p *GoogleBusinessPublisher) Publish(ctx context.Context, post *models.Post) error {
+	t := post.Targeting
+	if t == nil || t.Location == "" {
+		return errors.New("google business profile posts need a location")
+	}
+
+	local := businessLocalPost{Summary: post.Content, TopicType: "STANDARD"}
+	if a := t.CallToAction; a != nil {
+		local.CallToAction = &businessCallToAction{ActionType: a.ActionType, URL: a.URL}
+	}
+	if o := t.Offer; o != nil {
+		local.TopicType = "OFFER"
+		local.Event = &businessEvent{
+			Title:    o.Title,
+			Schedule: businessSchedule{StartDate: parseBusinessDate(o.StartDate), EndDate: parseBusinessDate(o.EndDate)},
+		}
+		local.Offer = &businessOffer{CouponCode: o.CouponCode, RedeemOnlineURL: o.RedeemURL, TermsConditions: o.Terms}
+	}
+	for _, m := range post.Media {
+		format := "PHOTO"
+		if strings.HasPrefix(m.ContentType, "video/") {
+			format = "VIDEO"
+		}
+		local.Media = append(local.Media, businessMedia{MediaFormat: format, SourceURL: m.URL})
+	}
+
+	logPayload(models.ChannelGoogleBusiness, post, businessRequest{Parent: t.Location, LocalPost: local})
+	return nil
+}
+
+// parseBusinessDate converts a validated YYYY-MM-DD date to Google's Date
+func parseBusinessDate(s string) businessDate {
+	d, _ := time.Parse(time.DateOnly, s)
+	return businessDate{Year: d.Year(), Month: int(d.Month()), Day: d.Day()}
+}
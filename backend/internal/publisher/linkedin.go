@@ -0,0 +1,77 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// LinkedInPublisher publishes posts to LinkedIn profiles and company pages
+type LinkedInPublisher struct{}
+
+// linkedInPayload mirrors the body of POST /rest/posts
+type linkedInPayload struct {
+	Author       string            `json:"author"`
+	Commentary   string            `json:"commentary"`
+	Visibility   string            `json:"visibility"`
+	Distribution linkedInTargeting `json:"distribution"`
+	Content      *linkedInContent  `json:"content,omitempty"`
+}
+
+type linkedInContent struct {
+	MultiImage struct {
+		Images []linkedInImage `json:"images"`
+	} `json:"multiImage"`
+}
+
+type linkedInImage struct {
+	ID      string `json:"id"`
+	AltText string `json:"altText,omitempty"`
+}
+
+type linkedInTargeting struct {
+	FeedDistribution string   `json:"feedDistribution"`
+	TargetEntities   []string `json:"targetEntities,omitempty"`
+}
+
+// linkedInVisibility maps our visibility values to LinkedIn's
+var linkedInVisibility = map[string]string{
+	"public":      "PUBLIC",
+	"connections": "CONNECTIONS",
+}
+
+// Publish publishes a LinkedIn post, honouring targeting metadata
+func (p *LinkedInPublisher) Publish(ctx context.Context, post *models.Post) error {
+	payload := linkedInPayload{
+		Author:     "urn:li:person:" + post.UserID.String(),
+		Commentary: post.Content,
+		Visibility: "PUBLIC",
+		Distribution: linkedInTargeting{
+			FeedDistribution: "MAIN_FEED",
+		},
+	}
+
+	if t := post.Targeting; t != nil {
+		if t.Destination == models.DestinationPage {
+			payload.Author = "urn:li:organization:" + t.PageID
+		}
+		if v, ok := linkedInVisibility[t.Visibility]; ok {
+			payload.Visibility = v
+		}
+		payload.Distribution.TargetEntities = t.Audience
+	}
+
+	if len(post.Media) > 0 {
+		payload.Content = &linkedInContent{}
+		for _, m := range post.Media {
+			image := linkedInImage{ID: "urn:li:image:" + m.MediaID.String()}
+			if m.AltText != nil {
+				image.AltText = *m.AltText
+			}
+			payload.Content.MultiImage.Images = append(payload.Content.MultiImage.Images, image)
+		}
+	}
+
+	logPayload(models.ChannelLinkedIn, post, payload)
+	return nil
+}
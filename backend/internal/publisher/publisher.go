@@ -0,0 +1,121 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/media"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Publisher publishes a post to a single social media platform
+type Publisher interface {
+	Publish(ctx context.Context, post *models.Post) error
+}
+
+// Connections looks up the account a post is published as, for publishers
+// that address the platform with the user's own credentials
+type Connections interface {
+	GetChannelConnection(ctx context.Context, userID uuid.UUID, channel models.Channel) (*models.ChannelConnection, error)
+}
+
+// MediaLookup finds uploaded media, for publishers that send a rendition
+// of a video rather than the upload
+type MediaLookup interface {
+	GetMediaByIDs(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Media, error)
+}
+
+// Store is what publishers look up while publishing
+type Store interface {
+	Connections
+	MediaLookup
+}
+
+// Registry routes posts to the publisher for their channel
+type Registry struct {
+	publishers map[models.Channel]Publisher
+}
+
+// NewRegistry creates a registry with the default publisher for every
+// channel, looking up users' credentials and media in store
+func NewRegistry(store Store) *Registry {
+	return &Registry{
+		publishers: map[models.Channel]Publisher{
+			models.ChannelTwitter:        &TwitterPublisher{},
+			models.ChannelLinkedIn:       &LinkedInPublisher{},
+			models.ChannelFacebook:       &FacebookPublisher{},
+			models.ChannelReddit:         &RedditPublisher{},
+			models.ChannelTelegram:       &TelegramPublisher{connections: store},
+			models.ChannelDiscord:        &DiscordPublisher{connections: store},
+			models.ChannelWebhook:        NewWebhookPublisher(store),
+			models.ChannelGoogleBusiness: &GoogleBusinessPublisher{},
+			models.ChannelYouTube:        &YouTubePublisher{media: store},
+			models.ChannelTikTok:         &TikTokPublisher{media: store},
+		},
+	}
+}
+
+// Register sets the publisher used for a channel
+func (r *Registry) Register(channel models.Channel, p Publisher) {
+	r.publishers[channel] = p
+}
+
+// Publish publishes the post using its channel's publisher
+func (r *Registry) Publish(ctx context.Context, post *models.Post) error {
+	p, ok := r.publishers[post.Channel]
+	if !ok {
+		return fmt.Errorf("no publisher registered for channel %s", post.Channel)
+	}
+	return p.Publish(ctx, post)
+}
+
+// connection returns the user's connection for the post's channel, or an
+// error if they haven't connected one
+func connection(ctx context.Context, conns Connections, post *models.Post) (*models.ChannelConnection, error) {
+	if conns == nil {
+		return nil, fmt.Errorf("%s publishing needs channel connections", post.Channel)
+	}
+	conn, err := conns.GetChannelConnection(ctx, post.UserID, post.Channel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s connection: %w", post.Channel, err)
+	}
+	if conn == nil {
+		return nil, fmt.Errorf("%s is not connected", post.Channel)
+	}
+	return conn, nil
+}
+
+// logPayload logs the request a publisher would send to the platform API.
+// Publishers build the real platform payload but the HTTP call is simulated
+// until platform API credentials are wired in.
+func logPayload(channel models.Channel, post *models.Post, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("⚠️ [PUBLISHER] Failed to encode %s payload for post %s: %v", channel, post.ID, err)
+		return
+	}
+	log.Printf("📨 [PUBLISHER] %s request for post %s: %s", channel, post.ID, data)
+}
+
+// verticalVideoURL returns the URL to publish a video from on channels that
+// show vertical video: its vertical rendition if it is landscape and one has
+// been made, and otherwise the upload itself
+func verticalVideoURL(ctx context.Context, lookup MediaLookup, userID uuid.UUID, video models.PostMedia) (string, error) {
+	if video.Width <= video.Height || lookup == nil {
+		return video.URL, nil
+	}
+	items, err := lookup.GetMediaByIDs(ctx, userID, []uuid.UUID{video.MediaID})
+	if err != nil {
+		return "", fmt.Errorf("failed to load media %s: %w", video.MediaID, err)
+	}
+	if item, ok := items[video.MediaID]; ok {
+		if r, ok := item.Rendition(media.RenditionVertical); ok {
+			return r.URL, nil
+		}
+	}
+	log.Printf("⚠️ [PUBLISHER] No vertical rendition of media %s; publishing the landscape upload", video.MediaID)
+	return video.URL, nil
+}
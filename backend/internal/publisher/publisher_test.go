@@ -0,0 +1,40 @@
+package publisher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/media"
+	"github.com/scheduler/backend/internal/models"
+)
+
+type mediaLookupFunc func(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Media, error)
+
+func (f mediaLookupFunc) GetMediaByIDs(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Media, error) {
+	return f(ctx, userID, ids)
+}
+
+func TestVerticalVideoURL(t *testing.T) {
+	id := uuid.New()
+	item := &models.Media{ID: id}
+	lookup := mediaLookupFunc(func(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Media, error) {
+		return map[uuid.UUID]*models.Media{id: item}, nil
+	})
+	landscape := models.PostMedia{MediaID: id, URL: "https://cdn.example.com/clip.mp4", ContentType: "video/mp4", Width: 1920, Height: 1080}
+
+	if got, err := verticalVideoURL(context.Background(), lookup, uuid.New(), landscape); err != nil || got != landscape.URL {
+		t.Errorf("without renditions = %q, %v, want the original", got, err)
+	}
+
+	item.Renditions = []models.MediaRendition{{Name: media.RenditionVertical, URL: "https://cdn.example.com/clip-vertical.mp4"}}
+	if got, _ := verticalVideoURL(context.Background(), lookup, uuid.New(), landscape); got != "https://cdn.example.com/clip-vertical.mp4" {
+		t.Errorf("landscape = %q, want the vertical rendition", got)
+	}
+
+	portrait := landscape
+	portrait.Width, portrait.Height = 1080, 1920
+	if got, _ := verticalVideoURL(context.Background(), lookup, uuid.New(), portrait); got != portrait.URL {
+		t.Errorf("portrait = %q, want the original", got)
+	}
+}
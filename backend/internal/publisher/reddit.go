@@ -0,0 +1,60 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// RedditPublisher submits posts to subreddits
+type RedditPublisher struct{}
+
+// redditPayload mirrors the body of POST /api/submit for text posts, or of
+// POST /api/submit_gallery_post.json when the post has media
+type redditPayload struct {
+	Subreddit   string              `json:"sr"`
+	Kind        string              `json:"kind,omitempty"` // "self" for text posts
+	Title       string              `json:"title"`
+	Text        string              `json:"text"`
+	FlairID     string              `json:"flair_id,omitempty"`
+	FlairText   string              `json:"flair_text,omitempty"`
+	SendReplies bool                `json:"sendreplies"`
+	Items       []redditGalleryItem `json:"items,omitempty"`
+}
+
+type redditGalleryItem struct {
+	MediaID string `json:"media_id"`
+	Caption string `json:"caption,omitempty"`
+}
+
+// Publish submits a text post, or a gallery when the post has media, with
+// the content as its body
+func (p *RedditPublisher) Publish(ctx context.Context, post *models.Post) error {
+	if post.Title == nil || post.Targeting == nil || post.Targeting.Subreddit == "" {
+		return errors.New("reddit posts need a title and a subreddit")
+	}
+
+	payload := redditPayload{
+		Subreddit:   post.Targeting.Subreddit,
+		Title:       *post.Title,
+		Text:        post.Content,
+		FlairID:     post.Targeting.FlairID,
+		FlairText:   post.Targeting.FlairText,
+		SendReplies: true,
+	}
+
+	if len(post.Media) == 0 {
+		payload.Kind = "self"
+	}
+	for _, m := range post.Media {
+		item := redditGalleryItem{MediaID: m.MediaID.String()}
+		if m.AltText != nil {
+			item.Caption = *m.AltText
+		}
+		payload.Items = append(payload.Items, item)
+	}
+
+	logPayload(models.ChannelReddit, post, payload)
+	return nil
+}
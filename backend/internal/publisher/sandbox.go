@@ -0,0 +1,107 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// SandboxConfig controls simulated platform behaviour in sandbox mode
+type SandboxConfig struct {
+	FailureRate float64       // Probability in [0, 1] that a publish fails
+	MinLatency  time.Duration // Simulated platform response time range
+	MaxLatency  time.Duration
+}
+
+// sandboxErrors are the simulated platform failures returned at random
+var sandboxErrors = []string{
+	"503 Service Unavailable",
+	sandboxRateLimited,
+	"500 Internal Server Error",
+	"502 Bad Gateway",
+}
+
+const (
+	sandboxRateLimited = "429 Too Many Requests"
+	// sandboxRetryAfter is the Retry-After sent with simulated rate limits
+	sandboxRetryAfter = time.Minute
+)
+
+// SandboxPublisher wraps a channel's publisher, building its payload as usual but
+// simulating the platform response with configurable latency and failure rate, so
+// staging exercises the full retry/notify path without hitting real APIs
+type SandboxPublisher struct {
+	channel models.Channel
+	next    Publisher
+	cfg     SandboxConfig
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewSandboxPublisher creates a sandbox publisher around next
+func NewSandboxPublisher(channel models.Channel, next Publisher, cfg SandboxConfig) *SandboxPublisher {
+	return &SandboxPublisher{
+		channel: channel,
+		next:    next,
+		cfg:     cfg,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// NewSandboxRegistry creates a registry whose publishers all run in sandbox mode
+func NewSandboxRegistry(store Store, cfg SandboxConfig) *Registry {
+	r := NewRegistry(store)
+	// Sandbox deliveries are logged, not sent to users' endpoints
+	r.publishers[models.ChannelWebhook] = &WebhookPublisher{connections: store}
+	for channel, p := range r.publishers {
+		r.publishers[channel] = NewSandboxPublisher(channel, p, cfg)
+	}
+	return r
+}
+
+// Publish builds the platform payload and simulates the platform's response
+func (p *SandboxPublisher) Publish(ctx context.Context, post *models.Post) error {
+	if err := p.next.Publish(ctx, post); err != nil {
+		return err
+	}
+
+	latency, fail, failure := p.roll()
+
+	select {
+	case <-time.After(latency):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if fail {
+		log.Printf("🧪 [SANDBOX] %s simulated failure for post %s after %v: %s", p.channel, post.ID, latency, failure)
+		if failure == sandboxRateLimited {
+			return &RateLimitError{Channel: p.channel, RetryAfter: sandboxRetryAfter, Message: "sandbox: " + failure}
+		}
+		return fmt.Errorf("sandbox: %s returned %s", p.channel, failure)
+	}
+
+	log.Printf("🧪 [SANDBOX] %s accepted post %s after %v (platform id %s)", p.channel, post.ID, latency, uuid.NewString())
+	return nil
+}
+
+// roll draws the simulated latency and outcome for one publish
+func (p *SandboxPublisher) roll() (time.Duration, bool, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	latency := p.cfg.MinLatency
+	if spread := p.cfg.MaxLatency - p.cfg.MinLatency; spread > 0 {
+		latency += time.Duration(p.rng.Int63n(int64(spread)))
+	}
+
+	fail := p.rng.Float64() < p.cfg.FailureRate
+	return latency, fail, sandboxErrors[p.rng.Intn(len(sandboxErrors))]
+}
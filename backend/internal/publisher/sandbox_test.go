@@ -0,0 +1,47 @@
+package publisher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestSandboxPublisher_FailureRate(t *testing.T) {
+	post := &models.Post{Channel: models.ChannelTwitter, Content: "Hello"}
+
+	tests := []struct {
+		name    string
+		rate    float64
+		wantErr bool
+	}{
+		{"never fails", 0, false},
+		{"always fails", 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewSandboxRegistry(nil, SandboxConfig{FailureRate: tt.rate})
+			for i := 0; i < 20; i++ {
+				if err := r.Publish(context.Background(), post); (err != nil) != tt.wantErr {
+					t.Fatalf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestSandboxPublisher_LatencyRespectsContext(t *testing.T) {
+	p := NewSandboxPublisher(models.ChannelTwitter, &TwitterPublisher{}, SandboxConfig{
+		MinLatency: time.Minute,
+		MaxLatency: time.Minute,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := p.Publish(ctx, &models.Post{Channel: models.ChannelTwitter}); err != context.DeadlineExceeded {
+		t.Errorf("Expected context deadline error, got: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// TelegramPublisher posts to a Telegram channel as the user's own bot
+type TelegramPublisher struct {
+	connections Connections
+}
+
+// telegramCall is one Bot API method call, made to
+// https://api.telegram.org/bot<token>/<method>
+type telegramCall struct {
+	Method string         `json:"method"`
+	Params telegramParams `json:"params"`
+}
+
+// telegramParams mirrors the body of sendMessage, sendPhoto, sendVideo and
+// sendMediaGroup
+type telegramParams struct {
+	ChatID  string               `json:"chat_id"`
+	Text    string               `json:"text,omitempty"`
+	Photo   string               `json:"photo,omitempty"`
+	Video   string               `json:"video,omitempty"`
+	Caption string               `json:"caption,omitempty"`
+	Media   []telegramInputMedia `json:"media,omitempty"`
+}
+
+type telegramInputMedia struct {
+	Type    string `json:"type"` // "photo" or "video"
+	Media   string `json:"media"`
+	Caption string `json:"caption,omitempty"`
+}
+
+// Publish sends the post to the connection's chat: a text message, or its
+// media with the content as the caption. Content too long for a caption is
+// sent as a message after the media. In an album, attachments after the
+// first are captioned with their alt text.
+func (p *TelegramPublisher) Publish(ctx context.Context, post *models.Post) error {
+	conn, err := connection(ctx, p.connections, post)
+	if err != nil {
+		return err
+	}
+	if conn.Target == nil || *conn.Target == "" {
+		return errors.New("telegram connection has no target chat")
+	}
+
+	for _, call := range telegramCalls(*conn.Target, post) {
+		logPayload(models.ChannelTelegram, post, call)
+	}
+	return nil
+}
+
+// telegramCalls builds the method calls that publish post to chatID
+func telegramCalls(chatID string, post *models.Post) []telegramCall {
+	message := telegramCall{Method: "sendMessage", Params: telegramParams{ChatID: chatID, Text: post.Content}}
+	if len(post.Media) == 0 {
+		return []telegramCall{message}
+	}
+
+	caption := post.Content
+	if models.ContentLength(models.ChannelTelegram, caption) > models.MaxTelegramCaptionLength {
+		caption = ""
+	}
+
+	var calls []telegramCall
+	if len(post.Media) == 1 {
+		m := post.Media[0]
+		params := telegramParams{ChatID: chatID, Caption: caption}
+		method := "sendPhoto"
+		if telegramMediaType(m) == "video" {
+			method = "sendVideo"
+			params.Video = m.URL
+		} else {
+			params.Photo = m.URL
+		}
+		calls = append(calls, telegramCall{Method: method, Params: params})
+	} else {
+		params := telegramParams{ChatID: chatID}
+		for i, m := range post.Media {
+			item := telegramInputMedia{Type: telegramMediaType(m), Media: m.URL}
+			if i == 0 {
+				item.Caption = caption
+			} else if m.AltText != nil {
+				item.Caption = *m.AltText
+			}
+			params.Media = append(params.Media, item)
+		}
+		calls = append(calls, telegramCall{Method: "sendMediaGroup", Params: params})
+	}
+
+	if caption == "" {
+		calls = append(calls, message)
+	}
+	return calls
+}
+
+func telegramMediaType(m models.PostMedia) string {
+	if strings.HasPrefix(m.ContentType, "video/") {
+		return "video"
+	}
+	return "photo"
+}
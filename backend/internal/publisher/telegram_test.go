@@ -0,0 +1,56 @@
+package publisher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestTelegramCalls(t *testing.T) {
+	photo := models.PostMedia{URL: "https://cdn.example.com/a.jpg", ContentType: "image/jpeg"}
+	video := models.PostMedia{URL: "https://cdn.example.com/b.mp4", ContentType: "video/mp4"}
+	long := strings.Repeat("a", models.MaxTelegramCaptionLength+1)
+
+	methods := func(calls []telegramCall) string {
+		var out []string
+		for _, c := range calls {
+			out = append(out, c.Method)
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		media   []models.PostMedia
+		want    string
+	}{
+		{"text", "Hello", nil, "sendMessage"},
+		{"photo", "Hello", []models.PostMedia{photo}, "sendPhoto"},
+		{"video", "Hello", []models.PostMedia{video}, "sendVideo"},
+		{"album", "Hello", []models.PostMedia{photo, video}, "sendMediaGroup"},
+		{"long caption", long, []models.PostMedia{photo}, "sendPhoto,sendMessage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := telegramCalls("@launch_news", &models.Post{Content: tt.content, Media: tt.media})
+			if got := methods(calls); got != tt.want {
+				t.Fatalf("methods = %s, want %s", got, tt.want)
+			}
+			for _, c := range calls {
+				if c.Params.ChatID != "@launch_news" {
+					t.Errorf("%s chat_id = %q", c.Method, c.Params.ChatID)
+				}
+			}
+		})
+	}
+
+	alt := "A cat"
+	calls := telegramCalls("1", &models.Post{Content: "Hello", Media: []models.PostMedia{photo, {URL: "x", ContentType: "image/png", AltText: &alt}}})
+	media := calls[0].Params.Media
+	if media[0].Caption != "Hello" || media[1].Caption != alt || media[1].Type != "photo" {
+		t.Errorf("album media = %+v", media)
+	}
+}
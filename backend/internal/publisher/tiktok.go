@@ -0,0 +1,54 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// TikTokPublisher publishes videos through TikTok's Content Posting API
+type TikTokPublisher struct {
+	media MediaLookup
+}
+
+// tikTokVideoInit mirrors POST /v2/post/publish/video/init/, with TikTok
+// pulling the video from the URL
+type tikTokVideoInit struct {
+	PostInfo   tikTokPostInfo   `json:"post_info"`
+	SourceInfo tikTokSourceInfo `json:"source_info"`
+}
+
+type tikTokPostInfo struct {
+	Title        string `json:"title"` // The caption
+	PrivacyLevel string `json:"privacy_level"`
+}
+
+type tikTokSourceInfo struct {
+	Source   string `json:"source"` // "PULL_FROM_URL"
+	VideoURL string `json:"video_url"`
+}
+
+// Publish posts the post's video with its content as the caption. A
+// landscape video is swapped for its vertical rendition when one has been
+// made.
+func (p *TikTokPublisher) Publish(ctx context.Context, post *models.Post) error {
+	if len(post.Media) == 0 || !post.Media[0].IsVideo() {
+		return errors.New("tiktok posts need a video")
+	}
+	source, err := verticalVideoURL(ctx, p.media, post.UserID, post.Media[0])
+	if err != nil {
+		return err
+	}
+
+	visibility := "public"
+	if post.Targeting != nil && post.Targeting.Visibility != "" {
+		visibility = post.Targeting.Visibility
+	}
+
+	logPayload(models.ChannelTikTok, post, tikTokVideoInit{
+		PostInfo:   tikTokPostInfo{Title: post.Content, PrivacyLevel: models.TikTokPrivacyLevels[visibility]},
+		SourceInfo: tikTokSourceInfo{Source: "PULL_FROM_URL", VideoURL: source},
+	})
+	return nil
+}
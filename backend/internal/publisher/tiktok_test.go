@@ -0,0 +1,20 @@
+package publisher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestTikTokPublisher_NeedsVideo(t *testing.T) {
+	p := &TikTokPublisher{}
+	image := &models.Post{Channel: models.ChannelTikTok, Media: []models.PostMedia{{URL: "https://cdn.example.com/a.png", ContentType: "image/png"}}}
+	if err := p.Publish(context.Background(), image); err == nil {
+		t.Error("published a post without a video")
+	}
+	video := &models.Post{Channel: models.ChannelTikTok, Media: []models.PostMedia{{URL: "https://cdn.example.com/a.mp4", ContentType: "video/mp4"}}}
+	if err := p.Publish(context.Background(), video); err != nil {
+		t.Errorf("Publish = %v", err)
+	}
+}
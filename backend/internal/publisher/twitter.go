@@ -0,0 +1,80 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// TwitterPublisher publishes posts to Twitter/X
+type TwitterPublisher struct{}
+
+// twitterPayload mirrors the body of POST /2/tweets
+type twitterPayload struct {
+	Text  string        `json:"text"`
+	Poll  *twitterPoll  `json:"poll,omitempty"`
+	Media *twitterMedia `json:"media,omitempty"`
+	Geo   *twitterGeo   `json:"geo,omitempty"`
+}
+
+type twitterGeo struct {
+	PlaceID     string      `json:"place_id,omitempty"`
+	Coordinates *[2]float64 `json:"coordinates,omitempty"` // [longitude, latitude]
+}
+
+// twitterMedia references uploaded media; alt text is sent via the media metadata endpoint
+type twitterMedia struct {
+	MediaIDs []string               `json:"media_ids"`
+	Metadata []twitterMediaMetadata `json:"-"`
+}
+
+type twitterMediaMetadata struct {
+	MediaID string `json:"media_id"`
+	AltText struct {
+		Text string `json:"text"`
+	} `json:"alt_text"`
+}
+
+type twitterPoll struct {
+	Options         []string `json:"options"`
+	DurationMinutes int      `json:"duration_minutes"`
+}
+
+// Publish publishes a tweet
+func (p *TwitterPublisher) Publish(ctx context.Context, post *models.Post) error {
+	payload := twitterPayload{
+		Text: post.Content,
+	}
+
+	if post.Type == models.PostTypePoll && post.Poll != nil {
+		payload.Poll = &twitterPoll{
+			Options:         post.Poll.Options,
+			DurationMinutes: post.Poll.DurationMinutes,
+		}
+	}
+
+	if l := post.Location; l != nil {
+		payload.Geo = &twitterGeo{PlaceID: l.PlaceID}
+		if l.Latitude != nil && l.Longitude != nil {
+			payload.Geo.Coordinates = &[2]float64{*l.Longitude, *l.Latitude}
+		}
+	}
+
+	if len(post.Media) > 0 {
+		payload.Media = &twitterMedia{}
+		for _, m := range post.Media {
+			payload.Media.MediaIDs = append(payload.Media.MediaIDs, m.MediaID.String())
+			if m.AltText != nil {
+				meta := twitterMediaMetadata{MediaID: m.MediaID.String()}
+				meta.AltText.Text = *m.AltText
+				payload.Media.Metadata = append(payload.Media.Metadata, meta)
+			}
+		}
+		for _, meta := range payload.Media.Metadata {
+			logPayload(models.ChannelTwitter, post, meta)
+		}
+	}
+
+	logPayload(models.ChannelTwitter, post, payload)
+	return nil
+}
@@ -0,0 +1,166 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// webhookTimeout bounds each delivery to a webhook channel
+const webhookTimeout = 10 * time.Second
+
+// Webhook channel delivery headers
+const (
+	WebhookSignatureHeader = "X-Scheduler-Signature" // "sha256=" and the hex HMAC of "<timestamp>.<body>"
+	WebhookTimestampHeader = "X-Scheduler-Timestamp" // Unix seconds, part of the signed message
+	WebhookDeliveryHeader  = "X-Scheduler-Delivery"  // Unique per attempt
+	WebhookEventHeader     = "X-Scheduler-Event"
+)
+
+// WebhookPublisher delivers posts to the user's own HTTPS endpoint, signed
+// with their secret, so they can bridge to platforms without a publisher
+type WebhookPublisher struct {
+	connections Connections
+	client      *http.Client // Nil only logs the delivery, as in sandbox mode
+	now         func() time.Time
+}
+
+// NewWebhookPublisher creates a webhook publisher that refuses to connect
+// to private addresses
+func NewWebhookPublisher(conns Connections) *WebhookPublisher {
+	return &WebhookPublisher{connections: conns, client: newWebhookClient(), now: time.Now}
+}
+
+// Publish POSTs the post to the connection's endpoint. A 429 response is a
+// rate limit and any other non-2xx response fails the publish.
+func (p *WebhookPublisher) Publish(ctx context.Context, post *models.Post) error {
+	conn, err := connection(ctx, p.connections, post)
+	if err != nil {
+		return err
+	}
+	if conn.Target == nil {
+		return errors.New("webhook connection has no endpoint")
+	}
+	endpoint, err := models.ValidateWebhookEndpoint(*conn.Target)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+	sentAt := now().UTC()
+	body, err := json.Marshal(models.WebhookPublish{
+		Event:       models.WebhookEventPublish,
+		PostID:      post.ID,
+		Title:       post.Title,
+		Content:     post.Content,
+		Media:       post.Media,
+		Targeting:   post.Targeting,
+		ScheduledAt: post.ScheduledAt,
+		SentAt:      sentAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook body: %w", err)
+	}
+
+	if p.client == nil {
+		logPayload(models.ChannelWebhook, post, json.RawMessage(body))
+		return nil
+	}
+
+	if err := p.deliver(ctx, endpoint.String(), conn.AccessToken, body, sentAt); err != nil {
+		return err
+	}
+	log.Printf("📨 [PUBLISHER] webhook delivered post %s to %s", post.ID, endpoint.Host)
+	return nil
+}
+
+// deliver POSTs a signed body to endpoint
+func (p *WebhookPublisher) deliver(ctx context.Context, endpoint, secret string, body []byte, sentAt time.Time) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "post-scheduler-webhook/1")
+	req.Header.Set(WebhookEventHeader, models.WebhookEventPublish)
+	req.Header.Set(WebhookDeliveryHeader, uuid.NewString())
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(secret, timestamp, body))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook delivery to %s failed: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter, _ := ParseRetryAfter(resp.Header.Get("Retry-After"), sentAt)
+		return &RateLimitError{Channel: models.ChannelWebhook, RetryAfter: retryAfter, Message: resp.Status}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook endpoint %s returned %s", req.URL.Host, resp.Status)
+	}
+	return nil
+}
+
+// SignWebhook returns the signature header value for a delivery: "sha256="
+// and the hex HMAC-SHA256 of the timestamp, a dot and the body, keyed with
+// the connection's secret. Receivers recompute it to check that a delivery
+// came from the scheduler, and reject old timestamps to stop replays.
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// newWebhookClient returns a client that won't follow redirects and refuses
+// to connect to loopback, private or link-local addresses, which a public
+// hostname could otherwise resolve to
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !models.IsPublicIP(ip) {
+				return fmt.Errorf("webhook endpoint resolves to a non-public address %s", host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package publisher
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestWebhookPublisher_Deliver(t *testing.T) {
+	const secret = "0123456789abcdef"
+	sentAt := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	body := []byte(`{"event":"post.publish"}`)
+
+	status := http.StatusNoContent
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ := io.ReadAll(r.Body)
+		timestamp := r.Header.Get(WebhookTimestampHeader)
+		if timestamp != "1705320000" {
+			t.Errorf("timestamp = %q", timestamp)
+		}
+		if sig := r.Header.Get(WebhookSignatureHeader); sig != SignWebhook(secret, timestamp, got) {
+			t.Errorf("signature %q doesn't match the body", sig)
+		}
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "30")
+		}
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	p := &WebhookPublisher{client: srv.Client()}
+	if err := p.deliver(context.Background(), srv.URL, secret, body, sentAt); err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+
+	status = http.StatusTooManyRequests
+	err := p.deliver(context.Background(), srv.URL, secret, body, sentAt)
+	if rl, ok := AsRateLimit(err); !ok || rl.RetryAfter != 30*time.Second {
+		t.Errorf("429: err = %v, want a rate limit with retry after 30s", err)
+	}
+
+	status = http.StatusInternalServerError
+	if err := p.deliver(context.Background(), srv.URL, secret, body, sentAt); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("500: err = %v", err)
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	// printf '1705320000.{}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=30b81b6ef1ca0dedb209c5b233955c423e61372fb7081e54e6a7b361cc92de50"
+	if got := SignWebhook("secret", "1705320000", []byte("{}")); got != want {
+		t.Errorf("SignWebhook = %q, want %q", got, want)
+	}
+}
+
+func TestWebhookClient_RefusesPrivateAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached a loopback server")
+	}))
+	defer srv.Close()
+
+	if _, err := newWebhookClient().Get(srv.URL); err == nil {
+		t.Error("client connected to a loopback address")
+	}
+}
+
+func TestWebhookPublisher_Publish_RequiresPublicEndpoint(t *testing.T) {
+	endpoint := "https://127.0.0.1/hook"
+	p := NewWebhookPublisher(staticConnection{AccessToken: "0123456789abcdef", Target: &endpoint})
+	if err := p.Publish(context.Background(), &models.Post{Channel: models.ChannelWebhook}); err == nil {
+		t.Error("Publish delivered to a loopback endpoint")
+	}
+}
+
+// staticConnection returns itself as every user's connection
+type staticConnection models.ChannelConnection
+
+func (c staticConnection) GetChannelConnection(ctx context.Context, userID uuid.UUID, channel models.Channel) (*models.ChannelConnection, error) {
+	conn := models.ChannelConnection(c)
+	return &conn, nil
+}
@@ -0,0 +1,81 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// YouTubePublisher uploads Shorts and publishes community posts
+type YouTubePublisher struct {
+	media MediaLookup
+}
+
+// youTubeVideoInsert mirrors POST /upload/youtube/v3/videos?part=snippet,status,
+// with the video uploaded from Source
+type youTubeVideoInsert struct {
+	Snippet youTubeSnippet `json:"snippet"`
+	Status  youTubeStatus  `json:"status"`
+	Source  string         `json:"source"`
+}
+
+type youTubeSnippet struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	CategoryID  string `json:"categoryId"`
+}
+
+type youTubeStatus struct {
+	PrivacyStatus           string `json:"privacyStatus"`
+	SelfDeclaredMadeForKids bool   `json:"selfDeclaredMadeForKids"`
+}
+
+// youTubeCommunityPost is a text post to the channel's community tab, with
+// up to five images
+type youTubeCommunityPost struct {
+	Text   string   `json:"text"`
+	Images []string `json:"images,omitempty"`
+}
+
+// youTubeCategoryPeopleBlogs is the category uploads default to
+const youTubeCategoryPeopleBlogs = "22"
+
+// Publish uploads the post's video as a Short, or publishes a community
+// post when it has no video. A landscape video is swapped for its vertical
+// rendition when one has been made, since only vertical and square videos
+// are shown as Shorts.
+func (p *YouTubePublisher) Publish(ctx context.Context, post *models.Post) error {
+	if !models.IsYouTubeShort(post.Media) {
+		payload := youTubeCommunityPost{Text: post.Content}
+		for _, m := range post.Media {
+			payload.Images = append(payload.Images, m.URL)
+		}
+		logPayload(models.ChannelYouTube, post, payload)
+		return nil
+	}
+
+	if post.Title == nil {
+		return errors.New("youtube shorts need a title")
+	}
+	video := post.Media[0]
+	source, err := verticalVideoURL(ctx, p.media, post.UserID, video)
+	if err != nil {
+		return err
+	}
+
+	status := youTubeStatus{PrivacyStatus: "public"}
+	if t := post.Targeting; t != nil {
+		if t.Visibility != "" {
+			status.PrivacyStatus = t.Visibility
+		}
+		status.SelfDeclaredMadeForKids = t.MadeForKids
+	}
+
+	logPayload(models.ChannelYouTube, post, youTubeVideoInsert{
+		Snippet: youTubeSnippet{Title: *post.Title, Description: post.Content, CategoryID: youTubeCategoryPeopleBlogs},
+		Status:  status,
+		Source:  source,
+	})
+	return nil
+}
@@ -0,0 +1,120 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/tenant"
+)
+
+// Client identifies the requester that rate limits count against: their IP
+// address, counted separately per tenant
+func Client(r *http.Request) string {
+	return fmt.Sprintf("%s:%s", tenant.IDFromContext(r.Context()), ClientIP(r))
+}
+
+// ClientIP returns the requester's IP address, as forwarded by the proxy,
+// without the connection's port
+func ClientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return forwarded
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// bucketKey is the counter of a client's requests to path in scope
+func bucketKey(client, scope, path string) string {
+	return fmt.Sprintf("ratelimit:%s:%s:%s", client, scope, path)
+}
+
+// indexKey lists a client's buckets as "scope:path" fields, so they can be
+// reported without scanning the keyspace
+func indexKey(client string) string {
+	return "ratelimit:index:" + client
+}
+
+// Take counts a request by client to path against the scope's window and
+// returns the number of requests in the window so far
+func (r *Registry) Take(ctx context.Context, client, scope, path string, window time.Duration) (int, error) {
+	pipe := r.redis.Pipeline()
+	take := r.QueueTake(ctx, pipe, client, scope, path, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return take()
+}
+
+// QueueTake adds counting a request as Take does to pipe and returns a func
+// that gives the count, once pipe has run
+func (r *Registry) QueueTake(ctx context.Context, pipe redis.Pipeliner, client, scope, path string, window time.Duration) func() (int, error) {
+	key := bucketKey(client, scope, path)
+	incrCmd := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, window)
+	pipe.HSet(ctx, indexKey(client), scope+":"+path, 1)
+	pipe.Expire(ctx, indexKey(client), MaxWindow)
+
+	return func() (int, error) {
+		n, err := incrCmd.Result()
+		return int(n), err
+	}
+}
+
+// Buckets returns the client's windows that have requests in them, with the
+// limits of plan p, by scope and path
+func (r *Registry) Buckets(ctx context.Context, client string, p models.Plan) ([]models.RateLimitBucket, error) {
+	fields, err := r.redis.HKeys(ctx, indexKey(client)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := r.redis.Pipeline()
+	counts := make([]*redis.StringCmd, len(fields))
+	ttls := make([]*redis.DurationCmd, len(fields))
+	for i, field := range fields {
+		scope, path, _ := strings.Cut(field, ":")
+		counts[i] = pipe.Get(ctx, bucketKey(client, scope, path))
+		ttls[i] = pipe.PTTL(ctx, bucketKey(client, scope, path))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	buckets := make([]models.RateLimitBucket, 0, len(fields))
+	var expired []string
+	for i, field := range fields {
+		used, err := counts[i].Int()
+		if err != nil {
+			expired = append(expired, field)
+			continue
+		}
+		scope, path, _ := strings.Cut(field, ":")
+		if _, ok := r.base[scope]; !ok {
+			continue
+		}
+		buckets = append(buckets, models.NewRateLimitBucket(r.Policy(ctx, scope, p), path, used, now.Add(ttls[i].Val())))
+	}
+
+	// Windows that have passed are dropped from the index
+	if len(expired) > 0 {
+		r.redis.HDel(ctx, indexKey(client), expired...)
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Scope != buckets[j].Scope {
+			return buckets[i].Scope < buckets[j].Scope
+		}
+		return buckets[i].Path < buckets[j].Path
+	})
+	return buckets, nil
+}
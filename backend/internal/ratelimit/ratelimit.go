@@ -0,0 +1,183 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Rate limit scopes, one per group of endpoints
+const (
+	ScopeLogin      = "login"
+	ScopeRegister   = "register"
+	ScopePostCreate = "post_create"
+	ScopeAPI        = "api"
+)
+
+const (
+	overridesKey = "ratelimit:overrides"
+
+	// overridesTTL is how long overrides are cached before reloading, so
+	// runtime changes reach every API instance within this time
+	overridesTTL = 10 * time.Second
+
+	// MaxWindow is the longest window a rate limit may use
+	MaxWindow = 24 * time.Hour
+)
+
+// ErrUnknownScope is returned when adjusting a scope without a configured limit
+var ErrUnknownScope = errors.New("unknown rate limit scope")
+
+// Registry holds the rate limit of each scope: the configured limits,
+// replaced by overrides set at runtime and shared through Redis, and scaled by
+// per-plan multipliers for authenticated users
+type Registry struct {
+	redis       *redis.Client
+	base        map[string]models.RateLimitPolicy
+	multipliers map[models.Plan]float64
+
+	mu        sync.Mutex
+	overrides map[string]models.RateLimitPolicy
+	loadedAt  time.Time
+}
+
+// NewRegistry creates a registry of the configured limits per scope
+func NewRegistry(redisClient *redis.Client, base map[string]models.RateLimitPolicy, multipliers map[models.Plan]float64) *Registry {
+	return &Registry{
+		redis:       redisClient,
+		base:        base,
+		multipliers: multipliers,
+	}
+}
+
+// Policy returns the scope's limit for a user on plan p; an empty plan is
+// for unauthenticated requests. The configured limit is used if overrides
+// can't be loaded.
+func (r *Registry) Policy(ctx context.Context, scope string, p models.Plan) models.RateLimitPolicy {
+	policy := r.effective(ctx, scope)
+	if m, ok := r.multipliers[p]; ok && p != "" {
+		policy.Limit = scale(policy.Limit, m)
+	}
+	return policy
+}
+
+// scale multiplies a limit, rounding to the nearest request and allowing at least one
+func scale(limit int, multiplier float64) int {
+	return int(math.Max(1, math.Round(float64(limit)*multiplier)))
+}
+
+// List returns every scope's limit for plan p, by scope; an empty plan
+// lists the limits before plan multipliers
+func (r *Registry) List(ctx context.Context, p models.Plan) []models.RateLimitPolicy {
+	policies := make([]models.RateLimitPolicy, 0, len(r.base))
+	for scope := range r.base {
+		policies = append(policies, r.Policy(ctx, scope, p))
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Scope < policies[j].Scope })
+	return policies
+}
+
+// Multipliers returns the per-plan limit multipliers
+func (r *Registry) Multipliers() map[models.Plan]float64 {
+	return r.multipliers
+}
+
+// Set overrides a scope's limit on every API instance until it is reset
+func (r *Registry) Set(ctx context.Context, scope string, limit int, window time.Duration) (models.RateLimitPolicy, error) {
+	if _, ok := r.base[scope]; !ok {
+		return models.RateLimitPolicy{}, ErrUnknownScope
+	}
+	if limit < 1 {
+		return models.RateLimitPolicy{}, errors.New("limit must be at least 1")
+	}
+	if window < time.Second || window > MaxWindow {
+		return models.RateLimitPolicy{}, fmt.Errorf("window_seconds must be between 1 and %d", int(MaxWindow.Seconds()))
+	}
+
+	policy := models.RateLimitPolicy{
+		Scope:         scope,
+		Limit:         limit,
+		WindowSeconds: int(window / time.Second),
+		Overridden:    true,
+	}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return models.RateLimitPolicy{}, err
+	}
+	if err := r.redis.HSet(ctx, overridesKey, scope, data).Err(); err != nil {
+		return models.RateLimitPolicy{}, err
+	}
+	r.invalidate()
+	return policy, nil
+}
+
+// Reset removes a scope's override, restoring its configured limit
+func (r *Registry) Reset(ctx context.Context, scope string) (models.RateLimitPolicy, error) {
+	base, ok := r.base[scope]
+	if !ok {
+		return models.RateLimitPolicy{}, ErrUnknownScope
+	}
+	if err := r.redis.HDel(ctx, overridesKey, scope).Err(); err != nil {
+		return models.RateLimitPolicy{}, err
+	}
+	r.invalidate()
+	return base, nil
+}
+
+// effective returns the scope's override, or its configured limit
+func (r *Registry) effective(ctx context.Context, scope string) models.RateLimitPolicy {
+	if override, ok := r.loadOverrides(ctx)[scope]; ok {
+		return override
+	}
+	return r.base[scope]
+}
+
+// loadOverrides returns the overrides, reloading them at most once per overridesTTL
+func (r *Registry) loadOverrides(ctx context.Context) map[string]models.RateLimitPolicy {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.overrides != nil && time.Since(r.loadedAt) < overridesTTL {
+		return r.overrides
+	}
+
+	values, err := r.redis.HGetAll(ctx, overridesKey).Result()
+	if err != nil {
+		log.Printf("⚠️ Failed to load rate limit overrides: %v", err)
+		if r.overrides == nil {
+			return map[string]models.RateLimitPolicy{}
+		}
+		return r.overrides
+	}
+
+	overrides := make(map[string]models.RateLimitPolicy, len(values))
+	for scope, data := range values {
+		var policy models.RateLimitPolicy
+		if err := json.Unmarshal([]byte(data), &policy); err != nil {
+			log.Printf("⚠️ Ignoring invalid rate limit override for %s: %v", scope, err)
+			continue
+		}
+		if _, ok := r.base[scope]; ok {
+			overrides[scope] = policy
+		}
+	}
+	r.overrides = overrides
+	r.loadedAt = time.Now()
+	return overrides
+}
+
+// invalidate forces the next lookup to reload overrides
+func (r *Registry) invalidate() {
+	r.mu.Lock()
+	r.overrides = nil
+	r.mu.Unlock()
+}
@@ -0,0 +1,23 @@
+package ratelimit
+
+import "testing"
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		limit      int
+		multiplier float64
+		want       int
+	}{
+		{100, 1, 100},
+		{100, 2, 200},
+		{30, 1.5, 45},
+		{5, 0.5, 3},
+		{1, 0.1, 1},
+	}
+
+	for _, tt := range tests {
+		if got := scale(tt.limit, tt.multiplier); got != tt.want {
+			t.Errorf("scale(%d, %v) = %d, want %d", tt.limit, tt.multiplier, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package redisclient
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/metrics"
+)
+
+// New creates the Redis client shared by the API server and worker. Every
+// round trip is timed in scheduler_redis_command_duration_seconds, by command,
+// with pipelines counted once and their size in scheduler_redis_pipeline_commands.
+func New(addr string) *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr: addr,
+	})
+	client.AddHook(metricsHook{})
+	return client
+}
+
+// metricsHook records the latency and failures of each command and pipeline
+type metricsHook struct{}
+
+func (metricsHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (metricsHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		observe(strings.ToLower(cmd.Name()), start, err)
+		return err
+	}
+}
+
+func (metricsHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		metrics.RedisPipelineCommands.Observe(float64(len(cmds)))
+		observe("pipeline", start, err)
+		return err
+	}
+}
+
+// observe records one round trip. redis.Nil only reports a missing key.
+func observe(command string, start time.Time, err error) {
+	metrics.RedisCommandDuration.WithLabelValues(command).Observe(time.Since(start).Seconds())
+	if err != nil && !errors.Is(err, redis.Nil) {
+		metrics.RedisErrors.WithLabelValues(command).Inc()
+	}
+}
@@ -0,0 +1,49 @@
+package redisclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/metrics"
+)
+
+func TestMetricsHook(t *testing.T) {
+	ctx := context.Background()
+	hook := metricsHook{}
+	failing := errors.New("connection refused")
+
+	errorCount := func(command string) float64 {
+		return testutil.ToFloat64(metrics.RedisErrors.WithLabelValues(command))
+	}
+
+	// A missing key isn't a failure
+	getErrors := errorCount("get")
+	process := hook.ProcessHook(func(ctx context.Context, cmd redis.Cmder) error { return redis.Nil })
+	if err := process(ctx, redis.NewStringCmd(ctx, "GET", "missing")); !errors.Is(err, redis.Nil) {
+		t.Errorf("Expected redis.Nil to pass through, got %v", err)
+	}
+	if got := errorCount("get"); got != getErrors {
+		t.Errorf("Expected no get error counted, got %v more", got-getErrors)
+	}
+
+	incrErrors := errorCount("incr")
+	process = hook.ProcessHook(func(ctx context.Context, cmd redis.Cmder) error { return failing })
+	_ = process(ctx, redis.NewIntCmd(ctx, "INCR", "counter"))
+	if got := errorCount("incr"); got != incrErrors+1 {
+		t.Errorf("Expected one incr error counted, got %v", got-incrErrors)
+	}
+
+	// A pipeline is observed once, whatever it holds
+	pipelines := testutil.CollectAndCount(metrics.RedisCommandDuration)
+	pipeline := hook.ProcessPipelineHook(func(ctx context.Context, cmds []redis.Cmder) error { return nil })
+	_ = pipeline(ctx, []redis.Cmder{redis.NewIntCmd(ctx, "INCR", "a"), redis.NewStringCmd(ctx, "GET", "b")})
+	if got := testutil.CollectAndCount(metrics.RedisCommandDuration); got != pipelines+1 {
+		t.Errorf("Expected a pipeline series to be added, got %d series after %d", got, pipelines)
+	}
+	if got := testutil.CollectAndCount(metrics.RedisPipelineCommands); got != 1 {
+		t.Errorf("Expected the pipeline size histogram to be collected, got %d", got)
+	}
+}
@@ -0,0 +1,79 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// auditHeader names the columns of audit log exports
+var auditHeader = []string{"id", "created_at", "actor_id", "actor_email", "action", "target_id", "details"}
+
+// AuditCSV writes an audit log export one entry at a time, so long exports
+// are never held in memory. The header is written with the first entry, or
+// on Close when there are none.
+type AuditCSV struct {
+	cw      *csv.Writer
+	started bool
+	rows    int
+}
+
+// NewAuditCSV creates an audit log CSV writer
+func NewAuditCSV(w io.Writer) *AuditCSV {
+	return &AuditCSV{cw: csv.NewWriter(w)}
+}
+
+// Write appends an entry. Details are written as a JSON object.
+func (a *AuditCSV) Write(e *models.AuditEntry) error {
+	if err := a.start(); err != nil {
+		return err
+	}
+
+	details := ""
+	if len(e.Details) > 0 {
+		b, err := json.Marshal(e.Details)
+		if err != nil {
+			return err
+		}
+		details = string(b)
+	}
+	row := []string{e.ID.String(), e.CreatedAt.UTC().Format(time.RFC3339), "", "", string(e.Action), "", spreadsheetSafe(details)}
+	if e.ActorID != nil {
+		row[2] = e.ActorID.String()
+	}
+	if e.ActorEmail != nil {
+		row[3] = spreadsheetSafe(*e.ActorEmail)
+	}
+	if e.TargetID != nil {
+		row[5] = e.TargetID.String()
+	}
+
+	a.rows++
+	return a.cw.Write(row)
+}
+
+// Rows returns how many entries were written. The writer buffers, so nothing
+// has reached the underlying writer before the first entry.
+func (a *AuditCSV) Rows() int {
+	return a.rows
+}
+
+// Close writes anything buffered
+func (a *AuditCSV) Close() error {
+	if err := a.start(); err != nil {
+		return err
+	}
+	a.cw.Flush()
+	return a.cw.Error()
+}
+
+func (a *AuditCSV) start() error {
+	if a.started {
+		return nil
+	}
+	a.started = true
+	return a.cw.Write(auditHeader)
+}
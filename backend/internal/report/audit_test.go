@@ -0,0 +1,62 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestAuditCSV(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewAuditCSV(&buf)
+
+	actor, target := uuid.New(), uuid.New()
+	email := "owner@example.com"
+	entries := []*models.AuditEntry{
+		{ID: uuid.New(), ActorID: &actor, ActorEmail: &email, Action: models.AuditMemberSet, TargetID: &target,
+			Details: map[string]any{"role": "admin"}, CreatedAt: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)},
+		{ID: uuid.New(), Action: models.AuditPostReject, Details: map[string]any{"reason": "=cmd"}, CreatedAt: time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, e := range entries {
+		if err := out.Write(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := out.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || strings.Join(rows[0], ",") != strings.Join(auditHeader, ",") || out.Rows() != 2 {
+		t.Fatalf("rows = %v", rows)
+	}
+	want := []string{entries[0].ID.String(), "2024-05-01T09:00:00Z", actor.String(), email, "member.set", target.String(), `{"role":"admin"}`}
+	if strings.Join(rows[1], ",") != strings.Join(want, ",") {
+		t.Errorf("row = %v, want %v", rows[1], want)
+	}
+	if rows[2][2] != "" || rows[2][3] != "" || rows[2][5] != "" {
+		t.Errorf("entry without actor or target = %v", rows[2])
+	}
+}
+
+func TestAuditCSV_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewAuditCSV(&buf)
+	if buf.Len() != 0 {
+		t.Fatal("wrote before the first entry")
+	}
+	if err := out.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != strings.Join(auditHeader, ",") {
+		t.Errorf("empty export = %q, want only the header", got)
+	}
+}
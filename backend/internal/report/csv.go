@@ -0,0 +1,47 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// csvHeader names the columns of CSV exports
+var csvHeader = []string{"post_id", "channel", "published_at", "post", "impressions", "likes", "comments", "shares", "clicks", "engagement_rate"}
+
+// WriteCSV writes one row per post in the report. Engagement cells are empty
+// for posts without engagement reported.
+func WriteCSV(w io.Writer, r *models.AnalyticsReport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, p := range r.Posts {
+		row := []string{p.PostID.String(), string(p.Channel), p.PublishedAt.UTC().Format(time.RFC3339), spreadsheetSafe(p.Excerpt)}
+		if e := p.Engagement; e != nil {
+			row = append(row, itoa(e.Impressions), itoa(e.Likes), itoa(e.Comments), itoa(e.Shares), itoa(e.Clicks),
+				strconv.FormatFloat(e.Rate(), 'f', 4, 64))
+		} else {
+			row = append(row, "", "", "", "", "", "")
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// spreadsheetSafe keeps spreadsheets from running post text as a formula
+func spreadsheetSafe(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,123 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// PDF page layout, in points: A4 with a monospaced font so the tables line up
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	pageMargin   = 50
+	fontSize     = 8
+	lineHeight   = 11
+	linesPerPage = (pageHeight - 2*pageMargin) / lineHeight
+	// maxLineChars is how many Courier characters fit between the margins
+	maxLineChars = (pageWidth - 2*pageMargin) * 10 / (fontSize * 6)
+)
+
+// WritePDF writes the report as a PDF: its range, the totals and channel
+// table, then every post
+func WritePDF(w io.Writer, r *models.AnalyticsReport) error {
+	lines := []string{"Analytics report", Period(r), ""}
+	lines = append(lines, Summary(r)...)
+	if len(r.Posts) > 0 {
+		lines = append(lines, "", "")
+		lines = append(lines, Posts(r)...)
+	}
+
+	_, err := w.Write(renderPDF(lines))
+	return err
+}
+
+// renderPDF lays lines out on as many pages as they need. The document uses
+// the standard Courier font, so it embeds nothing.
+func renderPDF(lines []string) []byte {
+	var pages [][]string
+	for len(lines) > linesPerPage {
+		pages = append(pages, lines[:linesPerPage])
+		lines = lines[linesPerPage:]
+	}
+	pages = append(pages, lines)
+
+	// Objects 1 to 3 are the catalog, page tree and font, then each page is
+	// followed by its content stream
+	var objects [][]byte
+	kids := &bytes.Buffer{}
+	for i := range pages {
+		fmt.Fprintf(kids, "%d 0 R ", 4+2*i)
+	}
+	objects = append(objects,
+		[]byte("<< /Type /Catalog /Pages 2 0 R >>"),
+		[]byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", bytes.TrimSpace(kids.Bytes()), len(pages))),
+		[]byte("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>"),
+	)
+	for i, page := range pages {
+		content := &bytes.Buffer{}
+		// Each ' operator moves down a line before showing its text
+		fmt.Fprintf(content, "BT /F1 %d Tf %d TL %d %d Td\n", fontSize, lineHeight, pageMargin, pageHeight-pageMargin)
+		for _, line := range page {
+			content.WriteString("(")
+			content.Write(pdfString(line))
+			content.WriteString(") '\n")
+		}
+		content.WriteString("ET")
+
+		objects = append(objects,
+			[]byte(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, 5+2*i)),
+			[]byte(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.Bytes())),
+		)
+	}
+
+	out := &bytes.Buffer{}
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := out.Len()
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes()
+}
+
+// winAnsi maps the characters outside Latin-1 that WinAnsiEncoding has
+var winAnsi = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// pdfString encodes a line as the body of a PDF string literal, cut to fit
+// the page. Characters the font can't show become '?'.
+func pdfString(s string) []byte {
+	var b []byte
+	n := 0
+	for _, r := range s {
+		if n == maxLineChars {
+			break
+		}
+		n++
+
+		c, ok := winAnsi[r]
+		switch {
+		case ok:
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			c = byte(r)
+		default:
+			c = '?'
+		}
+		if c == '(' || c == ')' || c == '\\' {
+			b = append(b, '\\')
+		}
+		b = append(b, c)
+	}
+	return b
+}
@@ -0,0 +1,75 @@
+// Package report renders analytics reports as CSV, as PDF and as the plain
+// text of the monthly report email.
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+// dateFormat is how days are written in reports
+const dateFormat = "2 Jan 2006"
+
+// Period returns the report's range for people, with its exclusive end
+// shown as the last day covered
+func Period(r *models.AnalyticsReport) string {
+	last := r.To.AddDate(0, 0, -1)
+	if last.Before(r.From) {
+		last = r.From
+	}
+	return r.From.Format(dateFormat) + " - " + last.Format(dateFormat)
+}
+
+// Summary returns the report's totals and per-channel table as lines of
+// text, laid out for a monospaced font
+func Summary(r *models.AnalyticsReport) []string {
+	lines := []string{
+		fmt.Sprintf("Posts published: %d (%d with engagement reported)", r.Total.Posts, r.Total.Reported),
+		fmt.Sprintf("Impressions: %d   Interactions: %d   Engagement rate: %s",
+			r.Total.Impressions, r.Total.Interactions(), percent(r.Total.EngagementRate)),
+	}
+	if len(r.Channels) == 0 {
+		return lines
+	}
+
+	lines = append(lines, "", channelRow("Channel", "Posts", "Impressions", "Likes", "Comments", "Shares", "Clicks", "Rate"))
+	for _, c := range r.Channels {
+		lines = append(lines, channelRow(string(c.Channel), itoa(int64(c.Posts)), itoa(c.Impressions), itoa(c.Likes),
+			itoa(c.Comments), itoa(c.Shares), itoa(c.Clicks), percent(c.EngagementRate)))
+	}
+	return lines
+}
+
+// Posts returns one line of text per post in the report, laid out for a
+// monospaced font
+func Posts(r *models.AnalyticsReport) []string {
+	lines := []string{postRow("Published", "Channel", "Impr.", "Inter.", "Rate", "Post")}
+	for _, p := range r.Posts {
+		impressions, interactions, rate := "-", "-", "-"
+		if p.Engagement != nil {
+			impressions, interactions, rate = itoa(p.Engagement.Impressions), itoa(p.Engagement.Interactions()), percent(p.Engagement.Rate())
+		}
+		lines = append(lines, postRow(p.PublishedAt.Format("2006-01-02"), string(p.Channel), impressions, interactions, rate, p.Excerpt))
+	}
+	return lines
+}
+
+func channelRow(channel, posts, impressions, likes, comments, shares, clicks, rate string) string {
+	return fmt.Sprintf("%-16s %6s %12s %8s %9s %7s %7s %7s", channel, posts, impressions, likes, comments, shares, clicks, rate)
+}
+
+func postRow(date, channel, impressions, interactions, rate, excerpt string) string {
+	return strings.TrimRight(fmt.Sprintf("%-10s %-16s %9s %7s %7s  %s", date, channel, impressions, interactions, rate, excerpt), " ")
+}
+
+func itoa(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+// percent formats a rate such as 0.0325 as "3.25%"
+func percent(rate float64) string {
+	return strconv.FormatFloat(rate*100, 'f', 2, 64) + "%"
+}
@@ -0,0 +1,82 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func testReport(posts int) *models.AnalyticsReport {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	list := make([]*models.Post, posts)
+	for i := range list {
+		published := from.Add(time.Duration(i) * time.Hour)
+		list[i] = &models.Post{ID: uuid.New(), Channel: models.ChannelTwitter, Content: "Post (draft) — café", PublishedAt: &published}
+	}
+	list[0].Content = "=HYPERLINK(\"x\")"
+	list[0].Engagement = &models.Engagement{Impressions: 200, Likes: 4, Clicks: 6}
+	return models.NewAnalyticsReport(from, from.AddDate(0, 1, 0), list)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, testReport(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || strings.Join(rows[0], ",") != strings.Join(csvHeader, ",") {
+		t.Fatalf("rows = %v", rows)
+	}
+	if rows[1][3] != `'=HYPERLINK("x")` {
+		t.Errorf("post = %q, want formulas escaped", rows[1][3])
+	}
+	if rows[1][4] != "200" || rows[1][9] != "0.0500" {
+		t.Errorf("engagement = %v", rows[1][4:])
+	}
+	if rows[2][4] != "" {
+		t.Errorf("unreported engagement = %v, want empty cells", rows[2][4:])
+	}
+}
+
+func TestWritePDF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePDF(&buf, testReport(2*linesPerPage)); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "%PDF-1.4\n") || !strings.HasSuffix(out, "%%EOF\n") {
+		t.Errorf("not a PDF document:\n%.200s", out)
+	}
+	if n := strings.Count(out, "/Type /Page "); n != 3 {
+		t.Errorf("%d pages, want 3", n)
+	}
+	if !strings.Contains(out, `Post \(draft\) `+"\x97"+" caf\xe9") {
+		t.Error("post text not escaped and WinAnsi encoded")
+	}
+
+	// Every xref offset points at its object
+	xref := out[strings.LastIndex(out, "xref\n"):]
+	for i, line := range strings.Split(xref, "\n")[3:] {
+		if !strings.HasSuffix(line, " n ") {
+			break
+		}
+		offset, err := strconv.Atoi(line[:10])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := itoa(int64(i+1)) + " 0 obj"; !strings.HasPrefix(out[offset:], want) {
+			t.Errorf("xref entry %d points at %.20q", i+1, out[offset:])
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Package sanitize cleans user-written post text before it is stored. It
+// removes characters that are invisible to the reader but change what a post
+// says to a filter or a platform: zero-width spaces that split words past
+// keyword checks, bidirectional overrides that reorder text, and control
+// characters. Every change is reported so it can be shown to the author
+// rather than made silently.
+package sanitize
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Warning codes, one per kind of change
+const (
+	CodeControlCharacters   = "control_characters_removed"
+	CodeInvisibleCharacters = "invisible_characters_removed"
+	CodeUnsafeLinks         = "unsafe_links_removed"
+	CodeNormalized          = "unicode_normalized"
+)
+
+// Warning describes one kind of change Text made
+type Warning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// unsafeLink matches links with schemes that run script or embed content
+// when clicked: javascript: and vbscript: anywhere, data: with a media type,
+// and file: paths. The scheme must start a word that isn't part of a path,
+// and one followed by a space, as in "data: ...", is prose and left alone.
+// The first group is the character before the link, which is kept.
+var unsafeLink = regexp.MustCompile(`(?i)(^|[^\w/.:-])(?:(?:javascript|vbscript):\S+|data:[a-z]+/[a-z0-9.+-]+[;,]\S*|file:/\S*)`)
+
+// Text returns s with control characters, invisible formatting characters
+// and unsafe links removed and Unicode in composed form (NFC), and a warning
+// for each kind of change. Newlines and tabs are kept; CRLF becomes LF.
+func Text(s string) (string, []Warning) {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	var b strings.Builder
+	b.Grow(len(s))
+	controls, invisible := 0, 0
+	var prev rune
+	for _, r := range s {
+		switch {
+		case r == '\n', r == '\t':
+		case isControl(r):
+			controls++
+			continue
+		case isInvisible(r, prev):
+			invisible++
+			continue
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	s = b.String()
+
+	var warnings []Warning
+	if controls > 0 {
+		warnings = append(warnings, Warning{
+			Code:    CodeControlCharacters,
+			Message: fmt.Sprintf("Removed %s", plural(controls, "control character")),
+		})
+	}
+	if invisible > 0 {
+		warnings = append(warnings, Warning{
+			Code:    CodeInvisibleCharacters,
+			Message: fmt.Sprintf("Removed %s", plural(invisible, "zero-width or invisible formatting character")),
+		})
+	}
+
+	if links := unsafeLink.FindAllString(s, -1); len(links) > 0 {
+		s = unsafeLink.ReplaceAllString(s, "$1")
+		warnings = append(warnings, Warning{
+			Code:    CodeUnsafeLinks,
+			Message: fmt.Sprintf("Removed %s with a javascript:, vbscript:, data: or file: scheme", plural(len(links), "link")),
+		})
+	}
+
+	if !norm.NFC.IsNormalString(s) {
+		s = norm.NFC.String(s)
+		warnings = append(warnings, Warning{
+			Code:    CodeNormalized,
+			Message: "Combined accents with their letters (Unicode NFC)",
+		})
+	}
+
+	return s, warnings
+}
+
+// isControl reports C0 and C1 control characters and DEL
+func isControl(r rune) bool {
+	return r < 0x20 || (r >= 0x7F && r < 0xA0)
+}
+
+// isInvisible reports formatting characters that render as nothing. Bidi
+// marks (LRM, RLM, ALM) are kept as mixed-direction text needs them, and so
+// is the zero width non-joiner, which changes letter shapes in Persian and
+// Indic scripts. A zero width joiner is kept after a mark or symbol, where it
+// joins an emoji sequence or forms an Indic half letter, and dropped elsewhere.
+func isInvisible(r, prev rune) bool {
+	switch {
+	case r == 0x200B, r == 0x2060, r == 0xFEFF, r == 0x180E, r == 0x00AD: // Zero width space, word joiner, BOM, Mongolian vowel separator, soft hyphen
+		return true
+	case r >= 0x2061 && r <= 0x2064: // Invisible math operators
+		return true
+	case r >= 0x202A && r <= 0x202E, r >= 0x2066 && r <= 0x2069: // Bidi embeddings, overrides and isolates
+		return true
+	case r == 0x200D:
+		return !unicode.In(prev, unicode.Mn, unicode.Me, unicode.Sk, unicode.So)
+	}
+	return false
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
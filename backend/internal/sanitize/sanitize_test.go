@@ -0,0 +1,64 @@
+package sanitize
+
+import (
+	"reflect"
+	"testing"
+)
+
+func codes(warnings []Warning) []string {
+	var out []string
+	for _, w := range warnings {
+		out = append(out, w.Code)
+	}
+	return out
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		want  string
+		codes []string
+	}{
+		{"plain text", "Launch day!\n\tSee you there", "Launch day!\n\tSee you there", nil},
+		{"crlf", "one\r\ntwo", "one\ntwo", nil},
+		{"control characters", "bell\a and null\x00 and \u0085next", "bell and null and next", []string{CodeControlCharacters}},
+		{"zero width space", "fr\u200Bee mon\u200Bey", "free money", []string{CodeInvisibleCharacters}},
+		{"bidi override", "file\u202Etxt.exe", "filetxt.exe", []string{CodeInvisibleCharacters}},
+		{"bom and soft hyphen", "\uFEFFco\u00ADoperate", "cooperate", []string{CodeInvisibleCharacters}},
+		{"emoji zwj sequence", "\U0001F468\u200D\U0001F469\u200D\U0001F467", "\U0001F468\u200D\U0001F469\u200D\U0001F467", nil},
+		{"zwj after skin tone", "\U0001F469\U0001F3FD\u200D\U0001F4BB", "\U0001F469\U0001F3FD\u200D\U0001F4BB", nil},
+		{"zwj between letters", "pass\u200Dword", "password", []string{CodeInvisibleCharacters}},
+		{"zwnj and rlm kept", "می\u200Cخواهم\u200F", "می\u200Cخواهم\u200F", nil},
+		{"javascript link", "Click javascript:alert(1) now", "Click  now", []string{CodeUnsafeLinks}},
+		{"link split by zero width space", "java\u200Bscript:alert(1)", "", []string{CodeInvisibleCharacters, CodeUnsafeLinks}},
+		{"data link", "Open data:text/html;base64,PHNjcmlwdD4= please", "Open  please", []string{CodeUnsafeLinks}},
+		{"file link", "(file:///etc/passwd)", "(", []string{CodeUnsafeLinks}},
+		{"data as prose", "Key data: revenue grew", "Key data: revenue grew", nil},
+		{"https link kept", "Read https://example.com/javascript:guide", "Read https://example.com/javascript:guide", nil},
+		{"decomposed accents", "Cafe\u0301", "Café", []string{CodeNormalized}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, warnings := Text(tt.in)
+			if got != tt.want {
+				t.Errorf("Text(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if c := codes(warnings); !reflect.DeepEqual(c, tt.codes) {
+				t.Errorf("Text(%q) warnings = %v, want %v", tt.in, c, tt.codes)
+			}
+		})
+	}
+}
+
+func TestText_Messages(t *testing.T) {
+	_, warnings := Text("a\u200Bb\u200Bc\x00")
+	want := []Warning{
+		{Code: CodeControlCharacters, Message: "Removed 1 control character"},
+		{Code: CodeInvisibleCharacters, Message: "Removed 2 zero-width or invisible formatting characters"},
+	}
+	if !reflect.DeepEqual(warnings, want) {
+		t.Errorf("warnings = %+v, want %+v", warnings, want)
+	}
+}
@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+)
+
+// abTestBatchSize is the most A/B tests advanced per scan
+const abTestBatchSize = 100
+
+// RunABTests publishes variant B of tests whose variant A window has passed,
+// then picks winners of tests whose variant B window has passed, reposting
+// them when requested; run periodically by the cron runner
+func (w *Worker) RunABTests(ctx context.Context) error {
+	if w.paused(ctx) {
+		return nil
+	}
+
+	starting, err := w.db.GetABTestsToStart(ctx, abTestBatchSize)
+	if err != nil {
+		return fmt.Errorf("get A/B tests to start: %w", err)
+	}
+	for _, post := range starting {
+		variant, err := w.db.StartABVariant(ctx, post.ID)
+		if err != nil {
+			log.Printf("❌ Failed to start variant B of post %s: %v", post.ID, err)
+			continue
+		}
+		if variant == nil {
+			// Already started by another worker
+			continue
+		}
+		w.scheduleCopy(ctx, variant)
+		log.Printf("🧪 Scheduled variant B of post %s as %s", post.ID, variant.ID)
+	}
+
+	deciding, err := w.db.GetABTestsToDecide(ctx, abTestBatchSize)
+	if err != nil {
+		return fmt.Errorf("get A/B tests to decide: %w", err)
+	}
+	for _, post := range deciding {
+		w.decideABTest(ctx, post)
+	}
+	return nil
+}
+
+// decideABTest records the winner of a test and reposts it if requested
+func (w *Worker) decideABTest(ctx context.Context, post *models.Post) {
+	variant, err := w.db.GetABVariant(ctx, post.ID)
+	if err != nil || variant == nil {
+		log.Printf("❌ Failed to load variant B of post %s: %v", post.ID, err)
+		return
+	}
+
+	winner := models.ABInconclusive
+	if variant.Status == models.PostStatusPublished {
+		winner = models.CompareEngagement(post.Engagement, variant.Engagement)
+	}
+
+	decided, err := w.db.DecideABTest(ctx, post.ID, winner)
+	if err != nil {
+		log.Printf("❌ Failed to decide A/B test of post %s: %v", post.ID, err)
+		return
+	}
+	if !decided {
+		// Already decided by another worker
+		return
+	}
+	log.Printf("🧪 A/B test of post %s decided: %s", post.ID, winner)
+
+	if w.notifier != nil {
+		w.notifier.Notify(post.UserID, notifier.UpdateTypeUpdate)
+	}
+
+	if !post.ABTest.AutoRepost || (winner != models.ABVariantA && winner != models.ABVariantB) {
+		return
+	}
+	source := post
+	if winner == models.ABVariantB {
+		source = variant
+	}
+	repost, err := w.db.RepostPost(ctx, source.ID)
+	if err != nil || repost == nil {
+		log.Printf("❌ Failed to repost A/B test winner %s: %v", source.ID, err)
+		return
+	}
+	w.scheduleCopy(ctx, repost)
+	log.Printf("🧪 Reposted variant %s of post %s as %s", winner, post.ID, repost.ID)
+}
+
+// scheduleCopy queues a post the worker created and tells its owner about it
+func (w *Worker) scheduleCopy(ctx context.Context, post *models.Post) {
+	if err := w.queue.Enqueue(ctx, post.ID, post.ScheduledAt, post.Priority); err != nil {
+		log.Printf("⚠️ Failed to enqueue post %s: %v", post.ID, err)
+	}
+	if w.cache != nil {
+		_ = w.cache.InvalidatePost(ctx, post)
+	}
+	if w.notifier != nil {
+		w.notifier.Notify(post.UserID, notifier.UpdateTypeCreate)
+	}
+}
@@ -0,0 +1,31 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scheduler/backend/internal/db"
+)
+
+// AnalyticsRollup keeps the system-wide admin metrics up to date
+type AnalyticsRollup struct {
+	db db.Store
+}
+
+// NewAnalyticsRollup creates a new analytics rollup
+func NewAnalyticsRollup(database db.Store) *AnalyticsRollup {
+	return &AnalyticsRollup{
+		db: database,
+	}
+}
+
+// Run recounts the last few hours and yesterday onwards, so late writes and
+// retries still land in their buckets; meant to run periodically from cron
+func (a *AnalyticsRollup) Run(ctx context.Context) error {
+	now := time.Now()
+	if err := a.db.RollupSystemMetrics(ctx, now.Add(-3*time.Hour), now.AddDate(0, 0, -1)); err != nil {
+		return fmt.Errorf("roll up system metrics: %w", err)
+	}
+	return nil
+}
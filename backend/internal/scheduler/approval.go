@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+)
+
+// approvalBatchSize is the most pending posts handled per scan
+const approvalBatchSize = 100
+
+// ApprovalEscalation is what happens to a post still pending close to its scheduled time
+type ApprovalEscalation string
+
+const (
+	EscalateNone         ApprovalEscalation = "none"
+	EscalateNotifyOwners ApprovalEscalation = "notify_owners" // Remind organization owners directly
+	EscalateReject       ApprovalEscalation = "reject"        // Reject the post and tell its author
+)
+
+// ApprovalPolicy configures reminders for posts pending approval
+type ApprovalPolicy struct {
+	ReminderWindow   time.Duration // Remind approvers once a post is due within this window
+	EscalationWindow time.Duration // Escalate once a post is due within this window
+	Escalation       ApprovalEscalation
+}
+
+// ApprovalMonitor reminds organization approvers about pending posts as their
+// scheduled time approaches, and escalates posts left pending too long
+type ApprovalMonitor struct {
+	db       db.Store
+	notifier *notifier.Notifier
+	dispatch *Dispatcher
+	policy   ApprovalPolicy
+}
+
+// NewApprovalMonitor creates a new approval monitor
+func NewApprovalMonitor(database db.Store, n *notifier.Notifier, jobs *JobQueue, policy ApprovalPolicy) *ApprovalMonitor {
+	return &ApprovalMonitor{
+		db:       database,
+		notifier: n,
+		dispatch: NewDispatcher(n, jobs),
+		policy:   policy,
+	}
+}
+
+// Run sends due reminders and escalations; meant to run periodically from cron.
+// Each post is reminded and escalated at most once.
+func (m *ApprovalMonitor) Run(ctx context.Context) error {
+	now := time.Now()
+
+	reminders, err := m.db.ClaimApprovalReminders(ctx, now.Add(m.policy.ReminderWindow), approvalBatchSize)
+	if err != nil {
+		return fmt.Errorf("claim approval reminders: %w", err)
+	}
+	for _, post := range reminders {
+		m.notifyApprovers(ctx, post, models.OrgRole.CanApprove,
+			"Post awaiting your approval",
+			fmt.Sprintf("A post scheduled for %s is waiting for approval.", post.ScheduledAt.UTC().Format(time.RFC1123)))
+	}
+
+	if m.policy.Escalation == EscalateNone {
+		return nil
+	}
+
+	escalations, err := m.db.ClaimApprovalEscalations(ctx, now.Add(m.policy.EscalationWindow), approvalBatchSize)
+	if err != nil {
+		return fmt.Errorf("claim approval escalations: %w", err)
+	}
+	for _, post := range escalations {
+		m.escalate(ctx, post)
+	}
+
+	if len(reminders) > 0 || len(escalations) > 0 {
+		log.Printf("⏰ Sent %d approval reminders and %d escalations", len(reminders), len(escalations))
+	}
+	return nil
+}
+
+// escalate applies the escalation policy to a post still pending approval
+func (m *ApprovalMonitor) escalate(ctx context.Context, post *models.Post) {
+	switch m.policy.Escalation {
+	case EscalateNotifyOwners:
+		m.notifyApprovers(ctx, post, func(r models.OrgRole) bool { return r == models.OrgRoleOwner },
+			"Urgent: post still awaiting approval",
+			fmt.Sprintf("A post scheduled for %s is still waiting for approval and will not publish until it is approved.",
+				post.ScheduledAt.UTC().Format(time.RFC1123)))
+
+	case EscalateReject:
+		rejected, err := m.db.RejectPost(ctx, post.ID, "Not approved before its scheduled time")
+		if err != nil {
+			log.Printf("❌ Failed to auto-reject post %s: %v", post.ID, err)
+			return
+		}
+		if rejected == nil {
+			return // Reviewed in the meantime
+		}
+		m.notifier.Notify(post.UserID, notifier.UpdateTypeApproval)
+
+		author, err := m.db.GetUserByID(ctx, post.UserID)
+		if err != nil || author == nil {
+			log.Printf("⚠️ Failed to look up author of post %s: %v", post.ID, err)
+			return
+		}
+		m.dispatch.Send(ctx, author, Notification{
+			Event:   models.NotifyApproval,
+			PostID:  &post.ID,
+			Subject: "Your post was not approved in time",
+			Body: fmt.Sprintf("Your post scheduled for %s was rejected because it was not approved before its scheduled time.",
+				post.ScheduledAt.UTC().Format(time.RFC1123)),
+		})
+	}
+}
+
+// notifyApprovers notifies each member of the post's organization whose role
+// matches, over the channels their preferences route approvals to
+func (m *ApprovalMonitor) notifyApprovers(ctx context.Context, post *models.Post, match func(models.OrgRole) bool, subject, body string) {
+	members, err := m.db.ListOrganizationMembers(ctx, *post.OrgID)
+	if err != nil {
+		log.Printf("❌ Failed to list approvers for post %s: %v", post.ID, err)
+		return
+	}
+
+	for _, member := range members {
+		if !match(member.Role) {
+			continue
+		}
+		approver, err := m.db.GetUserByID(ctx, member.UserID)
+		if err != nil || approver == nil {
+			log.Printf("⚠️ Failed to look up approver %s of post %s: %v", member.UserID, post.ID, err)
+			continue
+		}
+		m.dispatch.Send(ctx, approver, Notification{
+			Event:   models.NotifyApproval,
+			PostID:  &post.ID,
+			Subject: subject,
+			Body:    body,
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/clock"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// BackoffStore shares platform rate limit backoffs between workers. While a
+// user's channel is backing off, none of their posts to it are published.
+// Each backoff stores when it ends; its Redis TTL only cleans it up.
+type BackoffStore struct {
+	redis *redis.Client
+	clock clock.Clock
+}
+
+// NewBackoffStore creates a new backoff store
+func NewBackoffStore(redisClient *redis.Client, clk clock.Clock) *BackoffStore {
+	return &BackoffStore{
+		redis: redisClient,
+		clock: clk,
+	}
+}
+
+func backoffKey(userID uuid.UUID, c models.Channel) string {
+	return fmt.Sprintf("publish:backoff:%s:%s", userID, c)
+}
+
+// Until returns when the user's channel may be published to again, or the
+// zero time if it isn't backing off
+func (s *BackoffStore) Until(ctx context.Context, userID uuid.UUID, c models.Channel) (time.Time, error) {
+	value, err := s.redis.Get(ctx, backoffKey(userID, c)).Result()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil || !until.After(s.clock.Now()) {
+		return time.Time{}, nil
+	}
+	return until, nil
+}
+
+// Extend backs the user's channel off until the given time, unless it is
+// already backing off for longer
+func (s *BackoffStore) Extend(ctx context.Context, userID uuid.UUID, c models.Channel, until time.Time) error {
+	current, err := s.Until(ctx, userID, c)
+	if err != nil {
+		return err
+	}
+	if !until.After(current) {
+		return nil
+	}
+	return s.redis.Set(ctx, backoffKey(userID, c), until.UTC().Format(time.RFC3339), until.Sub(s.clock.Now())).Err()
+}
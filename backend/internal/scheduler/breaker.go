@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/scheduler/backend/internal/metrics"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// CircuitBreaker stops publishing to a channel whose platform keeps failing.
+// After threshold consecutive failures the channel's circuit opens for cooldown
+// and its posts are deferred. Once the cooldown passes, the next post is let
+// through as a trial: success closes the circuit, failure reopens it.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	channels map[models.Channel]*circuitState
+}
+
+type circuitState struct {
+	failures  int
+	openUntil time.Time
+}
+
+// NewCircuitBreaker creates a per-channel circuit breaker. A threshold of zero disables it.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		channels:  make(map[models.Channel]*circuitState),
+	}
+}
+
+// Allow reports whether a post may be published to the channel now. When the
+// circuit is open it returns false and the time the cooldown ends.
+func (b *CircuitBreaker) Allow(c models.Channel, now time.Time) (bool, time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.channels[c]
+	if !ok || !now.Before(state.openUntil) {
+		return true, time.Time{}
+	}
+	return false, state.openUntil
+}
+
+// Open returns the channels whose circuit is open at now, with when each cooldown ends
+func (b *CircuitBreaker) Open(now time.Time) map[models.Channel]time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	open := make(map[models.Channel]time.Time)
+	for c, state := range b.channels {
+		if now.Before(state.openUntil) {
+			open[c] = state.openUntil
+		}
+	}
+	return open
+}
+
+// RecordSuccess closes the channel's circuit
+func (b *CircuitBreaker) RecordSuccess(c models.Channel) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.channels[c]; ok {
+		delete(b.channels, c)
+		metrics.CircuitOpen.WithLabelValues(string(c)).Set(0)
+	}
+}
+
+// RecordFailure counts a failed publish and reports whether it opened the circuit
+func (b *CircuitBreaker) RecordFailure(c models.Channel, now time.Time) bool {
+	if b.threshold <= 0 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.channels[c]
+	if !ok {
+		state = &circuitState{}
+		b.channels[c] = state
+	}
+	state.failures++
+
+	if state.failures < b.threshold {
+		return false
+	}
+	state.openUntil = now.Add(b.cooldown)
+	metrics.CircuitOpen.WithLabelValues(string(c)).Set(1)
+	return true
+}
@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := NewCircuitBreaker(3, time.Minute)
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		if b.RecordFailure(models.ChannelTwitter, now) {
+			t.Fatalf("Circuit opened after %d failures, threshold is 3", i+1)
+		}
+	}
+	if !b.RecordFailure(models.ChannelTwitter, now) {
+		t.Fatal("Expected circuit to open at threshold")
+	}
+
+	if ok, until := b.Allow(models.ChannelTwitter, now.Add(30*time.Second)); ok || !until.Equal(now.Add(time.Minute)) {
+		t.Errorf("Expected channel to be blocked until cooldown ends, got ok=%v until=%v", ok, until)
+	}
+	if ok, _ := b.Allow(models.ChannelLinkedIn, now); !ok {
+		t.Error("Expected other channels to be unaffected")
+	}
+
+	// Trial after cooldown fails: circuit reopens immediately
+	trial := now.Add(time.Minute)
+	if ok, _ := b.Allow(models.ChannelTwitter, trial); !ok {
+		t.Fatal("Expected a trial publish after cooldown")
+	}
+	if !b.RecordFailure(models.ChannelTwitter, trial) {
+		t.Error("Expected failed trial to reopen the circuit")
+	}
+
+	// Successful publish closes the circuit
+	b.RecordSuccess(models.ChannelTwitter)
+	if ok, _ := b.Allow(models.ChannelTwitter, trial); !ok {
+		t.Error("Expected circuit to close after success")
+	}
+}
+
+func TestCircuitBreaker_Disabled(t *testing.T) {
+	b := NewCircuitBreaker(0, time.Minute)
+	for i := 0; i < 10; i++ {
+		if b.RecordFailure(models.ChannelTwitter, time.Now()) {
+			t.Fatal("Disabled breaker should never open")
+		}
+	}
+}
+
+func TestCircuitBreaker_Open(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Minute)
+	now := time.Now()
+
+	if open := b.Open(now); len(open) != 0 {
+		t.Fatalf("Expected no open circuits, got %v", open)
+	}
+
+	b.RecordFailure(models.ChannelTwitter, now)
+	open := b.Open(now)
+	if until, ok := open[models.ChannelTwitter]; !ok || !until.Equal(now.Add(time.Minute)) {
+		t.Errorf("Expected Twitter open until %v, got %v", now.Add(time.Minute), open)
+	}
+	if len(b.Open(now.Add(time.Minute))) != 0 {
+		t.Error("Expected circuit to be reported closed after cooldown")
+	}
+}
@@ -0,0 +1,19 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scheduler/backend/internal/mailer"
+)
+
+// EmailJobHandler returns the handler for email.send jobs, whose payload is a mailer.Message
+func EmailJobHandler(m *mailer.Mailer) JobHandler {
+	return func(ctx context.Context, job *Job) error {
+		var msg mailer.Message
+		if err := job.Decode(&msg); err != nil {
+			return fmt.Errorf("decode email payload: %w", err)
+		}
+		return m.Send(msg)
+	}
+}
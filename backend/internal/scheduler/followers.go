@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/audience"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// followerBatchSize is the most connections whose followers are counted per run
+const followerBatchSize = 200
+
+// FollowerPoll records the follower count of every connected account once a
+// day (UTC), for growth charts
+type FollowerPoll struct {
+	db       db.Store
+	counters *audience.Registry
+	now      func() time.Time
+}
+
+// NewFollowerPoll creates a new follower poll
+func NewFollowerPoll(database db.Store, counters *audience.Registry) *FollowerPoll {
+	return &FollowerPoll{
+		db:       database,
+		counters: counters,
+		now:      time.Now,
+	}
+}
+
+// Run counts the followers of connections not polled today; meant to run
+// periodically from cron. A connection whose count fails is retried next run,
+// and one whose platform reports no count is left until tomorrow.
+func (p *FollowerPoll) Run(ctx context.Context) error {
+	day := p.now().UTC().Truncate(24 * time.Hour)
+	conns, err := p.db.GetConnectionsForFollowerPoll(ctx, p.counters.Channels(), day, followerBatchSize)
+	if err != nil {
+		return fmt.Errorf("get connections for follower poll: %w", err)
+	}
+	if len(conns) == 0 {
+		return nil
+	}
+
+	var counts []models.FollowerCount
+	polled := make([]uuid.UUID, 0, len(conns))
+	for _, conn := range conns {
+		followers, err := p.counters.Followers(ctx, conn)
+		if err != nil {
+			log.Printf("⚠️ [AUDIENCE] Failed to count %s followers of user %s: %v", conn.Channel, conn.UserID, err)
+			continue
+		}
+		polled = append(polled, conn.ID)
+		if followers != nil {
+			counts = append(counts, models.FollowerCount{
+				UserID:      conn.UserID,
+				Channel:     conn.Channel,
+				AccountName: conn.AccountName,
+				Day:         day,
+				Followers:   *followers,
+			})
+		}
+	}
+
+	if err := p.db.SaveFollowerCounts(ctx, day, polled, counts); err != nil {
+		return fmt.Errorf("save follower counts: %w", err)
+	}
+	if len(counts) > 0 {
+		log.Printf("👥 [AUDIENCE] Recorded the followers of %d accounts", len(counts))
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/audience"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/models"
+)
+
+type counterFunc func(ctx context.Context, conn *models.ChannelConnection) (*int64, error)
+
+func (f counterFunc) Followers(ctx context.Context, conn *models.ChannelConnection) (*int64, error) {
+	return f(ctx, conn)
+}
+
+func TestFollowerPoll_Run(t *testing.T) {
+	now := time.Date(2024, 6, 1, 15, 30, 0, 0, time.UTC)
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	counted := &models.ChannelConnection{ID: uuid.New(), UserID: uuid.New(), Channel: models.ChannelTwitter}
+	silent := &models.ChannelConnection{ID: uuid.New(), UserID: uuid.New(), Channel: models.ChannelFacebook}
+	failing := &models.ChannelConnection{ID: uuid.New(), UserID: uuid.New(), Channel: models.ChannelYouTube}
+
+	var saved []models.FollowerCount
+	var polled []uuid.UUID
+	store := &dbmock.Store{
+		GetConnectionsForFollowerPollFunc: func(ctx context.Context, channels []models.Channel, d time.Time, limit int) ([]*models.ChannelConnection, error) {
+			if !d.Equal(day) {
+				t.Errorf("day = %v, want %v", d, day)
+			}
+			return []*models.ChannelConnection{counted, silent, failing}, nil
+		},
+		SaveFollowerCountsFunc: func(ctx context.Context, d time.Time, ids []uuid.UUID, counts []models.FollowerCount) error {
+			polled, saved = ids, counts
+			return nil
+		},
+	}
+
+	counters := audience.NewRegistry()
+	counters.Register(models.ChannelTwitter, counterFunc(func(ctx context.Context, conn *models.ChannelConnection) (*int64, error) {
+		n := int64(1200)
+		return &n, nil
+	}))
+	counters.Register(models.ChannelYouTube, counterFunc(func(ctx context.Context, conn *models.ChannelConnection) (*int64, error) {
+		return nil, errors.New("401 Unauthorized")
+	}))
+
+	p := NewFollowerPoll(store, counters)
+	p.now = func() time.Time { return now }
+	if err := p.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(saved) != 1 || saved[0].UserID != counted.UserID || saved[0].Followers != 1200 || !saved[0].Day.Equal(day) {
+		t.Errorf("saved %+v, want the one reported count", saved)
+	}
+	if len(polled) != 2 || polled[0] != counted.ID || polled[1] != silent.ID {
+		t.Errorf("polled %v, want every connection but the failing one", polled)
+	}
+}
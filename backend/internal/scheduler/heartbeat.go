@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/models"
+)
+
+const (
+	heartbeatKeyPrefix = "worker:heartbeat:"
+	// minHeartbeatTTL keeps heartbeats of fast-polling workers around long enough to be seen
+	minHeartbeatTTL = 30 * time.Second
+)
+
+// Heartbeat is a worker's periodically reported status
+type Heartbeat struct {
+	InstanceID      string    `json:"instance_id"`
+	Hostname        string    `json:"hostname"`
+	StartedAt       time.Time `json:"started_at"`
+	LastTick        time.Time `json:"last_tick"`
+	Interval        string    `json:"interval"`
+	PostsProcessed  int64     `json:"posts_processed"`
+	PublishFailures int64     `json:"publish_failures"`
+	LagSeconds      float64   `json:"lag_seconds"` // How late the most recently published post went out
+	Paused          bool      `json:"paused"`      // Maintenance mode is on
+	Alive           bool      `json:"alive"`
+
+	// Channels whose publishing circuit is open, with when it may close
+	OpenCircuits map[models.Channel]time.Time `json:"open_circuits,omitempty"`
+}
+
+// HeartbeatStore reads and writes worker heartbeats in Redis
+type HeartbeatStore struct {
+	redis *redis.Client
+}
+
+// NewHeartbeatStore creates a new heartbeat store
+func NewHeartbeatStore(redisClient *redis.Client) *HeartbeatStore {
+	return &HeartbeatStore{
+		redis: redisClient,
+	}
+}
+
+// Write stores a heartbeat that expires after ttl unless refreshed
+func (s *HeartbeatStore) Write(ctx context.Context, hb Heartbeat, ttl time.Duration) error {
+	data, err := json.Marshal(hb)
+	if err != nil {
+		return err
+	}
+	return s.redis.Set(ctx, heartbeatKeyPrefix+hb.InstanceID, data, ttl).Err()
+}
+
+// List returns the heartbeats of all workers seen recently, oldest first.
+// A worker is alive if it ticked within three polling intervals.
+func (s *HeartbeatStore) List(ctx context.Context) ([]Heartbeat, error) {
+	var keys []string
+	iter := s.redis.Scan(ctx, 0, heartbeatKeyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	heartbeats := []Heartbeat{}
+	if len(keys) == 0 {
+		return heartbeats, nil
+	}
+
+	values, err := s.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, v := range values {
+		data, ok := v.(string)
+		if !ok {
+			continue // Expired between SCAN and MGET
+		}
+		var hb Heartbeat
+		if err := json.Unmarshal([]byte(data), &hb); err != nil {
+			continue
+		}
+		interval, _ := time.ParseDuration(hb.Interval)
+		hb.Alive = now.Sub(hb.LastTick) < 3*interval
+		heartbeats = append(heartbeats, hb)
+	}
+
+	sort.Slice(heartbeats, func(i, j int) bool {
+		return heartbeats[i].StartedAt.Before(heartbeats[j].StartedAt)
+	})
+	return heartbeats, nil
+}
+
+// workerStats holds counters updated by the worker between heartbeats
+type workerStats struct {
+	processed atomic.Int64
+	failed    atomic.Int64 // Failed publish attempts, including retried ones
+	lagMillis atomic.Int64
+	paused    atomic.Bool
+}
+
+// newInstanceID returns a unique identifier for this worker process
+func newInstanceID() (string, string) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8]), hostname
+}
@@ -0,0 +1,105 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/inbox"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+)
+
+const (
+	// inboxBatchSize is the most posts whose replies are pulled per run
+	inboxBatchSize = 200
+	// inboxSyncInterval is how long a post's replies are left before they
+	// are pulled again
+	inboxSyncInterval = 10 * time.Minute
+)
+
+// InboxSync pulls replies and mentions on recently published posts into
+// their authors' inboxes
+type InboxSync struct {
+	db       db.Store
+	fetchers *inbox.Registry
+	notifier *notifier.Notifier
+	now      func() time.Time
+}
+
+// NewInboxSync creates a new inbox sync
+func NewInboxSync(database db.Store, fetchers *inbox.Registry, n *notifier.Notifier) *InboxSync {
+	return &InboxSync{
+		db:       database,
+		fetchers: fetchers,
+		notifier: n,
+		now:      time.Now,
+	}
+}
+
+// Run pulls the replies of the posts published in the last
+// models.InboxSyncWindow that are due a sync, least recently synced first,
+// and tells each author with new items their unread count; meant to run
+// periodically from cron. A post whose fetch fails is retried next run.
+func (s *InboxSync) Run(ctx context.Context) error {
+	now := s.now()
+	posts, err := s.db.GetPostsForInboxSync(ctx, s.fetchers.Channels(), now.Add(-models.InboxSyncWindow), now.Add(-inboxSyncInterval), inboxBatchSize)
+	if err != nil {
+		return fmt.Errorf("get posts for inbox sync: %w", err)
+	}
+	if len(posts) == 0 {
+		return nil
+	}
+
+	var items []models.InboxItem
+	synced := make([]uuid.UUID, 0, len(posts))
+	for _, post := range posts {
+		fetched, err := s.fetchers.Fetch(ctx, post)
+		if err != nil {
+			log.Printf("⚠️ [INBOX] Failed to fetch replies of post %s: %v", post.ID, err)
+			continue
+		}
+		items = append(items, fetched...)
+		synced = append(synced, post.ID)
+	}
+
+	added, err := s.db.AddInboxItems(ctx, items)
+	if err != nil {
+		return fmt.Errorf("add inbox items: %w", err)
+	}
+	if err := s.db.MarkInboxSynced(ctx, synced, now); err != nil {
+		return fmt.Errorf("mark inbox synced: %w", err)
+	}
+	if added > 0 {
+		log.Printf("📥 [INBOX] Pulled %d new items from %d posts", added, len(synced))
+		notifyInbox(ctx, s.db, s.notifier, items)
+	}
+	return nil
+}
+
+// notifyInbox sends the users of the fetched items their unread count. Items
+// that were already in the inbox are counted towards New, which is a hint
+// for the client to refresh rather than an exact count.
+func notifyInbox(ctx context.Context, database db.Store, n *notifier.Notifier, items []models.InboxItem) {
+	if n == nil {
+		return
+	}
+
+	fetched := make(map[uuid.UUID]int)
+	for _, item := range items {
+		fetched[item.UserID]++
+	}
+	for userID, count := range fetched {
+		unread, err := database.CountUnreadInbox(ctx, userID)
+		if err != nil {
+			log.Printf("⚠️ [INBOX] Failed to count unread items of user %s: %v", userID, err)
+			continue
+		}
+		data, _ := json.Marshal(models.InboxCounts{Unread: unread, New: count})
+		n.NotifyData(userID, notifier.UpdateTypeInbox, data)
+	}
+}
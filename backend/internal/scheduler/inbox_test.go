@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/inbox"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+)
+
+type fetcherFunc func(ctx context.Context, post *models.Post) ([]models.InboxItem, error)
+
+func (f fetcherFunc) Fetch(ctx context.Context, post *models.Post) ([]models.InboxItem, error) {
+	return f(ctx, post)
+}
+
+func TestInboxSync_Run(t *testing.T) {
+	userID := uuid.New()
+	replied := &models.Post{ID: uuid.New(), UserID: userID, Channel: models.ChannelTwitter}
+	failing := &models.Post{ID: uuid.New(), UserID: userID, Channel: models.ChannelReddit}
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var added []models.InboxItem
+	var synced []uuid.UUID
+	store := &dbmock.Store{
+		GetPostsForInboxSyncFunc: func(ctx context.Context, channels []models.Channel, publishedSince, syncedBefore time.Time, limit int) ([]*models.Post, error) {
+			if !publishedSince.Equal(now.Add(-models.InboxSyncWindow)) || !syncedBefore.Equal(now.Add(-inboxSyncInterval)) {
+				t.Errorf("published since %v, synced before %v", publishedSince, syncedBefore)
+			}
+			return []*models.Post{replied, failing}, nil
+		},
+		AddInboxItemsFunc: func(ctx context.Context, items []models.InboxItem) (int, error) {
+			added = items
+			return len(items), nil
+		},
+		MarkInboxSyncedFunc: func(ctx context.Context, postIDs []uuid.UUID, at time.Time) error {
+			synced = postIDs
+			return nil
+		},
+		CountUnreadInboxFunc: func(ctx context.Context, uid uuid.UUID) (int, error) {
+			return 5, nil
+		},
+	}
+
+	fetchers := inbox.NewRegistry()
+	fetchers.Register(models.ChannelTwitter, fetcherFunc(func(ctx context.Context, post *models.Post) ([]models.InboxItem, error) {
+		return []models.InboxItem{
+			{UserID: post.UserID, PostID: &post.ID, ExternalID: "1"},
+			{UserID: post.UserID, PostID: &post.ID, ExternalID: "2"},
+		}, nil
+	}))
+	fetchers.Register(models.ChannelReddit, fetcherFunc(func(ctx context.Context, post *models.Post) ([]models.InboxItem, error) {
+		return nil, errors.New("503 Service Unavailable")
+	}))
+
+	n := notifier.NewNotifier(nil)
+	updates := n.Subscribe(userID)
+	s := NewInboxSync(store, fetchers, n)
+	s.now = func() time.Time { return now }
+
+	if err := s.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(added) != 2 {
+		t.Errorf("added %d items, want 2", len(added))
+	}
+	if len(synced) != 1 || synced[0] != replied.ID {
+		t.Errorf("synced %v, want only the post whose fetch succeeded", synced)
+	}
+
+	select {
+	case update := <-updates:
+		var counts models.InboxCounts
+		if err := json.Unmarshal(update.Data, &counts); err != nil {
+			t.Fatal(err)
+		}
+		if update.Type != notifier.UpdateTypeInbox || counts != (models.InboxCounts{Unread: 5, New: 2}) {
+			t.Errorf("update = %s %+v", update.Type, counts)
+		}
+	default:
+		t.Error("author not notified")
+	}
+}
@@ -0,0 +1,184 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	jobsScheduledKey = "jobs:scheduled"
+	jobsPayloadKey   = "jobs:payloads"
+	jobsDeadKey      = "jobs:dead"
+
+	// MaxJobAttempts is how many times a job runs before it is dead-lettered
+	MaxJobAttempts = 5
+	// unhandledJobDelay is how long a job waits when no handler is registered
+	// for its type, e.g. while a newer worker version rolls out
+	unhandledJobDelay = time.Minute
+	// maxDeadJobs caps the dead-letter list
+	maxDeadJobs = 1000
+)
+
+// JobType identifies the kind of background work a job does
+type JobType string
+
+// Job types. Handlers for each are registered on the worker at startup.
+const (
+	JobPostPublish    JobType = "post.publish"
+	JobEmailSend      JobType = "email.send"
+	JobAnalyticsFetch JobType = "analytics.fetch"
+	JobMediaProcess   JobType = "media.process"
+	JobWebhookSend    JobType = "webhook.send"
+)
+
+// Job is a unit of delayed background work
+type Job struct {
+	ID        string          `json:"id"`
+	Type      JobType         `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Decode unmarshals the job's payload into v
+func (j *Job) Decode(v any) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// JobHandler runs a job; returning an error retries it with backoff
+type JobHandler func(ctx context.Context, job *Job) error
+
+// JobQueue stores typed delayed jobs in Redis: a ZSET of job IDs scored by
+// run time and a hash of job bodies
+type JobQueue struct {
+	redis *redis.Client
+}
+
+// NewJobQueue creates a new job queue
+func NewJobQueue(redisClient *redis.Client) *JobQueue {
+	return &JobQueue{
+		redis: redisClient,
+	}
+}
+
+// Enqueue schedules a job of the given type to run at runAt
+func (q *JobQueue) Enqueue(ctx context.Context, jobType JobType, payload any, runAt time.Time) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("encode %s payload: %w", jobType, err)
+	}
+
+	job := &Job{
+		ID:        uuid.NewString(),
+		Type:      jobType,
+		Payload:   data,
+		CreatedAt: time.Now(),
+	}
+	return job.ID, q.schedule(ctx, job, runAt)
+}
+
+// schedule stores the job body and (re)adds it to the ZSET
+func (q *JobQueue) schedule(ctx context.Context, job *Job, runAt time.Time) error {
+	body, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, jobsPayloadKey, job.ID, body)
+		pipe.ZAdd(ctx, jobsScheduledKey, redis.Z{
+			Score:  float64(runAt.Unix()),
+			Member: job.ID,
+		})
+		return nil
+	})
+	return err
+}
+
+// Claim removes up to maxCount due jobs from the queue and returns them.
+// A job is only returned to the worker whose ZREM removed it.
+func (q *JobQueue) Claim(ctx context.Context, maxCount int) ([]*Job, error) {
+	ids, err := q.redis.ZRangeByScore(ctx, jobsScheduledKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   fmt.Sprintf("%d", time.Now().Unix()),
+		Count: int64(maxCount),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*Job
+	for _, id := range ids {
+		removed, err := q.redis.ZRem(ctx, jobsScheduledKey, id).Result()
+		if err != nil || removed == 0 {
+			continue
+		}
+
+		body, err := q.redis.HGet(ctx, jobsPayloadKey, id).Result()
+		if err != nil {
+			log.Printf("⚠️ Job %s has no body, dropping: %v", id, err)
+			continue
+		}
+
+		var job Job
+		if err := json.Unmarshal([]byte(body), &job); err != nil {
+			log.Printf("⚠️ Job %s has an invalid body, dropping: %v", id, err)
+			q.redis.HDel(ctx, jobsPayloadKey, id)
+			continue
+		}
+		jobs = append(jobs, &job)
+	}
+
+	return jobs, nil
+}
+
+// Complete deletes a finished job's body
+func (q *JobQueue) Complete(ctx context.Context, job *Job) error {
+	return q.redis.HDel(ctx, jobsPayloadKey, job.ID).Err()
+}
+
+// Retry records a failed attempt and reschedules the job with exponential
+// backoff, dead-lettering it after MaxJobAttempts
+func (q *JobQueue) Retry(ctx context.Context, job *Job, jobErr error) error {
+	job.Attempts++
+	job.LastError = jobErr.Error()
+
+	if job.Attempts >= MaxJobAttempts {
+		return q.bury(ctx, job)
+	}
+	return q.schedule(ctx, job, time.Now().Add(jobBackoff(job.Attempts)))
+}
+
+// Defer reschedules a job without counting an attempt
+func (q *JobQueue) Defer(ctx context.Context, job *Job, runAt time.Time) error {
+	return q.schedule(ctx, job, runAt)
+}
+
+// bury moves a job to the dead-letter list for inspection
+func (q *JobQueue) bury(ctx context.Context, job *Job) error {
+	body, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HDel(ctx, jobsPayloadKey, job.ID)
+		pipe.LPush(ctx, jobsDeadKey, body)
+		pipe.LTrim(ctx, jobsDeadKey, 0, maxDeadJobs-1)
+		return nil
+	})
+	return err
+}
+
+// jobBackoff returns the delay before retry attempt n: 30s, 1m, 2m, 4m, ...
+func jobBackoff(attempt int) time.Duration {
+	return time.Duration(math.Pow(2, float64(attempt-1))) * 30 * time.Second
+}
@@ -0,0 +1,121 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/scheduler/backend/internal/metrics"
+	"github.com/scheduler/backend/internal/models"
+)
+
+const (
+	// lagAlertCooldown limits alerts to one per channel in this window
+	lagAlertCooldown  = 5 * time.Minute
+	lagWebhookTimeout = 10 * time.Second
+)
+
+// LagAlert is the payload posted to the lag alert webhook
+type LagAlert struct {
+	Alert            string    `json:"alert"`
+	PostID           string    `json:"post_id"`
+	Channel          string    `json:"channel"`
+	ScheduledAt      time.Time `json:"scheduled_at"`
+	PublishedAt      time.Time `json:"published_at"`
+	LagSeconds       float64   `json:"lag_seconds"`
+	ThresholdSeconds float64   `json:"threshold_seconds"`
+}
+
+// LagMonitor records publish lag metrics and alerts when lag exceeds a threshold
+type LagMonitor struct {
+	threshold  time.Duration
+	webhookURL string
+	client     *http.Client
+
+	mu        sync.Mutex
+	lastAlert map[models.Channel]time.Time
+}
+
+// NewLagMonitor creates a lag monitor. A zero threshold disables alerts; alerts
+// are always logged and also posted to webhookURL when set.
+func NewLagMonitor(threshold time.Duration, webhookURL string) *LagMonitor {
+	return &LagMonitor{
+		threshold:  threshold,
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: lagWebhookTimeout},
+		lastAlert:  make(map[models.Channel]time.Time),
+	}
+}
+
+// Observe records the lag of a published post and alerts if it is over the threshold
+func (m *LagMonitor) Observe(post *models.Post, publishedAt time.Time) {
+	lag := publishedAt.Sub(post.ScheduledAt)
+	if lag < 0 {
+		lag = 0
+	}
+	metrics.PublishLag.WithLabelValues(string(post.Channel)).Observe(lag.Seconds())
+
+	if m.threshold <= 0 || lag <= m.threshold || !m.shouldAlert(post.Channel, publishedAt) {
+		return
+	}
+
+	metrics.LagAlerts.WithLabelValues(string(post.Channel)).Inc()
+	log.Printf("🚨 Publish lag alert: post %s on %s went out %v late (threshold %v)",
+		post.ID, post.Channel, lag.Round(time.Second), m.threshold)
+
+	if m.webhookURL != "" {
+		go m.sendWebhook(LagAlert{
+			Alert:            "publish_lag",
+			PostID:           post.ID.String(),
+			Channel:          string(post.Channel),
+			ScheduledAt:      post.ScheduledAt,
+			PublishedAt:      publishedAt,
+			LagSeconds:       lag.Seconds(),
+			ThresholdSeconds: m.threshold.Seconds(),
+		})
+	}
+}
+
+// shouldAlert reports whether an alert may fire for the channel, starting its cooldown if so
+func (m *LagMonitor) shouldAlert(channel models.Channel, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if last, ok := m.lastAlert[channel]; ok && now.Sub(last) < lagAlertCooldown {
+		return false
+	}
+	m.lastAlert[channel] = now
+	return true
+}
+
+func (m *LagMonitor) sendWebhook(alert LagAlert) {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lagWebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("⚠️ Failed to build lag alert webhook request: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		log.Printf("⚠️ Failed to send lag alert webhook: %v", err)
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		log.Printf("⚠️ Lag alert webhook returned %s", resp.Status)
+	}
+}
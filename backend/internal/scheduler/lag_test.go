@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestLagMonitor_ShouldAlertCooldown(t *testing.T) {
+	m := NewLagMonitor(time.Minute, "")
+	now := time.Now()
+
+	if !m.shouldAlert(models.ChannelTwitter, now) {
+		t.Error("Expected first alert to fire")
+	}
+	if m.shouldAlert(models.ChannelTwitter, now.Add(time.Minute)) {
+		t.Error("Expected alert within cooldown to be suppressed")
+	}
+	if !m.shouldAlert(models.ChannelLinkedIn, now.Add(time.Minute)) {
+		t.Error("Expected cooldown to be tracked per channel")
+	}
+	if !m.shouldAlert(models.ChannelTwitter, now.Add(lagAlertCooldown+time.Second)) {
+		t.Error("Expected alert after cooldown to fire")
+	}
+}
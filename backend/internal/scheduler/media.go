@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/media"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// mediaTranscodeTimeout bounds transcoding one rendition
+const mediaTranscodeTimeout = 10 * time.Minute
+
+// MediaPayload is the payload of a media.process job
+type MediaPayload struct {
+	MediaID uuid.UUID `json:"media_id"`
+	UserID  uuid.UUID `json:"user_id"`
+}
+
+// MediaJobHandler returns the handler for media.process jobs, which make
+// the renditions of an uploaded video that it doesn't have yet. Media
+// deleted since upload is skipped.
+func MediaJobHandler(database db.Store, files *media.Store, transcoder media.Transcoder) JobHandler {
+	return func(ctx context.Context, job *Job) error {
+		var payload MediaPayload
+		if err := job.Decode(&payload); err != nil {
+			return fmt.Errorf("decode media payload: %w", err)
+		}
+
+		items, err := database.GetMediaByIDs(ctx, payload.UserID, []uuid.UUID{payload.MediaID})
+		if err != nil {
+			return fmt.Errorf("load media %s: %w", payload.MediaID, err)
+		}
+		item, ok := items[payload.MediaID]
+		if !ok || !models.IsVideoContentType(item.ContentType) {
+			return nil
+		}
+
+		src, err := files.Path(item.StorageKey)
+		if err != nil {
+			return err
+		}
+
+		renditions := item.Renditions
+		made := false
+		for _, r := range media.VideoRenditions {
+			if _, ok := item.Rendition(r.Name); ok {
+				continue
+			}
+			key := media.RenditionKey(item.StorageKey, r.Name)
+			dst, err := files.Path(key)
+			if err != nil {
+				return err
+			}
+
+			tctx, cancel := context.WithTimeout(ctx, mediaTranscodeTimeout)
+			err = transcoder.Transcode(tctx, src, dst, r)
+			cancel()
+			if err != nil {
+				return fmt.Errorf("transcode media %s: %w", item.ID, err)
+			}
+
+			renditions = append(renditions, models.MediaRendition{
+				Name:        r.Name,
+				URL:         models.MediaURL(key),
+				ContentType: "video/mp4",
+				Width:       r.Width,
+				Height:      r.Height,
+			})
+			made = true
+		}
+		if !made {
+			return nil
+		}
+		return database.SetMediaRenditions(ctx, item.ID, renditions)
+	}
+}
@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/media"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// fakeTranscoder copies the source to each rendition
+type fakeTranscoder struct {
+	calls []string
+}
+
+func (f *fakeTranscoder) Transcode(ctx context.Context, src, dst string, r media.Rendition) error {
+	f.calls = append(f.calls, r.Name)
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0o644)
+}
+
+func TestMediaJobHandler(t *testing.T) {
+	files, err := media.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	userID := uuid.New()
+	video := &models.Media{ID: uuid.New(), UserID: userID, StorageKey: "uploads/u/clip.mp4", ContentType: "video/mp4"}
+	image := &models.Media{ID: uuid.New(), UserID: userID, StorageKey: "uploads/u/photo.png", ContentType: "image/png"}
+	if err := files.Put(video.StorageKey, []byte("video")); err != nil {
+		t.Fatal(err)
+	}
+
+	var saved []models.MediaRendition
+	store := &dbmock.Store{
+		GetMediaByIDsFunc: func(ctx context.Context, uid uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Media, error) {
+			out := map[uuid.UUID]*models.Media{}
+			for _, m := range []*models.Media{video, image} {
+				if m.ID == ids[0] && m.UserID == uid {
+					out[m.ID] = m
+				}
+			}
+			return out, nil
+		},
+		SetMediaRenditionsFunc: func(ctx context.Context, id uuid.UUID, renditions []models.MediaRendition) error {
+			if id != video.ID {
+				t.Errorf("renditions saved for %s", id)
+			}
+			saved = renditions
+			return nil
+		},
+	}
+	transcoder := &fakeTranscoder{}
+	handler := MediaJobHandler(store, files, transcoder)
+
+	run := func(m *models.Media) {
+		t.Helper()
+		payload, _ := json.Marshal(MediaPayload{MediaID: m.ID, UserID: m.UserID})
+		if err := handler(context.Background(), &Job{Type: JobMediaProcess, Payload: payload}); err != nil {
+			t.Fatalf("handler() error = %v", err)
+		}
+	}
+
+	run(video)
+	if len(saved) != 1 || saved[0].Name != media.RenditionVertical || saved[0].URL != "/media/uploads/u/clip-vertical.mp4" {
+		t.Fatalf("saved renditions = %+v", saved)
+	}
+	if _, err := os.Stat(mustPath(t, files, "uploads/u/clip-vertical.mp4")); err != nil {
+		t.Errorf("rendition file: %v", err)
+	}
+
+	// Renditions already made and images are skipped
+	video.Renditions = saved
+	transcoder.calls = nil
+	run(video)
+	run(image)
+	if len(transcoder.calls) != 0 {
+		t.Errorf("transcoded again: %v", transcoder.calls)
+	}
+}
+
+func mustPath(t *testing.T, files *media.Store, key string) string {
+	t.Helper()
+	p, err := files.Path(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
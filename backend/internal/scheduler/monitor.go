@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/inbox"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+)
+
+const (
+	// monitorBatchSize is the most keyword monitors polled per run
+	monitorBatchSize = 100
+	// monitorPollInterval is how long a keyword monitor is left before its
+	// channels are searched again
+	monitorPollInterval = 15 * time.Minute
+	// monitorLookback is how far back a new monitor's first search reaches
+	monitorLookback = 24 * time.Hour
+)
+
+// KeywordMonitorSync searches platforms for the keywords and handles users
+// monitor and pulls the matches into their inboxes
+type KeywordMonitorSync struct {
+	db        db.Store
+	searchers *inbox.Registry
+	notifier  *notifier.Notifier
+	now       func() time.Time
+}
+
+// NewKeywordMonitorSync creates a new keyword monitor sync
+func NewKeywordMonitorSync(database db.Store, searchers *inbox.Registry, n *notifier.Notifier) *KeywordMonitorSync {
+	return &KeywordMonitorSync{
+		db:        database,
+		searchers: searchers,
+		notifier:  n,
+		now:       time.Now,
+	}
+}
+
+// Run searches each channel of the monitors due a poll for posts since the
+// monitor's last poll, least recently polled first, and tells each user with
+// new matches their unread count; meant to run periodically from cron. A
+// monitor with a failed search is left for the next run, and its matches on
+// other channels are skipped as duplicates then.
+func (s *KeywordMonitorSync) Run(ctx context.Context) error {
+	now := s.now()
+	monitors, err := s.db.GetKeywordMonitorsToPoll(ctx, now.Add(-monitorPollInterval), monitorBatchSize)
+	if err != nil {
+		return fmt.Errorf("get keyword monitors to poll: %w", err)
+	}
+	if len(monitors) == 0 {
+		return nil
+	}
+
+	var items []models.InboxItem
+	polled := make([]uuid.UUID, 0, len(monitors))
+	for _, m := range monitors {
+		since := now.Add(-monitorLookback)
+		if m.LastPolledAt != nil {
+			since = *m.LastPolledAt
+		}
+
+		failed := false
+		for _, channel := range m.Channels {
+			matches, err := s.searchers.Search(ctx, channel, m, since)
+			if err != nil {
+				log.Printf("⚠️ [INBOX] Failed to search %s for monitor %s: %v", channel, m.ID, err)
+				failed = true
+				continue
+			}
+			items = append(items, matches...)
+		}
+		if !failed {
+			polled = append(polled, m.ID)
+		}
+	}
+
+	added, err := s.db.AddInboxItems(ctx, items)
+	if err != nil {
+		return fmt.Errorf("add inbox items: %w", err)
+	}
+	if len(polled) > 0 {
+		if err := s.db.MarkKeywordMonitorsPolled(ctx, polled, now); err != nil {
+			return fmt.Errorf("mark keyword monitors polled: %w", err)
+		}
+	}
+	if added > 0 {
+		log.Printf("🔎 [INBOX] Pulled %d new keyword matches from %d monitors", added, len(monitors))
+		notifyInbox(ctx, s.db, s.notifier, items)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/inbox"
+	"github.com/scheduler/backend/internal/models"
+)
+
+type searcherFunc func(ctx context.Context, monitor *models.KeywordMonitor, since time.Time) ([]models.InboxItem, error)
+
+func (f searcherFunc) Search(ctx context.Context, monitor *models.KeywordMonitor, since time.Time) ([]models.InboxItem, error) {
+	return f(ctx, monitor, since)
+}
+
+func TestKeywordMonitorSync_Run(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	lastPolled := now.Add(-time.Hour)
+	fresh := &models.KeywordMonitor{ID: uuid.New(), UserID: uuid.New(), Query: "acme", Channels: []models.Channel{models.ChannelTwitter}}
+	polled := &models.KeywordMonitor{ID: uuid.New(), UserID: uuid.New(), Query: "@acme", Channels: []models.Channel{models.ChannelTwitter, models.ChannelReddit}, LastPolledAt: &lastPolled}
+
+	var added []models.InboxItem
+	var marked []uuid.UUID
+	store := &dbmock.Store{
+		GetKeywordMonitorsToPollFunc: func(ctx context.Context, polledBefore time.Time, limit int) ([]*models.KeywordMonitor, error) {
+			if !polledBefore.Equal(now.Add(-monitorPollInterval)) {
+				t.Errorf("polled before %v", polledBefore)
+			}
+			return []*models.KeywordMonitor{fresh, polled}, nil
+		},
+		AddInboxItemsFunc: func(ctx context.Context, items []models.InboxItem) (int, error) {
+			added = items
+			return len(items), nil
+		},
+		MarkKeywordMonitorsPolledFunc: func(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+			marked = ids
+			return nil
+		},
+		CountUnreadInboxFunc: func(ctx context.Context, uid uuid.UUID) (int, error) {
+			return 1, nil
+		},
+	}
+
+	searches := make(map[uuid.UUID]time.Time)
+	searchers := inbox.NewRegistry()
+	searchers.RegisterSearcher(models.ChannelTwitter, searcherFunc(func(ctx context.Context, m *models.KeywordMonitor, since time.Time) ([]models.InboxItem, error) {
+		searches[m.ID] = since
+		return []models.InboxItem{{UserID: m.UserID, MonitorID: &m.ID, Kind: models.InboxKindKeyword, ExternalID: "1"}}, nil
+	}))
+	searchers.RegisterSearcher(models.ChannelReddit, searcherFunc(func(ctx context.Context, m *models.KeywordMonitor, since time.Time) ([]models.InboxItem, error) {
+		return nil, errors.New("429 Too Many Requests")
+	}))
+
+	s := NewKeywordMonitorSync(store, searchers, nil)
+	s.now = func() time.Time { return now }
+
+	if err := s.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !searches[fresh.ID].Equal(now.Add(-monitorLookback)) || !searches[polled.ID].Equal(lastPolled) {
+		t.Errorf("searched since %v, want the lookback for a new monitor and the last poll otherwise", searches)
+	}
+	if len(added) != 2 {
+		t.Errorf("added %d items, want 2", len(added))
+	}
+	if len(marked) != 1 || marked[0] != fresh.ID {
+		t.Errorf("marked %v polled, want only the monitor whose searches succeeded", marked)
+	}
+}
@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/mailer"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+)
+
+// inAppUpdateTypes is the SSE event sent for each notification event
+var inAppUpdateTypes = map[models.NotificationEvent]notifier.UpdateType{
+	models.NotifyPublishSuccess: notifier.UpdateTypePublish,
+	models.NotifyPublishFailure: notifier.UpdateTypeFailure,
+	models.NotifyApproval:       notifier.UpdateTypeApproval,
+	models.NotifyDigest:         notifier.UpdateTypeDigest,
+}
+
+// Notification tells a user about one event
+type Notification struct {
+	Event   models.NotificationEvent
+	PostID  *uuid.UUID // Set for events about a single post
+	Subject string
+	Body    string
+
+	Attachments []mailer.Attachment // Sent with the email only
+}
+
+// Dispatcher delivers notifications over the channels the user's notification
+// preferences route them to: an SSE event, a queued email and a queued POST to
+// the account's webhook URL. It doesn't refresh the user's post lists; callers
+// that changed a post still notify for that.
+type Dispatcher struct {
+	notifier *notifier.Notifier
+	jobs     *JobQueue
+}
+
+// NewDispatcher creates a new notification dispatcher
+func NewDispatcher(n *notifier.Notifier, jobs *JobQueue) *Dispatcher {
+	return &Dispatcher{
+		notifier: n,
+		jobs:     jobs,
+	}
+}
+
+// Send delivers the notification to user
+func (d *Dispatcher) Send(ctx context.Context, user *models.User, n Notification) {
+	route := user.NotificationPreferences.Route(n.Event)
+
+	if route.InApp && d.notifier != nil {
+		if n.PostID != nil {
+			d.notifier.NotifyPost(user.ID, inAppUpdateTypes[n.Event], *n.PostID)
+		} else {
+			d.notifier.Notify(user.ID, inAppUpdateTypes[n.Event])
+		}
+	}
+
+	if route.Email && d.jobs != nil {
+		msg := mailer.Message{To: user.Email, Subject: n.Subject, Body: n.Body, Attachments: n.Attachments}
+		if _, err := d.jobs.Enqueue(ctx, JobEmailSend, msg, time.Now()); err != nil {
+			log.Printf("❌ Failed to queue %s email to user %s: %v", n.Event, user.ID, err)
+		}
+	}
+
+	if route.Webhook && user.ReminderWebhookURL != nil && d.jobs != nil {
+		body, err := json.Marshal(models.NotificationWebhook{
+			Event:   n.Event,
+			PostID:  n.PostID,
+			Subject: n.Subject,
+			Message: n.Body,
+			SentAt:  time.Now().UTC(),
+		})
+		if err != nil {
+			log.Printf("❌ Failed to encode %s webhook for user %s: %v", n.Event, user.ID, err)
+			return
+		}
+		payload := WebhookPayload{URL: *user.ReminderWebhookURL, Body: body}
+		if _, err := d.jobs.Enqueue(ctx, JobWebhookSend, payload, time.Now()); err != nil {
+			log.Printf("❌ Failed to queue %s webhook for user %s: %v", n.Event, user.ID, err)
+		}
+	}
+}
+
+// SendAccountNotice tells user about a change to their account, such as a
+// suspension, by email and in-app regardless of their preferences
+func (d *Dispatcher) SendAccountNotice(ctx context.Context, user *models.User, subject, body string) {
+	if d.notifier != nil {
+		d.notifier.Notify(user.ID, notifier.UpdateTypeAccount)
+	}
+
+	if d.jobs != nil {
+		msg := mailer.Message{To: user.Email, Subject: subject, Body: body}
+		if _, err := d.jobs.Enqueue(ctx, JobEmailSend, msg, time.Now()); err != nil {
+			log.Printf("❌ Failed to queue account notice to user %s: %v", user.ID, err)
+		}
+	}
+}
@@ -3,87 +3,334 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/clock"
+	"github.com/scheduler/backend/internal/db"
 )
 
-const scheduledPostsKey = "posts:scheduled"
+const (
+	scheduledPostsKey = "posts:scheduled"
+	priorityPostsKey  = "posts:scheduled:priority"
+	remindersKey      = "posts:reminders"
 
-// Queue manages the Redis-based scheduling queue
+	// claimsKey records which worker claimed each post it took off a lane,
+	// until it is done with the post. Claims of workers whose lease has expired
+	// are reaped back onto their lane.
+	claimsKey           = "posts:claims"
+	claimLeaseKeyPrefix = "posts:claims:lease:"
+
+	// normalLaneShare reserves at least 1 in every normalLaneShare claimed slots
+	// for the normal lane, so priority posts can't starve it
+	normalLaneShare = 4
+)
+
+// Queue manages the Redis-based scheduling queue. Posts live in one of two
+// lanes: priority (paid plans, publish-now) and normal.
 type Queue struct {
 	redis *redis.Client
+	clock clock.Clock // Decides which posts and reminders are due
 }
 
 // NewQueue creates a new scheduling queue
-func NewQueue(redisClient *redis.Client) *Queue {
+func NewQueue(redisClient *redis.Client, clk clock.Clock) *Queue {
 	return &Queue{
 		redis: redisClient,
+		clock: clk,
 	}
 }
 
-// Enqueue adds a post to the scheduling queue
-func (q *Queue) Enqueue(ctx context.Context, postID uuid.UUID, scheduledAt time.Time) error {
-	return q.redis.ZAdd(ctx, scheduledPostsKey, redis.Z{
-		Score:  float64(scheduledAt.Unix()),
-		Member: postID.String(),
-	}).Err()
+// laneKeys returns the key of the post's lane and of the other lane
+func laneKeys(priority bool) (string, string) {
+	if priority {
+		return priorityPostsKey, scheduledPostsKey
+	}
+	return scheduledPostsKey, priorityPostsKey
 }
 
-// Remove removes a post from the scheduling queue
+// Enqueue adds a post to the scheduling queue in the given lane,
+// moving it out of the other lane if it was there
+func (q *Queue) Enqueue(ctx context.Context, postID uuid.UUID, scheduledAt time.Time, priority bool) error {
+	lane, other := laneKeys(priority)
+	_, err := q.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, lane, redis.Z{
+			Score:  float64(scheduledAt.Unix()),
+			Member: postID.String(),
+		})
+		pipe.ZRem(ctx, other, postID.String())
+		return nil
+	})
+	return err
+}
+
+// Remove removes a post and its reminder from the scheduling queue
 func (q *Queue) Remove(ctx context.Context, postID uuid.UUID) error {
-	return q.redis.ZRem(ctx, scheduledPostsKey, postID.String()).Err()
+	_, err := q.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, scheduledPostsKey, postID.String())
+		pipe.ZRem(ctx, priorityPostsKey, postID.String())
+		pipe.ZRem(ctx, remindersKey, postID.String())
+		return nil
+	})
+	return err
 }
 
 // Update updates a post's scheduled time in the queue
-func (q *Queue) Update(ctx context.Context, postID uuid.UUID, scheduledAt time.Time) error {
-	// ZADD with XX flag only updates if member exists
-	// But for simplicity, we just re-add (ZADD updates score if member exists)
-	return q.Enqueue(ctx, postID, scheduledAt)
+func (q *Queue) Update(ctx context.Context, postID uuid.UUID, scheduledAt time.Time, priority bool) error {
+	// ZADD updates the score if the member exists
+	return q.Enqueue(ctx, postID, scheduledAt, priority)
 }
 
-// GetDuePosts retrieves posts that are due for publishing
-// Uses atomic ZPOPMIN-like behavior to prevent duplicate processing
-func (q *Queue) GetDuePosts(ctx context.Context, maxCount int) ([]uuid.UUID, error) {
-	now := time.Now().Unix()
-
-	// Get posts with scores <= now
-	results, err := q.redis.ZRangeByScoreWithScores(ctx, scheduledPostsKey, &redis.ZRangeBy{
-		Min:   "-inf",
-		Max:   fmt.Sprintf("%d", now),
-		Count: int64(maxCount),
-	}).Result()
+// GetDuePosts retrieves posts that are due for publishing, priority lane first.
+// When both lanes have due posts, part of the batch is reserved for the normal lane.
+// Uses atomic ZPOPMIN-like behavior to prevent duplicate processing. Each post
+// is recorded as claimed by workerID until ReleaseClaim; the worker must hold
+// a lease from RenewLease.
+func (q *Queue) GetDuePosts(ctx context.Context, workerID string, maxCount int) ([]uuid.UUID, error) {
+	now := fmt.Sprintf("%d", q.clock.Now().Unix())
 
+	priorityDue, err := q.dueMembers(ctx, priorityPostsKey, now, maxCount)
+	if err != nil {
+		return nil, err
+	}
+	normalDue, err := q.dueMembers(ctx, scheduledPostsKey, now, maxCount)
 	if err != nil {
 		return nil, err
 	}
 
+	fromPriority, fromNormal := splitBatch(len(priorityDue), len(normalDue), maxCount)
+
+	postIDs := q.claimPosts(ctx, workerID, true, priorityDue[:fromPriority])
+	postIDs = append(postIDs, q.claimPosts(ctx, workerID, false, normalDue[:fromNormal])...)
+	return postIDs, nil
+}
+
+// dueMembers returns up to maxCount members of a lane with scores <= now
+func (q *Queue) dueMembers(ctx context.Context, key, now string, maxCount int) ([]string, error) {
+	return q.redis.ZRangeByScore(ctx, key, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   now,
+		Count: int64(maxCount),
+	}).Result()
+}
+
+// claim removes members from a lane, returning the IDs this worker won
+func (q *Queue) claim(ctx context.Context, key string, members []string) []uuid.UUID {
 	var postIDs []uuid.UUID
-	for _, result := range results {
-		postIDStr, ok := result.Member.(string)
-		if !ok {
+	for _, member := range members {
+		postID, err := uuid.Parse(member)
+		if err != nil {
 			continue
 		}
 
-		postID, err := uuid.Parse(postIDStr)
+		// Try to remove atomically - if removal fails, another worker got it
+		removed, err := q.redis.ZRem(ctx, key, member).Result()
+		if err != nil || removed == 0 {
+			continue
+		}
+
+		postIDs = append(postIDs, postID)
+	}
+	return postIDs
+}
+
+// claimScript moves a post from a lane into the claim ledger, returning 1 if
+// this caller won it
+var claimScript = redis.NewScript(`
+if redis.call("ZREM", KEYS[1], ARGV[1]) == 1 then
+	redis.call("HSET", KEYS[2], ARGV[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// claimPosts removes posts from a lane and records them as claimed by the
+// worker, returning the IDs this worker won
+func (q *Queue) claimPosts(ctx context.Context, workerID string, priority bool, members []string) []uuid.UUID {
+	lane, _ := laneKeys(priority)
+	claim := claimValue(workerID, priority)
+
+	var postIDs []uuid.UUID
+	for _, member := range members {
+		postID, err := uuid.Parse(member)
 		if err != nil {
 			continue
 		}
 
-		// Try to remove atomically - if removal fails, another worker got it
-		removed, err := q.redis.ZRem(ctx, scheduledPostsKey, postIDStr).Result()
-		if err != nil || removed == 0 {
+		won, err := claimScript.Run(ctx, q.redis, []string{lane, claimsKey}, member, claim).Int()
+		if err != nil || won == 0 {
 			continue
 		}
 
 		postIDs = append(postIDs, postID)
 	}
+	return postIDs
+}
 
-	return postIDs, nil
+// claimValue records the claiming worker and the post's lane
+func claimValue(workerID string, priority bool) string {
+	if priority {
+		return workerID + "|priority"
+	}
+	return workerID + "|normal"
+}
+
+// parseClaim returns the claiming worker and the post's lane from a claim value
+func parseClaim(v string) (string, bool) {
+	i := strings.LastIndex(v, "|")
+	if i < 0 {
+		return v, false
+	}
+	return v[:i], v[i+1:] == "priority"
+}
+
+// RenewLease keeps the worker's claims from being reaped for ttl. Workers
+// renew it while they work through claimed posts.
+func (q *Queue) RenewLease(ctx context.Context, workerID string, ttl time.Duration) error {
+	return q.redis.Set(ctx, claimLeaseKeyPrefix+workerID, q.clock.Now().UTC().Format(time.RFC3339), ttl).Err()
+}
+
+// ReleaseClaim removes a post from the claim ledger once the worker is done
+// with it, whether it was published, re-queued or failed
+func (q *Queue) ReleaseClaim(ctx context.Context, postID uuid.UUID) error {
+	return q.redis.HDel(ctx, claimsKey, postID.String()).Err()
+}
+
+// reapScript puts a claimed post back on its lane, due now, unless the claim
+// changed in the meantime
+var reapScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], ARGV[1]) == ARGV[2] then
+	redis.call("HDEL", KEYS[1], ARGV[1])
+	redis.call("ZADD", KEYS[2], "NX", ARGV[3], ARGV[1])
+	return 1
+end
+return 0
+`)
+
+// ReapClaims re-queues posts claimed by workers whose lease has expired,
+// i.e. that crashed or hung before finishing them. Returns how many posts
+// were re-queued.
+func (q *Queue) ReapClaims(ctx context.Context) (int, error) {
+	claims, err := q.redis.HGetAll(ctx, claimsKey).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	alive := make(map[string]bool)
+	reaped := 0
+	for member, claim := range claims {
+		workerID, priority := parseClaim(claim)
+		live, checked := alive[workerID]
+		if !checked {
+			n, err := q.redis.Exists(ctx, claimLeaseKeyPrefix+workerID).Result()
+			if err != nil {
+				return reaped, err
+			}
+			live = n > 0
+			alive[workerID] = live
+		}
+		if live {
+			continue
+		}
+
+		lane, _ := laneKeys(priority)
+		n, err := reapScript.Run(ctx, q.redis, []string{claimsKey, lane}, member, claim, q.clock.Now().Unix()).Int()
+		if err != nil {
+			return reaped, err
+		}
+		reaped += n
+	}
+	return reaped, nil
+}
+
+// splitBatch decides how many due posts to take from each lane for a batch of
+// maxCount. Priority posts go first, but when normal posts are waiting at least
+// maxCount/normalLaneShare (minimum one) slots go to them.
+func splitBatch(priorityDue, normalDue, maxCount int) (int, int) {
+	reserved := maxCount / normalLaneShare
+	if reserved < 1 {
+		reserved = 1
+	}
+	if reserved > normalDue {
+		reserved = normalDue
+	}
+
+	fromPriority := min(priorityDue, maxCount-reserved)
+	fromNormal := min(normalDue, maxCount-fromPriority)
+	return fromPriority, fromNormal
+}
+
+// EnqueueMissing adds posts that are in neither lane nor claimed by a worker,
+// leaving queued posts untouched. Returns how many posts were added.
+func (q *Queue) EnqueueMissing(ctx context.Context, refs []db.QueuedPostRef) (int, error) {
+	if len(refs) == 0 {
+		return 0, nil
+	}
+
+	normal := make([]*redis.FloatCmd, len(refs))
+	priority := make([]*redis.FloatCmd, len(refs))
+	claimed := make([]*redis.BoolCmd, len(refs))
+	_, err := q.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, ref := range refs {
+			normal[i] = pipe.ZScore(ctx, scheduledPostsKey, ref.ID.String())
+			priority[i] = pipe.ZScore(ctx, priorityPostsKey, ref.ID.String())
+			claimed[i] = pipe.HExists(ctx, claimsKey, ref.ID.String())
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return 0, err
+	}
+
+	added := 0
+	_, err = q.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, ref := range refs {
+			if normal[i].Err() != redis.Nil || priority[i].Err() != redis.Nil || claimed[i].Val() {
+				continue // Already queued or in flight (or lookup failed; leave it alone)
+			}
+			lane, _ := laneKeys(ref.Priority)
+			pipe.ZAddNX(ctx, lane, redis.Z{
+				Score:  float64(ref.RunAt.Unix()),
+				Member: ref.ID.String(),
+			})
+			added++
+		}
+		return nil
+	})
+	return added, err
+}
+
+// ScheduleReminder sets when a post's reminder is due, replacing any earlier
+// time. Reminders are a second timing wheel alongside the publishing lanes.
+func (q *Queue) ScheduleReminder(ctx context.Context, postID uuid.UUID, remindAt time.Time) error {
+	return q.redis.ZAdd(ctx, remindersKey, redis.Z{
+		Score:  float64(remindAt.Unix()),
+		Member: postID.String(),
+	}).Err()
+}
+
+// RemoveReminder cancels a post's pending reminder
+func (q *Queue) RemoveReminder(ctx context.Context, postID uuid.UUID) error {
+	return q.redis.ZRem(ctx, remindersKey, postID.String()).Err()
+}
+
+// GetDueReminders claims up to maxCount posts whose reminders are due
+func (q *Queue) GetDueReminders(ctx context.Context, maxCount int) ([]uuid.UUID, error) {
+	due, err := q.dueMembers(ctx, remindersKey, fmt.Sprintf("%d", q.clock.Now().Unix()), maxCount)
+	if err != nil {
+		return nil, err
+	}
+	return q.claim(ctx, remindersKey, due), nil
 }
 
 // GetQueueLength returns the number of items in the scheduling queue
 func (q *Queue) GetQueueLength(ctx context.Context) (int64, error) {
-	return q.redis.ZCard(ctx, scheduledPostsKey).Result()
+	normal, err := q.redis.ZCard(ctx, scheduledPostsKey).Result()
+	if err != nil {
+		return 0, err
+	}
+	priority, err := q.redis.ZCard(ctx, priorityPostsKey).Result()
+	return normal + priority, err
 }
@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/clock"
+)
+
+func TestSplitBatch(t *testing.T) {
+	tests := []struct {
+		name                     string
+		priorityDue, normalDue   int
+		maxCount                 int
+		wantPriority, wantNormal int
+	}{
+		{"only normal", 0, 50, 100, 0, 50},
+		{"only priority", 150, 0, 100, 100, 0},
+		{"both fit", 30, 40, 100, 30, 40},
+		{"priority flood reserves normal share", 100, 100, 100, 75, 25},
+		{"few normal posts take only what they need", 100, 10, 100, 90, 10},
+		{"small batch still reserves one slot", 5, 5, 2, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, n := splitBatch(tt.priorityDue, tt.normalDue, tt.maxCount)
+			if p != tt.wantPriority || n != tt.wantNormal {
+				t.Errorf("splitBatch(%d, %d, %d) = (%d, %d), want (%d, %d)",
+					tt.priorityDue, tt.normalDue, tt.maxCount, p, n, tt.wantPriority, tt.wantNormal)
+			}
+		})
+	}
+}
+
+func TestJobBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{4, 4 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := jobBackoff(tt.attempt); got != tt.want {
+			t.Errorf("jobBackoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestClaimValue(t *testing.T) {
+	tests := []struct {
+		workerID string
+		priority bool
+	}{
+		{"host-1234-abcd", false},
+		{"host-1234-abcd", true},
+		{"host|with|pipes", true},
+	}
+
+	for _, tt := range tests {
+		workerID, priority := parseClaim(claimValue(tt.workerID, tt.priority))
+		if workerID != tt.workerID || priority != tt.priority {
+			t.Errorf("parseClaim(claimValue(%q, %v)) = (%q, %v)", tt.workerID, tt.priority, workerID, priority)
+		}
+	}
+}
+
+// benchRedis connects to the Redis named by BENCH_REDIS_URL, skipping the
+// benchmark without one. The database is flushed, so point it at a throwaway
+// instance such as redis-test from docker-compose.test.yml.
+func benchRedis(b *testing.B) *redis.Client {
+	addr := os.Getenv("BENCH_REDIS_URL")
+	if addr == "" {
+		b.Skip("BENCH_REDIS_URL not set")
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	b.Cleanup(func() { client.Close() })
+	if err := client.FlushDB(context.Background()).Err(); err != nil {
+		b.Fatalf("Failed to flush benchmark Redis: %v", err)
+	}
+	return client
+}
+
+func BenchmarkQueue_GetDuePosts(b *testing.B) {
+	client := benchRedis(b)
+	ctx := context.Background()
+	clk := clock.NewFake(time.Date(2030, 1, 15, 12, 0, 0, 0, time.UTC))
+	q := NewQueue(client, clk)
+
+	// Each lane holds a backlog of posts not yet due, so the range scans
+	// skip past them as they do in production
+	for i := 0; i < 1000; i++ {
+		if err := q.Enqueue(ctx, uuid.New(), clk.Now().Add(time.Hour+time.Duration(i)*time.Minute), i%4 == 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	for _, batch := range []int{10, 100} {
+		b.Run(fmt.Sprintf("batch=%d", batch), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				due := clk.Now().Add(-time.Minute)
+				for j := 0; j < batch; j++ {
+					if err := q.Enqueue(ctx, uuid.New(), due, j%4 == 0); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.StartTimer()
+
+				ids, err := q.GetDuePosts(ctx, "bench-worker", batch)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(ids) != batch {
+					b.Fatalf("GetDuePosts returned %d posts, want %d", len(ids), batch)
+				}
+			}
+			b.StopTimer()
+			client.Del(ctx, claimsKey)
+		})
+	}
+}
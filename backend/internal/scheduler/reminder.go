@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/mailer"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+)
+
+// reminderBatchSize is the most reminders sent per tick
+const reminderBatchSize = 100
+
+// processDueReminders sends the reminders that have come due on the reminder wheel
+func (w *Worker) processDueReminders(ctx context.Context) {
+	postIDs, err := w.queue.GetDueReminders(ctx, reminderBatchSize)
+	if err != nil {
+		log.Printf("❌ Error getting due reminders from queue: %v", err)
+		return
+	}
+
+	for _, postID := range postIDs {
+		if err := w.sendReminder(ctx, postID); err != nil {
+			log.Printf("❌ Failed to send reminder for post %s: %v", postID, err)
+		}
+	}
+}
+
+// sendReminder tells a post's author that it is about to publish: an SSE
+// event, an email and, when configured, a webhook. Posts that were
+// published, deleted or had their reminder turned off in the meantime are
+// skipped, as are posts of suspended accounts, which won't publish.
+func (w *Worker) sendReminder(ctx context.Context, postID uuid.UUID) error {
+	post, err := w.db.GetPostByID(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if post == nil || post.Status != models.PostStatusScheduled {
+		return nil
+	}
+	if _, ok := post.ReminderAt(); !ok {
+		return nil
+	}
+
+	author, err := w.db.GetUserByID(ctx, post.UserID)
+	if err != nil {
+		return err
+	}
+	if author == nil || author.Suspended() {
+		return nil
+	}
+
+	if w.notifier != nil {
+		w.notifier.NotifyPost(post.UserID, notifier.UpdateTypeReminder, post.ID)
+	}
+
+	msg := mailer.Message{
+		To:      author.Email,
+		Subject: "Reminder: a post is about to publish",
+		Body: fmt.Sprintf("%s is scheduled to publish to %s at %s:\n\n%s",
+			postName(post), post.Channel, post.ScheduledAt.UTC().Format(time.RFC1123), post.Content),
+	}
+	if _, err := w.jobs.Enqueue(ctx, JobEmailSend, msg, time.Now()); err != nil {
+		log.Printf("❌ Failed to queue reminder email for post %s: %v", post.ID, err)
+	}
+
+	if author.ReminderWebhookURL != nil {
+		body, err := json.Marshal(models.PostReminder{
+			Event:       "post.reminder",
+			PostID:      post.ID,
+			Title:       post.Title,
+			Content:     post.Content,
+			Channel:     post.Channel,
+			ScheduledAt: post.ScheduledAt,
+		})
+		if err != nil {
+			return err
+		}
+		payload := WebhookPayload{URL: *author.ReminderWebhookURL, Body: body}
+		if _, err := w.jobs.Enqueue(ctx, JobWebhookSend, payload, time.Now()); err != nil {
+			log.Printf("❌ Failed to queue reminder webhook for post %s: %v", post.ID, err)
+		}
+	}
+
+	log.Printf("⏰ Sent reminder for post %s, due %s", post.ID, post.ScheduledAt.Format(time.RFC3339))
+	return nil
+}
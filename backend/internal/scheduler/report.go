@@ -0,0 +1,105 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/mailer"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+	"github.com/scheduler/backend/internal/report"
+)
+
+// reportBatchSize is the most users sent their monthly report per run
+const reportBatchSize = 100
+
+// MonthlyReports sends each user the analytics report of the previous
+// calendar month (UTC) as a digest notification, with the PDF attached to
+// the email
+type MonthlyReports struct {
+	db       db.Store
+	dispatch *Dispatcher
+	now      func() time.Time
+}
+
+// NewMonthlyReports creates a new monthly report sender
+func NewMonthlyReports(database db.Store, n *notifier.Notifier, jobs *JobQueue) *MonthlyReports {
+	return &MonthlyReports{
+		db:       database,
+		dispatch: NewDispatcher(n, jobs),
+		now:      time.Now,
+	}
+}
+
+// Run sends the previous month's report to users who haven't had it yet;
+// meant to run periodically from cron. Users who published nothing that
+// month, or route digests nowhere, are marked sent without a report.
+func (m *MonthlyReports) Run(ctx context.Context) error {
+	from, to := models.ReportMonth(m.now().UTC())
+	users, err := m.db.GetUsersForMonthlyReport(ctx, from, reportBatchSize)
+	if err != nil {
+		return fmt.Errorf("get users for monthly report: %w", err)
+	}
+
+	sent := 0
+	for _, user := range users {
+		if user.NotificationPreferences.Route(models.NotifyDigest) != (models.NotificationRoute{}) {
+			ok, err := m.send(ctx, user, from, to)
+			if err != nil {
+				log.Printf("⚠️ [REPORT] Failed to build the report of user %s: %v", user.ID, err)
+				continue
+			}
+			if ok {
+				sent++
+			}
+		}
+		if err := m.db.MarkMonthlyReportSent(ctx, user.ID, from); err != nil {
+			return fmt.Errorf("mark monthly report sent: %w", err)
+		}
+	}
+	if sent > 0 {
+		log.Printf("📊 [REPORT] Sent %d monthly reports for %s", sent, from.Format("January 2006"))
+	}
+	return nil
+}
+
+// send builds and sends a user's report of the posts they published in
+// [from, to), reporting false if they published none
+func (m *MonthlyReports) send(ctx context.Context, user *models.User, from, to time.Time) (bool, error) {
+	posts, err := m.db.GetHistoryPosts(ctx, user.ID, nil, db.HistoryFilter{
+		Statuses: []models.PostStatus{models.PostStatusPublished},
+		From:     &from,
+		To:       &to,
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(posts) == 0 {
+		return false, nil
+	}
+
+	r := models.NewAnalyticsReport(from, to, posts)
+	var pdf bytes.Buffer
+	if err := report.WritePDF(&pdf, r); err != nil {
+		return false, err
+	}
+
+	month := from.Format("January 2006")
+	m.dispatch.Send(ctx, user, Notification{
+		Event:   models.NotifyDigest,
+		Subject: "Your analytics report for " + month,
+		Body: "Here's how your posts did in " + month + ".\n\n" + strings.Join(report.Summary(r), "\n") +
+			"\n\nThe attached PDF lists every post.",
+		Attachments: []mailer.Attachment{{
+			Filename:    "analytics-" + from.Format("2006-01") + ".pdf",
+			ContentType: "application/pdf",
+			Data:        pdf.Bytes(),
+		}},
+	})
+	return true, nil
+}
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/notifier"
+)
+
+func TestMonthlyReports_Run(t *testing.T) {
+	now := time.Date(2024, 6, 3, 8, 0, 0, 0, time.UTC)
+	month := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	published := month.Add(time.Hour)
+	active := &models.User{ID: uuid.New(), Email: "active@example.com", NotificationPreferences: models.NotificationPreferences{models.NotifyDigest: {InApp: true}}}
+	idle := &models.User{ID: uuid.New(), Email: "idle@example.com"}
+	optedOut := &models.User{ID: uuid.New(), Email: "out@example.com", NotificationPreferences: models.NotificationPreferences{models.NotifyDigest: {}}}
+
+	var historyFor []uuid.UUID
+	marked := make(map[uuid.UUID]time.Time)
+	store := &dbmock.Store{
+		GetUsersForMonthlyReportFunc: func(ctx context.Context, m time.Time, limit int) ([]*models.User, error) {
+			if !m.Equal(month) {
+				t.Errorf("month = %v, want %v", m, month)
+			}
+			return []*models.User{active, idle, optedOut}, nil
+		},
+		GetHistoryPostsFunc: func(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID, filter db.HistoryFilter) ([]*models.Post, error) {
+			historyFor = append(historyFor, userID)
+			if !filter.From.Equal(month) || !filter.To.Equal(month.AddDate(0, 1, 0)) {
+				t.Errorf("history from %v to %v, want May", filter.From, filter.To)
+			}
+			if userID != active.ID {
+				return nil, nil
+			}
+			return []*models.Post{{ID: uuid.New(), UserID: userID, Channel: models.ChannelTwitter, PublishedAt: &published}}, nil
+		},
+		MarkMonthlyReportSentFunc: func(ctx context.Context, userID uuid.UUID, m time.Time) error {
+			marked[userID] = m
+			return nil
+		},
+	}
+
+	n := notifier.NewNotifier(nil)
+	updates := n.Subscribe(active.ID)
+	reports := NewMonthlyReports(store, n, nil)
+	reports.now = func() time.Time { return now }
+
+	if err := reports.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(historyFor) != 2 {
+		t.Errorf("read the history of %d users, want all but the one routing digests nowhere", len(historyFor))
+	}
+	if len(marked) != 3 {
+		t.Errorf("marked %d users sent, want 3", len(marked))
+	}
+	select {
+	case update := <-updates:
+		if update.Type != notifier.UpdateTypeDigest {
+			t.Errorf("update type = %s, want digest", update.Type)
+		}
+	default:
+		t.Error("active user not sent their report")
+	}
+}
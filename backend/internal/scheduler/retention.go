@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Retention deletes and anonymizes user data past its retention under the
+// default policy or each organization's overrides
+type Retention struct {
+	db     db.Store
+	policy models.RetentionPolicy
+	dryRun bool
+}
+
+// NewRetention creates a new retention job. A dry run only logs what each
+// run would change.
+func NewRetention(database db.Store, policy models.RetentionPolicy, dryRun bool) *Retention {
+	return &Retention{
+		db:     database,
+		policy: policy,
+		dryRun: dryRun,
+	}
+}
+
+// Run applies the retention rules; meant to run periodically from cron
+func (r *Retention) Run(ctx context.Context) error {
+	report, err := r.db.ApplyRetention(ctx, r.policy, time.Now(), nil, r.dryRun)
+	if err != nil {
+		return fmt.Errorf("apply retention: %w", err)
+	}
+
+	if report.DryRun {
+		log.Printf("🧹 Retention dry run: would purge %d failed posts and anonymize %d audit log entries",
+			report.FailedPostsPurged, report.AuditEntriesAnonymized)
+	} else if report.FailedPostsPurged > 0 || report.AuditEntriesAnonymized > 0 {
+		log.Printf("🧹 Retention: purged %d failed posts and anonymized %d audit log entries",
+			report.FailedPostsPurged, report.AuditEntriesAnonymized)
+	}
+	return nil
+}
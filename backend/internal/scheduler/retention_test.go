@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestRetention_Run(t *testing.T) {
+	policy := models.RetentionPolicy{FailedPostDays: 90, AuditAnonymizeMonths: 24}
+	var runs []bool
+	var fail error
+	store := &dbmock.Store{
+		ApplyRetentionFunc: func(ctx context.Context, defaults models.RetentionPolicy, now time.Time, orgID *uuid.UUID, dryRun bool) (models.RetentionReport, error) {
+			if defaults != policy || orgID != nil {
+				t.Errorf("ApplyRetention(%+v, %v), want the default policy for every organization", defaults, orgID)
+			}
+			runs = append(runs, dryRun)
+			return models.RetentionReport{DryRun: dryRun, FailedPostsPurged: 3}, fail
+		},
+	}
+
+	if err := NewRetention(store, policy, false).Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewRetention(store, policy, true).Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 || runs[0] || !runs[1] {
+		t.Errorf("dry runs = %v, want [false true]", runs)
+	}
+
+	fail = errors.New("connection reset")
+	if err := NewRetention(store, policy, false).Run(context.Background()); !errors.Is(err, fail) {
+		t.Errorf("Run() = %v, want the store's error", err)
+	}
+}
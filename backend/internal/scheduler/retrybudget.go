@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	retryBudgetKeyPrefix = "publish:retry-budget:"
+
+	// maxRetryBudgetSpill is how many minutes past its due time a retry may
+	// be pushed looking for budget; beyond that it is scheduled regardless
+	maxRetryBudgetSpill = 60
+)
+
+// RetryBudget caps how many publish retries all workers schedule per minute,
+// so that when a platform has an outage its failed posts are spread out
+// instead of retrying in synchronized storms. Retries over the budget move to
+// the next minute with budget left.
+type RetryBudget struct {
+	redis     *redis.Client
+	perMinute int
+}
+
+// NewRetryBudget creates a retry budget of perMinute retries. Zero disables it.
+func NewRetryBudget(redisClient *redis.Client, perMinute int) *RetryBudget {
+	return &RetryBudget{
+		redis:     redisClient,
+		perMinute: perMinute,
+	}
+}
+
+// Reserve takes a retry slot at or after at and returns the time to retry
+func (b *RetryBudget) Reserve(ctx context.Context, at time.Time) (time.Time, error) {
+	if b.perMinute <= 0 {
+		return at, nil
+	}
+
+	minute := at.Truncate(time.Minute)
+	offset := at.Sub(minute)
+	for i := 0; i < maxRetryBudgetSpill; i++ {
+		slot := minute.Add(time.Duration(i) * time.Minute)
+		key := fmt.Sprintf("%s%d", retryBudgetKeyPrefix, slot.Unix())
+
+		pipe := b.redis.Pipeline()
+		used := pipe.Incr(ctx, key)
+		pipe.ExpireAt(ctx, key, slot.Add(time.Hour))
+		if _, err := pipe.Exec(ctx); err != nil {
+			return at, err
+		}
+		if used.Val() <= int64(b.perMinute) {
+			return slot.Add(offset), nil
+		}
+	}
+	return at, nil
+}
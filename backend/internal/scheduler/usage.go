@@ -0,0 +1,40 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/usage"
+)
+
+// UsageRollup copies the Redis usage counters into daily Postgres rows
+type UsageRollup struct {
+	db    db.Store
+	meter *usage.Meter
+}
+
+// NewUsageRollup creates a new usage rollup
+func NewUsageRollup(database db.Store, meter *usage.Meter) *UsageRollup {
+	return &UsageRollup{
+		db:    database,
+		meter: meter,
+	}
+}
+
+// Run rolls up yesterday's final counters and today's so far; meant to run
+// periodically from cron. Rollups are idempotent.
+func (u *UsageRollup) Run(ctx context.Context) error {
+	now := time.Now()
+	for _, day := range []time.Time{now.AddDate(0, 0, -1), now} {
+		counts, err := u.meter.Day(ctx, day)
+		if err != nil {
+			return fmt.Errorf("read usage for %s: %w", day.UTC().Format(usage.DayFormat), err)
+		}
+		if err := u.db.SaveUsageDay(ctx, day, counts); err != nil {
+			return fmt.Errorf("save usage for %s: %w", day.UTC().Format(usage.DayFormat), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// webhookTimeout bounds each outbound webhook delivery
+const webhookTimeout = 10 * time.Second
+
+// WebhookPayload is the payload of a webhook.send job
+type WebhookPayload struct {
+	URL  string          `json:"url"`
+	Body json.RawMessage `json:"body"`
+}
+
+// WebhookJobHandler returns the handler for webhook.send jobs, which POST the
+// body as JSON. Non-2xx responses fail the job so it is retried.
+func WebhookJobHandler(client *http.Client) JobHandler {
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	return func(ctx context.Context, job *Job) error {
+		var payload WebhookPayload
+		if err := job.Decode(&payload); err != nil {
+			return fmt.Errorf("decode webhook payload: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, payload.URL, bytes.NewReader(payload.Body))
+		if err != nil {
+			return fmt.Errorf("build webhook request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("webhook returned %s", resp.Status)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,40 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookJobHandler(t *testing.T) {
+	var gotBody string
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	payload, _ := json.Marshal(WebhookPayload{URL: server.URL, Body: json.RawMessage(`{"event":"post.reminder"}`)})
+	job := &Job{ID: "job-1", Type: JobWebhookSend, Payload: payload}
+	handler := WebhookJobHandler(server.Client())
+
+	if err := handler(context.Background(), job); err != nil {
+		t.Fatalf("handler() error = %v", err)
+	}
+	if gotBody != `{"event":"post.reminder"}` {
+		t.Errorf("body = %q, want the payload body", gotBody)
+	}
+
+	status = http.StatusBadGateway
+	if err := handler(context.Background(), job); err == nil {
+		t.Error("handler() should fail on a non-2xx response so the job retries")
+	}
+}
@@ -2,50 +2,136 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"math"
+	"math/rand"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/scheduler/backend/internal/cache"
+	"github.com/scheduler/backend/internal/clock"
 	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/maintenance"
+	"github.com/scheduler/backend/internal/metrics"
+	"github.com/scheduler/backend/internal/models"
 	"github.com/scheduler/backend/internal/notifier"
+	"github.com/scheduler/backend/internal/publisher"
+	"github.com/scheduler/backend/internal/usage"
 )
 
 const (
 	// MaxRetries is the maximum number of retry attempts
 	MaxRetries = 3
+
+	// recycleBatchSize is the most posts recycled per scan
+	recycleBatchSize = 100
+
+	// reconcilePageSize is how many scheduled posts are checked per query
+	reconcilePageSize = 1000
+	// reconcileInFlightGrace skips recently due posts, which may be missing from
+	// the queue only because another worker has claimed them and is publishing
+	reconcileInFlightGrace = time.Minute
+
+	// minClaimLeaseTTL is the shortest time claims outlive their worker
+	minClaimLeaseTTL = time.Minute
+
+	// publishBatchSize is the most accepted posts recorded as published at once
+	publishBatchSize = 25
 )
 
 // Worker handles background post publishing
 type Worker struct {
-	db       *db.DB
-	queue    *Queue
-	cache    *cache.Cache
-	notifier *notifier.Notifier
-	interval time.Duration
+	db          db.Store
+	queue       *Queue
+	cache       *cache.Cache
+	notifier    *notifier.Notifier
+	publishers  *publisher.Registry
+	limits      models.DailyLimits
+	heartbeats  *HeartbeatStore
+	lag         *LagMonitor
+	breaker     *CircuitBreaker
+	backoff     *BackoffStore // Platform rate limits hold all of an account's posts to the channel
+	retryBudget *RetryBudget  // Spreads retries out during platform outages
+	jobs        *JobQueue
+	dispatch    *Dispatcher
+	maintenance *maintenance.Store // Publishing stops while maintenance mode is on
+	usage       *usage.Meter       // Publishes are counted against plan quotas
+	interval    time.Duration
+	timeout     time.Duration // Per-post publish deadline
+	undoWindow  time.Duration // Due posts wait this long so users can abort them
+	clock       clock.Clock   // Decides when posts are due; heartbeats use the system clock
+
+	jobHandlers map[JobType]JobHandler
+
+	// unrecorded are posts their platforms accepted that couldn't be recorded
+	// as published. Their claims are kept, so neither queue reconciliation nor
+	// another worker sends them again, and recording is retried every tick.
+	unrecorded []*models.Post
+
+	instanceID string
+	hostname   string
+	startedAt  time.Time
+	stats      workerStats
 }
 
 // NewWorker creates a new background worker
-func NewWorker(database *db.DB, queue *Queue, postCache *cache.Cache, n *notifier.Notifier, interval time.Duration) *Worker {
-	return &Worker{
-		db:       database,
-		queue:    queue,
-		cache:    postCache,
-		notifier: n,
-		interval: interval,
+func NewWorker(database db.Store, queue *Queue, postCache *cache.Cache, n *notifier.Notifier, publishers *publisher.Registry, limits models.DailyLimits, heartbeats *HeartbeatStore, lag *LagMonitor, breaker *CircuitBreaker, backoff *BackoffStore, retryBudget *RetryBudget, jobs *JobQueue, maintenanceStore *maintenance.Store, meter *usage.Meter, interval, publishTimeout, undoWindow time.Duration, clk clock.Clock) *Worker {
+	instanceID, hostname := newInstanceID()
+	w := &Worker{
+		db:          database,
+		queue:       queue,
+		cache:       postCache,
+		notifier:    n,
+		publishers:  publishers,
+		limits:      limits,
+		heartbeats:  heartbeats,
+		lag:         lag,
+		breaker:     breaker,
+		backoff:     backoff,
+		retryBudget: retryBudget,
+		jobs:        jobs,
+		dispatch:    NewDispatcher(n, jobs),
+		maintenance: maintenanceStore,
+		usage:       meter,
+		interval:    interval,
+		timeout:     publishTimeout,
+		undoWindow:  undoWindow,
+		clock:       clk,
+		instanceID:  instanceID,
+		hostname:    hostname,
+		startedAt:   time.Now(),
+
+		jobHandlers: make(map[JobType]JobHandler),
 	}
+	w.RegisterJob(JobPostPublish, w.handlePostPublishJob)
+	return w
+}
+
+// InstanceID returns the unique ID this worker reports in heartbeats
+func (w *Worker) InstanceID() string {
+	return w.instanceID
+}
+
+// RegisterJob sets the handler for a job type; call before Run
+func (w *Worker) RegisterJob(jobType JobType, handler JobHandler) {
+	w.jobHandlers[jobType] = handler
 }
 
 // Run starts the worker loop
 func (w *Worker) Run(ctx context.Context) {
-	log.Printf("🔄 Worker started, polling every %v", w.interval)
+	log.Printf("🔄 Worker %s started, polling every %v", w.instanceID, w.interval)
+
+	// Restore queue entries lost to a Redis restart before processing
+	if err := w.ReconcileQueue(ctx); err != nil {
+		log.Printf("❌ Queue reconciliation failed: %v", err)
+	}
 
 	ticker := time.NewTicker(w.interval)
 	defer ticker.Stop()
 
 	// Process immediately on start
-	w.processDuePosts(ctx)
+	w.tick(ctx)
 
 	for {
 		select {
@@ -53,15 +139,223 @@ func (w *Worker) Run(ctx context.Context) {
 			log.Println("⏹️ Worker stopped")
 			return
 		case <-ticker.C:
-			w.processDuePosts(ctx)
+			w.tick(ctx)
+		}
+	}
+}
+
+// tick sends due reminders and processes due posts and jobs unless
+// maintenance mode is on, then reports a heartbeat
+func (w *Worker) tick(ctx context.Context) {
+	if !w.paused(ctx) {
+		w.processDueReminders(ctx)
+		w.processDuePosts(ctx)
+		w.processDueJobs(ctx)
+	}
+	w.writeHeartbeat(ctx)
+}
+
+// paused reports whether maintenance mode is on, logging when it changes.
+// Publishing continues if the flag can't be read.
+func (w *Worker) paused(ctx context.Context) bool {
+	if w.maintenance == nil {
+		return false
+	}
+
+	state, err := w.maintenance.Get(ctx)
+	if err != nil {
+		log.Printf("⚠️ Failed to check maintenance mode: %v", err)
+		return w.stats.paused.Load()
+	}
+
+	if was := w.stats.paused.Swap(state.Enabled); was != state.Enabled {
+		if state.Enabled {
+			log.Printf("🚧 Maintenance mode on, worker %s paused", w.instanceID)
+		} else {
+			log.Printf("▶️ Maintenance mode off, worker %s resumed", w.instanceID)
+		}
+	}
+	return state.Enabled
+}
+
+// ReconcileQueue re-enqueues scheduled posts that are missing from the Redis
+// queue, so data loss in Redis can't orphan them. Safe to run at any time.
+func (w *Worker) ReconcileQueue(ctx context.Context) error {
+	graceStart := w.clock.Now().Add(-reconcileInFlightGrace)
+	afterID := uuid.Nil
+	checked, added := 0, 0
+
+	for {
+		refs, err := w.db.ListScheduledPostRefs(ctx, afterID, reconcilePageSize)
+		if err != nil {
+			return fmt.Errorf("list scheduled posts: %w", err)
+		}
+		if len(refs) == 0 {
+			break
 		}
+		checked += len(refs)
+		afterID = refs[len(refs)-1].ID
+
+		candidates := refs[:0]
+		for _, ref := range refs {
+			if ref.RunAt.Before(graceStart) || ref.RunAt.After(w.clock.Now()) {
+				candidates = append(candidates, ref)
+			}
+		}
+
+		n, err := w.queue.EnqueueMissing(ctx, candidates)
+		if err != nil {
+			return fmt.Errorf("enqueue missing posts: %w", err)
+		}
+		added += n
 	}
+
+	if added > 0 {
+		log.Printf("🩹 Queue reconciliation restored %d of %d scheduled posts", added, checked)
+	}
+	return nil
+}
+
+// processDueJobs runs all background jobs that are due
+func (w *Worker) processDueJobs(ctx context.Context) {
+	jobs, err := w.jobs.Claim(ctx, 100)
+	if err != nil {
+		log.Printf("❌ Error getting due jobs from queue: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		w.runJob(ctx, job)
+	}
+}
+
+// runJob dispatches a job to its handler, retrying it on failure
+func (w *Worker) runJob(ctx context.Context, job *Job) {
+	handler, ok := w.jobHandlers[job.Type]
+	if !ok {
+		log.Printf("⚠️ No handler for job %s (%s), deferring", job.ID, job.Type)
+		if err := w.jobs.Defer(ctx, job, time.Now().Add(unhandledJobDelay)); err != nil {
+			log.Printf("❌ Failed to defer job %s: %v", job.ID, err)
+		}
+		return
+	}
+
+	if jobErr := handler(ctx, job); jobErr != nil {
+		log.Printf("❌ Job %s (%s) attempt %d failed: %v", job.ID, job.Type, job.Attempts+1, jobErr)
+		if err := w.jobs.Retry(ctx, job, jobErr); err != nil {
+			log.Printf("❌ Failed to reschedule job %s: %v", job.ID, err)
+		}
+		return
+	}
+
+	if err := w.jobs.Complete(ctx, job); err != nil {
+		log.Printf("⚠️ Failed to clean up job %s: %v", job.ID, err)
+	}
+}
+
+// PostPublishPayload is the payload of a post.publish job
+type PostPublishPayload struct {
+	PostID uuid.UUID `json:"post_id"`
+}
+
+// handlePostPublishJob publishes a post through the job queue. Publish
+// failures use the post's own retry tracking, so only lookup errors retry the job.
+func (w *Worker) handlePostPublishJob(ctx context.Context, job *Job) error {
+	var payload PostPublishPayload
+	if err := job.Decode(&payload); err != nil {
+		return err
+	}
+	return w.publishPost(ctx, payload.PostID)
+}
+
+// writeHeartbeat reports the worker's status so operators can see it is alive
+func (w *Worker) writeHeartbeat(ctx context.Context) {
+	if w.heartbeats == nil {
+		return
+	}
+
+	ttl := 3 * w.interval
+	if ttl < minHeartbeatTTL {
+		ttl = minHeartbeatTTL
+	}
+
+	err := w.heartbeats.Write(ctx, Heartbeat{
+		InstanceID:      w.instanceID,
+		Hostname:        w.hostname,
+		StartedAt:       w.startedAt,
+		LastTick:        time.Now(),
+		Interval:        w.interval.String(),
+		PostsProcessed:  w.stats.processed.Load(),
+		PublishFailures: w.stats.failed.Load(),
+		LagSeconds:      float64(w.stats.lagMillis.Load()) / 1000,
+		Paused:          w.stats.paused.Load(),
+		OpenCircuits:    w.breaker.Open(w.clock.Now()),
+	}, ttl)
+	if err != nil {
+		log.Printf("⚠️ Failed to write worker heartbeat: %v", err)
+	}
+}
+
+// RecyclePosts reschedules copies of evergreen posts whose recycle interval
+// has elapsed; run periodically by the cron runner
+func (w *Worker) RecyclePosts(ctx context.Context) error {
+	if w.paused(ctx) {
+		return nil
+	}
+
+	posts, err := w.db.GetRecyclablePosts(ctx, recycleBatchSize)
+	if err != nil {
+		return fmt.Errorf("get posts to recycle: %w", err)
+	}
+
+	for _, post := range posts {
+		recycled, err := w.db.RecyclePost(ctx, post.ID)
+		if err != nil {
+			log.Printf("❌ Failed to recycle post %s: %v", post.ID, err)
+			continue
+		}
+		if recycled == nil {
+			// Already recycled by another worker
+			continue
+		}
+
+		if err := w.queue.Enqueue(ctx, recycled.ID, recycled.ScheduledAt, recycled.Priority); err != nil {
+			log.Printf("⚠️ Failed to enqueue recycled post %s: %v", recycled.ID, err)
+		}
+
+		if w.cache != nil {
+			_ = w.cache.InvalidatePost(ctx, recycled)
+		}
+		if w.notifier != nil {
+			w.notifier.Notify(recycled.UserID, notifier.UpdateTypeCreate)
+		}
+
+		log.Printf("♻️ Recycled post %s as %s (%d/%d)", post.ID, recycled.ID, recycled.RecycleCount, recycled.Recycle.MaxCount)
+	}
+	return nil
 }
 
 // processDuePosts processes all posts that are due for publishing
 func (w *Worker) processDuePosts(ctx context.Context) {
+	// Hold a lease so the reaper leaves this worker's claims alone
+	if err := w.queue.RenewLease(ctx, w.instanceID, w.claimLeaseTTL()); err != nil {
+		log.Printf("❌ Failed to renew claim lease: %v", err)
+		return
+	}
+
+	// Retry recording posts published on earlier ticks
+	if len(w.unrecorded) > 0 {
+		pending := w.unrecorded
+		w.unrecorded = nil
+		ids := make([]uuid.UUID, len(pending))
+		for i, post := range pending {
+			ids[i] = post.ID
+		}
+		w.recordPublished(ctx, pending, ids)
+	}
+
 	// Get due posts from Redis queue
-	postIDs, err := w.queue.GetDuePosts(ctx, 100)
+	postIDs, err := w.queue.GetDuePosts(ctx, w.instanceID, 100)
 	if err != nil {
 		log.Printf("❌ Error getting due posts from queue: %v", err)
 		return
@@ -73,89 +367,527 @@ func (w *Worker) processDuePosts(ctx context.Context) {
 
 	log.Printf("📋 Found %d posts to publish", len(postIDs))
 
+	// Posts the platforms accepted are recorded as published in batches, and
+	// claims are kept until then
+	var accepted []*models.Post
+	var done []uuid.UUID
+	flush := func() {
+		w.recordPublished(ctx, accepted, done)
+		accepted, done = accepted[:0], done[:0]
+	}
+
 	for _, postID := range postIDs {
-		if err := w.publishPost(ctx, postID); err != nil {
+		if err := w.queue.RenewLease(ctx, w.instanceID, w.claimLeaseTTL()); err != nil {
+			log.Printf("⚠️ Failed to renew claim lease: %v", err)
+		}
+		post, err := w.attemptPublish(ctx, postID)
+		if err != nil {
 			log.Printf("❌ Failed to publish post %s: %v", postID, err)
 		}
+		if post != nil {
+			accepted = append(accepted, post)
+		}
+		done = append(done, postID)
+
+		if len(accepted) >= publishBatchSize {
+			flush()
+		}
+	}
+	flush()
+}
+
+// recordPublished records accepted posts as published and releases the claims
+// on done posts. If recording fails, the accepted posts keep their claims and
+// are added to w.unrecorded to be retried.
+func (w *Worker) recordPublished(ctx context.Context, accepted []*models.Post, done []uuid.UUID) {
+	var kept []*models.Post
+	if err := w.finishPublished(ctx, accepted); err != nil {
+		log.Printf("❌ Failed to record %d published posts, will retry: %v", len(accepted), err)
+		kept = accepted
+		w.unrecorded = append(w.unrecorded, accepted...)
+	}
+	for _, postID := range releasableClaims(done, kept) {
+		if err := w.queue.ReleaseClaim(ctx, postID); err != nil {
+			log.Printf("⚠️ Failed to release claim on post %s: %v", postID, err)
+		}
+	}
+}
+
+// releasableClaims returns the done posts whose claims can be released: all
+// but those kept until they are recorded as published
+func releasableClaims(done []uuid.UUID, kept []*models.Post) []uuid.UUID {
+	if len(kept) == 0 {
+		return done
+	}
+	keep := make(map[uuid.UUID]bool, len(kept))
+	for _, post := range kept {
+		keep[post.ID] = true
+	}
+	release := make([]uuid.UUID, 0, len(done))
+	for _, postID := range done {
+		if !keep[postID] {
+			release = append(release, postID)
+		}
+	}
+	return release
+}
+
+// claimLeaseTTL is how long the worker's claims survive without a renewal:
+// long enough to publish one post, which renews it
+func (w *Worker) claimLeaseTTL() time.Duration {
+	return max(2*w.timeout, minClaimLeaseTTL)
+}
+
+// ReapClaims re-queues posts claimed by workers that stopped before finishing
+// them; run periodically by the cron runner
+func (w *Worker) ReapClaims(ctx context.Context) error {
+	n, err := w.queue.ReapClaims(ctx)
+	if err != nil {
+		return fmt.Errorf("reap claims: %w", err)
+	}
+	if n > 0 {
+		log.Printf("🩹 Re-queued %d posts claimed by stopped workers", n)
 	}
+	return nil
 }
 
 // publishPost publishes a single post with retry logic
 func (w *Worker) publishPost(ctx context.Context, postID uuid.UUID) error {
+	post, err := w.attemptPublish(ctx, postID)
+	if err != nil || post == nil {
+		return err
+	}
+	return w.finishPublished(ctx, []*models.Post{post})
+}
+
+// attemptPublish runs a due post through the publishing checks and sends it
+// to its platform. Returns the post if the platform accepted it, for
+// finishPublished to record; nil if it was deferred, held or failed.
+func (w *Worker) attemptPublish(ctx context.Context, postID uuid.UUID) (*models.Post, error) {
 	// Get post with retry info
 	post, err := w.db.GetPostForRetry(ctx, postID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if post == nil {
 		log.Printf("⚠️ Post %s not found", postID)
-		return nil
+		return nil, nil
 	}
 
 	if post.Status != "scheduled" {
 		log.Printf("⚠️ Post %s is not scheduled (status: %s)", postID, post.Status)
-		return nil
+		return nil, nil
+	}
+
+	owner, err := w.db.GetUserByID(ctx, post.UserID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Attempt to publish (mock publishing - in real app, this would call social media APIs)
-	publishErr := w.mockPublish(post)
+	// Posts of suspended accounts stay scheduled but leave the queue; they are
+	// queued again when the account is unsuspended
+	if owner != nil && owner.Suspended() {
+		log.Printf("⛔ Skipping post %s of suspended user %s", post.ID, post.UserID)
+		return nil, nil
+	}
+
+	// Hold organization posts until their next publishing window opens
+	if deferred, err := w.deferOutsideWindow(ctx, post); err != nil || deferred {
+		return nil, err
+	}
+
+	// Hold the post until tomorrow if today's limit for the channel is used up
+	if deferred, err := w.deferOverLimit(ctx, post); err != nil || deferred {
+		return nil, err
+	}
+
+	// Hold the post until tomorrow if the owner's plan has no publishes left today
+	if deferred, err := w.deferOverQuota(ctx, post, owner); err != nil || deferred {
+		return nil, err
+	}
+
+	// Hold the post while the channel's circuit is open, without using up a retry
+	if ok, until := w.breaker.Allow(post.Channel, w.clock.Now()); !ok {
+		return nil, w.queue.Enqueue(ctx, post.ID, until, post.Priority)
+	}
+
+	// Hold the post while the platform rate limits its account on the channel,
+	// without using up a retry
+	if until, err := w.backoff.Until(ctx, post.UserID, post.Channel); err != nil {
+		log.Printf("⚠️ Failed to check rate limit backoff for post %s: %v", post.ID, err)
+	} else if !until.IsZero() {
+		return nil, w.queue.Enqueue(ctx, post.ID, until, post.Priority)
+	}
+
+	// Give the user a last chance to abort before the platform call
+	if held, err := w.holdForUndo(ctx, post); err != nil || held {
+		return nil, err
+	}
+
+	// Attempt to publish via the channel's publisher
+	publishErr := w.publishWithTimeout(ctx, w.signed(ctx, post))
 
 	if publishErr != nil {
+		// Rate limits are about the account, not the platform's health
+		if _, rateLimited := publisher.AsRateLimit(publishErr); !rateLimited && w.breaker.RecordFailure(post.Channel, w.clock.Now()) {
+			log.Printf("🔌 Circuit opened for %s after repeated failures, deferring its posts", post.Channel)
+		}
 		// Handle failure with retry logic
-		return w.handlePublishError(ctx, post, publishErr)
+		return nil, w.handlePublishError(ctx, post, publishErr)
 	}
+	w.breaker.RecordSuccess(post.Channel)
+	return post, nil
+}
 
-	// Success - mark as published
-	publishedPost, err := w.db.PublishPost(ctx, postID)
+// finishPublished records posts their platforms accepted as published in a
+// batch: one UPDATE for all posts, one for their channel connections, and one
+// cache invalidation and SSE notification per user
+func (w *Worker) finishPublished(ctx context.Context, accepted []*models.Post) error {
+	if len(accepted) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(accepted))
+	for i, post := range accepted {
+		ids[i] = post.ID
+	}
+	published, err := w.db.PublishPosts(ctx, ids)
 	if err != nil {
 		return err
 	}
-
-	if publishedPost == nil {
-		log.Printf("⚠️ Post %s not found or already published", postID)
+	if skipped := len(accepted) - len(published); skipped > 0 {
+		log.Printf("⚠️ %d of %d posts not found or already published", skipped, len(accepted))
+	}
+	if len(published) == 0 {
 		return nil
 	}
 
-	// Invalidate cache for this user
+	refs := make([]db.ChannelRef, 0, len(published))
+	publishes := make(map[uuid.UUID]int)
+	var owners []cache.Owner
+	seen := make(map[cache.Owner]bool)
+	var users []uuid.UUID
+	for _, post := range published {
+		refs = append(refs, db.ChannelRef{UserID: post.UserID, Channel: post.Channel})
+		if publishes[post.UserID] == 0 {
+			users = append(users, post.UserID)
+		}
+		publishes[post.UserID]++
+		if owner := cache.PostOwner(post); !seen[owner] {
+			seen[owner] = true
+			owners = append(owners, owner)
+		}
+
+		w.stats.processed.Add(1)
+		if post.PublishedAt != nil {
+			w.stats.lagMillis.Store(post.PublishedAt.Sub(post.ScheduledAt).Milliseconds())
+			if w.lag != nil {
+				w.lag.Observe(post, *post.PublishedAt)
+			}
+		}
+
+		log.Printf("📤 Published post %s to %s: %s", post.ID, post.Channel, truncate(post.Content, 50))
+	}
+
+	// Record the successful publishes on the users' channel connections
+	if err := w.db.RecordChannelPublishes(ctx, refs); err != nil {
+		log.Printf("⚠️ Failed to record channel publishes: %v", err)
+	}
+
+	// Count the publishes against the owners' plan quotas
+	if w.usage != nil {
+		if err := w.usage.RecordPublishes(ctx, publishes); err != nil {
+			log.Printf("⚠️ Failed to meter publishes: %v", err)
+		}
+	}
+
+	// Invalidate cache for these workspaces
 	if w.cache != nil {
-		_ = w.cache.InvalidateUserPosts(ctx, post.UserID)
+		_ = w.cache.InvalidateOwners(ctx, owners)
 	}
 
-	// Notify SSE clients via Redis pub/sub
+	// Notify SSE clients via Redis pub/sub, once per user
 	if w.notifier != nil {
-		w.notifier.Notify(post.UserID, notifier.UpdateTypePublish)
+		for _, userID := range users {
+			w.notifier.Notify(userID, notifier.UpdateTypePublish)
+		}
 	}
 
-	log.Printf("📤 Published post %s to %s: %s", post.ID, post.Channel, truncate(post.Content, 50))
+	w.notifyPublished(ctx, published)
 	return nil
 }
 
-// mockPublish simulates publishing to a social media platform
-// In a real application, this would make API calls to Twitter, LinkedIn, etc.
-func (w *Worker) mockPublish(post *db.PostWithRetry) error {
-	// Simulate occasional failures for testing (1 in 10 chance)
-	// In production, remove this and implement real API calls
-	// if rand.Intn(10) == 0 {
-	// 	return fmt.Errorf("simulated API failure")
-	// }
-	return nil
+// notifyPublished tells each post's owner it was published, over the channels
+// their preferences route publish successes to
+func (w *Worker) notifyPublished(ctx context.Context, published []*models.Post) {
+	owners := make(map[uuid.UUID]*models.User)
+	for _, post := range published {
+		owner, ok := owners[post.UserID]
+		if !ok {
+			var err error
+			if owner, err = w.db.GetUserByID(ctx, post.UserID); err != nil {
+				log.Printf("⚠️ Failed to look up owner of post %s: %v", post.ID, err)
+			}
+			owners[post.UserID] = owner
+		}
+		if owner == nil {
+			continue
+		}
+
+		w.dispatch.Send(ctx, owner, Notification{
+			Event:   models.NotifyPublishSuccess,
+			PostID:  &post.ID,
+			Subject: fmt.Sprintf("%s published to %s", postName(post), post.Channel),
+			Body:    fmt.Sprintf("%s was published to %s:\n\n%s", postName(post), post.Channel, post.Content),
+		})
+	}
 }
 
-// handlePublishError handles a failed publish attempt with exponential backoff
+// notifyFailed tells the post's owner it failed after its last retry, over the
+// channels their preferences route publish failures to
+func (w *Worker) notifyFailed(ctx context.Context, post *models.Post, errorMsg string) {
+	owner, err := w.db.GetUserByID(ctx, post.UserID)
+	if err != nil || owner == nil {
+		log.Printf("⚠️ Failed to look up owner of post %s: %v", post.ID, err)
+		return
+	}
+
+	w.dispatch.Send(ctx, owner, Notification{
+		Event:   models.NotifyPublishFailure,
+		PostID:  &post.ID,
+		Subject: fmt.Sprintf("%s failed to publish to %s", postName(post), post.Channel),
+		Body: fmt.Sprintf("%s could not be published to %s after %d retries: %s\n\n%s",
+			postName(post), post.Channel, MaxRetries, errorMsg, post.Content),
+	})
+}
+
+// postName names a post in notifications: its title, if it has one
+func postName(post *models.Post) string {
+	if post.Title != nil {
+		return fmt.Sprintf("Your post %q", *post.Title)
+	}
+	return "Your post"
+}
+
+// signed returns the post as it should publish: a copy with the author's
+// signature for the channel appended, unless the post opts out or the
+// signature doesn't fit the channel's length limit. The stored content is
+// left as written.
+func (w *Worker) signed(ctx context.Context, post *models.Post) *models.Post {
+	if post.NoSignature {
+		return post
+	}
+	signature, err := w.db.GetChannelSignature(ctx, post.UserID, post.Channel)
+	if err != nil {
+		log.Printf("⚠️ Failed to look up signature for post %s, publishing without it: %v", post.ID, err)
+		return post
+	}
+	content, ok := models.SignContent(post.Channel, post.Content, signature)
+	if !ok {
+		if signature != nil {
+			log.Printf("✂️ Signature doesn't fit post %s on %s, publishing without it", post.ID, post.Channel)
+		}
+		return post
+	}
+	signedPost := *post
+	signedPost.Content = content
+	return &signedPost
+}
+
+// publishWithTimeout calls the channel's publisher with a deadline so a hung
+// platform API can't stall the batch. A timeout is returned as an ordinary,
+// retryable publish error.
+func (w *Worker) publishWithTimeout(ctx context.Context, post *models.Post) error {
+	if w.timeout <= 0 {
+		return w.publishers.Publish(ctx, post)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	// Run in a goroutine so publishers that ignore ctx still can't block us
+	done := make(chan error, 1)
+	go func() {
+		done <- w.publishers.Publish(ctx, post)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			metrics.PublishTimeouts.WithLabelValues(string(post.Channel)).Inc()
+			return fmt.Errorf("publish to %s timed out after %v", post.Channel, w.timeout)
+		}
+		return ctx.Err()
+	}
+}
+
+// deferOverLimit reschedules the post to the next UTC day when the user has
+// already published the channel's daily limit today
+func (w *Worker) deferOverLimit(ctx context.Context, post *models.Post) (bool, error) {
+	if w.limits[post.Channel] <= 0 {
+		return false, nil
+	}
+
+	start, end := models.DayBounds(w.clock.Now())
+	count, err := w.db.CountPublishedChannelPosts(ctx, post.UserID, post.Channel, start)
+	if err != nil {
+		return false, err
+	}
+
+	limitErr := w.limits.Check(post.Channel, start, count)
+	if limitErr == nil {
+		return false, nil
+	}
+
+	log.Printf("⏸️ Deferring post %s to %s: %v", post.ID, end.Format(time.RFC3339), limitErr)
+	if err := w.db.DeferPost(ctx, post.ID, end, limitErr.Error()); err != nil {
+		return false, err
+	}
+	if w.cache != nil {
+		_ = w.cache.InvalidatePost(ctx, post)
+	}
+	return true, w.queue.Enqueue(ctx, post.ID, end, post.Priority)
+}
+
+// holdForUndo starts the undo window of a post that has just come due, and
+// re-queues posts whose window is still open. Retries don't get a new window.
+func (w *Worker) holdForUndo(ctx context.Context, post *models.Post) (bool, error) {
+	if w.undoWindow <= 0 {
+		return false, nil
+	}
+
+	if post.UndoUntil != nil {
+		if w.clock.Now().Before(*post.UndoUntil) {
+			return true, w.queue.Enqueue(ctx, post.ID, *post.UndoUntil, post.Priority)
+		}
+		return false, nil
+	}
+
+	until := w.clock.Now().Add(w.undoWindow)
+	started, err := w.db.StartUndoWindow(ctx, post.ID, until)
+	if err != nil || !started {
+		return true, err
+	}
+	if err := w.queue.Enqueue(ctx, post.ID, until, post.Priority); err != nil {
+		return true, err
+	}
+
+	if w.cache != nil {
+		_ = w.cache.InvalidatePost(ctx, post)
+	}
+	if w.notifier != nil {
+		w.notifier.Notify(post.UserID, notifier.UpdateTypeUpdate)
+	}
+	log.Printf("⏳ Post %s publishes at %s unless aborted", post.ID, until.Format(time.RFC3339))
+	return true, nil
+}
+
+// deferOverQuota reschedules the post to the next UTC day when its owner has
+// used up their plan's publishes for today. Metering errors don't hold posts.
+func (w *Worker) deferOverQuota(ctx context.Context, post *models.Post, owner *models.User) (bool, error) {
+	if w.usage == nil || owner == nil {
+		return false, nil
+	}
+
+	quota := owner.Plan.Quota()
+	if quota.PublishesPerDay <= 0 {
+		return false, nil
+	}
+
+	today, err := w.usage.Today(ctx, post.UserID)
+	if err != nil {
+		log.Printf("⚠️ Failed to read publish usage for user %s: %v", post.UserID, err)
+		return false, nil
+	}
+	if quota.AllowsPublish(today.Publishes) {
+		return false, nil
+	}
+
+	_, end := models.DayBounds(w.clock.Now())
+	reason := fmt.Sprintf("Daily publish quota of %d reached for the %s plan", quota.PublishesPerDay, owner.Plan)
+	log.Printf("⏸️ Deferring post %s to %s: %s", post.ID, end.Format(time.RFC3339), reason)
+	if err := w.db.DeferPost(ctx, post.ID, end, reason); err != nil {
+		return false, err
+	}
+	if w.cache != nil {
+		_ = w.cache.InvalidatePost(ctx, post)
+	}
+	return true, w.queue.Enqueue(ctx, post.ID, end, post.Priority)
+}
+
+// deferOutsideWindow reschedules an organization post to the start of the
+// organization's next publishing window when it is due outside them, unless it
+// was scheduled with an override
+func (w *Worker) deferOutsideWindow(ctx context.Context, post *models.Post) (bool, error) {
+	if post.OrgID == nil || post.WindowOverride {
+		return false, nil
+	}
+
+	schedule, err := w.db.GetPublishingSchedule(ctx, *post.OrgID)
+	if err != nil {
+		return false, err
+	}
+	now := w.clock.Now()
+	if schedule.Allows(now) {
+		return false, nil
+	}
+
+	next := schedule.Next(now)
+	log.Printf("⏸️ Deferring post %s to %s: outside publishing windows", post.ID, next.Format(time.RFC3339))
+	if err := w.db.DeferPost(ctx, post.ID, next, "Outside the organization's publishing windows"); err != nil {
+		return false, err
+	}
+	if w.cache != nil {
+		_ = w.cache.InvalidatePost(ctx, post)
+	}
+	return true, w.queue.Enqueue(ctx, post.ID, next, post.Priority)
+}
+
+// handlePublishError handles a failed publish attempt, retrying with
+// exponential backoff or after the platform's Retry-After
 func (w *Worker) handlePublishError(ctx context.Context, post *db.PostWithRetry, publishErr error) error {
 	retryCount := post.RetryCount + 1
 	errorMsg := publishErr.Error()
 
+	if err := w.db.RecordChannelError(ctx, post.UserID, post.Channel, errorMsg); err != nil {
+		log.Printf("⚠️ Failed to record channel error for post %s: %v", post.ID, err)
+	}
+
+	w.stats.failed.Add(1)
+
+	delay, rateLimited := retryDelay(retryCount, publishErr, rand.Float64())
+	nextRetryAt := w.clock.Now().Add(delay)
+
+	// Hold the account's other posts to the channel until the limit lifts
+	if rateLimited {
+		if err := w.backoff.Extend(ctx, post.UserID, post.Channel, nextRetryAt); err != nil {
+			log.Printf("⚠️ Failed to record rate limit backoff for post %s: %v", post.ID, err)
+		}
+	}
+
 	if retryCount >= MaxRetries {
 		// Max retries exceeded, mark as failed
 		log.Printf("❌ Post %s failed after %d retries: %s", post.ID, retryCount, errorMsg)
-		return w.db.MarkPostFailed(ctx, post.ID, errorMsg)
+		if err := w.db.MarkPostFailed(ctx, post.ID, errorMsg); err != nil {
+			return err
+		}
+		if w.cache != nil {
+			_ = w.cache.InvalidatePost(ctx, post)
+		}
+		w.notifyFailed(ctx, post, errorMsg)
+		return nil
 	}
 
-	// Calculate next retry with exponential backoff: 2, 4, 8 minutes
-	backoffMinutes := math.Pow(2, float64(retryCount))
-	nextRetryAt := time.Now().Add(time.Duration(backoffMinutes) * time.Minute)
+	// Keep within the retry budget
+	if at, err := w.retryBudget.Reserve(ctx, nextRetryAt); err != nil {
+		log.Printf("⚠️ Failed to reserve retry budget for post %s: %v", post.ID, err)
+	} else if at.After(nextRetryAt) {
+		metrics.RetriesDeferred.Inc()
+		nextRetryAt = at
+	}
 
 	// Schedule retry
 	log.Printf("🔄 Scheduling retry %d/%d for post %s at %s", retryCount, MaxRetries, post.ID, nextRetryAt.Format(time.RFC3339))
@@ -165,7 +897,25 @@ func (w *Worker) handlePublishError(ctx context.Context, post *db.PostWithRetry,
 	}
 
 	// Re-enqueue in Redis for the next retry time
-	return w.queue.Enqueue(ctx, post.ID, nextRetryAt)
+	return w.queue.Enqueue(ctx, post.ID, nextRetryAt, post.Priority)
+}
+
+// retryJitter is the most random delay added to a retry, as a fraction of it
+const retryJitter = 0.25
+
+// retryDelay returns how long to wait before retry retryCount: the platform's
+// Retry-After when it rate limited the account and said how long, exponential
+// backoff of 2, 4, 8 minutes otherwise. Up to retryJitter more is added, by
+// the random number r in [0, 1), so posts that failed together don't retry
+// together. It also reports whether the publish was rate limited.
+func retryDelay(retryCount int, publishErr error, r float64) (time.Duration, bool) {
+	delay := time.Duration(math.Pow(2, float64(retryCount))) * time.Minute
+
+	rl, rateLimited := publisher.AsRateLimit(publishErr)
+	if rateLimited && rl.RetryAfter > 0 {
+		delay = rl.RetryAfter
+	}
+	return delay + time.Duration(float64(delay)*retryJitter*r), rateLimited
 }
 
 // truncate truncates a string to maxLen and adds ellipsis
@@ -0,0 +1,114 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/db/dbmock"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/publisher"
+)
+
+// hangingPublisher blocks until released, ignoring its context
+type hangingPublisher struct {
+	release chan struct{}
+}
+
+func (p *hangingPublisher) Publish(ctx context.Context, post *models.Post) error {
+	<-p.release
+	return nil
+}
+
+func TestPublishWithTimeout(t *testing.T) {
+	hang := &hangingPublisher{release: make(chan struct{})}
+	defer close(hang.release)
+
+	publishers := publisher.NewRegistry(nil)
+	publishers.Register(models.ChannelTwitter, hang)
+
+	w := &Worker{publishers: publishers, timeout: 20 * time.Millisecond}
+	post := &models.Post{Channel: models.ChannelTwitter}
+
+	start := time.Now()
+	err := w.publishWithTimeout(context.Background(), post)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("Expected timeout error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("publishWithTimeout took %v, expected to return at the deadline", elapsed)
+	}
+
+	// Fast publishers are unaffected
+	if err := w.publishWithTimeout(context.Background(), &models.Post{Channel: models.ChannelLinkedIn}); err != nil {
+		t.Errorf("Expected LinkedIn publish to succeed, got: %v", err)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name            string
+		retryCount      int
+		err             error
+		r               float64
+		wantDelay       time.Duration
+		wantRateLimited bool
+	}{
+		{"ordinary error", 1, errors.New("502 Bad Gateway"), 0, 2 * time.Minute, false},
+		{"ordinary error backs off", 2, errors.New("502 Bad Gateway"), 0, 4 * time.Minute, false},
+		{"jitter", 2, errors.New("502 Bad Gateway"), 0.5, 4*time.Minute + 30*time.Second, false},
+		{"retry after", 1, &publisher.RateLimitError{Channel: models.ChannelTwitter, RetryAfter: 15 * time.Minute}, 0, 15 * time.Minute, true},
+		{"retry after with jitter", 1, &publisher.RateLimitError{RetryAfter: 4 * time.Minute}, 0.5, 4*time.Minute + 30*time.Second, true},
+		{"wrapped retry after", 2, fmt.Errorf("publish: %w", &publisher.RateLimitError{RetryAfter: 30 * time.Second}), 0, 30 * time.Second, true},
+		{"rate limited without retry after", 2, &publisher.RateLimitError{Channel: models.ChannelTwitter}, 0, 4 * time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, rateLimited := retryDelay(tt.retryCount, tt.err, tt.r)
+			if delay != tt.wantDelay || rateLimited != tt.wantRateLimited {
+				t.Errorf("retryDelay() = (%v, %v), want (%v, %v)", delay, rateLimited, tt.wantDelay, tt.wantRateLimited)
+			}
+		})
+	}
+
+	// Jitter never exceeds retryJitter of the delay
+	if delay, _ := retryDelay(3, errors.New("boom"), 0.999999); delay < 8*time.Minute || delay >= 10*time.Minute {
+		t.Errorf("retryDelay() with maximum jitter = %v, want within [8m, 10m)", delay)
+	}
+}
+
+func TestRecordPublished_KeepsClaimsWhenRecordingFails(t *testing.T) {
+	post := &models.Post{ID: uuid.New()}
+	store := &dbmock.Store{
+		PublishPostsFunc: func(ctx context.Context, ids []uuid.UUID) ([]*models.Post, error) {
+			return nil, errors.New("database unavailable")
+		},
+	}
+	// No queue: releasing the kept claim would panic
+	w := &Worker{db: store}
+
+	w.recordPublished(context.Background(), []*models.Post{post}, []uuid.UUID{post.ID})
+
+	if len(w.unrecorded) != 1 || w.unrecorded[0] != post {
+		t.Errorf("unrecorded = %v, want the accepted post", w.unrecorded)
+	}
+}
+
+func TestReleasableClaims(t *testing.T) {
+	published, deferred, failed := uuid.New(), uuid.New(), uuid.New()
+	done := []uuid.UUID{published, deferred, failed}
+
+	if got := releasableClaims(done, nil); len(got) != 3 {
+		t.Errorf("releasableClaims() with nothing kept = %v, want all done posts", got)
+	}
+
+	got := releasableClaims(done, []*models.Post{{ID: published}})
+	if len(got) != 2 || got[0] != deferred || got[1] != failed {
+		t.Errorf("releasableClaims() = %v, want %v", got, []uuid.UUID{deferred, failed})
+	}
+}
@@ -0,0 +1,187 @@
+// Package seed fills a database with demo users and posts for local
+// development, demos and load testing environments.
+package seed
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/scheduler/backend/internal/auth"
+	"github.com/scheduler/backend/internal/db"
+	"github.com/scheduler/backend/internal/models"
+	"github.com/scheduler/backend/internal/scheduler"
+)
+
+const (
+	// DefaultPassword is the password of every demo user
+	DefaultPassword = "demo-password-123"
+
+	// scheduledDays is how far ahead scheduled posts are spread
+	scheduledDays = 14
+	// historyDays is how far back published and failed posts are spread
+	historyDays = 30
+)
+
+// Options configures what is seeded
+type Options struct {
+	Users        int    // Demo users to create: demo1@example.com, demo2@example.com, ...
+	PostsPerUser int    // Posts created for each user
+	Password     string // Defaults to DefaultPassword
+	RandSeed     int64  // The same seed produces the same posts
+}
+
+// DefaultOptions seeds a few users with a month or so of activity each
+var DefaultOptions = Options{Users: 3, PostsPerUser: 40, RandSeed: 1}
+
+// PostSpec is a demo post to create
+type PostSpec struct {
+	Channel     models.Channel
+	Content     string
+	ScheduledAt time.Time
+	Status      models.PostStatus // Scheduled, published or failed
+	PublishedAt *time.Time
+	LastError   *string
+	Retries     int
+}
+
+// Status mix: most posts are upcoming or published, some failed
+const (
+	scheduledShare = 0.45
+	failedShare    = 0.1
+)
+
+var sampleContent = []string{
+	"Big news: our spring collection drops next week. Sign up for early access!",
+	"Five tips for staying productive while working remotely 🧵",
+	"We're hiring! Join our engineering team and help us build the future of scheduling.",
+	"Thanks to everyone who joined our webinar today. The recording is up on our blog.",
+	"Behind the scenes: how our team plans a month of content in one afternoon.",
+	"Customer spotlight: how a small bakery doubled its online orders.",
+	"Quick poll: what's your favourite time of day to read the news?",
+	"Our product roadmap for the next quarter is live. Tell us what you think!",
+	"Happy Friday! What are you working on this weekend?",
+	"New blog post: the beginner's guide to building a content calendar.",
+	"We just crossed 10,000 customers. Thank you for being part of the journey 🎉",
+	"Maintenance window tonight from 2–3am UTC. No action needed on your side.",
+}
+
+var sampleErrors = []string{
+	"rate limit exceeded (429): retry after 900 seconds",
+	"access token expired; reconnect the account",
+	"platform returned 503 Service Unavailable",
+	"publish timed out after 30s",
+	"content rejected: duplicate status",
+}
+
+// Plan returns n demo posts spread around now: scheduled posts over the next
+// two weeks during waking hours, and published and failed posts over the past
+// month, across every channel
+func Plan(r *rand.Rand, now time.Time, n int) []PostSpec {
+	channels := models.ValidChannels()
+	specs := make([]PostSpec, 0, n)
+	for i := 0; i < n; i++ {
+		spec := PostSpec{
+			Channel: channels[r.Intn(len(channels))],
+			Content: sampleContent[r.Intn(len(sampleContent))],
+		}
+
+		roll := r.Float64()
+		switch {
+		case roll < scheduledShare:
+			spec.Status = models.PostStatusScheduled
+			spec.ScheduledAt = wakingHour(r, now.AddDate(0, 0, 1+r.Intn(scheduledDays)))
+		case roll < scheduledShare+failedShare:
+			spec.Status = models.PostStatusFailed
+			spec.ScheduledAt = wakingHour(r, now.AddDate(0, 0, -1-r.Intn(historyDays)))
+			msg := sampleErrors[r.Intn(len(sampleErrors))]
+			spec.LastError = &msg
+			spec.Retries = scheduler.MaxRetries
+		default:
+			spec.Status = models.PostStatusPublished
+			spec.ScheduledAt = wakingHour(r, now.AddDate(0, 0, -1-r.Intn(historyDays)))
+			// Most posts go out within seconds, a few were late
+			publishedAt := spec.ScheduledAt.Add(time.Duration(1+r.Intn(30)) * time.Second)
+			if r.Float64() < 0.1 {
+				publishedAt = publishedAt.Add(time.Duration(1+r.Intn(10)) * time.Minute)
+			}
+			spec.PublishedAt = &publishedAt
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// wakingHour moves day to a random quarter hour between 08:00 and 20:00 UTC
+func wakingHour(r *rand.Rand, day time.Time) time.Time {
+	y, m, d := day.UTC().Date()
+	return time.Date(y, m, d, 8+r.Intn(12), 15*r.Intn(4), 0, 0, time.UTC)
+}
+
+// Run creates the demo users and their posts in the default tenant, queueing
+// scheduled posts for the worker. Users that already exist are skipped, so
+// running it again only adds what is missing.
+func Run(ctx context.Context, database *db.DB, queue *scheduler.Queue, opts Options) error {
+	if opts.Password == "" {
+		opts.Password = DefaultPassword
+	}
+	passwordHash, err := auth.HashPassword(opts.Password)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+
+	r := rand.New(rand.NewSource(opts.RandSeed))
+	now := time.Now().UTC()
+	for i := 1; i <= opts.Users; i++ {
+		email := fmt.Sprintf("demo%d@example.com", i)
+		existing, err := database.GetUserByEmail(ctx, email)
+		if err != nil {
+			return fmt.Errorf("look up %s: %w", email, err)
+		}
+		if existing != nil {
+			log.Printf("⏭️ Skipping %s: already exists", email)
+			continue
+		}
+
+		user, err := database.CreateUser(ctx, email, passwordHash)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", email, err)
+		}
+		// The first demo user shows off the paid plan
+		if i == 1 {
+			if user, err = database.SetUserPlan(ctx, user.ID, models.PlanPro); err != nil {
+				return fmt.Errorf("set plan of %s: %w", email, err)
+			}
+		}
+
+		specs := Plan(r, now, opts.PostsPerUser)
+		for _, spec := range specs {
+			if err := createPost(ctx, database, queue, user, spec); err != nil {
+				return fmt.Errorf("create post for %s: %w", email, err)
+			}
+		}
+		log.Printf("🌱 Seeded %s with %d posts", email, len(specs))
+	}
+	return nil
+}
+
+// createPost stores one demo post with its outcome, queueing it if it is upcoming
+func createPost(ctx context.Context, database *db.DB, queue *scheduler.Queue, user *models.User, spec PostSpec) error {
+	post, err := database.CreatePost(ctx, db.NewPost{
+		UserID:        user.ID,
+		Content:       spec.Content,
+		Channel:       spec.Channel,
+		ScheduledAt:   spec.ScheduledAt,
+		WorkflowState: models.WorkflowApproved,
+	})
+	if err != nil {
+		return err
+	}
+
+	if spec.Status == models.PostStatusScheduled {
+		return queue.Enqueue(ctx, post.ID, post.ScheduledAt, user.Plan == models.PlanPro)
+	}
+	return database.SetPostOutcome(ctx, post.ID, spec.Status, spec.PublishedAt, spec.LastError, spec.Retries)
+}
@@ -0,0 +1,54 @@
+package seed
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestPlan(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	specs := Plan(rand.New(rand.NewSource(1)), now, 200)
+	if len(specs) != 200 {
+		t.Fatalf("len(Plan) = %d, want 200", len(specs))
+	}
+
+	counts := make(map[models.PostStatus]int)
+	for _, s := range specs {
+		counts[s.Status]++
+		if h := s.ScheduledAt.Hour(); h < 8 || h >= 20 {
+			t.Errorf("post scheduled at %v, outside waking hours", s.ScheduledAt)
+		}
+		switch s.Status {
+		case models.PostStatusScheduled:
+			if !s.ScheduledAt.After(now) {
+				t.Errorf("scheduled post at %v is not in the future", s.ScheduledAt)
+			}
+		case models.PostStatusPublished:
+			if s.PublishedAt == nil || s.PublishedAt.Before(s.ScheduledAt) || !s.PublishedAt.Before(now) {
+				t.Errorf("published post scheduled %v has published_at %v", s.ScheduledAt, s.PublishedAt)
+			}
+		case models.PostStatusFailed:
+			if s.LastError == nil || s.ScheduledAt.After(now) {
+				t.Errorf("failed post at %v has last_error %v", s.ScheduledAt, s.LastError)
+			}
+		default:
+			t.Errorf("unexpected status %q", s.Status)
+		}
+	}
+	for _, status := range []models.PostStatus{models.PostStatusScheduled, models.PostStatusPublished, models.PostStatusFailed} {
+		if counts[status] == 0 {
+			t.Errorf("no %s posts in the plan", status)
+		}
+	}
+
+	// The same seed gives the same plan
+	again := Plan(rand.New(rand.NewSource(1)), now, 200)
+	for i := range specs {
+		if specs[i].Content != again[i].Content || !specs[i].ScheduledAt.Equal(again[i].ScheduledAt) {
+			t.Fatalf("plan differs at post %d with the same seed", i)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+// Header lets API clients select a tenant by slug, overriding the hostname
+const Header = "X-Tenant"
+
+// ErrUnknownTenant is returned when a requested tenant does not exist
+var ErrUnknownTenant = errors.New("unknown tenant")
+
+type contextKey struct{}
+
+// NewContext returns a context scoped to the tenant
+func NewContext(ctx context.Context, t *models.Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the tenant the context is scoped to, or nil for
+// unscoped contexts such as the worker's
+func FromContext(ctx context.Context) *models.Tenant {
+	t, _ := ctx.Value(contextKey{}).(*models.Tenant)
+	return t
+}
+
+// IDFromContext returns the ID of the context's tenant, or the default tenant's
+func IDFromContext(ctx context.Context) uuid.UUID {
+	if t := FromContext(ctx); t != nil {
+		return t.ID
+	}
+	return models.DefaultTenantID
+}
+
+// Loader returns every tenant
+type Loader func(ctx context.Context) ([]*models.Tenant, error)
+
+// Resolver maps request hostnames and tenant slugs to tenants, reloading
+// the tenant list at most once per ttl
+type Resolver struct {
+	load Loader
+	ttl  time.Duration
+
+	mu       sync.Mutex
+	bySlug   map[string]*models.Tenant
+	byHost   map[string]*models.Tenant
+	loadedAt time.Time
+}
+
+// NewResolver creates a new tenant resolver
+func NewResolver(load Loader, ttl time.Duration) *Resolver {
+	return &Resolver{
+		load: load,
+		ttl:  ttl,
+	}
+}
+
+// Resolve returns the tenant selected by slug if given, else the tenant that
+// owns host, else the default tenant
+func (r *Resolver) Resolve(ctx context.Context, slug, host string) (*models.Tenant, error) {
+	bySlug, byHost, err := r.tenants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if slug = strings.ToLower(strings.TrimSpace(slug)); slug != "" {
+		if t, ok := bySlug[slug]; ok {
+			return t, nil
+		}
+		return nil, ErrUnknownTenant
+	}
+	if t, ok := byHost[normalizeHost(host)]; ok {
+		return t, nil
+	}
+	if t, ok := bySlug[models.DefaultTenantSlug]; ok {
+		return t, nil
+	}
+	return &models.Tenant{ID: models.DefaultTenantID, Slug: models.DefaultTenantSlug}, nil
+}
+
+// tenants returns the cached lookup tables, reloading them when stale.
+// Stale tables are kept if a reload fails.
+func (r *Resolver) tenants(ctx context.Context) (map[string]*models.Tenant, map[string]*models.Tenant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.bySlug != nil && time.Since(r.loadedAt) < r.ttl {
+		return r.bySlug, r.byHost, nil
+	}
+
+	tenants, err := r.load(ctx)
+	if err != nil {
+		if r.bySlug != nil {
+			return r.bySlug, r.byHost, nil
+		}
+		return nil, nil, err
+	}
+
+	r.bySlug = make(map[string]*models.Tenant, len(tenants))
+	r.byHost = make(map[string]*models.Tenant)
+	for _, t := range tenants {
+		r.bySlug[strings.ToLower(t.Slug)] = t
+		for _, h := range t.Hostnames {
+			r.byHost[normalizeHost(h)] = t
+		}
+	}
+	r.loadedAt = time.Now()
+	return r.bySlug, r.byHost, nil
+}
+
+// normalizeHost lowercases a host and strips any port and trailing dot
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
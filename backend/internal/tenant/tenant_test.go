@@ -0,0 +1,79 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/scheduler/backend/internal/models"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	acme := &models.Tenant{ID: uuid.New(), Slug: "acme", Hostnames: []string{"social.acme.com"}}
+	def := &models.Tenant{ID: models.DefaultTenantID, Slug: models.DefaultTenantSlug}
+	r := NewResolver(func(context.Context) ([]*models.Tenant, error) {
+		return []*models.Tenant{acme, def}, nil
+	}, time.Minute)
+
+	tests := []struct {
+		name    string
+		slug    string
+		host    string
+		want    *models.Tenant
+		wantErr error
+	}{
+		{"hostname", "", "social.acme.com", acme, nil},
+		{"hostname with port", "", "Social.Acme.com:8080", acme, nil},
+		{"slug header", "ACME", "localhost", acme, nil},
+		{"slug overrides hostname", "default", "social.acme.com", def, nil},
+		{"unknown host falls back to default", "", "example.com", def, nil},
+		{"unknown slug", "globex", "social.acme.com", nil, ErrUnknownTenant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Resolve(context.Background(), tt.slug, tt.host)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Resolve() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolver_KeepsStaleTenantsOnLoadError(t *testing.T) {
+	acme := &models.Tenant{ID: uuid.New(), Slug: "acme"}
+	fail := false
+	r := NewResolver(func(context.Context) ([]*models.Tenant, error) {
+		if fail {
+			return nil, errors.New("database down")
+		}
+		return []*models.Tenant{acme}, nil
+	}, 0)
+
+	if _, err := r.Resolve(context.Background(), "acme", ""); err != nil {
+		t.Fatalf("Initial Resolve() failed: %v", err)
+	}
+
+	fail = true
+	got, err := r.Resolve(context.Background(), "acme", "")
+	if err != nil || got != acme {
+		t.Errorf("Resolve() after load error = %v, %v; want stale tenant", got, err)
+	}
+}
+
+func TestIDFromContext(t *testing.T) {
+	if got := IDFromContext(context.Background()); got != models.DefaultTenantID {
+		t.Errorf("IDFromContext(unscoped) = %v, want default tenant", got)
+	}
+
+	id := uuid.New()
+	ctx := NewContext(context.Background(), &models.Tenant{ID: id})
+	if got := IDFromContext(ctx); got != id {
+		t.Errorf("IDFromContext() = %v, want %v", got, id)
+	}
+}
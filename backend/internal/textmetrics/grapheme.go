@@ -0,0 +1,172 @@
+package textmetrics
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Graphemes counts the user-perceived characters in s: extended grapheme
+// clusters, so an emoji with a skin tone, a flag or a family sequence, and a
+// letter with combining accents, each count once.
+func Graphemes(s string) int {
+	n := 0
+	for s != "" {
+		_, rest := nextCluster(s)
+		s = rest
+		n++
+	}
+	return n
+}
+
+// class is the grapheme break property of a rune, as far as the rules below
+// need it
+type class int
+
+const (
+	classOther class = iota
+	classCR
+	classLF
+	classControl
+	classExtend
+	classZWJ
+	classSpacingMark
+	classRegionalIndicator
+	classPictographic
+	classL   // Hangul leading consonant
+	classV   // Hangul vowel
+	classT   // Hangul trailing consonant
+	classLV  // Hangul syllable without trailing consonant
+	classLVT // Hangul syllable with trailing consonant
+)
+
+func classify(r rune) class {
+	switch {
+	case r == '\r':
+		return classCR
+	case r == '\n':
+		return classLF
+	case r == 0x200D:
+		return classZWJ
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return classRegionalIndicator
+	case isExtend(r):
+		return classExtend
+	case unicode.Is(unicode.Mc, r):
+		return classSpacingMark
+	case r < 0x20 || (r >= 0x7F && r < 0xA0) || r == 0x2028 || r == 0x2029:
+		return classControl
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return classL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return classV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return classT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return classLV
+		}
+		return classLVT
+	case isPictographic(r):
+		return classPictographic
+	}
+	return classOther
+}
+
+// isExtend reports runes that never start a cluster: combining marks,
+// variation selectors, emoji skin tone modifiers and tag characters
+func isExtend(r rune) bool {
+	switch {
+	case r >= 0xFE00 && r <= 0xFE0F, r >= 0xE0100 && r <= 0xE01EF: // Variation selectors
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF: // Skin tone modifiers
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // Tags, as in subdivision flags
+		return true
+	case r == 0x200C: // Zero width non-joiner
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me)
+}
+
+// isPictographic approximates Unicode's Extended_Pictographic property: the
+// runes emoji are built from
+func isPictographic(r rune) bool {
+	switch {
+	case r == 0xA9, r == 0xAE, r == 0x203C, r == 0x2049, r == 0x2122, r == 0x2139,
+		r == 0x3030, r == 0x303D, r == 0x3297, r == 0x3299:
+		return true
+	case r >= 0x2194 && r <= 0x21AA:
+		return true
+	case r >= 0x2300 && r <= 0x23FF, r >= 0x25A0 && r <= 0x27BF, r >= 0x2900 && r <= 0x297F, r >= 0x2B00 && r <= 0x2BFF:
+		return true
+	case r >= 0x1F000 && r <= 0x1FAFF && !(r >= 0x1F1E6 && r <= 0x1F1FF) && !(r >= 0x1F3FB && r <= 0x1F3FF):
+		return true
+	case r >= 0x1FC00 && r <= 0x1FFFD:
+		return true
+	}
+	return false
+}
+
+// nextCluster returns the first grapheme cluster of s and the rest, following
+// the rules of UAX #29 other than GB9b (prepended concatenation marks)
+func nextCluster(s string) (string, string) {
+	r, size := utf8.DecodeRuneInString(s)
+	prev := classify(r)
+	i := size
+
+	// Within the cluster: whether it started an emoji sequence that only
+	// extenders have followed since, and how many regional indicators ran
+	emoji := prev == classPictographic
+	regional := 0
+	if prev == classRegionalIndicator {
+		regional = 1
+	}
+
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		next := classify(r)
+		if breaksBetween(prev, next, emoji, regional) {
+			break
+		}
+
+		switch {
+		case next == classPictographic:
+			emoji = true
+		case next != classExtend && next != classZWJ:
+			emoji = false
+		}
+		if next == classRegionalIndicator {
+			regional++
+		} else {
+			regional = 0
+		}
+
+		prev = next
+		i += size
+	}
+	return s[:i], s[i:]
+}
+
+func breaksBetween(prev, next class, emoji bool, regional int) bool {
+	switch {
+	case prev == classCR && next == classLF: // GB3
+		return false
+	case prev == classCR, prev == classLF, prev == classControl: // GB4
+		return true
+	case next == classCR, next == classLF, next == classControl: // GB5
+		return true
+	case prev == classL && (next == classL || next == classV || next == classLV || next == classLVT): // GB6
+		return false
+	case (prev == classLV || prev == classV) && (next == classV || next == classT): // GB7
+		return false
+	case (prev == classLVT || prev == classT) && next == classT: // GB8
+		return false
+	case next == classExtend, next == classZWJ, next == classSpacingMark: // GB9, GB9a
+		return false
+	case prev == classZWJ && next == classPictographic && emoji: // GB11
+		return false
+	case prev == classRegionalIndicator && next == classRegionalIndicator: // GB12, GB13
+		return regional%2 == 0
+	}
+	return true // GB999
+}
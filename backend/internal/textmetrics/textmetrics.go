@@ -0,0 +1,111 @@
+// Package textmetrics measures post content the way platforms count it
+// against their length limits. Counting bytes or runes overcounts emoji and
+// accented text: a family emoji is seven runes and 25 bytes, but one
+// character to the reader and two to Twitter.
+package textmetrics
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Twitter's length rules, from the v3 configuration of twitter-text. Weights
+// are in hundredths of a character.
+const (
+	// TwitterURLLength is what every link counts as once shortened to t.co
+	TwitterURLLength = 23
+
+	twitterScale         = 100
+	twitterDefaultWeight = 200
+	twitterEmojiWeight   = 200
+)
+
+// twitterLightRanges are the code points that count as one character; all
+// others count as two
+var twitterLightRanges = []struct{ lo, hi rune }{
+	{0x0000, 0x10FF}, // Latin, Greek, Cyrillic, Hebrew, Arabic, Indic scripts...
+	{0x2000, 0x200D}, // Spaces and joiners
+	{0x2010, 0x201F}, // Dashes and quotes
+	{0x2032, 0x2037}, // Primes
+}
+
+// urlPattern finds the links Twitter shortens. Trailing punctuation is
+// trimmed from each match as it is usually part of the sentence.
+var urlPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]+`)
+
+// HasLink reports whether s contains a link
+func HasLink(s string) bool {
+	return urlPattern.MatchString(s)
+}
+
+// FirstLink returns the first link in s without trailing punctuation, or ""
+// if there is none. Links starting www. are returned with https://.
+func FirstLink(s string) string {
+	link := strings.TrimRight(urlPattern.FindString(s), ".,;:!?)]}'")
+	if strings.HasPrefix(strings.ToLower(link), "www.") {
+		link = "https://" + link
+	}
+	return link
+}
+
+// TwitterLength returns the weighted length Twitter checks against its 280
+// character limit: links count as 23, emoji as 2, CJK and other characters
+// outside the light ranges as 2, and everything else as 1
+func TwitterLength(s string) int {
+	weight := 0
+	last := 0
+	for _, loc := range urlPattern.FindAllStringIndex(s, -1) {
+		end := loc[0] + len(strings.TrimRight(s[loc[0]:loc[1]], ".,;:!?)]}'"))
+		weight += twitterTextWeight(s[last:loc[0]])
+		weight += TwitterURLLength * twitterScale
+		last = end
+	}
+	weight += twitterTextWeight(s[last:])
+	return weight / twitterScale
+}
+
+func twitterTextWeight(s string) int {
+	weight := 0
+	for s != "" {
+		cluster, rest := nextCluster(s)
+		s = rest
+		if isEmoji(cluster) {
+			weight += twitterEmojiWeight
+			continue
+		}
+		for _, r := range cluster {
+			weight += runeWeight(r)
+		}
+	}
+	return weight
+}
+
+func runeWeight(r rune) int {
+	for _, rng := range twitterLightRanges {
+		if r >= rng.lo && r <= rng.hi {
+			return twitterScale
+		}
+	}
+	return twitterDefaultWeight
+}
+
+// isEmoji reports whether a grapheme cluster is an emoji: it starts with a
+// pictograph or is a flag, or is a keycap such as 1️⃣
+func isEmoji(cluster string) bool {
+	r, _ := utf8.DecodeRuneInString(cluster)
+	switch classify(r) {
+	case classPictographic:
+		// Text-style symbols such as © only become emoji with U+FE0F
+		return r >= 0x1F000 || strings.ContainsRune(cluster, 0xFE0F) || !isTextDefault(r)
+	case classRegionalIndicator:
+		return true
+	}
+	return strings.ContainsRune(cluster, 0x20E3)
+}
+
+// isTextDefault reports pictographs below the emoji planes that show as plain
+// symbols unless followed by U+FE0F, which twitter-text counts as text
+func isTextDefault(r rune) bool {
+	return r < 0x2300 || r == 0x3030 || r == 0x303D || r == 0x3297 || r == 0x3299
+}
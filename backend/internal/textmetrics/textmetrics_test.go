@@ -0,0 +1,80 @@
+package textmetrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{"empty", "", 0},
+		{"ascii", "hello", 5},
+		{"precomposed accent", "café", 4},
+		{"combining accent", "cafe\u0301", 4},
+		{"crlf", "a\r\nb", 3},
+		{"cjk", "日本語", 3},
+		{"hangul jamo", "\u1100\u1161\u11A8", 1},
+		{"emoji", "👍", 1},
+		{"skin tone", "👍🏽", 1},
+		{"zwj family", "👨‍👩‍👧‍👦", 1},
+		{"flag", "🇬🇧", 1},
+		{"two flags", "🇬🇧🇫🇷", 2},
+		{"odd regional indicators", "🇬🇧🇫", 2},
+		{"subdivision flag", "🏴\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F", 1},
+		{"keycap", "1️⃣", 1},
+		{"zwj without emoji", "a\u200Db", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Graphemes(tt.s); got != tt.want {
+				t.Errorf("Graphemes(%q) = %d, want %d", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTwitterLength(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{"ascii", "hello", 5},
+		{"accents", "café", 4},
+		{"cjk counts double", "日本語", 6},
+		{"emoji counts double", "👍", 2},
+		{"zwj family counts once", "👨‍👩‍👧", 2},
+		{"flag", "🇬🇧", 2},
+		{"copyright is text", "©", 1},
+		{"url", "see https://example.com/a/very/long/path?with=query", 4 + TwitterURLLength},
+		{"url with trailing period", "go to www.example.com.", 6 + TwitterURLLength + 1},
+		{"smart quotes are light", "“hi”", 4},
+		{"limit", strings.Repeat("a", 280), 280},
+		{"cjk limit", strings.Repeat("語", 140), 280},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TwitterLength(tt.s); got != tt.want {
+				t.Errorf("TwitterLength(%q) = %d, want %d", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasLink(t *testing.T) {
+	for s, want := range map[string]bool{
+		"Read more at https://example.com/post": true,
+		"www.example.com":                       true,
+		"No links, just example.com text":       false,
+	} {
+		if got := HasLink(s); got != want {
+			t.Errorf("HasLink(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
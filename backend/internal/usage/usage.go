@@ -0,0 +1,127 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/scheduler/backend/internal/models"
+)
+
+const (
+	keyPrefix = "usage:"
+
+	fieldRequests  = "api_requests"
+	fieldPublishes = "publishes"
+
+	// keyTTL keeps each day's counters long enough to be rolled up
+	keyTTL = 72 * time.Hour
+)
+
+// DayFormat is how usage days are written in keys and responses
+const DayFormat = "2006-01-02"
+
+// Meter counts per-user API requests and publishes in Redis, bucketed by UTC day
+type Meter struct {
+	redis *redis.Client
+}
+
+// NewMeter creates a new usage meter
+func NewMeter(redisClient *redis.Client) *Meter {
+	return &Meter{
+		redis: redisClient,
+	}
+}
+
+// key returns the hash holding a user's counters for the UTC day containing t
+func key(t time.Time, userID uuid.UUID) string {
+	return keyPrefix + t.UTC().Format(DayFormat) + ":" + userID.String()
+}
+
+// RecordRequest counts an API request and returns the user's requests so far today
+func (m *Meter) RecordRequest(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return m.incr(ctx, userID, fieldRequests)
+}
+
+// QueueRecordRequest adds counting an API request to pipe and returns a func
+// that gives the user's requests so far today, once pipe has run
+func (m *Meter) QueueRecordRequest(ctx context.Context, pipe redis.Pipeliner, userID uuid.UUID) func() (int64, error) {
+	k := key(time.Now(), userID)
+	count := pipe.HIncrBy(ctx, k, fieldRequests, 1)
+	pipe.Expire(ctx, k, keyTTL)
+	return count.Result
+}
+
+// ForgetRequest takes back a request counted today that was rejected before
+// it was served
+func (m *Meter) ForgetRequest(ctx context.Context, userID uuid.UUID) error {
+	return m.redis.HIncrBy(ctx, key(time.Now(), userID), fieldRequests, -1).Err()
+}
+
+// RecordPublishes counts published posts, by user, in one round trip
+func (m *Meter) RecordPublishes(ctx context.Context, counts map[uuid.UUID]int) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	pipe := m.redis.Pipeline()
+	for userID, n := range counts {
+		k := key(now, userID)
+		pipe.HIncrBy(ctx, k, fieldPublishes, int64(n))
+		pipe.Expire(ctx, k, keyTTL)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (m *Meter) incr(ctx context.Context, userID uuid.UUID, field string) (int64, error) {
+	k := key(time.Now(), userID)
+	pipe := m.redis.TxPipeline()
+	count := pipe.HIncrBy(ctx, k, field, 1)
+	pipe.Expire(ctx, k, keyTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return count.Val(), nil
+}
+
+// Today returns the user's usage so far today
+func (m *Meter) Today(ctx context.Context, userID uuid.UUID) (models.UsageCounts, error) {
+	fields, err := m.redis.HGetAll(ctx, key(time.Now(), userID)).Result()
+	if err != nil {
+		return models.UsageCounts{}, err
+	}
+	return parseCounts(fields), nil
+}
+
+// Day returns every user's usage on the UTC day containing t
+func (m *Meter) Day(ctx context.Context, t time.Time) (map[uuid.UUID]models.UsageCounts, error) {
+	prefix := keyPrefix + t.UTC().Format(DayFormat) + ":"
+	counts := make(map[uuid.UUID]models.UsageCounts)
+
+	iter := m.redis.Scan(ctx, 0, prefix+"*", 500).Iterator()
+	for iter.Next(ctx) {
+		userID, err := uuid.Parse(strings.TrimPrefix(iter.Val(), prefix))
+		if err != nil {
+			continue
+		}
+		fields, err := m.redis.HGetAll(ctx, iter.Val()).Result()
+		if err != nil {
+			return nil, fmt.Errorf("read usage for %s: %w", userID, err)
+		}
+		counts[userID] = parseCounts(fields)
+	}
+	return counts, iter.Err()
+}
+
+// parseCounts reads counters from a usage hash, treating missing fields as zero
+func parseCounts(fields map[string]string) models.UsageCounts {
+	requests, _ := strconv.ParseInt(fields[fieldRequests], 10, 64)
+	publishes, _ := strconv.ParseInt(fields[fieldPublishes], 10, 64)
+	return models.UsageCounts{APIRequests: requests, Publishes: publishes}
+}
@@ -16,6 +16,8 @@ export default function DashboardPage() {
   // Use SSE for real-time updates
   const { 
     upcoming: upcomingPosts, 
+    hasMoreUpcoming,
+    loadMoreUpcoming,
     history: publishedPosts, 
     isConnected,
     error: sseError,
@@ -85,12 +87,22 @@ export default function DashboardPage() {
           <Tabs tabs={tabs} activeTab={activeTab} onTabChange={setActiveTab} />
 
           {activeTab === 'upcoming' ? (
-            <PostList
-              posts={upcomingPosts}
-              onUpdate={refresh}
-              showActions={true}
-              emptyMessage="No upcoming posts. Schedule one above!"
-            />
+            <>
+              <PostList
+                posts={upcomingPosts}
+                onUpdate={refresh}
+                showActions={true}
+                emptyMessage="No upcoming posts. Schedule one above!"
+              />
+              {hasMoreUpcoming && (
+                <button
+                  onClick={loadMoreUpcoming}
+                  className="mt-4 w-full text-sm text-blue-600 hover:text-blue-800 dark:text-blue-400 dark:hover:text-blue-300"
+                >
+                  Load later posts
+                </button>
+              )}
+            </>
           ) : (
             <PostList
               posts={publishedPosts}
@@ -14,6 +14,13 @@ const channelEmoji: Record<string, string> = {
   twitter: '🐦',
   linkedin: '💼',
   facebook: '📘',
+  reddit: '👽',
+  telegram: '✈️',
+  discord: '🎮',
+  webhook: '🪝',
+  google_business: '📍',
+  youtube: '▶️',
+  tiktok: '🎵',
 };
 
 const statusColors: Record<string, string> = {
@@ -70,6 +77,11 @@ export function PostCard({ post, onUpdate, showActions = true }: PostCardProps)
           <span className={`px-2 py-1 rounded-full text-xs font-medium ${statusColors[post.status]}`}>
             {post.status}
           </span>
+          {!!post.recycle_count && (
+            <span className="px-2 py-1 rounded-full text-xs font-medium bg-purple-100 text-purple-800 dark:bg-purple-900/30 dark:text-purple-400">
+              ♻️ recycled ×{post.recycle_count}
+            </span>
+          )}
         </div>
         
         {showActions && post.status === 'scheduled' && (
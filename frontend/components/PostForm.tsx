@@ -3,6 +3,7 @@
 import { useState } from 'react';
 import { CreatePostRequest } from '@/lib/types';
 import { postsApi, ApiError } from '@/lib/api';
+import { graphemeLength } from '@/lib/textLength';
 
 interface PostFormProps {
   onSuccess?: () => void;
@@ -12,6 +13,9 @@ export function PostForm({ onSuccess }: PostFormProps) {
   const [title, setTitle] = useState('');
   const [content, setContent] = useState('');
   const [channel, setChannel] = useState('twitter');
+  const [subreddit, setSubreddit] = useState('');
+  const [flairId, setFlairId] = useState('');
+  const [location, setLocation] = useState('');
   const [scheduledAt, setScheduledAt] = useState('');
   const [loading, setLoading] = useState(false);
   const [error, setError] = useState('');
@@ -24,19 +28,29 @@ export function PostForm({ onSuccess }: PostFormProps) {
     try {
       // Frontend validation
       const trimmedContent = content.trim();
-      if (trimmedContent.length < 3) {
+      if (graphemeLength(trimmedContent) < 3) {
         setError('Content must be at least 3 characters');
         setLoading(false);
         return;
       }
-      if (trimmedContent.length > 5000) {
+      if (graphemeLength(trimmedContent) > 5000) {
         setError('Content must not exceed 5000 characters');
         setLoading(false);
         return;
       }
 
       const trimmedTitle = title.trim();
-      if (trimmedTitle.length > 200) {
+      if (channel === 'reddit' && (!trimmedTitle || !subreddit.trim())) {
+        setError('Reddit posts need a title and a subreddit');
+        setLoading(false);
+        return;
+      }
+      if (channel === 'google_business' && !location.trim()) {
+        setError('Google Business Profile posts need a location');
+        setLoading(false);
+        return;
+      }
+      if (graphemeLength(trimmedTitle) > 200) {
         setError('Title must not exceed 200 characters');
         setLoading(false);
         return;
@@ -68,6 +82,15 @@ export function PostForm({ onSuccess }: PostFormProps) {
       if (trimmedTitle) {
         data.title = trimmedTitle;
       }
+      if (channel === 'reddit') {
+        data.targeting = { subreddit: subreddit.trim() };
+        if (flairId.trim()) {
+          data.targeting.flair_id = flairId.trim();
+        }
+      }
+      if (channel === 'google_business') {
+        data.targeting = { location: location.trim() };
+      }
 
       await postsApi.create(data);
       
@@ -75,6 +98,9 @@ export function PostForm({ onSuccess }: PostFormProps) {
       setTitle('');
       setContent('');
       setChannel('twitter');
+      setSubreddit('');
+      setFlairId('');
+      setLocation('');
       setScheduledAt('');
       
       onSuccess?.();
@@ -111,7 +137,7 @@ export function PostForm({ onSuccess }: PostFormProps) {
       <div className="space-y-4">
         <div>
           <label htmlFor="title" className="block text-sm font-medium text-gray-700 dark:text-gray-300 mb-1">
-            Title (optional) <span className="text-xs text-gray-500">({title.length}/200)</span>
+            Title {channel === 'reddit' ? '*' : '(optional)'} <span className="text-xs text-gray-500">({title.length}/200)</span>
           </label>
           <input
             type="text"
@@ -126,7 +152,7 @@ export function PostForm({ onSuccess }: PostFormProps) {
 
         <div>
           <label htmlFor="content" className="block text-sm font-medium text-gray-700 dark:text-gray-300 mb-1">
-            Content * <span className="text-xs text-gray-500">({content.length}/5000)</span>
+            Content * <span className="text-xs text-gray-500">({graphemeLength(content)}/5000)</span>
           </label>
           <textarea
             id="content"
@@ -155,9 +181,67 @@ export function PostForm({ onSuccess }: PostFormProps) {
               <option value="twitter">🐦 Twitter</option>
               <option value="linkedin">💼 LinkedIn</option>
               <option value="facebook">📘 Facebook</option>
+              <option value="reddit">👽 Reddit</option>
+              <option value="telegram">✈️ Telegram</option>
+              <option value="discord">🎮 Discord</option>
+              <option value="webhook">🪝 Webhook</option>
+              <option value="google_business">📍 Google Business Profile</option>
+              <option value="youtube">▶️ YouTube</option>
+              <option value="tiktok">🎵 TikTok</option>
             </select>
           </div>
 
+          {channel === 'reddit' && (
+            <>
+              <div>
+                <label htmlFor="subreddit" className="block text-sm font-medium text-gray-700 dark:text-gray-300 mb-1">
+                  Subreddit *
+                </label>
+                <input
+                  type="text"
+                  id="subreddit"
+                  value={subreddit}
+                  onChange={(e) => setSubreddit(e.target.value)}
+                  required
+                  maxLength={23}
+                  className="w-full px-4 py-2 border border-gray-300 dark:border-gray-600 rounded-lg focus:ring-2 focus:ring-blue-500 focus:border-transparent dark:bg-gray-700 dark:text-white"
+                  placeholder="r/community"
+                />
+              </div>
+
+              <div>
+                <label htmlFor="flairId" className="block text-sm font-medium text-gray-700 dark:text-gray-300 mb-1">
+                  Flair ID (optional)
+                </label>
+                <input
+                  type="text"
+                  id="flairId"
+                  value={flairId}
+                  onChange={(e) => setFlairId(e.target.value)}
+                  className="w-full px-4 py-2 border border-gray-300 dark:border-gray-600 rounded-lg focus:ring-2 focus:ring-blue-500 focus:border-transparent dark:bg-gray-700 dark:text-white"
+                  placeholder="Flair template ID"
+                />
+              </div>
+            </>
+          )}
+
+          {channel === 'google_business' && (
+            <div>
+              <label htmlFor="location" className="block text-sm font-medium text-gray-700 dark:text-gray-300 mb-1">
+                Location *
+              </label>
+              <input
+                type="text"
+                id="location"
+                value={location}
+                onChange={(e) => setLocation(e.target.value)}
+                required
+                className="w-full px-4 py-2 border border-gray-300 dark:border-gray-600 rounded-lg focus:ring-2 focus:ring-blue-500 focus:border-transparent dark:bg-gray-700 dark:text-white"
+                placeholder="accounts/123/locations/456"
+              />
+            </div>
+          )}
+
           <div>
             <label htmlFor="scheduledAt" className="block text-sm font-medium text-gray-700 dark:text-gray-300 mb-1">
               Schedule For *
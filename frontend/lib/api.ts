@@ -1,4 +1,4 @@
-import { AuthResponse, Post, CreatePostRequest, UpdatePostRequest, ErrorResponse } from './types';
+import { AuthResponse, Post, PostSummary, CreatePostRequest, UpdatePostRequest, ErrorResponse, ListResponse } from './types';
 
 // Use NEXT_PUBLIC_API_URL for browser (client-side) requests
 // Use API_URL for server-side (SSR) requests
@@ -51,16 +51,16 @@ async function fetchApi<T>(
 
 // Auth API
 export const authApi = {
-    register: (email: string, password: string) =>
+    register: (email: string, password: string, inviteCode?: string) =>
         fetchApi<AuthResponse>('/api/auth/register', {
             method: 'POST',
-            body: JSON.stringify({ email, password }),
+            body: JSON.stringify({ email, password, invite_code: inviteCode }),
         }),
 
-    login: (email: string, password: string) =>
+    login: (email: string, password: string, rememberMe = false) =>
         fetchApi<AuthResponse>('/api/auth/login', {
             method: 'POST',
-            body: JSON.stringify({ email, password }),
+            body: JSON.stringify({ email, password, remember_me: rememberMe }),
         }),
 
     logout: () =>
@@ -84,9 +84,25 @@ export const postsApi = {
             body: JSON.stringify(data),
         }),
 
-    getUpcoming: () => fetchApi<Post[]>('/api/posts/upcoming'),
+    getUpcoming: () =>
+        fetchApi<ListResponse<Post>>('/api/posts/upcoming').then((res) => res.data),
 
-    getHistory: () => fetchApi<Post[]>('/api/posts/history'),
+    // One page of upcoming posts; pass the previous page's next_cursor for the next one
+    getUpcomingPage: (cursor?: string | null, limit = 100) => {
+        const params = new URLSearchParams({ limit: String(limit) });
+        if (cursor) params.set('cursor', cursor);
+        return fetchApi<ListResponse<Post>>(`/api/posts/upcoming?${params}`);
+    },
+
+    getHistory: () =>
+        fetchApi<ListResponse<Post>>('/api/posts/history').then((res) => res.data),
+
+    // Summaries (no content) for views such as a calendar
+    getUpcomingSummaries: () =>
+        fetchApi<ListResponse<PostSummary>>('/api/posts/upcoming?fields=summary').then((res) => res.data),
+
+    getHistorySummaries: () =>
+        fetchApi<ListResponse<PostSummary>>('/api/posts/history?fields=summary').then((res) => res.data),
 
     getById: (id: string) => fetchApi<Post>(`/api/posts/${id}`),
 
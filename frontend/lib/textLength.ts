@@ -0,0 +1,14 @@
+// Counts user-perceived characters (grapheme clusters), matching the server's
+// length checks: an emoji with a skin tone or a flag counts once. Twitter's
+// weighted length is reported by /api/posts/validate.
+const segmenter =
+    typeof Intl !== 'undefined' && 'Segmenter' in Intl
+        ? new Intl.Segmenter(undefined, { granularity: 'grapheme' })
+        : null;
+
+export function graphemeLength(text: string): number {
+    if (!segmenter) {
+        return Array.from(text).length;
+    }
+    return Array.from(segmenter.segment(text)).length;
+}
@@ -1,7 +1,13 @@
 export interface User {
     id: string;
     email: string;
+    avatar_url?: string;
+    plan: 'free' | 'pro';
     created_at: string;
+    suspension?: {
+        suspended_at: string;
+        reason: string;
+    };
 }
 
 export interface AuthResponse {
@@ -13,19 +19,70 @@ export interface Post {
     user_id: string;
     title?: string;
     content: string;
-    channel: 'twitter' | 'linkedin' | 'facebook';
-    status: 'scheduled' | 'published' | 'failed';
+    channel: 'twitter' | 'linkedin' | 'facebook' | 'reddit' | 'telegram' | 'discord' | 'webhook' | 'google_business' | 'youtube' | 'tiktok';
+    status: 'scheduled' | 'published' | 'failed' | 'pending_approval' | 'rejected' | 'canceled';
     scheduled_at: string;
     published_at?: string;
+    recycle_count?: number;
+    recycled_from_id?: string;
+    org_id?: string;
+    window_override?: boolean;
+    workflow_state: 'idea' | 'drafting' | 'review' | 'approved';
+    assignee_id?: string;
+    ab_test?: ABTest;
+    ab_variant?: 'a' | 'b';
+    ab_parent_id?: string;
+    engagement?: Engagement;
+    remind_before_minutes?: number;
+    undo_until?: string;
+    canceled_at?: string;
+    no_signature?: boolean;
     created_at: string;
     updated_at: string;
 }
 
+export interface ABTest {
+    variant_b: string;
+    window_hours: number;
+    auto_repost: boolean;
+    winner?: 'a' | 'b' | 'tie' | 'inconclusive';
+    decided_at?: string;
+}
+
+export interface Engagement {
+    impressions: number;
+    likes: number;
+    comments: number;
+    shares: number;
+    clicks: number;
+}
+
+export interface Comment {
+    id: string;
+    post_id: string;
+    user_id: string;
+    author_email: string;
+    parent_id?: string;
+    body: string;
+    created_at: string;
+    replies: Comment[];
+}
+
 export interface CreatePostRequest {
     title?: string;
     content: string;
     channel: string;
     scheduled_at: string;
+    targeting?: PostTargeting;
+}
+
+// PostTargeting is where a post goes on its channel; only Reddit's and Google
+// Business Profile's fields are used here
+export interface PostTargeting {
+    subreddit?: string;
+    flair_id?: string;
+    flair_text?: string;
+    location?: string;
 }
 
 export interface UpdatePostRequest {
@@ -35,7 +92,30 @@ export interface UpdatePostRequest {
     scheduled_at?: string;
 }
 
+// PostSummary is a post without its content, from list endpoints with ?fields=summary
+export interface PostSummary {
+    id: string;
+    title?: string;
+    channel: Post['channel'];
+    status: Post['status'];
+    scheduled_at: string;
+}
+
+export interface ListResponse<T> {
+    data: T[];
+    next_cursor: string | null;
+    total?: number;
+}
+
 export interface ErrorResponse {
     error: string;
     message: string;
 }
+
+export interface Announcement {
+    id: string;
+    message: string;
+    level: 'info' | 'warning' | 'critical';
+    created_at: string;
+    expires_at?: string;
+}
@@ -6,6 +6,7 @@ import { postsApi } from './api';
 
 interface SSEData {
     upcoming: Post[];
+    upcoming_next_cursor: string | null; // Set when more upcoming posts follow the window
     history: Post[];
 }
 
@@ -16,6 +17,7 @@ interface UsePostStreamOptions {
 export function usePostStream(options: UsePostStreamOptions = {}) {
     const { enabled = true } = options;
     const [upcoming, setUpcoming] = useState<Post[]>([]);
+    const [upcomingCursor, setUpcomingCursor] = useState<string | null>(null);
     const [history, setHistory] = useState<Post[]>([]);
     const [isConnected, setIsConnected] = useState(false);
     const [error, setError] = useState<string | null>(null);
@@ -23,11 +25,12 @@ export function usePostStream(options: UsePostStreamOptions = {}) {
     // Fallback: fetch via REST API
     const fetchViaREST = useCallback(async () => {
         try {
-            const [upcomingData, historyData] = await Promise.all([
-                postsApi.getUpcoming(),
+            const [upcomingPage, historyData] = await Promise.all([
+                postsApi.getUpcomingPage(),
                 postsApi.getHistory(),
             ]);
-            setUpcoming(upcomingData);
+            setUpcoming(upcomingPage.data);
+            setUpcomingCursor(upcomingPage.next_cursor);
             setHistory(historyData);
         } catch (err) {
             console.error('REST: Failed to fetch posts', err);
@@ -68,6 +71,7 @@ export function usePostStream(options: UsePostStreamOptions = {}) {
                 try {
                     const data: SSEData = JSON.parse(event.data);
                     setUpcoming(data.upcoming || []);
+                    setUpcomingCursor(data.upcoming_next_cursor || null);
                     setHistory(data.history || []);
                     consecutiveErrors = 0; // Reset on successful update
                 } catch (err) {
@@ -125,8 +129,23 @@ export function usePostStream(options: UsePostStreamOptions = {}) {
         fetchViaREST();
     }, [fetchViaREST]);
 
+    // Appends the next page of upcoming posts after the live window. The next
+    // update replaces the list with the window again.
+    const loadMoreUpcoming = useCallback(async () => {
+        if (!upcomingCursor) return;
+        try {
+            const page = await postsApi.getUpcomingPage(upcomingCursor);
+            setUpcoming((posts) => [...posts, ...page.data]);
+            setUpcomingCursor(page.next_cursor);
+        } catch (err) {
+            console.error('REST: Failed to fetch more upcoming posts', err);
+        }
+    }, [upcomingCursor]);
+
     return {
         upcoming,
+        hasMoreUpcoming: upcomingCursor !== null,
+        loadMoreUpcoming,
         history,
         isConnected,
         error,